@@ -0,0 +1,142 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/utsabbera/task-master/core/task"
+	"github.com/utsabbera/task-master/pkg/middleware"
+	"github.com/utsabbera/task-master/pkg/util"
+)
+
+// AuthConfig holds the credentials accepted by the API.
+// The requests are not authenticated when neither API keys nor JWT are configured.
+type AuthConfig struct {
+	// APIKeys maps the API keys accepted in the X-API-Key header to the caller they authenticate.
+	APIKeys map[string]middleware.Principal
+	// JWT configures the bearer tokens accepted in the Authorization header, they are rejected when it is nil.
+	JWT *middleware.JWTConfig
+	// PublicDocs serves the Swagger UI without authentication.
+	PublicDocs bool
+	// DefaultRole is the role of the callers without any known role, defaults to member.
+	DefaultRole task.Role
+	// FeedSecret signs the tokens of the calendar feed URLs, it is required when the requests are authenticated
+	// so that the URLs keep working after a restart and across the instances of the API.
+	FeedSecret []byte
+	// FeedTokenTTL is how long the calendar feed URLs keep working after they are given out, defaults to 90 days.
+	FeedTokenTTL time.Duration
+}
+
+const (
+	feedTokenScope      = "calendar-feed"
+	defaultFeedTokenTTL = 90 * 24 * time.Hour
+)
+
+// Enabled reports whether the requests are authenticated.
+func (c AuthConfig) Enabled() bool {
+	return len(c.APIKeys) > 0 || c.JWT != nil
+}
+
+// middleware returns the middleware rejecting the requests which are not authenticated with the configured credentials.
+func (c AuthConfig) middleware() middleware.Middleware {
+	return middleware.Auth(handleError, c.authenticators()...)
+}
+
+// feedMiddleware returns the middleware authenticating the requests of the calendar feed with the token of their URL,
+// or else with the configured credentials.
+func (c AuthConfig) feedMiddleware(tokens *middleware.URLTokens) middleware.Middleware {
+	feed := feedAuthenticator{tokens: tokens, cfg: c}
+	return middleware.Auth(handleError, append([]middleware.Authenticator{feed}, c.authenticators()...)...)
+}
+
+// feedTokens returns the tokens of the calendar feed URLs.
+func (c AuthConfig) feedTokens(clock util.Clock) (*middleware.URLTokens, error) {
+	if len(c.FeedSecret) == 0 {
+		return nil, errors.New("feed secret is required when authentication is enabled")
+	}
+
+	ttl := c.FeedTokenTTL
+	if ttl <= 0 {
+		ttl = defaultFeedTokenTTL
+	}
+
+	return middleware.NewURLTokens(c.FeedSecret, feedTokenScope, ttl, clock), nil
+}
+
+// feedAuthenticator authenticates the caller of a calendar feed with the token of its URL, granting them the roles
+// they currently have rather than those they had when the URL was given out: the roles of their API keys,
+// none when they are authenticated with JWT. Callers who no longer have an API key are rejected unless JWT are accepted.
+type feedAuthenticator struct {
+	tokens *middleware.URLTokens
+	cfg    AuthConfig
+}
+
+func (a feedAuthenticator) Authenticate(r *http.Request) (middleware.Principal, error) {
+	principal, err := a.tokens.Authenticate(r)
+	if err != nil {
+		return principal, err
+	}
+
+	known := false
+	for _, key := range a.cfg.APIKeys {
+		if key.Subject == principal.Subject {
+			known = true
+			principal.Roles = append(principal.Roles, key.Roles...)
+		}
+	}
+	if !known && a.cfg.JWT == nil {
+		return middleware.Principal{}, middleware.ErrInvalidCredentials
+	}
+
+	return principal, nil
+}
+
+func (a feedAuthenticator) Challenge() string {
+	return a.tokens.Challenge()
+}
+
+func (c AuthConfig) authenticators() []middleware.Authenticator {
+	var authenticators []middleware.Authenticator
+	if len(c.APIKeys) > 0 {
+		authenticators = append(authenticators, middleware.APIKeys(c.APIKeys))
+	}
+	if c.JWT != nil {
+		authenticators = append(authenticators, middleware.JWT(*c.JWT))
+	}
+
+	return authenticators
+}
+
+// withUser performs the task operations of the authenticated requests on behalf of their caller,
+// with the most privileged of their roles, and records the task changes as made by them.
+func (c AuthConfig) withUser() middleware.Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if principal, ok := middleware.PrincipalFromContext(r.Context()); ok {
+				ctx := task.WithActor(r.Context(), principal.Subject)
+				ctx = task.WithUser(ctx, task.User{ID: principal.Subject, Role: c.role(principal)})
+				r = r.WithContext(ctx)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// role returns the most privileged known role of the caller, the default role if they have none.
+func (c AuthConfig) role(principal middleware.Principal) task.Role {
+	role := c.DefaultRole
+	if role == "" {
+		role = task.RoleMember
+	}
+
+	for _, known := range slices.Backward(task.Roles()) {
+		if slices.Contains(principal.Roles, string(known)) {
+			return known
+		}
+	}
+
+	return role
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	taskcore "github.com/utsabbera/task-master/core/task"
+	"github.com/utsabbera/task-master/pkg/middleware"
+)
+
+//go:generate mockgen -destination=calendar_handler_mock.go -package=api . CalendarHandler
+
+const calendarFeedPath = "/tasks.ics"
+
+// CalendarHandler defines the interface for handling HTTP requests of the calendar feed of the tasks.
+type CalendarHandler interface {
+	// Feed writes the tasks having a due date as an iCalendar file.
+	Feed(w http.ResponseWriter, r *http.Request)
+
+	// Subscription returns the URL of the calendar feed of the caller.
+	Subscription(w http.ResponseWriter, r *http.Request)
+}
+
+type calendarHandler struct {
+	transfer  taskcore.TransferService
+	tokens    *middleware.URLTokens
+	publicURL *url.URL
+}
+
+// NewCalendarHandler returns a new instance of CalendarHandler.
+// The subscription URLs carry a token of the caller issued by tokens, none when tokens is nil.
+// They start with publicURL, or with the scheme and host of the request when publicURL is nil.
+func NewCalendarHandler(transferService taskcore.TransferService, tokens *middleware.URLTokens, publicURL *url.URL) CalendarHandler {
+	return &calendarHandler{transfer: transferService, tokens: tokens, publicURL: publicURL}
+}
+
+// Feed godoc
+// @Summary Calendar Feed
+// @Description Export the tasks having a due date and matching the filters as the VTODO components of an iCalendar file,
+// @Description for the calendar clients subscribing to the URL returned by the calendar subscription endpoint.
+// @Description Its token parameter authenticates the caller instead of the credentials headers
+// @Tags calendar
+// @Produce text/calendar
+// @Param token query string false "Token of the subscription URL"
+// @Param status query []string false "Only tasks with any of these statuses" collectionFormat(csv)
+// @Param tag query []string false "Only tasks having all of these tags, or none of the tags prefixed with !" collectionFormat(multi)
+// @Param filter query string false "Only tasks matching this expression"
+// @Param q query string false "Only tasks whose title or description contains this text"
+// @Success 200 {string} string "iCalendar file of the tasks"
+// @Failure 400 {object} Problem "Invalid query parameters"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /tasks.ics [get]
+func (h *calendarHandler) Feed(w http.ResponseWriter, r *http.Request) {
+	opts, err := parseExportOptions(r.Context(), r.URL.Query())
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	opts.Filter.HasDueDate = true
+
+	var buf bytes.Buffer
+	if err := h.transfer.Export(r.Context(), &buf, taskcore.FormatICalendar, opts); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", formatMediaTypes[taskcore.FormatICalendar]+"; charset=utf-8")
+	_, _ = w.Write(buf.Bytes())
+}
+
+// Subscription godoc
+// @Summary Calendar Subscription
+// @Description Get the URL of the calendar feed of the caller, authenticating them with its token until it expires when the API
+// @Description requires credentials. The filters are kept in the URL, so that the feed only has the tasks matching them
+// @Tags calendar
+// @Produce json
+// @Param status query []string false "Only tasks with any of these statuses" collectionFormat(csv)
+// @Param tag query []string false "Only tasks having all of these tags, or none of the tags prefixed with !" collectionFormat(multi)
+// @Param filter query string false "Only tasks matching this expression"
+// @Param q query string false "Only tasks whose title or description contains this text"
+// @Success 200 {object} CalendarSubscription "URL of the calendar feed"
+// @Failure 400 {object} Problem "Invalid query parameters"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /calendar/subscription [get]
+func (h *calendarHandler) Subscription(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if _, err := parseExportOptions(r.Context(), query); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	var subscription CalendarSubscription
+	query.Del(middleware.URLTokenParam)
+	if principal, ok := middleware.PrincipalFromContext(r.Context()); ok && h.tokens != nil {
+		token, expiresAt := h.tokens.Issue(principal)
+		query.Set(middleware.URLTokenParam, token)
+		subscription.ExpiresAt = &expiresAt
+	}
+
+	feed := &url.URL{Scheme: requestScheme(r), Host: r.Host, Path: calendarFeedPath}
+	if h.publicURL != nil {
+		feed = h.publicURL.JoinPath(calendarFeedPath)
+	}
+	feed.RawQuery = query.Encode()
+	subscription.URL = feed.String()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+
+	if err := json.NewEncoder(w).Encode(subscription); err != nil {
+		handleError(w, r, fmt.Errorf("error encoding response: %w", err))
+		return
+	}
+}
+
+// requestScheme returns the scheme of the URL requested by the client, as forwarded by a proxy.
+func requestScheme(r *http.Request) string {
+	switch proto := r.Header.Get("X-Forwarded-Proto"); {
+	case proto == "http" || proto == "https":
+		return proto
+	case r.TLS != nil:
+		return "https"
+	default:
+		return "http"
+	}
+}
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/core/task"
+	"github.com/utsabbera/task-master/pkg/middleware"
+	"github.com/utsabbera/task-master/pkg/util"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCalendarHandler_Feed(t *testing.T) {
+	t.Run("should export the tasks having a due date as iCalendar", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTransferService := task.NewMockTransferService(ctrl)
+		handler := NewCalendarHandler(mockTransferService, nil, nil)
+
+		mockTransferService.EXPECT().Export(gomock.Any(), gomock.Any(), task.FormatICalendar, task.ListOptions{
+			Filter: task.Filter{Statuses: []task.Status{task.StatusNotStarted}, HasDueDate: true},
+		}).DoAndReturn(func(_ any, w io.Writer, _ task.Format, _ task.ListOptions) error {
+			_, err := io.WriteString(w, "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n")
+			return err
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/tasks.ics?status=NOT_STARTED&token=abc", nil)
+		res := httptest.NewRecorder()
+		handler.Feed(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "text/calendar; charset=utf-8", res.Header().Get("Content-Type"))
+		assert.Equal(t, "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n", res.Body.String())
+	})
+
+	t.Run("should return bad request when query parameters are invalid", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		handler := NewCalendarHandler(task.NewMockTransferService(ctrl), nil, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/tasks.ics?status=DONE", nil)
+		res := httptest.NewRecorder()
+		handler.Feed(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, CodeValidationFailed, decodeProblem(t, res).Code)
+	})
+}
+
+func TestCalendarHandler_Subscription(t *testing.T) {
+	now := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+	clock := util.NewMockClock(gomock.NewController(t))
+	clock.EXPECT().Now().Return(now).AnyTimes()
+	tokens := middleware.NewURLTokens([]byte("secret"), "calendar-feed", time.Hour, clock)
+
+	subscribe := func(t *testing.T, handler CalendarHandler, req *http.Request) (*url.URL, *time.Time) {
+		t.Helper()
+
+		res := httptest.NewRecorder()
+		handler.Subscription(res, req)
+
+		require.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "no-store", res.Header().Get("Cache-Control"))
+		var subscription CalendarSubscription
+		require.NoError(t, json.Unmarshal(res.Body.Bytes(), &subscription))
+		feed, err := url.Parse(subscription.URL)
+		require.NoError(t, err)
+
+		return feed, subscription.ExpiresAt
+	}
+
+	t.Run("should return the feed URL with a token of the caller and the filters", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		handler := NewCalendarHandler(task.NewMockTransferService(ctrl), tokens, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "http://tasks.example.com/calendar/subscription?tag=work&token=forged", nil)
+		req = req.WithContext(middleware.WithPrincipal(req.Context(), middleware.Principal{Subject: "alice", Method: "api_key"}))
+		feed, expiresAt := subscribe(t, handler, req)
+
+		assert.Equal(t, "http://tasks.example.com/tasks.ics", feed.Scheme+"://"+feed.Host+feed.Path)
+		assert.Equal(t, "work", feed.Query().Get("tag"))
+		principal, err := tokens.Authenticate(httptest.NewRequest(http.MethodGet, feed.String(), nil))
+		require.NoError(t, err)
+		assert.Equal(t, "alice", principal.Subject)
+		require.NotNil(t, expiresAt)
+		assert.True(t, now.Add(time.Hour).Equal(*expiresAt))
+	})
+
+	t.Run("should return the feed URL without token when requests are not authenticated", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		handler := NewCalendarHandler(task.NewMockTransferService(ctrl), nil, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/calendar/subscription", nil)
+		req.Host = "tasks.example.com"
+		req.TLS = &tls.ConnectionState{}
+		feed, expiresAt := subscribe(t, handler, req)
+
+		assert.Equal(t, "https://tasks.example.com/tasks.ics", feed.String())
+		assert.Nil(t, expiresAt)
+	})
+
+	t.Run("should use the scheme forwarded by a proxy", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		handler := NewCalendarHandler(task.NewMockTransferService(ctrl), nil, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "http://tasks.example.com/calendar/subscription", nil)
+		req.Header.Set("X-Forwarded-Proto", "https")
+		feed, _ := subscribe(t, handler, req)
+
+		assert.Equal(t, "https", feed.Scheme)
+	})
+
+	t.Run("should start the feed URL with the public URL instead of the requested host", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		publicURL, err := url.Parse("https://tasks.example.com/api")
+		require.NoError(t, err)
+		handler := NewCalendarHandler(task.NewMockTransferService(ctrl), nil, publicURL)
+
+		req := httptest.NewRequest(http.MethodGet, "http://attacker.example.com/calendar/subscription?tag=work", nil)
+		req.Header.Set("X-Forwarded-Proto", "http")
+		feed, _ := subscribe(t, handler, req)
+
+		assert.Equal(t, "https://tasks.example.com/api/tasks.ics?tag=work", feed.String())
+	})
+
+	t.Run("should return bad request when query parameters are invalid", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		handler := NewCalendarHandler(task.NewMockTransferService(ctrl), tokens, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/calendar/subscription?filter=priority%20%3D", nil)
+		res := httptest.NewRecorder()
+		handler.Subscription(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+	})
+}
@@ -1,15 +1,27 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/utsabbera/task-master/core/assistant"
+	pkgassistant "github.com/utsabbera/task-master/pkg/assistant"
+	"golang.org/x/net/websocket"
 
 	taskcore "github.com/utsabbera/task-master/core/task"
 )
 
+// heartbeatInterval is the idle duration after which the change feed sends a keep-alive comment.
+const heartbeatInterval = 15 * time.Second
+
 //go:generate mockgen -destination=handler_mock.go -package=api . Handler
 
 // Handler defines the interface for handling HTTP requests related to tasks.
@@ -23,26 +35,83 @@ type Handler interface {
 	// List lists all tasks.
 	List(w http.ResponseWriter, r *http.Request)
 
+	// Search searches the tasks by the words of their title and description.
+	Search(w http.ResponseWriter, r *http.Request)
+
 	// Update updates an existing task by its ID.
 	Update(w http.ResponseWriter, r *http.Request)
 
 	// Delete deletes a task by its ID.
 	Delete(w http.ResponseWriter, r *http.Request)
 
+	// Batch applies a list of create, update and delete operations all together.
+	Batch(w http.ResponseWriter, r *http.Request)
+
+	// Move moves a task to another project.
+	Move(w http.ResponseWriter, r *http.Request)
+
+	// ListSubtasks lists the subtasks of a task.
+	ListSubtasks(w http.ResponseWriter, r *http.Request)
+
+	// History lists the changes of a task.
+	History(w http.ResponseWriter, r *http.Request)
+
+	// Order plans the execution order of the open tasks.
+	Order(w http.ResponseWriter, r *http.Request)
+
+	// Events streams the changes of the tasks as Server-Sent Events.
+	Events(w http.ResponseWriter, r *http.Request)
+
+	// EventsWebSocket streams the changes of the tasks over a WebSocket.
+	EventsWebSocket(w http.ResponseWriter, r *http.Request)
+
+	// ListTags lists the tags of the tasks with their usage counts.
+	ListTags(w http.ResponseWriter, r *http.Request)
+
+	// RenameTag renames a tag on every task having it.
+	RenameTag(w http.ResponseWriter, r *http.Request)
+
+	// MergeTags merges tags into another tag on every task having them.
+	MergeTags(w http.ResponseWriter, r *http.Request)
+
 	// Chat handles natural language messages for task management.
 	Chat(w http.ResponseWriter, r *http.Request)
+
+	// GetChatSession retrieves a chat session with its conversation history.
+	GetChatSession(w http.ResponseWriter, r *http.Request)
+
+	// DeleteChatSession resets a chat session.
+	DeleteChatSession(w http.ResponseWriter, r *http.Request)
+
+	// GetChatChanges lists the task operations staged in a chat session.
+	GetChatChanges(w http.ResponseWriter, r *http.Request)
+
+	// CommitChatChanges applies the task operations staged in a chat session.
+	CommitChatChanges(w http.ResponseWriter, r *http.Request)
+
+	// DiscardChatChanges drops the task operations staged in a chat session.
+	DiscardChatChanges(w http.ResponseWriter, r *http.Request)
 }
 
 type handler struct {
 	task      taskcore.Service
 	assistant assistant.Service
+	origins   []string
 }
 
 // NewHandler returns a new instance of Handler for task operations.
+// The WebSocket change feed only accepts pages served from the host of the API.
 func NewHandler(taskService taskcore.Service, assistantService assistant.Service) Handler {
+	return NewHandlerWithOrigins(taskService, assistantService, nil)
+}
+
+// NewHandlerWithOrigins returns a new instance of Handler for task operations whose WebSocket change feed
+// also accepts the pages served from the given origins, e.g. https://app.example.com.
+func NewHandlerWithOrigins(taskService taskcore.Service, assistantService assistant.Service, origins []string) Handler {
 	return &handler{
 		task:      taskService,
 		assistant: assistantService,
+		origins:   origins,
 	}
 }
 
@@ -54,46 +123,51 @@ func NewHandler(taskService taskcore.Service, assistantService assistant.Service
 // @Produce json
 // @Param task body TaskInput true "Task input"
 // @Success 201 {object} Task
+// @Failure 400 {object} Problem "Invalid request body or fields"
+// @Failure 413 {object} Problem "Request body too large"
+// @Failure 409 {object} Problem "Completed task is blocked"
+// @Failure 422 {object} Problem "Parent or blocking task not found, or cyclic dependency"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /tasks [post]
 func (h *handler) Create(w http.ResponseWriter, r *http.Request) {
+	h.create(w, r, "")
+}
+
+// create creates the task of the request body in the given project.
+func (h *handler) create(w http.ResponseWriter, r *http.Request, project string) {
 	var input TaskInput
-	decoder := json.NewDecoder(r.Body)
-	if err := decoder.Decode(&input); err != nil {
-		http.Error(w, "Invalid request payload", http.StatusBadRequest)
-		return
-	}
-	err := r.Body.Close()
-	if err != nil {
-		http.Error(w, "Failed to close request body", http.StatusInternalServerError)
+	if err := decodeJSON(w, r, &input); err != nil {
+		handleError(w, r, err)
 		return
 	}
 
-	if input.Title == "" {
-		http.Error(w, "Title cannot be empty", http.StatusBadRequest)
+	if err := input.validate(opCreate); err != nil {
+		handleError(w, r, err)
 		return
 	}
 
-	task := &taskcore.Task{
-		Title:       input.Title,
-		Description: input.Description,
-		Status:      input.Status,
-		Priority:    input.Priority,
-		DueDate:     input.DueDate,
+	task, err := mapTaskInputToRequest(input)
+	if err != nil {
+		handleError(w, r, err)
+		return
 	}
+	task.Project = project
 
-	err = h.task.Create(task)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if err := h.task.Create(r.Context(), task); err != nil {
+		handleError(w, r, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag(task.Version))
 	w.WriteHeader(http.StatusCreated)
 
 	response := mapTaskToResponse(task)
 
 	if err := json.NewEncoder(w).Encode(response); err != nil {
-		http.Error(w, "Error encoding response", http.StatusInternalServerError)
+		handleError(w, r, fmt.Errorf("error encoding response: %w", err))
 		return
 	}
 }
@@ -104,180 +178,1072 @@ func (h *handler) Create(w http.ResponseWriter, r *http.Request) {
 // @Tags tasks
 // @Produce json
 // @Param id path string true "Task ID"
+// @Param If-None-Match header string false "ETags of the task, the task is not returned if it still has one of them"
 // @Success 200 {object} Task
-// @Failure 404 {string} string "Task not found"
+// @Header 200 {string} ETag "Version of the task"
+// @Success 304 "Task not modified"
+// @Failure 404 {object} Problem "Task not found"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /tasks/{id} [get]
 func (h *handler) Get(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 	if id == "" {
-		http.Error(w, "Missing task ID", http.StatusBadRequest)
+		handleError(w, r, newValidationError("id", violationRequired, "task ID is required"))
 		return
 	}
 
-	task, err := h.task.Get(id)
+	task, err := h.task.Get(r.Context(), id)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag(task.Version))
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" && matchETag(ifNoneMatch, task.Version, true) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
 
-	response := mapTaskToResponse(task)
+	response, err := h.mapTask(r.Context(), task)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
 
 	if err := json.NewEncoder(w).Encode(response); err != nil {
-		http.Error(w, "Error encoding response", http.StatusInternalServerError)
+		handleError(w, r, fmt.Errorf("error encoding response: %w", err))
 		return
 	}
 }
 
 // List godoc
 // @Summary List Tasks
-// @Description List all tasks
+// @Description List tasks matching the filters, sorted and paginated
 // @Tags tasks
 // @Produce json
+// @Param status query []string false "Only tasks with any of these statuses" collectionFormat(csv)
+// @Param priority query []string false "Only tasks with any of these priorities" collectionFormat(csv)
+// @Param dueBefore query string false "Only tasks due before this time (RFC 3339)"
+// @Param dueAfter query string false "Only tasks due after this time (RFC 3339)"
+// @Param tag query []string false "Only tasks having all of these tags, or none of the tags prefixed with !" collectionFormat(multi)
+// @Param q query string false "Only tasks whose title or description contains this text"
+// @Param owner query []string false "Only tasks owned by any of these users, me for the caller" collectionFormat(csv)
+// @Param assignee query []string false "Only tasks assigned to any of these users, me for the caller" collectionFormat(csv)
+// @Param filter query string false "Only tasks matching this expression of fields (id, title, description, status, priority, due, created, updated, tag, assignee, owner, project, parent), operators (=, !=, <, <=, >, >=, : and ~), and, or, not and parentheses, e.g. status != COMPLETED and (priority = HIGH or due < now+3d) and tag:backend"
+// @Param project query []string false "Only tasks of any of these projects" collectionFormat(csv)
+// @Param sort query string false "Comma separated sort fields (createdAt, updatedAt, dueDate, priority, title), prefixed with - for descending order"
+// @Param limit query int false "Maximum number of tasks to return" default(100) minimum(1) maximum(1000)
+// @Param cursor query string false "Cursor of the page to return, taken from X-Next-Cursor"
 // @Success 200 {array} Task
+// @Header 200 {integer} X-Total-Count "Number of tasks matching the filters"
+// @Header 200 {string} X-Next-Cursor "Cursor of the next page, absent on the last page"
+// @Failure 400 {object} Problem "Invalid query parameters"
+// @Failure 422 {object} Problem "Filter expression using me without credentials"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /tasks [get]
 func (h *handler) List(w http.ResponseWriter, r *http.Request) {
-	tasks, err := h.task.List()
+	opts, err := parseListOptions(r.Context(), r.URL.Query())
 	if err != nil {
-		http.Error(w, "Failed to retrieve tasks", http.StatusInternalServerError)
+		handleError(w, r, err)
+		return
+	}
+
+	h.listTasks(w, r, opts)
+}
+
+func (h *handler) listTasks(w http.ResponseWriter, r *http.Request, opts taskcore.ListOptions) {
+	page, err := h.task.List(r.Context(), opts)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	h.writePage(w, r, page)
+}
+
+// writePage writes the tasks of a page with the total count and the cursor of the next page in the headers.
+func (h *handler) writePage(w http.ResponseWriter, r *http.Request, page *taskcore.Page) {
+	response, err := h.mapTasks(r.Context(), page.Tasks)
+	if err != nil {
+		handleError(w, r, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Total-Count", strconv.Itoa(page.Total))
+	if page.NextCursor != "" {
+		w.Header().Set("X-Next-Cursor", page.NextCursor)
+	}
 
-	response := mapTasksToResponse(tasks)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		handleError(w, r, fmt.Errorf("error encoding response: %w", err))
+		return
+	}
+}
+
+// Search godoc
+// @Summary Search Tasks
+// @Description Search the tasks whose title or description contain the words of the query, the most relevant first.
+// @Description Words are matched regardless of case and inflection, e.g. report matches reports and reporting,
+// @Description and a word ending with * matches every word starting with it. Common words such as the are ignored.
+// @Description The tasks are ranked with BM25, the words of the title weigh more than the words of the description.
+// @Description The highlights of a hit wrap its matching words in <mark> and </mark> and escape the rest of their HTML.
+// @Tags tasks
+// @Produce json
+// @Param q query string true "Words to search for" maxlength(500)
+// @Param status query []string false "Only tasks with any of these statuses" collectionFormat(csv)
+// @Param priority query []string false "Only tasks with any of these priorities" collectionFormat(csv)
+// @Param tag query []string false "Only tasks having all of these tags, or none of the tags prefixed with !" collectionFormat(multi)
+// @Param project query []string false "Only tasks of any of these projects" collectionFormat(csv)
+// @Param owner query []string false "Only tasks owned by any of these users, me for the caller" collectionFormat(csv)
+// @Param assignee query []string false "Only tasks assigned to any of these users, me for the caller" collectionFormat(csv)
+// @Param filter query string false "Only tasks matching this expression of fields (id, title, description, status, priority, due, created, updated, tag, assignee, owner, project, parent), operators (=, !=, <, <=, >, >=, : and ~), and, or, not and parentheses, e.g. status != COMPLETED and (priority = HIGH or due < now+3d) and tag:backend"
+// @Param limit query int false "Maximum number of hits to return" default(20) minimum(1) maximum(100)
+// @Success 200 {array} TaskSearchHit
+// @Header 200 {integer} X-Total-Count "Number of tasks matching the query and the filters"
+// @Failure 400 {object} Problem "Invalid query parameters"
+// @Failure 422 {object} Problem "Filter expression using me without credentials"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Failure 501 {object} Problem "Search unavailable"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /tasks/search [get]
+func (h *handler) Search(w http.ResponseWriter, r *http.Request) {
+	query, opts, err := parseSearchOptions(r.Context(), r.URL.Query())
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	result, err := h.task.Search(r.Context(), query, opts)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Total-Count", strconv.Itoa(result.Total))
+
+	response := mapSearchHitsToResponse(result.Hits)
 
 	if err := json.NewEncoder(w).Encode(response); err != nil {
-		http.Error(w, "Error encoding response", http.StatusInternalServerError)
+		handleError(w, r, fmt.Errorf("error encoding response: %w", err))
 		return
 	}
 }
 
 // Update godoc
 // @Summary Update Task
-// @Description Partially update a task by ID
+// @Description Partially update a task by ID.
+// @Description A task with unfinished blockers becomes BLOCKED and resumes when they are completed, cancelled or deleted.
+// @Description Completing a recurring task creates the task of its next occurrence, due one period later.
+// @Description Status changes follow the task lifecycle: NOT_STARTED -> IN_PROGRESS, BLOCKED or CANCELLED;
+// @Description IN_PROGRESS -> NOT_STARTED, BLOCKED, COMPLETED or CANCELLED; BLOCKED -> NOT_STARTED, IN_PROGRESS or CANCELLED;
+// @Description COMPLETED -> IN_PROGRESS (reopen); CANCELLED -> NOT_STARTED (reopen).
 // @Tags tasks
 // @Accept json
 // @Produce json
 // @Param id path string true "Task ID"
 // @Param task body TaskInput true "Task fields to update"
+// @Param If-Match header string false "ETags of the task, the task is only updated if it still has one of them"
+// @Param If-None-Match header string false "ETags of the task, the task is only updated if it has none of them"
 // @Success 200 {object} Task
-// @Failure 404 {string} string "Task not found"
+// @Header 200 {string} ETag "Version of the updated task"
+// @Failure 400 {object} Problem "Invalid request body or fields"
+// @Failure 404 {object} Problem "Task not found"
+// @Failure 409 {object} Problem "Status transition not allowed, completed task is blocked or task modified concurrently"
+// @Failure 412 {object} Problem "Precondition failed"
+// @Failure 413 {object} Problem "Request body too large"
+// @Failure 422 {object} Problem "Parent or blocking task not found, or cyclic parent or dependency"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /tasks/{id} [patch]
 func (h *handler) Update(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 	if id == "" {
-		http.Error(w, "Missing task ID", http.StatusBadRequest)
+		handleError(w, r, newValidationError("id", violationRequired, "task ID is required"))
 		return
 	}
 
 	var input TaskInput
-	decoder := json.NewDecoder(r.Body)
-	if err := decoder.Decode(&input); err != nil {
-		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+	if err := decodeJSON(w, r, &input); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	if err := input.validate(opUpdate); err != nil {
+		handleError(w, r, err)
 		return
 	}
-	err := r.Body.Close()
+
+	patch, err := mapTaskInputToRequest(input)
 	if err != nil {
-		http.Error(w, "Failed to close request body", http.StatusInternalServerError)
+		handleError(w, r, err)
 		return
 	}
 
-	patch := &taskcore.Task{
-		Title:       input.Title,
-		Description: input.Description,
-		Status:      input.Status,
-		Priority:    input.Priority,
-		DueDate:     input.DueDate,
+	version, err := checkPreconditions(r, h.taskVersion(id))
+	if err != nil {
+		handleError(w, r, err)
+		return
 	}
+	patch.Version = version
 
-	task, err := h.task.Update(id, patch)
+	task, err := h.task.Update(r.Context(), id, patch)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, preconditionError(r, err))
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
+	response, err := h.mapTask(r.Context(), task)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
 
-	response := mapTaskToResponse(task)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag(task.Version))
 
 	if err := json.NewEncoder(w).Encode(response); err != nil {
-		http.Error(w, "Error encoding response", http.StatusInternalServerError)
+		handleError(w, r, fmt.Errorf("error encoding response: %w", err))
 		return
 	}
 }
 
 // Delete godoc
 // @Summary Delete Task
-// @Description Delete a task by ID
+// @Description Delete a task by ID.
+// @Description The mode defines what happens to the subtasks of the task: reject refuses to delete a task having subtasks,
+// @Description orphan moves them to the top level and cascade deletes them recursively.
 // @Tags tasks
 // @Param id path string true "Task ID"
+// @Param mode query string false "What happens to the subtasks of the task" Enums(reject, orphan, cascade) default(reject)
+// @Param If-Match header string false "ETags of the task, the task is only deleted if it still has one of them"
+// @Param If-None-Match header string false "ETags of the task, the task is only deleted if it has none of them"
 // @Success 204 {string} string "Task deleted"
-// @Failure 404 {string} string "Task not found"
+// @Failure 400 {object} Problem "Invalid mode"
+// @Failure 404 {object} Problem "Task not found"
+// @Failure 409 {object} Problem "Task modified concurrently or has subtasks"
+// @Failure 412 {object} Problem "Precondition failed"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /tasks/{id} [delete]
 func (h *handler) Delete(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 	if id == "" {
-		http.Error(w, "Missing task ID", http.StatusBadRequest)
+		handleError(w, r, newValidationError("id", violationRequired, "task ID is required"))
+		return
+	}
+
+	mode := taskcore.DeleteMode(r.URL.Query().Get("mode"))
+	if err := validate(field("mode", oneOf(mode, taskcore.DeleteModes()))); err != nil {
+		handleError(w, r, err)
 		return
 	}
 
-	if err := h.task.Delete(id); err != nil {
-		handleError(w, err)
+	version, err := checkPreconditions(r, h.taskVersion(id))
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	if err := h.task.Delete(r.Context(), id, taskcore.DeleteOptions{Version: version, Mode: mode}); err != nil {
+		handleError(w, r, preconditionError(r, err))
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// Batch godoc
+// @Summary Batch Tasks
+// @Description Apply a list of at most 100 create, update and delete operations in order and all together.
+// @Description Either every operation is applied or, when one of them fails, none is and the problem reports
+// @Description the index of the failed operation. The ref of a create operation can be used by later operations
+// @Description in place of the ID of the created task, as their id, parentId or blockedBy.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param batch body BatchInput true "Operations to apply"
+// @Success 200 {array} BatchResult
+// @Failure 400 {object} Problem "Invalid request body or fields"
+// @Failure 403 {object} Problem "Operation not allowed to the caller"
+// @Failure 404 {object} Problem "Task not found"
+// @Failure 409 {object} Problem "Status transition not allowed, task has subtasks or task modified concurrently"
+// @Failure 413 {object} Problem "Request body too large"
+// @Failure 422 {object} Problem "Parent or blocking task not found, or cyclic parent or dependency"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /tasks:batch [post]
+func (h *handler) Batch(w http.ResponseWriter, r *http.Request) {
+	var input BatchInput
+	if err := decodeJSON(w, r, &input); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	if err := input.validate(); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	ops, err := mapBatchInputToRequest(input)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	changes, err := h.task.Batch(r.Context(), ops)
+	if err != nil {
+		handleError(w, r, unmoved(err))
+		return
+	}
+
+	tasks := make([]*taskcore.Task, 0, len(changes))
+	for _, change := range changes {
+		if change.After != nil {
+			tasks = append(tasks, change.After)
+		}
+	}
+
+	mapped, err := h.mapTasks(r.Context(), tasks)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	response := make([]BatchResult, 0, len(changes))
+	for _, change := range changes {
+		result := BatchResult{Op: change.Type, ID: change.TaskID}
+		if change.After != nil {
+			result.Task = &mapped[0]
+			mapped = mapped[1:]
+		}
+		response = append(response, result)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		handleError(w, r, fmt.Errorf("error encoding response: %w", err))
+		return
+	}
+}
+
+// unmoved reports a task moved to another project as not found, for requests which cannot be redirected to its new ID.
+func unmoved(err error) error {
+	var movedErr *taskcore.MovedError
+	if !errors.As(err, &movedErr) {
+		return err
+	}
+
+	notFound := fmt.Errorf("%w: %s is now %s", taskcore.ErrTaskNotFound, movedErr.ID, movedErr.MovedTo)
+
+	var batchErr *taskcore.BatchError
+	if errors.As(err, &batchErr) {
+		return &taskcore.BatchError{Index: batchErr.Index, Err: notFound}
+	}
+
+	return notFound
+}
+
+// Move godoc
+// @Summary Move Task
+// @Description Move a task to another project, or to the default project when project is empty. The task gets the next ID of the project
+// @Description and keeps its other fields, its subtasks and the tasks it blocks refer to its new ID.
+// @Description Requests to its former ID are redirected to its new ID with a 308 status and a Location header.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID"
+// @Param move body MoveInput true "Project to move the task to"
+// @Success 200 {object} Task
+// @Header 200 {string} Location "URL of the moved task"
+// @Header 200 {string} ETag "Version of the moved task"
+// @Failure 400 {object} Problem "Invalid request body or fields"
+// @Failure 404 {object} Problem "Task or project not found"
+// @Failure 409 {object} Problem "Task modified concurrently"
+// @Failure 413 {object} Problem "Request body too large"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /tasks/{id}/move [post]
+func (h *handler) Move(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		handleError(w, r, newValidationError("id", violationRequired, "task ID is required"))
+		return
+	}
+
+	var input MoveInput
+	if err := decodeJSON(w, r, &input); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	if err := input.validate(); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	task, err := h.task.Move(r.Context(), id, input.Project)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	response, err := h.mapTask(r.Context(), task)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", "/tasks/"+task.ID)
+	w.Header().Set("ETag", etag(task.Version))
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		handleError(w, r, fmt.Errorf("error encoding response: %w", err))
+		return
+	}
+}
+
+// ListSubtasks godoc
+// @Summary List Subtasks
+// @Description List the direct subtasks of a task, filtered, sorted and paginated like the task list
+// @Tags tasks
+// @Produce json
+// @Param id path string true "Task ID"
+// @Param status query []string false "Only subtasks with any of these statuses" collectionFormat(csv)
+// @Param priority query []string false "Only subtasks with any of these priorities" collectionFormat(csv)
+// @Param tag query []string false "Only subtasks having all of these tags, or none of the tags prefixed with !" collectionFormat(multi)
+// @Param q query string false "Only subtasks whose title or description contains this text"
+// @Param sort query string false "Comma separated sort fields (createdAt, updatedAt, dueDate, priority, title), prefixed with - for descending order"
+// @Param limit query int false "Maximum number of subtasks to return" default(100) minimum(1) maximum(1000)
+// @Param cursor query string false "Cursor of the page to return, taken from X-Next-Cursor"
+// @Success 200 {array} Task
+// @Header 200 {integer} X-Total-Count "Number of subtasks matching the filters"
+// @Header 200 {string} X-Next-Cursor "Cursor of the next page, absent on the last page"
+// @Failure 400 {object} Problem "Invalid query parameters"
+// @Failure 404 {object} Problem "Task not found"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /tasks/{id}/subtasks [get]
+func (h *handler) ListSubtasks(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		handleError(w, r, newValidationError("id", violationRequired, "task ID is required"))
+		return
+	}
+
+	opts, err := parseListOptions(r.Context(), r.URL.Query())
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	if _, err := h.task.Get(r.Context(), id); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	opts.Filter.Parents = []string{id}
+	h.listTasks(w, r, opts)
+}
+
+// History godoc
+// @Summary Task History
+// @Description List the changes of a task from its creation, including its deletion, with who made them and when
+// @Tags tasks
+// @Produce json
+// @Param id path string true "Task ID"
+// @Success 200 {array} TaskEvent
+// @Failure 404 {object} Problem "Task not found"
+// @Failure 501 {object} Problem "History not recorded by the storage"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /tasks/{id}/history [get]
+func (h *handler) History(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		handleError(w, r, newValidationError("id", violationRequired, "task ID is required"))
+		return
+	}
+
+	events, err := h.task.History(r.Context(), id)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	response := mapTaskEventsToResponse(events)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		handleError(w, r, fmt.Errorf("error encoding response: %w", err))
+		return
+	}
+}
+
+// Order godoc
+// @Summary Execution Order
+// @Description Plan the open tasks so that every task comes after the tasks blocking it,
+// @Description tasks which can be worked on at the same time are sorted by due date, then from high to low priority.
+// @Description The critical path is the longest chain of blocking tasks ending with a task having a due date.
+// @Tags tasks
+// @Produce json
+// @Success 200 {object} ExecutionPlan
+// @Failure 422 {object} Problem "Cyclic dependency"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /tasks/order [get]
+func (h *handler) Order(w http.ResponseWriter, r *http.Request) {
+	plan, err := h.task.Plan(r.Context())
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	order, err := h.mapTasks(r.Context(), plan.Order)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	criticalPath, err := h.mapTasks(r.Context(), plan.CriticalPath)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	response := ExecutionPlan{Order: order, CriticalPath: criticalPath}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		handleError(w, r, fmt.Errorf("error encoding response: %w", err))
+		return
+	}
+}
+
+// Events godoc
+// @Summary Task Change Feed
+// @Description Stream the changes of the tasks as Server-Sent Events, each event has the ID of the change, its type as name and a TaskChangeEvent as data.
+// @Description A client reconnecting with the Last-Event-ID header, or the lastEventId parameter, first receives the changes it missed
+// @Description as long as they are still buffered, otherwise the feed fails with 410 and the client has to reload the tasks.
+// @Description A client falling behind receives an "error" event carrying a Problem and is disconnected, it can then resume from its last event.
+// @Tags events
+// @Produce text/event-stream
+// @Param taskId query []string false "Only changes of any of these tasks" collectionFormat(csv)
+// @Param type query []string false "Only changes of any of these types" collectionFormat(csv) Enums(TASK_CREATED, TASK_UPDATED, STATUS_CHANGED, TASK_DELETED)
+// @Param tag query []string false "Only changes of tasks having any of these tags" collectionFormat(csv)
+// @Param Last-Event-ID header int false "ID of the last change received, to resume the feed after it"
+// @Param lastEventId query int false "ID of the last change received, for clients which cannot set the Last-Event-ID header"
+// @Success 200 {object} TaskChangeEvent
+// @Failure 400 {object} Problem "Invalid filters or event ID"
+// @Failure 410 {object} Problem "Changes after the event ID are no longer available"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /events [get]
+func (h *handler) Events(w http.ResponseWriter, r *http.Request) {
+	filter, lastID, err := parseSubscription(r)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	sub, err := h.task.Subscribe(r.Context(), filter, lastID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	defer sub.Close()
+
+	stream := newSSEWriter(w)
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case n, ok := <-sub.Notifications():
+			if !ok {
+				if err := sub.Err(); err != nil {
+					_ = stream.write("error", newProblem(r, err))
+				}
+				return
+			}
+
+			if err := stream.writeWithID(strconv.FormatInt(n.ID, 10), string(n.Type), mapNotificationToResponse(n)); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if err := stream.comment("keep-alive"); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// EventsWebSocket godoc
+// @Summary Task Change Feed over WebSocket
+// @Description Stream the changes of the tasks over a WebSocket, each text message is a JSON encoded TaskChangeEvent.
+// @Description The filters and the resumption after lastEventId are the same as for the Server-Sent Events feed,
+// @Description a client falling behind is disconnected and can then resume from its last event.
+// @Tags events
+// @Param taskId query []string false "Only changes of any of these tasks" collectionFormat(csv)
+// @Param type query []string false "Only changes of any of these types" collectionFormat(csv) Enums(TASK_CREATED, TASK_UPDATED, STATUS_CHANGED, TASK_DELETED)
+// @Param tag query []string false "Only changes of tasks having any of these tags" collectionFormat(csv)
+// @Param lastEventId query int false "ID of the last change received, to resume the feed after it"
+// @Success 101 {object} TaskChangeEvent
+// @Failure 400 {object} Problem "Invalid filters or event ID"
+// @Failure 410 {object} Problem "Changes after the event ID are no longer available"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /events/ws [get]
+func (h *handler) EventsWebSocket(w http.ResponseWriter, r *http.Request) {
+	filter, lastID, err := parseSubscription(r)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	sub, err := h.task.Subscribe(ctx, filter, lastID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	defer sub.Close()
+
+	server := websocket.Server{
+		Handshake: h.checkOrigin,
+		Handler: func(conn *websocket.Conn) {
+			go func() {
+				_, _ = io.Copy(io.Discard, conn)
+				cancel()
+			}()
+
+			for n := range sub.Notifications() {
+				if err := websocket.JSON.Send(conn, mapNotificationToResponse(n)); err != nil {
+					return
+				}
+			}
+		},
+	}
+	server.ServeHTTP(w, r)
+}
+
+// checkOrigin accepts the WebSocket handshakes of the pages served from the host of the API or from the allowed origins,
+// so that a page of another site can't stream the task changes with the credentials of the browser
+func (h *handler) checkOrigin(config *websocket.Config, r *http.Request) error {
+	origin, err := websocket.Origin(config, r)
+	if err != nil {
+		return err
+	}
+	if origin == nil {
+		return errors.New("missing origin")
+	}
+
+	if origin.Host == r.Host || slices.Contains(h.origins, origin.Scheme+"://"+origin.Host) {
+		return nil
+	}
+
+	return fmt.Errorf("origin %s not allowed", origin)
+}
+
+// ListTags godoc
+// @Summary List Tags
+// @Description List the tags of the tasks with the number of tasks having each tag, sorted by name
+// @Tags tags
+// @Produce json
+// @Success 200 {array} Tag
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /tags [get]
+func (h *handler) ListTags(w http.ResponseWriter, r *http.Request) {
+	tags, err := h.task.Tags(r.Context())
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	response := mapTagsToResponse(tags)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		handleError(w, r, fmt.Errorf("error encoding response: %w", err))
+		return
+	}
+}
+
+// RenameTag godoc
+// @Summary Rename Tag
+// @Description Rename a tag on every task having it, use merge to rename it to a tag which is already used
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param tag path string true "Tag"
+// @Param input body TagRenameInput true "New name of the tag"
+// @Success 200 {object} Tag
+// @Failure 400 {object} Problem "Invalid request body or fields"
+// @Failure 404 {object} Problem "Tag not found"
+// @Failure 409 {object} Problem "Tag already exists"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /tags/{tag} [patch]
+func (h *handler) RenameTag(w http.ResponseWriter, r *http.Request) {
+	var input TagRenameInput
+	if err := decodeJSON(w, r, &input); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	if err := input.validate(); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	tag, err := h.task.RenameTag(r.Context(), r.PathValue("tag"), input.Name)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	response := mapTagToResponse(tag)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		handleError(w, r, fmt.Errorf("error encoding response: %w", err))
+		return
+	}
+}
+
+// MergeTags godoc
+// @Summary Merge Tags
+// @Description Replace the source tags by the target tag on every task having any of them
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param tag path string true "Target tag"
+// @Param input body TagMergeInput true "Tags to merge into the target tag"
+// @Success 200 {object} Tag
+// @Failure 400 {object} Problem "Invalid request body or fields"
+// @Failure 404 {object} Problem "Tag not found"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /tags/{tag}/merge [post]
+func (h *handler) MergeTags(w http.ResponseWriter, r *http.Request) {
+	var input TagMergeInput
+	if err := decodeJSON(w, r, &input); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	if err := input.validate(); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	tag, err := h.task.MergeTags(r.Context(), input.Sources, r.PathValue("tag"))
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	response := mapTagToResponse(tag)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		handleError(w, r, fmt.Errorf("error encoding response: %w", err))
+		return
+	}
+}
+
+func (h *handler) mapTask(ctx context.Context, task *taskcore.Task) (Task, error) {
+	response, err := h.mapTasks(ctx, []*taskcore.Task{task})
+	if err != nil {
+		return Task{}, err
+	}
+
+	return response[0], nil
+}
+
+func (h *handler) mapTasks(ctx context.Context, tasks []*taskcore.Task) ([]Task, error) {
+	ids := make([]string, 0, len(tasks))
+	for _, t := range tasks {
+		ids = append(ids, t.ID)
+	}
+
+	progress, err := h.task.Progress(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapTasksToResponse(tasks, progress), nil
+}
+
+func (h *handler) taskVersion(id string) func(ctx context.Context) (int, error) {
+	return func(ctx context.Context) (int, error) {
+		task, err := h.task.Get(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+
+		return task.Version, nil
+	}
+}
+
 // Chat godoc
 // @Summary Chat
-// @Description Chat in natural language for task management
+// @Description Chat in natural language for task management.
+// @Description The response is streamed as Server-Sent Events when stream is true or the request accepts text/event-stream:
+// @Description "delta" events carry text chunks, "tool_call_start" and "tool_call_finish" events carry the task operations,
+// @Description a final "message" event carries the ChatResponse and an "error" event carries a Problem if the chat fails.
+// @Description When preview is true the task operations are staged instead of applied and returned as changes,
+// @Description to be committed with POST /chat/{sessionId}/commit or discarded with DELETE /chat/{sessionId}/changes.
 // @Tags chat
 // @Accept json
-// @Produce json
+// @Produce json,text/event-stream
 // @Param chat body ChatInput true "Chat input"
+// @Param stream query bool false "Stream the response as Server-Sent Events"
 // @Success 200 {object} ChatResponse
+// @Failure 400 {object} Problem "Invalid request body or fields"
+// @Failure 413 {object} Problem "Request body too large"
+// @Failure 502 {object} Problem "Assistant failed"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /chat [post]
 func (h *handler) Chat(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var input ChatInput
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(w, r, &input); err != nil {
+		handleError(w, r, err)
 		return
 	}
 
-	if input.Text == "" {
-		http.Error(w, "Chat text cannot be empty", http.StatusBadRequest)
+	if err := input.validate(); err != nil {
+		handleError(w, r, err)
 		return
 	}
 
-	response, err := h.assistant.Chat(ctx, input.Text)
+	if wantsStream(r) {
+		h.chatStream(w, r, input)
+		return
+	}
+
+	reply, err := h.assistant.Chat(ctx, mapChatInputToRequest(input))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		handleError(w, r, fmt.Errorf("%w: %w", errAssistantFailed, err))
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 
-	resp := ChatResponse{
-		Response: response,
+	if err := json.NewEncoder(w).Encode(mapReplyToResponse(reply)); err != nil {
+		handleError(w, r, fmt.Errorf("error encoding response: %w", err))
+		return
+	}
+}
+
+func (h *handler) chatStream(w http.ResponseWriter, r *http.Request, input ChatInput) {
+	stream := newSSEWriter(w)
+
+	reply, err := h.assistant.ChatStream(r.Context(), mapChatInputToRequest(input), func(event pkgassistant.Event) error {
+		return stream.write(string(event.Type), mapEventToResponse(event))
+	})
+	if err != nil {
+		_ = stream.write("error", newProblem(r, fmt.Errorf("%w: %w", errAssistantFailed, err)))
+		return
+	}
+
+	_ = stream.write("message", mapReplyToResponse(reply))
+}
+
+func wantsStream(r *http.Request) bool {
+	if stream, err := strconv.ParseBool(r.URL.Query().Get("stream")); err == nil {
+		return stream
+	}
+
+	return strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+}
+
+// GetChatSession godoc
+// @Summary Get Chat Session
+// @Description Get a chat session with its conversation history
+// @Tags chat
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Success 200 {object} ChatSession
+// @Failure 404 {object} Problem "Session not found"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /chat/{sessionId} [get]
+func (h *handler) GetChatSession(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.PathValue("sessionId")
+	if sessionID == "" {
+		handleError(w, r, newValidationError("sessionId", violationRequired, "session ID is required"))
+		return
+	}
+
+	session, err := h.assistant.GetSession(r.Context(), sessionID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	response := mapSessionToResponse(session)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		handleError(w, r, fmt.Errorf("error encoding response: %w", err))
+		return
+	}
+}
+
+// DeleteChatSession godoc
+// @Summary Delete Chat Session
+// @Description Reset a chat session by deleting its conversation history
+// @Tags chat
+// @Param sessionId path string true "Session ID"
+// @Success 204 {string} string "Session deleted"
+// @Failure 404 {object} Problem "Session not found"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /chat/{sessionId} [delete]
+func (h *handler) DeleteChatSession(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.PathValue("sessionId")
+	if sessionID == "" {
+		handleError(w, r, newValidationError("sessionId", violationRequired, "session ID is required"))
+		return
 	}
 
-	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		http.Error(w, "Error encoding response", http.StatusInternalServerError)
+	if err := h.assistant.DeleteSession(r.Context(), sessionID); err != nil {
+		handleError(w, r, err)
 		return
 	}
+
+	w.WriteHeader(http.StatusNoContent)
 }
 
-func handleError(w http.ResponseWriter, err error) {
-	if errors.Is(err, taskcore.ErrTaskNotFound) {
-		http.Error(w, "Task not found", http.StatusNotFound)
+// GetChatChanges godoc
+// @Summary Get Chat Changes
+// @Description List the task operations staged in a chat session by preview messages
+// @Tags chat
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Success 200 {array} ChatChange
+// @Failure 404 {object} Problem "Session not found"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /chat/{sessionId}/changes [get]
+func (h *handler) GetChatChanges(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.PathValue("sessionId")
+	if sessionID == "" {
+		handleError(w, r, newValidationError("sessionId", violationRequired, "session ID is required"))
+		return
+	}
+
+	changes, err := h.assistant.Changes(r.Context(), sessionID)
+	if err != nil {
+		handleError(w, r, err)
 		return
 	}
 
-	http.Error(w, err.Error(), http.StatusInternalServerError)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(mapChangesToResponse(changes)); err != nil {
+		handleError(w, r, fmt.Errorf("error encoding response: %w", err))
+		return
+	}
+}
+
+// CommitChatChanges godoc
+// @Summary Commit Chat Changes
+// @Description Apply the task operations staged in a chat session all together.
+// @Description Nothing is applied if a changed task was modified since the operation was staged.
+// @Tags chat
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Success 200 {array} ChatChange
+// @Failure 404 {object} Problem "Session or task not found"
+// @Failure 409 {object} Problem "Task was modified after the change was staged"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /chat/{sessionId}/commit [post]
+func (h *handler) CommitChatChanges(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.PathValue("sessionId")
+	if sessionID == "" {
+		handleError(w, r, newValidationError("sessionId", violationRequired, "session ID is required"))
+		return
+	}
+
+	changes, err := h.assistant.Commit(r.Context(), sessionID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(mapChangesToResponse(changes)); err != nil {
+		handleError(w, r, fmt.Errorf("error encoding response: %w", err))
+		return
+	}
+}
+
+// DiscardChatChanges godoc
+// @Summary Discard Chat Changes
+// @Description Drop the task operations staged in a chat session
+// @Tags chat
+// @Param sessionId path string true "Session ID"
+// @Success 204 {string} string "Changes discarded"
+// @Failure 404 {object} Problem "Session not found"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /chat/{sessionId}/changes [delete]
+func (h *handler) DiscardChatChanges(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.PathValue("sessionId")
+	if sessionID == "" {
+		handleError(w, r, newValidationError("sessionId", violationRequired, "session ID is required"))
+		return
+	}
+
+	if err := h.assistant.Discard(r.Context(), sessionID); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
 }
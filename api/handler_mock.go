@@ -39,6 +39,18 @@ func (m *MockHandler) EXPECT() *MockHandlerMockRecorder {
 	return m.recorder
 }
 
+// Batch mocks base method.
+func (m *MockHandler) Batch(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Batch", arg0, arg1)
+}
+
+// Batch indicates an expected call of Batch.
+func (mr *MockHandlerMockRecorder) Batch(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Batch", reflect.TypeOf((*MockHandler)(nil).Batch), arg0, arg1)
+}
+
 // Chat mocks base method.
 func (m *MockHandler) Chat(arg0 http.ResponseWriter, arg1 *http.Request) {
 	m.ctrl.T.Helper()
@@ -51,6 +63,18 @@ func (mr *MockHandlerMockRecorder) Chat(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Chat", reflect.TypeOf((*MockHandler)(nil).Chat), arg0, arg1)
 }
 
+// CommitChatChanges mocks base method.
+func (m *MockHandler) CommitChatChanges(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CommitChatChanges", arg0, arg1)
+}
+
+// CommitChatChanges indicates an expected call of CommitChatChanges.
+func (mr *MockHandlerMockRecorder) CommitChatChanges(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitChatChanges", reflect.TypeOf((*MockHandler)(nil).CommitChatChanges), arg0, arg1)
+}
+
 // Create mocks base method.
 func (m *MockHandler) Create(arg0 http.ResponseWriter, arg1 *http.Request) {
 	m.ctrl.T.Helper()
@@ -75,6 +99,54 @@ func (mr *MockHandlerMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockHandler)(nil).Delete), arg0, arg1)
 }
 
+// DeleteChatSession mocks base method.
+func (m *MockHandler) DeleteChatSession(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteChatSession", arg0, arg1)
+}
+
+// DeleteChatSession indicates an expected call of DeleteChatSession.
+func (mr *MockHandlerMockRecorder) DeleteChatSession(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChatSession", reflect.TypeOf((*MockHandler)(nil).DeleteChatSession), arg0, arg1)
+}
+
+// DiscardChatChanges mocks base method.
+func (m *MockHandler) DiscardChatChanges(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DiscardChatChanges", arg0, arg1)
+}
+
+// DiscardChatChanges indicates an expected call of DiscardChatChanges.
+func (mr *MockHandlerMockRecorder) DiscardChatChanges(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscardChatChanges", reflect.TypeOf((*MockHandler)(nil).DiscardChatChanges), arg0, arg1)
+}
+
+// Events mocks base method.
+func (m *MockHandler) Events(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Events", arg0, arg1)
+}
+
+// Events indicates an expected call of Events.
+func (mr *MockHandlerMockRecorder) Events(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Events", reflect.TypeOf((*MockHandler)(nil).Events), arg0, arg1)
+}
+
+// EventsWebSocket mocks base method.
+func (m *MockHandler) EventsWebSocket(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "EventsWebSocket", arg0, arg1)
+}
+
+// EventsWebSocket indicates an expected call of EventsWebSocket.
+func (mr *MockHandlerMockRecorder) EventsWebSocket(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EventsWebSocket", reflect.TypeOf((*MockHandler)(nil).EventsWebSocket), arg0, arg1)
+}
+
 // Get mocks base method.
 func (m *MockHandler) Get(arg0 http.ResponseWriter, arg1 *http.Request) {
 	m.ctrl.T.Helper()
@@ -87,6 +159,42 @@ func (mr *MockHandlerMockRecorder) Get(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockHandler)(nil).Get), arg0, arg1)
 }
 
+// GetChatChanges mocks base method.
+func (m *MockHandler) GetChatChanges(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetChatChanges", arg0, arg1)
+}
+
+// GetChatChanges indicates an expected call of GetChatChanges.
+func (mr *MockHandlerMockRecorder) GetChatChanges(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatChanges", reflect.TypeOf((*MockHandler)(nil).GetChatChanges), arg0, arg1)
+}
+
+// GetChatSession mocks base method.
+func (m *MockHandler) GetChatSession(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetChatSession", arg0, arg1)
+}
+
+// GetChatSession indicates an expected call of GetChatSession.
+func (mr *MockHandlerMockRecorder) GetChatSession(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatSession", reflect.TypeOf((*MockHandler)(nil).GetChatSession), arg0, arg1)
+}
+
+// History mocks base method.
+func (m *MockHandler) History(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "History", arg0, arg1)
+}
+
+// History indicates an expected call of History.
+func (mr *MockHandlerMockRecorder) History(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockHandler)(nil).History), arg0, arg1)
+}
+
 // List mocks base method.
 func (m *MockHandler) List(arg0 http.ResponseWriter, arg1 *http.Request) {
 	m.ctrl.T.Helper()
@@ -99,6 +207,90 @@ func (mr *MockHandlerMockRecorder) List(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockHandler)(nil).List), arg0, arg1)
 }
 
+// ListSubtasks mocks base method.
+func (m *MockHandler) ListSubtasks(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListSubtasks", arg0, arg1)
+}
+
+// ListSubtasks indicates an expected call of ListSubtasks.
+func (mr *MockHandlerMockRecorder) ListSubtasks(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubtasks", reflect.TypeOf((*MockHandler)(nil).ListSubtasks), arg0, arg1)
+}
+
+// ListTags mocks base method.
+func (m *MockHandler) ListTags(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListTags", arg0, arg1)
+}
+
+// ListTags indicates an expected call of ListTags.
+func (mr *MockHandlerMockRecorder) ListTags(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTags", reflect.TypeOf((*MockHandler)(nil).ListTags), arg0, arg1)
+}
+
+// MergeTags mocks base method.
+func (m *MockHandler) MergeTags(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "MergeTags", arg0, arg1)
+}
+
+// MergeTags indicates an expected call of MergeTags.
+func (mr *MockHandlerMockRecorder) MergeTags(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeTags", reflect.TypeOf((*MockHandler)(nil).MergeTags), arg0, arg1)
+}
+
+// Move mocks base method.
+func (m *MockHandler) Move(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Move", arg0, arg1)
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockHandlerMockRecorder) Move(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockHandler)(nil).Move), arg0, arg1)
+}
+
+// Order mocks base method.
+func (m *MockHandler) Order(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Order", arg0, arg1)
+}
+
+// Order indicates an expected call of Order.
+func (mr *MockHandlerMockRecorder) Order(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Order", reflect.TypeOf((*MockHandler)(nil).Order), arg0, arg1)
+}
+
+// RenameTag mocks base method.
+func (m *MockHandler) RenameTag(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RenameTag", arg0, arg1)
+}
+
+// RenameTag indicates an expected call of RenameTag.
+func (mr *MockHandlerMockRecorder) RenameTag(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameTag", reflect.TypeOf((*MockHandler)(nil).RenameTag), arg0, arg1)
+}
+
+// Search mocks base method.
+func (m *MockHandler) Search(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Search", arg0, arg1)
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockHandlerMockRecorder) Search(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockHandler)(nil).Search), arg0, arg1)
+}
+
 // Update mocks base method.
 func (m *MockHandler) Update(arg0 http.ResponseWriter, arg1 *http.Request) {
 	m.ctrl.T.Helper()
@@ -2,11 +2,14 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -16,6 +19,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/utsabbera/task-master/core/assistant"
 	"github.com/utsabbera/task-master/core/task"
+	pkgassistant "github.com/utsabbera/task-master/pkg/assistant"
 	"github.com/utsabbera/task-master/pkg/match"
 	"github.com/utsabbera/task-master/pkg/util"
 )
@@ -38,15 +42,15 @@ func TestHandler_Create(t *testing.T) {
 		inputBytes, err := json.Marshal(input)
 		require.NoError(t, err)
 
-		createTime := time.Now().Truncate(time.Second)
+		createTime := time.Now().UTC().Truncate(time.Second)
 
-		mockTaskService.EXPECT().Create(match.PtrTo(task.Task{
+		mockTaskService.EXPECT().Create(gomock.Any(), match.PtrTo(task.Task{
 			Title:       input.Title,
 			Description: input.Description,
 			Status:      input.Status,
 			Priority:    input.Priority,
 			DueDate:     input.DueDate,
-		})).DoAndReturn(func(tk *task.Task) error {
+		})).DoAndReturn(func(_ context.Context, tk *task.Task) error {
 			tk.ID = "task-123"
 			tk.CreatedAt = createTime
 			tk.UpdatedAt = tk.CreatedAt
@@ -73,6 +77,9 @@ func TestHandler_Create(t *testing.T) {
 			DueDate:     input.DueDate,
 			CreatedAt:   createTime,
 			UpdatedAt:   createTime,
+			Tags:        []string{},
+			BlockedBy:   []string{},
+			Assignees:   []string{},
 		}
 		assert.Equal(t, expected, response)
 	})
@@ -90,10 +97,10 @@ func TestHandler_Create(t *testing.T) {
 		handler.Create(res, req)
 
 		assert.Equal(t, http.StatusBadRequest, res.Code)
-		assert.Contains(t, res.Body.String(), "Invalid request payload")
+		assert.Equal(t, CodeInvalidBody, decodeProblem(t, res).Code)
 	})
 
-	t.Run("should return bad request when service returns error", func(t *testing.T) {
+	t.Run("should return internal server error when service returns error", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockTaskService := task.NewMockService(ctrl)
@@ -108,7 +115,7 @@ func TestHandler_Create(t *testing.T) {
 		inputBytes, err := json.Marshal(input)
 		require.NoError(t, err)
 
-		mockTaskService.EXPECT().Create(match.PtrTo(task.Task{
+		mockTaskService.EXPECT().Create(gomock.Any(), match.PtrTo(task.Task{
 			Title:       input.Title,
 			Description: input.Description,
 			Priority:    input.Priority,
@@ -119,8 +126,10 @@ func TestHandler_Create(t *testing.T) {
 		res := httptest.NewRecorder()
 		handler.Create(res, req)
 
-		assert.Equal(t, http.StatusBadRequest, res.Code)
-		assert.Contains(t, res.Body.String(), "service error")
+		assert.Equal(t, http.StatusInternalServerError, res.Code)
+		problem := decodeProblem(t, res)
+		assert.Equal(t, CodeInternal, problem.Code)
+		assert.Empty(t, problem.Detail)
 	})
 
 	t.Run("should return bad request when required fields are missing", func(t *testing.T) {
@@ -140,7 +149,128 @@ func TestHandler_Create(t *testing.T) {
 		handler.Create(res, req)
 
 		assert.Equal(t, http.StatusBadRequest, res.Code)
-		assert.Contains(t, res.Body.String(), "Title cannot be empty")
+		problem := decodeProblem(t, res)
+		assert.Equal(t, CodeValidationFailed, problem.Code)
+		assert.Equal(t, []FieldError{{Field: "title", Code: "required", Message: "title is required"}}, problem.Errors)
+	})
+
+	t.Run("should return bad request when status is unknown", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		inputBytes, err := json.Marshal(TaskInput{Title: "Test Task", Status: "DONE"})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/tasks", bytes.NewReader(inputBytes))
+		req.Header.Set("Content-Type", "application/json")
+		res := httptest.NewRecorder()
+		handler.Create(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		problem := decodeProblem(t, res)
+		assert.Equal(t, CodeValidationFailed, problem.Code)
+		require.Len(t, problem.Errors, 1)
+		assert.Equal(t, "status", problem.Errors[0].Field)
+		assert.Equal(t, "invalid", problem.Errors[0].Code)
+	})
+
+	t.Run("should report every invalid field", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		body := `{"title":" ","priority":"URGENT","dueDate":"0001-01-01T00:00:00Z"}`
+
+		req := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		res := httptest.NewRecorder()
+		handler.Create(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		problem := decodeProblem(t, res)
+		assert.Equal(t, CodeValidationFailed, problem.Code)
+		assert.Equal(t, []string{"title", "priority", "dueDate"}, util.Map(problem.Errors, func(e FieldError) string { return e.Field }))
+		assert.Equal(t, []string{"required", "invalid", "out_of_range"}, util.Map(problem.Errors, func(e FieldError) string { return e.Code }))
+	})
+
+	t.Run("should create recurring task", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, assistant.NewMockService(ctrl))
+
+		mockTaskService.EXPECT().Create(gomock.Any(), match.PtrTo(&task.Task{
+			Title:      "Take out the trash",
+			Recurrence: &task.Recurrence{Frequency: task.FrequencyWeekly, ByWeekday: []time.Weekday{time.Monday}},
+		})).Return(nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(`{"title":"Take out the trash","recurrence":"FREQ=WEEKLY;BYDAY=MO"}`))
+		req.Header.Set("Content-Type", "application/json")
+		res := httptest.NewRecorder()
+		handler.Create(res, req)
+
+		assert.Equal(t, http.StatusCreated, res.Code)
+		var response Task
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&response))
+		assert.Equal(t, util.Ptr("FREQ=WEEKLY;BYDAY=MO"), response.Recurrence)
+	})
+
+	t.Run("should return bad request when recurrence is invalid", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		handler := NewHandler(task.NewMockService(ctrl), assistant.NewMockService(ctrl))
+
+		req := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(`{"title":"Take out the trash","recurrence":"FREQ=HOURLY"}`))
+		req.Header.Set("Content-Type", "application/json")
+		res := httptest.NewRecorder()
+		handler.Create(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		problem := decodeProblem(t, res)
+		require.Len(t, problem.Errors, 1)
+		assert.Equal(t, "recurrence", problem.Errors[0].Field)
+		assert.Equal(t, "invalid", problem.Errors[0].Code)
+	})
+
+	t.Run("should return bad request when body has unknown field", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		req := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(`{"title":"Test Task","owner":"me"}`))
+		req.Header.Set("Content-Type", "application/json")
+		res := httptest.NewRecorder()
+		handler.Create(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		problem := decodeProblem(t, res)
+		assert.Equal(t, CodeValidationFailed, problem.Code)
+		assert.Equal(t, []FieldError{{Field: "owner", Code: "unknown", Message: "owner is not a known field"}}, problem.Errors)
+	})
+
+	t.Run("should return request entity too large when body exceeds limit", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		body := `{"title":"Test Task","description":"` + strings.Repeat("a", maxBodyBytes) + `"}`
+
+		req := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		res := httptest.NewRecorder()
+		handler.Create(res, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, res.Code)
+		assert.Equal(t, CodeRequestTooLarge, decodeProblem(t, res).Code)
 	})
 }
 
@@ -159,7 +289,9 @@ func TestHandler_Get(t *testing.T) {
 			Description: "Test Description",
 			Status:      task.StatusNotStarted,
 		}
-		mockTaskService.EXPECT().Get(taskID).Return(existingTask, nil)
+		mockTaskService.EXPECT().Get(gomock.Any(), taskID).Return(existingTask, nil)
+
+		mockTaskService.EXPECT().Progress(gomock.Any(), gomock.Any()).Return(map[string]task.Progress{}, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/tasks/"+taskID, nil)
 		req.SetPathValue("id", taskID)
@@ -177,6 +309,9 @@ func TestHandler_Get(t *testing.T) {
 			Title:       existingTask.Title,
 			Description: existingTask.Description,
 			Status:      task.StatusNotStarted,
+			Tags:        []string{},
+			BlockedBy:   []string{},
+			Assignees:   []string{},
 		}
 		assert.Equal(t, expected, response)
 	})
@@ -193,7 +328,7 @@ func TestHandler_Get(t *testing.T) {
 		handler.Get(res, req)
 
 		assert.Equal(t, http.StatusBadRequest, res.Code)
-		assert.Contains(t, res.Body.String(), "Missing task ID")
+		assert.Equal(t, CodeValidationFailed, decodeProblem(t, res).Code)
 	})
 
 	t.Run("should return not found when service returns error", func(t *testing.T) {
@@ -204,7 +339,7 @@ func TestHandler_Get(t *testing.T) {
 		handler := NewHandler(mockTaskService, mockAssistantService)
 
 		taskID := "non-existent"
-		mockTaskService.EXPECT().Get(taskID).Return(nil, task.ErrTaskNotFound)
+		mockTaskService.EXPECT().Get(gomock.Any(), taskID).Return(nil, task.ErrTaskNotFound)
 
 		req := httptest.NewRequest(http.MethodGet, "/tasks/"+taskID, nil)
 		req.SetPathValue("id", taskID)
@@ -212,7 +347,7 @@ func TestHandler_Get(t *testing.T) {
 		handler.Get(res, req)
 
 		assert.Equal(t, http.StatusNotFound, res.Code)
-		assert.Contains(t, res.Body.String(), "Task not found")
+		assert.Equal(t, CodeTaskNotFound, decodeProblem(t, res).Code)
 	})
 
 	t.Run("should return internal server error when service returns unexpected error", func(t *testing.T) {
@@ -223,7 +358,7 @@ func TestHandler_Get(t *testing.T) {
 		handler := NewHandler(mockTaskService, mockAssistantService)
 
 		taskID := "task-err"
-		mockTaskService.EXPECT().Get(taskID).Return(nil, fmt.Errorf("unexpected error"))
+		mockTaskService.EXPECT().Get(gomock.Any(), taskID).Return(nil, fmt.Errorf("unexpected error"))
 
 		req := httptest.NewRequest(http.MethodGet, "/tasks/"+taskID, nil)
 		req.SetPathValue("id", taskID)
@@ -231,7 +366,69 @@ func TestHandler_Get(t *testing.T) {
 		handler.Get(res, req)
 
 		assert.Equal(t, http.StatusInternalServerError, res.Code)
-		assert.Contains(t, res.Body.String(), "unexpected error")
+		assert.Equal(t, CodeInternal, decodeProblem(t, res).Code)
+	})
+
+	t.Run("should return ETag of task version", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		mockTaskService.EXPECT().Get(gomock.Any(), "task-123").Return(&task.Task{ID: "task-123", Version: 4}, nil)
+
+		mockTaskService.EXPECT().Progress(gomock.Any(), gomock.Any()).Return(map[string]task.Progress{}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/tasks/task-123", nil)
+		req.SetPathValue("id", "task-123")
+		res := httptest.NewRecorder()
+		handler.Get(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, `"4"`, res.Header().Get("ETag"))
+	})
+
+	t.Run("should return not modified when If-None-Match matches", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		mockTaskService.EXPECT().Get(gomock.Any(), "task-123").Return(&task.Task{ID: "task-123", Version: 4}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/tasks/task-123", nil)
+		req.Header.Set("If-None-Match", `"3", W/"4"`)
+		req.SetPathValue("id", "task-123")
+		res := httptest.NewRecorder()
+		handler.Get(res, req)
+
+		assert.Equal(t, http.StatusNotModified, res.Code)
+		assert.Equal(t, `"4"`, res.Header().Get("ETag"))
+		assert.Empty(t, res.Body.Bytes())
+	})
+
+	t.Run("should redirect to current ID of moved task", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		mockTaskService.EXPECT().Get(gomock.Any(), "TASK-000001").
+			Return(nil, fmt.Errorf("error finding task: %w", &task.MovedError{ID: "TASK-000001", MovedTo: "WEB-000001"}))
+
+		req := httptest.NewRequest(http.MethodGet, "/tasks/TASK-000001?fields=title", nil)
+		req.SetPathValue("id", "TASK-000001")
+		res := httptest.NewRecorder()
+		handler.Get(res, req)
+
+		assert.Equal(t, http.StatusPermanentRedirect, res.Code)
+		assert.Equal(t, "/tasks/WEB-000001?fields=title", res.Header().Get("Location"))
+		problem := decodeProblem(t, res)
+		assert.Equal(t, CodeTaskMoved, problem.Code)
+		assert.Equal(t, "task moved: TASK-000001 is now WEB-000001", problem.Detail)
 	})
 }
 
@@ -257,13 +454,17 @@ func TestHandler_List(t *testing.T) {
 				Status:      task.StatusInProgress,
 			},
 		}
-		mockTaskService.EXPECT().List().Return(tasks, nil)
+		mockTaskService.EXPECT().List(gomock.Any(), task.ListOptions{Limit: 100}).Return(&task.Page{Tasks: tasks, Total: 2}, nil)
+
+		mockTaskService.EXPECT().Progress(gomock.Any(), gomock.Any()).Return(map[string]task.Progress{}, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/tasks", nil)
 		res := httptest.NewRecorder()
 		handler.List(res, req)
 
 		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "2", res.Header().Get("X-Total-Count"))
+		assert.Empty(t, res.Header().Get("X-Next-Cursor"))
 
 		var response []Task
 		err := json.Unmarshal(res.Body.Bytes(), &response)
@@ -275,12 +476,18 @@ func TestHandler_List(t *testing.T) {
 				Title:       "Task 1",
 				Description: "Description 1",
 				Status:      task.StatusNotStarted,
+				Tags:        []string{},
+				BlockedBy:   []string{},
+				Assignees:   []string{},
 			},
 			{
 				ID:          "task-2",
 				Title:       "Task 2",
 				Description: "Description 2",
 				Status:      task.StatusInProgress,
+				Tags:        []string{},
+				BlockedBy:   []string{},
+				Assignees:   []string{},
 			},
 		}
 		assert.Equal(t, expected, response)
@@ -293,216 +500,297 @@ func TestHandler_List(t *testing.T) {
 		mockAssistantService := assistant.NewMockService(ctrl)
 		handler := NewHandler(mockTaskService, mockAssistantService)
 
-		mockTaskService.EXPECT().List().Return(nil, fmt.Errorf("database error"))
+		mockTaskService.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("database error"))
 
 		req := httptest.NewRequest(http.MethodGet, "/tasks", nil)
 		res := httptest.NewRecorder()
 		handler.List(res, req)
 
 		assert.Equal(t, http.StatusInternalServerError, res.Code)
-		assert.Contains(t, res.Body.String(), "Failed to retrieve tasks")
+		assert.Equal(t, CodeInternal, decodeProblem(t, res).Code)
 	})
-}
 
-func TestHandler_Update(t *testing.T) {
-	t.Run("should update task with valid data", func(t *testing.T) {
+	t.Run("should pass query parameters to service", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockTaskService := task.NewMockService(ctrl)
 		mockAssistantService := assistant.NewMockService(ctrl)
 		handler := NewHandler(mockTaskService, mockAssistantService)
 
-		taskID := "task-123"
-		input := TaskInput{
-			Title:       "Updated Task",
-			Description: "Updated Description",
-			Priority:    util.Ptr(task.PriorityHigh),
-			DueDate:     util.Ptr(time.Date(2025, 2, 1, 12, 0, 0, 0, time.UTC)),
+		expected := task.ListOptions{
+			Filter: task.Filter{
+				Statuses:   []task.Status{task.StatusInProgress, task.StatusCompleted},
+				Priorities: []task.Priority{task.PriorityHigh},
+				DueBefore:  util.Ptr(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)),
+				DueAfter:   util.Ptr(time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)),
+				Query:      "report",
+			},
+			Sort:   []task.Sort{{Field: task.SortByDueDate, Descending: true}, {Field: task.SortByTitle}},
+			Limit:  50,
+			Cursor: "NTA",
 		}
-		inputBytes, err := json.Marshal(input)
-		require.NoError(t, err)
+		mockTaskService.EXPECT().List(gomock.Any(), expected).Return(&task.Page{
+			Tasks:      []*task.Task{{ID: "task-1", Title: "Report"}},
+			Total:      120,
+			NextCursor: "MTAw",
+		}, nil)
 
-		updated := &task.Task{
-			ID:          taskID,
-			Title:       input.Title,
-			Description: input.Description,
-			Status:      task.StatusNotStarted,
-			Priority:    input.Priority,
-			DueDate:     input.DueDate,
-		}
-		mockTaskService.EXPECT().Update(taskID, match.PtrTo(task.Task{
-			Title:       input.Title,
-			Description: input.Description,
-			Priority:    input.Priority,
-			DueDate:     input.DueDate,
-		})).Return(updated, nil)
+		query := "status=IN_PROGRESS,COMPLETED&priority=HIGH&dueBefore=2025-06-01T00:00:00Z&dueAfter=2025-05-01T00:00:00Z" +
+			"&q=report&sort=-dueDate,title&limit=50&cursor=NTA"
+		mockTaskService.EXPECT().Progress(gomock.Any(), gomock.Any()).Return(map[string]task.Progress{}, nil)
 
-		req := httptest.NewRequest(http.MethodPatch, "/tasks/"+taskID, bytes.NewReader(inputBytes))
-		req.Header.Set("Content-Type", "application/json")
-		req.SetPathValue("id", taskID)
+		req := httptest.NewRequest(http.MethodGet, "/tasks?"+query, nil)
 		res := httptest.NewRecorder()
-		handler.Update(res, req)
+		handler.List(res, req)
 
 		assert.Equal(t, http.StatusOK, res.Code)
-
-		var response Task
-		err = json.Unmarshal(res.Body.Bytes(), &response)
-		require.NoError(t, err)
-
-		expected := Task{
-			ID:          taskID,
-			Title:       input.Title,
-			Description: input.Description,
-			Status:      task.StatusNotStarted,
-			Priority:    input.Priority,
-			DueDate:     input.DueDate,
-		}
-		assert.Equal(t, expected, response)
+		assert.Equal(t, "120", res.Header().Get("X-Total-Count"))
+		assert.Equal(t, "MTAw", res.Header().Get("X-Next-Cursor"))
 	})
 
-	t.Run("should return bad request with missing ID", func(t *testing.T) {
+	t.Run("should pass included and excluded tags to service", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockTaskService := task.NewMockService(ctrl)
 		mockAssistantService := assistant.NewMockService(ctrl)
 		handler := NewHandler(mockTaskService, mockAssistantService)
 
-		input := TaskInput{
-			Title:       "Updated Task",
-			Description: "Updated Description",
-		}
-		inputBytes, err := json.Marshal(input)
-		require.NoError(t, err)
+		mockTaskService.EXPECT().List(gomock.Any(), task.ListOptions{
+			Filter: task.Filter{Tags: []string{"backend", "urgent"}, ExcludedTags: []string{"blocked"}},
+			Limit:  100,
+		}).Return(&task.Page{Tasks: []*task.Task{}}, nil)
 
-		req := httptest.NewRequest(http.MethodPatch, "/tasks/", bytes.NewReader(inputBytes))
-		req.Header.Set("Content-Type", "application/json")
+		mockTaskService.EXPECT().Progress(gomock.Any(), gomock.Any()).Return(map[string]task.Progress{}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/tasks?tag=Backend&tag=!blocked,urgent", nil)
 		res := httptest.NewRecorder()
-		handler.Update(res, req)
+		handler.List(res, req)
 
-		assert.Equal(t, http.StatusBadRequest, res.Code)
-		assert.Contains(t, res.Body.String(), "Missing task ID")
+		assert.Equal(t, http.StatusOK, res.Code)
 	})
 
-	t.Run("should return bad request with invalid JSON", func(t *testing.T) {
+	t.Run("should resolve me to the caller in owner and assignee", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockTaskService := task.NewMockService(ctrl)
 		mockAssistantService := assistant.NewMockService(ctrl)
 		handler := NewHandler(mockTaskService, mockAssistantService)
 
-		taskID := "task-123"
-		req := httptest.NewRequest(http.MethodPatch, "/tasks/"+taskID, bytes.NewReader([]byte("invalid json")))
-		req.Header.Set("Content-Type", "application/json")
-		req.SetPathValue("id", taskID)
+		mockTaskService.EXPECT().List(gomock.Any(), task.ListOptions{
+			Filter: task.Filter{Owners: []string{"bob"}, Assignees: []string{"alice", "carol"}},
+			Limit:  100,
+		}).Return(&task.Page{Tasks: []*task.Task{}}, nil)
+
+		mockTaskService.EXPECT().Progress(gomock.Any(), gomock.Any()).Return(map[string]task.Progress{}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/tasks?owner=bob&assignee=me,carol", nil)
+		req = req.WithContext(task.WithUser(req.Context(), task.User{ID: "alice", Role: task.RoleMember}))
 		res := httptest.NewRecorder()
-		handler.Update(res, req)
+		handler.List(res, req)
 
-		assert.Equal(t, http.StatusBadRequest, res.Code)
-		assert.Contains(t, res.Body.String(), "Invalid request payload")
+		assert.Equal(t, http.StatusOK, res.Code)
 	})
 
-	t.Run("should return not found when task doesn't exist", func(t *testing.T) {
+	t.Run("should accept repeated filter parameters", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockTaskService := task.NewMockService(ctrl)
 		mockAssistantService := assistant.NewMockService(ctrl)
 		handler := NewHandler(mockTaskService, mockAssistantService)
 
-		taskID := "non-existent"
-		input := TaskInput{
-			Title:       "Updated Task",
-			Description: "Updated Description",
-		}
-		inputBytes, err := json.Marshal(input)
-		require.NoError(t, err)
+		mockTaskService.EXPECT().List(gomock.Any(), task.ListOptions{
+			Filter: task.Filter{Priorities: []task.Priority{task.PriorityLow, task.PriorityMedium}},
+			Limit:  100,
+		}).Return(&task.Page{Tasks: []*task.Task{}}, nil)
 
-		mockTaskService.EXPECT().Update(taskID, match.PtrTo(task.Task{
-			Title:       input.Title,
-			Description: input.Description,
-		})).Return(nil, task.ErrTaskNotFound)
+		mockTaskService.EXPECT().Progress(gomock.Any(), gomock.Any()).Return(map[string]task.Progress{}, nil)
 
-		req := httptest.NewRequest(http.MethodPatch, "/tasks/"+taskID, bytes.NewReader(inputBytes))
-		req.Header.Set("Content-Type", "application/json")
-		req.SetPathValue("id", taskID)
+		req := httptest.NewRequest(http.MethodGet, "/tasks?priority=LOW&priority=MEDIUM", nil)
 		res := httptest.NewRecorder()
-		handler.Update(res, req)
+		handler.List(res, req)
 
-		assert.Equal(t, http.StatusNotFound, res.Code)
-		assert.Contains(t, res.Body.String(), "Task not found")
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, "[]", res.Body.String())
 	})
 
-	t.Run("should return server error when update fails", func(t *testing.T) {
+	t.Run("should pass filter expression to service", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockTaskService := task.NewMockService(ctrl)
 		mockAssistantService := assistant.NewMockService(ctrl)
 		handler := NewHandler(mockTaskService, mockAssistantService)
 
-		taskID := "task-123"
-		input := TaskInput{
-			Title:       "Updated Task",
-			Description: "Updated Description",
-		}
-		inputBytes, err := json.Marshal(input)
-		require.NoError(t, err)
+		mockTaskService.EXPECT().List(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, opts task.ListOptions) (*task.Page, error) {
+				require.NotNil(t, opts.Filter.Expression)
+				assert.Equal(t, "status != COMPLETED and tag:backend", opts.Filter.Expression.String())
+				assert.Equal(t, 100, opts.Limit)
+				return &task.Page{Tasks: []*task.Task{}}, nil
+			})
 
-		mockTaskService.EXPECT().Update(taskID, match.PtrTo(task.Task{
-			Title:       input.Title,
-			Description: input.Description,
-		})).Return(nil, fmt.Errorf("database error"))
+		mockTaskService.EXPECT().Progress(gomock.Any(), gomock.Any()).Return(map[string]task.Progress{}, nil)
 
-		req := httptest.NewRequest(http.MethodPatch, "/tasks/"+taskID, bytes.NewReader(inputBytes))
-		req.Header.Set("Content-Type", "application/json")
-		req.SetPathValue("id", taskID)
+		req := httptest.NewRequest(http.MethodGet, "/tasks?filter="+url.QueryEscape("status != COMPLETED and tag:backend"), nil)
 		res := httptest.NewRecorder()
-		handler.Update(res, req)
+		handler.List(res, req)
 
-		assert.Equal(t, http.StatusInternalServerError, res.Code)
-		assert.Contains(t, res.Body.String(), "database error")
+		assert.Equal(t, http.StatusOK, res.Code)
 	})
 
-	t.Run("should update only provided fields (partial update)", func(t *testing.T) {
+	t.Run("should return bad request with invalid filter expression", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockTaskService := task.NewMockService(ctrl)
 		mockAssistantService := assistant.NewMockService(ctrl)
 		handler := NewHandler(mockTaskService, mockAssistantService)
 
-		taskID := "task-123"
-		input := TaskInput{
-			Title: "Partial Update",
-		}
-		inputBytes, err := json.Marshal(input)
-		require.NoError(t, err)
-
-		updated := &task.Task{
-			ID:    taskID,
-			Title: input.Title,
-			// other fields remain unchanged or zero
-		}
-		mockTaskService.EXPECT().Update(taskID, match.PtrTo(task.Task{
-			Title: input.Title,
-		})).Return(updated, nil)
-
-		req := httptest.NewRequest(http.MethodPatch, "/tasks/"+taskID, bytes.NewReader(inputBytes))
-		req.Header.Set("Content-Type", "application/json")
-		req.SetPathValue("id", taskID)
+		req := httptest.NewRequest(http.MethodGet, "/tasks?filter="+url.QueryEscape("status = DONE"), nil)
 		res := httptest.NewRecorder()
-		handler.Update(res, req)
-
-		assert.Equal(t, http.StatusOK, res.Code)
-
-		var response Task
-		err = json.Unmarshal(res.Body.Bytes(), &response)
-		require.NoError(t, err)
+		handler.List(res, req)
 
-		assert.Equal(t, updated.ID, response.ID)
-		assert.Equal(t, updated.Title, response.Title)
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		problem := decodeProblem(t, res)
+		assert.Equal(t, CodeValidationFailed, problem.Code)
+		require.Len(t, problem.Errors, 1)
+		assert.Equal(t, "filter", problem.Errors[0].Field)
+		assert.Equal(t, `invalid filter expression: unknown status "DONE" at position 1`, problem.Errors[0].Message)
 	})
-}
 
-func TestHandler_Delete(t *testing.T) {
-	t.Run("should delete task with valid ID", func(t *testing.T) {
+	t.Run("should return bad request with invalid query parameters", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		queries := []string{
+			"limit=0",
+			"limit=1001",
+			"limit=ten",
+			"sort=color",
+			"dueBefore=tomorrow",
+			"dueAfter=2025-05-01",
+			"status=DONE",
+			"priority=URGENT",
+			"tag=two%20words",
+			"assignee=me",
+		}
+
+		for _, query := range queries {
+			req := httptest.NewRequest(http.MethodGet, "/tasks?"+query, nil)
+			res := httptest.NewRecorder()
+			handler.List(res, req)
+
+			assert.Equal(t, http.StatusBadRequest, res.Code, query)
+			assert.Equal(t, CodeValidationFailed, decodeProblem(t, res).Code, query)
+		}
+	})
+
+	t.Run("should report every invalid query parameter", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		req := httptest.NewRequest(http.MethodGet, "/tasks?limit=0&sort=color&status=DONE", nil)
+		res := httptest.NewRecorder()
+		handler.List(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		problem := decodeProblem(t, res)
+		assert.Equal(t, []string{"status", "sort", "limit"}, util.Map(problem.Errors, func(e FieldError) string { return e.Field }))
+	})
+
+	t.Run("should return bad request when service rejects options", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		mockTaskService.EXPECT().List(gomock.Any(), gomock.Any()).
+			Return(nil, fmt.Errorf("error listing tasks: %w", task.ErrInvalidListOptions))
+
+		req := httptest.NewRequest(http.MethodGet, "/tasks?cursor=bogus", nil)
+		res := httptest.NewRecorder()
+		handler.List(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		problem := decodeProblem(t, res)
+		assert.Equal(t, CodeInvalidQuery, problem.Code)
+		assert.Equal(t, task.ErrInvalidListOptions.Error(), problem.Detail)
+	})
+}
+
+func TestHandler_Search(t *testing.T) {
+	t.Run("should return ranked hits with highlights", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, assistant.NewMockService(ctrl))
+
+		mockTaskService.EXPECT().Search(gomock.Any(), "quarterly report", task.SearchOptions{
+			Filter: task.Filter{Statuses: []task.Status{task.StatusNotStarted}},
+			Limit:  5,
+		}).Return(&task.SearchResult{
+			Hits: []task.SearchHit{{
+				Task:       &task.Task{ID: "TASK-000001", Title: "Write quarterly report", Status: task.StatusNotStarted},
+				Score:      2.5,
+				Highlights: []task.Highlight{{Field: "title", Fragment: "Write <mark>quarterly</mark> <mark>report</mark>"}},
+			}},
+			Total: 3,
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/tasks/search?q=quarterly+report&status=NOT_STARTED&limit=5", nil)
+		res := httptest.NewRecorder()
+		handler.Search(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "3", res.Header().Get("X-Total-Count"))
+
+		var response []TaskSearchHit
+		require.NoError(t, json.Unmarshal(res.Body.Bytes(), &response))
+		require.Len(t, response, 1)
+		assert.Equal(t, "TASK-000001", response[0].Task.ID)
+		assert.Equal(t, 2.5, response[0].Score)
+		assert.Equal(t, []SearchHighlight{{Field: "title", Fragment: "Write <mark>quarterly</mark> <mark>report</mark>"}}, response[0].Highlights)
+	})
+
+	t.Run("should return bad request when query is missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		handler := NewHandler(task.NewMockService(ctrl), assistant.NewMockService(ctrl))
+
+		req := httptest.NewRequest(http.MethodGet, "/tasks/search?q=+&limit=500", nil)
+		res := httptest.NewRecorder()
+		handler.Search(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		problem := decodeProblem(t, res)
+		assert.Equal(t, CodeValidationFailed, problem.Code)
+		assert.Equal(t, []string{"q", "limit"}, util.Map(problem.Errors, func(e FieldError) string { return e.Field }))
+	})
+
+	t.Run("should return not implemented when search is unavailable", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, assistant.NewMockService(ctrl))
+
+		mockTaskService.EXPECT().Search(gomock.Any(), "report", gomock.Any()).Return(nil, task.ErrSearchUnavailable)
+
+		req := httptest.NewRequest(http.MethodGet, "/tasks/search?q=report", nil)
+		res := httptest.NewRecorder()
+		handler.Search(res, req)
+
+		assert.Equal(t, http.StatusNotImplemented, res.Code)
+		assert.Equal(t, CodeSearchUnavailable, decodeProblem(t, res).Code)
+	})
+}
+
+func TestHandler_Update(t *testing.T) {
+	t.Run("should update task with valid data", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockTaskService := task.NewMockService(ctrl)
@@ -510,15 +798,56 @@ func TestHandler_Delete(t *testing.T) {
 		handler := NewHandler(mockTaskService, mockAssistantService)
 
 		taskID := "task-123"
-		mockTaskService.EXPECT().Delete(taskID).Return(nil)
+		input := TaskInput{
+			Title:       "Updated Task",
+			Description: "Updated Description",
+			Priority:    util.Ptr(task.PriorityHigh),
+			DueDate:     util.Ptr(time.Date(2025, 2, 1, 12, 0, 0, 0, time.UTC)),
+		}
+		inputBytes, err := json.Marshal(input)
+		require.NoError(t, err)
 
-		req := httptest.NewRequest(http.MethodDelete, "/tasks/"+taskID, nil)
+		updated := &task.Task{
+			ID:          taskID,
+			Title:       input.Title,
+			Description: input.Description,
+			Status:      task.StatusNotStarted,
+			Priority:    input.Priority,
+			DueDate:     input.DueDate,
+		}
+		mockTaskService.EXPECT().Update(gomock.Any(), taskID, match.PtrTo(task.Task{
+			Title:       input.Title,
+			Description: input.Description,
+			Priority:    input.Priority,
+			DueDate:     input.DueDate,
+		})).Return(updated, nil)
+
+		mockTaskService.EXPECT().Progress(gomock.Any(), gomock.Any()).Return(map[string]task.Progress{}, nil)
+
+		req := httptest.NewRequest(http.MethodPatch, "/tasks/"+taskID, bytes.NewReader(inputBytes))
+		req.Header.Set("Content-Type", "application/json")
 		req.SetPathValue("id", taskID)
 		res := httptest.NewRecorder()
-		handler.Delete(res, req)
+		handler.Update(res, req)
 
-		assert.Equal(t, http.StatusNoContent, res.Code)
-		assert.Empty(t, res.Body.String())
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		var response Task
+		err = json.Unmarshal(res.Body.Bytes(), &response)
+		require.NoError(t, err)
+
+		expected := Task{
+			ID:          taskID,
+			Title:       input.Title,
+			Description: input.Description,
+			Status:      task.StatusNotStarted,
+			Priority:    input.Priority,
+			DueDate:     input.DueDate,
+			Tags:        []string{},
+			BlockedBy:   []string{},
+			Assignees:   []string{},
+		}
+		assert.Equal(t, expected, response)
 	})
 
 	t.Run("should return bad request with missing ID", func(t *testing.T) {
@@ -528,12 +857,38 @@ func TestHandler_Delete(t *testing.T) {
 		mockAssistantService := assistant.NewMockService(ctrl)
 		handler := NewHandler(mockTaskService, mockAssistantService)
 
-		req := httptest.NewRequest(http.MethodDelete, "/tasks/", nil)
+		input := TaskInput{
+			Title:       "Updated Task",
+			Description: "Updated Description",
+		}
+		inputBytes, err := json.Marshal(input)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPatch, "/tasks/", bytes.NewReader(inputBytes))
+		req.Header.Set("Content-Type", "application/json")
 		res := httptest.NewRecorder()
-		handler.Delete(res, req)
+		handler.Update(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, CodeValidationFailed, decodeProblem(t, res).Code)
+	})
+
+	t.Run("should return bad request with invalid JSON", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		taskID := "task-123"
+		req := httptest.NewRequest(http.MethodPatch, "/tasks/"+taskID, bytes.NewReader([]byte("invalid json")))
+		req.Header.Set("Content-Type", "application/json")
+		req.SetPathValue("id", taskID)
+		res := httptest.NewRecorder()
+		handler.Update(res, req)
 
 		assert.Equal(t, http.StatusBadRequest, res.Code)
-		assert.Contains(t, res.Body.String(), "Missing task ID")
+		assert.Equal(t, CodeInvalidBody, decodeProblem(t, res).Code)
 	})
 
 	t.Run("should return not found when task doesn't exist", func(t *testing.T) {
@@ -544,111 +899,1611 @@ func TestHandler_Delete(t *testing.T) {
 		handler := NewHandler(mockTaskService, mockAssistantService)
 
 		taskID := "non-existent"
-		mockTaskService.EXPECT().Delete(taskID).Return(task.ErrTaskNotFound)
+		input := TaskInput{
+			Title:       "Updated Task",
+			Description: "Updated Description",
+		}
+		inputBytes, err := json.Marshal(input)
+		require.NoError(t, err)
 
-		req := httptest.NewRequest(http.MethodDelete, "/tasks/"+taskID, nil)
+		mockTaskService.EXPECT().Update(gomock.Any(), taskID, match.PtrTo(task.Task{
+			Title:       input.Title,
+			Description: input.Description,
+		})).Return(nil, task.ErrTaskNotFound)
+
+		req := httptest.NewRequest(http.MethodPatch, "/tasks/"+taskID, bytes.NewReader(inputBytes))
+		req.Header.Set("Content-Type", "application/json")
 		req.SetPathValue("id", taskID)
 		res := httptest.NewRecorder()
-		handler.Delete(res, req)
+		handler.Update(res, req)
 
 		assert.Equal(t, http.StatusNotFound, res.Code)
-		assert.Contains(t, res.Body.String(), "Task not found")
+		assert.Equal(t, CodeTaskNotFound, decodeProblem(t, res).Code)
 	})
 
-	t.Run("should return internal server error when service returns unexpected error", func(t *testing.T) {
+	t.Run("should return conflict when status transition is not allowed", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockTaskService := task.NewMockService(ctrl)
 		mockAssistantService := assistant.NewMockService(ctrl)
 		handler := NewHandler(mockTaskService, mockAssistantService)
 
-		taskID := "task-err"
-		mockTaskService.EXPECT().Delete(taskID).Return(fmt.Errorf("unexpected error"))
+		taskID := "task-123"
+		inputBytes, err := json.Marshal(TaskInput{Status: task.StatusCompleted})
+		require.NoError(t, err)
 
-		req := httptest.NewRequest(http.MethodDelete, "/tasks/"+taskID, nil)
+		transitionErr := &task.TransitionError{From: task.StatusCancelled, To: task.StatusCompleted}
+		mockTaskService.EXPECT().Update(gomock.Any(), taskID, match.PtrTo(task.Task{
+			Status: task.StatusCompleted,
+		})).Return(nil, fmt.Errorf("error updating task: %w", transitionErr))
+
+		req := httptest.NewRequest(http.MethodPatch, "/tasks/"+taskID, bytes.NewReader(inputBytes))
+		req.Header.Set("Content-Type", "application/json")
 		req.SetPathValue("id", taskID)
 		res := httptest.NewRecorder()
-		handler.Delete(res, req)
+		handler.Update(res, req)
 
-		assert.Equal(t, http.StatusInternalServerError, res.Code)
-		assert.Contains(t, res.Body.String(), "unexpected error")
+		assert.Equal(t, http.StatusConflict, res.Code)
+		problem := decodeProblem(t, res)
+		assert.Equal(t, CodeInvalidTransition, problem.Code)
+		assert.Equal(t, transitionErr.Error(), problem.Detail)
 	})
-}
 
-func TestHandler_Chat(t *testing.T) {
-	t.Run("should process assistant successfully", func(t *testing.T) {
+	t.Run("should return bad request when status is unknown", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockTaskService := task.NewMockService(ctrl)
 		mockAssistantService := assistant.NewMockService(ctrl)
 		handler := NewHandler(mockTaskService, mockAssistantService)
 
-		input := ChatInput{
-			Text: "Create a new task to finish the report",
-		}
-
-		body, err := json.Marshal(input)
+		taskID := "task-123"
+		inputBytes, err := json.Marshal(TaskInput{Status: "DONE"})
 		require.NoError(t, err)
 
-		req := httptest.NewRequest(http.MethodPost, "/chat", bytes.NewReader(body))
-		w := httptest.NewRecorder()
-
-		mockAssistantService.EXPECT().Chat(req.Context(), input.Text).Return("Task created: TASK-123", nil)
+		req := httptest.NewRequest(http.MethodPatch, "/tasks/"+taskID, bytes.NewReader(inputBytes))
+		req.Header.Set("Content-Type", "application/json")
+		req.SetPathValue("id", taskID)
+		res := httptest.NewRecorder()
+		handler.Update(res, req)
 
-		handler.Chat(w, req)
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		problem := decodeProblem(t, res)
+		require.Len(t, problem.Errors, 1)
+		assert.Equal(t, "status", problem.Errors[0].Field)
+	})
 
-		assert.Equal(t, http.StatusOK, w.Code)
+	t.Run("should return bad request when title is too long", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
 
-		var response ChatResponse
-		err = json.Unmarshal(w.Body.Bytes(), &response)
+		taskID := "task-123"
+		inputBytes, err := json.Marshal(TaskInput{Title: strings.Repeat("a", task.MaxTitleLength+1)})
 		require.NoError(t, err)
 
-		assert.Contains(t, response.Response, "TASK-123")
+		req := httptest.NewRequest(http.MethodPatch, "/tasks/"+taskID, bytes.NewReader(inputBytes))
+		req.Header.Set("Content-Type", "application/json")
+		req.SetPathValue("id", taskID)
+		res := httptest.NewRecorder()
+		handler.Update(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		problem := decodeProblem(t, res)
+		assert.Equal(t, []FieldError{{Field: "title", Code: "too_long", Message: "title must be at most 200 characters"}}, problem.Errors)
 	})
 
-	t.Run("should handle empty assistant", func(t *testing.T) {
+	t.Run("should return server error when update fails", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockTaskService := task.NewMockService(ctrl)
 		mockAssistantService := assistant.NewMockService(ctrl)
 		handler := NewHandler(mockTaskService, mockAssistantService)
 
-		input := ChatInput{
-			Text: "",
+		taskID := "task-123"
+		input := TaskInput{
+			Title:       "Updated Task",
+			Description: "Updated Description",
 		}
-		body, err := json.Marshal(input)
+		inputBytes, err := json.Marshal(input)
 		require.NoError(t, err)
 
-		req := httptest.NewRequest(http.MethodPost, "/chat", bytes.NewReader(body))
-		w := httptest.NewRecorder()
-		handler.Chat(w, req)
+		mockTaskService.EXPECT().Update(gomock.Any(), taskID, match.PtrTo(task.Task{
+			Title:       input.Title,
+			Description: input.Description,
+		})).Return(nil, fmt.Errorf("database error"))
 
-		assert.Equal(t, http.StatusBadRequest, w.Code)
-		assert.Contains(t, w.Body.String(), "cannot be empty")
+		req := httptest.NewRequest(http.MethodPatch, "/tasks/"+taskID, bytes.NewReader(inputBytes))
+		req.Header.Set("Content-Type", "application/json")
+		req.SetPathValue("id", taskID)
+		res := httptest.NewRecorder()
+		handler.Update(res, req)
+
+		assert.Equal(t, http.StatusInternalServerError, res.Code)
+		assert.Equal(t, CodeInternal, decodeProblem(t, res).Code)
 	})
 
-	t.Run("should handle assistant service error", func(t *testing.T) {
+	t.Run("should update only provided fields (partial update)", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockTaskService := task.NewMockService(ctrl)
 		mockAssistantService := assistant.NewMockService(ctrl)
 		handler := NewHandler(mockTaskService, mockAssistantService)
 
-		input := ChatInput{
-			Text: "Invalid assistant",
+		taskID := "task-123"
+		input := TaskInput{
+			Title: "Partial Update",
 		}
-
-		body, err := json.Marshal(input)
+		inputBytes, err := json.Marshal(input)
 		require.NoError(t, err)
 
-		req := httptest.NewRequest(http.MethodPost, "/chat", bytes.NewReader(body))
-		w := httptest.NewRecorder()
+		updated := &task.Task{
+			ID:    taskID,
+			Title: input.Title,
+		}
+		mockTaskService.EXPECT().Update(gomock.Any(), taskID, match.PtrTo(task.Task{
+			Title: input.Title,
+		})).Return(updated, nil)
 
-		mockAssistantService.EXPECT().Chat(req.Context(), input.Text).Return("", errors.New("failed to process assistant"))
+		mockTaskService.EXPECT().Progress(gomock.Any(), gomock.Any()).Return(map[string]task.Progress{}, nil)
 
-		handler.Chat(w, req)
+		req := httptest.NewRequest(http.MethodPatch, "/tasks/"+taskID, bytes.NewReader(inputBytes))
+		req.Header.Set("Content-Type", "application/json")
+		req.SetPathValue("id", taskID)
+		res := httptest.NewRecorder()
+		handler.Update(res, req)
 
-		assert.Equal(t, http.StatusBadRequest, w.Code)
-		assert.Contains(t, w.Body.String(), "failed to process assistant")
-	})
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		var response Task
+		err = json.Unmarshal(res.Body.Bytes(), &response)
+		require.NoError(t, err)
+
+		assert.Equal(t, updated.ID, response.ID)
+		assert.Equal(t, updated.Title, response.Title)
+	})
+
+	t.Run("should update task with version of If-Match", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		mockTaskService.EXPECT().Get(gomock.Any(), "task-123").Return(&task.Task{ID: "task-123", Version: 4}, nil)
+		mockTaskService.EXPECT().Update(gomock.Any(), "task-123", match.PtrTo(task.Task{Title: "New Title", Version: 4})).
+			Return(&task.Task{ID: "task-123", Title: "New Title", Version: 5}, nil)
+
+		mockTaskService.EXPECT().Progress(gomock.Any(), gomock.Any()).Return(map[string]task.Progress{}, nil)
+
+		req := httptest.NewRequest(http.MethodPatch, "/tasks/task-123", strings.NewReader(`{"title":"New Title"}`))
+		req.Header.Set("If-Match", `"4"`)
+		req.SetPathValue("id", "task-123")
+		res := httptest.NewRecorder()
+		handler.Update(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, `"5"`, res.Header().Get("ETag"))
+	})
+
+	t.Run("should return precondition failed when If-Match is stale", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		mockTaskService.EXPECT().Get(gomock.Any(), "task-123").Return(&task.Task{ID: "task-123", Version: 5}, nil)
+
+		req := httptest.NewRequest(http.MethodPatch, "/tasks/task-123", strings.NewReader(`{"title":"New Title"}`))
+		req.Header.Set("If-Match", `"4"`)
+		req.SetPathValue("id", "task-123")
+		res := httptest.NewRecorder()
+		handler.Update(res, req)
+
+		assert.Equal(t, http.StatusPreconditionFailed, res.Code)
+		assert.Equal(t, CodePreconditionFailed, decodeProblem(t, res).Code)
+	})
+
+	t.Run("should return precondition failed when task changes after If-Match is checked", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		mockTaskService.EXPECT().Get(gomock.Any(), "task-123").Return(&task.Task{ID: "task-123", Version: 4}, nil)
+		mockTaskService.EXPECT().Update(gomock.Any(), "task-123", gomock.Any()).
+			Return(nil, fmt.Errorf("error updating task: %w", task.ErrConflict))
+
+		req := httptest.NewRequest(http.MethodPatch, "/tasks/task-123", strings.NewReader(`{"title":"New Title"}`))
+		req.Header.Set("If-Match", `"4"`)
+		req.SetPathValue("id", "task-123")
+		res := httptest.NewRecorder()
+		handler.Update(res, req)
+
+		assert.Equal(t, http.StatusPreconditionFailed, res.Code)
+	})
+
+	t.Run("should return conflict when task is modified concurrently", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		mockTaskService.EXPECT().Update(gomock.Any(), "task-123", gomock.Any()).
+			Return(nil, fmt.Errorf("error updating task: %w", task.ErrConflict))
+
+		req := httptest.NewRequest(http.MethodPatch, "/tasks/task-123", strings.NewReader(`{"title":"New Title"}`))
+		req.SetPathValue("id", "task-123")
+		res := httptest.NewRecorder()
+		handler.Update(res, req)
+
+		assert.Equal(t, http.StatusConflict, res.Code)
+		assert.Equal(t, CodeVersionConflict, decodeProblem(t, res).Code)
+	})
+
+	t.Run("should return conflict when completed task is blocked", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, assistant.NewMockService(ctrl))
+
+		mockTaskService.EXPECT().Update(gomock.Any(), "task-123", match.PtrTo(&task.Task{Status: task.StatusCompleted})).
+			Return(nil, fmt.Errorf("error updating task: %w by task-122", task.ErrBlocked))
+
+		req := httptest.NewRequest(http.MethodPatch, "/tasks/task-123", strings.NewReader(`{"status":"COMPLETED"}`))
+		req.SetPathValue("id", "task-123")
+		res := httptest.NewRecorder()
+		handler.Update(res, req)
+
+		assert.Equal(t, http.StatusConflict, res.Code)
+		assert.Equal(t, CodeTaskBlocked, decodeProblem(t, res).Code)
+	})
+}
+
+func TestHandler_Delete(t *testing.T) {
+	t.Run("should delete task with valid ID", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		taskID := "task-123"
+		mockTaskService.EXPECT().Delete(gomock.Any(), taskID, task.DeleteOptions{}).Return(nil)
+
+		req := httptest.NewRequest(http.MethodDelete, "/tasks/"+taskID, nil)
+		req.SetPathValue("id", taskID)
+		res := httptest.NewRecorder()
+		handler.Delete(res, req)
+
+		assert.Equal(t, http.StatusNoContent, res.Code)
+		assert.Empty(t, res.Body.String())
+	})
+
+	t.Run("should return bad request with missing ID", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		req := httptest.NewRequest(http.MethodDelete, "/tasks/", nil)
+		res := httptest.NewRecorder()
+		handler.Delete(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, CodeValidationFailed, decodeProblem(t, res).Code)
+	})
+
+	t.Run("should return not found when task doesn't exist", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		taskID := "non-existent"
+		mockTaskService.EXPECT().Delete(gomock.Any(), taskID, task.DeleteOptions{}).Return(task.ErrTaskNotFound)
+
+		req := httptest.NewRequest(http.MethodDelete, "/tasks/"+taskID, nil)
+		req.SetPathValue("id", taskID)
+		res := httptest.NewRecorder()
+		handler.Delete(res, req)
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.Equal(t, CodeTaskNotFound, decodeProblem(t, res).Code)
+	})
+
+	t.Run("should return internal server error when service returns unexpected error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		taskID := "task-err"
+		mockTaskService.EXPECT().Delete(gomock.Any(), taskID, task.DeleteOptions{}).Return(fmt.Errorf("unexpected error"))
+
+		req := httptest.NewRequest(http.MethodDelete, "/tasks/"+taskID, nil)
+		req.SetPathValue("id", taskID)
+		res := httptest.NewRecorder()
+		handler.Delete(res, req)
+
+		assert.Equal(t, http.StatusInternalServerError, res.Code)
+		assert.Equal(t, CodeInternal, decodeProblem(t, res).Code)
+	})
+
+	t.Run("should delete task with version of If-Match", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		mockTaskService.EXPECT().Get(gomock.Any(), "task-123").Return(&task.Task{ID: "task-123", Version: 2}, nil)
+		mockTaskService.EXPECT().Delete(gomock.Any(), "task-123", task.DeleteOptions{Version: 2}).Return(nil)
+
+		req := httptest.NewRequest(http.MethodDelete, "/tasks/task-123", nil)
+		req.Header.Set("If-Match", `"1", "2"`)
+		req.SetPathValue("id", "task-123")
+		res := httptest.NewRecorder()
+		handler.Delete(res, req)
+
+		assert.Equal(t, http.StatusNoContent, res.Code)
+	})
+
+	t.Run("should return precondition failed when If-None-Match matches", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		mockTaskService.EXPECT().Get(gomock.Any(), "task-123").Return(&task.Task{ID: "task-123", Version: 2}, nil)
+
+		req := httptest.NewRequest(http.MethodDelete, "/tasks/task-123", nil)
+		req.Header.Set("If-None-Match", "*")
+		req.SetPathValue("id", "task-123")
+		res := httptest.NewRecorder()
+		handler.Delete(res, req)
+
+		assert.Equal(t, http.StatusPreconditionFailed, res.Code)
+	})
+
+	t.Run("should pass delete mode to service", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		mockTaskService.EXPECT().Delete(gomock.Any(), "task-123", task.DeleteOptions{Mode: task.DeleteCascade}).Return(nil)
+
+		req := httptest.NewRequest(http.MethodDelete, "/tasks/task-123?mode=cascade", nil)
+		req.SetPathValue("id", "task-123")
+		res := httptest.NewRecorder()
+		handler.Delete(res, req)
+
+		assert.Equal(t, http.StatusNoContent, res.Code)
+	})
+
+	t.Run("should return bad request with unknown delete mode", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		req := httptest.NewRequest(http.MethodDelete, "/tasks/task-123?mode=purge", nil)
+		req.SetPathValue("id", "task-123")
+		res := httptest.NewRecorder()
+		handler.Delete(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		problem := decodeProblem(t, res)
+		assert.Equal(t, CodeValidationFailed, problem.Code)
+		assert.Equal(t, "mode", problem.Errors[0].Field)
+	})
+
+	t.Run("should return conflict when task has subtasks", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		mockTaskService.EXPECT().Delete(gomock.Any(), "task-123", task.DeleteOptions{}).
+			Return(fmt.Errorf("error deleting task: %w", task.ErrHasSubtasks))
+
+		req := httptest.NewRequest(http.MethodDelete, "/tasks/task-123", nil)
+		req.SetPathValue("id", "task-123")
+		res := httptest.NewRecorder()
+		handler.Delete(res, req)
+
+		assert.Equal(t, http.StatusConflict, res.Code)
+		assert.Equal(t, CodeHasSubtasks, decodeProblem(t, res).Code)
+	})
+}
+
+func TestHandler_Batch(t *testing.T) {
+	t.Run("should apply operations and return their results", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		mockTaskService.EXPECT().Batch(gomock.Any(), []task.Operation{
+			{Type: task.ChangeCreate, Ref: "review", Task: &task.Task{Title: "Review report", Priority: util.Ptr(task.PriorityHigh)}},
+			{Type: task.ChangeUpdate, TaskID: "TASK-000001", Task: &task.Task{BlockedBy: []string{"review"}, Version: 2}},
+			{Type: task.ChangeDelete, TaskID: "TASK-000002", DeleteOptions: task.DeleteOptions{Version: 1, Mode: task.DeleteCascade}},
+		}).Return([]task.Change{
+			{Type: task.ChangeCreate, TaskID: "TASK-000003", After: &task.Task{ID: "TASK-000003", Title: "Review report", Version: 1}},
+			{Type: task.ChangeUpdate, TaskID: "TASK-000001", After: &task.Task{ID: "TASK-000001", Title: "Write report", BlockedBy: []string{"TASK-000003"}, Version: 3}},
+			{Type: task.ChangeDelete, TaskID: "TASK-000002", Before: &task.Task{ID: "TASK-000002"}},
+		}, nil)
+		mockTaskService.EXPECT().Progress(gomock.Any(), []string{"TASK-000003", "TASK-000001"}).Return(map[string]task.Progress{}, nil)
+
+		body := `{"operations":[` +
+			`{"op":"create","ref":"review","task":{"title":"Review report","priority":"HIGH"}},` +
+			`{"op":"update","id":"TASK-000001","task":{"blockedBy":["review"]},"version":2},` +
+			`{"op":"delete","id":"TASK-000002","version":1,"mode":"cascade"}]}`
+		req := httptest.NewRequest(http.MethodPost, "/tasks:batch", strings.NewReader(body))
+		res := httptest.NewRecorder()
+		handler.Batch(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		var results []BatchResult
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&results))
+		require.Len(t, results, 3)
+		assert.Equal(t, "TASK-000003", results[0].Task.ID)
+		assert.Equal(t, []string{"TASK-000003"}, results[1].Task.BlockedBy)
+		assert.Equal(t, BatchResult{Op: task.ChangeDelete, ID: "TASK-000002"}, results[2])
+	})
+
+	t.Run("should return bad request with invalid operations", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		body := `{"operations":[{"op":"create","task":{"title":""}},{"op":"delete","mode":"purge"}]}`
+		req := httptest.NewRequest(http.MethodPost, "/tasks:batch", strings.NewReader(body))
+		res := httptest.NewRecorder()
+		handler.Batch(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		problem := decodeProblem(t, res)
+		assert.Equal(t, CodeValidationFailed, problem.Code)
+		assert.Equal(t, []string{"operations[0].task.title", "operations[1].id", "operations[1].mode"},
+			util.Map(problem.Errors, func(e FieldError) string { return e.Field }))
+	})
+
+	t.Run("should return error with index of failed operation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		mockTaskService.EXPECT().Batch(gomock.Any(), gomock.Any()).
+			Return(nil, fmt.Errorf("error applying batch: %w", &task.BatchError{Index: 1, Err: fmt.Errorf("error deleting task: %w", task.ErrHasSubtasks)}))
+
+		body := `{"operations":[{"op":"create","task":{"title":"Review report"}},{"op":"delete","id":"TASK-000001"}]}`
+		req := httptest.NewRequest(http.MethodPost, "/tasks:batch", strings.NewReader(body))
+		res := httptest.NewRecorder()
+		handler.Batch(res, req)
+
+		assert.Equal(t, http.StatusConflict, res.Code)
+		problem := decodeProblem(t, res)
+		assert.Equal(t, CodeHasSubtasks, problem.Code)
+		assert.Equal(t, util.Ptr(1), problem.Operation)
+	})
+
+	t.Run("should return not found without redirect when task was moved", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		mockTaskService.EXPECT().Batch(gomock.Any(), gomock.Any()).
+			Return(nil, fmt.Errorf("error applying batch: %w", &task.BatchError{Index: 0, Err: &task.MovedError{ID: "TASK-000001", MovedTo: "WEB-1"}}))
+
+		body := `{"operations":[{"op":"delete","id":"TASK-000001"}]}`
+		req := httptest.NewRequest(http.MethodPost, "/tasks:batch", strings.NewReader(body))
+		res := httptest.NewRecorder()
+		handler.Batch(res, req)
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.Empty(t, res.Header().Get("Location"))
+		problem := decodeProblem(t, res)
+		assert.Equal(t, "task not found: TASK-000001 is now WEB-1", problem.Detail)
+		assert.Equal(t, util.Ptr(0), problem.Operation)
+	})
+}
+
+func TestHandler_Move(t *testing.T) {
+	t.Run("should return moved task with its new location", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		mockTaskService.EXPECT().Move(gomock.Any(), "TASK-000001", "web").
+			Return(&task.Task{ID: "WEB-000001", Project: "WEB", Title: "Landing page", Status: task.StatusNotStarted, Version: 1}, nil)
+		mockTaskService.EXPECT().Progress(gomock.Any(), []string{"WEB-000001"}).Return(map[string]task.Progress{}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/tasks/TASK-000001/move", strings.NewReader(`{"project":"web"}`))
+		req.SetPathValue("id", "TASK-000001")
+		res := httptest.NewRecorder()
+		handler.Move(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "/tasks/WEB-000001", res.Header().Get("Location"))
+		assert.Equal(t, `"1"`, res.Header().Get("ETag"))
+
+		var response Task
+		require.NoError(t, json.Unmarshal(res.Body.Bytes(), &response))
+		assert.Equal(t, "WEB-000001", response.ID)
+		assert.Equal(t, "WEB", response.Project)
+	})
+
+	t.Run("should return bad request when project key is invalid", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		handler := NewHandler(task.NewMockService(ctrl), assistant.NewMockService(ctrl))
+
+		req := httptest.NewRequest(http.MethodPost, "/tasks/TASK-000001/move", strings.NewReader(`{"project":"web-app"}`))
+		req.SetPathValue("id", "TASK-000001")
+		res := httptest.NewRecorder()
+		handler.Move(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		problem := decodeProblem(t, res)
+		assert.Equal(t, CodeValidationFailed, problem.Code)
+		assert.Equal(t, "project", problem.Errors[0].Field)
+	})
+
+	t.Run("should return not found when project doesn't exist", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, assistant.NewMockService(ctrl))
+
+		mockTaskService.EXPECT().Move(gomock.Any(), "TASK-000001", "OPS").
+			Return(nil, fmt.Errorf("error moving task: %w", fmt.Errorf("%w: OPS", task.ErrProjectNotFound)))
+
+		req := httptest.NewRequest(http.MethodPost, "/tasks/TASK-000001/move", strings.NewReader(`{"project":"OPS"}`))
+		req.SetPathValue("id", "TASK-000001")
+		res := httptest.NewRecorder()
+		handler.Move(res, req)
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		problem := decodeProblem(t, res)
+		assert.Equal(t, CodeProjectNotFound, problem.Code)
+		assert.Equal(t, "project not found: OPS", problem.Detail)
+	})
+}
+
+func TestHandler_ListSubtasks(t *testing.T) {
+	t.Run("should return subtasks with their progress", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		subtasks := []*task.Task{
+			{ID: "task-2", Title: "Write changelog", Status: task.StatusCompleted, ParentID: util.Ptr("task-1")},
+			{ID: "task-3", Title: "Tag release", Status: task.StatusNotStarted, ParentID: util.Ptr("task-1")},
+		}
+		mockTaskService.EXPECT().Get(gomock.Any(), "task-1").Return(&task.Task{ID: "task-1"}, nil)
+		mockTaskService.EXPECT().List(gomock.Any(), task.ListOptions{
+			Filter: task.Filter{Statuses: []task.Status{task.StatusCompleted, task.StatusNotStarted}, Parents: []string{"task-1"}},
+			Limit:  100,
+		}).Return(&task.Page{Tasks: subtasks, Total: 2}, nil)
+		mockTaskService.EXPECT().Progress(gomock.Any(), []string{"task-2", "task-3"}).
+			Return(map[string]task.Progress{"task-3": {Total: 2, Completed: 1}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/tasks/task-1/subtasks?status=COMPLETED,NOT_STARTED", nil)
+		req.SetPathValue("id", "task-1")
+		res := httptest.NewRecorder()
+		handler.ListSubtasks(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "2", res.Header().Get("X-Total-Count"))
+
+		var response []Task
+		require.NoError(t, json.Unmarshal(res.Body.Bytes(), &response))
+		require.Len(t, response, 2)
+		assert.Equal(t, util.Ptr("task-1"), response[0].ParentID)
+		assert.Nil(t, response[0].Progress)
+		assert.Equal(t, &TaskProgress{Total: 2, Completed: 1, Percent: 50}, response[1].Progress)
+	})
+
+	t.Run("should return not found when task doesn't exist", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		mockTaskService.EXPECT().Get(gomock.Any(), "task-404").Return(nil, task.ErrTaskNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/tasks/task-404/subtasks", nil)
+		req.SetPathValue("id", "task-404")
+		res := httptest.NewRecorder()
+		handler.ListSubtasks(res, req)
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.Equal(t, CodeTaskNotFound, decodeProblem(t, res).Code)
+	})
+}
+
+func TestHandler_Chat(t *testing.T) {
+	t.Run("should process assistant successfully", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		input := ChatInput{
+			Text: "Create a new task to finish the report",
+		}
+
+		body, err := json.Marshal(input)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/chat", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		mockAssistantService.EXPECT().Chat(req.Context(), assistant.Request{Message: input.Text}).Return(&assistant.Reply{
+			SessionID: "session-1",
+			Response:  "Task created: TASK-123",
+		}, nil)
+
+		handler.Chat(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response ChatResponse
+		err = json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+
+		assert.Contains(t, response.Response, "TASK-123")
+		assert.Equal(t, "session-1", response.SessionID)
+	})
+
+	t.Run("should continue existing session", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		input := ChatInput{
+			SessionID: "session-1",
+			Text:      "Mark it as high priority",
+		}
+
+		body, err := json.Marshal(input)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/chat", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		mockAssistantService.EXPECT().Chat(req.Context(), assistant.Request{SessionID: "session-1", Message: input.Text}).Return(&assistant.Reply{
+			SessionID: "session-1",
+			Response:  "Done",
+		}, nil)
+
+		handler.Chat(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response ChatResponse
+		err = json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+
+		assert.Equal(t, ChatResponse{SessionID: "session-1", Response: "Done"}, response)
+	})
+
+	t.Run("should handle empty assistant", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		input := ChatInput{
+			Text: "",
+		}
+		body, err := json.Marshal(input)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/chat", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+		handler.Chat(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, CodeValidationFailed, decodeProblem(t, w).Code)
+	})
+
+	t.Run("should handle assistant service error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		input := ChatInput{
+			Text: "Invalid assistant",
+		}
+
+		body, err := json.Marshal(input)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/chat", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		mockAssistantService.EXPECT().Chat(req.Context(), assistant.Request{Message: input.Text}).Return(nil, errors.New("failed to process assistant"))
+
+		handler.Chat(w, req)
+
+		assert.Equal(t, http.StatusBadGateway, w.Code)
+		problem := decodeProblem(t, w)
+		assert.Equal(t, CodeAssistantFailed, problem.Code)
+		assert.Equal(t, "assistant failed to process the message: failed to process assistant", problem.Detail)
+	})
+
+	t.Run("should return staged changes in preview mode", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		input := ChatInput{
+			SessionID: "session-1",
+			Text:      "Create a task to buy milk",
+			Preview:   true,
+		}
+
+		body, err := json.Marshal(input)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/chat", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		now := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+		staged := &task.Task{ID: "STAGED-1", Title: "Buy milk", Status: task.StatusNotStarted, CreatedAt: now, UpdatedAt: now}
+		mockAssistantService.EXPECT().Chat(req.Context(), assistant.Request{SessionID: "session-1", Message: input.Text, Preview: true}).Return(&assistant.Reply{
+			SessionID: "session-1",
+			Response:  "I will create the task once you confirm",
+			Changes:   []task.Change{{Type: task.ChangeCreate, TaskID: "STAGED-1", After: staged}},
+		}, nil)
+
+		handler.Chat(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response ChatResponse
+		err = json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+
+		assert.Equal(t, []ChatChange{{
+			Type:   task.ChangeCreate,
+			TaskID: "STAGED-1",
+			After:  util.Ptr(mapTaskToResponse(staged)),
+			Diff: []FieldChange{
+				{Field: "title", Before: nil, After: "Buy milk"},
+				{Field: "status", Before: nil, After: "NOT_STARTED"},
+			},
+		}}, response.Changes)
+	})
+}
+
+func TestHandler_ChatStream(t *testing.T) {
+	t.Run("should stream events when stream query parameter is set", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		body, err := json.Marshal(ChatInput{SessionID: "session-1", Text: "Create a task"})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/chat?stream=true", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		call := &pkgassistant.ToolCall{ID: "1", Name: "create_task", Arguments: `{"title":"Report"}`}
+		mockAssistantService.EXPECT().ChatStream(gomock.Any(), assistant.Request{SessionID: "session-1", Message: "Create a task"}, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ assistant.Request, emit pkgassistant.EmitFunc) (*assistant.Reply, error) {
+				require.NoError(t, emit(pkgassistant.Event{Type: pkgassistant.EventToolCallStart, ToolCall: call}))
+				require.NoError(t, emit(pkgassistant.Event{Type: pkgassistant.EventToolCallFinish, ToolCall: call, Content: `{"id":"TASK-1"}`}))
+				require.NoError(t, emit(pkgassistant.Event{Type: pkgassistant.EventDelta, Content: "Created "}))
+				require.NoError(t, emit(pkgassistant.Event{Type: pkgassistant.EventDelta, Content: "TASK-1"}))
+				return &assistant.Reply{SessionID: "session-1", Response: "Created TASK-1"}, nil
+			})
+
+		handler.Chat(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+		assert.True(t, w.Flushed)
+
+		toolCall := `{"id":"1","name":"create_task","arguments":"{\"title\":\"Report\"}"}`
+		expected := "event: tool_call_start\ndata: {\"toolCall\":" + toolCall + "}\n\n" +
+			"event: tool_call_finish\ndata: " + `{"content":"{\"id\":\"TASK-1\"}","toolCall":` + toolCall + "}\n\n" +
+			"event: delta\ndata: {\"content\":\"Created \"}\n\n" +
+			"event: delta\ndata: {\"content\":\"TASK-1\"}\n\n" +
+			"event: message\ndata: {\"sessionId\":\"session-1\",\"response\":\"Created TASK-1\"}\n\n"
+		assert.Equal(t, expected, w.Body.String())
+	})
+
+	t.Run("should stream events when request accepts event stream", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		body, err := json.Marshal(ChatInput{Text: "Hello"})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/chat", bytes.NewReader(body))
+		req.Header.Set("Accept", "text/event-stream")
+		w := httptest.NewRecorder()
+
+		mockAssistantService.EXPECT().ChatStream(gomock.Any(), assistant.Request{Message: "Hello"}, gomock.Any()).
+			Return(&assistant.Reply{SessionID: "session-2", Response: "Hi"}, nil)
+
+		handler.Chat(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "event: message\ndata: {\"sessionId\":\"session-2\",\"response\":\"Hi\"}\n\n", w.Body.String())
+	})
+
+	t.Run("should not stream when stream query parameter is false", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		body, err := json.Marshal(ChatInput{Text: "Hello"})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/chat?stream=false", bytes.NewReader(body))
+		req.Header.Set("Accept", "text/event-stream")
+		w := httptest.NewRecorder()
+
+		mockAssistantService.EXPECT().Chat(gomock.Any(), assistant.Request{Message: "Hello"}).
+			Return(&assistant.Reply{SessionID: "session-2", Response: "Hi"}, nil)
+
+		handler.Chat(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	})
+
+	t.Run("should send error event when assistant fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		body, err := json.Marshal(ChatInput{Text: "Hello"})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/chat?stream=true", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		mockAssistantService.EXPECT().ChatStream(gomock.Any(), assistant.Request{Message: "Hello"}, gomock.Any()).
+			Return(nil, errors.New("assistant failed"))
+
+		handler.Chat(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "event: error\ndata: "+
+			`{"type":"urn:task-master:problem:ASSISTANT_FAILED","title":"Assistant failed","status":502,`+
+			`"detail":"assistant failed to process the message: assistant failed","instance":"/chat","code":"ASSISTANT_FAILED"}`+
+			"\n\n", w.Body.String())
+	})
+
+	t.Run("should return bad request before streaming when text is empty", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		req := httptest.NewRequest(http.MethodPost, "/chat?stream=true", bytes.NewReader([]byte(`{"text":""}`)))
+		w := httptest.NewRecorder()
+
+		handler.Chat(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestHandler_GetChatSession(t *testing.T) {
+	t.Run("should return session with messages", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		createdAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		session := &pkgassistant.Session{
+			ID: "session-1",
+			Messages: []pkgassistant.Message{
+				{Role: pkgassistant.RoleUser, Content: "List my tasks"},
+				{Role: pkgassistant.RoleAssistant, ToolCalls: []pkgassistant.ToolCall{{ID: "1", Name: "list_tasks", Arguments: "{}"}}},
+				{Role: pkgassistant.RoleTool, Content: "[]", ToolCallID: "1"},
+				{Role: pkgassistant.RoleAssistant, Content: "You have no tasks"},
+			},
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/chat/session-1", nil)
+		req.SetPathValue("sessionId", "session-1")
+		res := httptest.NewRecorder()
+
+		mockAssistantService.EXPECT().GetSession(req.Context(), "session-1").Return(session, nil)
+
+		handler.GetChatSession(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		var response ChatSession
+		err := json.Unmarshal(res.Body.Bytes(), &response)
+		require.NoError(t, err)
+
+		expected := ChatSession{
+			ID: "session-1",
+			Messages: []ChatMessage{
+				{Role: "user", Content: "List my tasks"},
+				{Role: "assistant", ToolCalls: []ChatToolCall{{ID: "1", Name: "list_tasks", Arguments: "{}"}}},
+				{Role: "tool", Content: "[]", ToolCallID: "1"},
+				{Role: "assistant", Content: "You have no tasks"},
+			},
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		}
+		assert.Equal(t, expected, response)
+	})
+
+	t.Run("should return bad request with missing session ID", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		req := httptest.NewRequest(http.MethodGet, "/chat/", nil)
+		res := httptest.NewRecorder()
+		handler.GetChatSession(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, CodeValidationFailed, decodeProblem(t, res).Code)
+	})
+
+	t.Run("should return not found when session doesn't exist", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		req := httptest.NewRequest(http.MethodGet, "/chat/unknown", nil)
+		req.SetPathValue("sessionId", "unknown")
+		res := httptest.NewRecorder()
+
+		mockAssistantService.EXPECT().GetSession(req.Context(), "unknown").Return(nil, assistant.ErrSessionNotFound)
+
+		handler.GetChatSession(res, req)
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.Equal(t, CodeSessionNotFound, decodeProblem(t, res).Code)
+	})
+}
+
+func TestHandler_DeleteChatSession(t *testing.T) {
+	t.Run("should delete session", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		req := httptest.NewRequest(http.MethodDelete, "/chat/session-1", nil)
+		req.SetPathValue("sessionId", "session-1")
+		res := httptest.NewRecorder()
+
+		mockAssistantService.EXPECT().DeleteSession(req.Context(), "session-1").Return(nil)
+
+		handler.DeleteChatSession(res, req)
+
+		assert.Equal(t, http.StatusNoContent, res.Code)
+		assert.Empty(t, res.Body.String())
+	})
+
+	t.Run("should return not found when session doesn't exist", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		req := httptest.NewRequest(http.MethodDelete, "/chat/unknown", nil)
+		req.SetPathValue("sessionId", "unknown")
+		res := httptest.NewRecorder()
+
+		mockAssistantService.EXPECT().DeleteSession(req.Context(), "unknown").Return(assistant.ErrSessionNotFound)
+
+		handler.DeleteChatSession(res, req)
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.Equal(t, CodeSessionNotFound, decodeProblem(t, res).Code)
+	})
+}
+
+func TestHandler_GetChatChanges(t *testing.T) {
+	t.Run("should return staged changes", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		req := httptest.NewRequest(http.MethodGet, "/chat/session-1/changes", nil)
+		req.SetPathValue("sessionId", "session-1")
+		res := httptest.NewRecorder()
+
+		before := &task.Task{ID: "TASK-000001", Title: "Write report", Status: task.StatusNotStarted}
+		mockAssistantService.EXPECT().Changes(req.Context(), "session-1").Return([]task.Change{
+			{Type: task.ChangeDelete, TaskID: "TASK-000001", Before: before},
+		}, nil)
+
+		handler.GetChatChanges(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		var response []ChatChange
+		err := json.Unmarshal(res.Body.Bytes(), &response)
+		require.NoError(t, err)
+
+		assert.Equal(t, []ChatChange{{
+			Type:   task.ChangeDelete,
+			TaskID: "TASK-000001",
+			Before: util.Ptr(mapTaskToResponse(before)),
+			Diff: []FieldChange{
+				{Field: "title", Before: "Write report", After: nil},
+				{Field: "status", Before: "NOT_STARTED", After: nil},
+			},
+		}}, response)
+	})
+
+	t.Run("should return empty list when nothing is staged", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		req := httptest.NewRequest(http.MethodGet, "/chat/session-1/changes", nil)
+		req.SetPathValue("sessionId", "session-1")
+		res := httptest.NewRecorder()
+
+		mockAssistantService.EXPECT().Changes(req.Context(), "session-1").Return(nil, nil)
+
+		handler.GetChatChanges(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, "[]", res.Body.String())
+	})
+
+	t.Run("should return not found when session doesn't exist", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		req := httptest.NewRequest(http.MethodGet, "/chat/unknown/changes", nil)
+		req.SetPathValue("sessionId", "unknown")
+		res := httptest.NewRecorder()
+
+		mockAssistantService.EXPECT().Changes(req.Context(), "unknown").Return(nil, assistant.ErrSessionNotFound)
+
+		handler.GetChatChanges(res, req)
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.Equal(t, CodeSessionNotFound, decodeProblem(t, res).Code)
+	})
+}
+
+func TestHandler_CommitChatChanges(t *testing.T) {
+	t.Run("should return committed changes", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		req := httptest.NewRequest(http.MethodPost, "/chat/session-1/commit", nil)
+		req.SetPathValue("sessionId", "session-1")
+		res := httptest.NewRecorder()
+
+		created := &task.Task{ID: "TASK-000002", Title: "Buy milk", Status: task.StatusNotStarted}
+		mockAssistantService.EXPECT().Commit(req.Context(), "session-1").Return([]task.Change{
+			{Type: task.ChangeCreate, TaskID: "TASK-000002", After: created},
+		}, nil)
+
+		handler.CommitChatChanges(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		var response []ChatChange
+		err := json.Unmarshal(res.Body.Bytes(), &response)
+		require.NoError(t, err)
+
+		require.Len(t, response, 1)
+		assert.Equal(t, "TASK-000002", response[0].TaskID)
+		assert.Equal(t, util.Ptr(mapTaskToResponse(created)), response[0].After)
+	})
+
+	t.Run("should return conflict when a task changed after staging", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		req := httptest.NewRequest(http.MethodPost, "/chat/session-1/commit", nil)
+		req.SetPathValue("sessionId", "session-1")
+		res := httptest.NewRecorder()
+
+		mockAssistantService.EXPECT().Commit(req.Context(), "session-1").Return(nil, fmt.Errorf("error committing changes: %w", task.ErrStaleChange))
+
+		handler.CommitChatChanges(res, req)
+
+		assert.Equal(t, http.StatusConflict, res.Code)
+		assert.Equal(t, CodeStaleChange, decodeProblem(t, res).Code)
+	})
+
+	t.Run("should return not found when session doesn't exist", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		req := httptest.NewRequest(http.MethodPost, "/chat/unknown/commit", nil)
+		req.SetPathValue("sessionId", "unknown")
+		res := httptest.NewRecorder()
+
+		mockAssistantService.EXPECT().Commit(req.Context(), "unknown").Return(nil, assistant.ErrSessionNotFound)
+
+		handler.CommitChatChanges(res, req)
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.Equal(t, CodeSessionNotFound, decodeProblem(t, res).Code)
+	})
+}
+
+func TestHandler_DiscardChatChanges(t *testing.T) {
+	t.Run("should discard staged changes", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		req := httptest.NewRequest(http.MethodDelete, "/chat/session-1/changes", nil)
+		req.SetPathValue("sessionId", "session-1")
+		res := httptest.NewRecorder()
+
+		mockAssistantService.EXPECT().Discard(req.Context(), "session-1").Return(nil)
+
+		handler.DiscardChatChanges(res, req)
+
+		assert.Equal(t, http.StatusNoContent, res.Code)
+		assert.Empty(t, res.Body.String())
+	})
+
+	t.Run("should return not found when session doesn't exist", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistantService := assistant.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, mockAssistantService)
+
+		req := httptest.NewRequest(http.MethodDelete, "/chat/unknown/changes", nil)
+		req.SetPathValue("sessionId", "unknown")
+		res := httptest.NewRecorder()
+
+		mockAssistantService.EXPECT().Discard(req.Context(), "unknown").Return(assistant.ErrSessionNotFound)
+
+		handler.DiscardChatChanges(res, req)
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.Equal(t, CodeSessionNotFound, decodeProblem(t, res).Code)
+	})
+}
+
+func TestHandler_History(t *testing.T) {
+	t.Run("should return changes of task", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, assistant.NewMockService(ctrl))
+
+		occurredAt := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+		mockTaskService.EXPECT().History(gomock.Any(), "task-1").Return([]task.Event{
+			{Sequence: 1, TaskID: "task-1", Type: task.EventCreated, Version: 1, Actor: "alice", OccurredAt: occurredAt,
+				Changes: []task.FieldChange{{Field: "title", After: "Design"}}},
+			{Sequence: 3, TaskID: "task-1", Type: task.EventStatusChanged, Version: 2, OccurredAt: occurredAt.Add(time.Hour),
+				Changes: []task.FieldChange{{Field: "status", Before: task.StatusNotStarted, After: task.StatusInProgress}}},
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/tasks/task-1/history", nil)
+		req.SetPathValue("id", "task-1")
+		res := httptest.NewRecorder()
+		handler.History(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, `[
+			{"sequence":1,"type":"TASK_CREATED","version":1,"actor":"alice","occurredAt":"2025-05-01T09:00:00Z",
+				"changes":[{"field":"title","before":null,"after":"Design"}]},
+			{"sequence":3,"type":"STATUS_CHANGED","version":2,"actor":"","occurredAt":"2025-05-01T10:00:00Z",
+				"changes":[{"field":"status","before":"NOT_STARTED","after":"IN_PROGRESS"}]}
+		]`, res.Body.String())
+	})
+
+	t.Run("should return not found when task never existed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, assistant.NewMockService(ctrl))
+
+		mockTaskService.EXPECT().History(gomock.Any(), "unknown").Return(nil, task.ErrTaskNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/tasks/unknown/history", nil)
+		req.SetPathValue("id", "unknown")
+		res := httptest.NewRecorder()
+		handler.History(res, req)
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.Equal(t, CodeTaskNotFound, decodeProblem(t, res).Code)
+	})
+
+	t.Run("should return not implemented when history is not recorded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, assistant.NewMockService(ctrl))
+
+		mockTaskService.EXPECT().History(gomock.Any(), "task-1").Return(nil, task.ErrHistoryUnavailable)
+
+		req := httptest.NewRequest(http.MethodGet, "/tasks/task-1/history", nil)
+		req.SetPathValue("id", "task-1")
+		res := httptest.NewRecorder()
+		handler.History(res, req)
+
+		assert.Equal(t, http.StatusNotImplemented, res.Code)
+		assert.Equal(t, CodeHistoryUnavailable, decodeProblem(t, res).Code)
+	})
+}
+
+func TestHandler_Order(t *testing.T) {
+	t.Run("should return execution order and critical path", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, assistant.NewMockService(ctrl))
+
+		design := &task.Task{ID: "task-1", Title: "Design", Status: task.StatusInProgress}
+		deploy := &task.Task{ID: "task-2", Title: "Deploy", Status: task.StatusBlocked, BlockedBy: []string{"task-1"}, DueDate: util.Ptr(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC))}
+		mockTaskService.EXPECT().Plan(gomock.Any()).Return(&task.Plan{Order: []*task.Task{design, deploy}, CriticalPath: []*task.Task{design, deploy}}, nil)
+		mockTaskService.EXPECT().Progress(gomock.Any(), []string{"task-1", "task-2"}).Return(map[string]task.Progress{}, nil).Times(2)
+
+		req := httptest.NewRequest(http.MethodGet, "/tasks/order", nil)
+		res := httptest.NewRecorder()
+		handler.Order(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		var response ExecutionPlan
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&response))
+		require.Len(t, response.Order, 2)
+		assert.Equal(t, "task-1", response.Order[0].ID)
+		assert.Equal(t, []string{"task-1"}, response.Order[1].BlockedBy)
+		assert.Len(t, response.CriticalPath, 2)
+	})
+
+	t.Run("should return unprocessable entity when tasks block each other", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, assistant.NewMockService(ctrl))
+
+		mockTaskService.EXPECT().Plan(gomock.Any()).Return(nil, task.ErrCyclicDependency)
+
+		req := httptest.NewRequest(http.MethodGet, "/tasks/order", nil)
+		res := httptest.NewRecorder()
+		handler.Order(res, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
+		assert.Equal(t, CodeCyclicDependency, decodeProblem(t, res).Code)
+	})
+}
+
+func TestHandler_Events(t *testing.T) {
+	occurredAt := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("should stream changes after last event id", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, assistant.NewMockService(ctrl))
+
+		bus := task.NewBus(10)
+		bus.Publish(task.Notification{Type: task.EventCreated, TaskID: "task-1", Task: &task.Task{ID: "task-1", Title: "Design"}, OccurredAt: occurredAt})
+		bus.Publish(task.Notification{Type: task.EventStatusChanged, TaskID: "task-1", Actor: "alice", OccurredAt: occurredAt,
+			Task:    &task.Task{ID: "task-1", Title: "Design", Status: task.StatusInProgress, Version: 2},
+			Changes: []task.FieldChange{{Field: "status", Before: task.StatusNotStarted, After: task.StatusInProgress}}})
+
+		filter := task.NotificationFilter{TaskIDs: []string{"task-1"}, Types: []task.EventType{task.EventStatusChanged}, Tags: []string{"design"}}
+		mockTaskService.EXPECT().Subscribe(gomock.Any(), filter, int64(1)).
+			DoAndReturn(func(ctx context.Context, filter task.NotificationFilter, lastID int64) (*task.Subscription, error) {
+				sub, err := bus.Subscribe(ctx, task.NotificationFilter{}, lastID)
+				sub.Close()
+				return sub, err
+			})
+
+		req := httptest.NewRequest(http.MethodGet, "/events?taskId=task-1&type=STATUS_CHANGED&tag=Design", nil)
+		req.Header.Set("Last-Event-ID", "1")
+		res := httptest.NewRecorder()
+		handler.Events(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "text/event-stream", res.Header().Get("Content-Type"))
+		assert.True(t, res.Flushed)
+
+		id, rest, found := strings.Cut(res.Body.String(), "\n")
+		require.True(t, found)
+		assert.Equal(t, "id: 2", id)
+		event, data, found := strings.Cut(rest, "\n")
+		require.True(t, found)
+		assert.Equal(t, "event: STATUS_CHANGED", event)
+
+		var change TaskChangeEvent
+		require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(data, "data: ")), &change))
+		assert.Equal(t, int64(2), change.ID)
+		assert.Equal(t, "task-1", change.TaskID)
+		assert.Equal(t, task.StatusInProgress, change.Task.Status)
+		assert.Equal(t, "alice", change.Actor)
+		assert.Equal(t, []FieldChange{{Field: "status", Before: "NOT_STARTED", After: "IN_PROGRESS"}}, change.Changes)
+	})
+
+	t.Run("should send error event when subscription falls behind", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, assistant.NewMockService(ctrl))
+
+		bus := task.NewBus(0)
+		mockTaskService.EXPECT().Subscribe(gomock.Any(), task.NotificationFilter{}, int64(0)).
+			DoAndReturn(func(ctx context.Context, filter task.NotificationFilter, lastID int64) (*task.Subscription, error) {
+				sub, err := bus.Subscribe(ctx, filter, lastID)
+				for range 100 {
+					bus.Publish(task.Notification{Type: task.EventCreated, TaskID: "task-1", Task: &task.Task{ID: "task-1"}})
+				}
+				return sub, err
+			})
+
+		req := httptest.NewRequest(http.MethodGet, "/events", nil)
+		res := httptest.NewRecorder()
+		handler.Events(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Contains(t, res.Body.String(), "event: error\ndata: ")
+		assert.Contains(t, res.Body.String(), `"code":"SUBSCRIPTION_LAGGED"`)
+	})
+
+	t.Run("should return gone when changes after last event id are no longer available", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, assistant.NewMockService(ctrl))
+
+		mockTaskService.EXPECT().Subscribe(gomock.Any(), task.NotificationFilter{}, int64(7)).Return(nil, task.ErrNotificationsExpired)
+
+		req := httptest.NewRequest(http.MethodGet, "/events?lastEventId=7", nil)
+		res := httptest.NewRecorder()
+		handler.Events(res, req)
+
+		assert.Equal(t, http.StatusGone, res.Code)
+		assert.Equal(t, CodeEventsExpired, decodeProblem(t, res).Code)
+	})
+
+	t.Run("should return bad request for invalid filters", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		handler := NewHandler(task.NewMockService(ctrl), assistant.NewMockService(ctrl))
+
+		req := httptest.NewRequest(http.MethodGet, "/events?type=TASK_RENAMED", nil)
+		req.Header.Set("Last-Event-ID", "abc")
+		res := httptest.NewRecorder()
+		handler.Events(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		problem := decodeProblem(t, res)
+		assert.Equal(t, CodeValidationFailed, problem.Code)
+		assert.Equal(t, []string{"type", "Last-Event-ID"}, util.Map(problem.Errors, func(e FieldError) string { return e.Field }))
+	})
+}
+
+func TestHandler_ListTags(t *testing.T) {
+	t.Run("should return tags with counts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, assistant.NewMockService(ctrl))
+
+		mockTaskService.EXPECT().Tags(gomock.Any()).Return([]task.TagCount{{Tag: "backend", Count: 2}, {Tag: "docs", Count: 1}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/tags", nil)
+		res := httptest.NewRecorder()
+		handler.ListTags(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, `[{"name":"backend","count":2},{"name":"docs","count":1}]`, res.Body.String())
+	})
+
+	t.Run("should return empty array when there are no tags", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, assistant.NewMockService(ctrl))
+
+		mockTaskService.EXPECT().Tags(gomock.Any()).Return(nil, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/tags", nil)
+		res := httptest.NewRecorder()
+		handler.ListTags(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, `[]`, res.Body.String())
+	})
+}
+
+func TestHandler_RenameTag(t *testing.T) {
+	t.Run("should rename tag", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, assistant.NewMockService(ctrl))
+
+		mockTaskService.EXPECT().RenameTag(gomock.Any(), "backend", "server").Return(task.TagCount{Tag: "server", Count: 2}, nil)
+
+		req := httptest.NewRequest(http.MethodPatch, "/tags/backend", strings.NewReader(`{"name":"server"}`))
+		req.SetPathValue("tag", "backend")
+		res := httptest.NewRecorder()
+		handler.RenameTag(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, `{"name":"server","count":2}`, res.Body.String())
+	})
+
+	t.Run("should return bad request when name is invalid", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		handler := NewHandler(task.NewMockService(ctrl), assistant.NewMockService(ctrl))
+
+		req := httptest.NewRequest(http.MethodPatch, "/tags/backend", strings.NewReader(`{"name":"back end"}`))
+		req.SetPathValue("tag", "backend")
+		res := httptest.NewRecorder()
+		handler.RenameTag(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		problem := decodeProblem(t, res)
+		require.Len(t, problem.Errors, 1)
+		assert.Equal(t, "name", problem.Errors[0].Field)
+	})
+
+	t.Run("should return conflict when new name is already used", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, assistant.NewMockService(ctrl))
+
+		mockTaskService.EXPECT().RenameTag(gomock.Any(), "backend", "api").
+			Return(task.TagCount{}, fmt.Errorf("error renaming tag: %w: %q", task.ErrTagExists, "api"))
+
+		req := httptest.NewRequest(http.MethodPatch, "/tags/backend", strings.NewReader(`{"name":"api"}`))
+		req.SetPathValue("tag", "backend")
+		res := httptest.NewRecorder()
+		handler.RenameTag(res, req)
+
+		assert.Equal(t, http.StatusConflict, res.Code)
+		assert.Equal(t, CodeTagExists, decodeProblem(t, res).Code)
+	})
+
+	t.Run("should return not found when tag is not used", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, assistant.NewMockService(ctrl))
+
+		mockTaskService.EXPECT().RenameTag(gomock.Any(), "frontend", "ui").
+			Return(task.TagCount{}, fmt.Errorf("error renaming tag: %w: %q", task.ErrTagNotFound, "frontend"))
+
+		req := httptest.NewRequest(http.MethodPatch, "/tags/frontend", strings.NewReader(`{"name":"ui"}`))
+		req.SetPathValue("tag", "frontend")
+		res := httptest.NewRecorder()
+		handler.RenameTag(res, req)
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.Equal(t, CodeTagNotFound, decodeProblem(t, res).Code)
+	})
+}
+
+func TestHandler_MergeTags(t *testing.T) {
+	t.Run("should merge tags into target tag", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		handler := NewHandler(mockTaskService, assistant.NewMockService(ctrl))
+
+		mockTaskService.EXPECT().MergeTags(gomock.Any(), []string{"api", "backend"}, "server").Return(task.TagCount{Tag: "server", Count: 3}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/tags/server/merge", strings.NewReader(`{"sources":["api","backend"]}`))
+		req.SetPathValue("tag", "server")
+		res := httptest.NewRecorder()
+		handler.MergeTags(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, `{"name":"server","count":3}`, res.Body.String())
+	})
+
+	t.Run("should return bad request when sources are missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		handler := NewHandler(task.NewMockService(ctrl), assistant.NewMockService(ctrl))
+
+		req := httptest.NewRequest(http.MethodPost, "/tags/server/merge", strings.NewReader(`{"sources":[]}`))
+		req.SetPathValue("tag", "server")
+		res := httptest.NewRecorder()
+		handler.MergeTags(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, []FieldError{{Field: "sources", Code: "required", Message: "sources is required"}}, decodeProblem(t, res).Errors)
+	})
+}
+
+func decodeProblem(t *testing.T, res *httptest.ResponseRecorder) Problem {
+	t.Helper()
+
+	assert.Equal(t, problemContentType, res.Header().Get("Content-Type"))
+
+	var problem Problem
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &problem))
+	assert.Equal(t, res.Code, problem.Status)
+	assert.Equal(t, problemTypePrefix+string(problem.Code), problem.Type)
+
+	return problem
 }
@@ -1,26 +1,368 @@
 package api
 
 import (
+	coreassistant "github.com/utsabbera/task-master/core/assistant"
 	"github.com/utsabbera/task-master/core/task"
+	"github.com/utsabbera/task-master/core/webhook"
+	"github.com/utsabbera/task-master/pkg/assistant"
+	"github.com/utsabbera/task-master/pkg/util"
 )
 
 func mapTaskToResponse(task *task.Task) Task {
 	return Task{
 		ID:          task.ID,
+		Project:     task.Project,
 		Title:       task.Title,
 		Description: task.Description,
 		Status:      task.Status,
 		Priority:    task.Priority,
 		DueDate:     task.DueDate,
+		StartedAt:   task.StartedAt,
+		CompletedAt: task.CompletedAt,
 		CreatedAt:   task.CreatedAt,
 		UpdatedAt:   task.UpdatedAt,
+		Version:     task.Version,
+		Tags:        nonNil(task.Tags),
+		ParentID:    task.ParentID,
+		BlockedBy:   nonNil(task.BlockedBy),
+		Recurrence:  mapRecurrenceToResponse(task.Recurrence),
+		OwnerID:     task.OwnerID,
+		Assignees:   nonNil(task.Assignees),
 	}
 }
 
-func mapTasksToResponse(tasks []*task.Task) []Task {
+func mapRecurrenceToResponse(recurrence *task.Recurrence) *string {
+	if recurrence == nil {
+		return nil
+	}
+	return util.Ptr(recurrence.String())
+}
+
+func mapRecurrenceToRequest(rule *string) (*task.Recurrence, error) {
+	if rule == nil {
+		return nil, nil
+	}
+	if *rule == "" {
+		return &task.Recurrence{}, nil
+	}
+	return task.ParseRecurrence(*rule)
+}
+
+func mapTaskInputToRequest(input TaskInput) (*task.Task, error) {
+	recurrence, err := mapRecurrenceToRequest(input.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+
+	return &task.Task{
+		Title:       input.Title,
+		Description: input.Description,
+		Status:      input.Status,
+		Priority:    input.Priority,
+		DueDate:     input.DueDate,
+		Tags:        input.Tags,
+		ParentID:    input.ParentID,
+		BlockedBy:   input.BlockedBy,
+		Recurrence:  recurrence,
+		Assignees:   input.Assignees,
+	}, nil
+}
+
+func mapBatchInputToRequest(input BatchInput) ([]task.Operation, error) {
+	ops := make([]task.Operation, 0, len(input.Operations))
+	for _, in := range input.Operations {
+		op := task.Operation{Type: in.Op, Ref: in.Ref, TaskID: in.ID}
+		if in.Op == task.ChangeDelete {
+			op.DeleteOptions = task.DeleteOptions{Version: in.Version, Mode: in.Mode}
+		} else {
+			t, err := mapTaskInputToRequest(*in.Task)
+			if err != nil {
+				return nil, err
+			}
+			t.Version = in.Version
+			op.Task = t
+		}
+		ops = append(ops, op)
+	}
+	return ops, nil
+}
+
+func mapTasksToResponse(tasks []*task.Task, progress map[string]task.Progress) []Task {
 	response := make([]Task, 0, len(tasks))
 	for _, t := range tasks {
-		response = append(response, mapTaskToResponse(t))
+		r := mapTaskToResponse(t)
+		if p, ok := progress[t.ID]; ok {
+			r.Progress = mapProgressToResponse(p)
+		}
+		response = append(response, r)
 	}
 	return response
 }
+
+func mapImportResultToResponse(result *task.ImportResult, dryRun bool) ImportReport {
+	failed := len(result.Failed()) > 0
+	rows := make([]ImportRowResult, 0, len(result.Rows))
+	for _, row := range result.Rows {
+		r := ImportRowResult{Line: row.Line, ID: row.Ref}
+		if row.Err != nil {
+			r.Error = row.Err.Error()
+		} else if !failed {
+			mapped := mapTaskToResponse(row.Task)
+			r.Task = &mapped
+		}
+		rows = append(rows, r)
+	}
+
+	return ImportReport{DryRun: dryRun, Imported: result.Imported, Rows: rows}
+}
+
+func mapImportErrorToResponse(err *task.ImportError) []RowError {
+	rows := make([]RowError, 0, len(err.Rows))
+	for _, row := range err.Rows {
+		rows = append(rows, RowError{Line: row.Line, ID: row.Ref, Message: row.Err.Error()})
+	}
+
+	return rows
+}
+
+func mapProgressToResponse(progress task.Progress) *TaskProgress {
+	return &TaskProgress{
+		Total:     progress.Total,
+		Completed: progress.Completed,
+		Percent:   progress.Percent(),
+	}
+}
+
+func mapTagsToResponse(tags []task.TagCount) []Tag {
+	response := make([]Tag, 0, len(tags))
+	for _, t := range tags {
+		response = append(response, mapTagToResponse(t))
+	}
+	return response
+}
+
+func mapTagToResponse(tag task.TagCount) Tag {
+	return Tag{Name: tag.Tag, Count: tag.Count}
+}
+
+func mapTaskEventsToResponse(events []task.Event) []TaskEvent {
+	response := make([]TaskEvent, 0, len(events))
+	for _, e := range events {
+		response = append(response, mapTaskEventToResponse(e))
+	}
+	return response
+}
+
+func mapTaskEventToResponse(event task.Event) TaskEvent {
+	changes := make([]FieldChange, 0, len(event.Changes))
+	for _, c := range event.Changes {
+		changes = append(changes, FieldChange{Field: c.Field, Before: c.Before, After: c.After})
+	}
+
+	return TaskEvent{
+		Sequence:   event.Sequence,
+		Type:       event.Type,
+		Version:    event.Version,
+		Actor:      event.Actor,
+		OccurredAt: event.OccurredAt,
+		Changes:    changes,
+	}
+}
+
+func mapNotificationToResponse(n task.Notification) TaskChangeEvent {
+	changes := make([]FieldChange, 0, len(n.Changes))
+	for _, c := range n.Changes {
+		changes = append(changes, FieldChange{Field: c.Field, Before: c.Before, After: c.After})
+	}
+
+	return TaskChangeEvent{
+		ID:         n.ID,
+		Type:       n.Type,
+		TaskID:     n.TaskID,
+		Task:       mapTaskToResponse(n.Task),
+		Changes:    changes,
+		Actor:      n.Actor,
+		OccurredAt: n.OccurredAt,
+	}
+}
+
+func mapSearchHitsToResponse(hits []task.SearchHit) []TaskSearchHit {
+	return util.Map(hits, func(hit task.SearchHit) TaskSearchHit {
+		return TaskSearchHit{
+			Task:  mapTaskToResponse(hit.Task),
+			Score: hit.Score,
+			Highlights: util.Map(hit.Highlights, func(h task.Highlight) SearchHighlight {
+				return SearchHighlight{Field: h.Field, Fragment: h.Fragment}
+			}),
+		}
+	})
+}
+
+func mapProjectToResponse(p *task.Project) Project {
+	return Project{
+		Key:         p.Key,
+		Name:        p.Name,
+		Description: p.Description,
+		CreatedAt:   p.CreatedAt,
+		UpdatedAt:   p.UpdatedAt,
+	}
+}
+
+func mapProjectsToResponse(projects []*task.Project) []Project {
+	return util.Map(projects, mapProjectToResponse)
+}
+
+func mapViewToResponse(v *task.View) View {
+	return View{
+		Name:        v.Name,
+		Description: v.Description,
+		Filter:      v.Filter,
+		Sort:        task.FormatSort(v.Sort),
+		OwnerID:     v.OwnerID,
+		CreatedAt:   v.CreatedAt,
+		UpdatedAt:   v.UpdatedAt,
+	}
+}
+
+func mapViewsToResponse(views []*task.View) []View {
+	return util.Map(views, mapViewToResponse)
+}
+
+func mapWebhookToResponse(w *webhook.Webhook) Webhook {
+	return Webhook{
+		ID:        w.ID,
+		URL:       w.URL,
+		Events:    nonNil(w.Events),
+		CreatedAt: w.CreatedAt,
+	}
+}
+
+func mapWebhooksToResponse(webhooks []*webhook.Webhook) []Webhook {
+	return util.Map(webhooks, mapWebhookToResponse)
+}
+
+func mapDeliveryToResponse(d *webhook.Delivery) WebhookDelivery {
+	attempts := make([]WebhookDeliveryAttempt, 0, len(d.Attempts))
+	for _, a := range d.Attempts {
+		attempts = append(attempts, WebhookDeliveryAttempt{At: a.At, StatusCode: a.StatusCode, Error: a.Error})
+	}
+
+	return WebhookDelivery{
+		ID:            d.ID,
+		WebhookID:     d.WebhookID,
+		EventID:       d.EventID,
+		EventType:     d.EventType,
+		Status:        d.Status,
+		Payload:       d.Payload,
+		Attempts:      attempts,
+		NextAttemptAt: d.NextAttemptAt,
+		CreatedAt:     d.CreatedAt,
+		UpdatedAt:     d.UpdatedAt,
+	}
+}
+
+func mapDeliveriesToResponse(deliveries []*webhook.Delivery) []WebhookDelivery {
+	return util.Map(deliveries, mapDeliveryToResponse)
+}
+
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
+
+func mapSessionToResponse(session *assistant.Session) ChatSession {
+	messages := make([]ChatMessage, 0, len(session.Messages))
+	for _, m := range session.Messages {
+		messages = append(messages, mapMessageToResponse(m))
+	}
+
+	return ChatSession{
+		ID:        session.ID,
+		Messages:  messages,
+		CreatedAt: session.CreatedAt,
+		UpdatedAt: session.UpdatedAt,
+	}
+}
+
+func mapMessageToResponse(message assistant.Message) ChatMessage {
+	var toolCalls []ChatToolCall
+	for _, call := range message.ToolCalls {
+		toolCalls = append(toolCalls, ChatToolCall{
+			ID:        call.ID,
+			Name:      call.Name,
+			Arguments: call.Arguments,
+		})
+	}
+
+	return ChatMessage{
+		Role:       string(message.Role),
+		Content:    message.Content,
+		ToolCalls:  toolCalls,
+		ToolCallID: message.ToolCallID,
+	}
+}
+
+func mapEventToResponse(event assistant.Event) ChatEvent {
+	response := ChatEvent{Content: event.Content}
+	if event.ToolCall != nil {
+		response.ToolCall = &ChatToolCall{
+			ID:        event.ToolCall.ID,
+			Name:      event.ToolCall.Name,
+			Arguments: event.ToolCall.Arguments,
+		}
+	}
+
+	return response
+}
+
+func mapChatInputToRequest(input ChatInput) coreassistant.Request {
+	return coreassistant.Request{
+		SessionID: input.SessionID,
+		Message:   input.Text,
+		Preview:   input.Preview,
+	}
+}
+
+func mapReplyToResponse(reply *coreassistant.Reply) ChatResponse {
+	response := ChatResponse{
+		SessionID: reply.SessionID,
+		Response:  reply.Response,
+	}
+	if reply.Changes != nil {
+		response.Changes = mapChangesToResponse(reply.Changes)
+	}
+
+	return response
+}
+
+func mapChangesToResponse(changes []task.Change) []ChatChange {
+	response := make([]ChatChange, 0, len(changes))
+	for _, c := range changes {
+		response = append(response, mapChangeToResponse(c))
+	}
+	return response
+}
+
+func mapChangeToResponse(change task.Change) ChatChange {
+	diff := change.Diff()
+	fields := make([]FieldChange, 0, len(diff))
+	for _, d := range diff {
+		fields = append(fields, FieldChange{Field: d.Field, Before: d.Before, After: d.After})
+	}
+
+	response := ChatChange{
+		Type:   change.Type,
+		TaskID: change.TaskID,
+		Diff:   fields,
+	}
+	if change.Before != nil {
+		response.Before = util.Ptr(mapTaskToResponse(change.Before))
+	}
+	if change.After != nil {
+		response.After = util.Ptr(mapTaskToResponse(change.After))
+	}
+
+	return response
+}
@@ -5,7 +5,10 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/utsabbera/task-master/core/task"
+	"github.com/utsabbera/task-master/pkg/assistant"
+	"github.com/utsabbera/task-master/pkg/util"
 )
 
 func TestMapTaskToResponse(t *testing.T) {
@@ -20,6 +23,7 @@ func TestMapTaskToResponse(t *testing.T) {
 		DueDate:     &due,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
+		Tags:        []string{"backend", "urgent"},
 	}
 
 	resp := mapTaskToResponse(coreTask)
@@ -32,17 +36,71 @@ func TestMapTaskToResponse(t *testing.T) {
 	assert.Equal(t, coreTask.DueDate, resp.DueDate)
 	assert.Equal(t, coreTask.CreatedAt, resp.CreatedAt)
 	assert.Equal(t, coreTask.UpdatedAt, resp.UpdatedAt)
+	assert.Equal(t, coreTask.Tags, resp.Tags)
+}
+
+func TestMapTaskToResponse_Tags(t *testing.T) {
+	t.Run("should return empty tags when task has none", func(t *testing.T) {
+		resp := mapTaskToResponse(&task.Task{ID: "1"})
+
+		assert.NotNil(t, resp.Tags)
+		assert.Empty(t, resp.Tags)
+	})
 }
 
 func TestMapTasksToResponse(t *testing.T) {
 	coreTasks := []*task.Task{
 		{ID: "1", Title: "A"},
-		{ID: "2", Title: "B"},
+		{ID: "2", Title: "B", ParentID: util.Ptr("1")},
 	}
-	resp := mapTasksToResponse(coreTasks)
+	resp := mapTasksToResponse(coreTasks, map[string]task.Progress{"1": {Total: 4, Completed: 1}})
 	assert.Equal(t, len(coreTasks), len(resp))
 	for i := range coreTasks {
 		assert.Equal(t, coreTasks[i].ID, resp[i].ID)
 		assert.Equal(t, coreTasks[i].Title, resp[i].Title)
+		assert.Equal(t, coreTasks[i].ParentID, resp[i].ParentID)
 	}
+	assert.Equal(t, &TaskProgress{Total: 4, Completed: 1, Percent: 25}, resp[0].Progress)
+	assert.Nil(t, resp[1].Progress)
+}
+
+func TestMapRecurrenceToRequest(t *testing.T) {
+	t.Run("should leave recurrence unchanged without rule", func(t *testing.T) {
+		recurrence, err := mapRecurrenceToRequest(nil)
+
+		require.NoError(t, err)
+		assert.Nil(t, recurrence)
+	})
+
+	t.Run("should remove recurrence with empty rule", func(t *testing.T) {
+		recurrence, err := mapRecurrenceToRequest(util.Ptr(""))
+
+		require.NoError(t, err)
+		assert.Equal(t, &task.Recurrence{}, recurrence)
+	})
+}
+
+func TestMapSessionToResponse(t *testing.T) {
+	createdAt := time.Now()
+	session := &assistant.Session{
+		ID: "session-1",
+		Messages: []assistant.Message{
+			{Role: assistant.RoleUser, Content: "Delete TASK-1"},
+			{Role: assistant.RoleAssistant, ToolCalls: []assistant.ToolCall{{ID: "call-1", Name: "delete_task", Arguments: `{"id":"TASK-1"}`}}},
+			{Role: assistant.RoleTool, Content: "done", ToolCallID: "call-1"},
+		},
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}
+
+	resp := mapSessionToResponse(session)
+
+	assert.Equal(t, session.ID, resp.ID)
+	assert.Equal(t, session.CreatedAt, resp.CreatedAt)
+	assert.Equal(t, session.UpdatedAt, resp.UpdatedAt)
+	assert.Equal(t, []ChatMessage{
+		{Role: "user", Content: "Delete TASK-1"},
+		{Role: "assistant", ToolCalls: []ChatToolCall{{ID: "call-1", Name: "delete_task", Arguments: `{"id":"TASK-1"}`}}},
+		{Role: "tool", Content: "done", ToolCallID: "call-1"},
+	}, resp.Messages)
 }
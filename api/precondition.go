@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	taskcore "github.com/utsabbera/task-master/core/task"
+)
+
+var errPreconditionFailed = errors.New("precondition failed")
+
+func etag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// matchETag reports whether an If-Match or If-None-Match header value matches the ETag of the version.
+// Weak comparison ignores the W/ prefix of the listed tags, strong comparison never matches weak tags.
+func matchETag(header string, version int, weak bool) bool {
+	current := etag(version)
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return true
+		}
+
+		tag, isWeak := strings.CutPrefix(tag, "W/")
+		if isWeak && !weak {
+			continue
+		}
+		if tag == current {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkPreconditions evaluates the If-Match and If-None-Match headers of a request modifying a task,
+// returning the version the task must still have when it is modified, or zero if the request has no If-Match.
+// Returns an error matching errPreconditionFailed if a precondition doesn't hold.
+func checkPreconditions(r *http.Request, currentVersion func(ctx context.Context) (int, error)) (int, error) {
+	ifMatch := r.Header.Get("If-Match")
+	ifNoneMatch := r.Header.Get("If-None-Match")
+	if ifMatch == "" && ifNoneMatch == "" {
+		return 0, nil
+	}
+
+	version, err := currentVersion(r.Context())
+	if err != nil {
+		return 0, err
+	}
+
+	if ifMatch != "" && !matchETag(ifMatch, version, false) {
+		return 0, fmt.Errorf("%w: If-Match %s doesn't match %s", errPreconditionFailed, ifMatch, etag(version))
+	}
+
+	if ifNoneMatch != "" && matchETag(ifNoneMatch, version, true) {
+		return 0, fmt.Errorf("%w: If-None-Match %s matches %s", errPreconditionFailed, ifNoneMatch, etag(version))
+	}
+
+	if ifMatch == "" {
+		return 0, nil
+	}
+
+	return version, nil
+}
+
+// preconditionError reports a version conflict of a request with If-Match as a failed precondition,
+// since the task was modified after the preconditions were checked.
+func preconditionError(r *http.Request, err error) error {
+	if r.Header.Get("If-Match") != "" && errors.Is(err, taskcore.ErrConflict) {
+		return fmt.Errorf("%w: %w", errPreconditionFailed, err)
+	}
+
+	return err
+}
@@ -0,0 +1,29 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchETag(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		weak   bool
+		want   bool
+	}{
+		{name: "should match same version", header: `"3"`, want: true},
+		{name: "should match any version with wildcard", header: "*", want: true},
+		{name: "should match one of listed versions", header: `"1", "3"`, want: true},
+		{name: "should not match other version", header: `"2"`, want: false},
+		{name: "should not match weak tag in strong comparison", header: `W/"3"`, want: false},
+		{name: "should match weak tag in weak comparison", header: `W/"3"`, weak: true, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchETag(tt.header, 3, tt.weak))
+		})
+	}
+}
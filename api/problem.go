@@ -0,0 +1,336 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/utsabbera/task-master/core/assistant"
+	taskcore "github.com/utsabbera/task-master/core/task"
+	"github.com/utsabbera/task-master/core/webhook"
+	"github.com/utsabbera/task-master/pkg/middleware"
+)
+
+const (
+	problemContentType = "application/problem+json"
+	problemTypePrefix  = "urn:task-master:problem:"
+)
+
+// ErrorCode is a stable machine-readable identifier of an API error.
+type ErrorCode string
+
+const (
+	// CodeInvalidBody indicates a request body which is not valid JSON for the endpoint.
+	CodeInvalidBody ErrorCode = "INVALID_BODY"
+	// CodeRequestTooLarge indicates a request body exceeding the size limit.
+	CodeRequestTooLarge ErrorCode = "REQUEST_TOO_LARGE"
+	// CodeValidationFailed indicates request fields with invalid values, listed in Problem.Errors.
+	CodeValidationFailed ErrorCode = "VALIDATION_FAILED"
+	// CodeInvalidQuery indicates query parameters which cannot be applied to the task list.
+	CodeInvalidQuery ErrorCode = "INVALID_QUERY"
+	// CodeAuthenticationRequired indicates a request without credentials to an API requiring them.
+	CodeAuthenticationRequired ErrorCode = "AUTHENTICATION_REQUIRED"
+	// CodeInvalidCredentials indicates an unknown API key or an invalid, expired or tampered bearer token.
+	CodeInvalidCredentials ErrorCode = "INVALID_CREDENTIALS"
+	// CodeForbidden indicates an operation the role of the caller doesn't allow or on a task they neither own nor are assigned to.
+	CodeForbidden ErrorCode = "FORBIDDEN"
+	// CodeTaskNotFound indicates a task which doesn't exist.
+	CodeTaskNotFound ErrorCode = "TASK_NOT_FOUND"
+	// CodeTaskMoved indicates a task moved to another project, the Location header has its current URL.
+	CodeTaskMoved ErrorCode = "TASK_MOVED"
+	// CodeProjectNotFound indicates a project which doesn't exist.
+	CodeProjectNotFound ErrorCode = "PROJECT_NOT_FOUND"
+	// CodeProjectExists indicates a project created with the key of another project.
+	CodeProjectExists ErrorCode = "PROJECT_EXISTS"
+	// CodeProjectNotEmpty indicates a project which cannot be deleted while it has tasks.
+	CodeProjectNotEmpty ErrorCode = "PROJECT_NOT_EMPTY"
+	// CodeInvalidProject indicates a project with an invalid key or without a name.
+	CodeInvalidProject ErrorCode = "INVALID_PROJECT"
+	// CodeViewNotFound indicates a saved view which doesn't exist.
+	CodeViewNotFound ErrorCode = "VIEW_NOT_FOUND"
+	// CodeViewExists indicates a view saved with the name of another view.
+	CodeViewExists ErrorCode = "VIEW_EXISTS"
+	// CodeInvalidView indicates a view with an invalid name or without a filter.
+	CodeInvalidView ErrorCode = "INVALID_VIEW"
+	// CodeInvalidExpression indicates a filter expression which cannot be parsed or applied, e.g. me without credentials.
+	CodeInvalidExpression ErrorCode = "INVALID_EXPRESSION"
+	// CodeTagNotFound indicates a tag which no task has.
+	CodeTagNotFound ErrorCode = "TAG_NOT_FOUND"
+	// CodeTagExists indicates a tag renamed to a tag which is already used.
+	CodeTagExists ErrorCode = "TAG_EXISTS"
+	// CodeInvalidTag indicates a tag with characters which are not allowed.
+	CodeInvalidTag ErrorCode = "INVALID_TAG"
+	// CodeParentNotFound indicates a parent task which doesn't exist.
+	CodeParentNotFound ErrorCode = "PARENT_NOT_FOUND"
+	// CodeCyclicParent indicates a parent task which is the task itself or one of its subtasks.
+	CodeCyclicParent ErrorCode = "CYCLIC_PARENT"
+	// CodeHasSubtasks indicates a task which cannot be deleted while it has subtasks.
+	CodeHasSubtasks ErrorCode = "HAS_SUBTASKS"
+	// CodeBlockerNotFound indicates a blocking task which doesn't exist.
+	CodeBlockerNotFound ErrorCode = "BLOCKER_NOT_FOUND"
+	// CodeCyclicDependency indicates tasks which block each other.
+	CodeCyclicDependency ErrorCode = "CYCLIC_DEPENDENCY"
+	// CodeTaskBlocked indicates a task which cannot be completed while its blockers are open.
+	CodeTaskBlocked ErrorCode = "TASK_BLOCKED"
+	// CodeInvalidBatch indicates a batch operation without its task or ID, or of an unknown type.
+	CodeInvalidBatch ErrorCode = "INVALID_BATCH"
+	// CodeInvalidImport indicates an import file which cannot be read or has rows which cannot be imported, listed in Problem.Rows.
+	CodeInvalidImport ErrorCode = "INVALID_IMPORT"
+	// CodeUnsupportedFormat indicates an import file in a format which cannot be read.
+	CodeUnsupportedFormat ErrorCode = "UNSUPPORTED_FORMAT"
+	// CodeNotAcceptable indicates an export requested in a format which cannot be written.
+	CodeNotAcceptable ErrorCode = "NOT_ACCEPTABLE"
+	// CodeInvalidRecurrence indicates a recurrence rule which cannot be parsed.
+	CodeInvalidRecurrence ErrorCode = "INVALID_RECURRENCE"
+	// CodeHistoryUnavailable indicates a task history which is not recorded by the storage.
+	CodeHistoryUnavailable ErrorCode = "HISTORY_UNAVAILABLE"
+	// CodeSearchUnavailable indicates a task search on a storage which doesn't index the tasks.
+	CodeSearchUnavailable ErrorCode = "SEARCH_UNAVAILABLE"
+	// CodeEventsExpired indicates a change feed resumed after a change which is no longer buffered.
+	CodeEventsExpired ErrorCode = "EVENTS_EXPIRED"
+	// CodeSubscriptionLagged indicates a change feed closed because the client didn't receive the changes fast enough.
+	CodeSubscriptionLagged ErrorCode = "SUBSCRIPTION_LAGGED"
+	// CodeWebhookNotFound indicates a webhook which doesn't exist.
+	CodeWebhookNotFound ErrorCode = "WEBHOOK_NOT_FOUND"
+	// CodeInvalidWebhook indicates a webhook with a URL, secret or event types which cannot be used.
+	CodeInvalidWebhook ErrorCode = "INVALID_WEBHOOK"
+	// CodeSessionNotFound indicates a chat session which doesn't exist or has expired.
+	CodeSessionNotFound ErrorCode = "SESSION_NOT_FOUND"
+	// CodeInvalidStatus indicates a status which is not part of the task lifecycle.
+	CodeInvalidStatus ErrorCode = "INVALID_STATUS"
+	// CodeInvalidTransition indicates a status change not allowed by the task lifecycle.
+	CodeInvalidTransition ErrorCode = "INVALID_TRANSITION"
+	// CodeVersionConflict indicates a task modified concurrently by another request.
+	CodeVersionConflict ErrorCode = "VERSION_CONFLICT"
+	// CodePreconditionFailed indicates an If-Match or If-None-Match precondition which doesn't hold for the task.
+	CodePreconditionFailed ErrorCode = "PRECONDITION_FAILED"
+	// CodeStaleChange indicates a staged change to a task modified after it was staged.
+	CodeStaleChange ErrorCode = "STALE_CHANGE"
+	// CodeAssistantFailed indicates a chat message the assistant failed to process.
+	CodeAssistantFailed ErrorCode = "ASSISTANT_FAILED"
+	// CodeInternal indicates an unexpected server error.
+	CodeInternal ErrorCode = "INTERNAL"
+)
+
+// Problem represents an error response as defined by RFC 7807, extended with a stable error code,
+// the validation errors of the request fields, the index of the failed operation of a batch
+// and the rows of an import which cannot be imported.
+type Problem struct {
+	Type      string       `json:"type" example:"urn:task-master:problem:TASK_NOT_FOUND"`
+	Title     string       `json:"title" example:"Task not found"`
+	Status    int          `json:"status" example:"404"`
+	Detail    string       `json:"detail,omitempty" example:"task not found"`
+	Instance  string       `json:"instance,omitempty" example:"/tasks/TASK-000001"`
+	Code      ErrorCode    `json:"code" example:"TASK_NOT_FOUND"`
+	Errors    []FieldError `json:"errors,omitempty"`
+	Operation *int         `json:"operation,omitempty" example:"2"`
+	Rows      []RowError   `json:"rows,omitempty"`
+}
+
+// FieldError represents a validation error of a single request field.
+type FieldError struct {
+	Field   string `json:"field" example:"title"`
+	Code    string `json:"code" example:"required"`
+	Message string `json:"message" example:"title is required"`
+}
+
+// RowError represents the error of a row of an import file.
+type RowError struct {
+	Line    int    `json:"line" example:"3"`
+	ID      string `json:"id,omitempty" example:"a"`
+	Message string `json:"message" example:"title is required"`
+}
+
+// ValidationError is returned when request fields are invalid, it lists every invalid field.
+type ValidationError struct {
+	Errors []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, fieldErr := range e.Errors {
+		messages = append(messages, fieldErr.Message)
+	}
+
+	return "invalid request: " + strings.Join(messages, ", ")
+}
+
+func newValidationError(field, code, message string) *ValidationError {
+	e := &ValidationError{}
+	e.add(field, code, message)
+	return e
+}
+
+func (e *ValidationError) add(field, code, message string) {
+	e.Errors = append(e.Errors, FieldError{Field: field, Code: code, Message: message})
+}
+
+func (e *ValidationError) errOrNil() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e
+}
+
+var (
+	errInvalidBody     = errors.New("invalid request body")
+	errBodyTooLarge    = errors.New("request body too large")
+	errAssistantFailed = errors.New("assistant failed to process the message")
+	errNotAcceptable   = errors.New("no acceptable format")
+)
+
+type errorMapping struct {
+	err    error
+	status int
+	code   ErrorCode
+	title  string
+}
+
+var errorMappings = []errorMapping{
+	{errInvalidBody, http.StatusBadRequest, CodeInvalidBody, "Invalid request body"},
+	{errBodyTooLarge, http.StatusRequestEntityTooLarge, CodeRequestTooLarge, "Request body too large"},
+	{taskcore.ErrInvalidListOptions, http.StatusBadRequest, CodeInvalidQuery, "Invalid query parameters"},
+	{middleware.ErrNoCredentials, http.StatusUnauthorized, CodeAuthenticationRequired, "Authentication required"},
+	{middleware.ErrInvalidCredentials, http.StatusUnauthorized, CodeInvalidCredentials, "Invalid credentials"},
+	{taskcore.ErrForbidden, http.StatusForbidden, CodeForbidden, "Permission denied"},
+	{taskcore.ErrTaskMoved, http.StatusPermanentRedirect, CodeTaskMoved, "Task moved"},
+	{taskcore.ErrTaskNotFound, http.StatusNotFound, CodeTaskNotFound, "Task not found"},
+	{taskcore.ErrProjectNotFound, http.StatusNotFound, CodeProjectNotFound, "Project not found"},
+	{taskcore.ErrViewNotFound, http.StatusNotFound, CodeViewNotFound, "View not found"},
+	{taskcore.ErrTagNotFound, http.StatusNotFound, CodeTagNotFound, "Tag not found"},
+	{webhook.ErrWebhookNotFound, http.StatusNotFound, CodeWebhookNotFound, "Webhook not found"},
+	{assistant.ErrSessionNotFound, http.StatusNotFound, CodeSessionNotFound, "Session not found"},
+	{errPreconditionFailed, http.StatusPreconditionFailed, CodePreconditionFailed, "Precondition failed"},
+	{taskcore.ErrConflict, http.StatusConflict, CodeVersionConflict, "Task modified concurrently"},
+	{taskcore.ErrTagExists, http.StatusConflict, CodeTagExists, "Tag already exists"},
+	{taskcore.ErrProjectExists, http.StatusConflict, CodeProjectExists, "Project already exists"},
+	{taskcore.ErrProjectNotEmpty, http.StatusConflict, CodeProjectNotEmpty, "Project has tasks"},
+	{taskcore.ErrViewExists, http.StatusConflict, CodeViewExists, "View already exists"},
+	{taskcore.ErrInvalidTransition, http.StatusConflict, CodeInvalidTransition, "Status transition not allowed"},
+	{taskcore.ErrHasSubtasks, http.StatusConflict, CodeHasSubtasks, "Task has subtasks"},
+	{taskcore.ErrBlocked, http.StatusConflict, CodeTaskBlocked, "Task is blocked"},
+	{taskcore.ErrStaleChange, http.StatusConflict, CodeStaleChange, "Task modified after the change was staged"},
+	{taskcore.ErrParentNotFound, http.StatusUnprocessableEntity, CodeParentNotFound, "Parent task not found"},
+	{taskcore.ErrCyclicParent, http.StatusUnprocessableEntity, CodeCyclicParent, "Cyclic parent"},
+	{taskcore.ErrBlockerNotFound, http.StatusUnprocessableEntity, CodeBlockerNotFound, "Blocking task not found"},
+	{taskcore.ErrCyclicDependency, http.StatusUnprocessableEntity, CodeCyclicDependency, "Cyclic dependency"},
+	{taskcore.ErrInvalidTag, http.StatusUnprocessableEntity, CodeInvalidTag, "Invalid tag"},
+	{taskcore.ErrInvalidProject, http.StatusUnprocessableEntity, CodeInvalidProject, "Invalid project"},
+	{taskcore.ErrInvalidView, http.StatusUnprocessableEntity, CodeInvalidView, "Invalid view"},
+	{taskcore.ErrInvalidExpression, http.StatusUnprocessableEntity, CodeInvalidExpression, "Invalid filter expression"},
+	{taskcore.ErrInvalidBatch, http.StatusUnprocessableEntity, CodeInvalidBatch, "Invalid batch"},
+	{taskcore.ErrInvalidImport, http.StatusUnprocessableEntity, CodeInvalidImport, "Invalid import"},
+	{taskcore.ErrInvalidRecurrence, http.StatusUnprocessableEntity, CodeInvalidRecurrence, "Invalid recurrence rule"},
+	{webhook.ErrInvalidWebhook, http.StatusUnprocessableEntity, CodeInvalidWebhook, "Invalid webhook"},
+	{taskcore.ErrInvalidStatus, http.StatusUnprocessableEntity, CodeInvalidStatus, "Unknown status"},
+	{taskcore.ErrUnsupportedFormat, http.StatusUnsupportedMediaType, CodeUnsupportedFormat, "Unsupported format"},
+	{errNotAcceptable, http.StatusNotAcceptable, CodeNotAcceptable, "Not acceptable"},
+	{taskcore.ErrNotificationsExpired, http.StatusGone, CodeEventsExpired, "Events expired"},
+	{taskcore.ErrSubscriptionLagged, http.StatusServiceUnavailable, CodeSubscriptionLagged, "Subscription fell behind"},
+	{taskcore.ErrHistoryUnavailable, http.StatusNotImplemented, CodeHistoryUnavailable, "Task history unavailable"},
+	{taskcore.ErrSearchUnavailable, http.StatusNotImplemented, CodeSearchUnavailable, "Task search unavailable"},
+	{errAssistantFailed, http.StatusBadGateway, CodeAssistantFailed, "Assistant failed"},
+}
+
+// handleError writes the problem of the error, redirecting the requests to a moved task to its current URL.
+func handleError(w http.ResponseWriter, r *http.Request, err error) {
+	var movedErr *taskcore.MovedError
+	if errors.As(err, &movedErr) {
+		location := *r.URL
+		location.Path = strings.Replace(r.URL.Path, "/"+movedErr.ID, "/"+movedErr.MovedTo, 1)
+		w.Header().Set("Location", location.RequestURI())
+	}
+
+	writeProblem(w, newProblem(r, err))
+}
+
+func newProblem(r *http.Request, err error) Problem {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return Problem{
+			Type:     problemTypePrefix + string(CodeValidationFailed),
+			Title:    "Validation failed",
+			Status:   http.StatusBadRequest,
+			Detail:   validationErr.Error(),
+			Instance: r.URL.Path,
+			Code:     CodeValidationFailed,
+			Errors:   validationErr.Errors,
+		}
+	}
+
+	for _, mapping := range errorMappings {
+		if errors.Is(err, mapping.err) {
+			problem := Problem{
+				Type:     problemTypePrefix + string(mapping.code),
+				Title:    mapping.title,
+				Status:   mapping.status,
+				Detail:   problemDetail(err, mapping.err),
+				Instance: r.URL.Path,
+				Code:     mapping.code,
+			}
+
+			var batchErr *taskcore.BatchError
+			if errors.As(err, &batchErr) {
+				problem.Operation = &batchErr.Index
+			}
+
+			var importErr *taskcore.ImportError
+			if errors.As(err, &importErr) {
+				problem.Rows = mapImportErrorToResponse(importErr)
+			}
+
+			return problem
+		}
+	}
+
+	log.Printf("%s %s: %v", r.Method, r.URL.Path, err)
+
+	return Problem{
+		Type:     problemTypePrefix + string(CodeInternal),
+		Title:    "Internal server error",
+		Status:   http.StatusInternalServerError,
+		Instance: r.URL.Path,
+		Code:     CodeInternal,
+	}
+}
+
+func writeProblem(w http.ResponseWriter, problem Problem) {
+	w.Header().Set("Content-Type", problemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(problem.Status)
+
+	_ = json.NewEncoder(w).Encode(problem)
+}
+
+// problemDetail returns the message of the innermost error of the chain which describes target,
+// leaving out the context added by the layers the error went through.
+func problemDetail(err, target error) string {
+	detail := target.Error()
+	for _, e := range errorChain(err) {
+		if e != target && strings.HasPrefix(e.Error(), target.Error()) && errors.Is(e, target) {
+			detail = e.Error()
+		}
+	}
+
+	return detail
+}
+
+func errorChain(err error) []error {
+	if err == nil {
+		return nil
+	}
+
+	chain := []error{err}
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		chain = append(chain, errorChain(e.Unwrap())...)
+	case interface{ Unwrap() []error }:
+		for _, inner := range e.Unwrap() {
+			chain = append(chain, errorChain(inner)...)
+		}
+	}
+
+	return chain
+}
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/utsabbera/task-master/core/assistant"
+	"github.com/utsabbera/task-master/core/task"
+	"github.com/utsabbera/task-master/pkg/middleware"
+	"github.com/utsabbera/task-master/pkg/util"
+)
+
+func TestNewProblem(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPatch, "/tasks/TASK-000001", nil)
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   ErrorCode
+		detail string
+	}{
+		{
+			name:   "should map task not found without wrapping context",
+			err:    fmt.Errorf("error finding task: %w", task.ErrTaskNotFound),
+			status: http.StatusNotFound,
+			code:   CodeTaskNotFound,
+			detail: "task not found",
+		},
+		{
+			name:   "should map session not found",
+			err:    assistant.ErrSessionNotFound,
+			status: http.StatusNotFound,
+			code:   CodeSessionNotFound,
+			detail: assistant.ErrSessionNotFound.Error(),
+		},
+		{
+			name:   "should map transition error with its description",
+			err:    fmt.Errorf("error updating task: %w", &task.TransitionError{From: task.StatusCompleted, To: task.StatusCancelled}),
+			status: http.StatusConflict,
+			code:   CodeInvalidTransition,
+			detail: "invalid status transition from COMPLETED to CANCELLED, allowed: [IN_PROGRESS]",
+		},
+		{
+			name:   "should map invalid status with the rejected value",
+			err:    fmt.Errorf("error creating task: %w", fmt.Errorf("%w: %q", task.ErrInvalidStatus, "DONE")),
+			status: http.StatusUnprocessableEntity,
+			code:   CodeInvalidStatus,
+			detail: `invalid status: "DONE"`,
+		},
+		{
+			name:   "should map stale change joined with other errors",
+			err:    fmt.Errorf("error committing changes: %w", errors.Join(fmt.Errorf("%w: TASK-000001", task.ErrStaleChange), errors.New("other"))),
+			status: http.StatusConflict,
+			code:   CodeStaleChange,
+			detail: "task was modified after the change was staged: TASK-000001",
+		},
+		{
+			name:   "should map invalid credentials with the reason",
+			err:    fmt.Errorf("%w: %w", middleware.ErrInvalidCredentials, errors.New("token is expired")),
+			status: http.StatusUnauthorized,
+			code:   CodeInvalidCredentials,
+			detail: "invalid credentials: token is expired",
+		},
+		{
+			name:   "should map permission denied",
+			err:    fmt.Errorf("error deleting task: %w", fmt.Errorf("%w: TASK-000001 is not owned by bob", task.ErrForbidden)),
+			status: http.StatusForbidden,
+			code:   CodeForbidden,
+			detail: "permission denied: TASK-000001 is not owned by bob",
+		},
+		{
+			name:   "should hide unexpected errors",
+			err:    errors.New("database is locked"),
+			status: http.StatusInternalServerError,
+			code:   CodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problem := newProblem(req, tt.err)
+
+			assert.Equal(t, Problem{
+				Type:     problemTypePrefix + string(tt.code),
+				Title:    problem.Title,
+				Status:   tt.status,
+				Detail:   tt.detail,
+				Instance: "/tasks/TASK-000001",
+				Code:     tt.code,
+			}, problem)
+			assert.NotEmpty(t, problem.Title)
+		})
+	}
+
+	t.Run("should list the invalid fields of a validation error", func(t *testing.T) {
+		validation := &ValidationError{}
+		validation.add("title", "required", "title is required")
+		validation.add("limit", "out_of_range", "limit must be between 1 and 1000")
+
+		problem := newProblem(req, validation)
+
+		assert.Equal(t, http.StatusBadRequest, problem.Status)
+		assert.Equal(t, CodeValidationFailed, problem.Code)
+		assert.Equal(t, "invalid request: title is required, limit must be between 1 and 1000", problem.Detail)
+		assert.Len(t, problem.Errors, 2)
+	})
+
+	t.Run("should report the failed operation of a batch", func(t *testing.T) {
+		err := fmt.Errorf("error applying batch: %w", &task.BatchError{Index: 3, Err: fmt.Errorf("%w: update without task", task.ErrInvalidBatch)})
+
+		problem := newProblem(req, err)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, problem.Status)
+		assert.Equal(t, CodeInvalidBatch, problem.Code)
+		assert.Equal(t, "invalid batch: update without task", problem.Detail)
+		assert.Equal(t, util.Ptr(3), problem.Operation)
+	})
+
+	t.Run("should list the failed rows of an import", func(t *testing.T) {
+		err := fmt.Errorf("error importing tasks: %w", &task.ImportError{Rows: []task.ImportRow{
+			{Line: 2, Err: errors.New("title is required")},
+			{Line: 4, Ref: "b", Err: task.ErrParentNotFound},
+		}})
+
+		problem := newProblem(req, err)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, problem.Status)
+		assert.Equal(t, CodeInvalidImport, problem.Code)
+		assert.Equal(t, "invalid import: line 2: title is required; line 4: parent task not found", problem.Detail)
+		assert.Equal(t, []RowError{
+			{Line: 2, Message: "title is required"},
+			{Line: 4, ID: "b", Message: "parent task not found"},
+		}, problem.Rows)
+	})
+}
@@ -0,0 +1,318 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	taskcore "github.com/utsabbera/task-master/core/task"
+)
+
+//go:generate mockgen -destination=project_handler_mock.go -package=api . ProjectHandler
+
+// ProjectHandler defines the interface for handling HTTP requests related to projects.
+type ProjectHandler interface {
+	// Create creates a new project.
+	Create(w http.ResponseWriter, r *http.Request)
+
+	// List lists all projects.
+	List(w http.ResponseWriter, r *http.Request)
+
+	// Get retrieves a project by its key.
+	Get(w http.ResponseWriter, r *http.Request)
+
+	// Update updates an existing project by its key.
+	Update(w http.ResponseWriter, r *http.Request)
+
+	// Delete deletes a project by its key.
+	Delete(w http.ResponseWriter, r *http.Request)
+
+	// ListTasks lists the tasks of a project.
+	ListTasks(w http.ResponseWriter, r *http.Request)
+
+	// CreateTask creates a new task in a project.
+	CreateTask(w http.ResponseWriter, r *http.Request)
+}
+
+type projectHandler struct {
+	project taskcore.ProjectService
+	tasks   *handler
+}
+
+// NewProjectHandler returns a new instance of ProjectHandler for project operations.
+func NewProjectHandler(projectService taskcore.ProjectService, taskService taskcore.Service) ProjectHandler {
+	return &projectHandler{
+		project: projectService,
+		tasks:   &handler{task: taskService},
+	}
+}
+
+// Create godoc
+// @Summary Create Project
+// @Description Create a new project. Its key is upper cased and prefixes the IDs of its tasks, e.g. WEB-000001
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param project body ProjectInput true "Project input"
+// @Success 201 {object} Project
+// @Header 201 {string} Location "URL of the created project"
+// @Failure 400 {object} Problem "Invalid request body or fields"
+// @Failure 403 {object} Problem "Caller is not an admin"
+// @Failure 409 {object} Problem "Project already exists"
+// @Failure 413 {object} Problem "Request body too large"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /projects [post]
+func (h *projectHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var input ProjectInput
+	if err := decodeJSON(w, r, &input); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	if err := input.validate(opCreate); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	project := &taskcore.Project{
+		Key:         input.Key,
+		Name:        input.Name,
+		Description: input.Description,
+	}
+
+	if err := h.project.Create(r.Context(), project); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", "/projects/"+project.Key)
+	w.WriteHeader(http.StatusCreated)
+
+	response := mapProjectToResponse(project)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		handleError(w, r, fmt.Errorf("error encoding response: %w", err))
+		return
+	}
+}
+
+// List godoc
+// @Summary List Projects
+// @Description List the projects sorted by key
+// @Tags projects
+// @Produce json
+// @Success 200 {array} Project
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /projects [get]
+func (h *projectHandler) List(w http.ResponseWriter, r *http.Request) {
+	projects, err := h.project.List(r.Context())
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	response := mapProjectsToResponse(projects)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		handleError(w, r, fmt.Errorf("error encoding response: %w", err))
+		return
+	}
+}
+
+// Get godoc
+// @Summary Get Project
+// @Description Get a project by key
+// @Tags projects
+// @Produce json
+// @Param key path string true "Project key"
+// @Success 200 {object} Project
+// @Failure 404 {object} Problem "Project not found"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /projects/{key} [get]
+func (h *projectHandler) Get(w http.ResponseWriter, r *http.Request) {
+	key := r.PathValue("key")
+	if key == "" {
+		handleError(w, r, newValidationError("key", violationRequired, "project key is required"))
+		return
+	}
+
+	project, err := h.project.Get(r.Context(), key)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	response := mapProjectToResponse(project)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		handleError(w, r, fmt.Errorf("error encoding response: %w", err))
+		return
+	}
+}
+
+// Update godoc
+// @Summary Update Project
+// @Description Change the name or the description of a project, its key cannot be changed
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param key path string true "Project key"
+// @Param project body ProjectInput true "Project fields to update"
+// @Success 200 {object} Project
+// @Failure 400 {object} Problem "Invalid request body or fields"
+// @Failure 403 {object} Problem "Caller is not an admin"
+// @Failure 404 {object} Problem "Project not found"
+// @Failure 413 {object} Problem "Request body too large"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /projects/{key} [patch]
+func (h *projectHandler) Update(w http.ResponseWriter, r *http.Request) {
+	key := r.PathValue("key")
+	if key == "" {
+		handleError(w, r, newValidationError("key", violationRequired, "project key is required"))
+		return
+	}
+
+	var input ProjectInput
+	if err := decodeJSON(w, r, &input); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	if err := input.validate(opUpdate); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	project, err := h.project.Update(r.Context(), key, &taskcore.Project{Name: input.Name, Description: input.Description})
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	response := mapProjectToResponse(project)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		handleError(w, r, fmt.Errorf("error encoding response: %w", err))
+		return
+	}
+}
+
+// Delete godoc
+// @Summary Delete Project
+// @Description Delete a project by key, a project is only deleted once its tasks are moved or deleted
+// @Tags projects
+// @Param key path string true "Project key"
+// @Success 204
+// @Failure 403 {object} Problem "Caller is not an admin"
+// @Failure 404 {object} Problem "Project not found"
+// @Failure 409 {object} Problem "Project has tasks"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /projects/{key} [delete]
+func (h *projectHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	key := r.PathValue("key")
+	if key == "" {
+		handleError(w, r, newValidationError("key", violationRequired, "project key is required"))
+		return
+	}
+
+	if err := h.project.Delete(r.Context(), key); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListTasks godoc
+// @Summary List Project Tasks
+// @Description List the tasks of a project, filtered, sorted and paginated like the task list
+// @Tags projects
+// @Produce json
+// @Param key path string true "Project key"
+// @Param status query []string false "Only tasks with any of these statuses" collectionFormat(csv)
+// @Param priority query []string false "Only tasks with any of these priorities" collectionFormat(csv)
+// @Param tag query []string false "Only tasks having all of these tags, or none of the tags prefixed with !" collectionFormat(multi)
+// @Param q query string false "Only tasks whose title or description contains this text"
+// @Param owner query []string false "Only tasks owned by any of these users, me for the caller" collectionFormat(csv)
+// @Param assignee query []string false "Only tasks assigned to any of these users, me for the caller" collectionFormat(csv)
+// @Param filter query string false "Only tasks matching this expression of fields (id, title, description, status, priority, due, created, updated, tag, assignee, owner, project, parent), operators (=, !=, <, <=, >, >=, : and ~), and, or, not and parentheses, e.g. status != COMPLETED and (priority = HIGH or due < now+3d) and tag:backend"
+// @Param sort query string false "Comma separated sort fields (createdAt, updatedAt, dueDate, priority, title), prefixed with - for descending order"
+// @Param limit query int false "Maximum number of tasks to return" default(100) minimum(1) maximum(1000)
+// @Param cursor query string false "Cursor of the page to return, taken from X-Next-Cursor"
+// @Success 200 {array} Task
+// @Header 200 {integer} X-Total-Count "Number of tasks matching the filters"
+// @Header 200 {string} X-Next-Cursor "Cursor of the next page, absent on the last page"
+// @Failure 400 {object} Problem "Invalid query parameters"
+// @Failure 404 {object} Problem "Project not found"
+// @Failure 422 {object} Problem "Filter expression using me without credentials"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /projects/{key}/tasks [get]
+func (h *projectHandler) ListTasks(w http.ResponseWriter, r *http.Request) {
+	key := r.PathValue("key")
+	if key == "" {
+		handleError(w, r, newValidationError("key", violationRequired, "project key is required"))
+		return
+	}
+
+	opts, err := parseListOptions(r.Context(), r.URL.Query())
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	project, err := h.project.Get(r.Context(), key)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	opts.Filter.Projects = []string{project.Key}
+	h.tasks.listTasks(w, r, opts)
+}
+
+// CreateTask godoc
+// @Summary Create Project Task
+// @Description Create a new task in a project, it gets the next ID of the project
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param key path string true "Project key"
+// @Param task body TaskInput true "Task input"
+// @Success 201 {object} Task
+// @Failure 400 {object} Problem "Invalid request body or fields"
+// @Failure 404 {object} Problem "Project not found"
+// @Failure 409 {object} Problem "Completed task is blocked"
+// @Failure 413 {object} Problem "Request body too large"
+// @Failure 422 {object} Problem "Parent or blocking task not found, or cyclic dependency"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /projects/{key}/tasks [post]
+func (h *projectHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
+	key := r.PathValue("key")
+	if key == "" {
+		handleError(w, r, newValidationError("key", violationRequired, "project key is required"))
+		return
+	}
+
+	h.tasks.create(w, r, key)
+}
@@ -0,0 +1,244 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/core/task"
+	"github.com/utsabbera/task-master/pkg/util"
+	"go.uber.org/mock/gomock"
+)
+
+func TestProjectHandler_Create(t *testing.T) {
+	createdAt := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("should create project", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockProjectService := task.NewMockProjectService(ctrl)
+		handler := NewProjectHandler(mockProjectService, task.NewMockService(ctrl))
+
+		mockProjectService.EXPECT().Create(gomock.Any(), &task.Project{Key: "web", Name: "Website"}).
+			DoAndReturn(func(_ any, p *task.Project) error {
+				p.Key = "WEB"
+				p.CreatedAt = createdAt
+				p.UpdatedAt = createdAt
+				return nil
+			})
+
+		req := httptest.NewRequest(http.MethodPost, "/projects", strings.NewReader(`{"key":"web","name":"Website"}`))
+		res := httptest.NewRecorder()
+		handler.Create(res, req)
+
+		assert.Equal(t, http.StatusCreated, res.Code)
+		assert.Equal(t, "/projects/WEB", res.Header().Get("Location"))
+		assert.JSONEq(t, `{"key":"WEB","name":"Website","description":"","createdAt":"2025-05-01T09:00:00Z","updatedAt":"2025-05-01T09:00:00Z"}`, res.Body.String())
+	})
+
+	t.Run("should return bad request when fields are invalid", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		handler := NewProjectHandler(task.NewMockProjectService(ctrl), task.NewMockService(ctrl))
+
+		req := httptest.NewRequest(http.MethodPost, "/projects", strings.NewReader(`{"key":"TASK"}`))
+		res := httptest.NewRecorder()
+		handler.Create(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		problem := decodeProblem(t, res)
+		assert.Equal(t, CodeValidationFailed, problem.Code)
+		assert.Equal(t, []string{"key", "name"}, util.Map(problem.Errors, func(e FieldError) string { return e.Field }))
+	})
+
+	t.Run("should return conflict when project exists", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockProjectService := task.NewMockProjectService(ctrl)
+		handler := NewProjectHandler(mockProjectService, task.NewMockService(ctrl))
+
+		mockProjectService.EXPECT().Create(gomock.Any(), gomock.Any()).
+			Return(fmt.Errorf("error creating project: %w", fmt.Errorf("%w: WEB", task.ErrProjectExists)))
+
+		req := httptest.NewRequest(http.MethodPost, "/projects", strings.NewReader(`{"key":"WEB","name":"Website"}`))
+		res := httptest.NewRecorder()
+		handler.Create(res, req)
+
+		assert.Equal(t, http.StatusConflict, res.Code)
+		assert.Equal(t, CodeProjectExists, decodeProblem(t, res).Code)
+	})
+}
+
+func TestProjectHandler_List(t *testing.T) {
+	t.Run("should return projects", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockProjectService := task.NewMockProjectService(ctrl)
+		handler := NewProjectHandler(mockProjectService, task.NewMockService(ctrl))
+
+		mockProjectService.EXPECT().List(gomock.Any()).Return([]*task.Project{{Key: "OPS"}, {Key: "WEB"}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/projects", nil)
+		res := httptest.NewRecorder()
+		handler.List(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		var response []Project
+		require.NoError(t, json.Unmarshal(res.Body.Bytes(), &response))
+		assert.Equal(t, []string{"OPS", "WEB"}, util.Map(response, func(p Project) string { return p.Key }))
+	})
+}
+
+func TestProjectHandler_Get(t *testing.T) {
+	t.Run("should return not found when project doesn't exist", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockProjectService := task.NewMockProjectService(ctrl)
+		handler := NewProjectHandler(mockProjectService, task.NewMockService(ctrl))
+
+		mockProjectService.EXPECT().Get(gomock.Any(), "WEB").Return(nil, task.ErrProjectNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/projects/WEB", nil)
+		req.SetPathValue("key", "WEB")
+		res := httptest.NewRecorder()
+		handler.Get(res, req)
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.Equal(t, CodeProjectNotFound, decodeProblem(t, res).Code)
+	})
+}
+
+func TestProjectHandler_Update(t *testing.T) {
+	t.Run("should update name and description", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockProjectService := task.NewMockProjectService(ctrl)
+		handler := NewProjectHandler(mockProjectService, task.NewMockService(ctrl))
+
+		mockProjectService.EXPECT().Update(gomock.Any(), "WEB", &task.Project{Name: "Web app", Description: "SPA"}).
+			Return(&task.Project{Key: "WEB", Name: "Web app", Description: "SPA"}, nil)
+
+		req := httptest.NewRequest(http.MethodPatch, "/projects/WEB", strings.NewReader(`{"name":"Web app","description":"SPA"}`))
+		req.SetPathValue("key", "WEB")
+		res := httptest.NewRecorder()
+		handler.Update(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		var response Project
+		require.NoError(t, json.Unmarshal(res.Body.Bytes(), &response))
+		assert.Equal(t, "Web app", response.Name)
+	})
+
+	t.Run("should return bad request when key is changed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		handler := NewProjectHandler(task.NewMockProjectService(ctrl), task.NewMockService(ctrl))
+
+		req := httptest.NewRequest(http.MethodPatch, "/projects/WEB", strings.NewReader(`{"key":"APP"}`))
+		req.SetPathValue("key", "WEB")
+		res := httptest.NewRecorder()
+		handler.Update(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, "key", decodeProblem(t, res).Errors[0].Field)
+	})
+}
+
+func TestProjectHandler_Delete(t *testing.T) {
+	t.Run("should return conflict when project has tasks", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockProjectService := task.NewMockProjectService(ctrl)
+		handler := NewProjectHandler(mockProjectService, task.NewMockService(ctrl))
+
+		mockProjectService.EXPECT().Delete(gomock.Any(), "WEB").
+			Return(fmt.Errorf("error deleting project: %w", fmt.Errorf("%w: WEB has 2 tasks", task.ErrProjectNotEmpty)))
+
+		req := httptest.NewRequest(http.MethodDelete, "/projects/WEB", nil)
+		req.SetPathValue("key", "WEB")
+		res := httptest.NewRecorder()
+		handler.Delete(res, req)
+
+		assert.Equal(t, http.StatusConflict, res.Code)
+		problem := decodeProblem(t, res)
+		assert.Equal(t, CodeProjectNotEmpty, problem.Code)
+		assert.Equal(t, "project has tasks: WEB has 2 tasks", problem.Detail)
+	})
+}
+
+func TestProjectHandler_ListTasks(t *testing.T) {
+	t.Run("should list tasks of project", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockProjectService := task.NewMockProjectService(ctrl)
+		mockTaskService := task.NewMockService(ctrl)
+		handler := NewProjectHandler(mockProjectService, mockTaskService)
+
+		mockProjectService.EXPECT().Get(gomock.Any(), "web").Return(&task.Project{Key: "WEB"}, nil)
+		mockTaskService.EXPECT().List(gomock.Any(), task.ListOptions{
+			Filter: task.Filter{Projects: []string{"WEB"}, Statuses: []task.Status{task.StatusNotStarted}},
+			Limit:  100,
+		}).Return(&task.Page{Tasks: []*task.Task{{ID: "WEB-000001", Project: "WEB"}}, Total: 1}, nil)
+		mockTaskService.EXPECT().Progress(gomock.Any(), []string{"WEB-000001"}).Return(map[string]task.Progress{}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/projects/web/tasks?status=NOT_STARTED&project=OPS", nil)
+		req.SetPathValue("key", "web")
+		res := httptest.NewRecorder()
+		handler.ListTasks(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "1", res.Header().Get("X-Total-Count"))
+	})
+
+	t.Run("should return not found when project doesn't exist", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockProjectService := task.NewMockProjectService(ctrl)
+		handler := NewProjectHandler(mockProjectService, task.NewMockService(ctrl))
+
+		mockProjectService.EXPECT().Get(gomock.Any(), "WEB").Return(nil, task.ErrProjectNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/projects/WEB/tasks", nil)
+		req.SetPathValue("key", "WEB")
+		res := httptest.NewRecorder()
+		handler.ListTasks(res, req)
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.Equal(t, CodeProjectNotFound, decodeProblem(t, res).Code)
+	})
+}
+
+func TestProjectHandler_CreateTask(t *testing.T) {
+	t.Run("should create task in project", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTaskService := task.NewMockService(ctrl)
+		handler := NewProjectHandler(task.NewMockProjectService(ctrl), mockTaskService)
+
+		mockTaskService.EXPECT().Create(gomock.Any(), &task.Task{Project: "WEB", Title: "Landing page"}).
+			DoAndReturn(func(_ any, t *task.Task) error {
+				t.ID = "WEB-000001"
+				return nil
+			})
+
+		req := httptest.NewRequest(http.MethodPost, "/projects/WEB/tasks", strings.NewReader(`{"title":"Landing page"}`))
+		req.SetPathValue("key", "WEB")
+		res := httptest.NewRecorder()
+		handler.CreateTask(res, req)
+
+		assert.Equal(t, http.StatusCreated, res.Code)
+
+		var response Task
+		require.NoError(t, json.Unmarshal(res.Body.Bytes(), &response))
+		assert.Equal(t, "WEB-000001", response.ID)
+		assert.Equal(t, "WEB", response.Project)
+	})
+}
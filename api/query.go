@@ -0,0 +1,281 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	taskcore "github.com/utsabbera/task-master/core/task"
+)
+
+const (
+	defaultListLimit     = 100
+	maxListLimit         = 1000
+	maxSearchLimit       = 100
+	maxSearchQueryLength = 500
+	currentUser          = "me"
+)
+
+func parseListOptions(ctx context.Context, query url.Values) (taskcore.ListOptions, error) {
+	var (
+		opts       taskcore.ListOptions
+		validation ValidationError
+		err        error
+	)
+
+	opts.Filter = parseFilter(ctx, query, &validation)
+	opts.Filter.Query = query.Get("q")
+
+	if opts.Sort, err = taskcore.ParseSort(query.Get("sort")); err != nil {
+		validation.add("sort", violationInvalid, fmt.Sprintf("invalid sort: %v", err))
+	}
+
+	opts.Limit = parseLimit(query, defaultListLimit, maxListLimit, &validation)
+	opts.Cursor = query.Get("cursor")
+
+	return opts, validation.errOrNil()
+}
+
+// parseExportOptions parses the filter and sort of the exported tasks, which are not paginated.
+func parseExportOptions(ctx context.Context, query url.Values) (taskcore.ListOptions, error) {
+	var (
+		opts       taskcore.ListOptions
+		validation ValidationError
+		err        error
+	)
+
+	opts.Filter = parseFilter(ctx, query, &validation)
+	opts.Filter.Query = query.Get("q")
+
+	if opts.Sort, err = taskcore.ParseSort(query.Get("sort")); err != nil {
+		validation.add("sort", violationInvalid, fmt.Sprintf("invalid sort: %v", err))
+	}
+
+	return opts, validation.errOrNil()
+}
+
+// parseImportOptions parses the dry run flag of an import and its column mappings given as Header:field.
+func parseImportOptions(query url.Values) (taskcore.ImportOptions, error) {
+	var (
+		opts       taskcore.ImportOptions
+		validation ValidationError
+	)
+
+	if value := query.Get("dryRun"); value != "" {
+		dryRun, err := strconv.ParseBool(value)
+		if err != nil {
+			validation.add("dryRun", violationInvalid, fmt.Sprintf("invalid dryRun %q, must be true or false", value))
+		}
+		opts.DryRun = dryRun
+	}
+
+	for _, value := range query["column"] {
+		i := strings.LastIndex(value, ":")
+		if i <= 0 || i == len(value)-1 {
+			validation.add("column", violationInvalid, fmt.Sprintf("invalid column %q, must be Header:field", value))
+			continue
+		}
+
+		if opts.Columns == nil {
+			opts.Columns = make(map[string]string)
+		}
+		opts.Columns[value[:i]] = value[i+1:]
+	}
+
+	return opts, validation.errOrNil()
+}
+
+// parseSearchOptions parses the query of a search with the filter of the searched tasks.
+func parseSearchOptions(ctx context.Context, query url.Values) (string, taskcore.SearchOptions, error) {
+	var (
+		opts       taskcore.SearchOptions
+		validation ValidationError
+	)
+
+	q := query.Get("q")
+	if strings.TrimSpace(q) == "" {
+		validation.add("q", violationRequired, "q is required")
+	} else if utf8.RuneCountInString(q) > maxSearchQueryLength {
+		validation.add("q", violationTooLong, fmt.Sprintf("q must be at most %d characters", maxSearchQueryLength))
+	}
+
+	opts.Filter = parseFilter(ctx, query, &validation)
+	opts.Limit = parseLimit(query, taskcore.DefaultSearchLimit, maxSearchLimit, &validation)
+
+	return q, opts, validation.errOrNil()
+}
+
+// parseFilter parses the filter parameters shared by the task list and the task search.
+func parseFilter(ctx context.Context, query url.Values, validation *ValidationError) taskcore.Filter {
+	var (
+		filter taskcore.Filter
+		err    error
+	)
+
+	for _, status := range splitValues(query["status"]) {
+		if !taskcore.Status(status).Valid() {
+			validation.add("status", violationInvalid, fmt.Sprintf("unknown status %q", status))
+		}
+		filter.Statuses = append(filter.Statuses, taskcore.Status(status))
+	}
+
+	for _, priority := range splitValues(query["priority"]) {
+		if !taskcore.Priority(priority).Valid() {
+			validation.add("priority", violationInvalid, fmt.Sprintf("unknown priority %q", priority))
+		}
+		filter.Priorities = append(filter.Priorities, taskcore.Priority(priority))
+	}
+
+	if filter.DueBefore, err = parseTimeParam(query, "dueBefore"); err != nil {
+		validation.add("dueBefore", violationInvalid, err.Error())
+	}
+
+	if filter.DueAfter, err = parseTimeParam(query, "dueAfter"); err != nil {
+		validation.add("dueAfter", violationInvalid, err.Error())
+	}
+
+	for _, value := range splitValues(query["tag"]) {
+		excluded := strings.HasPrefix(value, "!")
+		tag, err := taskcore.NormalizeTag(strings.TrimPrefix(value, "!"))
+		if err != nil {
+			validation.add("tag", violationInvalid, fmt.Sprintf("invalid tag %q", value))
+			continue
+		}
+
+		if excluded {
+			filter.ExcludedTags = append(filter.ExcludedTags, tag)
+		} else {
+			filter.Tags = append(filter.Tags, tag)
+		}
+	}
+
+	for _, project := range splitValues(query["project"]) {
+		filter.Projects = append(filter.Projects, strings.ToUpper(project))
+	}
+
+	if filter.Owners, err = resolveUsers(ctx, splitValues(query["owner"])); err != nil {
+		validation.add("owner", violationInvalid, err.Error())
+	}
+
+	if filter.Assignees, err = resolveUsers(ctx, splitValues(query["assignee"])); err != nil {
+		validation.add("assignee", violationInvalid, err.Error())
+	}
+
+	if value := query.Get("filter"); value != "" {
+		if utf8.RuneCountInString(value) > maxFilterLength {
+			validation.add("filter", violationTooLong, fmt.Sprintf("filter must be at most %d characters", maxFilterLength))
+		} else if filter.Expression, err = taskcore.ParseExpression(value); err != nil {
+			validation.add("filter", violationInvalid, err.Error())
+		}
+	}
+
+	return filter
+}
+
+func parseLimit(query url.Values, defaultLimit, maxLimit int, validation *ValidationError) int {
+	value := query.Get("limit")
+	if value == "" {
+		return defaultLimit
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 1 || limit > maxLimit {
+		validation.add("limit", violationOutOfRange, fmt.Sprintf("invalid limit %q, must be between 1 and %d", value, maxLimit))
+	}
+
+	return limit
+}
+
+// parseSubscription parses the filters of the change feed and the ID of the last change received by the client,
+// taken from the Last-Event-ID header or the lastEventId parameter for clients which cannot set headers.
+func parseSubscription(r *http.Request) (taskcore.NotificationFilter, int64, error) {
+	var (
+		filter     taskcore.NotificationFilter
+		lastID     int64
+		validation ValidationError
+	)
+
+	query := r.URL.Query()
+	filter.TaskIDs = splitValues(query["taskId"])
+
+	for _, eventType := range splitValues(query["type"]) {
+		if !slices.Contains(taskcore.EventTypes(), taskcore.EventType(eventType)) {
+			validation.add("type", violationInvalid, fmt.Sprintf("unknown event type %q", eventType))
+		}
+		filter.Types = append(filter.Types, taskcore.EventType(eventType))
+	}
+
+	for _, value := range splitValues(query["tag"]) {
+		tag, err := taskcore.NormalizeTag(value)
+		if err != nil {
+			validation.add("tag", violationInvalid, fmt.Sprintf("invalid tag %q", value))
+			continue
+		}
+		filter.Tags = append(filter.Tags, tag)
+	}
+
+	name, value := "Last-Event-ID", r.Header.Get("Last-Event-ID")
+	if value == "" {
+		name, value = "lastEventId", query.Get("lastEventId")
+	}
+	if value != "" {
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || id < 1 {
+			validation.add(name, violationInvalid, fmt.Sprintf("invalid event ID %q, must be a positive integer", value))
+		}
+		lastID = id
+	}
+
+	return filter, lastID, validation.errOrNil()
+}
+
+// resolveUsers replaces me with the ID of the user of the context.
+func resolveUsers(ctx context.Context, ids []string) ([]string, error) {
+	for i, id := range ids {
+		if id != currentUser {
+			continue
+		}
+
+		user, ok := taskcore.UserFromContext(ctx)
+		if !ok {
+			return nil, errors.New("me requires an authenticated caller")
+		}
+		ids[i] = user.ID
+	}
+
+	return ids, nil
+}
+
+func splitValues(values []string) []string {
+	var result []string
+	for _, value := range values {
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				result = append(result, v)
+			}
+		}
+	}
+
+	return result
+}
+
+func parseTimeParam(query url.Values, name string) (*time.Time, error) {
+	value := query.Get(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q, must be in RFC 3339 format", name, value)
+	}
+
+	return &t, nil
+}
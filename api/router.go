@@ -12,10 +12,83 @@ func NewTaskRouter(handler Handler, middlewares ...middleware.Middleware) http.H
 	router := http.NewServeMux()
 	router.HandleFunc("POST /tasks", handler.Create)
 	router.HandleFunc("GET /tasks", handler.List)
+	router.HandleFunc("POST /tasks:batch", handler.Batch)
+	router.HandleFunc("GET /tasks/search", handler.Search)
 	router.HandleFunc("GET /tasks/{id}", handler.Get)
 	router.HandleFunc("PATCH /tasks/{id}", handler.Update)
 	router.HandleFunc("DELETE /tasks/{id}", handler.Delete)
+	router.HandleFunc("POST /tasks/{id}/move", handler.Move)
+	router.HandleFunc("GET /tasks/{id}/subtasks", handler.ListSubtasks)
+	router.HandleFunc("GET /tasks/{id}/history", handler.History)
+	router.HandleFunc("GET /tasks/order", handler.Order)
+	router.HandleFunc("GET /events", handler.Events)
+	router.HandleFunc("GET /events/ws", handler.EventsWebSocket)
+	router.HandleFunc("GET /tags", handler.ListTags)
+	router.HandleFunc("PATCH /tags/{tag}", handler.RenameTag)
+	router.HandleFunc("POST /tags/{tag}/merge", handler.MergeTags)
 	router.HandleFunc("POST /chat", handler.Chat)
+	router.HandleFunc("GET /chat/{sessionId}", handler.GetChatSession)
+	router.HandleFunc("DELETE /chat/{sessionId}", handler.DeleteChatSession)
+	router.HandleFunc("GET /chat/{sessionId}/changes", handler.GetChatChanges)
+	router.HandleFunc("DELETE /chat/{sessionId}/changes", handler.DiscardChatChanges)
+	router.HandleFunc("POST /chat/{sessionId}/commit", handler.CommitChatChanges)
+
+	return middleware.Bind(router, middlewares...)
+}
+
+// NewWebhookRouter creates a new HTTP router for webhook-related endpoints.
+func NewWebhookRouter(handler WebhookHandler, middlewares ...middleware.Middleware) http.Handler {
+	router := http.NewServeMux()
+	router.HandleFunc("POST /webhooks", handler.Create)
+	router.HandleFunc("GET /webhooks", handler.List)
+	router.HandleFunc("GET /webhooks/dead-letters", handler.ListDeadLetters)
+	router.HandleFunc("GET /webhooks/{id}", handler.Get)
+	router.HandleFunc("DELETE /webhooks/{id}", handler.Delete)
+	router.HandleFunc("GET /webhooks/{id}/deliveries", handler.ListDeliveries)
+
+	return middleware.Bind(router, middlewares...)
+}
+
+// NewProjectRouter creates a new HTTP router for project-related endpoints.
+func NewProjectRouter(handler ProjectHandler, middlewares ...middleware.Middleware) http.Handler {
+	router := http.NewServeMux()
+	router.HandleFunc("POST /projects", handler.Create)
+	router.HandleFunc("GET /projects", handler.List)
+	router.HandleFunc("GET /projects/{key}", handler.Get)
+	router.HandleFunc("PATCH /projects/{key}", handler.Update)
+	router.HandleFunc("DELETE /projects/{key}", handler.Delete)
+	router.HandleFunc("GET /projects/{key}/tasks", handler.ListTasks)
+	router.HandleFunc("POST /projects/{key}/tasks", handler.CreateTask)
+
+	return middleware.Bind(router, middlewares...)
+}
+
+// NewViewRouter creates a new HTTP router for saved view endpoints.
+func NewViewRouter(handler ViewHandler, middlewares ...middleware.Middleware) http.Handler {
+	router := http.NewServeMux()
+	router.HandleFunc("POST /views", handler.Create)
+	router.HandleFunc("GET /views", handler.List)
+	router.HandleFunc("GET /views/{name}", handler.Get)
+	router.HandleFunc("DELETE /views/{name}", handler.Delete)
+	router.HandleFunc("GET /views/{name}/tasks", handler.ListTasks)
+
+	return middleware.Bind(router, middlewares...)
+}
+
+// NewTransferRouter creates a new HTTP router for task export and import endpoints.
+func NewTransferRouter(handler TransferHandler, middlewares ...middleware.Middleware) http.Handler {
+	router := http.NewServeMux()
+	router.HandleFunc("GET /tasks/export", handler.Export)
+	router.HandleFunc("POST /tasks/import", handler.Import)
+
+	return middleware.Bind(router, middlewares...)
+}
+
+// NewCalendarRouter creates a new HTTP router for calendar feed endpoints.
+func NewCalendarRouter(handler CalendarHandler, middlewares ...middleware.Middleware) http.Handler {
+	router := http.NewServeMux()
+	router.HandleFunc("GET "+calendarFeedPath, handler.Feed)
+	router.HandleFunc("GET /calendar/subscription", handler.Subscription)
 
 	return middleware.Bind(router, middlewares...)
 }
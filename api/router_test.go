@@ -28,6 +28,23 @@ func TestRouter(t *testing.T) {
 		assert.Equal(t, http.StatusOK, rw.Code)
 	})
 
+	t.Run("POST /tasks:batch", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockHandler(mockCtrl)
+		router := NewRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodPost, "/tasks:batch", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().Batch(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+
 	t.Run("GET /tasks", func(t *testing.T) {
 		mockCtrl := gomock.NewController(t)
 		defer mockCtrl.Finish()
@@ -96,6 +113,176 @@ func TestRouter(t *testing.T) {
 		assert.Equal(t, http.StatusOK, rw.Code)
 	})
 
+	t.Run("POST /tasks/{id}/move", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockHandler(mockCtrl)
+		router := NewRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodPost, "/tasks/123/move", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().Move(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("GET /tasks/search", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockHandler(mockCtrl)
+		router := NewRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodGet, "/tasks/search?q=report", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().Search(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("GET /tasks/{id}/subtasks", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockHandler(mockCtrl)
+		router := NewRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodGet, "/tasks/123/subtasks", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().ListSubtasks(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("GET /tasks/order", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockHandler(mockCtrl)
+		router := NewRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodGet, "/tasks/order", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().Order(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("GET /tasks/{id}/history", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockHandler(mockCtrl)
+		router := NewRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodGet, "/tasks/123/history", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().History(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("GET /events", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockHandler(mockCtrl)
+		router := NewRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodGet, "/events", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().Events(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("GET /events/ws", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockHandler(mockCtrl)
+		router := NewRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodGet, "/events/ws", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().EventsWebSocket(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("GET /tags", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockHandler(mockCtrl)
+		router := NewRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodGet, "/tags", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().ListTags(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("PATCH /tags/{tag}", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockHandler(mockCtrl)
+		router := NewRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodPatch, "/tags/backend", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().RenameTag(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("POST /tags/{tag}/merge", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockHandler(mockCtrl)
+		router := NewRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodPost, "/tags/server/merge", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().MergeTags(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+
 	t.Run("POST /chat", func(t *testing.T) {
 		mockCtrl := gomock.NewController(t)
 		defer mockCtrl.Finish()
@@ -109,6 +296,474 @@ func TestRouter(t *testing.T) {
 
 		handler.EXPECT().Chat(rw, req)
 
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+	t.Run("GET /chat/{sessionId}", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockHandler(mockCtrl)
+		router := NewRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodGet, "/chat/session-1", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().GetChatSession(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("DELETE /chat/{sessionId}", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockHandler(mockCtrl)
+		router := NewRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodDelete, "/chat/session-1", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().DeleteChatSession(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("GET /chat/{sessionId}/changes", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockHandler(mockCtrl)
+		router := NewRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodGet, "/chat/session-1/changes", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().GetChatChanges(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("DELETE /chat/{sessionId}/changes", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockHandler(mockCtrl)
+		router := NewRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodDelete, "/chat/session-1/changes", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().DiscardChatChanges(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("POST /chat/{sessionId}/commit", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockHandler(mockCtrl)
+		router := NewRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodPost, "/chat/session-1/commit", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().CommitChatChanges(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+}
+
+func TestWebhookRouter(t *testing.T) {
+	t.Run("POST /webhooks", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockWebhookHandler(mockCtrl)
+		router := NewWebhookRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodPost, "/webhooks", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().Create(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("GET /webhooks", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockWebhookHandler(mockCtrl)
+		router := NewWebhookRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodGet, "/webhooks", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().List(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("GET /webhooks/dead-letters", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockWebhookHandler(mockCtrl)
+		router := NewWebhookRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodGet, "/webhooks/dead-letters", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().ListDeadLetters(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("GET /webhooks/{id}", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockWebhookHandler(mockCtrl)
+		router := NewWebhookRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodGet, "/webhooks/WH-000001", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().Get(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("DELETE /webhooks/{id}", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockWebhookHandler(mockCtrl)
+		router := NewWebhookRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodDelete, "/webhooks/WH-000001", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().Delete(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("GET /webhooks/{id}/deliveries", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockWebhookHandler(mockCtrl)
+		router := NewWebhookRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodGet, "/webhooks/WH-000001/deliveries", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().ListDeliveries(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+}
+
+func TestProjectRouter(t *testing.T) {
+	t.Run("POST /projects", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockProjectHandler(mockCtrl)
+		router := NewProjectRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodPost, "/projects", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().Create(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("GET /projects", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockProjectHandler(mockCtrl)
+		router := NewProjectRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodGet, "/projects", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().List(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("GET /projects/{key}", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockProjectHandler(mockCtrl)
+		router := NewProjectRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodGet, "/projects/WEB", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().Get(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("PATCH /projects/{key}", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockProjectHandler(mockCtrl)
+		router := NewProjectRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodPatch, "/projects/WEB", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().Update(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("DELETE /projects/{key}", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockProjectHandler(mockCtrl)
+		router := NewProjectRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodDelete, "/projects/WEB", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().Delete(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("GET /projects/{key}/tasks", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockProjectHandler(mockCtrl)
+		router := NewProjectRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodGet, "/projects/WEB/tasks", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().ListTasks(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("POST /projects/{key}/tasks", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockProjectHandler(mockCtrl)
+		router := NewProjectRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodPost, "/projects/WEB/tasks", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().CreateTask(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+}
+
+func TestViewRouter(t *testing.T) {
+	t.Run("POST /views", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockViewHandler(mockCtrl)
+		router := NewViewRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodPost, "/views", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().Create(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("GET /views", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockViewHandler(mockCtrl)
+		router := NewViewRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodGet, "/views", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().List(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("GET /views/{name}", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockViewHandler(mockCtrl)
+		router := NewViewRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodGet, "/views/open-bugs", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().Get(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("DELETE /views/{name}", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockViewHandler(mockCtrl)
+		router := NewViewRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodDelete, "/views/open-bugs", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().Delete(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("GET /views/{name}/tasks", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockViewHandler(mockCtrl)
+		router := NewViewRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodGet, "/views/open-bugs/tasks", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().ListTasks(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+}
+
+func TestTransferRouter(t *testing.T) {
+	t.Run("GET /tasks/export", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockTransferHandler(mockCtrl)
+		router := NewTransferRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodGet, "/tasks/export?format=csv", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().Export(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("POST /tasks/import", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockTransferHandler(mockCtrl)
+		router := NewTransferRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodPost, "/tasks/import?dryRun=true", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().Import(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+}
+
+func TestCalendarRouter(t *testing.T) {
+	t.Run("GET /tasks.ics", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockCalendarHandler(mockCtrl)
+		router := NewCalendarRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodGet, "/tasks.ics?token=abc", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().Feed(rw, req)
+
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("GET /calendar/subscription", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := NewMockCalendarHandler(mockCtrl)
+		router := NewCalendarRouter(handler)
+		rw := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodGet, "/calendar/subscription", nil)
+		require.NoError(t, err)
+
+		handler.EXPECT().Subscription(rw, req)
+
 		router.ServeHTTP(rw, req)
 		assert.Equal(t, http.StatusOK, rw.Code)
 	})
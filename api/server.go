@@ -1,43 +1,327 @@
 package api
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
+	swagger "github.com/swaggo/http-swagger"
 	assistant1 "github.com/utsabbera/task-master/core/assistant"
 	"github.com/utsabbera/task-master/core/task"
+	"github.com/utsabbera/task-master/core/webhook"
 	"github.com/utsabbera/task-master/pkg/assistant"
+	"github.com/utsabbera/task-master/pkg/database"
 	"github.com/utsabbera/task-master/pkg/idgen"
 	"github.com/utsabbera/task-master/pkg/middleware"
 	"github.com/utsabbera/task-master/pkg/util"
 )
 
+const (
+	// StorageMemory keeps the tasks in memory, they are lost on restart.
+	StorageMemory = "memory"
+	// StorageSQLite stores the tasks in a SQLite database.
+	StorageSQLite = "sqlite"
+)
+
+const (
+	taskIDPrefix     = task.DefaultProjectKey + "-"
+	webhookIDPrefix  = "WH-"
+	deliveryIDPrefix = "DLV-"
+)
+
 // ServerConfig holds the configuration for the API server.
 type ServerConfig struct {
 	Addr      string
 	Assistant assistant.Config
+	// SessionTTL is the duration of inactivity after which a chat session expires.
+	SessionTTL time.Duration
+	// Storage selects where the tasks are stored.
+	Storage StorageConfig
+	// Webhooks holds the retry policy of the webhook deliveries.
+	Webhooks webhook.Config
+	// Auth holds the credentials accepted by the API, every request is allowed when none are configured.
+	Auth AuthConfig
+	// AllowedOrigins are the origins, besides the host of the API, of the web pages allowed to open
+	// the WebSocket change feed, e.g. https://app.example.com.
+	AllowedOrigins []string
+	// PublicURL is the URL the clients reach the API at, e.g. https://tasks.example.com, which the calendar
+	// subscription URLs start with. The scheme and host of each request are used when it is empty.
+	PublicURL string
+}
+
+// StorageConfig holds the configuration for the task storage.
+type StorageConfig struct {
+	// Driver is either StorageMemory or StorageSQLite, defaults to StorageMemory.
+	Driver string
+	// DSN is the data source name of the database, e.g. the path of the SQLite file.
+	DSN string
 }
 
 // NewServer returns a configured http.Server for the API.
-func NewServer(cfg ServerConfig) *http.Server {
+// The storage is opened immediately and closed when the server shuts down.
+func NewServer(cfg ServerConfig) (*http.Server, error) {
 	addr := cfg.Addr
 	if addr == "" {
 		addr = ":8080"
 	}
 
-	repo := task.NewMemoryRepository()
-	idGen := idgen.NewSequential("TASK-", 1, 6)
+	sessionTTL := cfg.SessionTTL
+	if sessionTTL == 0 {
+		sessionTTL = 30 * time.Minute
+	}
+
+	publicURL, err := parsePublicURL(cfg.PublicURL)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
 	clock := util.NewClock()
-	taskService := task.NewService(repo, idGen, clock)
-	assistant := assistant.NewClient(cfg.Assistant)
-	assistantService := assistant1.NewService(taskService, assistant)
-	handler := NewHandler(taskService, assistantService)
+
+	stored, err := newStorage(ctx, cfg.Storage, clock)
+	if err != nil {
+		return nil, err
+	}
+	closeRepo := stored.close
+
+	repo, err := task.NewIndexedRepository(ctx, stored.tasks)
+	if err != nil {
+		_ = closeRepo()
+		return nil, err
+	}
+
+	next, err := nextSequence(ctx, stored.events, taskIDPrefix)
+	if err != nil {
+		_ = closeRepo()
+		return nil, err
+	}
+
+	idGen := idgen.NewSequential(taskIDPrefix, next, 6)
+	taskService := task.NewServiceWithProjects(repo, idGen, task.NewProjects(stored.projects), clock)
+	projectService := task.NewProjectService(stored.projects, repo, clock)
+	userTaskService := taskService
+	if cfg.Auth.Enabled() {
+		userTaskService = task.NewAccessControl(taskService)
+		projectService = task.NewProjectAccessControl(projectService)
+	}
+	viewService := task.NewViewService(stored.views, userTaskService, clock)
+	if cfg.Auth.Enabled() {
+		viewService = task.NewViewAccessControl(viewService)
+	}
+
+	sessions := assistant.NewMemorySessionStore(sessionTTL, clock)
+	assistant := assistant.NewClient(cfg.Assistant, sessions)
+	assistantService := assistant1.NewService(userTaskService, assistant, clock)
+	handler := NewHandlerWithOrigins(userTaskService, assistantService, cfg.AllowedOrigins)
+	projectHandler := NewProjectHandler(projectService, userTaskService)
+	viewHandler := NewViewHandler(viewService, userTaskService)
+	transferService := task.NewTransferService(userTaskService, clock)
+	transferHandler := NewTransferHandler(transferService)
+
+	var feedTokens *middleware.URLTokens
+	if cfg.Auth.Enabled() {
+		if feedTokens, err = cfg.Auth.feedTokens(clock); err != nil {
+			_ = closeRepo()
+			return nil, err
+		}
+	}
+	calendarHandler := NewCalendarHandler(transferService, feedTokens, publicURL)
+
+	nextWebhook, nextDelivery, err := nextWebhookSequences(ctx, stored.webhooks)
+	if err != nil {
+		_ = closeRepo()
+		return nil, err
+	}
+
+	webhookService := webhook.NewService(stored.webhooks, idgen.NewSequential(webhookIDPrefix, nextWebhook, 6), clock)
+	if cfg.Auth.Enabled() {
+		webhookService = webhook.NewAccessControl(webhookService)
+	}
+	webhookHandler := NewWebhookHandler(webhookService)
+	dispatcher := webhook.NewDispatcher(taskService, stored.webhooks, idgen.NewSequential(deliveryIDPrefix, nextDelivery, 6), clock, encodeNotification, cfg.Webhooks)
+
+	dispatchCtx, stopDispatch := context.WithCancel(ctx)
+	if err := dispatcher.Start(dispatchCtx); err != nil {
+		stopDispatch()
+		_ = closeRepo()
+		return nil, err
+	}
 
 	middlewares := []middleware.Middleware{
 		middleware.Log(),
 	}
+	feedMiddlewares := middlewares
+	if cfg.Auth.Enabled() {
+		middlewares = []middleware.Middleware{
+			cfg.Auth.withUser(),
+			cfg.Auth.middleware(),
+			middleware.Log(),
+		}
+		feedMiddlewares = []middleware.Middleware{
+			cfg.Auth.withUser(),
+			cfg.Auth.feedMiddleware(feedTokens),
+			middleware.Log(),
+		}
+	}
 
-	return &http.Server{
+	router := http.NewServeMux()
+	router.Handle("/webhooks", NewWebhookRouter(webhookHandler, middlewares...))
+	router.Handle("/webhooks/", NewWebhookRouter(webhookHandler, middlewares...))
+	router.Handle("/projects", NewProjectRouter(projectHandler, middlewares...))
+	router.Handle("/projects/", NewProjectRouter(projectHandler, middlewares...))
+	router.Handle("/views", NewViewRouter(viewHandler, middlewares...))
+	router.Handle("/views/", NewViewRouter(viewHandler, middlewares...))
+	router.Handle("/tasks/export", NewTransferRouter(transferHandler, middlewares...))
+	router.Handle("/tasks/import", NewTransferRouter(transferHandler, middlewares...))
+	router.Handle(calendarFeedPath, NewCalendarRouter(calendarHandler, feedMiddlewares...))
+	router.Handle("/calendar/subscription", NewCalendarRouter(calendarHandler, middlewares...))
+	router.Handle("/", NewRouter(handler, middlewares...))
+	if cfg.Auth.Enabled() && !cfg.Auth.PublicDocs {
+		router.Handle("/swagger/", middleware.Bind(swagger.WrapHandler, cfg.Auth.middleware()))
+	}
+
+	server := &http.Server{
 		Addr:    addr,
-		Handler: NewRouter(handler, middlewares...),
+		Handler: router,
 	}
+	server.RegisterOnShutdown(func() {
+		stopDispatch()
+		_ = closeRepo()
+	})
+
+	return server, nil
+}
+
+// storage holds the repositories opened in the configured storage.
+type storage struct {
+	tasks    task.Repository
+	events   task.EventStore
+	projects task.ProjectRepository
+	views    task.ViewRepository
+	webhooks webhook.Repository
+	close    func() error
+}
+
+// newStorage opens the event log of the tasks, the projects, the views and the webhooks in the configured storage.
+// A SQLite database keeps the tasks in a table projecting the event log, which is queried directly.
+func newStorage(ctx context.Context, cfg StorageConfig, clock util.Clock) (*storage, error) {
+	switch cfg.Driver {
+	case "", StorageMemory:
+		events := task.NewMemoryEventStore()
+		repo, err := task.NewEventRepository(ctx, events, clock, task.DefaultSnapshotInterval)
+		if err != nil {
+			return nil, err
+		}
+
+		return &storage{
+			tasks:    repo,
+			events:   events,
+			projects: task.NewMemoryProjectRepository(),
+			views:    task.NewMemoryViewRepository(),
+			webhooks: webhook.NewMemoryRepository(),
+			close:    func() error { return nil },
+		}, nil
+	case StorageSQLite:
+		db, err := database.OpenSQLite(ctx, cfg.DSN)
+		if err != nil {
+			return nil, err
+		}
+
+		repo, err := task.NewSQLEventRepository(ctx, db, clock)
+		if err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+
+		events, err := task.NewSQLEventStore(ctx, db)
+		if err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+
+		projects, err := task.NewSQLProjectRepository(ctx, db)
+		if err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+
+		views, err := task.NewSQLViewRepository(ctx, db)
+		if err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+
+		webhooks, err := webhook.NewSQLRepository(ctx, db)
+		if err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+
+		return &storage{tasks: repo, events: events, projects: projects, views: views, webhooks: webhooks, close: db.Close}, nil
+	default:
+		return nil, fmt.Errorf("unsupported storage driver %q", cfg.Driver)
+	}
+}
+
+// parsePublicURL parses the URL the clients reach the API at, nil when it is empty.
+func parsePublicURL(value string) (*url.URL, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.RawQuery != "" || u.Fragment != "" {
+		return nil, fmt.Errorf("invalid public URL %q, expected an absolute http or https URL without query", value)
+	}
+
+	return u, nil
+}
+
+// nextSequence returns the sequence the IDs of the new tasks continue from, past the IDs of every task
+// of the event log so that the IDs of the deleted and moved tasks are not issued again
+func nextSequence(ctx context.Context, events task.EventStore, prefix string) (int, error) {
+	ids, err := events.TaskIDs(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error listing task IDs: %w", err)
+	}
+
+	return nextOf(ids, prefix), nil
+}
+
+// nextWebhookSequences returns the sequences the IDs of the new webhooks and deliveries continue from,
+// past the IDs of the deleted webhooks and deliveries
+func nextWebhookSequences(ctx context.Context, repo webhook.Repository) (int, int, error) {
+	ids, err := repo.IssuedIDs(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("error listing webhook IDs: %w", err)
+	}
+
+	return nextOf(ids, webhookIDPrefix), nextOf(ids, deliveryIDPrefix), nil
+}
+
+// nextOf returns the sequence following the highest sequence of the IDs with the given prefix
+func nextOf(ids []string, prefix string) int {
+	next := 1
+	for _, id := range ids {
+		suffix, found := strings.CutPrefix(id, prefix)
+		if !found {
+			continue
+		}
+
+		if n, err := strconv.Atoi(suffix); err == nil && n >= next {
+			next = n + 1
+		}
+	}
+
+	return next
+}
+
+// encodeNotification encodes a change of a task as posted to the webhooks, the same as the events of the change feed.
+func encodeNotification(n task.Notification) ([]byte, error) {
+	return json.Marshal(mapNotificationToResponse(n))
 }
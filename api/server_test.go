@@ -1,11 +1,19 @@
 package api
 
 import (
+	"bufio"
 	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -13,16 +21,21 @@ import (
 	"github.com/stretchr/testify/require"
 	coreassistant "github.com/utsabbera/task-master/core/assistant"
 	"github.com/utsabbera/task-master/core/task"
+	"github.com/utsabbera/task-master/core/webhook"
 	"github.com/utsabbera/task-master/pkg/assistant"
+	"github.com/utsabbera/task-master/pkg/database"
 	"github.com/utsabbera/task-master/pkg/idgen"
+	"github.com/utsabbera/task-master/pkg/middleware"
 	"github.com/utsabbera/task-master/pkg/util"
+	"golang.org/x/net/websocket"
 )
 
 func TestNewServer(t *testing.T) {
 	t.Run("should return configured HTTP server", func(t *testing.T) {
 		cfg := ServerConfig{Addr: ":9090"}
-		server := NewServer(cfg)
+		server, err := NewServer(cfg)
 
+		require.NoError(t, err)
 		assert.NotNil(t, server)
 		assert.Equal(t, ":9090", server.Addr)
 		assert.NotNil(t, server.Handler)
@@ -30,10 +43,84 @@ func TestNewServer(t *testing.T) {
 
 	t.Run("should use default Addr when empty", func(t *testing.T) {
 		cfg := ServerConfig{Addr: ""}
-		server := NewServer(cfg)
+		server, err := NewServer(cfg)
 
+		require.NoError(t, err)
 		assert.Equal(t, ":8080", server.Addr)
 	})
+
+	t.Run("should return error for unsupported storage driver", func(t *testing.T) {
+		cfg := ServerConfig{Storage: StorageConfig{Driver: "oracle"}}
+		server, err := NewServer(cfg)
+
+		assert.Error(t, err)
+		assert.Nil(t, server)
+	})
+
+	t.Run("should return error for invalid public URL", func(t *testing.T) {
+		for _, publicURL := range []string{"tasks.example.com", "ftp://tasks.example.com", "https://tasks.example.com?a=b"} {
+			server, err := NewServer(ServerConfig{PublicURL: publicURL})
+
+			assert.Error(t, err, publicURL)
+			assert.Nil(t, server)
+		}
+	})
+
+	t.Run("should continue task IDs stored in SQLite database", func(t *testing.T) {
+		dsn := filepath.Join(t.TempDir(), "tasks.db")
+		cfg := ServerConfig{Storage: StorageConfig{Driver: StorageSQLite, DSN: dsn}}
+
+		server, err := NewServer(cfg)
+		require.NoError(t, err)
+		ts := httptest.NewServer(server.Handler)
+		createTask(t, ts.URL, "First")
+		ts.Close()
+		require.NoError(t, server.Shutdown(context.Background()))
+
+		server, err = NewServer(cfg)
+		require.NoError(t, err)
+		ts = httptest.NewServer(server.Handler)
+		defer ts.Close()
+		defer server.Shutdown(context.Background())
+
+		created := createTask(t, ts.URL, "Second")
+		assert.Equal(t, "TASK-000002", created.ID)
+
+		resp, err := http.Get(ts.URL + "/tasks")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var tasks []Task
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&tasks))
+		assert.Len(t, tasks, 2)
+	})
+}
+
+func TestNewStorage(t *testing.T) {
+	t.Run("should filter the tasks of a SQLite database in SQL", func(t *testing.T) {
+		ctx := context.Background()
+		dsn := filepath.Join(t.TempDir(), "tasks.db")
+		stored, err := newStorage(ctx, StorageConfig{Driver: StorageSQLite, DSN: dsn}, util.NewClock())
+		require.NoError(t, err)
+		defer stored.close()
+
+		require.NoError(t, stored.tasks.Create(ctx, &task.Task{ID: "TASK-000001", Title: "Fix login", Status: task.StatusNotStarted, Priority: util.Ptr(task.PriorityLow)}))
+		require.NoError(t, stored.tasks.Create(ctx, &task.Task{ID: "TASK-000002", Title: "Fix footer", Status: task.StatusNotStarted, Priority: util.Ptr(task.PriorityLow)}))
+
+		db, err := database.OpenSQLite(ctx, dsn)
+		require.NoError(t, err)
+		_, err = db.ExecContext(ctx, `UPDATE tasks SET priority = 'HIGH' WHERE id = 'TASK-000002'`)
+		require.NoError(t, err)
+		require.NoError(t, db.Close())
+
+		expr, err := task.ParseExpression("priority >= HIGH")
+		require.NoError(t, err)
+		page, err := stored.tasks.List(ctx, task.ListOptions{Filter: task.Filter{Expression: expr}})
+		require.NoError(t, err)
+
+		require.Len(t, page.Tasks, 1)
+		assert.Equal(t, "TASK-000002", page.Tasks[0].ID)
+	})
 }
 
 func TestIntegration_Server(t *testing.T) {
@@ -45,9 +132,9 @@ func TestIntegration_Server(t *testing.T) {
 		clock := util.NewClock()
 		repo := task.NewMemoryRepository()
 		assistantConfig := assistant.Config{BaseURL: testAssistantServer.URL, Model: "echo"}
-		assistantClient := assistant.NewClient(assistantConfig)
+		assistantClient := assistant.NewClient(assistantConfig, assistant.NewMemorySessionStore(0, clock))
 		taskService := task.NewService(repo, idGen, clock)
-		chatService := coreassistant.NewService(taskService, assistantClient)
+		chatService := coreassistant.NewService(taskService, assistantClient, clock)
 		handler := NewHandler(taskService, chatService)
 		router := NewRouter(handler)
 
@@ -84,9 +171,9 @@ func TestIntegration_Server(t *testing.T) {
 		clock := util.NewClock()
 		repo := task.NewMemoryRepository()
 		assistantConfig := assistant.Config{BaseURL: testAssistantServer.URL, Model: "echo"}
-		assistantClient := assistant.NewClient(assistantConfig)
+		assistantClient := assistant.NewClient(assistantConfig, assistant.NewMemorySessionStore(0, clock))
 		taskService := task.NewService(repo, idGen, clock)
-		chatService := coreassistant.NewService(taskService, assistantClient)
+		chatService := coreassistant.NewService(taskService, assistantClient, clock)
 		handler := NewHandler(taskService, chatService)
 		router := NewRouter(handler)
 
@@ -125,9 +212,9 @@ func TestIntegration_Server(t *testing.T) {
 		clock := util.NewClock()
 		repo := task.NewMemoryRepository()
 		assistantConfig := assistant.Config{BaseURL: testAssistantServer.URL, Model: "echo"}
-		assistantClient := assistant.NewClient(assistantConfig)
+		assistantClient := assistant.NewClient(assistantConfig, assistant.NewMemorySessionStore(0, clock))
 		taskService := task.NewService(repo, idGen, clock)
-		chatService := coreassistant.NewService(taskService, assistantClient)
+		chatService := coreassistant.NewService(taskService, assistantClient, clock)
 		handler := NewHandler(taskService, chatService)
 		router := NewRouter(handler)
 
@@ -166,9 +253,9 @@ func TestIntegration_Server(t *testing.T) {
 		clock := util.NewClock()
 		repo := task.NewMemoryRepository()
 		assistantConfig := assistant.Config{BaseURL: testAssistantServer.URL, Model: "echo"}
-		assistantClient := assistant.NewClient(assistantConfig)
+		assistantClient := assistant.NewClient(assistantConfig, assistant.NewMemorySessionStore(0, clock))
 		taskService := task.NewService(repo, idGen, clock)
-		chatService := coreassistant.NewService(taskService, assistantClient)
+		chatService := coreassistant.NewService(taskService, assistantClient, clock)
 		handler := NewHandler(taskService, chatService)
 		router := NewRouter(handler)
 
@@ -217,9 +304,9 @@ func TestIntegration_Server(t *testing.T) {
 		clock := util.NewClock()
 		repo := task.NewMemoryRepository()
 		assistantConfig := assistant.Config{BaseURL: testAssistantServer.URL, Model: "echo"}
-		assistantClient := assistant.NewClient(assistantConfig)
+		assistantClient := assistant.NewClient(assistantConfig, assistant.NewMemorySessionStore(0, clock))
 		taskService := task.NewService(repo, idGen, clock)
-		chatService := coreassistant.NewService(taskService, assistantClient)
+		chatService := coreassistant.NewService(taskService, assistantClient, clock)
 		handler := NewHandler(taskService, chatService)
 		router := NewRouter(handler)
 
@@ -274,9 +361,9 @@ func TestIntegration_Server(t *testing.T) {
 		clock := util.NewClock()
 		repo := task.NewMemoryRepository()
 		assistantConfig := assistant.Config{BaseURL: testAssistantServer.URL, Model: "echo"}
-		assistantClient := assistant.NewClient(assistantConfig)
+		assistantClient := assistant.NewClient(assistantConfig, assistant.NewMemorySessionStore(0, clock))
 		taskService := task.NewService(repo, idGen, clock)
-		chatService := coreassistant.NewService(taskService, assistantClient)
+		chatService := coreassistant.NewService(taskService, assistantClient, clock)
 		handler := NewHandler(taskService, chatService)
 		router := NewRouter(handler)
 
@@ -338,9 +425,9 @@ func TestIntegration_Server(t *testing.T) {
 		clock := util.NewClock()
 		repo := task.NewMemoryRepository()
 		assistantConfig := assistant.Config{BaseURL: testAssistantServer.URL, Model: "echo"}
-		assistantClient := assistant.NewClient(assistantConfig)
+		assistantClient := assistant.NewClient(assistantConfig, assistant.NewMemorySessionStore(0, clock))
 		taskService := task.NewService(repo, idGen, clock)
-		chatService := coreassistant.NewService(taskService, assistantClient)
+		chatService := coreassistant.NewService(taskService, assistantClient, clock)
 		handler := NewHandler(taskService, chatService)
 		router := NewRouter(handler)
 
@@ -387,16 +474,16 @@ func TestIntegration_Server(t *testing.T) {
 		clock := util.NewClock()
 		repo := task.NewMemoryRepository()
 		assistantConfig := assistant.Config{BaseURL: testAssistantServer.URL, Model: "echo"}
-		assistantClient := assistant.NewClient(assistantConfig)
+		assistantClient := assistant.NewClient(assistantConfig, assistant.NewMemorySessionStore(0, clock))
 		taskService := task.NewService(repo, idGen, clock)
-		chatService := coreassistant.NewService(taskService, assistantClient)
+		chatService := coreassistant.NewService(taskService, assistantClient, clock)
 		handler := NewHandler(taskService, chatService)
 		router := NewRouter(handler)
 
 		ts := httptest.NewServer(router)
 		defer ts.Close()
 
-		message := "Create a task titled 'Buy milk' with description 'Get from store'"
+		message := "Hello, assistant!"
 		body, err := json.Marshal(ChatInput{Text: message})
 		require.NoError(t, err)
 
@@ -413,6 +500,1482 @@ func TestIntegration_Server(t *testing.T) {
 		require.NoError(t, err)
 		response, ok := result["response"].(string)
 		assert.True(t, ok)
-		assert.Contains(t, response, "Task created")
+		assert.Equal(t, message, response)
+	})
+
+	t.Run("should create task through chat tool call", func(t *testing.T) {
+		idGen := idgen.NewSequential("TASK-", 1, 3)
+		clock := util.NewClock()
+		repo := task.NewMemoryRepository()
+		assistantConfig := assistant.Config{BaseURL: testAssistantServer.URL, Model: "tool-call"}
+		assistantClient := assistant.NewClient(assistantConfig, assistant.NewMemorySessionStore(0, clock))
+		taskService := task.NewService(repo, idGen, clock)
+		chatService := coreassistant.NewService(taskService, assistantClient, clock)
+		handler := NewHandler(taskService, chatService)
+		router := NewRouter(handler)
+
+		ts := httptest.NewServer(router)
+		defer ts.Close()
+
+		message := `create_task {"title":"Buy milk","description":"Get from store"}`
+		body, err := json.Marshal(ChatInput{Text: message})
+		require.NoError(t, err)
+
+		resp, err := http.Post(ts.URL+"/chat", "application/json", bytes.NewReader(body))
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		require.NoError(t, resp.Body.Close())
+
+		getResp, err := http.Get(ts.URL + "/tasks/TASK-001")
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, getResp.StatusCode)
+		getBody, err := io.ReadAll(getResp.Body)
+		require.NoError(t, err)
+		require.NoError(t, getResp.Body.Close())
+
+		var created Task
+		err = json.Unmarshal(getBody, &created)
+		require.NoError(t, err)
+
+		assert.Equal(t, "Buy milk", created.Title)
+		assert.Equal(t, "Get from store", created.Description)
+	})
+	t.Run("should keep chat history per session", func(t *testing.T) {
+		idGen := idgen.NewSequential("TASK-", 1, 3)
+		clock := util.NewClock()
+		repo := task.NewMemoryRepository()
+		assistantConfig := assistant.Config{BaseURL: testAssistantServer.URL, Model: "echo"}
+		assistantClient := assistant.NewClient(assistantConfig, assistant.NewMemorySessionStore(0, clock))
+		taskService := task.NewService(repo, idGen, clock)
+		chatService := coreassistant.NewService(taskService, assistantClient, clock)
+		handler := NewHandler(taskService, chatService)
+		router := NewRouter(handler)
+
+		ts := httptest.NewServer(router)
+		defer ts.Close()
+
+		body, err := json.Marshal(ChatInput{Text: "Hello"})
+		require.NoError(t, err)
+
+		resp, err := http.Post(ts.URL+"/chat", "application/json", bytes.NewReader(body))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var chat ChatResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&chat))
+		require.NoError(t, resp.Body.Close())
+		require.NotEmpty(t, chat.SessionID)
+
+		body, err = json.Marshal(ChatInput{SessionID: chat.SessionID, Text: "Bye"})
+		require.NoError(t, err)
+
+		resp, err = http.Post(ts.URL+"/chat", "application/json", bytes.NewReader(body))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.NoError(t, resp.Body.Close())
+
+		getResp, err := http.Get(ts.URL + "/chat/" + chat.SessionID)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, getResp.StatusCode)
+
+		var session ChatSession
+		require.NoError(t, json.NewDecoder(getResp.Body).Decode(&session))
+		require.NoError(t, getResp.Body.Close())
+
+		assert.Equal(t, chat.SessionID, session.ID)
+		assert.Equal(t, []ChatMessage{
+			{Role: "user", Content: "Hello"},
+			{Role: "assistant", Content: "Hello"},
+			{Role: "user", Content: "Bye"},
+			{Role: "assistant", Content: "Bye"},
+		}, session.Messages)
+
+		req, err := http.NewRequest(http.MethodDelete, ts.URL+"/chat/"+chat.SessionID, nil)
+		require.NoError(t, err)
+
+		delResp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, delResp.StatusCode)
+		require.NoError(t, delResp.Body.Close())
+
+		getResp, err = http.Get(ts.URL + "/chat/" + chat.SessionID)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, getResp.StatusCode)
+		require.NoError(t, getResp.Body.Close())
+	})
+
+	t.Run("should stream chat events while creating task", func(t *testing.T) {
+		idGen := idgen.NewSequential("TASK-", 1, 3)
+		clock := util.NewClock()
+		repo := task.NewMemoryRepository()
+		assistantConfig := assistant.Config{BaseURL: testAssistantServer.URL, Model: "tool-call"}
+		assistantClient := assistant.NewClient(assistantConfig, assistant.NewMemorySessionStore(0, clock))
+		taskService := task.NewService(repo, idGen, clock)
+		chatService := coreassistant.NewService(taskService, assistantClient, clock)
+		handler := NewHandler(taskService, chatService)
+		router := NewRouter(handler, middleware.Log())
+
+		ts := httptest.NewServer(router)
+		defer ts.Close()
+
+		body, err := json.Marshal(ChatInput{Text: `create_task {"title":"Buy milk"}`})
+		require.NoError(t, err)
+
+		resp, err := http.Post(ts.URL+"/chat?stream=true", "application/json", bytes.NewReader(body))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		var events []string
+		var message ChatResponse
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			if event, found := strings.CutPrefix(scanner.Text(), "event: "); found {
+				events = append(events, event)
+			}
+			if data, found := strings.CutPrefix(scanner.Text(), "data: "); found && events[len(events)-1] == "message" {
+				require.NoError(t, json.Unmarshal([]byte(data), &message))
+			}
+		}
+		require.NoError(t, scanner.Err())
+
+		require.GreaterOrEqual(t, len(events), 4)
+		assert.Equal(t, []string{"tool_call_start", "tool_call_finish"}, events[:2])
+		assert.Equal(t, "message", events[len(events)-1])
+		for _, event := range events[2 : len(events)-1] {
+			assert.Equal(t, "delta", event)
+		}
+		assert.NotEmpty(t, message.SessionID)
+		assert.Contains(t, message.Response, `"id":"TASK-001"`)
+
+		_, err = taskService.Get(context.Background(), "TASK-001")
+		assert.NoError(t, err)
+	})
+
+	t.Run("should preview chat task operations before committing them", func(t *testing.T) {
+		idGen := idgen.NewSequential("TASK-", 1, 3)
+		clock := util.NewClock()
+		repo := task.NewMemoryRepository()
+		assistantConfig := assistant.Config{BaseURL: testAssistantServer.URL, Model: "tool-call"}
+		assistantClient := assistant.NewClient(assistantConfig, assistant.NewMemorySessionStore(0, clock))
+		taskService := task.NewService(repo, idGen, clock)
+		chatService := coreassistant.NewService(taskService, assistantClient, clock)
+		handler := NewHandler(taskService, chatService)
+		router := NewRouter(handler)
+
+		ts := httptest.NewServer(router)
+		defer ts.Close()
+
+		body, err := json.Marshal(ChatInput{Text: `create_task {"title":"Buy milk"}`, Preview: true})
+		require.NoError(t, err)
+
+		resp, err := http.Post(ts.URL+"/chat", "application/json", bytes.NewReader(body))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var chat ChatResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&chat))
+		require.NoError(t, resp.Body.Close())
+
+		require.Len(t, chat.Changes, 1)
+		assert.Equal(t, task.ChangeCreate, chat.Changes[0].Type)
+		assert.Equal(t, "STAGED-1", chat.Changes[0].TaskID)
+
+		getResp, err := http.Get(ts.URL + "/tasks/TASK-001")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, getResp.StatusCode)
+		require.NoError(t, getResp.Body.Close())
+
+		commitResp, err := http.Post(ts.URL+"/chat/"+chat.SessionID+"/commit", "application/json", nil)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, commitResp.StatusCode)
+
+		var committed []ChatChange
+		require.NoError(t, json.NewDecoder(commitResp.Body).Decode(&committed))
+		require.NoError(t, commitResp.Body.Close())
+
+		require.Len(t, committed, 1)
+		assert.Equal(t, "TASK-001", committed[0].TaskID)
+		assert.Equal(t, "Buy milk", committed[0].After.Title)
+
+		getResp, err = http.Get(ts.URL + "/tasks/TASK-001")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, getResp.StatusCode)
+		require.NoError(t, getResp.Body.Close())
+	})
+}
+
+func TestIntegration_ListTasks(t *testing.T) {
+	t.Run("should filter, sort and paginate tasks", func(t *testing.T) {
+		server, err := NewServer(ServerConfig{})
+		require.NoError(t, err)
+		ts := httptest.NewServer(server.Handler)
+		defer ts.Close()
+
+		for _, title := range []string{"Write report", "Fix login", "Review report", "Send report"} {
+			createTask(t, ts.URL, title)
+		}
+
+		var ids []string
+		next := ts.URL + "/tasks?q=report&sort=-title&limit=2"
+		for next != "" {
+			resp, err := http.Get(next)
+			require.NoError(t, err)
+
+			var tasks []Task
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&tasks))
+			require.NoError(t, resp.Body.Close())
+			assert.Equal(t, "3", resp.Header.Get("X-Total-Count"))
+
+			for _, task := range tasks {
+				ids = append(ids, task.ID)
+			}
+
+			next = ""
+			if cursor := resp.Header.Get("X-Next-Cursor"); cursor != "" {
+				next = ts.URL + "/tasks?q=report&sort=-title&limit=2&cursor=" + cursor
+			}
+		}
+
+		assert.Equal(t, []string{"TASK-000001", "TASK-000004", "TASK-000003"}, ids)
+	})
+}
+
+func TestIntegration_TaskLifecycle(t *testing.T) {
+	t.Run("should move task through status lifecycle", func(t *testing.T) {
+		taskService := task.NewService(task.NewMemoryRepository(), idgen.NewSequential("TASK-", 1, 3), util.NewClock())
+		handler := NewHandler(taskService, nil)
+
+		ts := httptest.NewServer(NewRouter(handler))
+		defer ts.Close()
+
+		created := createTask(t, ts.URL, "Write report")
+		assert.Equal(t, task.StatusNotStarted, created.Status)
+		assert.Nil(t, created.StartedAt)
+
+		resp, started := patchTask(t, ts.URL, created.ID, TaskInput{Status: task.StatusInProgress})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.NotNil(t, started.StartedAt)
+		assert.Nil(t, started.CompletedAt)
+
+		resp, completed := patchTask(t, ts.URL, created.ID, TaskInput{Status: task.StatusCompleted})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, task.StatusCompleted, completed.Status)
+		assert.Equal(t, started.StartedAt, completed.StartedAt)
+		assert.NotNil(t, completed.CompletedAt)
+
+		resp, _ = patchTask(t, ts.URL, created.ID, TaskInput{Status: task.StatusCancelled})
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+		assert.Equal(t, "application/problem+json", resp.Header.Get("Content-Type"))
+
+		resp, _ = patchTask(t, ts.URL, created.ID, TaskInput{Status: "DONE"})
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		resp, reopened := patchTask(t, ts.URL, created.ID, TaskInput{Status: task.StatusInProgress})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Nil(t, reopened.CompletedAt)
+	})
+}
+
+func TestIntegration_ConditionalRequests(t *testing.T) {
+	t.Run("should reject update of a task modified since it was read", func(t *testing.T) {
+		taskService := task.NewService(task.NewMemoryRepository(), idgen.NewSequential("TASK-", 1, 3), util.NewClock())
+		handler := NewHandler(taskService, nil)
+
+		ts := httptest.NewServer(NewRouter(handler))
+		defer ts.Close()
+
+		created := createTask(t, ts.URL, "Write report")
+		assert.Equal(t, 1, created.Version)
+
+		getResp, err := http.Get(ts.URL + "/tasks/" + created.ID)
+		require.NoError(t, err)
+		require.NoError(t, getResp.Body.Close())
+		etag := getResp.Header.Get("ETag")
+		assert.Equal(t, `"1"`, etag)
+
+		resp, updated := patchTask(t, ts.URL, created.ID, TaskInput{Title: "Write quarterly report"})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 2, updated.Version)
+
+		req, err := http.NewRequest(http.MethodPatch, ts.URL+"/tasks/"+created.ID, strings.NewReader(`{"title":"Write annual report"}`))
+		require.NoError(t, err)
+		req.Header.Set("If-Match", etag)
+		staleResp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, staleResp.Body.Close())
+		assert.Equal(t, http.StatusPreconditionFailed, staleResp.StatusCode)
+
+		req, err = http.NewRequest(http.MethodGet, ts.URL+"/tasks/"+created.ID, nil)
+		require.NoError(t, err)
+		req.Header.Set("If-None-Match", resp.Header.Get("ETag"))
+		notModifiedResp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, notModifiedResp.Body.Close())
+		assert.Equal(t, http.StatusNotModified, notModifiedResp.StatusCode)
+	})
+}
+
+func TestIntegration_Tags(t *testing.T) {
+	t.Run("should filter tasks by tags and rename tags", func(t *testing.T) {
+		taskService := task.NewService(task.NewMemoryRepository(), idgen.NewSequential("TASK-", 1, 3), util.NewClock())
+		handler := NewHandler(taskService, nil)
+
+		ts := httptest.NewServer(NewRouter(handler))
+		defer ts.Close()
+
+		for _, body := range []string{
+			`{"title":"Fix login","tags":["#Backend","urgent"]}`,
+			`{"title":"Write docs","tags":["docs"]}`,
+			`{"title":"Add endpoint","tags":["backend","blocked"]}`,
+		} {
+			resp, err := http.Post(ts.URL+"/tasks", "application/json", strings.NewReader(body))
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			require.Equal(t, http.StatusCreated, resp.StatusCode)
+		}
+
+		resp, err := http.Get(ts.URL + "/tasks?tag=backend&tag=!blocked")
+		require.NoError(t, err)
+		var tasks []Task
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&tasks))
+		require.NoError(t, resp.Body.Close())
+		require.Len(t, tasks, 1)
+		assert.Equal(t, "Fix login", tasks[0].Title)
+		assert.Equal(t, []string{"backend", "urgent"}, tasks[0].Tags)
+
+		req, err := http.NewRequest(http.MethodPatch, ts.URL+"/tags/backend", strings.NewReader(`{"name":"server"}`))
+		require.NoError(t, err)
+		renameResp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, renameResp.Body.Close())
+		require.Equal(t, http.StatusOK, renameResp.StatusCode)
+
+		tagsResp, err := http.Get(ts.URL + "/tags")
+		require.NoError(t, err)
+		var tags []Tag
+		require.NoError(t, json.NewDecoder(tagsResp.Body).Decode(&tags))
+		require.NoError(t, tagsResp.Body.Close())
+		assert.Equal(t, []Tag{{"blocked", 1}, {"docs", 1}, {"server", 2}, {"urgent", 1}}, tags)
+	})
+}
+
+func TestIntegration_Subtasks(t *testing.T) {
+	t.Run("should roll up progress of subtasks and delete them in cascade", func(t *testing.T) {
+		cfg := ServerConfig{Storage: StorageConfig{Driver: StorageSQLite, DSN: filepath.Join(t.TempDir(), "tasks.db")}}
+		server, err := NewServer(cfg)
+		require.NoError(t, err)
+		ts := httptest.NewServer(server.Handler)
+		defer ts.Close()
+		defer server.Shutdown(context.Background())
+
+		parent := createTask(t, ts.URL, "Release v2")
+		for _, title := range []string{"Write changelog", "Tag release"} {
+			resp, err := http.Post(ts.URL+"/tasks", "application/json",
+				strings.NewReader(`{"title":"`+title+`","parentId":"`+parent.ID+`"}`))
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			require.Equal(t, http.StatusCreated, resp.StatusCode)
+		}
+
+		resp, _ := patchTask(t, ts.URL, "TASK-000002", TaskInput{Status: task.StatusInProgress})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		resp, _ = patchTask(t, ts.URL, "TASK-000002", TaskInput{Status: task.StatusCompleted})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		resp, _ = patchTask(t, ts.URL, parent.ID, TaskInput{ParentID: util.Ptr("TASK-000003")})
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		getResp, err := http.Get(ts.URL + "/tasks/" + parent.ID)
+		require.NoError(t, err)
+		var fetched Task
+		require.NoError(t, json.NewDecoder(getResp.Body).Decode(&fetched))
+		require.NoError(t, getResp.Body.Close())
+		assert.Equal(t, &TaskProgress{Total: 2, Completed: 1, Percent: 50}, fetched.Progress)
+
+		subtasksResp, err := http.Get(ts.URL + "/tasks/" + parent.ID + "/subtasks")
+		require.NoError(t, err)
+		var subtasks []Task
+		require.NoError(t, json.NewDecoder(subtasksResp.Body).Decode(&subtasks))
+		require.NoError(t, subtasksResp.Body.Close())
+		assert.Len(t, subtasks, 2)
+
+		req, err := http.NewRequest(http.MethodDelete, ts.URL+"/tasks/"+parent.ID, nil)
+		require.NoError(t, err)
+		deleteResp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, deleteResp.Body.Close())
+		assert.Equal(t, http.StatusConflict, deleteResp.StatusCode)
+
+		req, err = http.NewRequest(http.MethodDelete, ts.URL+"/tasks/"+parent.ID+"?mode=cascade", nil)
+		require.NoError(t, err)
+		deleteResp, err = http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, deleteResp.Body.Close())
+		assert.Equal(t, http.StatusNoContent, deleteResp.StatusCode)
+
+		listResp, err := http.Get(ts.URL + "/tasks")
+		require.NoError(t, err)
+		var tasks []Task
+		require.NoError(t, json.NewDecoder(listResp.Body).Decode(&tasks))
+		require.NoError(t, listResp.Body.Close())
+		assert.Empty(t, tasks)
+	})
+}
+
+func TestIntegration_Projects(t *testing.T) {
+	t.Run("should number tasks per project and redirect moved tasks across restarts", func(t *testing.T) {
+		cfg := ServerConfig{Storage: StorageConfig{Driver: StorageSQLite, DSN: filepath.Join(t.TempDir(), "tasks.db")}}
+		server, err := NewServer(cfg)
+		require.NoError(t, err)
+		ts := httptest.NewServer(server.Handler)
+
+		for _, body := range []string{`{"key":"web","name":"Website"}`, `{"key":"OPS","name":"Operations"}`} {
+			resp, err := http.Post(ts.URL+"/projects", "application/json", strings.NewReader(body))
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			require.Equal(t, http.StatusCreated, resp.StatusCode)
+		}
+
+		resp, err := http.Post(ts.URL+"/projects/WEB/tasks", "application/json", strings.NewReader(`{"title":"Landing page"}`))
+		require.NoError(t, err)
+		var created Task
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+		require.NoError(t, resp.Body.Close())
+		assert.Equal(t, "WEB-000001", created.ID)
+		assert.Equal(t, "WEB", created.Project)
+
+		parent := createTask(t, ts.URL, "Deploy")
+		resp, err = http.Post(ts.URL+"/tasks", "application/json", strings.NewReader(`{"title":"Rotate keys","parentId":"`+parent.ID+`"}`))
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		resp, err = http.Post(ts.URL+"/tasks/"+parent.ID+"/move", "application/json", strings.NewReader(`{"project":"ops"}`))
+		require.NoError(t, err)
+		var moved Task
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&moved))
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "OPS-000001", moved.ID)
+		assert.Equal(t, "/tasks/OPS-000001", resp.Header.Get("Location"))
+
+		require.NoError(t, server.Shutdown(context.Background()))
+		ts.Close()
+
+		server, err = NewServer(cfg)
+		require.NoError(t, err)
+		ts = httptest.NewServer(server.Handler)
+		defer ts.Close()
+		defer server.Shutdown(context.Background())
+
+		getResp, err := http.Get(ts.URL + "/tasks/" + parent.ID)
+		require.NoError(t, err)
+		var fetched Task
+		require.NoError(t, json.NewDecoder(getResp.Body).Decode(&fetched))
+		require.NoError(t, getResp.Body.Close())
+		assert.Equal(t, "OPS-000001", fetched.ID)
+
+		subtasksResp, err := http.Get(ts.URL + "/tasks/OPS-000001/subtasks")
+		require.NoError(t, err)
+		var subtasks []Task
+		require.NoError(t, json.NewDecoder(subtasksResp.Body).Decode(&subtasks))
+		require.NoError(t, subtasksResp.Body.Close())
+		assert.Equal(t, []string{"TASK-000002"}, util.Map(subtasks, func(t Task) string { return t.ID }))
+
+		resp, err = http.Post(ts.URL+"/projects/WEB/tasks", "application/json", strings.NewReader(`{"title":"Pricing page"}`))
+		require.NoError(t, err)
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+		require.NoError(t, resp.Body.Close())
+		assert.Equal(t, "WEB-000002", created.ID)
+
+		listResp, err := http.Get(ts.URL + "/projects/WEB/tasks")
+		require.NoError(t, err)
+		var tasks []Task
+		require.NoError(t, json.NewDecoder(listResp.Body).Decode(&tasks))
+		require.NoError(t, listResp.Body.Close())
+		assert.Equal(t, []string{"WEB-000001", "WEB-000002"}, util.Map(tasks, func(t Task) string { return t.ID }))
+
+		req, err := http.NewRequest(http.MethodDelete, ts.URL+"/projects/OPS", nil)
+		require.NoError(t, err)
+		deleteResp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, deleteResp.Body.Close())
+		assert.Equal(t, http.StatusConflict, deleteResp.StatusCode)
+	})
+}
+
+func TestIntegration_Search(t *testing.T) {
+	t.Run("should find tasks by words of their title and description after restart", func(t *testing.T) {
+		cfg := ServerConfig{Storage: StorageConfig{Driver: StorageSQLite, DSN: filepath.Join(t.TempDir(), "tasks.db")}}
+		server, err := NewServer(cfg)
+		require.NoError(t, err)
+		ts := httptest.NewServer(server.Handler)
+
+		for _, body := range []string{
+			`{"title":"Write quarterly report","description":"Numbers for Q3"}`,
+			`{"title":"Buy milk"}`,
+			`{"title":"Plan offsite","description":"Reporting lines and quarterly goals"}`,
+		} {
+			resp, err := http.Post(ts.URL+"/tasks", "application/json", strings.NewReader(body))
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			require.Equal(t, http.StatusCreated, resp.StatusCode)
+		}
+
+		resp, _ := patchTask(t, ts.URL, "TASK-000002", TaskInput{Title: "Buy milk for the quarterly party"})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		require.NoError(t, server.Shutdown(context.Background()))
+		ts.Close()
+
+		server, err = NewServer(cfg)
+		require.NoError(t, err)
+		ts = httptest.NewServer(server.Handler)
+		defer ts.Close()
+		defer server.Shutdown(context.Background())
+
+		searchResp, err := http.Get(ts.URL + "/tasks/search?q=quarterly+reports")
+		require.NoError(t, err)
+		var hits []TaskSearchHit
+		require.NoError(t, json.NewDecoder(searchResp.Body).Decode(&hits))
+		require.NoError(t, searchResp.Body.Close())
+
+		require.Equal(t, http.StatusOK, searchResp.StatusCode)
+		assert.Equal(t, "3", searchResp.Header.Get("X-Total-Count"))
+		assert.Equal(t, []string{"TASK-000001", "TASK-000003", "TASK-000002"}, util.Map(hits, func(h TaskSearchHit) string { return h.Task.ID }))
+		assert.Equal(t, []SearchHighlight{{Field: "title", Fragment: "Write <mark>quarterly</mark> <mark>report</mark>"}}, hits[0].Highlights)
+	})
+}
+
+func TestIntegration_Views(t *testing.T) {
+	t.Run("should filter tasks by expression and list tasks of saved views after restart", func(t *testing.T) {
+		cfg := ServerConfig{Storage: StorageConfig{Driver: StorageSQLite, DSN: filepath.Join(t.TempDir(), "tasks.db")}}
+		server, err := NewServer(cfg)
+		require.NoError(t, err)
+		ts := httptest.NewServer(server.Handler)
+
+		for _, body := range []string{
+			`{"title":"Fix login","priority":"HIGH","tags":["backend","bug"]}`,
+			`{"title":"Fix footer","priority":"LOW","tags":["frontend","bug"]}`,
+			`{"title":"Fix timeout","priority":"MEDIUM","tags":["backend","bug"]}`,
+			`{"title":"Write docs","tags":["backend"]}`,
+		} {
+			resp, err := http.Post(ts.URL+"/tasks", "application/json", strings.NewReader(body))
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			require.Equal(t, http.StatusCreated, resp.StatusCode)
+		}
+
+		listResp, err := http.Get(ts.URL + "/tasks?filter=" + url.QueryEscape("tag:bug and (priority = HIGH or title ~ footer)"))
+		require.NoError(t, err)
+		var tasks []Task
+		require.NoError(t, json.NewDecoder(listResp.Body).Decode(&tasks))
+		require.NoError(t, listResp.Body.Close())
+		assert.Equal(t, []string{"TASK-000001", "TASK-000002"}, util.Map(tasks, func(t Task) string { return t.ID }))
+
+		body := `{"name":"Backend-Bugs","filter":"tag:backend and tag:bug","sort":"-priority"}`
+		resp, err := http.Post(ts.URL+"/views", "application/json", strings.NewReader(body))
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Equal(t, "/views/backend-bugs", resp.Header.Get("Location"))
+
+		resp, err = http.Post(ts.URL+"/views", "application/json", strings.NewReader(body))
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+		require.NoError(t, server.Shutdown(context.Background()))
+		ts.Close()
+
+		server, err = NewServer(cfg)
+		require.NoError(t, err)
+		ts = httptest.NewServer(server.Handler)
+		defer ts.Close()
+		defer server.Shutdown(context.Background())
+
+		viewResp, err := http.Get(ts.URL + "/views/backend-bugs/tasks")
+		require.NoError(t, err)
+		require.NoError(t, json.NewDecoder(viewResp.Body).Decode(&tasks))
+		require.NoError(t, viewResp.Body.Close())
+		assert.Equal(t, "2", viewResp.Header.Get("X-Total-Count"))
+		assert.Equal(t, []string{"TASK-000001", "TASK-000003"}, util.Map(tasks, func(t Task) string { return t.ID }))
+
+		viewResp, err = http.Get(ts.URL + "/views/backend-bugs/tasks?filter=" + url.QueryEscape("priority < HIGH"))
+		require.NoError(t, err)
+		require.NoError(t, json.NewDecoder(viewResp.Body).Decode(&tasks))
+		require.NoError(t, viewResp.Body.Close())
+		assert.Equal(t, []string{"TASK-000003"}, util.Map(tasks, func(t Task) string { return t.ID }))
+
+		missingResp, err := http.Get(ts.URL + "/views/missing/tasks")
+		require.NoError(t, err)
+		require.NoError(t, missingResp.Body.Close())
+		assert.Equal(t, http.StatusNotFound, missingResp.StatusCode)
+	})
+}
+
+func TestIntegration_Batch(t *testing.T) {
+	for _, storage := range []StorageConfig{
+		{Driver: StorageMemory},
+		{Driver: StorageSQLite},
+	} {
+		t.Run("should apply operations all together or not at all with "+storage.Driver+" storage", func(t *testing.T) {
+			if storage.Driver == StorageSQLite {
+				storage.DSN = filepath.Join(t.TempDir(), "tasks.db")
+			}
+			server, err := NewServer(ServerConfig{Storage: storage})
+			require.NoError(t, err)
+			defer server.Shutdown(context.Background())
+			ts := httptest.NewServer(server.Handler)
+			defer ts.Close()
+
+			report := createTask(t, ts.URL, "Write report")
+
+			post := func(body string) *http.Response {
+				resp, err := http.Post(ts.URL+"/tasks:batch", "application/json", strings.NewReader(body))
+				require.NoError(t, err)
+				return resp
+			}
+
+			resp := post(`{"operations":[` +
+				`{"op":"create","task":{"title":"Review report"}},` +
+				`{"op":"update","id":"TASK-000001","task":{"title":"Write summary"}},` +
+				`{"op":"delete","id":"TASK-000009"}]}`)
+			var problem Problem
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+			require.NoError(t, resp.Body.Close())
+			assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+			assert.Equal(t, util.Ptr(2), problem.Operation)
+
+			listResp, err := http.Get(ts.URL + "/tasks")
+			require.NoError(t, err)
+			var tasks []Task
+			require.NoError(t, json.NewDecoder(listResp.Body).Decode(&tasks))
+			require.NoError(t, listResp.Body.Close())
+			assert.Equal(t, []Task{report}, tasks)
+
+			resp = post(`{"operations":[` +
+				`{"op":"create","ref":"review","task":{"title":"Review report"}},` +
+				`{"op":"create","task":{"title":"Fix figures","parentId":"review"}},` +
+				`{"op":"update","id":"TASK-000001","task":{"status":"IN_PROGRESS","blockedBy":["review"]},"version":1}]}`)
+			var results []BatchResult
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&results))
+			require.NoError(t, resp.Body.Close())
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+
+			require.Len(t, results, 3)
+			assert.Equal(t, []string{"TASK-000003", "TASK-000004", "TASK-000001"}, util.Map(results, func(r BatchResult) string { return r.ID }))
+			assert.Equal(t, util.Ptr("TASK-000003"), results[1].Task.ParentID)
+			assert.Equal(t, []string{"TASK-000003"}, results[2].Task.BlockedBy)
+			assert.Equal(t, task.StatusBlocked, results[2].Task.Status)
+		})
+	}
+}
+
+func TestIntegration_Transfer(t *testing.T) {
+	t.Run("should import exported tasks after a dry run", func(t *testing.T) {
+		server, err := NewServer(ServerConfig{Storage: StorageConfig{Driver: StorageSQLite, DSN: filepath.Join(t.TempDir(), "tasks.db")}})
+		require.NoError(t, err)
+		defer server.Shutdown(context.Background())
+		ts := httptest.NewServer(server.Handler)
+		defer ts.Close()
+
+		importTasks := func(query, contentType, body string) (*http.Response, []byte) {
+			resp, err := http.Post(ts.URL+"/tasks/import"+query, contentType, strings.NewReader(body))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			data, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			return resp, data
+		}
+		listTasks := func() []Task {
+			resp, err := http.Get(ts.URL + "/tasks")
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			var tasks []Task
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&tasks))
+			return tasks
+		}
+
+		resp, _ := importTasks("", "text/markdown", "# Report\n- [ ] Write report\n  - [x] Draft outline\n")
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		req, err := http.NewRequest(http.MethodGet, ts.URL+"/tasks/export?sort=createdAt", nil)
+		require.NoError(t, err)
+		req.Header.Set("Accept", "text/csv")
+		exportResp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		exported, err := io.ReadAll(exportResp.Body)
+		require.NoError(t, err)
+		require.NoError(t, exportResp.Body.Close())
+		require.Equal(t, http.StatusOK, exportResp.StatusCode)
+		assert.Equal(t, "text/csv; charset=utf-8", exportResp.Header.Get("Content-Type"))
+		lines := strings.Split(strings.TrimSpace(string(exported)), "\n")
+		require.Len(t, lines, 3)
+		assert.True(t, strings.HasPrefix(lines[2], "TASK-000002,,Draft outline,,COMPLETED,,,,TASK-000001,"))
+
+		resp, body := importTasks("?dryRun=true", "text/csv", string(exported))
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var report ImportReport
+		require.NoError(t, json.Unmarshal(body, &report))
+		assert.False(t, report.Imported)
+		require.Len(t, report.Rows, 2)
+		assert.True(t, strings.HasPrefix(report.Rows[1].Task.ID, task.StagedIDPrefix))
+		assert.Len(t, listTasks(), 2)
+
+		resp, body = importTasks("", "text/csv", string(exported))
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		require.NoError(t, json.Unmarshal(body, &report))
+		assert.True(t, report.Imported)
+		assert.Equal(t, "TASK-000004", report.Rows[1].Task.ID)
+		assert.Equal(t, util.Ptr("TASK-000003"), report.Rows[1].Task.ParentID)
+		assert.Equal(t, task.StatusCompleted, report.Rows[1].Task.Status)
+		assert.Len(t, listTasks(), 4)
+
+		resp, body = importTasks("?format=csv", "", "title,parent\nWrite summary,TASK-000009\n")
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+		var problem Problem
+		require.NoError(t, json.Unmarshal(body, &problem))
+		assert.Equal(t, CodeInvalidImport, problem.Code)
+		require.Len(t, problem.Rows, 1)
+		assert.Equal(t, 2, problem.Rows[0].Line)
+		assert.Len(t, listTasks(), 4)
+	})
+}
+
+func TestIntegration_Calendar(t *testing.T) {
+	server, err := NewServer(ServerConfig{Auth: AuthConfig{
+		APIKeys:    map[string]middleware.Principal{"alice-key": {Subject: "alice"}},
+		FeedSecret: []byte("feed-secret"),
+	}})
+	require.NoError(t, err)
+	ts := httptest.NewServer(server.Handler)
+	defer ts.Close()
+
+	do := func(t *testing.T, key, method, url, contentType, body string) *http.Response {
+		t.Helper()
+
+		req, err := http.NewRequest(method, url, strings.NewReader(body))
+		require.NoError(t, err)
+		if key != "" {
+			req.Header.Set("X-API-Key", key)
+		}
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = resp.Body.Close() })
+
+		return resp
+	}
+
+	calendar := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VTODO",
+		"UID:report",
+		"SUMMARY:Write report",
+		"PRIORITY:1",
+		"DUE:20250502T170000Z",
+		"END:VTODO",
+		"BEGIN:VTODO",
+		"UID:budget",
+		"SUMMARY:Plan budget",
+		"END:VTODO",
+		"END:VCALENDAR",
+	}, "\r\n")
+	resp := do(t, "alice-key", http.MethodPost, ts.URL+"/tasks/import", "text/calendar", calendar)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	t.Run("should serve the tasks having a due date to the subscription URL", func(t *testing.T) {
+		resp := do(t, "alice-key", http.MethodGet, ts.URL+"/calendar/subscription?status=NOT_STARTED", "", "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var subscription CalendarSubscription
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&subscription))
+		assert.True(t, strings.HasPrefix(subscription.URL, ts.URL+"/tasks.ics?"))
+		assert.NotNil(t, subscription.ExpiresAt)
+
+		resp = do(t, "", http.MethodGet, subscription.URL, "", "")
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/calendar; charset=utf-8", resp.Header.Get("Content-Type"))
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), "\r\nSUMMARY:Write report\r\nSTATUS:NEEDS-ACTION\r\nPRIORITY:1\r\nDUE:20250502T170000Z\r\n")
+		assert.NotContains(t, string(body), "Plan budget")
+	})
+
+	t.Run("should reject feed requests without valid token", func(t *testing.T) {
+		expired, _ := middleware.NewURLTokens([]byte("feed-secret"), "calendar-feed", -time.Hour, util.NewClock()).
+			Issue(middleware.Principal{Subject: "alice"})
+		revoked, _ := middleware.NewURLTokens([]byte("feed-secret"), "calendar-feed", time.Hour, util.NewClock()).
+			Issue(middleware.Principal{Subject: "mallory"})
+
+		for _, url := range []string{
+			ts.URL + "/tasks.ics",
+			ts.URL + "/tasks.ics?token=tampered.c2ln",
+			ts.URL + "/tasks.ics?token=" + expired,
+			ts.URL + "/tasks.ics?token=" + revoked,
+		} {
+			resp := do(t, "", http.MethodGet, url, "", "")
+
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, url)
+		}
+	})
+
+	t.Run("should not authenticate other endpoints with the token", func(t *testing.T) {
+		resp := do(t, "alice-key", http.MethodGet, ts.URL+"/calendar/subscription", "", "")
+		var subscription CalendarSubscription
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&subscription))
+		token := subscription.URL[strings.Index(subscription.URL, "?"):]
+
+		assert.Equal(t, http.StatusUnauthorized, do(t, "", http.MethodGet, ts.URL+"/tasks"+token, "", "").StatusCode)
+		assert.Equal(t, http.StatusUnauthorized, do(t, "", http.MethodGet, ts.URL+"/calendar/subscription"+token, "", "").StatusCode)
+	})
+}
+
+func createTask(t *testing.T, url, title string) Task {
+	t.Helper()
+
+	body, err := json.Marshal(TaskInput{Title: title})
+	require.NoError(t, err)
+
+	resp, err := http.Post(url+"/tasks", "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var created Task
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+	return created
+}
+
+func patchTask(t *testing.T, url, id string, input TaskInput) (*http.Response, Task) {
+	t.Helper()
+
+	body, err := json.Marshal(input)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPatch, url+"/tasks/"+id, bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var updated Task
+	if resp.StatusCode == http.StatusOK {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&updated))
+	}
+	return resp, updated
+}
+
+func TestIntegration_Dependencies(t *testing.T) {
+	t.Run("should block tasks until their blockers are completed and plan their order", func(t *testing.T) {
+		cfg := ServerConfig{Storage: StorageConfig{Driver: StorageSQLite, DSN: filepath.Join(t.TempDir(), "tasks.db")}}
+		server, err := NewServer(cfg)
+		require.NoError(t, err)
+		ts := httptest.NewServer(server.Handler)
+		defer ts.Close()
+		defer server.Shutdown(context.Background())
+
+		design := createTask(t, ts.URL, "Design schema")
+		deploy := createTask(t, ts.URL, "Deploy")
+
+		resp, updated := patchTask(t, ts.URL, deploy.ID, TaskInput{BlockedBy: []string{design.ID}})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, task.StatusBlocked, updated.Status)
+		assert.Equal(t, []string{design.ID}, updated.BlockedBy)
+
+		resp, _ = patchTask(t, ts.URL, design.ID, TaskInput{BlockedBy: []string{deploy.ID}})
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		orderResp, err := http.Get(ts.URL + "/tasks/order")
+		require.NoError(t, err)
+		var plan ExecutionPlan
+		require.NoError(t, json.NewDecoder(orderResp.Body).Decode(&plan))
+		require.NoError(t, orderResp.Body.Close())
+		require.Len(t, plan.Order, 2)
+		assert.Equal(t, design.ID, plan.Order[0].ID)
+		assert.Equal(t, deploy.ID, plan.Order[1].ID)
+		assert.Empty(t, plan.CriticalPath)
+
+		resp, _ = patchTask(t, ts.URL, deploy.ID, TaskInput{Status: task.StatusCompleted})
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+		resp, _ = patchTask(t, ts.URL, design.ID, TaskInput{Status: task.StatusInProgress})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		resp, _ = patchTask(t, ts.URL, design.ID, TaskInput{Status: task.StatusCompleted})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		getResp, err := http.Get(ts.URL + "/tasks/" + deploy.ID)
+		require.NoError(t, err)
+		var fetched Task
+		require.NoError(t, json.NewDecoder(getResp.Body).Decode(&fetched))
+		require.NoError(t, getResp.Body.Close())
+		assert.Equal(t, task.StatusNotStarted, fetched.Status)
+	})
+}
+
+func TestIntegration_Recurrence(t *testing.T) {
+	t.Run("should create next occurrence when recurring task is completed", func(t *testing.T) {
+		cfg := ServerConfig{Storage: StorageConfig{Driver: StorageSQLite, DSN: filepath.Join(t.TempDir(), "tasks.db")}}
+		server, err := NewServer(cfg)
+		require.NoError(t, err)
+		ts := httptest.NewServer(server.Handler)
+		defer ts.Close()
+		defer server.Shutdown(context.Background())
+
+		chore := createTask(t, ts.URL, "Take out the trash")
+		due := time.Date(2025, 5, 5, 19, 0, 0, 0, time.UTC)
+		resp, updated := patchTask(t, ts.URL, chore.ID, TaskInput{DueDate: &due, Recurrence: util.Ptr("FREQ=WEEKLY;BYDAY=MO,TH;COUNT=4")})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, util.Ptr("FREQ=WEEKLY;BYDAY=MO,TH;COUNT=4"), updated.Recurrence)
+
+		resp, _ = patchTask(t, ts.URL, chore.ID, TaskInput{Status: task.StatusInProgress})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		resp, completed := patchTask(t, ts.URL, chore.ID, TaskInput{Status: task.StatusCompleted})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Nil(t, completed.Recurrence)
+
+		getResp, err := http.Get(ts.URL + "/tasks/TASK-000002")
+		require.NoError(t, err)
+		var next Task
+		require.NoError(t, json.NewDecoder(getResp.Body).Decode(&next))
+		require.NoError(t, getResp.Body.Close())
+		assert.Equal(t, "Take out the trash", next.Title)
+		assert.Equal(t, task.StatusNotStarted, next.Status)
+		assert.Equal(t, time.Date(2025, 5, 8, 19, 0, 0, 0, time.UTC), next.DueDate.UTC())
+		assert.Equal(t, util.Ptr("FREQ=WEEKLY;BYDAY=MO,TH;COUNT=3"), next.Recurrence)
+	})
+}
+
+func TestIntegration_History(t *testing.T) {
+	t.Run("should return history of deleted task after restart", func(t *testing.T) {
+		cfg := ServerConfig{Storage: StorageConfig{Driver: StorageSQLite, DSN: filepath.Join(t.TempDir(), "tasks.db")}}
+		server, err := NewServer(cfg)
+		require.NoError(t, err)
+		ts := httptest.NewServer(server.Handler)
+
+		report := createTask(t, ts.URL, "Write report")
+		resp, _ := patchTask(t, ts.URL, report.ID, TaskInput{Status: task.StatusInProgress})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		resp, _ = patchTask(t, ts.URL, report.ID, TaskInput{Title: "Write summary", Priority: util.Ptr(task.PriorityHigh)})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		req, err := http.NewRequest(http.MethodDelete, ts.URL+"/tasks/"+report.ID, nil)
+		require.NoError(t, err)
+		deleteResp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, deleteResp.Body.Close())
+		require.Equal(t, http.StatusNoContent, deleteResp.StatusCode)
+
+		ts.Close()
+		require.NoError(t, server.Shutdown(context.Background()))
+
+		server, err = NewServer(cfg)
+		require.NoError(t, err)
+		ts = httptest.NewServer(server.Handler)
+		defer ts.Close()
+		defer server.Shutdown(context.Background())
+
+		getResp, err := http.Get(ts.URL + "/tasks/" + report.ID + "/history")
+		require.NoError(t, err)
+		defer getResp.Body.Close()
+		require.Equal(t, http.StatusOK, getResp.StatusCode)
+
+		var events []TaskEvent
+		require.NoError(t, json.NewDecoder(getResp.Body).Decode(&events))
+		require.Len(t, events, 4)
+		assert.Equal(t, []task.EventType{task.EventCreated, task.EventStatusChanged, task.EventUpdated, task.EventDeleted},
+			util.Map(events, func(e TaskEvent) task.EventType { return e.Type }))
+		assert.Equal(t, []int{1, 2, 3, 4}, util.Map(events, func(e TaskEvent) int { return e.Version }))
+		assert.Contains(t, events[2].Changes, FieldChange{Field: "title", Before: "Write report", After: "Write summary"})
+
+		assert.Equal(t, "TASK-000002", createTask(t, ts.URL, "Write notes").ID)
+	})
+
+	t.Run("should record tasks stored before history was recorded with their version", func(t *testing.T) {
+		dsn := filepath.Join(t.TempDir(), "tasks.db")
+		db, err := database.OpenSQLite(context.Background(), dsn)
+		require.NoError(t, err)
+		legacy, err := task.NewSQLRepository(context.Background(), db)
+		require.NoError(t, err)
+		require.NoError(t, legacy.Create(context.Background(), &task.Task{ID: "TASK-000007", Title: "Write report", Status: task.StatusNotStarted}))
+		require.NoError(t, legacy.Update(context.Background(), &task.Task{ID: "TASK-000007", Title: "Write summary", Status: task.StatusNotStarted}))
+		require.NoError(t, db.Close())
+
+		server, err := NewServer(ServerConfig{Storage: StorageConfig{Driver: StorageSQLite, DSN: dsn}})
+		require.NoError(t, err)
+		ts := httptest.NewServer(server.Handler)
+		defer ts.Close()
+		defer server.Shutdown(context.Background())
+
+		created := createTask(t, ts.URL, "Review report")
+		assert.Equal(t, "TASK-000008", created.ID)
+
+		getResp, err := http.Get(ts.URL + "/tasks/TASK-000007/history")
+		require.NoError(t, err)
+		defer getResp.Body.Close()
+		require.Equal(t, http.StatusOK, getResp.StatusCode)
+
+		var events []TaskEvent
+		require.NoError(t, json.NewDecoder(getResp.Body).Decode(&events))
+		require.Len(t, events, 1)
+		assert.Equal(t, task.EventCreated, events[0].Type)
+		assert.Equal(t, 2, events[0].Version)
+	})
+}
+
+func TestIntegration_Events(t *testing.T) {
+	newEventsServer := func(t *testing.T) *httptest.Server {
+		t.Helper()
+
+		server, err := NewServer(ServerConfig{})
+		require.NoError(t, err)
+		ts := httptest.NewServer(server.Handler)
+		t.Cleanup(ts.Close)
+
+		return ts
+	}
+
+	t.Run("should stream changes as server-sent events and resume after last event id", func(t *testing.T) {
+		ts := newEventsServer(t)
+		createTask(t, ts.URL, "Write report")
+		createTask(t, ts.URL, "Review report")
+
+		req, err := http.NewRequest(http.MethodGet, ts.URL+"/events?type=TASK_CREATED,STATUS_CHANGED", nil)
+		require.NoError(t, err)
+		req.Header.Set("Last-Event-ID", "1")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		patchResp, _ := patchTask(t, ts.URL, "TASK-000001", TaskInput{Status: task.StatusInProgress})
+		require.Equal(t, http.StatusOK, patchResp.StatusCode)
+
+		var ids, events []string
+		scanner := bufio.NewScanner(resp.Body)
+		for len(events) < 2 && scanner.Scan() {
+			if id, found := strings.CutPrefix(scanner.Text(), "id: "); found {
+				ids = append(ids, id)
+			}
+			if event, found := strings.CutPrefix(scanner.Text(), "event: "); found {
+				events = append(events, event)
+			}
+		}
+		require.NoError(t, scanner.Err())
+
+		assert.Equal(t, []string{"2", "3"}, ids)
+		assert.Equal(t, []string{"TASK_CREATED", "STATUS_CHANGED"}, events)
+	})
+
+	t.Run("should stream changes over websocket", func(t *testing.T) {
+		ts := newEventsServer(t)
+
+		conn, err := websocket.Dial("ws"+strings.TrimPrefix(ts.URL, "http")+"/events/ws?type=TASK_DELETED", "", ts.URL)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		created := createTask(t, ts.URL, "Write report")
+		req, err := http.NewRequest(http.MethodDelete, ts.URL+"/tasks/"+created.ID, nil)
+		require.NoError(t, err)
+		deleteResp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, deleteResp.Body.Close())
+
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		var change TaskChangeEvent
+		require.NoError(t, websocket.JSON.Receive(conn, &change))
+		assert.Equal(t, int64(2), change.ID)
+		assert.Equal(t, task.EventDeleted, change.Type)
+		assert.Equal(t, created.ID, change.TaskID)
+		assert.Equal(t, "Write report", change.Task.Title)
+	})
+
+	t.Run("should reject websocket of another origin", func(t *testing.T) {
+		ts := newEventsServer(t)
+
+		_, err := websocket.Dial("ws"+strings.TrimPrefix(ts.URL, "http")+"/events/ws", "", "https://evil.example.com")
+		assert.Error(t, err)
+	})
+
+	t.Run("should accept websocket of an allowed origin", func(t *testing.T) {
+		server, err := NewServer(ServerConfig{AllowedOrigins: []string{"https://app.example.com"}})
+		require.NoError(t, err)
+		ts := httptest.NewServer(server.Handler)
+		defer ts.Close()
+		defer server.Shutdown(context.Background())
+
+		conn, err := websocket.Dial("ws"+strings.TrimPrefix(ts.URL, "http")+"/events/ws", "", "https://app.example.com")
+		require.NoError(t, err)
+		require.NoError(t, conn.Close())
+	})
+
+	t.Run("should return gone when resuming after unknown event", func(t *testing.T) {
+		ts := newEventsServer(t)
+
+		resp, err := http.Get(ts.URL + "/events?lastEventId=5")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusGone, resp.StatusCode)
+	})
+}
+
+func TestIntegration_Webhooks(t *testing.T) {
+	t.Run("should post signed task changes to webhook and record deliveries", func(t *testing.T) {
+		received := make(chan *http.Request, 10)
+		bodies := make(chan []byte, 10)
+		target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			received <- r
+			bodies <- body
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer target.Close()
+
+		server, err := NewServer(ServerConfig{})
+		require.NoError(t, err)
+		ts := httptest.NewServer(server.Handler)
+		defer ts.Close()
+
+		input := `{"url":"` + target.URL + `","events":["TASK_CREATED"],"secret":"s3cr3t"}`
+		resp, err := http.Post(ts.URL+"/webhooks", "application/json", strings.NewReader(input))
+		require.NoError(t, err)
+		var hook Webhook
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&hook))
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Equal(t, "WH-000001", hook.ID)
+
+		created := createTask(t, ts.URL, "Write report")
+
+		var req *http.Request
+		select {
+		case req = <-received:
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "webhook not called")
+		}
+		body := <-bodies
+
+		assert.True(t, webhook.Verify("s3cr3t", body, req.Header.Get(webhook.TimestampHeader), req.Header.Get(webhook.SignatureHeader), time.Now()))
+		assert.Equal(t, string(task.EventCreated), req.Header.Get("X-Webhook-Event"))
+		var change TaskChangeEvent
+		require.NoError(t, json.Unmarshal(body, &change))
+		assert.Equal(t, task.EventCreated, change.Type)
+		assert.Equal(t, created.ID, change.TaskID)
+
+		var deliveries []WebhookDelivery
+		require.Eventually(t, func() bool {
+			resp, err := http.Get(ts.URL + "/webhooks/" + hook.ID + "/deliveries?status=SUCCEEDED")
+			if err != nil {
+				return false
+			}
+			defer resp.Body.Close()
+			deliveries = nil
+			return json.NewDecoder(resp.Body).Decode(&deliveries) == nil && len(deliveries) == 1
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, req.Header.Get("X-Webhook-Delivery"), deliveries[0].ID)
+		assert.Len(t, deliveries[0].Attempts, 1)
+		assert.JSONEq(t, string(body), string(deliveries[0].Payload))
+	})
+
+	t.Run("should list delivery given up after last attempt as dead letter", func(t *testing.T) {
+		target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer target.Close()
+
+		server, err := NewServer(ServerConfig{Webhooks: webhook.Config{MaxAttempts: 2, InitialBackoff: time.Millisecond, PollInterval: time.Millisecond}})
+		require.NoError(t, err)
+		ts := httptest.NewServer(server.Handler)
+		defer ts.Close()
+
+		input := `{"url":"` + target.URL + `","secret":"s3cr3t"}`
+		resp, err := http.Post(ts.URL+"/webhooks", "application/json", strings.NewReader(input))
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		createTask(t, ts.URL, "Write report")
+
+		var deadLetters []WebhookDelivery
+		require.Eventually(t, func() bool {
+			resp, err := http.Get(ts.URL + "/webhooks/dead-letters")
+			if err != nil {
+				return false
+			}
+			defer resp.Body.Close()
+			deadLetters = nil
+			return json.NewDecoder(resp.Body).Decode(&deadLetters) == nil && len(deadLetters) == 1
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, webhook.DeliveryDead, deadLetters[0].Status)
+		require.Len(t, deadLetters[0].Attempts, 2)
+		assert.Equal(t, http.StatusServiceUnavailable, deadLetters[0].Attempts[1].StatusCode)
+	})
+
+	t.Run("should keep webhooks and dead letters stored in SQLite database after restart", func(t *testing.T) {
+		target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer target.Close()
+
+		cfg := ServerConfig{
+			Storage:  StorageConfig{Driver: StorageSQLite, DSN: filepath.Join(t.TempDir(), "tasks.db")},
+			Webhooks: webhook.Config{MaxAttempts: 1},
+		}
+		server, err := NewServer(cfg)
+		require.NoError(t, err)
+		ts := httptest.NewServer(server.Handler)
+
+		input := `{"url":"` + target.URL + `","secret":"s3cr3t"}`
+		resp, err := http.Post(ts.URL+"/webhooks", "application/json", strings.NewReader(input))
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		createTask(t, ts.URL, "Write report")
+
+		deadLetters := func(url string) []WebhookDelivery {
+			resp, err := http.Get(url + "/webhooks/dead-letters")
+			if err != nil {
+				return nil
+			}
+			defer resp.Body.Close()
+			var deliveries []WebhookDelivery
+			_ = json.NewDecoder(resp.Body).Decode(&deliveries)
+			return deliveries
+		}
+		require.Eventually(t, func() bool { return len(deadLetters(ts.URL)) == 1 }, 5*time.Second, 10*time.Millisecond)
+
+		resp, err = http.Post(ts.URL+"/webhooks", "application/json", strings.NewReader(input))
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		req, err := http.NewRequest(http.MethodDelete, ts.URL+"/webhooks/WH-000002", nil)
+		require.NoError(t, err)
+		resp, err = http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusNoContent, resp.StatusCode)
+		ts.Close()
+		require.NoError(t, server.Shutdown(context.Background()))
+
+		server, err = NewServer(cfg)
+		require.NoError(t, err)
+		ts = httptest.NewServer(server.Handler)
+		defer ts.Close()
+		defer server.Shutdown(context.Background())
+
+		stored := deadLetters(ts.URL)
+		require.Len(t, stored, 1)
+		assert.Equal(t, "DLV-000001", stored[0].ID)
+
+		resp, err = http.Post(ts.URL+"/webhooks", "application/json", strings.NewReader(input))
+		require.NoError(t, err)
+		var hook Webhook
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&hook))
+		require.NoError(t, resp.Body.Close())
+		assert.Equal(t, "WH-000003", hook.ID)
+	})
+}
+
+func TestIntegration_Auth(t *testing.T) {
+	signToken := func(secret, claims string) string {
+		encode := base64.RawURLEncoding.EncodeToString
+		signed := encode([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." + encode([]byte(claims))
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(signed))
+		return signed + "." + encode(mac.Sum(nil))
+	}
+
+	newAuthServer := func(t *testing.T, publicDocs bool) *httptest.Server {
+		t.Helper()
+
+		server, err := NewServer(ServerConfig{Auth: AuthConfig{
+			APIKeys:    map[string]middleware.Principal{"key-1": {Subject: "alice"}},
+			JWT:        &middleware.JWTConfig{Secret: []byte("secret")},
+			PublicDocs: publicDocs,
+			FeedSecret: []byte("feed-secret"),
+		}})
+		require.NoError(t, err)
+		ts := httptest.NewServer(server.Handler)
+		t.Cleanup(ts.Close)
+
+		return ts
+	}
+
+	get := func(t *testing.T, url string, header http.Header) *http.Response {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		require.NoError(t, err)
+		req.Header = header
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = resp.Body.Close() })
+
+		return resp
+	}
+
+	t.Run("should require a feed secret", func(t *testing.T) {
+		_, err := NewServer(ServerConfig{Auth: AuthConfig{APIKeys: map[string]middleware.Principal{"key-1": {Subject: "alice"}}}})
+
+		assert.EqualError(t, err, "feed secret is required when authentication is enabled")
+	})
+
+	t.Run("should reject requests without valid credentials", func(t *testing.T) {
+		ts := newAuthServer(t, true)
+
+		for _, tt := range []struct {
+			name   string
+			url    string
+			header http.Header
+			code   ErrorCode
+		}{
+			{"missing credentials", ts.URL + "/tasks", http.Header{}, CodeAuthenticationRequired},
+			{"unknown API key", ts.URL + "/tasks", http.Header{"X-Api-Key": {"key-2"}}, CodeInvalidCredentials},
+			{"token signed with other secret", ts.URL + "/chat/session-1", http.Header{"Authorization": {"Bearer " + signToken("other", `{"sub":"bob"}`)}}, CodeInvalidCredentials},
+			{"missing credentials for webhooks", ts.URL + "/webhooks", http.Header{}, CodeAuthenticationRequired},
+		} {
+			t.Run(tt.name, func(t *testing.T) {
+				resp := get(t, tt.url, tt.header)
+
+				assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+				assert.NotEmpty(t, resp.Header.Values("WWW-Authenticate"))
+				var problem Problem
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+				assert.Equal(t, tt.code, problem.Code)
+			})
+		}
+	})
+
+	t.Run("should record changes as made by authenticated caller", func(t *testing.T) {
+		ts := newAuthServer(t, true)
+
+		req, err := http.NewRequest(http.MethodPost, ts.URL+"/tasks", strings.NewReader(`{"title":"Write report","assignees":["bob"]}`))
+		require.NoError(t, err)
+		req.Header.Set("X-API-Key", "key-1")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		req, err = http.NewRequest(http.MethodPatch, ts.URL+"/tasks/TASK-000001", strings.NewReader(`{"status":"IN_PROGRESS"}`))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+signToken("secret", `{"sub":"bob"}`))
+		resp, err = http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		resp = get(t, ts.URL+"/tasks/TASK-000001/history", http.Header{"X-Api-Key": {"key-1"}})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var events []TaskEvent
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&events))
+		assert.Equal(t, []string{"alice", "bob"}, util.Map(events, func(e TaskEvent) string { return e.Actor }))
+	})
+
+	t.Run("should serve docs without credentials when public", func(t *testing.T) {
+		resp := get(t, newAuthServer(t, true).URL+"/swagger/index.html", http.Header{})
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("should require credentials for docs when not public", func(t *testing.T) {
+		ts := newAuthServer(t, false)
+
+		assert.Equal(t, http.StatusUnauthorized, get(t, ts.URL+"/swagger/index.html", http.Header{}).StatusCode)
+		assert.Equal(t, http.StatusOK, get(t, ts.URL+"/swagger/index.html", http.Header{"X-Api-Key": {"key-1"}}).StatusCode)
+	})
+}
+
+func TestIntegration_Access(t *testing.T) {
+	server, err := NewServer(ServerConfig{Auth: AuthConfig{APIKeys: map[string]middleware.Principal{
+		"alice-key":  {Subject: "alice"},
+		"bob-key":    {Subject: "bob"},
+		"viewer-key": {Subject: "victor", Roles: []string{"viewer"}},
+		"admin-key":  {Subject: "ada", Roles: []string{"member", "admin"}},
+	}, FeedSecret: []byte("feed-secret")}})
+	require.NoError(t, err)
+	ts := httptest.NewServer(server.Handler)
+	defer ts.Close()
+
+	do := func(t *testing.T, key, method, path, body string) *http.Response {
+		t.Helper()
+
+		req, err := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("X-API-Key", key)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = resp.Body.Close() })
+
+		return resp
+	}
+
+	resp := do(t, "alice-key", http.MethodPost, "/tasks", `{"title":"Write report","assignees":["bob"]}`)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var created Task
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+	assert.Equal(t, "alice", created.OwnerID)
+	assert.Equal(t, []string{"bob"}, created.Assignees)
+
+	require.Equal(t, http.StatusCreated, do(t, "bob-key", http.MethodPost, "/tasks", `{"title":"Plan budget"}`).StatusCode)
+
+	t.Run("should list tasks assigned to the caller", func(t *testing.T) {
+		resp := do(t, "bob-key", http.MethodGet, "/tasks?assignee=me", "")
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var tasks []Task
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&tasks))
+		assert.Equal(t, []string{"TASK-000001"}, util.Map(tasks, func(t Task) string { return t.ID }))
+	})
+
+	t.Run("should enforce the role and ownership of the caller", func(t *testing.T) {
+		for _, tt := range []struct {
+			name   string
+			key    string
+			method string
+			path   string
+			body   string
+			status int
+		}{
+			{"viewer reads tasks", "viewer-key", http.MethodGet, "/tasks/TASK-000001", "", http.StatusOK},
+			{"viewer cannot create tasks", "viewer-key", http.MethodPost, "/tasks", `{"title":"Fix login"}`, http.StatusForbidden},
+			{"member cannot update other tasks", "alice-key", http.MethodPatch, "/tasks/TASK-000002", `{"title":"Plan Q3 budget"}`, http.StatusForbidden},
+			{"assignee updates task", "bob-key", http.MethodPatch, "/tasks/TASK-000001", `{"status":"IN_PROGRESS"}`, http.StatusOK},
+			{"assignee cannot change assignees", "bob-key", http.MethodPatch, "/tasks/TASK-000001", `{"assignees":["carol"]}`, http.StatusForbidden},
+			{"assignee cannot delete task", "bob-key", http.MethodDelete, "/tasks/TASK-000001", "", http.StatusForbidden},
+			{"member cannot rename tags", "alice-key", http.MethodPatch, "/tags/docs", `{"name":"documentation"}`, http.StatusForbidden},
+			{"member cannot create projects", "alice-key", http.MethodPost, "/projects", `{"key":"WEB","name":"Website"}`, http.StatusForbidden},
+			{"admin creates projects", "admin-key", http.MethodPost, "/projects", `{"key":"WEB","name":"Website"}`, http.StatusCreated},
+			{"assignee cannot move task", "bob-key", http.MethodPost, "/tasks/TASK-000001/move", `{"project":"WEB"}`, http.StatusForbidden},
+			{"admin deletes other tasks", "admin-key", http.MethodDelete, "/tasks/TASK-000002", "", http.StatusNoContent},
+			{"member cannot create webhooks", "alice-key", http.MethodPost, "/webhooks", `{"url":"https://example.com/hook","secret":"s3cr3t"}`, http.StatusForbidden},
+			{"viewer cannot list dead letters", "viewer-key", http.MethodGet, "/webhooks/dead-letters", "", http.StatusForbidden},
+			{"admin creates webhooks", "admin-key", http.MethodPost, "/webhooks", `{"url":"https://example.com/hook","secret":"s3cr3t"}`, http.StatusCreated},
+			{"member cannot read webhooks", "alice-key", http.MethodGet, "/webhooks/WH-000001", "", http.StatusForbidden},
+			{"member cannot delete webhooks", "alice-key", http.MethodDelete, "/webhooks/WH-000001", "", http.StatusForbidden},
+			{"admin deletes webhooks", "admin-key", http.MethodDelete, "/webhooks/WH-000001", "", http.StatusNoContent},
+		} {
+			t.Run(tt.name, func(t *testing.T) {
+				resp := do(t, tt.key, tt.method, tt.path, tt.body)
+
+				assert.Equal(t, tt.status, resp.StatusCode)
+				if tt.status == http.StatusForbidden {
+					var problem Problem
+					require.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+					assert.Equal(t, CodeForbidden, problem.Code)
+				}
+			})
+		}
 	})
 }
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// sseWriter writes Server-Sent Events to a response, flushing each event as soon as it is written.
+type sseWriter struct {
+	w          http.ResponseWriter
+	controller *http.ResponseController
+}
+
+func newSSEWriter(w http.ResponseWriter) *sseWriter {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	return &sseWriter{w: w, controller: http.NewResponseController(w)}
+}
+
+func (s *sseWriter) write(event string, data any) error {
+	return s.writeWithID("", event, data)
+}
+
+// writeWithID writes an event with an ID, sent back by the client in the Last-Event-ID header when it reconnects.
+func (s *sseWriter) writeWithID(id, event string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("error encoding event: %w", err)
+	}
+
+	if id != "" {
+		if _, err := fmt.Fprintf(s.w, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+
+	if _, err := fmt.Fprintf(s.w, "event: %s\ndata: %s\n\n", event, payload); err != nil {
+		return err
+	}
+
+	return s.controller.Flush()
+}
+
+// comment writes a comment ignored by the client, keeping the connection alive through idle proxies.
+func (s *sseWriter) comment(text string) error {
+	if _, err := fmt.Fprintf(s.w, ": %s\n\n", text); err != nil {
+		return err
+	}
+
+	return s.controller.Flush()
+}
@@ -0,0 +1,265 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	taskcore "github.com/utsabbera/task-master/core/task"
+)
+
+//go:generate mockgen -destination=transfer_handler_mock.go -package=api . TransferHandler
+
+const (
+	maxImportBytes = 10 << 20
+	defaultFormat  = taskcore.FormatCSV
+)
+
+var (
+	formatMediaTypes = map[taskcore.Format]string{
+		taskcore.FormatCSV:       "text/csv",
+		taskcore.FormatNDJSON:    "application/x-ndjson",
+		taskcore.FormatMarkdown:  "text/markdown",
+		taskcore.FormatICalendar: "text/calendar",
+	}
+	formatExtensions = map[taskcore.Format]string{
+		taskcore.FormatCSV:       "csv",
+		taskcore.FormatNDJSON:    "ndjson",
+		taskcore.FormatMarkdown:  "md",
+		taskcore.FormatICalendar: "ics",
+	}
+	mediaTypeFormats = map[string]taskcore.Format{
+		"text/csv":             taskcore.FormatCSV,
+		"application/x-ndjson": taskcore.FormatNDJSON,
+		"application/ndjson":   taskcore.FormatNDJSON,
+		"application/jsonl":    taskcore.FormatNDJSON,
+		"text/markdown":        taskcore.FormatMarkdown,
+		"text/x-markdown":      taskcore.FormatMarkdown,
+		"text/calendar":        taskcore.FormatICalendar,
+	}
+)
+
+// TransferHandler defines the interface for handling HTTP requests exporting and importing tasks.
+type TransferHandler interface {
+	// Export writes the tasks in the requested format.
+	Export(w http.ResponseWriter, r *http.Request)
+
+	// Import creates the tasks of an uploaded file.
+	Import(w http.ResponseWriter, r *http.Request)
+}
+
+type transferHandler struct {
+	transfer taskcore.TransferService
+}
+
+// NewTransferHandler returns a new instance of TransferHandler for export and import operations.
+func NewTransferHandler(transferService taskcore.TransferService) TransferHandler {
+	return &transferHandler{transfer: transferService}
+}
+
+// Export godoc
+// @Summary Export Tasks
+// @Description Export the tasks matching the filters as CSV, JSON Lines, a Markdown checklist or iCalendar VTODO components, chosen by the format
+// @Description parameter or else by the Accept header, CSV by default. Every matching task is exported, without pagination
+// @Tags transfer
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Produce text/markdown
+// @Produce text/calendar
+// @Param format query string false "Format of the export, overriding the Accept header" Enums(csv, ndjson, markdown, ical)
+// @Param status query []string false "Only tasks with any of these statuses" collectionFormat(csv)
+// @Param tag query []string false "Only tasks having all of these tags, or none of the tags prefixed with !" collectionFormat(multi)
+// @Param filter query string false "Only tasks matching this expression"
+// @Param q query string false "Only tasks whose title or description contains this text"
+// @Param sort query string false "Comma separated sort fields (createdAt, updatedAt, dueDate, priority, title), prefixed with - for descending order"
+// @Success 200 {string} string "Exported tasks"
+// @Header 200 {string} Content-Disposition "Attachment file name of the export"
+// @Failure 400 {object} Problem "Invalid query parameters"
+// @Failure 406 {object} Problem "No acceptable format"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /tasks/export [get]
+func (h *transferHandler) Export(w http.ResponseWriter, r *http.Request) {
+	format, err := exportFormat(r)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	opts, err := parseExportOptions(r.Context(), r.URL.Query())
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := h.transfer.Export(r.Context(), &buf, format, opts); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", formatMediaTypes[format]+"; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="tasks.%s"`, formatExtensions[format]))
+	w.Header().Add("Vary", "Accept")
+
+	_, _ = w.Write(buf.Bytes())
+}
+
+// Import godoc
+// @Summary Import Tasks
+// @Description Create the tasks of a CSV, JSON Lines, Markdown checklist or iCalendar file of at most 10 MB, its format given by the format
+// @Description parameter or else by the Content-Type header. The CSV header names the field of each column, by its name
+// @Description or an alias such as name, due or labels, or as mapped by the column parameters. The id, parentId and blockedBy
+// @Description of the rows refer to each other or else to existing tasks, and the indented checklist items are subtasks.
+// @Description The VTODO components of an iCalendar file are rows whose UID is their id and whose RELATED-TO is their parent.
+// @Description Either every row is imported or, when one of them has an error, none is and the problem lists the failed rows.
+// @Description A dry run validates each row on its own without creating their tasks and reports the errors of all its rows
+// @Tags transfer
+// @Accept text/csv
+// @Accept application/x-ndjson
+// @Accept text/markdown
+// @Accept text/calendar
+// @Produce json
+// @Param format query string false "Format of the file, overriding the Content-Type header" Enums(csv, ndjson, markdown, ical)
+// @Param dryRun query bool false "Validate the rows without creating their tasks"
+// @Param column query []string false "Mapping of a CSV column to a field, e.g. Effort:priority" collectionFormat(multi)
+// @Param file body string true "File to import"
+// @Success 200 {object} ImportReport "Dry run report"
+// @Success 201 {object} ImportReport "Imported tasks"
+// @Failure 400 {object} Problem "Invalid query parameters"
+// @Failure 403 {object} Problem "Caller is a viewer"
+// @Failure 413 {object} Problem "Request body too large"
+// @Failure 415 {object} Problem "Unsupported format"
+// @Failure 422 {object} Problem "File cannot be read or has rows which cannot be imported"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /tasks/import [post]
+func (h *transferHandler) Import(w http.ResponseWriter, r *http.Request) {
+	format, err := importFormat(r)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	opts, err := parseImportOptions(r.URL.Query())
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	result, err := h.transfer.Import(r.Context(), http.MaxBytesReader(w, r.Body, maxImportBytes), format, opts)
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		handleError(w, r, fmt.Errorf("%w: body must not exceed %d bytes", errBodyTooLarge, maxBytesErr.Limit))
+		return
+	}
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if result.Imported {
+		w.WriteHeader(http.StatusCreated)
+	}
+
+	response := mapImportResultToResponse(result, opts.DryRun)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		handleError(w, r, fmt.Errorf("error encoding response: %w", err))
+		return
+	}
+}
+
+// exportFormat returns the format of the format parameter, or else the most preferred format of the Accept header.
+func exportFormat(r *http.Request) (taskcore.Format, error) {
+	if value := r.URL.Query().Get("format"); value != "" {
+		return parseFormat(value)
+	}
+
+	accept := strings.TrimSpace(r.Header.Get("Accept"))
+	if accept == "" {
+		return defaultFormat, nil
+	}
+
+	var (
+		best  taskcore.Format
+		bestQ float64
+	)
+	for part := range strings.SplitSeq(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(part)
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(value, 64); err != nil {
+				continue
+			}
+		}
+
+		format, ok := mediaTypeFormats[mediaType]
+		if !ok && (mediaType == "*/*" || mediaType == "text/*") {
+			format, ok = defaultFormat, true
+		}
+		if ok && q > bestQ {
+			best, bestQ = format, q
+		}
+	}
+
+	if best == "" {
+		return "", fmt.Errorf("%w: %q, expected one of %s", errNotAcceptable, accept, strings.Join(mediaTypes(), ", "))
+	}
+
+	return best, nil
+}
+
+// importFormat returns the format of the format parameter, or else the format of the Content-Type header.
+func importFormat(r *http.Request) (taskcore.Format, error) {
+	if value := r.URL.Query().Get("format"); value != "" {
+		return parseFormat(value)
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", fmt.Errorf("%w: content type %q, expected one of %s",
+			taskcore.ErrUnsupportedFormat, contentType, strings.Join(mediaTypes(), ", "))
+	}
+
+	format, ok := mediaTypeFormats[mediaType]
+	if !ok {
+		return "", fmt.Errorf("%w: content type %q, expected one of %s",
+			taskcore.ErrUnsupportedFormat, mediaType, strings.Join(mediaTypes(), ", "))
+	}
+
+	return format, nil
+}
+
+func parseFormat(value string) (taskcore.Format, error) {
+	format := taskcore.Format(strings.ToLower(value))
+	if !slices.Contains(taskcore.Formats(), format) {
+		return "", newValidationError("format", violationInvalid,
+			fmt.Sprintf("unknown format %q, expected one of %v", value, taskcore.Formats()))
+	}
+
+	return format, nil
+}
+
+func mediaTypes() []string {
+	types := make([]string, 0, len(formatMediaTypes))
+	for _, format := range taskcore.Formats() {
+		types = append(types, formatMediaTypes[format])
+	}
+
+	return types
+}
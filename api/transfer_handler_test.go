@@ -0,0 +1,237 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/core/task"
+	"github.com/utsabbera/task-master/pkg/util"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTransferHandler_Export(t *testing.T) {
+	t.Run("should export tasks in the format of the Accept header", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTransferService := task.NewMockTransferService(ctrl)
+		handler := NewTransferHandler(mockTransferService)
+
+		mockTransferService.EXPECT().Export(gomock.Any(), gomock.Any(), task.FormatMarkdown, task.ListOptions{
+			Filter: task.Filter{Statuses: []task.Status{task.StatusNotStarted}},
+			Sort:   []task.Sort{{Field: task.SortByTitle}},
+		}).DoAndReturn(func(_ any, w io.Writer, _ task.Format, _ task.ListOptions) error {
+			_, err := io.WriteString(w, "- [ ] Write report\n")
+			return err
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/tasks/export?status=NOT_STARTED&sort=title", nil)
+		req.Header.Set("Accept", "application/json, text/csv;q=0.5, text/markdown;q=0.8")
+		res := httptest.NewRecorder()
+		handler.Export(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "text/markdown; charset=utf-8", res.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="tasks.md"`, res.Header().Get("Content-Disposition"))
+		assert.Equal(t, "- [ ] Write report\n", res.Body.String())
+	})
+
+	t.Run("should prefer the format parameter and default to CSV", func(t *testing.T) {
+		for _, tc := range []struct {
+			query  string
+			accept string
+			format task.Format
+		}{
+			{"?format=ndjson", "text/csv", task.FormatNDJSON},
+			{"", "", task.FormatCSV},
+			{"", "*/*", task.FormatCSV},
+			{"", "text/calendar", task.FormatICalendar},
+		} {
+			t.Run(fmt.Sprintf("query %q accept %q", tc.query, tc.accept), func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+				mockTransferService := task.NewMockTransferService(ctrl)
+				handler := NewTransferHandler(mockTransferService)
+
+				mockTransferService.EXPECT().Export(gomock.Any(), gomock.Any(), tc.format, gomock.Any()).Return(nil)
+
+				req := httptest.NewRequest(http.MethodGet, "/tasks/export"+tc.query, nil)
+				if tc.accept != "" {
+					req.Header.Set("Accept", tc.accept)
+				}
+				res := httptest.NewRecorder()
+				handler.Export(res, req)
+
+				assert.Equal(t, http.StatusOK, res.Code)
+			})
+		}
+	})
+
+	t.Run("should return not acceptable when no format is accepted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		handler := NewTransferHandler(task.NewMockTransferService(ctrl))
+
+		req := httptest.NewRequest(http.MethodGet, "/tasks/export", nil)
+		req.Header.Set("Accept", "application/json, text/csv;q=0")
+		res := httptest.NewRecorder()
+		handler.Export(res, req)
+
+		assert.Equal(t, http.StatusNotAcceptable, res.Code)
+		assert.Equal(t, CodeNotAcceptable, decodeProblem(t, res).Code)
+	})
+
+	t.Run("should return bad request when format parameter is unknown", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		handler := NewTransferHandler(task.NewMockTransferService(ctrl))
+
+		req := httptest.NewRequest(http.MethodGet, "/tasks/export?format=xlsx", nil)
+		res := httptest.NewRecorder()
+		handler.Export(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		problem := decodeProblem(t, res)
+		assert.Equal(t, CodeValidationFailed, problem.Code)
+		assert.Equal(t, "format", problem.Errors[0].Field)
+	})
+}
+
+func TestTransferHandler_Import(t *testing.T) {
+	createdAt := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("should import tasks of the format of the Content-Type header", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTransferService := task.NewMockTransferService(ctrl)
+		handler := NewTransferHandler(mockTransferService)
+
+		mockTransferService.EXPECT().Import(gomock.Any(), gomock.Any(), task.FormatCSV, task.ImportOptions{
+			Columns: map[string]string{"Effort": "priority"},
+		}).DoAndReturn(func(_ any, r io.Reader, _ task.Format, _ task.ImportOptions) (*task.ImportResult, error) {
+			body, err := io.ReadAll(r)
+			require.NoError(t, err)
+			assert.Equal(t, "title,Effort\nWrite report,high\n", string(body))
+
+			return &task.ImportResult{Imported: true, Rows: []task.ImportRow{{Line: 2, Task: &task.Task{
+				ID: "TASK-000001", Title: "Write report", Status: task.StatusNotStarted, Priority: util.Ptr(task.PriorityHigh),
+				CreatedAt: createdAt, UpdatedAt: createdAt, Version: 1,
+			}}}}, nil
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/tasks/import?column=Effort:priority", strings.NewReader("title,Effort\nWrite report,high\n"))
+		req.Header.Set("Content-Type", "text/csv; charset=utf-8")
+		res := httptest.NewRecorder()
+		handler.Import(res, req)
+
+		assert.Equal(t, http.StatusCreated, res.Code)
+		var report ImportReport
+		require.NoError(t, json.Unmarshal(res.Body.Bytes(), &report))
+		assert.False(t, report.DryRun)
+		assert.True(t, report.Imported)
+		require.Len(t, report.Rows, 1)
+		assert.Equal(t, 2, report.Rows[0].Line)
+		require.NotNil(t, report.Rows[0].Task)
+		assert.Equal(t, "TASK-000001", report.Rows[0].Task.ID)
+		assert.Equal(t, util.Ptr(task.PriorityHigh), report.Rows[0].Task.Priority)
+	})
+
+	t.Run("should report the errors of the rows of a dry run", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTransferService := task.NewMockTransferService(ctrl)
+		handler := NewTransferHandler(mockTransferService)
+
+		mockTransferService.EXPECT().Import(gomock.Any(), gomock.Any(), task.FormatMarkdown, task.ImportOptions{DryRun: true}).
+			Return(&task.ImportResult{Rows: []task.ImportRow{
+				{Line: 1, Ref: "L1", Task: &task.Task{Title: "Write report"}},
+				{Line: 2, Ref: "L2", Err: errors.New("title is required")},
+			}}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/tasks/import?format=markdown&dryRun=true", strings.NewReader("- [ ] Write report\n"))
+		res := httptest.NewRecorder()
+		handler.Import(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, `{"dryRun":true,"imported":false,"rows":[{"line":1,"id":"L1","task":null},`+
+			`{"line":2,"id":"L2","task":null,"error":"title is required"}]}`, res.Body.String())
+	})
+
+	t.Run("should return unprocessable entity listing the rows which cannot be imported", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTransferService := task.NewMockTransferService(ctrl)
+		handler := NewTransferHandler(mockTransferService)
+
+		failed := []task.ImportRow{{Line: 3, Ref: "b", Err: task.ErrParentNotFound}}
+		mockTransferService.EXPECT().Import(gomock.Any(), gomock.Any(), task.FormatNDJSON, gomock.Any()).
+			Return(&task.ImportResult{Rows: failed}, fmt.Errorf("error importing tasks: %w", &task.ImportError{Rows: failed}))
+
+		req := httptest.NewRequest(http.MethodPost, "/tasks/import", strings.NewReader(`{"id":"b","title":"Review","parentId":"x"}`))
+		req.Header.Set("Content-Type", "application/x-ndjson")
+		res := httptest.NewRecorder()
+		handler.Import(res, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
+		problem := decodeProblem(t, res)
+		assert.Equal(t, CodeInvalidImport, problem.Code)
+		assert.Equal(t, []RowError{{Line: 3, ID: "b", Message: "parent task not found"}}, problem.Rows)
+	})
+
+	t.Run("should return unsupported media type when content type is unknown", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		handler := NewTransferHandler(task.NewMockTransferService(ctrl))
+
+		req := httptest.NewRequest(http.MethodPost, "/tasks/import", strings.NewReader("{}"))
+		req.Header.Set("Content-Type", "application/json")
+		res := httptest.NewRecorder()
+		handler.Import(res, req)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, res.Code)
+		assert.Equal(t, CodeUnsupportedFormat, decodeProblem(t, res).Code)
+	})
+
+	t.Run("should return bad request when query parameters are invalid", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		handler := NewTransferHandler(task.NewMockTransferService(ctrl))
+
+		req := httptest.NewRequest(http.MethodPost, "/tasks/import?format=csv&dryRun=maybe&column=Effort", strings.NewReader("title\n"))
+		res := httptest.NewRecorder()
+		handler.Import(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		problem := decodeProblem(t, res)
+		assert.Equal(t, []string{"dryRun", "column"}, util.Map(problem.Errors, func(e FieldError) string { return e.Field }))
+	})
+
+	t.Run("should return request entity too large when body exceeds the limit", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockTransferService := task.NewMockTransferService(ctrl)
+		handler := NewTransferHandler(mockTransferService)
+
+		mockTransferService.EXPECT().Import(gomock.Any(), gomock.Any(), task.FormatCSV, gomock.Any()).
+			DoAndReturn(func(_ any, r io.Reader, _ task.Format, _ task.ImportOptions) (*task.ImportResult, error) {
+				_, err := io.ReadAll(r)
+				return nil, fmt.Errorf("error importing tasks: %w: %w", task.ErrInvalidImport, err)
+			})
+
+		req := httptest.NewRequest(http.MethodPost, "/tasks/import", strings.NewReader(strings.Repeat("a", maxImportBytes+1)))
+		req.Header.Set("Content-Type", "text/csv")
+		res := httptest.NewRecorder()
+		handler.Import(res, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, res.Code)
+		assert.Equal(t, CodeRequestTooLarge, decodeProblem(t, res).Code)
+	})
+}
@@ -1,37 +1,316 @@
 package api
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/utsabbera/task-master/core/task"
+	"github.com/utsabbera/task-master/core/webhook"
 )
 
 // Task represents a task in the task management system.
+// Project is empty for the tasks of the default project.
 type Task struct {
 	ID          string         `json:"id"`
+	Project     string         `json:"project" example:"WEB"`
 	Title       string         `json:"title"`
 	Description string         `json:"description"`
 	Status      task.Status    `json:"status"`
 	Priority    *task.Priority `json:"priority"`
 	DueDate     *time.Time     `json:"dueDate"`
+	StartedAt   *time.Time     `json:"startedAt"`
+	CompletedAt *time.Time     `json:"completedAt"`
 	CreatedAt   time.Time      `json:"createdAt"`
 	UpdatedAt   time.Time      `json:"updatedAt"`
+	Version     int            `json:"version"`
+	Tags        []string       `json:"tags" example:"backend,urgent"`
+	ParentID    *string        `json:"parentId" example:"TASK-000001"`
+	BlockedBy   []string       `json:"blockedBy" example:"TASK-000002"`
+	Recurrence  *string        `json:"recurrence" example:"FREQ=WEEKLY;BYDAY=MO"`
+	OwnerID     string         `json:"ownerId" example:"alice"`
+	Assignees   []string       `json:"assignees" example:"bob"`
+	Progress    *TaskProgress  `json:"progress"`
 }
 
+// TaskProgress represents the completion of the subtasks of a task, null for tasks without subtasks.
+type TaskProgress struct {
+	Total     int `json:"total" example:"4"`
+	Completed int `json:"completed" example:"1"`
+	Percent   int `json:"percent" example:"25"`
+}
+
+// TaskInput represents the fields of a created or updated task.
+// An empty parentId moves an updated task to the top level.
+// A task blocked by unfinished tasks is BLOCKED until they are completed or cancelled, an empty blockedBy clears them.
+// The recurrence is a RFC 5545 RRULE with FREQ, INTERVAL, BYDAY, COUNT and UNTIL, an empty recurrence stops the task repeating.
+// Only the owner of a task changes its assignees, an empty assignees clears them.
 type TaskInput struct {
 	Title       string         `json:"title"`
 	Description string         `json:"description"`
 	Status      task.Status    `json:"status"`
 	Priority    *task.Priority `json:"priority"`
 	DueDate     *time.Time     `json:"dueDate"`
+	Tags        []string       `json:"tags" example:"backend,urgent"`
+	ParentID    *string        `json:"parentId" example:"TASK-000001"`
+	BlockedBy   []string       `json:"blockedBy" example:"TASK-000002"`
+	Recurrence  *string        `json:"recurrence" example:"FREQ=WEEKLY;BYDAY=MO"`
+	Assignees   []string       `json:"assignees" example:"bob"`
+}
+
+// BatchInput represents a list of task operations applied in order and all together,
+// none of them being applied when one of them fails.
+type BatchInput struct {
+	Operations []BatchOperation `json:"operations"`
+}
+
+// BatchOperation represents a create, update or delete operation of a batch.
+// The ref of a create operation names the created task, later operations use it in place of its ID
+// as their id, parentId or blockedBy. Task holds the created task or the updated fields.
+// An updated or deleted task must have the given version, unless it is zero.
+type BatchOperation struct {
+	Op      task.ChangeType `json:"op" enums:"create,update,delete"`
+	Ref     string          `json:"ref" example:"review"`
+	ID      string          `json:"id" example:"TASK-000001"`
+	Task    *TaskInput      `json:"task"`
+	Version int             `json:"version"`
+	Mode    task.DeleteMode `json:"mode" enums:"reject,orphan,cascade"`
+}
+
+// BatchResult represents the result of an operation of a batch.
+// Task is the created or updated task, null for deleted tasks.
+type BatchResult struct {
+	Op   task.ChangeType `json:"op" enums:"create,update,delete"`
+	ID   string          `json:"id"`
+	Task *Task           `json:"task"`
+}
+
+// ImportReport represents the result of an import, none of its rows is imported when one of them has an error.
+type ImportReport struct {
+	DryRun   bool              `json:"dryRun"`
+	Imported bool              `json:"imported"`
+	Rows     []ImportRowResult `json:"rows"`
+}
+
+// ImportRowResult represents a row of an import file.
+// Task is the created task, or the task as a dry run would create it, null when any row has an error.
+type ImportRowResult struct {
+	Line  int    `json:"line" example:"2"`
+	ID    string `json:"id,omitempty" example:"a"`
+	Task  *Task  `json:"task"`
+	Error string `json:"error,omitempty" example:"title is required"`
+}
+
+// CalendarSubscription represents the URL of the calendar feed of the caller.
+// The URL authenticates the caller with its token, it must be kept secret and is rejected once its token expires.
+type CalendarSubscription struct {
+	URL       string     `json:"url" example:"https://tasks.example.com/tasks.ics?token=eyJzdWIiOiJhbGljZSJ9.c2lnbmF0dXJl"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty" example:"2025-08-01T09:00:00Z"`
+}
+
+// TaskSearchHit represents a task matching a search query with its relevance.
+// The highlights are the fragments of the title and the description of the task with their matching words
+// wrapped in <mark> and </mark> and the rest of their HTML escaped, a long description is shortened around its first match.
+type TaskSearchHit struct {
+	Task       Task              `json:"task"`
+	Score      float64           `json:"score" example:"2.41"`
+	Highlights []SearchHighlight `json:"highlights"`
+}
+
+// SearchHighlight represents a fragment of a field of a task matching a search query.
+type SearchHighlight struct {
+	Field    string `json:"field" enums:"title,description"`
+	Fragment string `json:"fragment" example:"Write the <mark>quarterly</mark> <mark>report</mark>"`
+}
+
+// ExecutionPlan represents the order to work on the open tasks.
+// Every task comes after the tasks blocking it, CriticalPath is the longest chain of blocking tasks ending with a due task.
+type ExecutionPlan struct {
+	Order        []Task `json:"order"`
+	CriticalPath []Task `json:"criticalPath"`
+}
+
+// TaskEvent represents a change recorded in the history of a task.
+// Changes include the timestamps of the task, Actor is empty when who made the change is unknown.
+type TaskEvent struct {
+	Sequence   int64          `json:"sequence" example:"42"`
+	Type       task.EventType `json:"type" enums:"TASK_CREATED,TASK_UPDATED,STATUS_CHANGED,TASK_DELETED"`
+	Version    int            `json:"version" example:"2"`
+	Actor      string         `json:"actor"`
+	OccurredAt time.Time      `json:"occurredAt"`
+	Changes    []FieldChange  `json:"changes"`
+}
+
+// TaskChangeEvent represents a change of a task streamed by the change feed.
+// Task is the task after the change, or before it for deleted tasks. Changes lists the changed fields.
+type TaskChangeEvent struct {
+	ID         int64          `json:"id" example:"42"`
+	Type       task.EventType `json:"type" enums:"TASK_CREATED,TASK_UPDATED,STATUS_CHANGED,TASK_DELETED"`
+	TaskID     string         `json:"taskId"`
+	Task       Task           `json:"task"`
+	Changes    []FieldChange  `json:"changes"`
+	Actor      string         `json:"actor"`
+	OccurredAt time.Time      `json:"occurredAt"`
+}
+
+// MoveInput represents the project a task is moved to, the default project when empty.
+type MoveInput struct {
+	Project string `json:"project" example:"WEB"`
+}
+
+// Project represents a project grouping tasks, its key prefixes the IDs of its tasks.
+type Project struct {
+	Key         string    `json:"key" example:"WEB"`
+	Name        string    `json:"name" example:"Website"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// ProjectInput represents a created or updated project.
+// The key is only set when the project is created, it is upper cased and cannot be changed later.
+type ProjectInput struct {
+	Key         string `json:"key" example:"WEB"`
+	Name        string `json:"name" example:"Website"`
+	Description string `json:"description"`
+}
+
+// View represents a saved filter expression, its tasks are listed at /views/{name}/tasks.
+type View struct {
+	Name        string    `json:"name" example:"open-backend"`
+	Description string    `json:"description"`
+	Filter      string    `json:"filter" example:"status != COMPLETED and (priority = HIGH or due < now+3d) and tag:backend"`
+	Sort        string    `json:"sort" example:"-priority,dueDate"`
+	OwnerID     string    `json:"ownerId" example:"alice"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// ViewInput represents a saved view, its name is lower cased.
+type ViewInput struct {
+	Name        string `json:"name" example:"open-backend"`
+	Description string `json:"description"`
+	Filter      string `json:"filter" example:"status != COMPLETED and (priority = HIGH or due < now+3d) and tag:backend"`
+	Sort        string `json:"sort" example:"-priority,dueDate"`
+}
+
+// Tag represents a tag with the number of tasks having it.
+type Tag struct {
+	Name  string `json:"name" example:"backend"`
+	Count int    `json:"count" example:"3"`
+}
+
+// TagRenameInput represents the new name of a tag.
+type TagRenameInput struct {
+	Name string `json:"name" example:"server"`
+}
+
+// TagMergeInput represents the tags merged into another tag.
+type TagMergeInput struct {
+	Sources []string `json:"sources" example:"api,backend"`
 }
 
 // ChatInput represents a natural language message for task management.
+// A new chat session is started when SessionID is empty.
+// In preview mode the task operations are staged in the session until they are committed or discarded.
 type ChatInput struct {
-	Text string `json:"text"`
+	SessionID string `json:"sessionId"`
+	Text      string `json:"text"`
+	Preview   bool   `json:"preview"`
 }
 
 // ChatResponse represents the response to a natural language message.
+// Changes lists the task operations staged in the session for preview messages.
 type ChatResponse struct {
-	Response string `json:"response"`
+	SessionID string       `json:"sessionId"`
+	Response  string       `json:"response"`
+	Changes   []ChatChange `json:"changes,omitempty"`
+}
+
+// ChatChange represents a task operation staged in a chat session.
+// Before is null for created tasks and After is null for deleted tasks.
+type ChatChange struct {
+	Type   task.ChangeType `json:"type" enums:"create,update,delete"`
+	TaskID string          `json:"taskId"`
+	Before *Task           `json:"before"`
+	After  *Task           `json:"after"`
+	Diff   []FieldChange   `json:"diff"`
+}
+
+// FieldChange represents the change of a single task field.
+type FieldChange struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+// ChatSession represents a chat session with its conversation history.
+type ChatSession struct {
+	ID        string        `json:"id"`
+	Messages  []ChatMessage `json:"messages"`
+	CreatedAt time.Time     `json:"createdAt"`
+	UpdatedAt time.Time     `json:"updatedAt"`
+}
+
+// ChatMessage represents a single message of a chat session.
+type ChatMessage struct {
+	Role       string         `json:"role"`
+	Content    string         `json:"content"`
+	ToolCalls  []ChatToolCall `json:"toolCalls,omitempty"`
+	ToolCallID string         `json:"toolCallId,omitempty"`
+}
+
+// ChatToolCall represents a task function called by the assistant.
+type ChatToolCall struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+}
+
+// ChatEvent represents the data of a streamed chat event.
+// Delta events carry a text chunk in Content, tool call events carry the ToolCall
+// and, once finished, its JSON encoded result in Content.
+type ChatEvent struct {
+	Content  string        `json:"content,omitempty"`
+	ToolCall *ChatToolCall `json:"toolCall,omitempty"`
+}
+
+// WebhookInput represents a new webhook subscription.
+// The webhook receives the changes of the given types, every change when events is empty.
+// The secret signs the payloads, the signature is sent in the X-Webhook-Signature-256 header as sha256=<hex HMAC-SHA256>
+// of the Unix time in seconds sent in the X-Webhook-Timestamp header, a dot and the payload.
+type WebhookInput struct {
+	URL    string           `json:"url" example:"https://example.com/hooks/tasks"`
+	Events []task.EventType `json:"events" example:"TASK_CREATED,STATUS_CHANGED"`
+	Secret string           `json:"secret" example:"s3cr3t"`
+}
+
+// Webhook represents a webhook subscription, its secret is never returned.
+type Webhook struct {
+	ID        string           `json:"id" example:"WH-000001"`
+	URL       string           `json:"url" example:"https://example.com/hooks/tasks"`
+	Events    []task.EventType `json:"events" example:"TASK_CREATED,STATUS_CHANGED"`
+	CreatedAt time.Time        `json:"createdAt"`
+}
+
+// WebhookDelivery represents the delivery of a change to a webhook with its attempts.
+// A PENDING delivery is retried at NextAttemptAt, a DEAD delivery was given up after its last attempt failed.
+type WebhookDelivery struct {
+	ID            string                   `json:"id" example:"DLV-000001"`
+	WebhookID     string                   `json:"webhookId" example:"WH-000001"`
+	EventID       int64                    `json:"eventId" example:"42"`
+	EventType     task.EventType           `json:"eventType" enums:"TASK_CREATED,TASK_UPDATED,STATUS_CHANGED,TASK_DELETED"`
+	Status        webhook.DeliveryStatus   `json:"status" enums:"PENDING,SUCCEEDED,DEAD"`
+	Payload       json.RawMessage          `json:"payload" swaggertype:"object"`
+	Attempts      []WebhookDeliveryAttempt `json:"attempts"`
+	NextAttemptAt *time.Time               `json:"nextAttemptAt"`
+	CreatedAt     time.Time                `json:"createdAt"`
+	UpdatedAt     time.Time                `json:"updatedAt"`
+}
+
+// WebhookDeliveryAttempt represents an attempt to deliver a change to a webhook.
+// StatusCode is 0 when the webhook couldn't be reached, Error is empty for successful attempts.
+type WebhookDeliveryAttempt struct {
+	At         time.Time `json:"at"`
+	StatusCode int       `json:"statusCode" example:"500"`
+	Error      string    `json:"error"`
 }
@@ -0,0 +1,397 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	taskcore "github.com/utsabbera/task-master/core/task"
+)
+
+const (
+	maxBodyBytes           = 1 << 20
+	maxChatTextLength      = 4000
+	maxSessionIDLength     = 128
+	maxTags                = 20
+	maxBlockers            = 50
+	maxAssignees           = 20
+	maxWebhookURLLength    = 2048
+	maxWebhookSecretLength = 256
+	maxProjectNameLength   = 100
+	maxFilterLength        = 1000
+	maxRefLength           = 128
+	maxBatchOperations     = 100
+)
+
+// Violation codes reported in FieldError.Code.
+const (
+	violationRequired    = "required"
+	violationTooLong     = "too_long"
+	violationInvalid     = "invalid"
+	violationOutOfRange  = "out_of_range"
+	violationTooMany     = "too_many"
+	violationUnknown     = "unknown"
+	violationInvalidType = "invalid_type"
+)
+
+type operation int
+
+const (
+	opCreate operation = iota
+	opUpdate
+)
+
+// rule checks a single constraint of a field value, returning the violation code and message when it is not met.
+type rule func() (code, message string)
+
+type fieldRules struct {
+	name  string
+	rules []rule
+}
+
+func field(name string, rules ...rule) fieldRules {
+	return fieldRules{name: name, rules: rules}
+}
+
+// validate checks every field against its rules and reports the first violated rule of each invalid field.
+func validate(fields ...fieldRules) error {
+	var validation ValidationError
+	for _, f := range fields {
+		for _, r := range f.rules {
+			if code, message := r(); code != "" {
+				validation.add(f.name, code, f.name+" "+message)
+				break
+			}
+		}
+	}
+
+	return validation.errOrNil()
+}
+
+func when(condition bool, r rule) rule {
+	return func() (string, string) {
+		if !condition {
+			return "", ""
+		}
+		return r()
+	}
+}
+
+func required(value string) rule {
+	return func() (string, string) {
+		if strings.TrimSpace(value) == "" {
+			return violationRequired, "is required"
+		}
+		return "", ""
+	}
+}
+
+func empty(value string) rule {
+	return func() (string, string) {
+		if value != "" {
+			return violationInvalid, "cannot be changed"
+		}
+		return "", ""
+	}
+}
+
+func maxLength(value string, limit int) rule {
+	return func() (string, string) {
+		if utf8.RuneCountInString(value) > limit {
+			return violationTooLong, fmt.Sprintf("must be at most %d characters", limit)
+		}
+		return "", ""
+	}
+}
+
+func oneOf[T ~string](value T, allowed []T) rule {
+	return func() (string, string) {
+		if value != "" && !slices.Contains(allowed, value) {
+			return violationInvalid, fmt.Sprintf("must be one of %v", allowed)
+		}
+		return "", ""
+	}
+}
+
+func optionalOneOf[T ~string](value *T, allowed []T) rule {
+	if value == nil {
+		return func() (string, string) { return "", "" }
+	}
+	return oneOf(*value, allowed)
+}
+
+func timeBetween(value *time.Time, min, max time.Time) rule {
+	return func() (string, string) {
+		if value != nil && (value.Before(min) || !value.Before(max)) {
+			return violationOutOfRange, fmt.Sprintf("must be between %s and %s", min.Format(time.RFC3339), max.Format(time.RFC3339))
+		}
+		return "", ""
+	}
+}
+
+func eachOneOf[T ~string](values []T, allowed []T) rule {
+	return func() (string, string) {
+		for _, value := range values {
+			if !slices.Contains(allowed, value) {
+				return violationInvalid, fmt.Sprintf("must only contain %v, got %q", allowed, value)
+			}
+		}
+		return "", ""
+	}
+}
+
+func httpURL(value string) rule {
+	return func() (string, string) {
+		u, err := url.Parse(value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return violationInvalid, "must be an absolute http or https URL"
+		}
+		return "", ""
+	}
+}
+
+func notEmpty[T any](values []T) rule {
+	return func() (string, string) {
+		if len(values) == 0 {
+			return violationRequired, "is required"
+		}
+		return "", ""
+	}
+}
+
+func maxItems[T any](values []T, limit int) rule {
+	return func() (string, string) {
+		if len(values) > limit {
+			return violationTooMany, fmt.Sprintf("must have at most %d items", limit)
+		}
+		return "", ""
+	}
+}
+
+func validUserIDs(ids []string) rule {
+	return func() (string, string) {
+		for _, id := range ids {
+			if id = strings.TrimSpace(id); id == "" || utf8.RuneCountInString(id) > taskcore.MaxUserIDLength {
+				return violationInvalid, fmt.Sprintf("must only contain non-blank user IDs of at most %d characters", taskcore.MaxUserIDLength)
+			}
+		}
+		return "", ""
+	}
+}
+
+func validTags(tags []string) rule {
+	return func() (string, string) {
+		for _, tag := range tags {
+			if _, err := taskcore.NormalizeTag(tag); err != nil {
+				return violationInvalid, fmt.Sprintf("must only contain letters, digits, '-', '_' and '/' and be at most %d characters, got %q", taskcore.MaxTagLength, tag)
+			}
+		}
+		return "", ""
+	}
+}
+
+func validRecurrence(rule *string) rule {
+	return func() (string, string) {
+		if rule == nil || *rule == "" {
+			return "", ""
+		}
+		if _, err := taskcore.ParseRecurrence(*rule); err != nil {
+			return violationInvalid, "must be a recurrence rule such as FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR with COUNT or UNTIL, " + err.Error()
+		}
+		return "", ""
+	}
+}
+
+func (in TaskInput) validate(op operation) error {
+	return validate(in.fields("", op)...)
+}
+
+// fields returns the rules of the fields of the task, their names starting with prefix.
+func (in TaskInput) fields(prefix string, op operation) []fieldRules {
+	return []fieldRules{
+		field(prefix+"title", when(op == opCreate, required(in.Title)), maxLength(in.Title, taskcore.MaxTitleLength)),
+		field(prefix+"description", maxLength(in.Description, taskcore.MaxDescriptionLength)),
+		field(prefix+"status", oneOf(in.Status, taskcore.Statuses())),
+		field(prefix+"priority", optionalOneOf(in.Priority, taskcore.Priorities())),
+		field(prefix+"dueDate", timeBetween(in.DueDate, taskcore.MinDueDate, taskcore.MaxDueDate)),
+		field(prefix+"tags", maxItems(in.Tags, maxTags), validTags(in.Tags)),
+		field(prefix+"blockedBy", maxItems(in.BlockedBy, maxBlockers)),
+		field(prefix+"recurrence", validRecurrence(in.Recurrence)),
+		field(prefix+"assignees", maxItems(in.Assignees, maxAssignees), validUserIDs(in.Assignees)),
+	}
+}
+
+func present(value bool) rule {
+	return func() (string, string) {
+		if !value {
+			return violationRequired, "is required"
+		}
+		return "", ""
+	}
+}
+
+func absent(value bool) rule {
+	return func() (string, string) {
+		if value {
+			return violationInvalid, "must not be set"
+		}
+		return "", ""
+	}
+}
+
+func (in BatchInput) validate() error {
+	fields := []fieldRules{
+		field("operations", notEmpty(in.Operations), maxItems(in.Operations, maxBatchOperations)),
+	}
+
+	for i, op := range in.Operations {
+		prefix := fmt.Sprintf("operations[%d].", i)
+		fields = append(fields, field(prefix+"op", required(string(op.Op)), oneOf(op.Op, taskcore.ChangeTypes())))
+		if !slices.Contains(taskcore.ChangeTypes(), op.Op) {
+			continue
+		}
+
+		create, del := op.Op == taskcore.ChangeCreate, op.Op == taskcore.ChangeDelete
+		fields = append(fields,
+			field(prefix+"ref", when(!create, absent(op.Ref != "")), maxLength(op.Ref, maxRefLength)),
+			field(prefix+"id", when(create, absent(op.ID != "")), when(!create, required(op.ID))),
+			field(prefix+"task", when(del, absent(op.Task != nil)), when(!del, present(op.Task != nil))),
+			field(prefix+"version", when(create, absent(op.Version != 0))),
+			field(prefix+"mode", when(!del, absent(op.Mode != "")), oneOf(op.Mode, taskcore.DeleteModes())),
+		)
+
+		if op.Task != nil && !del {
+			taskOp := opUpdate
+			if create {
+				taskOp = opCreate
+			}
+			fields = append(fields, op.Task.fields(prefix+"task.", taskOp)...)
+		}
+	}
+
+	return validate(fields...)
+}
+
+func (in TagRenameInput) validate() error {
+	return validate(
+		field("name", required(in.Name), validTags([]string{in.Name})),
+	)
+}
+
+func (in TagMergeInput) validate() error {
+	return validate(
+		field("sources", notEmpty(in.Sources), validTags(in.Sources)),
+	)
+}
+
+func validProjectKey(key string) rule {
+	return func() (string, string) {
+		if _, err := taskcore.NormalizeProjectKey(key); err != nil {
+			return violationInvalid, fmt.Sprintf("must be 2 to %d letters and digits starting with a letter and not %s, got %q", taskcore.MaxProjectKeyLength, taskcore.DefaultProjectKey, key)
+		}
+		return "", ""
+	}
+}
+
+func (in ProjectInput) validate(op operation) error {
+	return validate(
+		field("key", when(op == opCreate, required(in.Key)), when(op == opCreate, validProjectKey(in.Key)), when(op == opUpdate, empty(in.Key))),
+		field("name", when(op == opCreate, required(in.Name)), maxLength(in.Name, maxProjectNameLength)),
+		field("description", maxLength(in.Description, taskcore.MaxDescriptionLength)),
+	)
+}
+
+func validViewName(name string) rule {
+	return func() (string, string) {
+		if _, err := taskcore.NormalizeViewName(name); err != nil {
+			return violationInvalid, fmt.Sprintf("must be 1 to %d letters, digits, '-' and '_' starting with a letter or a digit, got %q", taskcore.MaxViewNameLength, name)
+		}
+		return "", ""
+	}
+}
+
+func validExpression(source string) rule {
+	return func() (string, string) {
+		if _, err := taskcore.ParseExpression(source); err != nil {
+			return violationInvalid, "is not a valid expression: " + strings.TrimPrefix(err.Error(), taskcore.ErrInvalidExpression.Error()+": ")
+		}
+		return "", ""
+	}
+}
+
+func validSort(value string) rule {
+	return func() (string, string) {
+		if _, err := taskcore.ParseSort(value); err != nil {
+			return violationInvalid, fmt.Sprintf("must be comma separated fields among createdAt, updatedAt, dueDate, priority and title, got %q", value)
+		}
+		return "", ""
+	}
+}
+
+func (in ViewInput) validate() error {
+	return validate(
+		field("name", required(in.Name), validViewName(in.Name)),
+		field("description", maxLength(in.Description, taskcore.MaxDescriptionLength)),
+		field("filter", required(in.Filter), maxLength(in.Filter, maxFilterLength), validExpression(in.Filter)),
+		field("sort", validSort(in.Sort)),
+	)
+}
+
+func (in MoveInput) validate() error {
+	return validate(
+		field("project", when(in.Project != "", validProjectKey(in.Project))),
+	)
+}
+
+func (in WebhookInput) validate() error {
+	return validate(
+		field("url", required(in.URL), maxLength(in.URL, maxWebhookURLLength), httpURL(in.URL)),
+		field("events", eachOneOf(in.Events, taskcore.EventTypes())),
+		field("secret", required(in.Secret), maxLength(in.Secret, maxWebhookSecretLength)),
+	)
+}
+
+func (in ChatInput) validate() error {
+	return validate(
+		field("sessionId", maxLength(in.SessionID, maxSessionIDLength)),
+		field("text", required(in.Text), maxLength(in.Text, maxChatTextLength)),
+	)
+}
+
+// decodeJSON decodes a request body of at most maxBodyBytes into v, rejecting unknown fields and trailing data.
+func decodeJSON(w http.ResponseWriter, r *http.Request, v any) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(v)
+	if err == nil && decoder.Decode(&struct{}{}) != io.EOF {
+		err = errors.New("body must contain a single JSON value")
+	}
+
+	var (
+		maxBytesErr *http.MaxBytesError
+		typeErr     *json.UnmarshalTypeError
+	)
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &maxBytesErr):
+		return fmt.Errorf("%w: body must not exceed %d bytes", errBodyTooLarge, maxBytesErr.Limit)
+	case errors.As(err, &typeErr):
+		return newValidationError(typeErr.Field, violationInvalidType, fmt.Sprintf("%s must be a %s", typeErr.Field, typeErr.Type))
+	}
+
+	if name, found := strings.CutPrefix(err.Error(), "json: unknown field "); found {
+		name = strings.Trim(name, `"`)
+		return newValidationError(name, violationUnknown, fmt.Sprintf("%s is not a known field", name))
+	}
+
+	return fmt.Errorf("%w: %w", errInvalidBody, err)
+}
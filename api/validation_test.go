@@ -0,0 +1,180 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/core/task"
+	"github.com/utsabbera/task-master/pkg/util"
+)
+
+func TestTaskInput_validate(t *testing.T) {
+	t.Run("should accept valid input", func(t *testing.T) {
+		input := TaskInput{
+			Title:    "Write report",
+			Status:   task.StatusInProgress,
+			Priority: util.Ptr(task.PriorityHigh),
+			DueDate:  util.Ptr(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)),
+		}
+
+		assert.NoError(t, input.validate(opCreate))
+	})
+
+	t.Run("should require title only on create", func(t *testing.T) {
+		input := TaskInput{Description: "Q2"}
+
+		assert.Error(t, input.validate(opCreate))
+		assert.NoError(t, input.validate(opUpdate))
+	})
+
+	t.Run("should report every invalid field", func(t *testing.T) {
+		input := TaskInput{
+			Title:       strings.Repeat("é", task.MaxTitleLength+1),
+			Description: strings.Repeat("a", task.MaxDescriptionLength+1),
+			Status:      "DONE",
+			Priority:    util.Ptr(task.Priority("URGENT")),
+			DueDate:     util.Ptr(task.MaxDueDate),
+			Assignees:   []string{"alice", " "},
+		}
+
+		err := input.validate(opUpdate)
+
+		var validationErr *ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []string{"title", "description", "status", "priority", "dueDate", "assignees"}, util.Map(validationErr.Errors, func(e FieldError) string { return e.Field }))
+		assert.Equal(t, []string{"too_long", "too_long", "invalid", "invalid", "out_of_range", "invalid"}, util.Map(validationErr.Errors, func(e FieldError) string { return e.Code }))
+	})
+
+	t.Run("should count title length in characters", func(t *testing.T) {
+		input := TaskInput{Title: strings.Repeat("é", task.MaxTitleLength)}
+
+		assert.NoError(t, input.validate(opCreate))
+	})
+}
+
+func TestBatchInput_validate(t *testing.T) {
+	t.Run("should accept valid operations", func(t *testing.T) {
+		input := BatchInput{Operations: []BatchOperation{
+			{Op: task.ChangeCreate, Ref: "review", Task: &TaskInput{Title: "Review report"}},
+			{Op: task.ChangeUpdate, ID: "review", Task: &TaskInput{Status: task.StatusInProgress}, Version: 1},
+			{Op: task.ChangeDelete, ID: "TASK-000001", Mode: task.DeleteOrphan},
+		}}
+
+		assert.NoError(t, input.validate())
+	})
+
+	t.Run("should require operations", func(t *testing.T) {
+		err := BatchInput{}.validate()
+
+		var validationErr *ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "operations", validationErr.Errors[0].Field)
+	})
+
+	t.Run("should reject too many operations", func(t *testing.T) {
+		input := BatchInput{Operations: make([]BatchOperation, maxBatchOperations+1)}
+		for i := range input.Operations {
+			input.Operations[i] = BatchOperation{Op: task.ChangeDelete, ID: "TASK-000001"}
+		}
+
+		err := input.validate()
+
+		var validationErr *ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []string{"operations"}, util.Map(validationErr.Errors, func(e FieldError) string { return e.Field }))
+		assert.Equal(t, violationTooMany, validationErr.Errors[0].Code)
+	})
+
+	t.Run("should report invalid fields of each operation", func(t *testing.T) {
+		input := BatchInput{Operations: []BatchOperation{
+			{Op: "move", ID: "TASK-000001"},
+			{Op: task.ChangeCreate, ID: "TASK-000001", Version: 1},
+			{Op: task.ChangeUpdate, Ref: "review", Task: &TaskInput{Status: "DONE"}, Mode: task.DeleteCascade},
+			{Op: task.ChangeDelete, ID: "TASK-000001", Task: &TaskInput{}},
+		}}
+
+		err := input.validate()
+
+		var validationErr *ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []string{
+			"operations[0].op",
+			"operations[1].id", "operations[1].task", "operations[1].version",
+			"operations[2].ref", "operations[2].id", "operations[2].mode", "operations[2].task.status",
+			"operations[3].task",
+		}, util.Map(validationErr.Errors, func(e FieldError) string { return e.Field }))
+	})
+}
+
+func TestChatInput_validate(t *testing.T) {
+	t.Run("should require text", func(t *testing.T) {
+		err := ChatInput{Text: "  "}.validate()
+
+		var validationErr *ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []FieldError{{Field: "text", Code: "required", Message: "text is required"}}, validationErr.Errors)
+	})
+
+	t.Run("should limit session ID and text length", func(t *testing.T) {
+		err := ChatInput{SessionID: strings.Repeat("s", maxSessionIDLength+1), Text: strings.Repeat("t", maxChatTextLength+1)}.validate()
+
+		var validationErr *ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []string{"sessionId", "text"}, util.Map(validationErr.Errors, func(e FieldError) string { return e.Field }))
+	})
+}
+
+func TestDecodeJSON(t *testing.T) {
+	decode := func(body string) (TaskInput, error) {
+		var input TaskInput
+		req := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(body))
+		err := decodeJSON(httptest.NewRecorder(), req, &input)
+		return input, err
+	}
+
+	t.Run("should decode body", func(t *testing.T) {
+		input, err := decode(`{"title":"Write report","priority":"HIGH"}`)
+
+		require.NoError(t, err)
+		assert.Equal(t, TaskInput{Title: "Write report", Priority: util.Ptr(task.PriorityHigh)}, input)
+	})
+
+	t.Run("should reject malformed body", func(t *testing.T) {
+		_, err := decode(`{"title":`)
+
+		assert.ErrorIs(t, err, errInvalidBody)
+	})
+
+	t.Run("should reject trailing data", func(t *testing.T) {
+		_, err := decode(`{"title":"a"} {"title":"b"}`)
+
+		assert.ErrorIs(t, err, errInvalidBody)
+	})
+
+	t.Run("should report unknown field", func(t *testing.T) {
+		_, err := decode(`{"title":"a","owner":"me"}`)
+
+		var validationErr *ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []FieldError{{Field: "owner", Code: "unknown", Message: "owner is not a known field"}}, validationErr.Errors)
+	})
+
+	t.Run("should report field of wrong type", func(t *testing.T) {
+		_, err := decode(`{"title":42}`)
+
+		var validationErr *ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []FieldError{{Field: "title", Code: "invalid_type", Message: "title must be a string"}}, validationErr.Errors)
+	})
+
+	t.Run("should reject body exceeding limit", func(t *testing.T) {
+		_, err := decode(`{"description":"` + strings.Repeat("a", maxBodyBytes) + `"}`)
+
+		assert.ErrorIs(t, err, errBodyTooLarge)
+	})
+}
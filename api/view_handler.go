@@ -0,0 +1,236 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	taskcore "github.com/utsabbera/task-master/core/task"
+)
+
+//go:generate mockgen -destination=view_handler_mock.go -package=api . ViewHandler
+
+// ViewHandler defines the interface for handling HTTP requests related to saved views.
+type ViewHandler interface {
+	// Create saves a new view.
+	Create(w http.ResponseWriter, r *http.Request)
+
+	// List lists all views.
+	List(w http.ResponseWriter, r *http.Request)
+
+	// Get retrieves a view by its name.
+	Get(w http.ResponseWriter, r *http.Request)
+
+	// Delete deletes a view by its name.
+	Delete(w http.ResponseWriter, r *http.Request)
+
+	// ListTasks lists the tasks matching a view.
+	ListTasks(w http.ResponseWriter, r *http.Request)
+}
+
+type viewHandler struct {
+	view  taskcore.ViewService
+	tasks *handler
+}
+
+// NewViewHandler returns a new instance of ViewHandler for saved view operations.
+func NewViewHandler(viewService taskcore.ViewService, taskService taskcore.Service) ViewHandler {
+	return &viewHandler{
+		view:  viewService,
+		tasks: &handler{task: taskService},
+	}
+}
+
+// Create godoc
+// @Summary Create View
+// @Description Save a filter expression under a name, e.g. status != COMPLETED and (priority = HIGH or due < now+3d) and tag:backend.
+// @Description Its relative times and me are resolved every time its tasks are listed
+// @Tags views
+// @Accept json
+// @Produce json
+// @Param view body ViewInput true "View input"
+// @Success 201 {object} View
+// @Header 201 {string} Location "URL of the created view"
+// @Failure 400 {object} Problem "Invalid request body or fields"
+// @Failure 403 {object} Problem "Caller is a viewer"
+// @Failure 409 {object} Problem "View already exists"
+// @Failure 413 {object} Problem "Request body too large"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /views [post]
+func (h *viewHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var input ViewInput
+	if err := decodeJSON(w, r, &input); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	if err := input.validate(); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	sort, err := taskcore.ParseSort(input.Sort)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	view := &taskcore.View{
+		Name:        input.Name,
+		Description: input.Description,
+		Filter:      input.Filter,
+		Sort:        sort,
+	}
+
+	if err := h.view.Create(r.Context(), view); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", "/views/"+view.Name)
+	w.WriteHeader(http.StatusCreated)
+
+	response := mapViewToResponse(view)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		handleError(w, r, fmt.Errorf("error encoding response: %w", err))
+		return
+	}
+}
+
+// List godoc
+// @Summary List Views
+// @Description List the saved views sorted by name
+// @Tags views
+// @Produce json
+// @Success 200 {array} View
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /views [get]
+func (h *viewHandler) List(w http.ResponseWriter, r *http.Request) {
+	views, err := h.view.List(r.Context())
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	response := mapViewsToResponse(views)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		handleError(w, r, fmt.Errorf("error encoding response: %w", err))
+		return
+	}
+}
+
+// Get godoc
+// @Summary Get View
+// @Description Get a saved view by name
+// @Tags views
+// @Produce json
+// @Param name path string true "View name"
+// @Success 200 {object} View
+// @Failure 404 {object} Problem "View not found"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /views/{name} [get]
+func (h *viewHandler) Get(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	if name == "" {
+		handleError(w, r, newValidationError("name", violationRequired, "view name is required"))
+		return
+	}
+
+	view, err := h.view.Get(r.Context(), name)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	response := mapViewToResponse(view)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		handleError(w, r, fmt.Errorf("error encoding response: %w", err))
+		return
+	}
+}
+
+// Delete godoc
+// @Summary Delete View
+// @Description Delete a saved view by name, only its owner or an admin may delete it
+// @Tags views
+// @Param name path string true "View name"
+// @Success 204
+// @Failure 403 {object} Problem "Caller neither owns the view nor is an admin"
+// @Failure 404 {object} Problem "View not found"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /views/{name} [delete]
+func (h *viewHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	if name == "" {
+		handleError(w, r, newValidationError("name", violationRequired, "view name is required"))
+		return
+	}
+
+	if err := h.view.Delete(r.Context(), name); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListTasks godoc
+// @Summary List View Tasks
+// @Description List the tasks matching the filter of a view and the filters of the query, sorted by the sort of the view
+// @Description unless sorted otherwise, and paginated like the task list
+// @Tags views
+// @Produce json
+// @Param name path string true "View name"
+// @Param status query []string false "Only tasks with any of these statuses" collectionFormat(csv)
+// @Param tag query []string false "Only tasks having all of these tags, or none of the tags prefixed with !" collectionFormat(multi)
+// @Param filter query string false "Only tasks also matching this expression"
+// @Param sort query string false "Comma separated sort fields (createdAt, updatedAt, dueDate, priority, title), prefixed with - for descending order"
+// @Param limit query int false "Maximum number of tasks to return" default(100) minimum(1) maximum(1000)
+// @Param cursor query string false "Cursor of the page to return, taken from X-Next-Cursor"
+// @Success 200 {array} Task
+// @Header 200 {integer} X-Total-Count "Number of tasks matching the view"
+// @Header 200 {string} X-Next-Cursor "Cursor of the next page, absent on the last page"
+// @Failure 400 {object} Problem "Invalid query parameters"
+// @Failure 404 {object} Problem "View not found"
+// @Failure 422 {object} Problem "Filter expression using me without credentials"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /views/{name}/tasks [get]
+func (h *viewHandler) ListTasks(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	if name == "" {
+		handleError(w, r, newValidationError("name", violationRequired, "view name is required"))
+		return
+	}
+
+	opts, err := parseListOptions(r.Context(), r.URL.Query())
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	page, err := h.view.ListTasks(r.Context(), name, opts)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	h.tasks.writePage(w, r, page)
+}
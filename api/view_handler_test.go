@@ -0,0 +1,209 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/core/task"
+	"github.com/utsabbera/task-master/pkg/util"
+	"go.uber.org/mock/gomock"
+)
+
+func TestViewHandler_Create(t *testing.T) {
+	createdAt := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("should create view", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockViewService := task.NewMockViewService(ctrl)
+		handler := NewViewHandler(mockViewService, task.NewMockService(ctrl))
+
+		mockViewService.EXPECT().Create(gomock.Any(), &task.View{
+			Name:   "Open-Bugs",
+			Filter: "status != COMPLETED and tag:bug",
+			Sort:   []task.Sort{{Field: task.SortByPriority, Descending: true}},
+		}).DoAndReturn(func(_ any, v *task.View) error {
+			v.Name = "open-bugs"
+			v.OwnerID = "alice"
+			v.CreatedAt = createdAt
+			v.UpdatedAt = createdAt
+			return nil
+		})
+
+		body := `{"name":"Open-Bugs","filter":"status != COMPLETED and tag:bug","sort":"-priority"}`
+		req := httptest.NewRequest(http.MethodPost, "/views", strings.NewReader(body))
+		res := httptest.NewRecorder()
+		handler.Create(res, req)
+
+		assert.Equal(t, http.StatusCreated, res.Code)
+		assert.Equal(t, "/views/open-bugs", res.Header().Get("Location"))
+		assert.JSONEq(t, `{"name":"open-bugs","description":"","filter":"status != COMPLETED and tag:bug","sort":"-priority",`+
+			`"ownerId":"alice","createdAt":"2025-05-01T09:00:00Z","updatedAt":"2025-05-01T09:00:00Z"}`, res.Body.String())
+	})
+
+	t.Run("should return bad request when fields are invalid", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		handler := NewViewHandler(task.NewMockViewService(ctrl), task.NewMockService(ctrl))
+
+		body := `{"name":"open bugs","filter":"status = DONE","sort":"color"}`
+		req := httptest.NewRequest(http.MethodPost, "/views", strings.NewReader(body))
+		res := httptest.NewRecorder()
+		handler.Create(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		problem := decodeProblem(t, res)
+		assert.Equal(t, CodeValidationFailed, problem.Code)
+		assert.Equal(t, []string{"name", "filter", "sort"}, util.Map(problem.Errors, func(e FieldError) string { return e.Field }))
+	})
+
+	t.Run("should return conflict when view exists", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockViewService := task.NewMockViewService(ctrl)
+		handler := NewViewHandler(mockViewService, task.NewMockService(ctrl))
+
+		mockViewService.EXPECT().Create(gomock.Any(), gomock.Any()).
+			Return(fmt.Errorf("error creating view: %w", fmt.Errorf("%w: open-bugs", task.ErrViewExists)))
+
+		req := httptest.NewRequest(http.MethodPost, "/views", strings.NewReader(`{"name":"open-bugs","filter":"tag:bug"}`))
+		res := httptest.NewRecorder()
+		handler.Create(res, req)
+
+		assert.Equal(t, http.StatusConflict, res.Code)
+		assert.Equal(t, CodeViewExists, decodeProblem(t, res).Code)
+	})
+}
+
+func TestViewHandler_List(t *testing.T) {
+	t.Run("should return views", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockViewService := task.NewMockViewService(ctrl)
+		handler := NewViewHandler(mockViewService, task.NewMockService(ctrl))
+
+		mockViewService.EXPECT().List(gomock.Any()).Return([]*task.View{{Name: "mine"}, {Name: "open-bugs"}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/views", nil)
+		res := httptest.NewRecorder()
+		handler.List(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		var response []View
+		require.NoError(t, json.Unmarshal(res.Body.Bytes(), &response))
+		assert.Equal(t, []string{"mine", "open-bugs"}, util.Map(response, func(v View) string { return v.Name }))
+	})
+}
+
+func TestViewHandler_Get(t *testing.T) {
+	t.Run("should return not found when view doesn't exist", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockViewService := task.NewMockViewService(ctrl)
+		handler := NewViewHandler(mockViewService, task.NewMockService(ctrl))
+
+		mockViewService.EXPECT().Get(gomock.Any(), "open-bugs").Return(nil, task.ErrViewNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/views/open-bugs", nil)
+		req.SetPathValue("name", "open-bugs")
+		res := httptest.NewRecorder()
+		handler.Get(res, req)
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.Equal(t, CodeViewNotFound, decodeProblem(t, res).Code)
+	})
+}
+
+func TestViewHandler_Delete(t *testing.T) {
+	t.Run("should delete view", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockViewService := task.NewMockViewService(ctrl)
+		handler := NewViewHandler(mockViewService, task.NewMockService(ctrl))
+
+		mockViewService.EXPECT().Delete(gomock.Any(), "open-bugs").Return(nil)
+
+		req := httptest.NewRequest(http.MethodDelete, "/views/open-bugs", nil)
+		req.SetPathValue("name", "open-bugs")
+		res := httptest.NewRecorder()
+		handler.Delete(res, req)
+
+		assert.Equal(t, http.StatusNoContent, res.Code)
+	})
+
+	t.Run("should return forbidden when caller doesn't own the view", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockViewService := task.NewMockViewService(ctrl)
+		handler := NewViewHandler(mockViewService, task.NewMockService(ctrl))
+
+		mockViewService.EXPECT().Delete(gomock.Any(), "open-bugs").
+			Return(fmt.Errorf("error deleting view: %w", fmt.Errorf("%w: open-bugs is not owned by bob", task.ErrForbidden)))
+
+		req := httptest.NewRequest(http.MethodDelete, "/views/open-bugs", nil)
+		req.SetPathValue("name", "open-bugs")
+		res := httptest.NewRecorder()
+		handler.Delete(res, req)
+
+		assert.Equal(t, http.StatusForbidden, res.Code)
+	})
+}
+
+func TestViewHandler_ListTasks(t *testing.T) {
+	t.Run("should return tasks of view", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockViewService := task.NewMockViewService(ctrl)
+		mockTaskService := task.NewMockService(ctrl)
+		handler := NewViewHandler(mockViewService, mockTaskService)
+
+		mockViewService.EXPECT().ListTasks(gomock.Any(), "open-bugs", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, opts task.ListOptions) (*task.Page, error) {
+				require.NotNil(t, opts.Filter.Expression)
+				assert.Equal(t, "priority = HIGH", opts.Filter.Expression.String())
+				assert.Equal(t, 10, opts.Limit)
+				return &task.Page{Tasks: []*task.Task{{ID: "TASK-000001"}}, Total: 3, NextCursor: "MQ"}, nil
+			})
+		mockTaskService.EXPECT().Progress(gomock.Any(), gomock.Any()).Return(map[string]task.Progress{}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/views/open-bugs/tasks?limit=10&filter=priority+%3D+HIGH", nil)
+		req.SetPathValue("name", "open-bugs")
+		res := httptest.NewRecorder()
+		handler.ListTasks(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "3", res.Header().Get("X-Total-Count"))
+		assert.Equal(t, "MQ", res.Header().Get("X-Next-Cursor"))
+
+		var response []Task
+		require.NoError(t, json.Unmarshal(res.Body.Bytes(), &response))
+		assert.Equal(t, []string{"TASK-000001"}, util.Map(response, func(t Task) string { return t.ID }))
+	})
+
+	t.Run("should return unprocessable entity when me is used without credentials", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockViewService := task.NewMockViewService(ctrl)
+		handler := NewViewHandler(mockViewService, task.NewMockService(ctrl))
+
+		mockViewService.EXPECT().ListTasks(gomock.Any(), "mine", gomock.Any()).
+			Return(nil, fmt.Errorf("error listing tasks: %w", fmt.Errorf("%w: me requires an authenticated user at position 1", task.ErrInvalidExpression)))
+
+		req := httptest.NewRequest(http.MethodGet, "/views/mine/tasks", nil)
+		req.SetPathValue("name", "mine")
+		res := httptest.NewRecorder()
+		handler.ListTasks(res, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
+		assert.Equal(t, CodeInvalidExpression, decodeProblem(t, res).Code)
+	})
+}
@@ -0,0 +1,259 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/utsabbera/task-master/core/webhook"
+)
+
+//go:generate mockgen -destination=webhook_handler_mock.go -package=api . WebhookHandler
+
+// WebhookHandler defines the interface for handling HTTP requests related to webhooks.
+type WebhookHandler interface {
+	// Create subscribes a new webhook to the changes of the tasks.
+	Create(w http.ResponseWriter, r *http.Request)
+
+	// List lists all webhooks.
+	List(w http.ResponseWriter, r *http.Request)
+
+	// Get retrieves a webhook by its ID.
+	Get(w http.ResponseWriter, r *http.Request)
+
+	// Delete deletes a webhook by its ID.
+	Delete(w http.ResponseWriter, r *http.Request)
+
+	// ListDeliveries lists the deliveries of a webhook.
+	ListDeliveries(w http.ResponseWriter, r *http.Request)
+
+	// ListDeadLetters lists the deliveries of every webhook given up after their last attempt.
+	ListDeadLetters(w http.ResponseWriter, r *http.Request)
+}
+
+type webhookHandler struct {
+	webhook webhook.Service
+}
+
+// NewWebhookHandler returns a new instance of WebhookHandler for webhook operations.
+func NewWebhookHandler(webhookService webhook.Service) WebhookHandler {
+	return &webhookHandler{
+		webhook: webhookService,
+	}
+}
+
+// Create godoc
+// @Summary Create Webhook
+// @Description Subscribe a webhook to the changes of the tasks. Every change is posted to the URL as a JSON encoded TaskChangeEvent
+// @Description signed with the secret along with its X-Webhook-Timestamp in the X-Webhook-Signature-256 header. A delivery which doesn't get a 2xx response is retried
+// @Description with an exponential backoff and moved to the dead letters after its last attempt.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param webhook body WebhookInput true "Webhook input"
+// @Success 201 {object} Webhook
+// @Failure 400 {object} Problem "Invalid request body or fields"
+// @Failure 413 {object} Problem "Request body too large"
+// @Failure 422 {object} Problem "Invalid webhook"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Failure 403 {object} Problem "Caller is not an admin"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /webhooks [post]
+func (h *webhookHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var input WebhookInput
+	if err := decodeJSON(w, r, &input); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	if err := input.validate(); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	hook := &webhook.Webhook{
+		URL:    input.URL,
+		Events: input.Events,
+		Secret: input.Secret,
+	}
+
+	if err := h.webhook.Create(r.Context(), hook); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", "/webhooks/"+hook.ID)
+	w.WriteHeader(http.StatusCreated)
+
+	response := mapWebhookToResponse(hook)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		handleError(w, r, fmt.Errorf("error encoding response: %w", err))
+		return
+	}
+}
+
+// List godoc
+// @Summary List Webhooks
+// @Description List the webhooks in the order they were created
+// @Tags webhooks
+// @Produce json
+// @Success 200 {array} Webhook
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Failure 403 {object} Problem "Caller is not an admin"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /webhooks [get]
+func (h *webhookHandler) List(w http.ResponseWriter, r *http.Request) {
+	webhooks, err := h.webhook.List(r.Context())
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	response := mapWebhooksToResponse(webhooks)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		handleError(w, r, fmt.Errorf("error encoding response: %w", err))
+		return
+	}
+}
+
+// Get godoc
+// @Summary Get Webhook
+// @Description Get a webhook by ID
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} Webhook
+// @Failure 404 {object} Problem "Webhook not found"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Failure 403 {object} Problem "Caller is not an admin"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /webhooks/{id} [get]
+func (h *webhookHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		handleError(w, r, newValidationError("id", violationRequired, "webhook ID is required"))
+		return
+	}
+
+	hook, err := h.webhook.Get(r.Context(), id)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	response := mapWebhookToResponse(hook)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		handleError(w, r, fmt.Errorf("error encoding response: %w", err))
+		return
+	}
+}
+
+// Delete godoc
+// @Summary Delete Webhook
+// @Description Delete a webhook by ID, its pending deliveries are not retried
+// @Tags webhooks
+// @Param id path string true "Webhook ID"
+// @Success 204
+// @Failure 404 {object} Problem "Webhook not found"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Failure 403 {object} Problem "Caller is not an admin"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /webhooks/{id} [delete]
+func (h *webhookHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		handleError(w, r, newValidationError("id", violationRequired, "webhook ID is required"))
+		return
+	}
+
+	if err := h.webhook.Delete(r.Context(), id); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListDeliveries godoc
+// @Summary List Webhook Deliveries
+// @Description List the latest deliveries of a webhook with their attempts, the most recent first
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Param status query string false "Only deliveries in this state" Enums(PENDING, SUCCEEDED, DEAD)
+// @Success 200 {array} WebhookDelivery
+// @Failure 400 {object} Problem "Invalid status"
+// @Failure 404 {object} Problem "Webhook not found"
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Failure 403 {object} Problem "Caller is not an admin"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /webhooks/{id}/deliveries [get]
+func (h *webhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		handleError(w, r, newValidationError("id", violationRequired, "webhook ID is required"))
+		return
+	}
+
+	status := webhook.DeliveryStatus(r.URL.Query().Get("status"))
+	if err := validate(field("status", oneOf(status, webhook.DeliveryStatuses()))); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	deliveries, err := h.webhook.Deliveries(r.Context(), id, status)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	response := mapDeliveriesToResponse(deliveries)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		handleError(w, r, fmt.Errorf("error encoding response: %w", err))
+		return
+	}
+}
+
+// ListDeadLetters godoc
+// @Summary List Dead Letters
+// @Description List the deliveries of every webhook given up after their last attempt failed, the most recent first
+// @Tags webhooks
+// @Produce json
+// @Success 200 {array} WebhookDelivery
+// @Failure 401 {object} Problem "Missing or invalid credentials"
+// @Failure 403 {object} Problem "Caller is not an admin"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /webhooks/dead-letters [get]
+func (h *webhookHandler) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	deliveries, err := h.webhook.DeadLetters(r.Context())
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	response := mapDeliveriesToResponse(deliveries)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		handleError(w, r, fmt.Errorf("error encoding response: %w", err))
+		return
+	}
+}
@@ -0,0 +1,214 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/core/task"
+	"github.com/utsabbera/task-master/core/webhook"
+	"github.com/utsabbera/task-master/pkg/util"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWebhookHandler_Create(t *testing.T) {
+	createdAt := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("should create webhook without returning its secret", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockWebhookService := webhook.NewMockService(ctrl)
+		handler := NewWebhookHandler(mockWebhookService)
+
+		mockWebhookService.EXPECT().Create(gomock.Any(), &webhook.Webhook{
+			URL:    "https://example.com/hooks",
+			Events: []task.EventType{task.EventCreated},
+			Secret: "s3cr3t",
+		}).DoAndReturn(func(_ any, w *webhook.Webhook) error {
+			w.ID = "WH-000001"
+			w.CreatedAt = createdAt
+			return nil
+		})
+
+		body := `{"url":"https://example.com/hooks","events":["TASK_CREATED"],"secret":"s3cr3t"}`
+		req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+		res := httptest.NewRecorder()
+		handler.Create(res, req)
+
+		assert.Equal(t, http.StatusCreated, res.Code)
+		assert.Equal(t, "/webhooks/WH-000001", res.Header().Get("Location"))
+		assert.JSONEq(t, `{"id":"WH-000001","url":"https://example.com/hooks","events":["TASK_CREATED"],"createdAt":"2025-05-01T09:00:00Z"}`, res.Body.String())
+	})
+
+	t.Run("should return bad request when fields are invalid", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		handler := NewWebhookHandler(webhook.NewMockService(ctrl))
+
+		body := `{"url":"ftp://example.com/hooks","events":["TASK_RENAMED"]}`
+		req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+		res := httptest.NewRecorder()
+		handler.Create(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		problem := decodeProblem(t, res)
+		assert.Equal(t, CodeValidationFailed, problem.Code)
+		assert.Equal(t, []string{"url", "events", "secret"}, util.Map(problem.Errors, func(e FieldError) string { return e.Field }))
+	})
+
+	t.Run("should return unprocessable entity when webhook is invalid", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockWebhookService := webhook.NewMockService(ctrl)
+		handler := NewWebhookHandler(mockWebhookService)
+
+		mockWebhookService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(webhook.ErrInvalidWebhook)
+
+		body := `{"url":"https://example.com/hooks","secret":"s3cr3t"}`
+		req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+		res := httptest.NewRecorder()
+		handler.Create(res, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
+		assert.Equal(t, CodeInvalidWebhook, decodeProblem(t, res).Code)
+	})
+}
+
+func TestWebhookHandler_List(t *testing.T) {
+	t.Run("should return webhooks", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockWebhookService := webhook.NewMockService(ctrl)
+		handler := NewWebhookHandler(mockWebhookService)
+
+		createdAt := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+		mockWebhookService.EXPECT().List(gomock.Any()).Return([]*webhook.Webhook{
+			{ID: "WH-000001", URL: "https://example.com/hooks", Secret: "s3cr3t", CreatedAt: createdAt},
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/webhooks", nil)
+		res := httptest.NewRecorder()
+		handler.List(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, `[{"id":"WH-000001","url":"https://example.com/hooks","events":[],"createdAt":"2025-05-01T09:00:00Z"}]`, res.Body.String())
+	})
+}
+
+func TestWebhookHandler_Get(t *testing.T) {
+	t.Run("should return not found when webhook doesn't exist", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockWebhookService := webhook.NewMockService(ctrl)
+		handler := NewWebhookHandler(mockWebhookService)
+
+		mockWebhookService.EXPECT().Get(gomock.Any(), "WH-000001").Return(nil, webhook.ErrWebhookNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/webhooks/WH-000001", nil)
+		req.SetPathValue("id", "WH-000001")
+		res := httptest.NewRecorder()
+		handler.Get(res, req)
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.Equal(t, CodeWebhookNotFound, decodeProblem(t, res).Code)
+	})
+}
+
+func TestWebhookHandler_Delete(t *testing.T) {
+	t.Run("should delete webhook", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockWebhookService := webhook.NewMockService(ctrl)
+		handler := NewWebhookHandler(mockWebhookService)
+
+		mockWebhookService.EXPECT().Delete(gomock.Any(), "WH-000001").Return(nil)
+
+		req := httptest.NewRequest(http.MethodDelete, "/webhooks/WH-000001", nil)
+		req.SetPathValue("id", "WH-000001")
+		res := httptest.NewRecorder()
+		handler.Delete(res, req)
+
+		assert.Equal(t, http.StatusNoContent, res.Code)
+	})
+}
+
+func TestWebhookHandler_ListDeliveries(t *testing.T) {
+	t.Run("should return deliveries with their attempts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockWebhookService := webhook.NewMockService(ctrl)
+		handler := NewWebhookHandler(mockWebhookService)
+
+		at := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+		next := at.Add(time.Second)
+		mockWebhookService.EXPECT().Deliveries(gomock.Any(), "WH-000001", webhook.DeliveryPending).Return([]*webhook.Delivery{{
+			ID:            "DLV-000001",
+			WebhookID:     "WH-000001",
+			EventID:       42,
+			EventType:     task.EventCreated,
+			Payload:       []byte(`{"id":42}`),
+			Status:        webhook.DeliveryPending,
+			Attempts:      []webhook.Attempt{{At: at, StatusCode: http.StatusInternalServerError, Error: "unexpected response status 500 Internal Server Error"}},
+			NextAttemptAt: &next,
+			CreatedAt:     at,
+			UpdatedAt:     at,
+		}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/webhooks/WH-000001/deliveries?status=PENDING", nil)
+		req.SetPathValue("id", "WH-000001")
+		res := httptest.NewRecorder()
+		handler.ListDeliveries(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, `[{
+			"id":"DLV-000001",
+			"webhookId":"WH-000001",
+			"eventId":42,
+			"eventType":"TASK_CREATED",
+			"status":"PENDING",
+			"payload":{"id":42},
+			"attempts":[{"at":"2025-05-01T09:00:00Z","statusCode":500,"error":"unexpected response status 500 Internal Server Error"}],
+			"nextAttemptAt":"2025-05-01T09:00:01Z",
+			"createdAt":"2025-05-01T09:00:00Z",
+			"updatedAt":"2025-05-01T09:00:00Z"
+		}]`, res.Body.String())
+	})
+
+	t.Run("should return bad request when status is unknown", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		handler := NewWebhookHandler(webhook.NewMockService(ctrl))
+
+		req := httptest.NewRequest(http.MethodGet, "/webhooks/WH-000001/deliveries?status=FAILED", nil)
+		req.SetPathValue("id", "WH-000001")
+		res := httptest.NewRecorder()
+		handler.ListDeliveries(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		problem := decodeProblem(t, res)
+		require.Len(t, problem.Errors, 1)
+		assert.Equal(t, "status", problem.Errors[0].Field)
+	})
+}
+
+func TestWebhookHandler_ListDeadLetters(t *testing.T) {
+	t.Run("should return empty array when there are no dead letters", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockWebhookService := webhook.NewMockService(ctrl)
+		handler := NewWebhookHandler(mockWebhookService)
+
+		mockWebhookService.EXPECT().DeadLetters(gomock.Any()).Return(nil, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/webhooks/dead-letters", nil)
+		res := httptest.NewRecorder()
+		handler.ListDeadLetters(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, `[]`, res.Body.String())
+	})
+}
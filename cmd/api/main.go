@@ -1,11 +1,17 @@
 package main
 
 import (
+	"fmt"
 	"log"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/utsabbera/task-master/api"
+	"github.com/utsabbera/task-master/core/task"
 	_ "github.com/utsabbera/task-master/docs/swagger" // swaggo generated docs
 	"github.com/utsabbera/task-master/pkg/assistant"
+	"github.com/utsabbera/task-master/pkg/middleware"
 )
 
 // @title Task Master
@@ -14,7 +20,22 @@ import (
 // @host localhost:8080
 // @BasePath /
 
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @description Static API key
+
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description JWT bearer token signed with HS256/384/512 or RS256/384/512, as "Bearer <token>", granted the viewer, member or admin role of its roles claim
+
 func main() {
+	auth, err := authConfig()
+	if err != nil {
+		log.Fatalf("error configuring authentication: %v", err)
+	}
+
 	cfg := api.ServerConfig{
 		Addr: ":8080",
 		Assistant: assistant.Config{
@@ -23,9 +44,32 @@ func main() {
 			AppName:        "Task Master",
 			AppDescription: "AI powered application for managing tasks",
 		},
+		Storage: api.StorageConfig{
+			Driver: api.StorageSQLite,
+			DSN:    "task-master.db",
+		},
+		Auth: auth,
+	}
+
+	// TASK_MASTER_ALLOWED_ORIGINS lists the origins of the web pages allowed to open the WebSocket change feed, separated by commas
+	if origins := os.Getenv("TASK_MASTER_ALLOWED_ORIGINS"); origins != "" {
+		for _, origin := range strings.Split(origins, ",") {
+			cfg.AllowedOrigins = append(cfg.AllowedOrigins, strings.TrimSpace(origin))
+		}
 	}
 
-	server := api.NewServer(cfg)
+	// TASK_MASTER_PUBLIC_URL is the URL the clients reach the API at, e.g. https://tasks.example.com, which the calendar
+	// subscription URLs start with instead of the host of the request
+	cfg.PublicURL = os.Getenv("TASK_MASTER_PUBLIC_URL")
+
+	if !auth.Enabled() {
+		log.Println("Authentication is disabled, set TASK_MASTER_API_KEYS or TASK_MASTER_JWT_SECRET to enable it")
+	}
+
+	server, err := api.NewServer(cfg)
+	if err != nil {
+		log.Fatalf("error creating server: %v", err)
+	}
 
 	log.Println("Starting server on", cfg.Addr)
 
@@ -33,3 +77,74 @@ func main() {
 		log.Fatalf("server error: %v", err)
 	}
 }
+
+// authConfig reads the accepted credentials from the environment:
+// TASK_MASTER_API_KEYS lists API keys as key=user pairs separated by commas,
+// TASK_MASTER_JWT_SECRET and TASK_MASTER_JWT_PUBLIC_KEY_FILE verify the HMAC and RSA signed bearer tokens
+// whose issuer and audience are checked against TASK_MASTER_JWT_ISSUER and TASK_MASTER_JWT_AUDIENCE when set,
+// TASK_MASTER_FEED_SECRET, required along with them, signs the calendar feed URLs so that they keep working after a restart
+// and TASK_MASTER_FEED_TOKEN_TTL sets how long they keep working, e.g. 720h.
+func authConfig() (api.AuthConfig, error) {
+	cfg := api.AuthConfig{PublicDocs: true}
+
+	if role := os.Getenv("TASK_MASTER_DEFAULT_ROLE"); role != "" {
+		if !task.Role(role).Valid() {
+			return cfg, fmt.Errorf("invalid default role %q, expected one of %v", role, task.Roles())
+		}
+		cfg.DefaultRole = task.Role(role)
+	}
+
+	if keys := os.Getenv("TASK_MASTER_API_KEYS"); keys != "" {
+		cfg.APIKeys = make(map[string]middleware.Principal)
+		for _, pair := range strings.Split(keys, ",") {
+			key, user, found := strings.Cut(strings.TrimSpace(pair), "=")
+			user, role, hasRole := strings.Cut(user, ":")
+			if !found || key == "" || user == "" || (hasRole && !task.Role(role).Valid()) {
+				return cfg, fmt.Errorf("invalid API key %q, expected key=user or key=user:role with role one of %v", pair, task.Roles())
+			}
+
+			principal := middleware.Principal{Subject: user}
+			if hasRole {
+				principal.Roles = []string{role}
+			}
+			cfg.APIKeys[key] = principal
+		}
+	}
+
+	if feedSecret := os.Getenv("TASK_MASTER_FEED_SECRET"); feedSecret != "" {
+		cfg.FeedSecret = []byte(feedSecret)
+	}
+
+	if ttl := os.Getenv("TASK_MASTER_FEED_TOKEN_TTL"); ttl != "" {
+		feedTokenTTL, err := time.ParseDuration(ttl)
+		if err != nil || feedTokenTTL <= 0 {
+			return cfg, fmt.Errorf("invalid feed token TTL %q, expected a positive duration such as 720h", ttl)
+		}
+		cfg.FeedTokenTTL = feedTokenTTL
+	}
+
+	secret := os.Getenv("TASK_MASTER_JWT_SECRET")
+	publicKeyFile := os.Getenv("TASK_MASTER_JWT_PUBLIC_KEY_FILE")
+	if secret == "" && publicKeyFile == "" {
+		return cfg, nil
+	}
+
+	cfg.JWT = &middleware.JWTConfig{
+		Secret:   []byte(secret),
+		Issuer:   os.Getenv("TASK_MASTER_JWT_ISSUER"),
+		Audience: os.Getenv("TASK_MASTER_JWT_AUDIENCE"),
+	}
+
+	if publicKeyFile != "" {
+		data, err := os.ReadFile(publicKeyFile)
+		if err != nil {
+			return cfg, fmt.Errorf("error reading JWT public key: %w", err)
+		}
+
+		if cfg.JWT.PublicKey, err = middleware.ParseRSAPublicKey(data); err != nil {
+			return cfg, err
+		}
+	}
+
+	return cfg, nil
+}
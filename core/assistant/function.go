@@ -0,0 +1,385 @@
+package assistant
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/utsabbera/task-master/core/task"
+	"github.com/utsabbera/task-master/pkg/assistant"
+	"github.com/utsabbera/task-master/pkg/util"
+)
+
+const (
+	currentUser        = "me"
+	defaultSearchLimit = 5
+	maxSearchLimit     = 20
+)
+
+type taskResult struct {
+	ID          string         `json:"id"`
+	Project     string         `json:"project,omitempty"`
+	Title       string         `json:"title"`
+	Description string         `json:"description,omitempty"`
+	Status      task.Status    `json:"status"`
+	Priority    *task.Priority `json:"priority,omitempty"`
+	DueDate     *time.Time     `json:"dueDate,omitempty"`
+	StartedAt   *time.Time     `json:"startedAt,omitempty"`
+	CompletedAt *time.Time     `json:"completedAt,omitempty"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+	Tags        []string       `json:"tags,omitempty"`
+	ParentID    *string        `json:"parentId,omitempty"`
+	BlockedBy   []string       `json:"blockedBy,omitempty"`
+	Recurrence  string         `json:"recurrence,omitempty"`
+	OwnerID     string         `json:"ownerId,omitempty"`
+	Assignees   []string       `json:"assignees,omitempty"`
+}
+
+type createTaskParams struct {
+	Title       string         `json:"title" jsonschema:"description=Short name of the task,minLength=1"`
+	Description string         `json:"description,omitempty" jsonschema:"description=Additional details about the task"`
+	Status      task.Status    `json:"status,omitempty" jsonschema:"description=Initial status of the task,enum=NOT_STARTED,enum=IN_PROGRESS,enum=BLOCKED,enum=COMPLETED,enum=CANCELLED"`
+	Priority    *task.Priority `json:"priority,omitempty" jsonschema:"description=Importance level of the task,enum=LOW,enum=MEDIUM,enum=HIGH"`
+	DueDate     *time.Time     `json:"dueDate,omitempty" jsonschema:"description=Deadline of the task in RFC 3339 format"`
+	Tags        []string       `json:"tags,omitempty" jsonschema:"description=Labels of the task without the leading #,example=backend"`
+	ParentID    *string        `json:"parentId,omitempty" jsonschema:"description=ID of the task this task is a subtask of,example=TASK-000001"`
+	BlockedBy   []string       `json:"blockedBy,omitempty" jsonschema:"description=IDs of the tasks which must be finished before this task,example=TASK-000002"`
+	Recurrence  string         `json:"recurrence,omitempty" jsonschema:"description=RFC 5545 RRULE of a recurring task with FREQ (DAILY\\, WEEKLY\\, MONTHLY or YEARLY)\\, INTERVAL\\, BYDAY\\, COUNT and UNTIL,example=FREQ=WEEKLY;BYDAY=MO"`
+	Assignees   []string       `json:"assignees,omitempty" jsonschema:"description=IDs of the users the task is assigned to,example=alice"`
+}
+
+type getTaskParams struct {
+	ID string `json:"id" jsonschema:"description=ID of the task,example=TASK-000001"`
+}
+
+type listTasksParams struct {
+	Status   []task.Status   `json:"status,omitempty" jsonschema:"description=Only list tasks with any of these statuses,enum=NOT_STARTED,enum=IN_PROGRESS,enum=BLOCKED,enum=COMPLETED,enum=CANCELLED"`
+	Priority []task.Priority `json:"priority,omitempty" jsonschema:"description=Only list tasks with any of these priorities,enum=LOW,enum=MEDIUM,enum=HIGH"`
+	Query    string          `json:"query,omitempty" jsonschema:"description=Only list tasks whose title or description contains this text"`
+	Tags     []string        `json:"tags,omitempty" jsonschema:"description=Only list tasks having all of these tags"`
+	NotTags  []string        `json:"notTags,omitempty" jsonschema:"description=Only list tasks having none of these tags"`
+	ParentID string          `json:"parentId,omitempty" jsonschema:"description=Only list the subtasks of this task,example=TASK-000001"`
+	Assignee string          `json:"assignee,omitempty" jsonschema:"description=Only list tasks assigned to this user\\, me for the user chatting,example=me"`
+	Sort     string          `json:"sort,omitempty" jsonschema:"description=Comma separated fields to sort by (createdAt\\, updatedAt\\, dueDate\\, priority\\, title)\\, prefixed with - for descending order,example=-priority"`
+}
+
+type searchTasksParams struct {
+	Query  string        `json:"query" jsonschema:"description=Words to look for in the title and the description of the tasks\\, a word ending with * matches every word starting with it,minLength=1,example=quarterly report"`
+	Status []task.Status `json:"status,omitempty" jsonschema:"description=Only find tasks with any of these statuses,enum=NOT_STARTED,enum=IN_PROGRESS,enum=BLOCKED,enum=COMPLETED,enum=CANCELLED"`
+	Limit  int           `json:"limit,omitempty" jsonschema:"description=Maximum number of tasks to return\\, 5 by default,minimum=1,maximum=20"`
+}
+
+type searchTaskResult struct {
+	taskResult
+	Score float64 `json:"score"`
+}
+
+type updateTaskParams struct {
+	ID          string         `json:"id" jsonschema:"description=ID of the task to update,example=TASK-000001"`
+	Title       string         `json:"title,omitempty" jsonschema:"description=New short name of the task"`
+	Description string         `json:"description,omitempty" jsonschema:"description=New details about the task"`
+	Status      task.Status    `json:"status,omitempty" jsonschema:"description=New status of the task\\, moving through the lifecycle NOT_STARTED to IN_PROGRESS to COMPLETED\\, BLOCKED while waiting and CANCELLED when abandoned,enum=NOT_STARTED,enum=IN_PROGRESS,enum=BLOCKED,enum=COMPLETED,enum=CANCELLED"`
+	Priority    *task.Priority `json:"priority,omitempty" jsonschema:"description=New importance level of the task,enum=LOW,enum=MEDIUM,enum=HIGH"`
+	DueDate     *time.Time     `json:"dueDate,omitempty" jsonschema:"description=New deadline of the task in RFC 3339 format"`
+	Tags        []string       `json:"tags,omitempty" jsonschema:"description=New labels of the task\\, replacing all its current tags"`
+	ParentID    *string        `json:"parentId,omitempty" jsonschema:"description=ID of the new parent task\\, empty to move the task to the top level"`
+	BlockedBy   []string       `json:"blockedBy,omitempty" jsonschema:"description=IDs of the tasks which must be finished before this task\\, replacing all its current blockers\\, empty to remove them"`
+	Recurrence  *string        `json:"recurrence,omitempty" jsonschema:"description=New RFC 5545 RRULE of the task\\, empty to stop the task repeating,example=FREQ=MONTHLY;COUNT=6"`
+	Assignees   []string       `json:"assignees,omitempty" jsonschema:"description=IDs of the users the task is assigned to\\, replacing all its current assignees"`
+}
+
+type deleteTaskParams struct {
+	ID   string          `json:"id" jsonschema:"description=ID of the task to delete,example=TASK-000001"`
+	Mode task.DeleteMode `json:"mode,omitempty" jsonschema:"description=What happens to the subtasks of the task: reject refuses to delete a task having subtasks\\, orphan moves them to the top level and cascade deletes them,enum=reject,enum=orphan,enum=cascade"`
+}
+
+type deleteTaskResult struct {
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+}
+
+type batchTasksParams struct {
+	Operations []batchOperationParams `json:"operations" jsonschema:"description=Operations applied in order\\, all of them or none when one fails,minItems=1,maxItems=100"`
+}
+
+type batchOperationParams struct {
+	Ref    string            `json:"ref,omitempty" jsonschema:"description=Temporary ID of the task created by this operation\\, which later operations may use as id\\, parentId or blockedBy,example=review"`
+	Create *createTaskParams `json:"create,omitempty" jsonschema:"description=Task to create"`
+	Update *updateTaskParams `json:"update,omitempty" jsonschema:"description=Fields of the task to update"`
+	Delete *deleteTaskParams `json:"delete,omitempty" jsonschema:"description=Task to delete"`
+}
+
+type batchOperationResult struct {
+	Op   task.ChangeType `json:"op"`
+	ID   string          `json:"id"`
+	Task *taskResult     `json:"task,omitempty"`
+}
+
+type currentTimeParams struct{}
+
+type currentTimeResult struct {
+	Now     time.Time `json:"now"`
+	Weekday string    `json:"weekday"`
+}
+
+func (s *service) functions() []assistant.Function {
+	return []assistant.Function{
+		assistant.NewFunction("create_task", "Create a new task", s.createTask),
+		assistant.NewFunction("get_task", "Get a task by its ID", s.getTask),
+		assistant.NewFunction("list_tasks", "List tasks, optionally filtered by status, priority, tags, parent task, assignee or text and sorted", s.listTasks),
+		assistant.NewFunction("search_tasks", "Find the tasks about a subject by words of their title or description, the most relevant first, use it to resolve the tasks the user refers to", s.searchTasks),
+		assistant.NewFunction("update_task", "Update the fields of an existing task by its ID, only the provided fields are changed", s.updateTask),
+		assistant.NewFunction("delete_task", "Delete a task by its ID", s.deleteTask),
+		assistant.NewFunction("batch_tasks", "Create, update and delete several tasks at once, each operation having exactly one of create, update or delete, use it when the user asks for more than one change", s.batchTasks),
+		assistant.NewFunction("get_current_time", "Get the current date and time, use it to resolve relative dates like tomorrow or Friday", s.currentTime),
+	}
+}
+
+func (s *service) createTask(ctx context.Context, params createTaskParams) (taskResult, error) {
+	t, err := params.task()
+	if err != nil {
+		return taskResult{}, err
+	}
+
+	if err := s.tasks(ctx).Create(ctx, t); err != nil {
+		return taskResult{}, err
+	}
+
+	return mapTaskToResult(t), nil
+}
+
+func (s *service) getTask(ctx context.Context, params getTaskParams) (taskResult, error) {
+	t, err := s.tasks(ctx).Get(ctx, params.ID)
+	if err != nil {
+		return taskResult{}, err
+	}
+
+	return mapTaskToResult(t), nil
+}
+
+func (s *service) listTasks(ctx context.Context, params listTasksParams) ([]taskResult, error) {
+	sort, err := task.ParseSort(params.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := task.NormalizeTags(params.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	excludedTags, err := task.NormalizeTags(params.NotTags)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := task.ListOptions{
+		Filter: task.Filter{
+			Statuses:     params.Status,
+			Priorities:   params.Priority,
+			Query:        params.Query,
+			Tags:         tags,
+			ExcludedTags: excludedTags,
+		},
+		Sort: sort,
+	}
+	if params.ParentID != "" {
+		opts.Filter.Parents = []string{params.ParentID}
+	}
+	if params.Assignee != "" {
+		assignee := params.Assignee
+		if user, ok := task.UserFromContext(ctx); ok && assignee == currentUser {
+			assignee = user.ID
+		}
+		opts.Filter.Assignees = []string{assignee}
+	}
+
+	page, err := s.tasks(ctx).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]taskResult, 0, len(page.Tasks))
+	for _, t := range page.Tasks {
+		results = append(results, mapTaskToResult(t))
+	}
+
+	return results, nil
+}
+
+func (s *service) searchTasks(ctx context.Context, params searchTasksParams) ([]searchTaskResult, error) {
+	limit := params.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+
+	result, err := s.tasks(ctx).Search(ctx, params.Query, task.SearchOptions{
+		Filter: task.Filter{Statuses: params.Status},
+		Limit:  min(limit, maxSearchLimit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]searchTaskResult, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		results = append(results, searchTaskResult{taskResult: mapTaskToResult(hit.Task), Score: hit.Score})
+	}
+
+	return results, nil
+}
+
+func (s *service) updateTask(ctx context.Context, params updateTaskParams) (taskResult, error) {
+	patch, err := params.patch()
+	if err != nil {
+		return taskResult{}, err
+	}
+
+	t, err := s.tasks(ctx).Update(ctx, params.ID, patch)
+	if err != nil {
+		return taskResult{}, err
+	}
+
+	return mapTaskToResult(t), nil
+}
+
+func (s *service) deleteTask(ctx context.Context, params deleteTaskParams) (deleteTaskResult, error) {
+	if err := s.tasks(ctx).Delete(ctx, params.ID, task.DeleteOptions{Mode: params.Mode}); err != nil {
+		return deleteTaskResult{}, err
+	}
+
+	return deleteTaskResult{ID: params.ID, Deleted: true}, nil
+}
+
+func (s *service) batchTasks(ctx context.Context, params batchTasksParams) ([]batchOperationResult, error) {
+	ops := make([]task.Operation, 0, len(params.Operations))
+	for i, p := range params.Operations {
+		op, err := p.operation()
+		if err != nil {
+			return nil, &task.BatchError{Index: i, Err: err}
+		}
+		ops = append(ops, op)
+	}
+
+	changes, err := s.tasks(ctx).Batch(ctx, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]batchOperationResult, 0, len(changes))
+	for _, change := range changes {
+		result := batchOperationResult{Op: change.Type, ID: change.TaskID}
+		if change.After != nil {
+			result.Task = util.Ptr(mapTaskToResult(change.After))
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func (s *service) currentTime(_ context.Context, _ currentTimeParams) (currentTimeResult, error) {
+	now := s.clock.Now()
+
+	return currentTimeResult{Now: now, Weekday: now.Weekday().String()}, nil
+}
+
+func (p createTaskParams) task() (*task.Task, error) {
+	var recurrence *task.Recurrence
+	if p.Recurrence != "" {
+		var err error
+		if recurrence, err = task.ParseRecurrence(p.Recurrence); err != nil {
+			return nil, err
+		}
+	}
+
+	return &task.Task{
+		Title:       p.Title,
+		Description: p.Description,
+		Status:      p.Status,
+		Priority:    p.Priority,
+		DueDate:     p.DueDate,
+		Tags:        p.Tags,
+		ParentID:    p.ParentID,
+		BlockedBy:   p.BlockedBy,
+		Recurrence:  recurrence,
+		Assignees:   p.Assignees,
+	}, nil
+}
+
+func (p updateTaskParams) patch() (*task.Task, error) {
+	var recurrence *task.Recurrence
+	if p.Recurrence != nil {
+		recurrence = &task.Recurrence{}
+		if *p.Recurrence != "" {
+			var err error
+			if recurrence, err = task.ParseRecurrence(*p.Recurrence); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &task.Task{
+		Title:       p.Title,
+		Description: p.Description,
+		Status:      p.Status,
+		Priority:    p.Priority,
+		DueDate:     p.DueDate,
+		Tags:        p.Tags,
+		ParentID:    p.ParentID,
+		BlockedBy:   p.BlockedBy,
+		Recurrence:  recurrence,
+		Assignees:   p.Assignees,
+	}, nil
+}
+
+func (p batchOperationParams) operation() (task.Operation, error) {
+	switch {
+	case p.Create != nil && p.Update == nil && p.Delete == nil:
+		t, err := p.Create.task()
+		if err != nil {
+			return task.Operation{}, err
+		}
+		return task.Operation{Type: task.ChangeCreate, Ref: p.Ref, Task: t}, nil
+	case p.Update != nil && p.Create == nil && p.Delete == nil:
+		patch, err := p.Update.patch()
+		if err != nil {
+			return task.Operation{}, err
+		}
+		return task.Operation{Type: task.ChangeUpdate, TaskID: p.Update.ID, Task: patch}, nil
+	case p.Delete != nil && p.Create == nil && p.Update == nil:
+		return task.Operation{Type: task.ChangeDelete, TaskID: p.Delete.ID, DeleteOptions: task.DeleteOptions{Mode: p.Delete.Mode}}, nil
+	default:
+		return task.Operation{}, fmt.Errorf("%w: operation must have exactly one of create, update or delete", task.ErrInvalidBatch)
+	}
+}
+
+func mapTaskToResult(t *task.Task) taskResult {
+	return taskResult{
+		ID:          t.ID,
+		Project:     t.Project,
+		Title:       t.Title,
+		Description: t.Description,
+		Status:      t.Status,
+		Priority:    t.Priority,
+		DueDate:     t.DueDate,
+		StartedAt:   t.StartedAt,
+		CompletedAt: t.CompletedAt,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+		Tags:        t.Tags,
+		ParentID:    t.ParentID,
+		BlockedBy:   t.BlockedBy,
+		Recurrence:  recurrenceString(t.Recurrence),
+		OwnerID:     t.OwnerID,
+		Assignees:   t.Assignees,
+	}
+}
+
+func recurrenceString(r *task.Recurrence) string {
+	if r == nil {
+		return ""
+	}
+	return r.String()
+}
@@ -2,54 +2,294 @@ package assistant
 
 import (
 	"context"
+	"errors"
+	"sync"
 
 	"github.com/utsabbera/task-master/core/task"
 	"github.com/utsabbera/task-master/pkg/assistant"
+	"github.com/utsabbera/task-master/pkg/util"
 )
 
+// ErrSessionNotFound is returned when a chat session doesn't exist or has expired
+var ErrSessionNotFound = assistant.ErrSessionNotFound
+
 //go:generate mockgen -destination=service_mock.go -package=assistant . Service
 
 // Service defines the interface for processing natural language messages
 // for task management operations
 type Service interface {
-	// Chat handles a natural language message and performs the appropriate task operation
-	Chat(ctx context.Context, message string) (string, error)
+	// Chat handles a natural language message within a session and performs the appropriate task operation.
+	// A new session is started when the session ID of the request is empty or unknown, owned by the user of the context.
+	// Returns ErrSessionNotFound if the session was started by another user.
+	// In preview mode the task operations are staged in the session instead of being applied.
+	Chat(ctx context.Context, req Request) (*Reply, error)
+
+	// ChatStream handles a natural language message like Chat, reporting the text chunks of the response
+	// and the task operations performed to emit as they happen.
+	ChatStream(ctx context.Context, req Request, emit assistant.EmitFunc) (*Reply, error)
+
+	// GetSession retrieves a chat session with its conversation history
+	// Returns ErrSessionNotFound if the session doesn't exist, has expired or was started by another user
+	GetSession(ctx context.Context, sessionID string) (*assistant.Session, error)
+
+	// DeleteSession resets a chat session by removing its conversation history
+	// Returns ErrSessionNotFound if the session doesn't exist, has expired or was started by another user
+	// The task operations staged in the session are discarded.
+	DeleteSession(ctx context.Context, sessionID string) error
+
+	// Changes returns the task operations staged in a session by preview chats
+	// Returns ErrSessionNotFound if the session doesn't exist, has expired or was started by another user
+	Changes(ctx context.Context, sessionID string) ([]task.Change, error)
+
+	// Commit applies the task operations staged in a session all together and returns them as committed
+	// Returns ErrSessionNotFound if the session doesn't exist, has expired or was started by another user, and task.ErrStaleChange
+	// if a changed task was modified since the operation was staged
+	Commit(ctx context.Context, sessionID string) ([]task.Change, error)
+
+	// Discard drops the task operations staged in a session
+	// Returns ErrSessionNotFound if the session doesn't exist, has expired or was started by another user
+	Discard(ctx context.Context, sessionID string) error
+}
+
+// Request represents a chat message sent to the assistant
+type Request struct {
+	// SessionID is the ID of the session the message belongs to, a new session is started when empty
+	SessionID string
+	// Message is the natural language message of the user
+	Message string
+	// Preview stages the task operations requested by the message instead of applying them
+	Preview bool
+}
+
+// Reply represents the response of the assistant to a chat message
+type Reply struct {
+	// SessionID is the ID of the session the message belongs to
+	SessionID string
+	// Response is the natural language response of the assistant
+	Response string
+	// Changes are the task operations staged in the session, only set for preview requests
+	Changes []task.Change
+}
+
+type stageKey struct{}
+
+// stageEntry is the stage of a session along with the number of preview chats in progress in the session,
+// whose stage is kept even though the session may not be saved yet
+type stageEntry struct {
+	stage *task.Stage
+	chats int
 }
 
 type service struct {
 	task      task.Service
 	assistant assistant.Client
+	clock     util.Clock
+	stages    map[string]*stageEntry
+	mu        sync.Mutex
 }
 
-// NewService creates a new assistant service with the provided task service
-func NewService(taskService task.Service, assistantClient assistant.Client) Service {
+// NewService creates a new assistant service with the provided task service.
+// It registers the task management functions with the assistant client before initializing it.
+func NewService(taskService task.Service, assistantClient assistant.Client, clock util.Clock) Service {
 	service := &service{
 		task:      taskService,
 		assistant: assistantClient,
+		clock:     clock,
+		stages:    make(map[string]*stageEntry),
 	}
 
+	service.assistant.RegisterFunctions(service.functions()...)
 	service.assistant.Init()
 
 	return service
 }
 
-// Chat handles a natural language message and delegates to the appropriate task service method
-func (s *service) Chat(ctx context.Context, message string) (string, error) {
-	// TODO: Implement the logic to process the message using the assistant client
-	//
-	// return s.assistant.Chat(ctx, message)
+// Chat handles a natural language message by passing it to the assistant client,
+// which calls the registered task functions as required by the message
+func (s *service) Chat(ctx context.Context, req Request) (*Reply, error) {
+	return s.chat(ctx, req, func(ctx context.Context, sessionID string) (string, error) {
+		return s.assistant.Chat(ctx, sessionID, req.Message)
+	})
+}
+
+// ChatStream handles a natural language message like Chat, streaming the progress of the response to emit
+func (s *service) ChatStream(ctx context.Context, req Request, emit assistant.EmitFunc) (*Reply, error) {
+	return s.chat(ctx, req, func(ctx context.Context, sessionID string) (string, error) {
+		return s.assistant.ChatStream(ctx, sessionID, req.Message, emit)
+	})
+}
+
+func (s *service) chat(ctx context.Context, req Request, send func(ctx context.Context, sessionID string) (string, error)) (*Reply, error) {
+	sessionID := req.SessionID
+	if sessionID == "" {
+		sessionID = assistant.NewSessionID()
+	}
+
+	if user, ok := task.UserFromContext(ctx); ok {
+		ctx = assistant.WithOwner(ctx, user.ID)
+	}
+
+	var stage *task.Stage
+	if req.Preview {
+		var release func()
+		stage, release = s.acquire(ctx, sessionID)
+		defer release()
+		ctx = context.WithValue(ctx, stageKey{}, stage)
+	}
+
+	response, err := send(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &Reply{SessionID: sessionID, Response: response}
+	if stage != nil {
+		reply.Changes = stage.Changes()
+	}
+
+	return reply, nil
+}
+
+func (s *service) GetSession(ctx context.Context, sessionID string) (*assistant.Session, error) {
+	return s.session(ctx, sessionID)
+}
+
+func (s *service) DeleteSession(ctx context.Context, sessionID string) error {
+	if _, err := s.session(ctx, sessionID); err != nil {
+		return err
+	}
+
+	if err := s.assistant.DeleteSession(ctx, sessionID); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.stages, sessionID)
+
+	return nil
+}
+
+func (s *service) Changes(ctx context.Context, sessionID string) ([]task.Change, error) {
+	if _, err := s.session(ctx, sessionID); err != nil {
+		return nil, err
+	}
+
+	stage := s.staged(sessionID)
+	if stage == nil {
+		return []task.Change{}, nil
+	}
+
+	return stage.Changes(), nil
+}
+
+func (s *service) Commit(ctx context.Context, sessionID string) ([]task.Change, error) {
+	if _, err := s.session(ctx, sessionID); err != nil {
+		return nil, err
+	}
+
+	stage := s.staged(sessionID)
+	if stage == nil {
+		return []task.Change{}, nil
+	}
+
+	return stage.Commit(ctx)
+}
+
+func (s *service) Discard(ctx context.Context, sessionID string) error {
+	if _, err := s.session(ctx, sessionID); err != nil {
+		return err
+	}
+
+	if stage := s.staged(sessionID); stage != nil {
+		stage.Discard()
+	}
 
-	// Placeholder implementation for creating a task from the message
-	task := &task.Task{
-		Title:       message,
-		Description: "",
-		Priority:    nil,
-		DueDate:     nil,
+	return nil
+}
+
+// session returns the session if it was started by the user of the context, the sessions of the other users
+// are reported as not found so that their IDs can't be probed
+func (s *service) session(ctx context.Context, sessionID string) (*assistant.Session, error) {
+	session, err := s.assistant.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var owner string
+	if user, ok := task.UserFromContext(ctx); ok {
+		owner = user.ID
+	}
+	if session.Owner != owner {
+		return nil, ErrSessionNotFound
 	}
 
-	if err := s.task.Create(task); err != nil {
-		return "", err
+	return session, nil
+}
+
+// acquire returns the stage of the session for a preview chat, creating it on the first preview chat of the session,
+// and the function releasing it once the chat is over. The stages of the sessions which have expired are dropped
+// along with their staged operations whenever a stage is created, a session ID reused after its session expired
+// starts with an empty stage
+func (s *service) acquire(ctx context.Context, sessionID string) (*task.Stage, func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.stages[sessionID]
+	if !ok || (entry.chats == 0 && s.expired(ctx, sessionID)) {
+		s.evict(ctx)
+		entry = &stageEntry{stage: task.NewStage(s.task, s.clock)}
+		s.stages[sessionID] = entry
+	}
+	entry.chats++
+
+	return entry.stage, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		entry.chats--
+	}
+}
+
+// staged returns the stage of the session, nil if nothing was staged in it
+func (s *service) staged(sessionID string) *task.Stage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry, ok := s.stages[sessionID]; ok {
+		return entry.stage
+	}
+
+	return nil
+}
+
+// evict drops the stages of the sessions which no longer exist, except those of the sessions having a chat in progress
+func (s *service) evict(ctx context.Context) {
+	for id, entry := range s.stages {
+		if entry.chats > 0 {
+			continue
+		}
+		if s.expired(ctx, id) {
+			delete(s.stages, id)
+		}
+	}
+}
+
+// expired reports whether the session no longer exists
+func (s *service) expired(ctx context.Context, sessionID string) bool {
+	_, err := s.assistant.GetSession(ctx, sessionID)
+	return errors.Is(err, ErrSessionNotFound)
+}
+
+// tasks returns the service the task functions operate on, the stage of the session for preview requests.
+// The staged operations are checked against the permissions of the user of the context as they are staged
+func (s *service) tasks(ctx context.Context) task.Service {
+	if stage, ok := ctx.Value(stageKey{}).(*task.Stage); ok {
+		if _, ok := task.UserFromContext(ctx); ok {
+			return task.NewAccessControl(stage)
+		}
+		return stage
 	}
 
-	return "Task created: " + task.ID, nil
+	return s.task
 }
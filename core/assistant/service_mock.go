@@ -13,6 +13,8 @@ import (
 	context "context"
 	reflect "reflect"
 
+	task "github.com/utsabbera/task-master/core/task"
+	assistant0 "github.com/utsabbera/task-master/pkg/assistant"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -39,11 +41,26 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 	return m.recorder
 }
 
+// Changes mocks base method.
+func (m *MockService) Changes(arg0 context.Context, arg1 string) ([]task.Change, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Changes", arg0, arg1)
+	ret0, _ := ret[0].([]task.Change)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Changes indicates an expected call of Changes.
+func (mr *MockServiceMockRecorder) Changes(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Changes", reflect.TypeOf((*MockService)(nil).Changes), arg0, arg1)
+}
+
 // Chat mocks base method.
-func (m *MockService) Chat(arg0 context.Context, arg1 string) (string, error) {
+func (m *MockService) Chat(arg0 context.Context, arg1 Request) (*Reply, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Chat", arg0, arg1)
-	ret0, _ := ret[0].(string)
+	ret0, _ := ret[0].(*Reply)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -53,3 +70,76 @@ func (mr *MockServiceMockRecorder) Chat(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Chat", reflect.TypeOf((*MockService)(nil).Chat), arg0, arg1)
 }
+
+// ChatStream mocks base method.
+func (m *MockService) ChatStream(arg0 context.Context, arg1 Request, arg2 assistant0.EmitFunc) (*Reply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChatStream", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*Reply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChatStream indicates an expected call of ChatStream.
+func (mr *MockServiceMockRecorder) ChatStream(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChatStream", reflect.TypeOf((*MockService)(nil).ChatStream), arg0, arg1, arg2)
+}
+
+// Commit mocks base method.
+func (m *MockService) Commit(arg0 context.Context, arg1 string) ([]task.Change, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Commit", arg0, arg1)
+	ret0, _ := ret[0].([]task.Change)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Commit indicates an expected call of Commit.
+func (mr *MockServiceMockRecorder) Commit(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockService)(nil).Commit), arg0, arg1)
+}
+
+// DeleteSession mocks base method.
+func (m *MockService) DeleteSession(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSession", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSession indicates an expected call of DeleteSession.
+func (mr *MockServiceMockRecorder) DeleteSession(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockService)(nil).DeleteSession), arg0, arg1)
+}
+
+// Discard mocks base method.
+func (m *MockService) Discard(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Discard", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Discard indicates an expected call of Discard.
+func (mr *MockServiceMockRecorder) Discard(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Discard", reflect.TypeOf((*MockService)(nil).Discard), arg0, arg1)
+}
+
+// GetSession mocks base method.
+func (m *MockService) GetSession(arg0 context.Context, arg1 string) (*assistant0.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", arg0, arg1)
+	ret0, _ := ret[0].(*assistant0.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockServiceMockRecorder) GetSession(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockService)(nil).GetSession), arg0, arg1)
+}
@@ -4,81 +4,810 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/utsabbera/task-master/core/task"
 	"github.com/utsabbera/task-master/pkg/assistant"
+	"github.com/utsabbera/task-master/pkg/idgen"
+	"github.com/utsabbera/task-master/pkg/match"
+	"github.com/utsabbera/task-master/pkg/util"
 	"go.uber.org/mock/gomock"
 )
 
 func TestNewService(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+	t.Run("should register functions and initialize the assistant", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
 
-	mockTaskService := task.NewMockService(ctrl)
-	mockAssistant := assistant.NewMockClient(ctrl)
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistant := assistant.NewMockClient(ctrl)
+		clock := util.NewMockClock(ctrl)
 
-	mockAssistant.EXPECT().Init()
+		gomock.InOrder(
+			mockAssistant.EXPECT().RegisterFunctions(gomock.Any()),
+			mockAssistant.EXPECT().Init(),
+		)
 
-	svc := NewService(mockTaskService, mockAssistant)
-	assert.NotNil(t, svc)
+		svc := NewService(mockTaskService, mockAssistant, clock)
+		assert.NotNil(t, svc)
+	})
 }
 
 func TestService_Chat(t *testing.T) {
-	t.Run("should create task from message", func(t *testing.T) {
+	t.Run("should return response from assistant", func(t *testing.T) {
 		ctx := context.Background()
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
 		mockTaskService := task.NewMockService(ctrl)
 		mockAssistant := assistant.NewMockClient(ctrl)
+		clock := util.NewMockClock(ctrl)
 
+		mockAssistant.EXPECT().RegisterFunctions(gomock.Any())
 		mockAssistant.EXPECT().Init()
+		mockAssistant.EXPECT().Chat(ctx, "session-1", "Create a new task").Return("Task created: TASK-123", nil)
 
-		service := NewService(mockTaskService, mockAssistant)
-		message := "Create a new task"
+		service := NewService(mockTaskService, mockAssistant, clock)
 
-		expectedTask := &task.Task{
-			Title:       message,
-			Description: "",
-			Priority:    nil,
-			DueDate:     nil,
-		}
-		mockTaskService.EXPECT().Create(expectedTask).DoAndReturn(func(t *task.Task) error {
-			t.ID = "TASK-123"
+		reply, err := service.Chat(ctx, Request{SessionID: "session-1", Message: "Create a new task"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &Reply{SessionID: "session-1", Response: "Task created: TASK-123"}, reply)
+	})
+
+	t.Run("should start a new session when session id is empty", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistant := assistant.NewMockClient(ctrl)
+		clock := util.NewMockClock(ctrl)
+
+		var sessionID string
+		mockAssistant.EXPECT().RegisterFunctions(gomock.Any())
+		mockAssistant.EXPECT().Init()
+		mockAssistant.EXPECT().Chat(ctx, gomock.Any(), "Hello").DoAndReturn(func(_ context.Context, id, _ string) (string, error) {
+			sessionID = id
+			return "Hi", nil
+		})
+
+		service := NewService(mockTaskService, mockAssistant, clock)
+
+		reply, err := service.Chat(ctx, Request{SessionID: "", Message: "Hello"})
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, reply.SessionID)
+		assert.Equal(t, sessionID, reply.SessionID)
+	})
+
+	t.Run("should return error from assistant", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistant := assistant.NewMockClient(ctrl)
+		clock := util.NewMockClock(ctrl)
+
+		mockAssistant.EXPECT().RegisterFunctions(gomock.Any())
+		mockAssistant.EXPECT().Init()
+		mockAssistant.EXPECT().Chat(ctx, "session-1", "Invalid task").Return("", errors.New("assistant failed"))
+
+		service := NewService(mockTaskService, mockAssistant, clock)
+
+		reply, err := service.Chat(ctx, Request{SessionID: "session-1", Message: "Invalid task"})
+
+		assert.Error(t, err)
+		assert.Nil(t, reply)
+		assert.ErrorContains(t, err, "assistant failed")
+	})
+}
+
+func TestService_ChatStream(t *testing.T) {
+	t.Run("should stream response from assistant", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistant := assistant.NewMockClient(ctrl)
+		clock := util.NewMockClock(ctrl)
+
+		mockAssistant.EXPECT().RegisterFunctions(gomock.Any())
+		mockAssistant.EXPECT().Init()
+		mockAssistant.EXPECT().ChatStream(ctx, "session-1", "Hello", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _ string, emit assistant.EmitFunc) (string, error) {
+				require.NoError(t, emit(assistant.Event{Type: assistant.EventDelta, Content: "Hi"}))
+				return "Hi", nil
+			})
+
+		service := NewService(mockTaskService, mockAssistant, clock)
+
+		var events []assistant.Event
+		reply, err := service.ChatStream(ctx, Request{SessionID: "session-1", Message: "Hello"}, func(event assistant.Event) error {
+			events = append(events, event)
 			return nil
 		})
 
-		result, err := service.Chat(ctx, message)
-		assert.NoError(t, err)
-		assert.Contains(t, result, "Task created")
+		require.NoError(t, err)
+		assert.Equal(t, &Reply{SessionID: "session-1", Response: "Hi"}, reply)
+		assert.Equal(t, []assistant.Event{{Type: assistant.EventDelta, Content: "Hi"}}, events)
+	})
+
+	t.Run("should start a new session when session id is empty", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTaskService := task.NewMockService(ctrl)
+		mockAssistant := assistant.NewMockClient(ctrl)
+		clock := util.NewMockClock(ctrl)
+
+		mockAssistant.EXPECT().RegisterFunctions(gomock.Any())
+		mockAssistant.EXPECT().Init()
+		mockAssistant.EXPECT().ChatStream(ctx, gomock.Not(""), "Hello", gomock.Any()).Return("Hi", nil)
+
+		service := NewService(mockTaskService, mockAssistant, clock)
+
+		reply, err := service.ChatStream(ctx, Request{SessionID: "", Message: "Hello"}, func(assistant.Event) error { return nil })
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, reply.SessionID)
 	})
 
-	t.Run("should handle errors from task service", func(t *testing.T) {
+	t.Run("should return error from assistant", func(t *testing.T) {
 		ctx := context.Background()
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
 		mockTaskService := task.NewMockService(ctrl)
 		mockAssistant := assistant.NewMockClient(ctrl)
+		clock := util.NewMockClock(ctrl)
 
+		mockAssistant.EXPECT().RegisterFunctions(gomock.Any())
 		mockAssistant.EXPECT().Init()
+		mockAssistant.EXPECT().ChatStream(ctx, "session-1", "Hello", gomock.Any()).Return("", errors.New("assistant failed"))
 
-		service := NewService(mockTaskService, mockAssistant)
-		message := "Invalid task"
+		service := NewService(mockTaskService, mockAssistant, clock)
+
+		reply, err := service.ChatStream(ctx, Request{SessionID: "session-1", Message: "Hello"}, func(assistant.Event) error { return nil })
+
+		assert.EqualError(t, err, "assistant failed")
+		assert.Nil(t, reply)
+	})
+}
+
+func TestService_GetSession(t *testing.T) {
+	t.Run("should return session from assistant", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAssistant := assistant.NewMockClient(ctrl)
+		mockAssistant.EXPECT().RegisterFunctions(gomock.Any())
+		mockAssistant.EXPECT().Init()
+
+		session := &assistant.Session{ID: "session-1", Messages: []assistant.Message{{Role: assistant.RoleUser, Content: "Hello"}}}
+		mockAssistant.EXPECT().GetSession(ctx, "session-1").Return(session, nil)
+
+		service := NewService(task.NewMockService(ctrl), mockAssistant, util.NewMockClock(ctrl))
+
+		result, err := service.GetSession(ctx, "session-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, session, result)
+	})
+
+	t.Run("should return error when session was started by another user", func(t *testing.T) {
+		ctx := task.WithUser(context.Background(), task.User{ID: "bob", Role: task.RoleMember})
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
 
-		expectedTask := &task.Task{
-			Title:       message,
-			Description: "",
-			Priority:    nil,
-			DueDate:     nil,
+		mockAssistant := assistant.NewMockClient(ctrl)
+		mockAssistant.EXPECT().RegisterFunctions(gomock.Any())
+		mockAssistant.EXPECT().Init()
+		mockAssistant.EXPECT().GetSession(ctx, "session-1").Return(&assistant.Session{ID: "session-1", Owner: "alice"}, nil)
+
+		service := NewService(task.NewMockService(ctrl), mockAssistant, util.NewMockClock(ctrl))
+
+		result, err := service.GetSession(ctx, "session-1")
+
+		assert.ErrorIs(t, err, ErrSessionNotFound)
+		assert.Nil(t, result)
+	})
+
+	t.Run("should return error when session not found", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAssistant := assistant.NewMockClient(ctrl)
+		mockAssistant.EXPECT().RegisterFunctions(gomock.Any())
+		mockAssistant.EXPECT().Init()
+		mockAssistant.EXPECT().GetSession(ctx, "unknown").Return(nil, assistant.ErrSessionNotFound)
+
+		service := NewService(task.NewMockService(ctrl), mockAssistant, util.NewMockClock(ctrl))
+
+		result, err := service.GetSession(ctx, "unknown")
+
+		assert.ErrorIs(t, err, ErrSessionNotFound)
+		assert.Nil(t, result)
+	})
+}
+
+func TestService_DeleteSession(t *testing.T) {
+	t.Run("should delete session from assistant", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAssistant := assistant.NewMockClient(ctrl)
+		mockAssistant.EXPECT().RegisterFunctions(gomock.Any())
+		mockAssistant.EXPECT().Init()
+		mockAssistant.EXPECT().GetSession(ctx, "session-1").Return(&assistant.Session{ID: "session-1"}, nil)
+		mockAssistant.EXPECT().DeleteSession(ctx, "session-1").Return(nil)
+
+		service := NewService(task.NewMockService(ctrl), mockAssistant, util.NewMockClock(ctrl))
+
+		err := service.DeleteSession(ctx, "session-1")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should not delete the session of another user", func(t *testing.T) {
+		ctx := task.WithUser(context.Background(), task.User{ID: "bob", Role: task.RoleAdmin})
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAssistant := assistant.NewMockClient(ctrl)
+		mockAssistant.EXPECT().RegisterFunctions(gomock.Any())
+		mockAssistant.EXPECT().Init()
+		mockAssistant.EXPECT().GetSession(ctx, "session-1").Return(&assistant.Session{ID: "session-1", Owner: "alice"}, nil)
+
+		service := NewService(task.NewMockService(ctrl), mockAssistant, util.NewMockClock(ctrl))
+
+		err := service.DeleteSession(ctx, "session-1")
+
+		assert.ErrorIs(t, err, ErrSessionNotFound)
+	})
+}
+
+func TestService_Changes(t *testing.T) {
+	t.Run("should return error when session not found", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAssistant := assistant.NewMockClient(ctrl)
+		mockAssistant.EXPECT().RegisterFunctions(gomock.Any())
+		mockAssistant.EXPECT().Init()
+		mockAssistant.EXPECT().GetSession(ctx, "unknown").Return(nil, assistant.ErrSessionNotFound).Times(3)
+
+		service := NewService(task.NewMockService(ctrl), mockAssistant, util.NewMockClock(ctrl))
+
+		changes, err := service.Changes(ctx, "unknown")
+		assert.ErrorIs(t, err, ErrSessionNotFound)
+		assert.Nil(t, changes)
+
+		changes, err = service.Commit(ctx, "unknown")
+		assert.ErrorIs(t, err, ErrSessionNotFound)
+		assert.Nil(t, changes)
+
+		assert.ErrorIs(t, service.Discard(ctx, "unknown"), ErrSessionNotFound)
+	})
+}
+
+func TestService_Functions(t *testing.T) {
+	ts := assistant.NewTestServer(t)
+	defer ts.Close()
+
+	newService := func(taskService task.Service, clock util.Clock) Service {
+		client := assistant.NewClient(assistant.Config{BaseURL: ts.URL, Model: "tool-call"}, assistant.NewMemorySessionStore(0, util.NewClock()))
+		return NewService(taskService, client, clock)
+	}
+
+	due := time.Date(2025, 6, 13, 17, 0, 0, 0, time.UTC)
+	now := time.Date(2025, 6, 10, 9, 0, 0, 0, time.UTC)
+
+	t.Run("should create task through create_task function", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTaskService := task.NewMockService(ctrl)
+		service := newService(mockTaskService, util.NewMockClock(ctrl))
+
+		mockTaskService.EXPECT().Create(gomock.Any(), match.PtrTo(task.Task{
+			Title:    "Buy milk",
+			Priority: util.Ptr(task.PriorityHigh),
+		})).DoAndReturn(func(_ context.Context, t *task.Task) error {
+			t.ID = "TASK-000001"
+			t.Status = task.StatusNotStarted
+			return nil
+		})
+
+		reply, err := service.Chat(context.Background(), Request{SessionID: "session-1", Message: `create_task {"title":"Buy milk","priority":"HIGH"}`})
+
+		require.NoError(t, err)
+		assert.Contains(t, reply.Response, `"id":"TASK-000001"`)
+		assert.Contains(t, reply.Response, `"title":"Buy milk"`)
+		assert.Contains(t, reply.Response, `"priority":"HIGH"`)
+	})
+
+	t.Run("should create recurring task through create_task function", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTaskService := task.NewMockService(ctrl)
+		service := newService(mockTaskService, util.NewMockClock(ctrl))
+
+		mockTaskService.EXPECT().Create(gomock.Any(), match.PtrTo(task.Task{
+			Title:      "Water plants",
+			Recurrence: &task.Recurrence{Frequency: task.FrequencyDaily, Interval: 2},
+		})).DoAndReturn(func(_ context.Context, t *task.Task) error {
+			t.ID = "TASK-000001"
+			return nil
+		})
+
+		reply, err := service.Chat(context.Background(), Request{SessionID: "session-1", Message: `create_task {"title":"Water plants","recurrence":"FREQ=DAILY;INTERVAL=2"}`})
+
+		require.NoError(t, err)
+		assert.Contains(t, reply.Response, `"recurrence":"FREQ=DAILY;INTERVAL=2"`)
+	})
+
+	t.Run("should get task through get_task function", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTaskService := task.NewMockService(ctrl)
+		service := newService(mockTaskService, util.NewMockClock(ctrl))
+
+		mockTaskService.EXPECT().Get(gomock.Any(), "TASK-000002").Return(&task.Task{
+			ID:     "TASK-000002",
+			Title:  "Write report",
+			Status: task.StatusInProgress,
+		}, nil)
+
+		reply, err := service.Chat(context.Background(), Request{SessionID: "session-1", Message: `get_task {"id":"TASK-000002"}`})
+
+		require.NoError(t, err)
+		assert.Contains(t, reply.Response, `"id":"TASK-000002"`)
+		assert.Contains(t, reply.Response, `"status":"IN_PROGRESS"`)
+	})
+
+	t.Run("should list tasks through list_tasks function", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTaskService := task.NewMockService(ctrl)
+		service := newService(mockTaskService, util.NewMockClock(ctrl))
+
+		mockTaskService.EXPECT().List(gomock.Any(), task.ListOptions{}).Return(&task.Page{
+			Tasks: []*task.Task{
+				{ID: "TASK-000001", Title: "First"},
+				{ID: "TASK-000002", Title: "Second"},
+			},
+			Total: 2,
+		}, nil)
+
+		reply, err := service.Chat(context.Background(), Request{SessionID: "session-1", Message: "list_tasks"})
+
+		require.NoError(t, err)
+		assert.Contains(t, reply.Response, `"id":"TASK-000001"`)
+		assert.Contains(t, reply.Response, `"id":"TASK-000002"`)
+	})
+
+	t.Run("should filter and sort tasks through list_tasks function", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTaskService := task.NewMockService(ctrl)
+		service := newService(mockTaskService, util.NewMockClock(ctrl))
+
+		mockTaskService.EXPECT().List(gomock.Any(), task.ListOptions{
+			Filter: task.Filter{
+				Statuses:   []task.Status{task.StatusInProgress},
+				Priorities: []task.Priority{task.PriorityHigh},
+				Query:      "report",
+			},
+			Sort: []task.Sort{{Field: task.SortByDueDate, Descending: true}},
+		}).Return(&task.Page{
+			Tasks: []*task.Task{{ID: "TASK-000003", Title: "Quarterly report"}},
+			Total: 1,
+		}, nil)
+
+		reply, err := service.Chat(context.Background(), Request{SessionID: "session-1", Message: `list_tasks {"status":["IN_PROGRESS"],"priority":["HIGH"],"query":"report","sort":"-dueDate"}`})
+
+		require.NoError(t, err)
+		assert.Contains(t, reply.Response, `"id":"TASK-000003"`)
+	})
+
+	t.Run("should list tasks assigned to the user through list_tasks function", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTaskService := task.NewMockService(ctrl)
+		service := newService(mockTaskService, util.NewMockClock(ctrl))
+
+		mockTaskService.EXPECT().List(gomock.Any(), task.ListOptions{
+			Filter: task.Filter{Assignees: []string{"alice"}},
+		}).Return(&task.Page{
+			Tasks: []*task.Task{{ID: "TASK-000004", Title: "Review budget", OwnerID: "bob", Assignees: []string{"alice"}}},
+			Total: 1,
+		}, nil)
+
+		ctx := task.WithUser(context.Background(), task.User{ID: "alice", Role: task.RoleMember})
+		reply, err := service.Chat(ctx, Request{SessionID: "session-1", Message: `list_tasks {"assignee":"me"}`})
+
+		require.NoError(t, err)
+		assert.Contains(t, reply.Response, `"ownerId":"bob"`)
+		assert.Contains(t, reply.Response, `"assignees":["alice"]`)
+	})
+
+	t.Run("should find tasks through search_tasks function", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTaskService := task.NewMockService(ctrl)
+		service := newService(mockTaskService, util.NewMockClock(ctrl))
+
+		mockTaskService.EXPECT().Search(gomock.Any(), "quarterly report", task.SearchOptions{
+			Filter: task.Filter{Statuses: []task.Status{task.StatusNotStarted}},
+			Limit:  5,
+		}).Return(&task.SearchResult{
+			Hits:  []task.SearchHit{{Task: &task.Task{ID: "TASK-000003", Title: "Write quarterly report"}, Score: 1.5}},
+			Total: 1,
+		}, nil)
+
+		reply, err := service.Chat(context.Background(), Request{SessionID: "session-1", Message: `search_tasks {"query":"quarterly report","status":["NOT_STARTED"]}`})
+
+		require.NoError(t, err)
+		assert.Contains(t, reply.Response, `"id":"TASK-000003"`)
+		assert.Contains(t, reply.Response, `"score":1.5`)
+	})
+
+	t.Run("should update task through update_task function", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTaskService := task.NewMockService(ctrl)
+		service := newService(mockTaskService, util.NewMockClock(ctrl))
+
+		mockTaskService.EXPECT().Update(gomock.Any(), "TASK-000004", match.PtrTo(task.Task{
+			Priority: util.Ptr(task.PriorityHigh),
+			DueDate:  &due,
+		})).Return(&task.Task{
+			ID:       "TASK-000004",
+			Title:    "Ship release",
+			Priority: util.Ptr(task.PriorityHigh),
+			DueDate:  &due,
+		}, nil)
+
+		reply, err := service.Chat(context.Background(), Request{SessionID: "session-1", Message: `update_task {"id":"TASK-000004","priority":"HIGH","dueDate":"2025-06-13T17:00:00Z"}`})
+
+		require.NoError(t, err)
+		assert.Contains(t, reply.Response, `"priority":"HIGH"`)
+		assert.Contains(t, reply.Response, `"dueDate":"2025-06-13T17:00:00Z"`)
+	})
+
+	t.Run("should set blockers through update_task function", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTaskService := task.NewMockService(ctrl)
+		service := newService(mockTaskService, util.NewMockClock(ctrl))
+
+		mockTaskService.EXPECT().Update(gomock.Any(), "TASK-000004", match.PtrTo(task.Task{
+			BlockedBy: []string{"TASK-000002"},
+		})).Return(&task.Task{
+			ID:        "TASK-000004",
+			Title:     "Ship release",
+			Status:    task.StatusBlocked,
+			BlockedBy: []string{"TASK-000002"},
+		}, nil)
+
+		reply, err := service.Chat(context.Background(), Request{SessionID: "session-1", Message: `update_task {"id":"TASK-000004","blockedBy":["TASK-000002"]}`})
+
+		require.NoError(t, err)
+		assert.Contains(t, reply.Response, `"status":"BLOCKED"`)
+		assert.Contains(t, reply.Response, `"blockedBy":["TASK-000002"]`)
+	})
+
+	t.Run("should delete task through delete_task function", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTaskService := task.NewMockService(ctrl)
+		service := newService(mockTaskService, util.NewMockClock(ctrl))
+
+		mockTaskService.EXPECT().Delete(gomock.Any(), "TASK-000003", task.DeleteOptions{}).Return(nil)
+
+		reply, err := service.Chat(context.Background(), Request{SessionID: "session-1", Message: `delete_task {"id":"TASK-000003"}`})
+
+		require.NoError(t, err)
+		assert.Contains(t, reply.Response, `{"data":{"id":"TASK-000003","deleted":true}}`)
+	})
+
+	t.Run("should delete task with its subtasks through delete_task function", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTaskService := task.NewMockService(ctrl)
+		service := newService(mockTaskService, util.NewMockClock(ctrl))
+
+		mockTaskService.EXPECT().Delete(gomock.Any(), "TASK-000003", task.DeleteOptions{Mode: task.DeleteCascade}).Return(nil)
+
+		reply, err := service.Chat(context.Background(), Request{SessionID: "session-1", Message: `delete_task {"id":"TASK-000003","mode":"cascade"}`})
+
+		require.NoError(t, err)
+		assert.Contains(t, reply.Response, `{"data":{"id":"TASK-000003","deleted":true}}`)
+	})
+
+	t.Run("should return current time through get_current_time function", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		clock := util.NewMockClock(ctrl)
+		service := newService(task.NewMockService(ctrl), clock)
+
+		clock.EXPECT().Now().Return(now)
+
+		reply, err := service.Chat(context.Background(), Request{SessionID: "session-1", Message: "get_current_time"})
+
+		require.NoError(t, err)
+		assert.Contains(t, reply.Response, `{"data":{"now":"2025-06-10T09:00:00Z","weekday":"Tuesday"}}`)
+	})
+
+	t.Run("should apply several operations through batch_tasks function", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTaskService := task.NewMockService(ctrl)
+		service := newService(mockTaskService, util.NewMockClock(ctrl))
+
+		mockTaskService.EXPECT().Batch(gomock.Any(), []task.Operation{
+			{Type: task.ChangeCreate, Ref: "review", Task: &task.Task{Title: "Review release"}},
+			{Type: task.ChangeUpdate, TaskID: "TASK-000004", Task: &task.Task{BlockedBy: []string{"review"}}},
+			{Type: task.ChangeDelete, TaskID: "TASK-000003", DeleteOptions: task.DeleteOptions{Mode: task.DeleteCascade}},
+		}).Return([]task.Change{
+			{Type: task.ChangeCreate, TaskID: "TASK-000005", After: &task.Task{ID: "TASK-000005", Title: "Review release"}},
+			{Type: task.ChangeUpdate, TaskID: "TASK-000004", After: &task.Task{ID: "TASK-000004", Title: "Ship release", BlockedBy: []string{"TASK-000005"}}},
+			{Type: task.ChangeDelete, TaskID: "TASK-000003", Before: &task.Task{ID: "TASK-000003"}},
+		}, nil)
+
+		reply, err := service.Chat(context.Background(), Request{SessionID: "session-1", Message: `batch_tasks {"operations":[` +
+			`{"ref":"review","create":{"title":"Review release"}},` +
+			`{"update":{"id":"TASK-000004","blockedBy":["review"]}},` +
+			`{"delete":{"id":"TASK-000003","mode":"cascade"}}]}`})
+
+		require.NoError(t, err)
+		assert.Contains(t, reply.Response, `"blockedBy":["TASK-000005"]`)
+		assert.Contains(t, reply.Response, `{"op":"delete","id":"TASK-000003"}`)
+	})
+
+	t.Run("should reject operation without exactly one change through batch_tasks function", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service := newService(task.NewMockService(ctrl), util.NewMockClock(ctrl))
+
+		reply, err := service.Chat(context.Background(), Request{SessionID: "session-1", Message: `batch_tasks {"operations":[` +
+			`{"create":{"title":"Review release"}},` +
+			`{"create":{"title":"Ship release"},"delete":{"id":"TASK-000003"}}]}`})
+
+		require.NoError(t, err)
+		assert.Contains(t, reply.Response, `operation 1: invalid batch`)
+	})
+
+	t.Run("should pass task service error to the assistant", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTaskService := task.NewMockService(ctrl)
+		service := newService(mockTaskService, util.NewMockClock(ctrl))
+
+		mockTaskService.EXPECT().Get(gomock.Any(), "UNKNOWN").Return(nil, task.ErrTaskNotFound)
+
+		reply, err := service.Chat(context.Background(), Request{SessionID: "session-1", Message: `get_task {"id":"UNKNOWN"}`})
+
+		require.NoError(t, err)
+		assert.Contains(t, reply.Response, `"error":"function execution failed: task not found"`)
+	})
+}
+
+func TestIntegration_Service(t *testing.T) {
+	ts := assistant.NewTestServer(t)
+	defer ts.Close()
+
+	t.Run("should update task priority and due date from chat", func(t *testing.T) {
+		ctx := context.Background()
+		repo := task.NewMemoryRepository()
+		clock := util.NewClock()
+		taskService := task.NewService(repo, idgen.NewSequential("TASK-", 1, 6), clock)
+		client := assistant.NewClient(assistant.Config{BaseURL: ts.URL, Model: "tool-call"}, assistant.NewMemorySessionStore(0, util.NewClock()))
+		service := NewService(taskService, client, clock)
+
+		_, err := service.Chat(ctx, Request{SessionID: "session-1", Message: `create_task {"title":"Ship release"}`})
+		require.NoError(t, err)
+
+		_, err = service.Chat(ctx, Request{SessionID: "session-1", Message: `update_task {"id":"TASK-000001","priority":"HIGH","dueDate":"2025-06-13T17:00:00Z"}`})
+		require.NoError(t, err)
+
+		updated, err := taskService.Get(ctx, "TASK-000001")
+		require.NoError(t, err)
+		assert.Equal(t, "Ship release", updated.Title)
+		assert.Equal(t, util.Ptr(task.PriorityHigh), updated.Priority)
+		assert.Equal(t, time.Date(2025, 6, 13, 17, 0, 0, 0, time.UTC), *updated.DueDate)
+	})
+
+	t.Run("should apply none of the batch_tasks operations when one fails", func(t *testing.T) {
+		ctx := context.Background()
+		clock := util.NewClock()
+		taskService := task.NewService(task.NewMemoryRepository(), idgen.NewSequential("TASK-", 1, 6), clock)
+		client := assistant.NewClient(assistant.Config{BaseURL: ts.URL, Model: "tool-call"}, assistant.NewMemorySessionStore(0, util.NewClock()))
+		service := NewService(taskService, client, clock)
+
+		reply, err := service.Chat(ctx, Request{SessionID: "session-1", Message: `batch_tasks {"operations":[` +
+			`{"create":{"title":"Ship release"}},` +
+			`{"update":{"id":"TASK-000009","priority":"HIGH"}}]}`})
+		require.NoError(t, err)
+		assert.Contains(t, reply.Response, `operation 1: error finding task: task not found`)
+
+		page, err := taskService.List(ctx, task.ListOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, page.Tasks)
+	})
+
+	t.Run("should stage task operations in preview mode until committed", func(t *testing.T) {
+		ctx := context.Background()
+		clock := util.NewClock()
+		taskService := task.NewService(task.NewMemoryRepository(), idgen.NewSequential("TASK-", 1, 6), clock)
+		client := assistant.NewClient(assistant.Config{BaseURL: ts.URL, Model: "tool-call"}, assistant.NewMemorySessionStore(0, util.NewClock()))
+		service := NewService(taskService, client, clock)
+
+		reply, err := service.Chat(ctx, Request{SessionID: "session-1", Message: `create_task {"title":"Ship release"}`, Preview: true})
+		require.NoError(t, err)
+		assert.Contains(t, reply.Response, `"id":"STAGED-1"`)
+		require.Len(t, reply.Changes, 1)
+		assert.Equal(t, task.ChangeCreate, reply.Changes[0].Type)
+
+		reply, err = service.Chat(ctx, Request{SessionID: "session-1", Message: `update_task {"id":"STAGED-1","priority":"HIGH"}`, Preview: true})
+		require.NoError(t, err)
+		require.Len(t, reply.Changes, 1)
+		assert.Equal(t, util.Ptr(task.PriorityHigh), reply.Changes[0].After.Priority)
+
+		page, err := taskService.List(ctx, task.ListOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, page.Tasks)
+
+		changes, err := service.Commit(ctx, "session-1")
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		assert.Equal(t, "TASK-000001", changes[0].TaskID)
+
+		committed, err := taskService.Get(ctx, "TASK-000001")
+		require.NoError(t, err)
+		assert.Equal(t, "Ship release", committed.Title)
+		assert.Equal(t, util.Ptr(task.PriorityHigh), committed.Priority)
+
+		changes, err = service.Changes(ctx, "session-1")
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("should run task functions under the permissions of the user", func(t *testing.T) {
+		clock := util.NewClock()
+		taskService := task.NewAccessControl(task.NewService(task.NewMemoryRepository(), idgen.NewSequential("TASK-", 1, 6), clock))
+		client := assistant.NewClient(assistant.Config{BaseURL: ts.URL, Model: "tool-call"}, assistant.NewMemorySessionStore(0, util.NewClock()))
+		service := NewService(taskService, client, clock)
+		alice := task.WithUser(context.Background(), task.User{ID: "alice", Role: task.RoleMember})
+		bob := task.WithUser(context.Background(), task.User{ID: "bob", Role: task.RoleMember})
+
+		reply, err := service.Chat(alice, Request{SessionID: "session-1", Message: `create_task {"title":"Ship release"}`})
+		require.NoError(t, err)
+		assert.Contains(t, reply.Response, `"ownerId":"alice"`)
+
+		for _, preview := range []bool{false, true} {
+			reply, err = service.Chat(bob, Request{SessionID: "session-2", Message: `delete_task {"id":"TASK-000001"}`, Preview: preview})
+			require.NoError(t, err)
+			assert.Contains(t, reply.Response, "permission denied")
+			assert.Empty(t, reply.Changes)
 		}
 
-		mockTaskService.EXPECT().Create(expectedTask).Return(errors.New("task creation failed"))
+		_, err = taskService.Get(alice, "TASK-000001")
+		assert.NoError(t, err)
+	})
 
-		result, err := service.Chat(ctx, message)
-		assert.Error(t, err)
-		assert.Empty(t, result)
-		assert.ErrorContains(t, err, "task creation failed")
+	t.Run("should keep the sessions and staged task operations of a user from the others", func(t *testing.T) {
+		clock := util.NewClock()
+		taskService := task.NewAccessControl(task.NewService(task.NewMemoryRepository(), idgen.NewSequential("TASK-", 1, 6), clock))
+		client := assistant.NewClient(assistant.Config{BaseURL: ts.URL, Model: "tool-call"}, assistant.NewMemorySessionStore(0, util.NewClock()))
+		service := NewService(taskService, client, clock)
+		alice := task.WithUser(context.Background(), task.User{ID: "alice", Role: task.RoleMember})
+		bob := task.WithUser(context.Background(), task.User{ID: "bob", Role: task.RoleMember})
+
+		_, err := service.Chat(alice, Request{SessionID: "session-1", Message: `create_task {"title":"Ship release"}`, Preview: true})
+		require.NoError(t, err)
+
+		_, err = service.Chat(bob, Request{SessionID: "session-1", Message: `create_task {"title":"Write notes"}`, Preview: true})
+		assert.ErrorIs(t, err, ErrSessionNotFound)
+		_, err = service.GetSession(bob, "session-1")
+		assert.ErrorIs(t, err, ErrSessionNotFound)
+		_, err = service.Changes(bob, "session-1")
+		assert.ErrorIs(t, err, ErrSessionNotFound)
+		_, err = service.Commit(bob, "session-1")
+		assert.ErrorIs(t, err, ErrSessionNotFound)
+		assert.ErrorIs(t, service.Discard(bob, "session-1"), ErrSessionNotFound)
+		assert.ErrorIs(t, service.DeleteSession(bob, "session-1"), ErrSessionNotFound)
+
+		changes, err := service.Changes(alice, "session-1")
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		assert.Equal(t, "Ship release", changes[0].After.Title)
+
+		changes, err = service.Commit(alice, "session-1")
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+	})
+
+	t.Run("should drop staged task operations when the session expires", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		now := time.Date(2025, 6, 13, 9, 0, 0, 0, time.UTC)
+		sessionClock := util.NewMockClock(ctrl)
+		sessionClock.EXPECT().Now().DoAndReturn(func() time.Time { return now }).AnyTimes()
+
+		clock := util.NewClock()
+		taskService := task.NewService(task.NewMemoryRepository(), idgen.NewSequential("TASK-", 1, 6), clock)
+		client := assistant.NewClient(assistant.Config{BaseURL: ts.URL, Model: "tool-call"}, assistant.NewMemorySessionStore(time.Hour, sessionClock))
+		svc := NewService(taskService, client, clock)
+
+		_, err := svc.Chat(ctx, Request{SessionID: "session-1", Message: `create_task {"title":"Ship release"}`, Preview: true})
+		require.NoError(t, err)
+
+		now = now.Add(2 * time.Hour)
+
+		reply, err := svc.Chat(ctx, Request{SessionID: "session-1", Message: `create_task {"title":"Write notes"}`, Preview: true})
+		require.NoError(t, err)
+		require.Len(t, reply.Changes, 1)
+		assert.Equal(t, "Write notes", reply.Changes[0].After.Title)
+
+		now = now.Add(2 * time.Hour)
+
+		_, err = svc.Chat(ctx, Request{SessionID: "session-2", Message: `create_task {"title":"Plan sprint"}`, Preview: true})
+		require.NoError(t, err)
+
+		_, err = svc.Changes(ctx, "session-1")
+		assert.ErrorIs(t, err, ErrSessionNotFound)
+		assert.NotContains(t, svc.(*service).stages, "session-1")
+	})
+
+	t.Run("should drop staged task operations when discarded", func(t *testing.T) {
+		ctx := context.Background()
+		clock := util.NewClock()
+		taskService := task.NewService(task.NewMemoryRepository(), idgen.NewSequential("TASK-", 1, 6), clock)
+		client := assistant.NewClient(assistant.Config{BaseURL: ts.URL, Model: "tool-call"}, assistant.NewMemorySessionStore(0, util.NewClock()))
+		service := NewService(taskService, client, clock)
+
+		_, err := service.Chat(ctx, Request{SessionID: "session-1", Message: `create_task {"title":"Ship release"}`, Preview: true})
+		require.NoError(t, err)
+
+		require.NoError(t, service.Discard(ctx, "session-1"))
+
+		changes, err := service.Changes(ctx, "session-1")
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+
+		changes, err = service.Commit(ctx, "session-1")
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+
+		page, err := taskService.List(ctx, task.ListOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, page.Tasks)
 	})
 }
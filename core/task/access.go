@@ -0,0 +1,474 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrForbidden is returned when the user of the context is not allowed to perform an operation
+var ErrForbidden = errors.New("permission denied")
+
+// Role is the set of permissions of a user on the tasks, every role has the permissions of the roles before it
+type Role string
+
+const (
+	// RoleViewer reads every task
+	RoleViewer Role = "viewer"
+	// RoleMember also creates tasks, changes the tasks they own or are assigned to and deletes or moves the tasks they own.
+	// Only the owner of a task changes its assignees
+	RoleMember Role = "member"
+	// RoleAdmin changes, deletes and moves every task, renames and merges the tags and manages the projects
+	RoleAdmin Role = "admin"
+)
+
+var roles = []Role{RoleViewer, RoleMember, RoleAdmin}
+
+// Roles returns every role from the least to the most privileged
+func Roles() []Role {
+	return slices.Clone(roles)
+}
+
+// Valid reports whether the role is a known role
+func (r Role) Valid() bool {
+	return slices.Contains(roles, r)
+}
+
+// includes reports whether the role has every permission of the other role
+func (r Role) includes(other Role) bool {
+	return slices.Index(roles, r) >= slices.Index(roles, other)
+}
+
+// User is the user on whose behalf the tasks are accessed
+type User struct {
+	// ID identifies the user as the owner or an assignee of the tasks
+	ID string
+	// Role defines what the user is allowed to do
+	Role Role
+}
+
+type userKey struct{}
+
+// WithUser returns a context whose task operations are performed on behalf of the user
+func WithUser(ctx context.Context, user User) context.Context {
+	return context.WithValue(ctx, userKey{}, user)
+}
+
+// UserFromContext returns the user set by WithUser, false if there is none
+func UserFromContext(ctx context.Context) (User, bool) {
+	user, ok := ctx.Value(userKey{}).(User)
+	return user, ok
+}
+
+// normalizeAssignees trims the user IDs and returns them sorted without duplicates nor empty IDs,
+// keeping an empty non-nil slice so that a patch clearing the assignees is told apart from one leaving them unchanged
+func normalizeAssignees(assignees []string) []string {
+	if assignees == nil {
+		return nil
+	}
+
+	normalized := make([]string, 0, len(assignees))
+	for _, id := range assignees {
+		if id = strings.TrimSpace(id); id != "" {
+			normalized = append(normalized, id)
+		}
+	}
+
+	slices.Sort(normalized)
+	return slices.Compact(normalized)
+}
+
+// AccessControl is a Service which checks the permissions of the user of the context before every operation
+// of the underlying service. Operations without a user in their context are rejected with ErrForbidden.
+// The tasks created by a member are owned by them, an admin may create tasks owned by another user
+type AccessControl struct {
+	base Service
+}
+
+// NewAccessControl creates a new access control on top of the given service
+func NewAccessControl(base Service) *AccessControl {
+	return &AccessControl{base: base}
+}
+
+func (a *AccessControl) Create(ctx context.Context, task *Task) error {
+	user, err := authorize(ctx, RoleMember)
+	if err != nil {
+		return fmt.Errorf("error creating task: %w", err)
+	}
+
+	if task.OwnerID == "" || user.Role != RoleAdmin {
+		task.OwnerID = user.ID
+	}
+
+	return a.base.Create(ctx, task)
+}
+
+func (a *AccessControl) Get(ctx context.Context, id string) (*Task, error) {
+	if _, err := authorize(ctx, RoleViewer); err != nil {
+		return nil, fmt.Errorf("error finding task: %w", err)
+	}
+
+	return a.base.Get(ctx, id)
+}
+
+func (a *AccessControl) List(ctx context.Context, opts ListOptions) (*Page, error) {
+	if _, err := authorize(ctx, RoleViewer); err != nil {
+		return nil, fmt.Errorf("error listing tasks: %w", err)
+	}
+
+	return a.base.List(ctx, opts)
+}
+
+func (a *AccessControl) Update(ctx context.Context, id string, patch *Task) (*Task, error) {
+	user, err := authorize(ctx, RoleMember)
+	if err != nil {
+		return nil, fmt.Errorf("error updating task: %w", err)
+	}
+
+	task, err := a.base.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error updating task: %w", err)
+	}
+
+	if !canChange(user, task) {
+		return nil, fmt.Errorf("error updating task: %w", fmt.Errorf("%w: %s is neither owned by nor assigned to %s", ErrForbidden, id, user.ID))
+	}
+
+	if patch.Assignees != nil && !owns(user, task) {
+		return nil, fmt.Errorf("error updating task: %w", fmt.Errorf("%w: only the owner of %s changes its assignees", ErrForbidden, id))
+	}
+
+	return a.base.Update(ctx, id, patch)
+}
+
+func (a *AccessControl) Delete(ctx context.Context, id string, opts DeleteOptions) error {
+	user, err := authorize(ctx, RoleMember)
+	if err != nil {
+		return fmt.Errorf("error deleting task: %w", err)
+	}
+
+	task, err := a.base.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("error deleting task: %w", err)
+	}
+
+	if !owns(user, task) {
+		return fmt.Errorf("error deleting task: %w", fmt.Errorf("%w: %s is not owned by %s", ErrForbidden, id, user.ID))
+	}
+
+	if err := a.authorizeSubtasks(ctx, user, id, opts.Mode); err != nil {
+		return fmt.Errorf("error deleting task: %w", err)
+	}
+
+	return a.base.Delete(ctx, id, opts)
+}
+
+// authorizeSubtasks checks that the user may apply the delete mode to the subtasks of a task:
+// every descendant deleted by a cascade must be owned by them, every child orphaned must be changeable by them
+func (a *AccessControl) authorizeSubtasks(ctx context.Context, user User, id string, mode DeleteMode) error {
+	if mode != DeleteCascade && mode != DeleteOrphan {
+		return nil
+	}
+
+	children, err := subtasks(ctx, a.base, id)
+	if err != nil {
+		return err
+	}
+
+	for _, child := range children {
+		if mode == DeleteOrphan {
+			if !canChange(user, child) {
+				return fmt.Errorf("%w: subtask %s is neither owned by nor assigned to %s", ErrForbidden, child.ID, user.ID)
+			}
+			continue
+		}
+
+		if !owns(user, child) {
+			return fmt.Errorf("%w: subtask %s is not owned by %s", ErrForbidden, child.ID, user.ID)
+		}
+		if err := a.authorizeSubtasks(ctx, user, child.ID, mode); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Batch checks every operation as if it was applied on its own before applying the batch,
+// the tasks created by an operation are owned by the user for the later operations
+func (a *AccessControl) Batch(ctx context.Context, ops []Operation) ([]Change, error) {
+	user, err := authorize(ctx, RoleMember)
+	if err != nil {
+		return nil, fmt.Errorf("error applying batch: %w", err)
+	}
+
+	ops = slices.Clone(ops)
+	created := make(map[string]bool)
+	for i := range ops {
+		if err := a.authorizeOperation(ctx, user, &ops[i], created); err != nil {
+			return nil, fmt.Errorf("error applying batch: %w", &BatchError{Index: i, Err: err})
+		}
+	}
+
+	return a.base.Batch(ctx, ops)
+}
+
+func (a *AccessControl) authorizeOperation(ctx context.Context, user User, op *Operation, created map[string]bool) error {
+	switch {
+	case op.Type == ChangeCreate:
+		if op.Task != nil {
+			op.Task = op.Task.clone()
+			if op.Task.OwnerID == "" || user.Role != RoleAdmin {
+				op.Task.OwnerID = user.ID
+			}
+		}
+		if op.Ref != "" {
+			created[op.Ref] = true
+		}
+		return nil
+	case created[op.TaskID]:
+		return nil
+	}
+
+	task, err := a.base.Get(ctx, op.TaskID)
+	if err != nil {
+		return err
+	}
+
+	if op.Type == ChangeDelete {
+		if !owns(user, task) {
+			return fmt.Errorf("error deleting task: %w", fmt.Errorf("%w: %s is not owned by %s", ErrForbidden, op.TaskID, user.ID))
+		}
+		if err := a.authorizeSubtasks(ctx, user, op.TaskID, op.DeleteOptions.Mode); err != nil {
+			return fmt.Errorf("error deleting task: %w", err)
+		}
+		return nil
+	}
+
+	if !canChange(user, task) {
+		return fmt.Errorf("error updating task: %w", fmt.Errorf("%w: %s is neither owned by nor assigned to %s", ErrForbidden, op.TaskID, user.ID))
+	}
+
+	if op.Task != nil && op.Task.Assignees != nil && !owns(user, task) {
+		return fmt.Errorf("error updating task: %w", fmt.Errorf("%w: only the owner of %s changes its assignees", ErrForbidden, op.TaskID))
+	}
+
+	return nil
+}
+
+func (a *AccessControl) Move(ctx context.Context, id, project string) (*Task, error) {
+	user, err := authorize(ctx, RoleMember)
+	if err != nil {
+		return nil, fmt.Errorf("error moving task: %w", err)
+	}
+
+	task, err := a.base.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error moving task: %w", err)
+	}
+
+	if !owns(user, task) {
+		return nil, fmt.Errorf("error moving task: %w", fmt.Errorf("%w: %s is not owned by %s", ErrForbidden, id, user.ID))
+	}
+
+	return a.base.Move(ctx, id, project)
+}
+
+func (a *AccessControl) Plan(ctx context.Context) (*Plan, error) {
+	if _, err := authorize(ctx, RoleViewer); err != nil {
+		return nil, fmt.Errorf("error planning tasks: %w", err)
+	}
+
+	return a.base.Plan(ctx)
+}
+
+func (a *AccessControl) Progress(ctx context.Context, ids []string) (map[string]Progress, error) {
+	if _, err := authorize(ctx, RoleViewer); err != nil {
+		return nil, fmt.Errorf("error computing progress: %w", err)
+	}
+
+	return a.base.Progress(ctx, ids)
+}
+
+func (a *AccessControl) Tags(ctx context.Context) ([]TagCount, error) {
+	if _, err := authorize(ctx, RoleViewer); err != nil {
+		return nil, fmt.Errorf("error listing tags: %w", err)
+	}
+
+	return a.base.Tags(ctx)
+}
+
+func (a *AccessControl) RenameTag(ctx context.Context, from, to string) (TagCount, error) {
+	if _, err := authorize(ctx, RoleAdmin); err != nil {
+		return TagCount{}, fmt.Errorf("error renaming tag: %w", err)
+	}
+
+	return a.base.RenameTag(ctx, from, to)
+}
+
+func (a *AccessControl) MergeTags(ctx context.Context, sources []string, target string) (TagCount, error) {
+	if _, err := authorize(ctx, RoleAdmin); err != nil {
+		return TagCount{}, fmt.Errorf("error merging tags: %w", err)
+	}
+
+	return a.base.MergeTags(ctx, sources, target)
+}
+
+func (a *AccessControl) History(ctx context.Context, id string) ([]Event, error) {
+	if _, err := authorize(ctx, RoleViewer); err != nil {
+		return nil, fmt.Errorf("error getting task history: %w", err)
+	}
+
+	return a.base.History(ctx, id)
+}
+
+func (a *AccessControl) Search(ctx context.Context, query string, opts SearchOptions) (*SearchResult, error) {
+	if _, err := authorize(ctx, RoleViewer); err != nil {
+		return nil, fmt.Errorf("error searching tasks: %w", err)
+	}
+
+	return a.base.Search(ctx, query, opts)
+}
+
+func (a *AccessControl) Subscribe(ctx context.Context, filter NotificationFilter, lastID int64) (*Subscription, error) {
+	if _, err := authorize(ctx, RoleViewer); err != nil {
+		return nil, fmt.Errorf("error subscribing to task changes: %w", err)
+	}
+
+	return a.base.Subscribe(ctx, filter, lastID)
+}
+
+// ProjectAccessControl is a ProjectService which lets every user read the projects and only admins change them
+type ProjectAccessControl struct {
+	base ProjectService
+}
+
+// NewProjectAccessControl creates a new access control on top of the given project service
+func NewProjectAccessControl(base ProjectService) *ProjectAccessControl {
+	return &ProjectAccessControl{base: base}
+}
+
+func (a *ProjectAccessControl) Create(ctx context.Context, project *Project) error {
+	if _, err := authorize(ctx, RoleAdmin); err != nil {
+		return fmt.Errorf("error creating project: %w", err)
+	}
+
+	return a.base.Create(ctx, project)
+}
+
+func (a *ProjectAccessControl) Get(ctx context.Context, key string) (*Project, error) {
+	if _, err := authorize(ctx, RoleViewer); err != nil {
+		return nil, fmt.Errorf("error finding project: %w", err)
+	}
+
+	return a.base.Get(ctx, key)
+}
+
+func (a *ProjectAccessControl) List(ctx context.Context) ([]*Project, error) {
+	if _, err := authorize(ctx, RoleViewer); err != nil {
+		return nil, fmt.Errorf("error listing projects: %w", err)
+	}
+
+	return a.base.List(ctx)
+}
+
+func (a *ProjectAccessControl) Update(ctx context.Context, key string, patch *Project) (*Project, error) {
+	if _, err := authorize(ctx, RoleAdmin); err != nil {
+		return nil, fmt.Errorf("error updating project: %w", err)
+	}
+
+	return a.base.Update(ctx, key, patch)
+}
+
+func (a *ProjectAccessControl) Delete(ctx context.Context, key string) error {
+	if _, err := authorize(ctx, RoleAdmin); err != nil {
+		return fmt.Errorf("error deleting project: %w", err)
+	}
+
+	return a.base.Delete(ctx, key)
+}
+
+// authorize returns the user of the context if their role includes the required role
+func authorize(ctx context.Context, required Role) (User, error) {
+	user, ok := UserFromContext(ctx)
+	if !ok {
+		return User{}, fmt.Errorf("%w: no user", ErrForbidden)
+	}
+
+	if !user.Role.Valid() || !user.Role.includes(required) {
+		return User{}, fmt.Errorf("%w: %s role required", ErrForbidden, required)
+	}
+
+	return user, nil
+}
+
+func owns(user User, task *Task) bool {
+	return user.Role == RoleAdmin || task.OwnerID == user.ID
+}
+
+func canChange(user User, task *Task) bool {
+	return owns(user, task) || slices.Contains(task.Assignees, user.ID)
+}
+
+// ViewAccessControl is a ViewService which lets every user read the views and list their tasks,
+// members save views and only their owner or an admin delete them
+type ViewAccessControl struct {
+	base ViewService
+}
+
+// NewViewAccessControl creates a new access control on top of the given view service
+func NewViewAccessControl(base ViewService) *ViewAccessControl {
+	return &ViewAccessControl{base: base}
+}
+
+func (a *ViewAccessControl) Create(ctx context.Context, view *View) error {
+	if _, err := authorize(ctx, RoleMember); err != nil {
+		return fmt.Errorf("error creating view: %w", err)
+	}
+
+	return a.base.Create(ctx, view)
+}
+
+func (a *ViewAccessControl) Get(ctx context.Context, name string) (*View, error) {
+	if _, err := authorize(ctx, RoleViewer); err != nil {
+		return nil, fmt.Errorf("error finding view: %w", err)
+	}
+
+	return a.base.Get(ctx, name)
+}
+
+func (a *ViewAccessControl) List(ctx context.Context) ([]*View, error) {
+	if _, err := authorize(ctx, RoleViewer); err != nil {
+		return nil, fmt.Errorf("error listing views: %w", err)
+	}
+
+	return a.base.List(ctx)
+}
+
+func (a *ViewAccessControl) Delete(ctx context.Context, name string) error {
+	user, err := authorize(ctx, RoleMember)
+	if err != nil {
+		return fmt.Errorf("error deleting view: %w", err)
+	}
+
+	view, err := a.base.Get(ctx, name)
+	if err != nil {
+		return fmt.Errorf("error deleting view: %w", err)
+	}
+
+	if user.Role != RoleAdmin && view.OwnerID != user.ID {
+		return fmt.Errorf("error deleting view: %w", fmt.Errorf("%w: %s is not owned by %s", ErrForbidden, view.Name, user.ID))
+	}
+
+	return a.base.Delete(ctx, name)
+}
+
+func (a *ViewAccessControl) ListTasks(ctx context.Context, name string, opts ListOptions) (*Page, error) {
+	if _, err := authorize(ctx, RoleViewer); err != nil {
+		return nil, fmt.Errorf("error listing view tasks: %w", err)
+	}
+
+	return a.base.ListTasks(ctx, name, opts)
+}
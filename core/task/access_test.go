@@ -0,0 +1,297 @@
+package task
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/pkg/idgen"
+	"github.com/utsabbera/task-master/pkg/util"
+	"go.uber.org/mock/gomock"
+)
+
+func asUser(id string, role Role) context.Context {
+	return WithUser(context.Background(), User{ID: id, Role: role})
+}
+
+func TestAccessControl_Create(t *testing.T) {
+	t.Run("should create task owned by member", func(t *testing.T) {
+		access := NewAccessControl(newFixture(t).service)
+
+		task := &Task{Title: "Write report", OwnerID: "bob", Assignees: []string{"carol", " bob", "carol"}}
+		require.NoError(t, access.Create(asUser("alice", RoleMember), task))
+
+		assert.Equal(t, "alice", task.OwnerID)
+		assert.Equal(t, []string{"bob", "carol"}, task.Assignees)
+	})
+
+	t.Run("should create task owned by another user for admin", func(t *testing.T) {
+		access := NewAccessControl(newFixture(t).service)
+
+		task := &Task{Title: "Write report", OwnerID: "bob"}
+		require.NoError(t, access.Create(asUser("alice", RoleAdmin), task))
+
+		assert.Equal(t, "bob", task.OwnerID)
+	})
+
+	t.Run("should return forbidden for viewer or missing user", func(t *testing.T) {
+		access := NewAccessControl(newFixture(t).service)
+
+		err := access.Create(asUser("alice", RoleViewer), &Task{Title: "Write report"})
+		assert.ErrorIs(t, err, ErrForbidden)
+
+		err = access.Create(context.Background(), &Task{Title: "Write report"})
+		assert.ErrorIs(t, err, ErrForbidden)
+	})
+}
+
+func TestAccessControl_Update(t *testing.T) {
+	setup := func(t *testing.T) *AccessControl {
+		access := NewAccessControl(newFixture(t).service)
+		require.NoError(t, access.Create(asUser("alice", RoleMember), &Task{Title: "Write report", Assignees: []string{"bob"}}))
+		return access
+	}
+
+	t.Run("should update task for owner and assignee", func(t *testing.T) {
+		access := setup(t)
+
+		_, err := access.Update(asUser("alice", RoleMember), "TASK-000001", &Task{Title: "Write summary"})
+		require.NoError(t, err)
+
+		updated, err := access.Update(asUser("bob", RoleMember), "TASK-000001", &Task{Status: StatusInProgress})
+		require.NoError(t, err)
+		assert.Equal(t, StatusInProgress, updated.Status)
+	})
+
+	t.Run("should return forbidden for other member", func(t *testing.T) {
+		access := setup(t)
+
+		_, err := access.Update(asUser("carol", RoleMember), "TASK-000001", &Task{Title: "Write summary"})
+
+		assert.ErrorIs(t, err, ErrForbidden)
+	})
+
+	t.Run("should only let owner change assignees", func(t *testing.T) {
+		access := setup(t)
+
+		_, err := access.Update(asUser("bob", RoleMember), "TASK-000001", &Task{Assignees: []string{"bob", "carol"}})
+		assert.ErrorIs(t, err, ErrForbidden)
+
+		updated, err := access.Update(asUser("alice", RoleMember), "TASK-000001", &Task{Assignees: []string{}})
+		require.NoError(t, err)
+		assert.Empty(t, updated.Assignees)
+	})
+
+	t.Run("should update any task for admin", func(t *testing.T) {
+		access := setup(t)
+
+		updated, err := access.Update(asUser("dave", RoleAdmin), "TASK-000001", &Task{Assignees: []string{"carol"}})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"carol"}, updated.Assignees)
+	})
+
+	t.Run("should return not found before checking ownership", func(t *testing.T) {
+		access := setup(t)
+
+		_, err := access.Update(asUser("carol", RoleMember), "TASK-000009", &Task{Title: "Write summary"})
+
+		assert.ErrorIs(t, err, ErrTaskNotFound)
+	})
+}
+
+func TestAccessControl_Delete(t *testing.T) {
+	t.Run("should only let owner or admin delete task", func(t *testing.T) {
+		base := newFixture(t).service
+		access := NewAccessControl(base)
+		require.NoError(t, access.Create(asUser("alice", RoleMember), &Task{Title: "Write report", Assignees: []string{"bob"}}))
+		require.NoError(t, access.Create(asUser("alice", RoleMember), &Task{Title: "Review report"}))
+
+		err := access.Delete(asUser("bob", RoleMember), "TASK-000001", DeleteOptions{})
+		assert.ErrorIs(t, err, ErrForbidden)
+
+		require.NoError(t, access.Delete(asUser("alice", RoleMember), "TASK-000001", DeleteOptions{}))
+		require.NoError(t, access.Delete(asUser("dave", RoleAdmin), "TASK-000002", DeleteOptions{}))
+
+		page, err := base.List(context.Background(), ListOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, page.Tasks)
+	})
+
+	t.Run("should reject cascade over subtask of another member but orphan subtask assigned to member", func(t *testing.T) {
+		base := newFixture(t).service
+		access := NewAccessControl(base)
+		require.NoError(t, access.Create(asUser("alice", RoleMember), &Task{Title: "Write report"}))
+		require.NoError(t, access.Create(asUser("alice", RoleMember), &Task{Title: "Draft outline", ParentID: util.Ptr("TASK-000001")}))
+		require.NoError(t, access.Create(asUser("bob", RoleMember), &Task{Title: "Collect figures", ParentID: util.Ptr("TASK-000002"), Assignees: []string{"alice"}}))
+
+		err := access.Delete(asUser("alice", RoleMember), "TASK-000001", DeleteOptions{Mode: DeleteCascade})
+		assert.ErrorIs(t, err, ErrForbidden)
+
+		_, err = access.Batch(asUser("alice", RoleMember), []Operation{{Type: ChangeDelete, TaskID: "TASK-000002", DeleteOptions: DeleteOptions{Mode: DeleteCascade}}})
+		assert.ErrorIs(t, err, ErrForbidden)
+
+		page, err := base.List(context.Background(), ListOptions{})
+		require.NoError(t, err)
+		assert.Len(t, page.Tasks, 3)
+
+		require.NoError(t, access.Delete(asUser("alice", RoleMember), "TASK-000002", DeleteOptions{Mode: DeleteOrphan}))
+		orphan, err := base.Get(context.Background(), "TASK-000003")
+		require.NoError(t, err)
+		assert.Nil(t, orphan.ParentID)
+	})
+
+	t.Run("should reject orphaning subtask neither owned by nor assigned to member", func(t *testing.T) {
+		base := newFixture(t).service
+		access := NewAccessControl(base)
+		require.NoError(t, access.Create(asUser("alice", RoleMember), &Task{Title: "Write report"}))
+		require.NoError(t, access.Create(asUser("bob", RoleMember), &Task{Title: "Collect figures", ParentID: util.Ptr("TASK-000001")}))
+
+		err := access.Delete(asUser("alice", RoleMember), "TASK-000001", DeleteOptions{Mode: DeleteOrphan})
+		assert.ErrorIs(t, err, ErrForbidden)
+
+		require.NoError(t, access.Delete(asUser("dave", RoleAdmin), "TASK-000001", DeleteOptions{Mode: DeleteCascade}))
+		page, err := base.List(context.Background(), ListOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, page.Tasks)
+	})
+}
+
+func TestAccessControl_Batch(t *testing.T) {
+	t.Run("should apply batch of member owning created tasks", func(t *testing.T) {
+		base := newFixture(t).service
+		access := NewAccessControl(base)
+		ctx := asUser("alice", RoleMember)
+
+		changes, err := access.Batch(ctx, []Operation{
+			{Type: ChangeCreate, Ref: "report", Task: &Task{Title: "Write report", OwnerID: "bob"}},
+			{Type: ChangeUpdate, TaskID: "report", Task: &Task{Assignees: []string{"carol"}}},
+			{Type: ChangeDelete, TaskID: "report"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "alice", changes[0].After.OwnerID)
+		_, err = base.Get(ctx, "TASK-000001")
+		assert.ErrorIs(t, err, ErrTaskNotFound)
+	})
+
+	t.Run("should return forbidden with index of operation on task of another member", func(t *testing.T) {
+		base := newFixture(t).service
+		access := NewAccessControl(base)
+		require.NoError(t, access.Create(asUser("alice", RoleMember), &Task{Title: "Write report"}))
+
+		_, err := access.Batch(asUser("bob", RoleMember), []Operation{
+			{Type: ChangeCreate, Task: &Task{Title: "Review report"}},
+			{Type: ChangeDelete, TaskID: "TASK-000001"},
+		})
+
+		var batchErr *BatchError
+		require.ErrorAs(t, err, &batchErr)
+		assert.Equal(t, 1, batchErr.Index)
+		assert.ErrorIs(t, err, ErrForbidden)
+
+		page, err := base.List(context.Background(), ListOptions{})
+		require.NoError(t, err)
+		assert.Len(t, page.Tasks, 1)
+	})
+
+	t.Run("should return forbidden for viewer", func(t *testing.T) {
+		access := NewAccessControl(newFixture(t).service)
+
+		_, err := access.Batch(asUser("alice", RoleViewer), []Operation{{Type: ChangeCreate, Task: &Task{Title: "Write report"}}})
+
+		assert.ErrorIs(t, err, ErrForbidden)
+	})
+}
+
+func TestAccessControl_Read(t *testing.T) {
+	t.Run("should let viewer read every task", func(t *testing.T) {
+		access := NewAccessControl(newFixture(t).service)
+		require.NoError(t, access.Create(asUser("alice", RoleMember), &Task{Title: "Write report", Tags: []string{"docs"}}))
+		ctx := asUser("victor", RoleViewer)
+
+		_, err := access.Get(ctx, "TASK-000001")
+		require.NoError(t, err)
+
+		page, err := access.List(ctx, ListOptions{Filter: Filter{Owners: []string{"alice"}}})
+		require.NoError(t, err)
+		assert.Len(t, page.Tasks, 1)
+
+		_, err = access.Tags(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("should return forbidden without user", func(t *testing.T) {
+		access := NewAccessControl(newFixture(t).service)
+
+		_, err := access.List(context.Background(), ListOptions{})
+
+		assert.ErrorIs(t, err, ErrForbidden)
+	})
+}
+
+func TestAccessControl_Tags(t *testing.T) {
+	t.Run("should only let admin rename and merge tags", func(t *testing.T) {
+		access := NewAccessControl(newFixture(t).service)
+		require.NoError(t, access.Create(asUser("alice", RoleMember), &Task{Title: "Write report", Tags: []string{"docs", "writing"}}))
+
+		_, err := access.RenameTag(asUser("alice", RoleMember), "docs", "documentation")
+		assert.ErrorIs(t, err, ErrForbidden)
+
+		_, err = access.MergeTags(asUser("alice", RoleMember), []string{"writing"}, "docs")
+		assert.ErrorIs(t, err, ErrForbidden)
+
+		renamed, err := access.RenameTag(asUser("dave", RoleAdmin), "docs", "documentation")
+		require.NoError(t, err)
+		assert.Equal(t, "documentation", renamed.Tag)
+	})
+}
+
+func TestAccessControl_Move(t *testing.T) {
+	t.Run("should only let owner or admin move task", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		clock := newTickingClock(ctrl)
+		tasks, projectRepo := NewMemoryRepository(), NewMemoryProjectRepository()
+		require.NoError(t, projectRepo.Create(context.Background(), &Project{Key: "WEB", Name: "Website"}))
+		access := NewAccessControl(NewServiceWithProjects(tasks, idgen.NewSequential("TASK-", 1, 6), NewProjects(projectRepo), clock))
+		require.NoError(t, access.Create(asUser("alice", RoleMember), &Task{Title: "Write report", Assignees: []string{"bob"}}))
+		require.NoError(t, access.Create(asUser("alice", RoleMember), &Task{Title: "Review report"}))
+
+		_, err := access.Move(asUser("bob", RoleMember), "TASK-000001", "WEB")
+		assert.ErrorIs(t, err, ErrForbidden)
+
+		moved, err := access.Move(asUser("alice", RoleMember), "TASK-000001", "WEB")
+		require.NoError(t, err)
+		assert.Equal(t, "WEB-000001", moved.ID)
+
+		moved, err = access.Move(asUser("dave", RoleAdmin), "TASK-000002", "WEB")
+		require.NoError(t, err)
+		assert.Equal(t, "WEB-000002", moved.ID)
+	})
+}
+
+func TestProjectAccessControl(t *testing.T) {
+	t.Run("should only let admin manage projects", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		access := NewProjectAccessControl(NewProjectService(NewMemoryProjectRepository(), NewMemoryRepository(), newTickingClock(ctrl)))
+
+		err := access.Create(asUser("alice", RoleMember), &Project{Key: "WEB", Name: "Website"})
+		assert.ErrorIs(t, err, ErrForbidden)
+
+		require.NoError(t, access.Create(asUser("dave", RoleAdmin), &Project{Key: "WEB", Name: "Website"}))
+
+		_, err = access.Update(asUser("alice", RoleMember), "WEB", &Project{Name: "Web app"})
+		assert.ErrorIs(t, err, ErrForbidden)
+		assert.ErrorIs(t, access.Delete(asUser("alice", RoleMember), "WEB"), ErrForbidden)
+
+		projects, err := access.List(asUser("victor", RoleViewer))
+		require.NoError(t, err)
+		assert.Len(t, projects, 1)
+
+		_, err = access.Get(asUser("victor", RoleViewer), "WEB")
+		require.NoError(t, err)
+
+		require.NoError(t, access.Delete(asUser("dave", RoleAdmin), "WEB"))
+	})
+}
@@ -0,0 +1,122 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrInvalidBatch is returned when a batch is empty or has an operation missing its task or ID
+var ErrInvalidBatch = errors.New("invalid batch")
+
+// Operation is a create, update or delete operation of a batch.
+// The changes of a batch are published once all its operations are applied, the IDs given to the tasks of a failed batch are not reused
+type Operation struct {
+	// Type is the kind of the operation
+	Type ChangeType
+	// Ref is a temporary ID of the task created by the operation, which the later operations of the batch
+	// use in place of its ID as their task ID, parent or blocker
+	Ref string
+	// TaskID is the ID of the updated or deleted task
+	TaskID string
+	// Task is the task to create, or the patch of the task to update along with its expected version
+	Task *Task
+	// DeleteOptions controls the deletion of the task
+	DeleteOptions DeleteOptions
+}
+
+// BatchError is returned when an operation of a batch fails, none of the operations of the batch being applied
+type BatchError struct {
+	// Index is the position of the failed operation in the batch, starting at zero
+	Index int
+	// Err is the error of the operation
+	Err error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("operation %d: %v", e.Index, e.Err)
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+// checkBatch returns ErrInvalidBatch if the batch is empty or has an operation which cannot be applied
+func checkBatch(ops []Operation) error {
+	if len(ops) == 0 {
+		return fmt.Errorf("%w: no operation", ErrInvalidBatch)
+	}
+
+	for i, op := range ops {
+		var err error
+		switch {
+		case !slices.Contains(changeTypes, op.Type):
+			err = fmt.Errorf("%w: unknown operation %q", ErrInvalidBatch, op.Type)
+		case op.Type != ChangeDelete && op.Task == nil:
+			err = fmt.Errorf("%w: %s without task", ErrInvalidBatch, op.Type)
+		case op.Type != ChangeCreate && op.TaskID == "":
+			err = fmt.Errorf("%w: %s without task ID", ErrInvalidBatch, op.Type)
+		}
+		if err != nil {
+			return &BatchError{Index: i, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// applyOperations applies the operations in order to the service and returns the change made by each of them,
+// replacing the references to the tasks created by earlier operations by their IDs
+func applyOperations(ctx context.Context, s Service, ops []Operation) ([]Change, error) {
+	refs := make(map[string]string)
+	changes := make([]Change, 0, len(ops))
+	for i, op := range ops {
+		change, err := applyOperation(ctx, s, op, refs)
+		if err != nil {
+			return nil, &BatchError{Index: i, Err: err}
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+func applyOperation(ctx context.Context, s Service, op Operation, refs map[string]string) (Change, error) {
+	if op.Type == ChangeCreate {
+		task := op.Task.clone()
+		task.ParentID = resolveID(refs, task.ParentID)
+		task.BlockedBy = resolveIDs(refs, task.BlockedBy)
+		if err := s.Create(ctx, task); err != nil {
+			return Change{}, err
+		}
+
+		if op.Ref != "" {
+			refs[op.Ref] = task.ID
+		}
+		return Change{Type: ChangeCreate, TaskID: task.ID, After: task}, nil
+	}
+
+	id := *resolveID(refs, &op.TaskID)
+	before, err := s.Get(ctx, id)
+	if err != nil {
+		return Change{}, err
+	}
+
+	if op.Type == ChangeDelete {
+		if err := s.Delete(ctx, id, op.DeleteOptions); err != nil {
+			return Change{}, err
+		}
+		return Change{Type: ChangeDelete, TaskID: id, Before: before}, nil
+	}
+
+	patch := op.Task.clone()
+	patch.ParentID = resolveID(refs, patch.ParentID)
+	patch.BlockedBy = resolveIDs(refs, patch.BlockedBy)
+	after, err := s.Update(ctx, id, patch)
+	if err != nil {
+		return Change{}, err
+	}
+
+	return Change{Type: ChangeUpdate, TaskID: id, Before: before, After: after}, nil
+}
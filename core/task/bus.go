@@ -0,0 +1,194 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DefaultReplayBufferSize is the number of notifications a bus keeps by default to resume subscriptions
+const DefaultReplayBufferSize = 1000
+
+const subscriptionBufferSize = 64
+
+var (
+	// ErrNotificationsExpired is returned when a subscription resumes after a notification which is no longer buffered
+	ErrNotificationsExpired = errors.New("notifications to resume from are no longer available")
+	// ErrSubscriptionLagged is the error of a subscription closed because it didn't receive its notifications fast enough
+	ErrSubscriptionLagged = errors.New("subscription closed for falling behind")
+)
+
+// Notification is a change of a task published on a Bus
+type Notification struct {
+	// ID is the position of the notification on the bus, set when it is published, starting from 1
+	ID int64
+	// Type is the kind of change
+	Type EventType
+	// TaskID is the ID of the changed task
+	TaskID string
+	// Task is the task after the change, or before it for deletions. It is shared by the subscribers and must not be modified
+	Task *Task
+	// Changes are the user editable fields changed by the change
+	Changes []FieldChange
+	// Actor is who made the change, empty when unknown
+	Actor string
+	// OccurredAt stores when the change was made
+	OccurredAt time.Time
+}
+
+// NotificationFilter selects the notifications a subscription receives, empty fields select every notification
+type NotificationFilter struct {
+	// TaskIDs selects the notifications of any of these tasks
+	TaskIDs []string
+	// Types selects the notifications of any of these types
+	Types []EventType
+	// Tags selects the notifications of tasks having any of these tags
+	Tags []string
+}
+
+// Match reports whether the notification is selected by the filter
+func (f NotificationFilter) Match(n Notification) bool {
+	if len(f.TaskIDs) > 0 && !slices.Contains(f.TaskIDs, n.TaskID) {
+		return false
+	}
+
+	if len(f.Types) > 0 && !slices.Contains(f.Types, n.Type) {
+		return false
+	}
+
+	if len(f.Tags) > 0 && (n.Task == nil || !slices.ContainsFunc(f.Tags, n.Task.HasTag)) {
+		return false
+	}
+
+	return true
+}
+
+// Bus is an in-memory publish/subscribe bus of the changes of the tasks.
+// The latest notifications are kept in a bounded buffer so that subscriptions can resume after the last one they received
+type Bus struct {
+	capacity    int
+	buffer      []Notification
+	lastID      int64
+	subscribers map[*Subscription]struct{}
+	mu          sync.Mutex
+}
+
+// NewBus creates a new bus keeping the given number of notifications to resume subscriptions,
+// a capacity of zero or less keeps DefaultReplayBufferSize notifications
+func NewBus(capacity int) *Bus {
+	if capacity <= 0 {
+		capacity = DefaultReplayBufferSize
+	}
+
+	return &Bus{
+		capacity:    capacity,
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// Publish sets the ID of the notification and delivers it to the matching subscriptions.
+// A subscription whose buffer is full is closed with ErrSubscriptionLagged instead of blocking the publisher
+func (b *Bus) Publish(n Notification) Notification {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	n.ID = b.lastID
+
+	b.buffer = append(b.buffer, n)
+	if len(b.buffer) > b.capacity {
+		b.buffer = slices.Delete(b.buffer, 0, len(b.buffer)-b.capacity)
+	}
+
+	for sub := range b.subscribers {
+		if !sub.filter.Match(n) {
+			continue
+		}
+
+		select {
+		case sub.ch <- n:
+		default:
+			b.unsubscribe(sub, ErrSubscriptionLagged)
+		}
+	}
+
+	return n
+}
+
+// Subscribe returns a subscription to the notifications matching the filter, closed when the context is done.
+// A positive lastID resumes after the notification with this ID, replaying the buffered notifications published since
+// Returns ErrNotificationsExpired if notifications published after lastID are no longer buffered or lastID is unknown
+func (b *Bus) Subscribe(ctx context.Context, filter NotificationFilter, lastID int64) (*Subscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var replay []Notification
+	if lastID > 0 {
+		if lastID > b.lastID || lastID < b.lastID-int64(len(b.buffer)) {
+			return nil, ErrNotificationsExpired
+		}
+
+		for _, n := range b.buffer[len(b.buffer)-int(b.lastID-lastID):] {
+			if filter.Match(n) {
+				replay = append(replay, n)
+			}
+		}
+	}
+
+	sub := &Subscription{
+		bus:    b,
+		filter: filter,
+		ch:     make(chan Notification, subscriptionBufferSize+len(replay)),
+	}
+	for _, n := range replay {
+		sub.ch <- n
+	}
+
+	b.subscribers[sub] = struct{}{}
+	sub.stop = context.AfterFunc(ctx, sub.Close)
+
+	return sub, nil
+}
+
+func (b *Bus) unsubscribe(sub *Subscription, err error) {
+	if _, ok := b.subscribers[sub]; !ok {
+		return
+	}
+
+	delete(b.subscribers, sub)
+	sub.stop()
+	sub.err = err
+	close(sub.ch)
+}
+
+// Subscription receives the notifications published on a bus until it is closed
+type Subscription struct {
+	bus    *Bus
+	filter NotificationFilter
+	ch     chan Notification
+	err    error
+	stop   func() bool
+}
+
+// Notifications returns the channel of the notifications, closed when the subscription is closed
+func (s *Subscription) Notifications() <-chan Notification {
+	return s.ch
+}
+
+// Err returns ErrSubscriptionLagged once the subscription is closed for falling behind, nil otherwise
+func (s *Subscription) Err() error {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+
+	return s.err
+}
+
+// Close stops the delivery of the notifications and closes the channel
+func (s *Subscription) Close() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+
+	s.bus.unsubscribe(s, nil)
+}
@@ -0,0 +1,197 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/pkg/idgen"
+	"github.com/utsabbera/task-master/pkg/util"
+	"go.uber.org/mock/gomock"
+)
+
+func receive(t *testing.T, sub *Subscription, count int) []Notification {
+	t.Helper()
+
+	notifications := make([]Notification, 0, count)
+	for range count {
+		select {
+		case n, ok := <-sub.Notifications():
+			require.True(t, ok, "subscription closed")
+			notifications = append(notifications, n)
+		case <-time.After(time.Second):
+			require.FailNow(t, "notification not received")
+		}
+	}
+
+	return notifications
+}
+
+func notificationIDs(notifications []Notification) []int64 {
+	ids := make([]int64, 0, len(notifications))
+	for _, n := range notifications {
+		ids = append(ids, n.ID)
+	}
+	return ids
+}
+
+func TestNotificationFilter_Match(t *testing.T) {
+	n := Notification{Type: EventUpdated, TaskID: "TASK-1", Task: &Task{ID: "TASK-1", Tags: []string{"work"}}}
+
+	tests := []struct {
+		name     string
+		filter   NotificationFilter
+		expected bool
+	}{
+		{"empty filter", NotificationFilter{}, true},
+		{"matching task", NotificationFilter{TaskIDs: []string{"TASK-2", "TASK-1"}}, true},
+		{"other task", NotificationFilter{TaskIDs: []string{"TASK-2"}}, false},
+		{"matching type", NotificationFilter{Types: []EventType{EventCreated, EventUpdated}}, true},
+		{"other type", NotificationFilter{Types: []EventType{EventDeleted}}, false},
+		{"matching tag", NotificationFilter{Tags: []string{"home", "work"}}, true},
+		{"other tag", NotificationFilter{Tags: []string{"home"}}, false},
+		{"matching task and other type", NotificationFilter{TaskIDs: []string{"TASK-1"}, Types: []EventType{EventDeleted}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run("should match notification with "+tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.filter.Match(n))
+		})
+	}
+}
+
+func TestBus(t *testing.T) {
+	t.Run("should deliver matching notifications to subscribers", func(t *testing.T) {
+		bus := NewBus(10)
+		all, err := bus.Subscribe(context.Background(), NotificationFilter{}, 0)
+		require.NoError(t, err)
+		deletions, err := bus.Subscribe(context.Background(), NotificationFilter{Types: []EventType{EventDeleted}}, 0)
+		require.NoError(t, err)
+
+		bus.Publish(Notification{Type: EventCreated, TaskID: "TASK-1"})
+		bus.Publish(Notification{Type: EventDeleted, TaskID: "TASK-1"})
+
+		assert.Equal(t, []int64{1, 2}, notificationIDs(receive(t, all, 2)))
+		assert.Equal(t, []int64{2}, notificationIDs(receive(t, deletions, 1)))
+	})
+
+	t.Run("should replay buffered notifications after last id", func(t *testing.T) {
+		bus := NewBus(10)
+		for i := range 5 {
+			bus.Publish(Notification{Type: EventCreated, TaskID: fmt.Sprintf("TASK-%d", i%2)})
+		}
+
+		sub, err := bus.Subscribe(context.Background(), NotificationFilter{TaskIDs: []string{"TASK-0"}}, 2)
+		require.NoError(t, err)
+		bus.Publish(Notification{Type: EventUpdated, TaskID: "TASK-0"})
+
+		assert.Equal(t, []int64{3, 5, 6}, notificationIDs(receive(t, sub, 3)))
+	})
+
+	t.Run("should return error when notifications after last id are no longer buffered", func(t *testing.T) {
+		bus := NewBus(3)
+		for range 5 {
+			bus.Publish(Notification{Type: EventCreated})
+		}
+
+		_, err := bus.Subscribe(context.Background(), NotificationFilter{}, 1)
+		assert.ErrorIs(t, err, ErrNotificationsExpired)
+
+		_, err = bus.Subscribe(context.Background(), NotificationFilter{}, 6)
+		assert.ErrorIs(t, err, ErrNotificationsExpired)
+
+		sub, err := bus.Subscribe(context.Background(), NotificationFilter{}, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{3, 4, 5}, notificationIDs(receive(t, sub, 3)))
+	})
+
+	t.Run("should close subscription falling behind", func(t *testing.T) {
+		bus := NewBus(0)
+		sub, err := bus.Subscribe(context.Background(), NotificationFilter{}, 0)
+		require.NoError(t, err)
+
+		for range subscriptionBufferSize + 1 {
+			bus.Publish(Notification{Type: EventCreated})
+		}
+
+		receive(t, sub, subscriptionBufferSize)
+		_, ok := <-sub.Notifications()
+		assert.False(t, ok)
+		assert.ErrorIs(t, sub.Err(), ErrSubscriptionLagged)
+	})
+
+	t.Run("should close subscription when context is done", func(t *testing.T) {
+		bus := NewBus(0)
+		ctx, cancel := context.WithCancel(context.Background())
+		sub, err := bus.Subscribe(ctx, NotificationFilter{}, 0)
+		require.NoError(t, err)
+
+		cancel()
+
+		select {
+		case _, ok := <-sub.Notifications():
+			assert.False(t, ok)
+		case <-time.After(time.Second):
+			require.FailNow(t, "subscription not closed")
+		}
+		assert.NoError(t, sub.Err())
+	})
+
+	t.Run("should allow closing subscription twice", func(t *testing.T) {
+		bus := NewBus(0)
+		sub, err := bus.Subscribe(context.Background(), NotificationFilter{}, 0)
+		require.NoError(t, err)
+
+		sub.Close()
+		sub.Close()
+
+		bus.Publish(Notification{Type: EventCreated})
+		_, ok := <-sub.Notifications()
+		assert.False(t, ok)
+	})
+}
+
+func TestService_Subscribe(t *testing.T) {
+	t.Run("should publish created, updated and deleted tasks", func(t *testing.T) {
+		ctx := context.Background()
+		service := NewService(NewMemoryRepository(), idgen.NewSequential("TASK-", 1, 6), newTickingClock(gomock.NewController(t)))
+		sub, err := service.Subscribe(ctx, NotificationFilter{}, 0)
+		require.NoError(t, err)
+
+		require.NoError(t, service.Create(WithActor(ctx, "alice"), &Task{Title: "Write report"}))
+		_, err = service.Update(ctx, "TASK-000001", &Task{Status: StatusInProgress})
+		require.NoError(t, err)
+		_, err = service.Update(ctx, "TASK-000001", &Task{Title: "Write summary"})
+		require.NoError(t, err)
+		require.NoError(t, service.Delete(ctx, "TASK-000001", DeleteOptions{}))
+
+		notifications := receive(t, sub, 4)
+		assert.Equal(t, []EventType{EventCreated, EventStatusChanged, EventUpdated, EventDeleted},
+			util.Map(notifications, func(n Notification) EventType { return n.Type }))
+		assert.Equal(t, "alice", notifications[0].Actor)
+		assert.Equal(t, "Write report", notifications[0].Task.Title)
+		assert.Equal(t, []FieldChange{{Field: "title", Before: "Write report", After: "Write summary"}}, notifications[2].Changes)
+		assert.Equal(t, "Write summary", notifications[3].Task.Title)
+		assert.True(t, notifications[0].OccurredAt.Before(notifications[3].OccurredAt))
+	})
+
+	t.Run("should not publish staged changes until they are committed", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		base := NewService(NewMemoryRepository(), idgen.NewSequential("TASK-", 1, 6), newTickingClock(ctrl))
+		stage := NewStage(base, newTickingClock(ctrl))
+		sub, err := stage.Subscribe(ctx, NotificationFilter{}, 0)
+		require.NoError(t, err)
+
+		require.NoError(t, stage.Create(ctx, &Task{Title: "Write report"}))
+		assert.Empty(t, sub.Notifications())
+
+		_, err = stage.Commit(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, EventCreated, receive(t, sub, 1)[0].Type)
+	})
+}
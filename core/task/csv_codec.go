@@ -0,0 +1,193 @@
+package task
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// fieldAliases are the usual header names of the imported fields besides their own names
+var fieldAliases = map[string]string{
+	"name":         FieldTitle,
+	"summary":      FieldTitle,
+	"task":         FieldTitle,
+	"details":      FieldDescription,
+	"notes":        FieldDescription,
+	"due":          FieldDueDate,
+	"deadline":     FieldDueDate,
+	"labels":       FieldTags,
+	"parent":       FieldParentID,
+	"dependencies": FieldBlockedBy,
+	"assignee":     FieldAssignees,
+}
+
+// csvCodec encodes a task per row of a CSV file, the header naming the field of each column
+type csvCodec struct {
+	columns map[string]string
+}
+
+// newCSVCodec creates a CSV codec mapping the given header names to the fields they hold
+// Returns ErrInvalidImport if a header name is mapped to an unknown field
+func newCSVCodec(columns map[string]string) (codec, error) {
+	mapped := make(map[string]string, len(columns))
+	for header, field := range columns {
+		target, ok := lookupField(field)
+		if !ok || !slices.Contains(importFields, target) {
+			return nil, fmt.Errorf("%w: column %q mapped to unknown field %q, expected one of %v",
+				ErrInvalidImport, header, field, importFields)
+		}
+		mapped[normalizeHeader(header)] = target
+	}
+
+	return csvCodec{columns: mapped}, nil
+}
+
+func (c csvCodec) encode(w io.Writer, tasks []*Task) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(exportFields); err != nil {
+		return fmt.Errorf("error writing header: %w", err)
+	}
+
+	for _, task := range tasks {
+		r := newRecord(task)
+		values := make([]string, 0, len(exportFields))
+		for _, field := range exportFields {
+			values = append(values, escapeCell(r.value(field)))
+		}
+		if err := writer.Write(values); err != nil {
+			return fmt.Errorf("error writing task %s: %w", task.ID, err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("error writing tasks: %w", err)
+	}
+
+	return nil
+}
+
+func (c csvCodec) decode(r io.Reader) ([]ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: error reading header: %w", ErrInvalidImport, err)
+	}
+
+	fields, err := c.fields(header)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []ImportRow
+	for {
+		values, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidImport, err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		if isBlank(values) {
+			continue
+		}
+
+		var rec record
+		for i, value := range values {
+			if i < len(fields) && fields[i] != "" {
+				rec.set(fields[i], unescapeCell(value))
+			}
+		}
+		rows = append(rows, rec.row(line))
+	}
+}
+
+// fields returns the field of each column of the header, empty for the ignored columns
+// Returns ErrInvalidImport if no column holds the title or two columns hold the same field
+func (c csvCodec) fields(header []string) ([]string, error) {
+	fields := make([]string, len(header))
+	columns := make(map[string]string)
+	for i, name := range header {
+		field, ok := c.columns[normalizeHeader(name)]
+		if !ok {
+			field, ok = lookupField(name)
+		}
+		if !ok || !slices.Contains(importFields, field) {
+			continue
+		}
+
+		if first, ok := columns[field]; ok {
+			return nil, fmt.Errorf("%w: columns %q and %q both hold %s", ErrInvalidImport, first, name, field)
+		}
+		columns[field] = name
+		fields[i] = field
+	}
+
+	if _, ok := columns[FieldTitle]; !ok {
+		return nil, fmt.Errorf("%w: no column holds the title, expected a column named %s or mapped to it",
+			ErrInvalidImport, FieldTitle)
+	}
+
+	return fields, nil
+}
+
+// lookupField returns the field named by a header name or one of its aliases,
+// regardless of case, spaces, dashes and underscores
+func lookupField(name string) (string, bool) {
+	normalized := normalizeHeader(name)
+	for _, field := range exportFields {
+		if normalizeHeader(field) == normalized {
+			return field, true
+		}
+	}
+
+	field, ok := fieldAliases[normalized]
+	return field, ok
+}
+
+// formulaPrefixes are the first characters of a cell which spreadsheets evaluate as a formula
+const formulaPrefixes = "=+-@\t\r"
+
+// escapeCell prefixes a cell which would be evaluated as a formula by a spreadsheet with a quote
+// so that it is shown as text
+func escapeCell(value string) string {
+	if value != "" && strings.ContainsRune(formulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+
+	return value
+}
+
+// unescapeCell removes the quote escapeCell prefixes a cell with
+func unescapeCell(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.ContainsRune(formulaPrefixes, rune(value[1])) {
+		return value[1:]
+	}
+
+	return value
+}
+
+func normalizeHeader(name string) string {
+	return strings.NewReplacer(" ", "", "_", "", "-", "", "\ufeff", "").Replace(strings.ToLower(strings.TrimSpace(name)))
+}
+
+func isBlank(values []string) bool {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+
+	return true
+}
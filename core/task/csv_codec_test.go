@@ -0,0 +1,120 @@
+package task
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/pkg/util"
+)
+
+func TestCSVCodec(t *testing.T) {
+	created := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+	due := time.Date(2025, 5, 2, 17, 0, 0, 0, time.UTC)
+	tasks := []*Task{
+		{
+			ID: "TASK-000001", Title: "Write report", Description: "Quarterly, with charts", Status: StatusInProgress,
+			Priority: util.Ptr(PriorityHigh), DueDate: &due, Tags: []string{"docs", "work"}, Assignees: []string{"alice"},
+			Recurrence: &Recurrence{Frequency: FrequencyWeekly}, OwnerID: "alice",
+			CreatedAt: created, UpdatedAt: created, StartedAt: &created,
+		},
+		{
+			ID: "TASK-000002", Title: "Review report", Status: StatusBlocked, ParentID: util.Ptr("TASK-000001"),
+			BlockedBy: []string{"TASK-000001"}, CreatedAt: created, UpdatedAt: created,
+		},
+	}
+
+	t.Run("should encode a header and a row per task", func(t *testing.T) {
+		c, err := newCSVCodec(nil)
+		require.NoError(t, err)
+		var buf bytes.Buffer
+
+		require.NoError(t, c.encode(&buf, tasks))
+
+		assert.Equal(t, strings.Join([]string{
+			"id,project,title,description,status,priority,dueDate,tags,parentId,blockedBy,recurrence,assignees,ownerId,createdAt,updatedAt,startedAt,completedAt",
+			`TASK-000001,,Write report,"Quarterly, with charts",IN_PROGRESS,HIGH,2025-05-02T17:00:00Z,"docs,work",,,FREQ=WEEKLY,alice,alice,2025-05-01T09:00:00Z,2025-05-01T09:00:00Z,2025-05-01T09:00:00Z,`,
+			"TASK-000002,,Review report,,BLOCKED,,,,TASK-000001,TASK-000001,,,,2025-05-01T09:00:00Z,2025-05-01T09:00:00Z,,",
+			"",
+		}, "\n"), buf.String())
+	})
+
+	t.Run("should decode the encoded tasks", func(t *testing.T) {
+		c, err := newCSVCodec(nil)
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		require.NoError(t, c.encode(&buf, tasks))
+
+		rows, err := c.decode(&buf)
+
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		assert.Equal(t, ImportRow{Line: 2, Ref: "TASK-000001", Task: &Task{
+			Title: "Write report", Description: "Quarterly, with charts", Status: StatusInProgress,
+			Priority: util.Ptr(PriorityHigh), DueDate: &due, Tags: []string{"docs", "work"}, Assignees: []string{"alice"},
+			Recurrence: &Recurrence{Frequency: FrequencyWeekly},
+		}}, rows[0])
+		assert.Equal(t, ImportRow{Line: 3, Ref: "TASK-000002", Task: &Task{
+			Title: "Review report", Status: StatusBlocked, ParentID: util.Ptr("TASK-000001"), BlockedBy: []string{"TASK-000001"},
+		}}, rows[1])
+	})
+
+	t.Run("should escape cells evaluated as formulas", func(t *testing.T) {
+		c, err := newCSVCodec(nil)
+		require.NoError(t, err)
+		formulas := []*Task{{ID: "TASK-000001", Title: "=HYPERLINK(\"https://example.com\")", Description: "@SUM(A1)", Tags: []string{"-work"}, CreatedAt: created, UpdatedAt: created}}
+		var buf bytes.Buffer
+
+		require.NoError(t, c.encode(&buf, formulas))
+
+		assert.Contains(t, buf.String(), `TASK-000001,,"'=HYPERLINK(""https://example.com"")",'@SUM(A1),,,,'-work,`)
+
+		rows, err := c.decode(&buf)
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Equal(t, "=HYPERLINK(\"https://example.com\")", rows[0].Task.Title)
+		assert.Equal(t, "@SUM(A1)", rows[0].Task.Description)
+	})
+
+	t.Run("should map headers by alias and by the given columns", func(t *testing.T) {
+		c, err := newCSVCodec(map[string]string{"Effort": "priority"})
+		require.NoError(t, err)
+		input := "\ufeffName,Due Date,Labels,Effort,Notes,Comments\n" +
+			"Write report,2025-05-02,work urgent,low,Quarterly,ignored\n" +
+			",,,,,\n" +
+			",2025-05-03,,,,\n"
+
+		rows, err := c.decode(strings.NewReader(input))
+
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		assert.Equal(t, ImportRow{Line: 2, Task: &Task{
+			Title: "Write report", Description: "Quarterly", Priority: util.Ptr(PriorityLow),
+			DueDate: util.Ptr(time.Date(2025, 5, 2, 0, 0, 0, 0, time.UTC)), Tags: []string{"urgent", "work"},
+		}}, rows[0])
+		assert.Equal(t, 4, rows[1].Line)
+		assert.EqualError(t, rows[1].Err, "title is required")
+	})
+
+	t.Run("should return error when the header cannot be mapped", func(t *testing.T) {
+		c, err := newCSVCodec(nil)
+		require.NoError(t, err)
+
+		_, err = c.decode(strings.NewReader("description,status\nQuarterly,done\n"))
+		assert.ErrorIs(t, err, ErrInvalidImport)
+
+		_, err = c.decode(strings.NewReader("title,name\nWrite report,Write summary\n"))
+		assert.ErrorIs(t, err, ErrInvalidImport)
+	})
+
+	t.Run("should return error when a column is mapped to an unknown field", func(t *testing.T) {
+		_, err := newCSVCodec(map[string]string{"Effort": "size"})
+		assert.ErrorIs(t, err, ErrInvalidImport)
+
+		_, err = newCSVCodec(map[string]string{"Created": "createdAt"})
+		assert.ErrorIs(t, err, ErrInvalidImport)
+	})
+}
@@ -0,0 +1,201 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrBlockerNotFound is returned when a task is blocked by a task which doesn't exist
+	ErrBlockerNotFound = errors.New("blocking task not found")
+	// ErrCyclicDependency is returned when a task is blocked by itself or by a task it blocks, directly or not
+	ErrCyclicDependency = errors.New("cyclic task dependency")
+	// ErrBlocked is returned when completing a task which has open blockers
+	ErrBlocked = errors.New("task is blocked")
+)
+
+func (s Status) finished() bool {
+	return s == StatusCompleted || s == StatusCancelled
+}
+
+// normalizeBlockers sorts the blocker IDs and removes duplicates, a nil slice stays nil
+func normalizeBlockers(ids []string) []string {
+	if ids == nil {
+		return nil
+	}
+
+	normalized := slices.DeleteFunc(slices.Clone(ids), func(id string) bool { return id == "" })
+	slices.Sort(normalized)
+	return slices.Compact(normalized)
+}
+
+// checkBlockers verifies that the blockers exist and that none of them is the task or depends on it,
+// an empty id checks a task which is not created yet
+func checkBlockers(ctx context.Context, get getter, id string, blockers []string) error {
+	visited := make(map[string]bool)
+	pending := slices.Clone(blockers)
+
+	for len(pending) > 0 {
+		current := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		if current == id {
+			return fmt.Errorf("%w: %s depends on itself", ErrCyclicDependency, id)
+		}
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+
+		t, err := get(ctx, current)
+		if errors.Is(err, ErrTaskNotFound) && slices.Contains(blockers, current) {
+			return fmt.Errorf("%w: %s", ErrBlockerNotFound, current)
+		}
+		if err != nil {
+			return err
+		}
+
+		pending = append(pending, t.BlockedBy...)
+	}
+
+	return nil
+}
+
+// openBlockers returns the blockers which are neither completed nor cancelled,
+// blockers which no longer exist are not open
+func openBlockers(ctx context.Context, get getter, blockers []string) ([]string, error) {
+	var open []string
+	for _, id := range blockers {
+		blocker, err := get(ctx, id)
+		if errors.Is(err, ErrTaskNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !blocker.Status.finished() {
+			open = append(open, id)
+		}
+	}
+
+	return open, nil
+}
+
+// checkCompletion returns the open blockers of a task, refusing the requested status when it completes the task
+// while some of them are open
+func checkCompletion(ctx context.Context, get getter, blockers []string, requested Status) ([]string, error) {
+	open, err := openBlockers(ctx, get, blockers)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(open) > 0 && requested == StatusCompleted {
+		return nil, fmt.Errorf("%w by %s", ErrBlocked, strings.Join(open, ", "))
+	}
+
+	return open, nil
+}
+
+// derivedStatus returns BLOCKED for a pending task with open blockers, and the status a blocked task
+// resumes to when its blockers are finished: IN_PROGRESS if it was started, NOT_STARTED otherwise
+func derivedStatus(t *Task, blocked bool) Status {
+	switch {
+	case blocked && (t.Status == StatusNotStarted || t.Status == StatusInProgress):
+		return StatusBlocked
+	case !blocked && t.Status == StatusBlocked && t.StartedAt != nil:
+		return StatusInProgress
+	case !blocked && t.Status == StatusBlocked:
+		return StatusNotStarted
+	default:
+		return t.Status
+	}
+}
+
+// resolveBlocked derives the status of a created or updated task from its open blockers.
+// A blocked task is only resumed when no status is requested and it had blockers, so that a task blocked
+// manually stays blocked
+func resolveBlocked(t *Task, open []string, requested Status, hadBlockers bool, now time.Time) error {
+	if len(open) > 0 || (requested == "" && hadBlockers) {
+		return t.transition(derivedStatus(t, len(open) > 0), now)
+	}
+
+	return nil
+}
+
+// applyBlockedPatch applies the patch to the task and derives its status from its blockers,
+// completing a task with open blockers is refused before the status changes
+func applyBlockedPatch(ctx context.Context, get getter, task *Task, patch *Task, now time.Time) error {
+	blockers := task.BlockedBy
+	if patch.BlockedBy != nil {
+		blockers = normalizeBlockers(patch.BlockedBy)
+	}
+
+	open, err := checkCompletion(ctx, get, blockers, patch.Status)
+	if err != nil {
+		return err
+	}
+
+	hadBlockers := len(task.BlockedBy) > 0
+	if err := applyPatch(task, patch, now); err != nil {
+		return err
+	}
+
+	return resolveBlocked(task, open, patch.Status, hadBlockers, now)
+}
+
+func dependents(ctx context.Context, s Service, id string) ([]*Task, error) {
+	page, err := s.List(ctx, ListOptions{Filter: Filter{Blockers: []string{id}}})
+	if err != nil {
+		return nil, err
+	}
+
+	return page.Tasks, nil
+}
+
+// refreshBlocked updates the status of the given tasks after one of their blockers was finished,
+// reopened or deleted, blocking or resuming them through updates of the service
+func refreshBlocked(ctx context.Context, s Service, tasks []*Task) error {
+	for _, t := range tasks {
+		current, err := s.Get(ctx, t.ID)
+		if errors.Is(err, ErrTaskNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		open, err := openBlockers(ctx, s.Get, current.BlockedBy)
+		if err != nil {
+			return err
+		}
+
+		status := derivedStatus(current, len(open) > 0)
+		if status == current.Status {
+			continue
+		}
+
+		if _, err := s.Update(ctx, current.ID, &Task{Status: status, Version: current.Version}); err != nil {
+			return fmt.Errorf("error refreshing status of task %s: %w", current.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// refreshDependents refreshes the status of the tasks blocked by the updated task when it was finished or reopened
+func refreshDependents(ctx context.Context, s Service, before, after *Task) error {
+	if before.Status.finished() == after.Status.finished() {
+		return nil
+	}
+
+	tasks, err := dependents(ctx, s, after.ID)
+	if err != nil {
+		return err
+	}
+
+	return refreshBlocked(ctx, s, tasks)
+}
@@ -0,0 +1,158 @@
+package task
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dependencyTasks returns a chain of tasks, each blocked by the previous one
+func dependencyTasks() []*Task {
+	return []*Task{
+		{Title: "Design schema", Status: StatusInProgress},
+		{Title: "Write migration", BlockedBy: []string{"TASK-000001"}},
+		{Title: "Deploy", BlockedBy: []string{"TASK-000002"}},
+	}
+}
+
+func TestService_Create_Blockers(t *testing.T) {
+	t.Run("should block task with open blocker", func(t *testing.T) {
+		service := newFixture(t, dependencyTasks()...).service
+
+		task, err := service.Get(context.Background(), "TASK-000002")
+
+		require.NoError(t, err)
+		assert.Equal(t, StatusBlocked, task.Status)
+		assert.Equal(t, []string{"TASK-000001"}, task.BlockedBy)
+	})
+
+	t.Run("should not block task with finished blocker", func(t *testing.T) {
+		ctx := context.Background()
+		service := newFixture(t, dependencyTasks()...).service
+		require.NoError(t, service.Create(ctx, &Task{Title: "Write docs", Status: StatusCompleted}))
+
+		task := &Task{Title: "Publish docs", BlockedBy: []string{"TASK-000004"}}
+		require.NoError(t, service.Create(ctx, task))
+
+		assert.Equal(t, StatusNotStarted, task.Status)
+	})
+
+	t.Run("should remove duplicate blockers", func(t *testing.T) {
+		task := &Task{Title: "Announce", BlockedBy: []string{"TASK-000003", "TASK-000001", "TASK-000003"}}
+
+		require.NoError(t, newFixture(t, dependencyTasks()...).service.Create(context.Background(), task))
+
+		assert.Equal(t, []string{"TASK-000001", "TASK-000003"}, task.BlockedBy)
+	})
+
+	t.Run("should return error when blocker doesn't exist", func(t *testing.T) {
+		err := newFixture(t, dependencyTasks()...).service.Create(context.Background(), &Task{Title: "Announce", BlockedBy: []string{"TASK-999999"}})
+
+		assert.ErrorIs(t, err, ErrBlockerNotFound)
+	})
+
+	t.Run("should refuse to create completed task with open blocker", func(t *testing.T) {
+		err := newFixture(t, dependencyTasks()...).service.Create(context.Background(), &Task{Title: "Announce", Status: StatusCompleted, BlockedBy: []string{"TASK-000001"}})
+
+		assert.ErrorIs(t, err, ErrBlocked)
+	})
+}
+
+func TestService_Update_Blockers(t *testing.T) {
+	t.Run("should return error when task blocks itself", func(t *testing.T) {
+		_, err := newFixture(t, dependencyTasks()...).service.Update(context.Background(), "TASK-000001", &Task{BlockedBy: []string{"TASK-000001"}})
+
+		assert.ErrorIs(t, err, ErrCyclicDependency)
+	})
+
+	t.Run("should return error when task is blocked by a task it blocks", func(t *testing.T) {
+		_, err := newFixture(t, dependencyTasks()...).service.Update(context.Background(), "TASK-000001", &Task{BlockedBy: []string{"TASK-000003"}})
+
+		assert.ErrorIs(t, err, ErrCyclicDependency)
+	})
+
+	t.Run("should refuse to complete task with open blockers", func(t *testing.T) {
+		_, err := newFixture(t, dependencyTasks()...).service.Update(context.Background(), "TASK-000002", &Task{Status: StatusCompleted})
+
+		assert.ErrorIs(t, err, ErrBlocked)
+		assert.ErrorContains(t, err, "TASK-000001")
+	})
+
+	t.Run("should block task when blocker is added", func(t *testing.T) {
+		ctx := context.Background()
+		service := newFixture(t, dependencyTasks()...).service
+		require.NoError(t, service.Create(ctx, &Task{Title: "Write docs"}))
+
+		task, err := service.Update(ctx, "TASK-000004", &Task{BlockedBy: []string{"TASK-000003"}})
+
+		require.NoError(t, err)
+		assert.Equal(t, StatusBlocked, task.Status)
+	})
+
+	t.Run("should resume task when blockers are cleared", func(t *testing.T) {
+		task, err := newFixture(t, dependencyTasks()...).service.Update(context.Background(), "TASK-000002", &Task{BlockedBy: []string{}})
+
+		require.NoError(t, err)
+		assert.Equal(t, StatusNotStarted, task.Status)
+		assert.Empty(t, task.BlockedBy)
+	})
+
+	t.Run("should resume dependents when blocker is completed", func(t *testing.T) {
+		ctx := context.Background()
+		service := newFixture(t, dependencyTasks()...).service
+
+		_, err := service.Update(ctx, "TASK-000001", &Task{Status: StatusCompleted})
+		require.NoError(t, err)
+
+		task, err := service.Get(ctx, "TASK-000002")
+		require.NoError(t, err)
+		assert.Equal(t, StatusNotStarted, task.Status)
+
+		task, err = service.Get(ctx, "TASK-000003")
+		require.NoError(t, err)
+		assert.Equal(t, StatusBlocked, task.Status)
+	})
+
+	t.Run("should block dependents again when blocker is reopened", func(t *testing.T) {
+		ctx := context.Background()
+		service := newFixture(t, dependencyTasks()...).service
+		_, err := service.Update(ctx, "TASK-000001", &Task{Status: StatusCompleted})
+		require.NoError(t, err)
+
+		_, err = service.Update(ctx, "TASK-000001", &Task{Status: StatusInProgress})
+		require.NoError(t, err)
+
+		task, err := service.Get(ctx, "TASK-000002")
+		require.NoError(t, err)
+		assert.Equal(t, StatusBlocked, task.Status)
+	})
+
+	t.Run("should keep task blocked manually", func(t *testing.T) {
+		ctx := context.Background()
+		service := newFixture(t, dependencyTasks()...).service
+		require.NoError(t, service.Create(ctx, &Task{Title: "Write docs"}))
+
+		_, err := service.Update(ctx, "TASK-000004", &Task{Status: StatusBlocked})
+		require.NoError(t, err)
+
+		task, err := service.Update(ctx, "TASK-000004", &Task{Title: "Write user docs"})
+		require.NoError(t, err)
+		assert.Equal(t, StatusBlocked, task.Status)
+	})
+}
+
+func TestService_Delete_Blocker(t *testing.T) {
+	t.Run("should resume dependents when blocker is deleted", func(t *testing.T) {
+		ctx := context.Background()
+		service := newFixture(t, dependencyTasks()...).service
+
+		require.NoError(t, service.Delete(ctx, "TASK-000001", DeleteOptions{}))
+
+		task, err := service.Get(ctx, "TASK-000002")
+		require.NoError(t, err)
+		assert.Equal(t, StatusNotStarted, task.Status)
+		assert.Empty(t, task.BlockedBy)
+	})
+}
@@ -0,0 +1,118 @@
+package task
+
+import (
+	"slices"
+	"time"
+)
+
+// FieldChange describes the change of a single task field
+type FieldChange struct {
+	// Field is the JSON name of the changed field
+	Field string `json:"field"`
+	// Before is the value before the change, nil if the field was not set
+	Before any `json:"before"`
+	// After is the value after the change, nil if the field was cleared
+	After any `json:"after"`
+}
+
+// Diff returns the changes of the user editable fields between two versions of a task.
+// A nil before describes a created task and a nil after describes a deleted task.
+func Diff(before, after *Task) []FieldChange {
+	if before == nil {
+		before = &Task{}
+	}
+	if after == nil {
+		after = &Task{}
+	}
+
+	changes := make([]FieldChange, 0)
+
+	if before.Project != after.Project {
+		changes = append(changes, FieldChange{Field: "project", Before: emptyToNil(before.Project), After: emptyToNil(after.Project)})
+	}
+
+	if before.Title != after.Title {
+		changes = append(changes, FieldChange{Field: "title", Before: emptyToNil(before.Title), After: emptyToNil(after.Title)})
+	}
+
+	if before.Description != after.Description {
+		changes = append(changes, FieldChange{Field: "description", Before: emptyToNil(before.Description), After: emptyToNil(after.Description)})
+	}
+
+	if before.Status != after.Status {
+		changes = append(changes, FieldChange{Field: "status", Before: emptyToNil(before.Status), After: emptyToNil(after.Status)})
+	}
+
+	if !equalPtr(before.Priority, after.Priority, func(a, b Priority) bool { return a == b }) {
+		changes = append(changes, FieldChange{Field: "priority", Before: derefOrNil(before.Priority), After: derefOrNil(after.Priority)})
+	}
+
+	if !equalPtr(before.DueDate, after.DueDate, time.Time.Equal) {
+		changes = append(changes, FieldChange{Field: "dueDate", Before: derefOrNil(before.DueDate), After: derefOrNil(after.DueDate)})
+	}
+
+	if !slices.Equal(before.Tags, after.Tags) {
+		changes = append(changes, FieldChange{Field: "tags", Before: emptySliceToNil(before.Tags), After: emptySliceToNil(after.Tags)})
+	}
+
+	if !equalPtr(before.ParentID, after.ParentID, func(a, b string) bool { return a == b }) {
+		changes = append(changes, FieldChange{Field: "parentId", Before: derefOrNil(before.ParentID), After: derefOrNil(after.ParentID)})
+	}
+
+	if !slices.Equal(before.BlockedBy, after.BlockedBy) {
+		changes = append(changes, FieldChange{Field: "blockedBy", Before: emptySliceToNil(before.BlockedBy), After: emptySliceToNil(after.BlockedBy)})
+	}
+
+	if recurrenceString(before.Recurrence) != recurrenceString(after.Recurrence) {
+		changes = append(changes, FieldChange{Field: "recurrence", Before: emptyToNil(recurrenceString(before.Recurrence)), After: emptyToNil(recurrenceString(after.Recurrence))})
+	}
+
+	if before.OwnerID != after.OwnerID {
+		changes = append(changes, FieldChange{Field: "ownerId", Before: emptyToNil(before.OwnerID), After: emptyToNil(after.OwnerID)})
+	}
+
+	if !slices.Equal(before.Assignees, after.Assignees) {
+		changes = append(changes, FieldChange{Field: "assignees", Before: emptySliceToNil(before.Assignees), After: emptySliceToNil(after.Assignees)})
+	}
+
+	return changes
+}
+
+func recurrenceString(r *Recurrence) string {
+	if r == nil {
+		return ""
+	}
+	return r.String()
+}
+
+func equalPtr[T any](a, b *T, equal func(T, T) bool) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return equal(*a, *b)
+}
+
+func derefOrNil[T any](v *T) any {
+	if v == nil {
+		return nil
+	}
+
+	return *v
+}
+
+func emptyToNil[T ~string](v T) any {
+	if v == "" {
+		return nil
+	}
+
+	return v
+}
+
+func emptySliceToNil[T any](v []T) any {
+	if len(v) == 0 {
+		return nil
+	}
+
+	return v
+}
@@ -0,0 +1,61 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/utsabbera/task-master/pkg/util"
+)
+
+func TestDiff(t *testing.T) {
+	due := time.Date(2025, 6, 1, 17, 0, 0, 0, time.UTC)
+
+	t.Run("should return changed fields", func(t *testing.T) {
+		before := &Task{ID: "A", Title: "Write report", Status: StatusNotStarted, Priority: util.Ptr(PriorityLow)}
+		after := &Task{ID: "A", Title: "Write report", Description: "Q2", Status: StatusInProgress, Priority: util.Ptr(PriorityHigh), DueDate: &due}
+
+		changes := Diff(before, after)
+
+		assert.Equal(t, []FieldChange{
+			{Field: "description", Before: nil, After: "Q2"},
+			{Field: "status", Before: StatusNotStarted, After: StatusInProgress},
+			{Field: "priority", Before: PriorityLow, After: PriorityHigh},
+			{Field: "dueDate", Before: nil, After: due},
+		}, changes)
+	})
+
+	t.Run("should ignore equal values behind different pointers", func(t *testing.T) {
+		before := &Task{Priority: util.Ptr(PriorityLow), DueDate: util.Ptr(due)}
+		after := &Task{Priority: util.Ptr(PriorityLow), DueDate: util.Ptr(due.In(time.FixedZone("CET", 3600)))}
+
+		assert.Empty(t, Diff(before, after))
+	})
+
+	t.Run("should compare recurrences by rule", func(t *testing.T) {
+		before := &Task{Recurrence: &Recurrence{Frequency: FrequencyWeekly, Interval: 1}}
+		after := &Task{Recurrence: &Recurrence{Frequency: FrequencyWeekly, ByWeekday: []time.Weekday{time.Monday}}}
+
+		assert.Empty(t, Diff(before, &Task{Recurrence: &Recurrence{Frequency: FrequencyWeekly}}))
+		assert.Equal(t, []FieldChange{{Field: "recurrence", Before: "FREQ=WEEKLY", After: "FREQ=WEEKLY;BYDAY=MO"}}, Diff(before, after))
+	})
+
+	t.Run("should return changed owner and assignees", func(t *testing.T) {
+		before := &Task{OwnerID: "alice", Assignees: []string{"bob"}}
+		after := &Task{OwnerID: "carol"}
+
+		assert.Equal(t, []FieldChange{
+			{Field: "ownerId", Before: "alice", After: "carol"},
+			{Field: "assignees", Before: []string{"bob"}, After: nil},
+		}, Diff(before, after))
+	})
+
+	t.Run("should describe deleted task", func(t *testing.T) {
+		changes := Diff(&Task{Title: "Write report", Status: StatusCompleted}, nil)
+
+		assert.Equal(t, []FieldChange{
+			{Field: "title", Before: "Write report", After: nil},
+			{Field: "status", Before: StatusCompleted, After: nil},
+		}, changes)
+	})
+}
@@ -0,0 +1,214 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrHistoryUnavailable is returned when the history of a task is requested from a repository which doesn't record it
+var ErrHistoryUnavailable = errors.New("task history is not recorded")
+
+// EventType is the kind of change of a task recorded in an event
+type EventType string
+
+const (
+	// EventCreated records the creation of a task with the fields it was created with
+	EventCreated EventType = "TASK_CREATED"
+	// EventUpdated records the update of the fields of a task
+	EventUpdated EventType = "TASK_UPDATED"
+	// EventStatusChanged records an update which only changed the status of a task
+	EventStatusChanged EventType = "STATUS_CHANGED"
+	// EventDeleted records the deletion of a task with the fields it had
+	EventDeleted EventType = "TASK_DELETED"
+)
+
+// EventTypes returns every kind of change of a task
+func EventTypes() []EventType {
+	return []EventType{EventCreated, EventUpdated, EventStatusChanged, EventDeleted}
+}
+
+// Event is a change of a task appended to the event log
+type Event struct {
+	// Sequence is the position of the event in the log, set when the event is appended, starting from 1
+	Sequence int64
+	// TaskID is the ID of the changed task
+	TaskID string
+	// Type is the kind of change
+	Type EventType
+	// Version is the version of the task after the change, one more than the deleted version for deletions
+	Version int
+	// Changes are the fields changed by the event, including the timestamps of the task
+	Changes []FieldChange
+	// Actor is who made the change, empty when unknown
+	Actor string
+	// OccurredAt stores when the change was appended to the log
+	OccurredAt time.Time
+}
+
+// HistoryRepository is a Repository which records the history of the tasks
+type HistoryRepository interface {
+	Repository
+
+	// History returns the events of a task in the order they happened, including its deletion
+	// Returns ErrTaskNotFound if the task has no events
+	History(ctx context.Context, id string) ([]Event, error)
+}
+
+type actorKey struct{}
+
+// WithActor returns a context whose task changes are recorded as made by the actor
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor set by WithActor, empty if there is none
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+// newEvent returns the event recording the change of a task from before to after made at the given time
+func newEvent(ctx context.Context, before, after *Task, at time.Time) *Event {
+	e := &Event{
+		Type:       eventType(before, after),
+		Changes:    eventChanges(before, after),
+		Actor:      ActorFromContext(ctx),
+		OccurredAt: at,
+	}
+
+	if after != nil {
+		e.TaskID, e.Version = after.ID, after.Version
+	} else {
+		e.TaskID, e.Version = before.ID, before.Version+1
+	}
+
+	return e
+}
+
+// eventType returns the type of the event recording the change from before to after
+func eventType(before, after *Task) EventType {
+	switch {
+	case before == nil:
+		return EventCreated
+	case after == nil:
+		return EventDeleted
+	}
+
+	diff := Diff(before, after)
+	if len(diff) == 1 && diff[0].Field == "status" {
+		return EventStatusChanged
+	}
+
+	return EventUpdated
+}
+
+// eventChanges returns the changes of the fields from before to after, the timestamps included so that
+// replaying the changes rebuilds the task
+func eventChanges(before, after *Task) []FieldChange {
+	changes := Diff(before, after)
+	if before == nil {
+		before = &Task{}
+	}
+	if after == nil {
+		after = &Task{}
+	}
+
+	for _, field := range []struct {
+		name          string
+		before, after *time.Time
+	}{
+		{"startedAt", before.StartedAt, after.StartedAt},
+		{"completedAt", before.CompletedAt, after.CompletedAt},
+		{"createdAt", zeroToNil(before.CreatedAt), zeroToNil(after.CreatedAt)},
+		{"updatedAt", zeroToNil(before.UpdatedAt), zeroToNil(after.UpdatedAt)},
+	} {
+		if !equalPtr(field.before, field.after, time.Time.Equal) {
+			changes = append(changes, FieldChange{Field: field.name, Before: derefOrNil(field.before), After: derefOrNil(field.after)})
+		}
+	}
+
+	return changes
+}
+
+func zeroToNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// applyChange sets the field of the task to the value after the change.
+// The value is converted through JSON so that changes read back from a store apply like the original ones
+func applyChange(t *Task, change FieldChange) error {
+	value, err := json.Marshal(change.After)
+	if err != nil {
+		return fmt.Errorf("error encoding change of %s: %w", change.Field, err)
+	}
+
+	var target any
+	switch change.Field {
+	case "project":
+		t.Project, target = "", &t.Project
+	case "title":
+		t.Title, target = "", &t.Title
+	case "description":
+		t.Description, target = "", &t.Description
+	case "status":
+		t.Status, target = "", &t.Status
+	case "priority":
+		t.Priority, target = nil, &t.Priority
+	case "dueDate":
+		t.DueDate, target = nil, &t.DueDate
+	case "tags":
+		t.Tags, target = nil, &t.Tags
+	case "parentId":
+		t.ParentID, target = nil, &t.ParentID
+	case "blockedBy":
+		t.BlockedBy, target = nil, &t.BlockedBy
+	case "recurrence":
+		return applyRecurrenceChange(t, value)
+	case "ownerId":
+		t.OwnerID, target = "", &t.OwnerID
+	case "assignees":
+		t.Assignees, target = nil, &t.Assignees
+	case "startedAt":
+		t.StartedAt, target = nil, &t.StartedAt
+	case "completedAt":
+		t.CompletedAt, target = nil, &t.CompletedAt
+	case "createdAt":
+		t.CreatedAt, target = time.Time{}, &t.CreatedAt
+	case "updatedAt":
+		t.UpdatedAt, target = time.Time{}, &t.UpdatedAt
+	default:
+		return fmt.Errorf("unknown field %q", change.Field)
+	}
+
+	if err := json.Unmarshal(value, target); err != nil {
+		return fmt.Errorf("error decoding change of %s: %w", change.Field, err)
+	}
+
+	return nil
+}
+
+func applyRecurrenceChange(t *Task, value []byte) error {
+	var rule *string
+	if err := json.Unmarshal(value, &rule); err != nil {
+		return fmt.Errorf("error decoding change of recurrence: %w", err)
+	}
+
+	t.Recurrence = nil
+	if rule == nil {
+		return nil
+	}
+
+	recurrence, err := ParseRecurrence(*rule)
+	if err != nil {
+		return err
+	}
+
+	t.Recurrence = recurrence
+	return nil
+}
@@ -0,0 +1,298 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/utsabbera/task-master/pkg/util"
+)
+
+// DefaultSnapshotInterval is the number of events after which EventRepository takes a snapshot by default
+const DefaultSnapshotInterval = 100
+
+// EventRepository is an implementation of Repository which appends every change of the tasks to an event log.
+// The current state of the tasks is rebuilt by replaying the log from the latest snapshot, and kept in memory.
+// A snapshot is taken every snapshotInterval events, a failed snapshot is retried after the next event
+type EventRepository struct {
+	store            EventStore
+	clock            util.Clock
+	snapshotInterval int
+	state            *MemoryRepository
+	sequence         int64
+	pending          int
+	mu               sync.Mutex
+}
+
+// NewEventRepository creates a new event repository on the given store and replays its events,
+// a snapshotInterval of zero or less takes a snapshot every DefaultSnapshotInterval events
+func NewEventRepository(ctx context.Context, store EventStore, clock util.Clock, snapshotInterval int) (*EventRepository, error) {
+	if snapshotInterval <= 0 {
+		snapshotInterval = DefaultSnapshotInterval
+	}
+
+	r := &EventRepository{
+		store:            store,
+		clock:            clock,
+		snapshotInterval: snapshotInterval,
+		state:            NewMemoryRepository(),
+	}
+
+	snapshot, err := store.LatestSnapshot(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error loading snapshot: %w", err)
+	}
+	if snapshot != nil {
+		for _, t := range snapshot.Tasks {
+			r.state.put(t)
+		}
+		r.sequence = snapshot.Sequence
+	}
+
+	events, err := store.Events(ctx, r.sequence)
+	if err != nil {
+		return nil, fmt.Errorf("error loading events: %w", err)
+	}
+
+	for _, e := range events {
+		if err := r.apply(e); err != nil {
+			return nil, fmt.Errorf("error replaying event %d: %w", e.Sequence, err)
+		}
+		r.sequence = e.Sequence
+		r.pending++
+	}
+
+	return r, nil
+}
+
+func (r *EventRepository) Create(ctx context.Context, t *Task) error {
+	return r.write(ctx, func(w *eventWriter) error { return w.Create(ctx, t) })
+}
+
+func (r *EventRepository) Get(ctx context.Context, id string) (*Task, error) {
+	return r.state.Get(ctx, id)
+}
+
+func (r *EventRepository) List(ctx context.Context, opts ListOptions) (*Page, error) {
+	return r.state.List(ctx, opts)
+}
+
+func (r *EventRepository) Update(ctx context.Context, t *Task) error {
+	return r.write(ctx, func(w *eventWriter) error { return w.Update(ctx, t) })
+}
+
+func (r *EventRepository) Delete(ctx context.Context, id string, version int) error {
+	return r.write(ctx, func(w *eventWriter) error { return w.Delete(ctx, id, version) })
+}
+
+// Transaction calls fn with a unit of work recording the events on the current state.
+// The events are appended to the store all together once fn returns nil, and their changes to the state
+// are undone when fn or the append fails
+func (r *EventRepository) Transaction(ctx context.Context, fn func(tx Repository) error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var events []*Event
+	err := r.state.transaction(func(state *MemoryRepository) error {
+		w := &eventWriter{state: state, clock: r.clock}
+		if err := fn(w); err != nil {
+			return err
+		}
+
+		if len(w.events) > 0 {
+			if err := r.store.Append(ctx, w.events...); err != nil {
+				return fmt.Errorf("error appending events: %w", err)
+			}
+		}
+
+		events = w.events
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	r.recorded(ctx, events)
+	return nil
+}
+
+// write calls fn with a writer appending every event to the store before applying it to the current state
+func (r *EventRepository) write(ctx context.Context, fn func(w *eventWriter) error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	w := &eventWriter{state: r.state, clock: r.clock, store: r.store}
+	err := fn(w)
+	r.recorded(ctx, w.events)
+	return err
+}
+
+// recorded advances the sequence past the appended events, taking a snapshot when enough of them were appended
+func (r *EventRepository) recorded(ctx context.Context, events []*Event) {
+	if len(events) == 0 {
+		return
+	}
+
+	r.sequence = events[len(events)-1].Sequence
+	if r.pending += len(events); r.pending >= r.snapshotInterval {
+		r.snapshot(ctx)
+	}
+}
+
+func (r *EventRepository) Tags(ctx context.Context) ([]TagCount, error) {
+	return r.state.Tags(ctx)
+}
+
+func (r *EventRepository) History(ctx context.Context, id string) ([]Event, error) {
+	events, err := r.store.TaskEvents(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error loading events: %w", err)
+	}
+
+	if len(events) == 0 {
+		return nil, ErrTaskNotFound
+	}
+
+	return events, nil
+}
+
+// apply replays the event on the current state
+func (r *EventRepository) apply(e Event) error {
+	return applyEvent(r.state, e)
+}
+
+func (r *EventRepository) snapshot(ctx context.Context) {
+	page, err := r.state.List(ctx, ListOptions{})
+	if err != nil {
+		return
+	}
+
+	snapshot := Snapshot{Sequence: r.sequence, Tasks: page.Tasks, CreatedAt: r.clock.Now()}
+	if err := r.store.SaveSnapshot(ctx, snapshot); err != nil {
+		return
+	}
+
+	r.pending = 0
+}
+
+// eventWriter validates the changes of the tasks against a state and records them as events applied to it.
+// The events are appended to the store one at a time when it is set, and kept for the caller to append otherwise
+type eventWriter struct {
+	state  *MemoryRepository
+	clock  util.Clock
+	store  EventStore
+	events []*Event
+}
+
+func (w *eventWriter) Create(ctx context.Context, t *Task) error {
+	if t.ID == "" {
+		return ErrInvalidTask
+	}
+
+	if _, err := w.state.Get(ctx, t.ID); err == nil {
+		return fmt.Errorf("%w: %s already exists", ErrInvalidTask, t.ID)
+	}
+
+	created := t.clone()
+	created.Version = 1
+	if err := w.record(ctx, nil, created); err != nil {
+		return err
+	}
+
+	t.Version = created.Version
+	return nil
+}
+
+func (w *eventWriter) Get(ctx context.Context, id string) (*Task, error) {
+	return w.state.Get(ctx, id)
+}
+
+func (w *eventWriter) List(ctx context.Context, opts ListOptions) (*Page, error) {
+	return w.state.List(ctx, opts)
+}
+
+func (w *eventWriter) Update(ctx context.Context, t *Task) error {
+	current, err := w.state.Get(ctx, t.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := checkVersion(t.Version, current.Version); err != nil {
+		return err
+	}
+
+	updated := t.clone()
+	updated.Version = current.Version + 1
+	if err := w.record(ctx, current, updated); err != nil {
+		return err
+	}
+
+	t.Version = updated.Version
+	return nil
+}
+
+func (w *eventWriter) Delete(ctx context.Context, id string, version int) error {
+	current, err := w.state.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := checkVersion(version, current.Version); err != nil {
+		return err
+	}
+
+	return w.record(ctx, current, nil)
+}
+
+func (w *eventWriter) Tags(ctx context.Context) ([]TagCount, error) {
+	return w.state.Tags(ctx)
+}
+
+// Transaction calls fn with the writer itself, its events are already part of a unit of work
+func (w *eventWriter) Transaction(_ context.Context, fn func(tx Repository) error) error {
+	return fn(w)
+}
+
+// record appends the event of the change from before to after and applies it to the state
+func (w *eventWriter) record(ctx context.Context, before, after *Task) error {
+	e := newEvent(ctx, before, after, w.clock.Now())
+	if w.store != nil {
+		if err := w.store.Append(ctx, e); err != nil {
+			return fmt.Errorf("error appending event: %w", err)
+		}
+	}
+
+	w.events = append(w.events, e)
+	if err := applyEvent(w.state, *e); err != nil {
+		return fmt.Errorf("error applying event %d: %w", e.Sequence, err)
+	}
+
+	return nil
+}
+
+// applyEvent replays the event on the state
+func applyEvent(state *MemoryRepository, e Event) error {
+	if e.Type == EventDeleted {
+		state.remove(e.TaskID)
+		return nil
+	}
+
+	t := &Task{ID: e.TaskID}
+	if e.Type != EventCreated {
+		current, err := state.Get(context.Background(), e.TaskID)
+		if err != nil {
+			return err
+		}
+		t = current
+	}
+
+	for _, change := range e.Changes {
+		if err := applyChange(t, change); err != nil {
+			return err
+		}
+	}
+
+	t.Version = e.Version
+	state.put(t)
+	return nil
+}
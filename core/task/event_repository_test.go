@@ -0,0 +1,281 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/pkg/database"
+	"github.com/utsabbera/task-master/pkg/util"
+	"go.uber.org/mock/gomock"
+)
+
+func TestEventRepository(t *testing.T) {
+	t.Run("Memory", func(t *testing.T) {
+		testRepository(t, func(t *testing.T) Repository {
+			repo, err := NewEventRepository(context.Background(), NewMemoryEventStore(), newTickingClock(gomock.NewController(t)), 2)
+			require.NoError(t, err)
+
+			return repo
+		})
+	})
+
+	t.Run("SQL", func(t *testing.T) {
+		testRepository(t, func(t *testing.T) Repository {
+			repo, err := NewEventRepository(context.Background(), newSQLEventStore(t), newTickingClock(gomock.NewController(t)), 2)
+			require.NoError(t, err)
+
+			return repo
+		})
+	})
+}
+
+func newSQLEventStore(t *testing.T) *SQLEventStore {
+	t.Helper()
+
+	db, err := database.OpenSQLite(context.Background(), ":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	store, err := NewSQLEventStore(context.Background(), db)
+	require.NoError(t, err)
+
+	return store
+}
+
+// countSnapshots returns the number of snapshots kept by the store
+func countSnapshots(t *testing.T, store EventStore) int {
+	t.Helper()
+
+	switch s := store.(type) {
+	case *MemoryEventStore:
+		if s.snapshot == nil {
+			return 0
+		}
+		return 1
+	case *SQLEventStore:
+		var count int
+		require.NoError(t, s.db.QueryRowContext(context.Background(), `SELECT COUNT(*) FROM task_snapshots`).Scan(&count))
+		return count
+	}
+
+	t.Fatalf("unexpected store %T", store)
+	return 0
+}
+
+func TestNewEventRepository(t *testing.T) {
+	due := time.Date(2025, 5, 2, 17, 0, 0, 0, time.UTC)
+
+	record := func(t *testing.T, repo *EventRepository) {
+		t.Helper()
+		ctx := context.Background()
+
+		for _, task := range []*Task{
+			{ID: "TASK-1", Title: "Write report", Status: StatusNotStarted, Priority: util.Ptr(PriorityHigh), DueDate: &due, Tags: []string{"work"}},
+			{ID: "TASK-2", Title: "Review report", Status: StatusNotStarted, BlockedBy: []string{"TASK-1"}, Recurrence: &Recurrence{Frequency: FrequencyWeekly, ByWeekday: []time.Weekday{time.Monday}}},
+			{ID: "TASK-3", Title: "Archive report", Status: StatusNotStarted},
+		} {
+			require.NoError(t, repo.Create(ctx, task))
+		}
+
+		task, err := repo.Get(ctx, "TASK-1")
+		require.NoError(t, err)
+		task.Status, task.StartedAt = StatusInProgress, util.Ptr(due.Add(-time.Hour))
+		require.NoError(t, repo.Update(ctx, task))
+
+		require.NoError(t, repo.Delete(ctx, "TASK-3", 0))
+	}
+
+	stores := []struct {
+		name     string
+		newStore func(t *testing.T) EventStore
+	}{
+		{"Memory", func(t *testing.T) EventStore { return NewMemoryEventStore() }},
+		{"SQL", func(t *testing.T) EventStore { return newSQLEventStore(t) }},
+	}
+
+	for _, s := range stores {
+		t.Run(s.name, func(t *testing.T) {
+			for _, interval := range []int{1, 2, 100} {
+				t.Run(fmt.Sprintf("should rebuild tasks by replaying events with snapshot interval %d", interval), func(t *testing.T) {
+					ctx := context.Background()
+					store := s.newStore(t)
+					repo, err := NewEventRepository(ctx, store, newTickingClock(gomock.NewController(t)), interval)
+					require.NoError(t, err)
+					record(t, repo)
+
+					rebuilt, err := NewEventRepository(ctx, store, newTickingClock(gomock.NewController(t)), interval)
+					require.NoError(t, err)
+
+					expected, err := repo.List(ctx, ListOptions{})
+					require.NoError(t, err)
+					actual, err := rebuilt.List(ctx, ListOptions{})
+					require.NoError(t, err)
+					assert.Equal(t, expected, actual)
+				})
+			}
+
+			t.Run("should take snapshot every interval events", func(t *testing.T) {
+				ctx := context.Background()
+				store := s.newStore(t)
+				repo, err := NewEventRepository(ctx, store, newTickingClock(gomock.NewController(t)), 2)
+				require.NoError(t, err)
+				record(t, repo)
+
+				snapshot, err := store.LatestSnapshot(ctx)
+				require.NoError(t, err)
+				require.NotNil(t, snapshot)
+				assert.Equal(t, int64(4), snapshot.Sequence)
+				assert.Len(t, snapshot.Tasks, 3)
+			})
+
+			t.Run("should keep only latest snapshot", func(t *testing.T) {
+				ctx := context.Background()
+				store := s.newStore(t)
+				require.NoError(t, store.SaveSnapshot(ctx, Snapshot{Sequence: 2, Tasks: []*Task{{ID: "TASK-1"}}}))
+				require.NoError(t, store.SaveSnapshot(ctx, Snapshot{Sequence: 4, Tasks: []*Task{{ID: "TASK-1"}, {ID: "TASK-2"}}}))
+
+				snapshot, err := store.LatestSnapshot(ctx)
+				require.NoError(t, err)
+				require.NotNil(t, snapshot)
+				assert.Equal(t, int64(4), snapshot.Sequence)
+				assert.Equal(t, 1, countSnapshots(t, store))
+			})
+		})
+	}
+
+	t.Run("should return error when events cannot be loaded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := NewMockEventStore(ctrl)
+		store.EXPECT().LatestSnapshot(gomock.Any()).Return(nil, nil)
+		store.EXPECT().Events(gomock.Any(), int64(0)).Return(nil, errors.New("db error"))
+
+		_, err := NewEventRepository(context.Background(), store, newTickingClock(ctrl), 0)
+
+		assert.ErrorContains(t, err, "db error")
+	})
+}
+
+func TestEventRepository_Update(t *testing.T) {
+	t.Run("should keep task unchanged when event cannot be appended", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		store := NewMockEventStore(ctrl)
+		store.EXPECT().LatestSnapshot(gomock.Any()).Return(nil, nil)
+		store.EXPECT().Events(gomock.Any(), int64(0)).Return(nil, nil)
+		store.EXPECT().Append(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, events ...*Event) error {
+			events[0].Sequence = 1
+			return nil
+		})
+		store.EXPECT().Append(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+
+		repo, err := NewEventRepository(ctx, store, newTickingClock(ctrl), 0)
+		require.NoError(t, err)
+		require.NoError(t, repo.Create(ctx, &Task{ID: "TASK-1", Title: "Write report", Status: StatusNotStarted}))
+
+		err = repo.Update(ctx, &Task{ID: "TASK-1", Title: "Write summary", Status: StatusNotStarted, Version: 1})
+		require.ErrorContains(t, err, "db error")
+
+		task, err := repo.Get(ctx, "TASK-1")
+		require.NoError(t, err)
+		assert.Equal(t, "Write report", task.Title)
+		assert.Equal(t, 1, task.Version)
+	})
+}
+
+func TestEventRepository_Transaction(t *testing.T) {
+	t.Run("should append events of transaction at once", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		store := NewMockEventStore(ctrl)
+		store.EXPECT().LatestSnapshot(gomock.Any()).Return(nil, nil)
+		store.EXPECT().Events(gomock.Any(), int64(0)).Return(nil, nil)
+		store.EXPECT().Append(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, events ...*Event) error {
+			for i, e := range events {
+				e.Sequence = int64(i + 1)
+			}
+			return nil
+		})
+
+		repo, err := NewEventRepository(ctx, store, newTickingClock(ctrl), 0)
+		require.NoError(t, err)
+
+		err = repo.Transaction(ctx, func(tx Repository) error {
+			if err := tx.Create(ctx, &Task{ID: "TASK-1", Title: "Write report", Status: StatusNotStarted}); err != nil {
+				return err
+			}
+			return tx.Update(ctx, &Task{ID: "TASK-1", Title: "Write summary", Status: StatusNotStarted, Version: 1})
+		})
+		require.NoError(t, err)
+
+		task, err := repo.Get(ctx, "TASK-1")
+		require.NoError(t, err)
+		assert.Equal(t, "Write summary", task.Title)
+		assert.Equal(t, 2, task.Version)
+	})
+
+	t.Run("should discard changes when events cannot be appended", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		store := NewMockEventStore(ctrl)
+		store.EXPECT().LatestSnapshot(gomock.Any()).Return(nil, nil)
+		store.EXPECT().Events(gomock.Any(), int64(0)).Return(nil, nil)
+		store.EXPECT().Append(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+
+		repo, err := NewEventRepository(ctx, store, newTickingClock(ctrl), 0)
+		require.NoError(t, err)
+
+		err = repo.Transaction(ctx, func(tx Repository) error {
+			if err := tx.Create(ctx, &Task{ID: "TASK-1", Title: "Write report", Status: StatusNotStarted}); err != nil {
+				return err
+			}
+			return tx.Create(ctx, &Task{ID: "TASK-2", Title: "Review report", Status: StatusNotStarted})
+		})
+		require.ErrorContains(t, err, "db error")
+
+		_, err = repo.Get(ctx, "TASK-1")
+		assert.ErrorIs(t, err, ErrTaskNotFound)
+	})
+}
+
+func TestEventRepository_History(t *testing.T) {
+	t.Run("should return events of task with actor and time", func(t *testing.T) {
+		ctx := context.Background()
+		clock := newTickingClock(gomock.NewController(t))
+		repo, err := NewEventRepository(ctx, NewMemoryEventStore(), clock, 0)
+		require.NoError(t, err)
+
+		require.NoError(t, repo.Create(WithActor(ctx, "alice"), &Task{ID: "TASK-1", Title: "Write report", Status: StatusNotStarted}))
+		require.NoError(t, repo.Create(ctx, &Task{ID: "TASK-2", Title: "Review report", Status: StatusNotStarted}))
+		require.NoError(t, repo.Update(WithActor(ctx, "bob"), &Task{ID: "TASK-1", Title: "Write report", Status: StatusInProgress}))
+		require.NoError(t, repo.Update(ctx, &Task{ID: "TASK-1", Title: "Write summary", Status: StatusInProgress}))
+		require.NoError(t, repo.Delete(ctx, "TASK-1", 0))
+
+		events, err := repo.History(ctx, "TASK-1")
+
+		require.NoError(t, err)
+		require.Len(t, events, 4)
+		assert.Equal(t, []EventType{EventCreated, EventStatusChanged, EventUpdated, EventDeleted},
+			util.Map(events, func(e Event) EventType { return e.Type }))
+		assert.Equal(t, []int{1, 2, 3, 4}, util.Map(events, func(e Event) int { return e.Version }))
+		assert.Equal(t, []int64{1, 3, 4, 5}, util.Map(events, func(e Event) int64 { return e.Sequence }))
+		assert.Equal(t, "alice", events[0].Actor)
+		assert.Equal(t, "bob", events[1].Actor)
+		assert.Empty(t, events[2].Actor)
+		assert.True(t, events[0].OccurredAt.Before(events[1].OccurredAt))
+		assert.Equal(t, []FieldChange{{Field: "status", Before: StatusNotStarted, After: StatusInProgress}}, events[1].Changes)
+	})
+
+	t.Run("should return error when task has no events", func(t *testing.T) {
+		repo, err := NewEventRepository(context.Background(), NewMemoryEventStore(), newTickingClock(gomock.NewController(t)), 0)
+		require.NoError(t, err)
+
+		_, err = repo.History(context.Background(), "TASK-1")
+
+		assert.ErrorIs(t, err, ErrTaskNotFound)
+	})
+}
@@ -0,0 +1,147 @@
+package task
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+//go:generate mockgen -destination=event_store_mock.go -package=task . EventStore
+
+// EventStore is an append-only log of the events of the tasks with snapshots of their state
+type EventStore interface {
+	// Append adds the events at the end of the log in the given order and sets their sequence numbers
+	Append(ctx context.Context, events ...*Event) error
+
+	// Events returns the events appended after the given sequence number, in order
+	Events(ctx context.Context, after int64) ([]Event, error)
+
+	// TaskEvents returns the events of a task, in order
+	TaskEvents(ctx context.Context, id string) ([]Event, error)
+
+	// TaskIDs returns the IDs of every task having events, the deleted and moved tasks included
+	TaskIDs(ctx context.Context) ([]string, error)
+
+	// SaveSnapshot stores the state of the tasks after the event of the snapshot sequence number,
+	// discarding the snapshots older than it
+	SaveSnapshot(ctx context.Context, snapshot Snapshot) error
+
+	// LatestSnapshot returns the snapshot with the highest sequence number, nil if there is none
+	LatestSnapshot(ctx context.Context) (*Snapshot, error)
+}
+
+// Snapshot is the state of the tasks at a position of the event log
+type Snapshot struct {
+	// Sequence is the sequence number of the last event applied to the tasks
+	Sequence int64
+	// Tasks are the tasks which existed after the event
+	Tasks []*Task
+	// CreatedAt stores when the snapshot was taken
+	CreatedAt time.Time
+}
+
+// MemoryEventStore is an in-memory implementation of EventStore
+type MemoryEventStore struct {
+	events   []Event
+	snapshot *Snapshot
+	mu       sync.RWMutex
+}
+
+// NewMemoryEventStore creates a new empty memory event store
+func NewMemoryEventStore() *MemoryEventStore {
+	return &MemoryEventStore{}
+}
+
+func (s *MemoryEventStore) Append(_ context.Context, events ...*Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, e := range events {
+		e.Sequence = int64(len(s.events) + 1)
+		s.events = append(s.events, cloneEvent(*e))
+	}
+
+	return nil
+}
+
+func (s *MemoryEventStore) Events(_ context.Context, after int64) ([]Event, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	events := make([]Event, 0)
+	for _, e := range s.events[min(int(max(after, 0)), len(s.events)):] {
+		events = append(events, cloneEvent(e))
+	}
+
+	return events, nil
+}
+
+func (s *MemoryEventStore) TaskEvents(_ context.Context, id string) ([]Event, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	events := make([]Event, 0)
+	for _, e := range s.events {
+		if e.TaskID == id {
+			events = append(events, cloneEvent(e))
+		}
+	}
+
+	return events, nil
+}
+
+func (s *MemoryEventStore) TaskIDs(_ context.Context) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ids := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, e := range s.events {
+		if !seen[e.TaskID] {
+			seen[e.TaskID] = true
+			ids = append(ids, e.TaskID)
+		}
+	}
+
+	return ids, nil
+}
+
+func (s *MemoryEventStore) SaveSnapshot(_ context.Context, snapshot Snapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.snapshot != nil && s.snapshot.Sequence > snapshot.Sequence {
+		return nil
+	}
+
+	saved := cloneSnapshot(snapshot)
+	s.snapshot = &saved
+	return nil
+}
+
+func (s *MemoryEventStore) LatestSnapshot(_ context.Context) (*Snapshot, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.snapshot == nil {
+		return nil, nil
+	}
+
+	snapshot := cloneSnapshot(*s.snapshot)
+	return &snapshot, nil
+}
+
+func cloneEvent(e Event) Event {
+	e.Changes = slices.Clone(e.Changes)
+	return e
+}
+
+func cloneSnapshot(s Snapshot) Snapshot {
+	tasks := make([]*Task, 0, len(s.Tasks))
+	for _, t := range s.Tasks {
+		tasks = append(tasks, t.clone())
+	}
+	s.Tasks = tasks
+	return s
+}
@@ -0,0 +1,80 @@
+package task
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/pkg/util"
+)
+
+func TestEventType(t *testing.T) {
+	task := &Task{ID: "TASK-1", Title: "Write report", Status: StatusNotStarted}
+
+	tests := []struct {
+		name          string
+		before, after *Task
+		expected      EventType
+	}{
+		{"created", nil, task, EventCreated},
+		{"deleted", task, nil, EventDeleted},
+		{"status changed", task, &Task{ID: "TASK-1", Title: "Write report", Status: StatusInProgress}, EventStatusChanged},
+		{"updated", task, &Task{ID: "TASK-1", Title: "Write summary", Status: StatusInProgress}, EventUpdated},
+	}
+
+	for _, tt := range tests {
+		t.Run("should return type of change "+tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, eventType(tt.before, tt.after))
+		})
+	}
+}
+
+func TestApplyChange(t *testing.T) {
+	t.Run("should rebuild task from changes read back as json", func(t *testing.T) {
+		now := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+		task := &Task{
+			ID:          "TASK-1",
+			Title:       "Write report",
+			Description: "Quarterly numbers",
+			Status:      StatusCompleted,
+			Priority:    util.Ptr(PriorityHigh),
+			DueDate:     util.Ptr(now.AddDate(0, 0, 1)),
+			Tags:        []string{"work"},
+			ParentID:    util.Ptr("TASK-0"),
+			BlockedBy:   []string{"TASK-2"},
+			Recurrence:  &Recurrence{Frequency: FrequencyWeekly, ByWeekday: []time.Weekday{time.Monday}},
+			StartedAt:   util.Ptr(now.Add(time.Hour)),
+			CompletedAt: util.Ptr(now.Add(2 * time.Hour)),
+			CreatedAt:   now,
+			UpdatedAt:   now.Add(2 * time.Hour),
+		}
+
+		encoded, err := json.Marshal(eventChanges(nil, task))
+		require.NoError(t, err)
+		var changes []FieldChange
+		require.NoError(t, json.Unmarshal(encoded, &changes))
+
+		rebuilt := &Task{ID: "TASK-1"}
+		for _, change := range changes {
+			require.NoError(t, applyChange(rebuilt, change))
+		}
+
+		assert.Equal(t, task, rebuilt)
+	})
+
+	t.Run("should clear field changed to nil", func(t *testing.T) {
+		task := &Task{Priority: util.Ptr(PriorityHigh), Tags: []string{"work"}}
+
+		require.NoError(t, applyChange(task, FieldChange{Field: "priority", Before: PriorityHigh}))
+		require.NoError(t, applyChange(task, FieldChange{Field: "tags", Before: []string{"work"}}))
+
+		assert.Nil(t, task.Priority)
+		assert.Nil(t, task.Tags)
+	})
+
+	t.Run("should return error for unknown field", func(t *testing.T) {
+		assert.Error(t, applyChange(&Task{}, FieldChange{Field: "owner"}))
+	})
+}
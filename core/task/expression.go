@@ -0,0 +1,298 @@
+package task
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/utsabbera/task-master/pkg/query"
+)
+
+// ErrInvalidExpression is returned when a filter expression cannot be parsed or compares the tasks in an invalid way
+var ErrInvalidExpression = errors.New("invalid filter expression")
+
+// CurrentUser is the value of the owner and assignee fields of an expression standing for the user of the context
+const CurrentUser = "me"
+
+type fieldKind int
+
+const (
+	kindText fieldKind = iota
+	kindString
+	kindStatus
+	kindPriority
+	kindTime
+	kindList
+)
+
+type expressionField struct {
+	name     string
+	kind     fieldKind
+	nullable bool
+}
+
+var expressionFields = map[string]expressionField{
+	"id":          {name: "id", kind: kindString},
+	"title":       {name: "title", kind: kindText},
+	"description": {name: "description", kind: kindText},
+	"status":      {name: "status", kind: kindStatus},
+	"priority":    {name: "priority", kind: kindPriority, nullable: true},
+	"due":         {name: "due", kind: kindTime, nullable: true},
+	"created":     {name: "created", kind: kindTime},
+	"updated":     {name: "updated", kind: kindTime},
+	"tag":         {name: "tag", kind: kindList},
+	"assignee":    {name: "assignee", kind: kindList},
+	"owner":       {name: "owner", kind: kindString},
+	"project":     {name: "project", kind: kindString},
+	"parent":      {name: "parent", kind: kindString, nullable: true},
+}
+
+var fieldOperators = map[fieldKind][]query.Operator{
+	kindText:     {query.Equal, query.NotEqual, query.Contains},
+	kindString:   {query.Equal, query.NotEqual},
+	kindStatus:   {query.Equal, query.NotEqual},
+	kindPriority: {query.Equal, query.NotEqual, query.Less, query.LessOrEqual, query.Greater, query.GreaterOrEqual},
+	kindTime:     {query.Equal, query.NotEqual, query.Less, query.LessOrEqual, query.Greater, query.GreaterOrEqual},
+	kindList:     {query.Equal, query.NotEqual},
+}
+
+// Expression is a parsed filter expression of the query language of package query, e.g.
+// status != COMPLETED and (priority = HIGH or due < now+3d) and tag:backend
+//
+// The fields are id, title, description, status, priority, due, created, updated, tag, assignee, owner, project
+// and parent. The : operator tests whether a task has a tag or an assignee, and whether its title or description
+// contains a text like ~, for the other fields it is the same as =. Priorities are ordered from LOW to HIGH,
+// times are relative to the time the tasks are listed, e.g. now+3d, and me is the user of the context.
+// A missing priority, due date or parent is only matched by != and by = null
+type Expression struct {
+	source     string
+	root       query.Expr
+	conditions map[*query.Comparison]condition
+}
+
+// ParseExpression parses a filter expression and checks its fields and values
+// Returns ErrInvalidExpression if the expression is malformed or has unknown fields, operators or values
+func ParseExpression(source string) (*Expression, error) {
+	root, err := query.Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidExpression, err)
+	}
+
+	return compileExpression(source, root, time.Time{}, CurrentUser)
+}
+
+// String returns the source of the expression
+func (e *Expression) String() string {
+	return e.source
+}
+
+// Matches reports whether the task satisfies the expression
+func (e *Expression) Matches(t *Task) bool {
+	return query.Eval(e.root, func(c *query.Comparison) bool {
+		return e.conditions[c].matches(t)
+	})
+}
+
+// And returns the expression matching the tasks matched by both expressions
+func (e *Expression) And(other *Expression) *Expression {
+	conditions := maps.Clone(e.conditions)
+	maps.Copy(conditions, other.conditions)
+
+	return &Expression{
+		source:     "(" + e.source + ") and (" + other.source + ")",
+		root:       &query.And{Left: e.root, Right: other.root},
+		conditions: conditions,
+	}
+}
+
+// bind resolves the times of the expression against now and me against the user of the context
+func (e *Expression) bind(ctx context.Context, now time.Time) (*Expression, error) {
+	user, _ := UserFromContext(ctx)
+	return compileExpression(e.source, e.root, now, user.ID)
+}
+
+func compileExpression(source string, root query.Expr, now time.Time, user string) (*Expression, error) {
+	conditions := make(map[*query.Comparison]condition)
+	for _, c := range query.Comparisons(root) {
+		compiled, err := compileCondition(c, now, user)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s at position %d", ErrInvalidExpression, err, c.Pos)
+		}
+		conditions[c] = compiled
+	}
+
+	return &Expression{source: source, root: root, conditions: conditions}, nil
+}
+
+// condition is a comparison of an expression with its operator and value resolved for its field
+type condition struct {
+	field expressionField
+	op    query.Operator
+	null  bool
+	value string
+	time  time.Time
+}
+
+func compileCondition(c *query.Comparison, now time.Time, user string) (condition, error) {
+	field, ok := expressionFields[strings.ToLower(c.Field)]
+	if !ok {
+		return condition{}, fmt.Errorf("unknown field %q, expected one of %s", c.Field, strings.Join(slices.Sorted(maps.Keys(expressionFields)), ", "))
+	}
+
+	op := c.Operator
+	if op == query.Has {
+		op = query.Equal
+		if field.kind == kindText {
+			op = query.Contains
+		}
+	}
+
+	if !slices.Contains(fieldOperators[field.kind], op) {
+		return condition{}, fmt.Errorf("operator %s cannot be applied to %s", c.Operator, field.name)
+	}
+
+	result := condition{field: field, op: op, value: c.Value}
+
+	if !c.Quoted && strings.EqualFold(c.Value, "null") {
+		if !field.nullable || (op != query.Equal && op != query.NotEqual) {
+			return condition{}, fmt.Errorf("%s cannot be compared with null using %s", field.name, c.Operator)
+		}
+		result.null = true
+		return result, nil
+	}
+
+	var err error
+	switch field.name {
+	case "status":
+		result.value = strings.ToUpper(c.Value)
+		if !Status(result.value).Valid() {
+			return condition{}, fmt.Errorf("unknown status %q", c.Value)
+		}
+	case "priority":
+		result.value = strings.ToUpper(c.Value)
+		if !Priority(result.value).Valid() {
+			return condition{}, fmt.Errorf("unknown priority %q", c.Value)
+		}
+	case "due", "created", "updated":
+		if result.time, err = query.ParseTime(c.Value, now); err != nil {
+			return condition{}, err
+		}
+	case "tag":
+		if result.value, err = NormalizeTag(c.Value); err != nil {
+			return condition{}, err
+		}
+	case "project":
+		result.value = strings.ToUpper(c.Value)
+	case "owner", "assignee":
+		if !c.Quoted && c.Value == CurrentUser {
+			if user == "" {
+				return condition{}, fmt.Errorf("%s requires an authenticated user", CurrentUser)
+			}
+			result.value = user
+		}
+	}
+
+	return result, nil
+}
+
+func (c condition) matches(t *Task) bool {
+	switch {
+	case c.field.kind == kindList:
+		return slices.Contains(c.list(t), c.value) == (c.op == query.Equal)
+	case c.op == query.Contains:
+		text, _ := c.text(t)
+		return strings.Contains(strings.ToLower(text), strings.ToLower(c.value))
+	}
+
+	ordering, present := c.compare(t)
+	if c.null {
+		return present == (c.op == query.NotEqual)
+	}
+
+	if !present {
+		return c.op == query.NotEqual
+	}
+
+	switch c.op {
+	case query.Equal:
+		return ordering == 0
+	case query.NotEqual:
+		return ordering != 0
+	case query.Less:
+		return ordering < 0
+	case query.LessOrEqual:
+		return ordering <= 0
+	case query.Greater:
+		return ordering > 0
+	default:
+		return ordering >= 0
+	}
+}
+
+// compare compares the field of the task with the value of the condition, false if the task has no value
+func (c condition) compare(t *Task) (int, bool) {
+	switch c.field.kind {
+	case kindPriority:
+		if t.Priority == nil {
+			return 0, false
+		}
+		return cmp.Compare(priorityRank(*t.Priority), priorityRank(Priority(c.value))), true
+	case kindTime:
+		value := c.timeOf(t)
+		if value == nil {
+			return 0, false
+		}
+		return value.Compare(c.time), true
+	default:
+		text, ok := c.text(t)
+		return strings.Compare(text, c.value), ok
+	}
+}
+
+func (c condition) text(t *Task) (string, bool) {
+	switch c.field.name {
+	case "id":
+		return t.ID, true
+	case "title":
+		return t.Title, true
+	case "description":
+		return t.Description, true
+	case "status":
+		return string(t.Status), true
+	case "owner":
+		return t.OwnerID, true
+	case "project":
+		return t.Project, true
+	case "parent":
+		if t.ParentID == nil {
+			return "", false
+		}
+		return *t.ParentID, true
+	default:
+		return "", false
+	}
+}
+
+func (c condition) timeOf(t *Task) *time.Time {
+	switch c.field.name {
+	case "due":
+		return t.DueDate
+	case "created":
+		return &t.CreatedAt
+	default:
+		return &t.UpdatedAt
+	}
+}
+
+func (c condition) list(t *Task) []string {
+	if c.field.name == "tag" {
+		return t.Tags
+	}
+
+	return t.Assignees
+}
@@ -0,0 +1,99 @@
+package task
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/pkg/util"
+)
+
+func TestParseExpression(t *testing.T) {
+	t.Run("should keep the source of the expression", func(t *testing.T) {
+		expression, err := ParseExpression("status != COMPLETED and tag:backend")
+
+		require.NoError(t, err)
+		assert.Equal(t, "status != COMPLETED and tag:backend", expression.String())
+	})
+
+	t.Run("should return error for invalid expressions", func(t *testing.T) {
+		tests := []struct {
+			source  string
+			message string
+		}{
+			{"status = ", "unexpected end of expression, expected value at position 10"},
+			{"size > 3", `unknown field "size", expected one of assignee, created, description, due, id, owner, parent, priority, project, status, tag, title, updated at position 1`},
+			{"status = DONE", `unknown status "DONE" at position 1`},
+			{"priority = URGENT", `unknown priority "URGENT" at position 1`},
+			{"status < COMPLETED", "operator < cannot be applied to status at position 1"},
+			{"tag ~ back", "operator ~ cannot be applied to tag at position 1"},
+			{"due = null or due < null", "due cannot be compared with null using < at position 15"},
+			{"tag = null", "tag cannot be compared with null using = at position 1"},
+			{"due < tomorrow", `invalid time "tomorrow", expected now, today, an offset such as now+3d, a date or an RFC 3339 time at position 1`},
+			{`tag:"bad tag"`, `invalid tag: "bad tag" at position 1`},
+		}
+
+		for _, tt := range tests {
+			expression, err := ParseExpression(tt.source)
+
+			assert.ErrorIs(t, err, ErrInvalidExpression, tt.source)
+			assert.EqualError(t, err, "invalid filter expression: "+tt.message, tt.source)
+			assert.Nil(t, expression)
+		}
+	})
+}
+
+func TestExpression_Matches(t *testing.T) {
+	t.Run("should compare text with null when quoted", func(t *testing.T) {
+		expression, err := ParseExpression(`title = "null" or parent = "null"`)
+		require.NoError(t, err)
+
+		assert.True(t, expression.Matches(&Task{Title: "null"}))
+		assert.True(t, expression.Matches(&Task{ParentID: util.Ptr("null")}))
+		assert.False(t, expression.Matches(&Task{Title: "Write report"}))
+	})
+
+	t.Run("should match tasks matched by both expressions", func(t *testing.T) {
+		high, err := ParseExpression("priority = HIGH")
+		require.NoError(t, err)
+		urgent, err := ParseExpression("tag:urgent or tag:backend")
+		require.NoError(t, err)
+
+		expression := high.And(urgent)
+
+		assert.Equal(t, "(priority = HIGH) and (tag:urgent or tag:backend)", expression.String())
+		assert.True(t, expression.Matches(&Task{Priority: util.Ptr(PriorityHigh), Tags: []string{"backend"}}))
+		assert.False(t, expression.Matches(&Task{Priority: util.Ptr(PriorityHigh)}))
+		assert.False(t, expression.Matches(&Task{Priority: util.Ptr(PriorityLow), Tags: []string{"urgent"}}))
+	})
+}
+
+func TestExpression_bind(t *testing.T) {
+	now := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("should resolve times against now and me against the user of the context", func(t *testing.T) {
+		expression, err := ParseExpression("due < now+1d and assignee:me")
+		require.NoError(t, err)
+
+		bound, err := expression.bind(WithUser(context.Background(), User{ID: "alice"}), now)
+
+		require.NoError(t, err)
+		assert.True(t, bound.Matches(&Task{DueDate: util.Ptr(now.Add(time.Hour)), Assignees: []string{"alice"}}))
+		assert.False(t, bound.Matches(&Task{DueDate: util.Ptr(now.Add(25 * time.Hour)), Assignees: []string{"alice"}}))
+		assert.False(t, bound.Matches(&Task{DueDate: util.Ptr(now.Add(time.Hour)), Assignees: []string{"bob"}}))
+		assert.Equal(t, expression.String(), bound.String())
+	})
+
+	t.Run("should return error when me is used without user", func(t *testing.T) {
+		expression, err := ParseExpression(`owner = me or owner = "me"`)
+		require.NoError(t, err)
+
+		bound, err := expression.bind(context.Background(), now)
+
+		assert.ErrorIs(t, err, ErrInvalidExpression)
+		assert.EqualError(t, err, "invalid filter expression: me requires an authenticated user at position 1")
+		assert.Nil(t, bound)
+	})
+}
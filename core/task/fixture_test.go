@@ -0,0 +1,51 @@
+package task
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/pkg/idgen"
+	"github.com/utsabbera/task-master/pkg/util"
+	"go.uber.org/mock/gomock"
+)
+
+// fixture is a task service on memory repositories, shared by the tests of the services built on it
+type fixture struct {
+	service  Service
+	projects ProjectService
+	clock    util.Clock
+}
+
+// newFixture returns a task service on memory repositories and a ticking clock, with the given tasks created in order
+func newFixture(t *testing.T, tasks ...*Task) fixture {
+	t.Helper()
+
+	clock := newTickingClock(gomock.NewController(t))
+	repo := NewMemoryRepository()
+	projectRepo := NewMemoryProjectRepository()
+	f := fixture{
+		service:  NewServiceWithProjects(repo, idgen.NewSequential("TASK-", 1, 6), NewProjects(projectRepo), clock),
+		projects: NewProjectService(projectRepo, repo, clock),
+		clock:    clock,
+	}
+
+	for _, task := range tasks {
+		require.NoError(t, f.service.Create(context.Background(), task))
+	}
+
+	return f
+}
+
+// newTickingClock returns a clock advancing by a second every time it is read
+func newTickingClock(ctrl *gomock.Controller) util.Clock {
+	clock := util.NewMockClock(ctrl)
+	now := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+	clock.EXPECT().Now().DoAndReturn(func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}).AnyTimes()
+
+	return clock
+}
@@ -0,0 +1,396 @@
+package task
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	icalProductID    = "-//Task Master//Tasks//EN"
+	icalTimeLayout   = "20060102T150405Z"
+	icalLocalLayout  = "20060102T150405"
+	icalDateLayout   = "20060102"
+	icalMaxLineBytes = 75
+)
+
+// icalStatuses maps the statuses of the tasks to the statuses of the VTODO components
+var icalStatuses = map[Status]string{
+	StatusNotStarted: "NEEDS-ACTION",
+	StatusInProgress: "IN-PROCESS",
+	StatusBlocked:    "NEEDS-ACTION",
+	StatusCompleted:  "COMPLETED",
+	StatusCancelled:  "CANCELLED",
+}
+
+// icalPriorities maps the priorities of the tasks to the highest, middle and lowest priority of the VTODO components
+var icalPriorities = map[Priority]int{
+	PriorityHigh:   1,
+	PriorityMedium: 5,
+	PriorityLow:    9,
+}
+
+// icalCodec encodes a task per VTODO component of an iCalendar file as defined by RFC 5545, its UID being the ID
+// of the task and its parent being related to it. The other components of an import are ignored
+type icalCodec struct{}
+
+func (icalCodec) encode(w io.Writer, tasks []*Task) error {
+	writer := bufio.NewWriter(w)
+	line := func(name, value string) {
+		_, _ = writer.WriteString(foldLine(name+":"+value) + "\r\n")
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", icalProductID)
+	line("CALSCALE", "GREGORIAN")
+	for _, task := range tasks {
+		line("BEGIN", "VTODO")
+		line("UID", escapeText(task.ID))
+		line("DTSTAMP", formatICalTime(task.UpdatedAt))
+		line("CREATED", formatICalTime(task.CreatedAt))
+		line("LAST-MODIFIED", formatICalTime(task.UpdatedAt))
+		line("SUMMARY", escapeText(task.Title))
+		if task.Description != "" {
+			line("DESCRIPTION", escapeText(task.Description))
+		}
+		line("STATUS", icalStatuses[task.Status])
+		if task.Priority != nil {
+			line("PRIORITY", strconv.Itoa(icalPriorities[*task.Priority]))
+		}
+		if task.DueDate != nil {
+			if task.Recurrence != nil {
+				line("DTSTART", formatICalTime(*task.DueDate))
+			}
+			line("DUE", formatICalTime(*task.DueDate))
+		}
+		if task.CompletedAt != nil {
+			line("COMPLETED", formatICalTime(*task.CompletedAt))
+		}
+		if len(task.Tags) > 0 {
+			categories := make([]string, 0, len(task.Tags))
+			for _, tag := range task.Tags {
+				categories = append(categories, escapeText(tag))
+			}
+			line("CATEGORIES", strings.Join(categories, ","))
+		}
+		if task.ParentID != nil {
+			line("RELATED-TO;RELTYPE=PARENT", escapeText(*task.ParentID))
+		}
+		if task.Recurrence != nil {
+			line("RRULE", task.Recurrence.String())
+		}
+		line("END", "VTODO")
+	}
+	line("END", "VCALENDAR")
+
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("error writing tasks: %w", err)
+	}
+
+	return nil
+}
+
+func (icalCodec) decode(r io.Reader) ([]ImportRow, error) {
+	lines, err := unfoldLines(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidImport, err)
+	}
+
+	var (
+		rows       []ImportRow
+		components []string
+		todo       *icalTodo
+	)
+	for _, l := range lines {
+		name, params, value, err := parseContentLine(l.text)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidImport, l.number, err)
+		}
+
+		switch {
+		case name == "BEGIN":
+			components = append(components, strings.ToUpper(value))
+			if len(components) == 2 && components[0] == "VCALENDAR" && components[1] == "VTODO" {
+				todo = &icalTodo{line: l.number}
+			}
+		case name == "END":
+			if len(components) == 0 || components[len(components)-1] != strings.ToUpper(value) {
+				return nil, fmt.Errorf("%w: line %d: unexpected END:%s", ErrInvalidImport, l.number, value)
+			}
+			components = components[:len(components)-1]
+			if todo != nil && len(components) == 1 {
+				rows = append(rows, todo.row())
+				todo = nil
+			}
+		case todo != nil && len(components) == 2:
+			todo.set(name, params, value)
+		}
+	}
+
+	if len(components) > 0 {
+		return nil, fmt.Errorf("%w: missing END:%s", ErrInvalidImport, components[len(components)-1])
+	}
+
+	return rows, nil
+}
+
+// icalTodo collects the properties of a VTODO component
+type icalTodo struct {
+	line int
+	rec  record
+	err  error
+}
+
+func (t *icalTodo) set(name string, params map[string]string, value string) {
+	switch name {
+	case "UID":
+		t.rec.ID = unescapeText(value)
+	case "SUMMARY":
+		t.rec.Title = unescapeText(value)
+	case "DESCRIPTION":
+		t.rec.Description = unescapeText(value)
+	case "STATUS":
+		t.rec.Status = parseICalStatus(value)
+	case "PRIORITY":
+		t.rec.Priority = parseICalPriority(value)
+	case "DUE":
+		due, err := parseICalTime(value, params)
+		if err != nil {
+			t.err = errors.Join(t.err, fmt.Errorf("invalid DUE: %w", err))
+			return
+		}
+		t.rec.DueDate = due.Format(time.RFC3339)
+	case "CATEGORIES":
+		for _, category := range splitText(value) {
+			t.rec.Tags = append(t.rec.Tags, unescapeText(category))
+		}
+	case "RELATED-TO":
+		if reltype := params["RELTYPE"]; reltype == "" || strings.EqualFold(reltype, "PARENT") {
+			t.rec.ParentID = unescapeText(value)
+		}
+	case "RRULE":
+		t.rec.Recurrence = value
+	}
+}
+
+func (t *icalTodo) row() ImportRow {
+	if t.err != nil {
+		return ImportRow{Line: t.line, Ref: strings.TrimSpace(t.rec.ID), Err: t.err}
+	}
+
+	return t.rec.row(t.line)
+}
+
+// parseICalStatus returns the status of a VTODO status, or the status as is when it is unknown
+func parseICalStatus(value string) string {
+	switch strings.ToUpper(strings.TrimSpace(value)) {
+	case "NEEDS-ACTION":
+		return string(StatusNotStarted)
+	case "IN-PROCESS":
+		return string(StatusInProgress)
+	case "COMPLETED":
+		return string(StatusCompleted)
+	case "CANCELLED":
+		return string(StatusCancelled)
+	default:
+		return value
+	}
+}
+
+// parseICalPriority returns the priority of a VTODO priority from 1 the highest to 9 the lowest, empty for 0
+// which is undefined, or the priority as is when it is out of range
+func parseICalPriority(value string) string {
+	priority, err := strconv.Atoi(strings.TrimSpace(value))
+	switch {
+	case err != nil || priority < 0 || priority > 9:
+		return value
+	case priority == 0:
+		return ""
+	case priority < 5:
+		return string(PriorityHigh)
+	case priority == 5:
+		return string(PriorityMedium)
+	default:
+		return string(PriorityLow)
+	}
+}
+
+// parseICalTime parses a date, a UTC time or a local time in the time zone of the TZID parameter,
+// a floating time or a time in an unknown time zone being read as UTC
+func parseICalTime(value string, params map[string]string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if strings.EqualFold(params["VALUE"], "DATE") || len(value) == len(icalDateLayout) {
+		return time.Parse(icalDateLayout, value)
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		return time.Parse(icalTimeLayout, value)
+	}
+
+	location := time.UTC
+	if tzid := params["TZID"]; tzid != "" {
+		if loc, err := time.LoadLocation(tzid); err == nil {
+			location = loc
+		}
+	}
+
+	t, err := time.ParseInLocation(icalLocalLayout, value, location)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return t.UTC(), nil
+}
+
+func formatICalTime(t time.Time) string {
+	return t.UTC().Format(icalTimeLayout)
+}
+
+type icalLine struct {
+	number int
+	text   string
+}
+
+// unfoldLines reads the content lines of an iCalendar file, joining the folded lines starting with a space or a tab
+// to the line they continue and skipping the blank lines
+func unfoldLines(r io.Reader) ([]icalLine, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLineSize)
+
+	var lines []icalLine
+	for number := 1; scanner.Scan(); number++ {
+		text := strings.TrimSuffix(scanner.Text(), "\r")
+		if number == 1 {
+			text = strings.TrimPrefix(text, "\ufeff")
+		}
+
+		switch {
+		case text == "":
+		case (text[0] == ' ' || text[0] == '\t') && len(lines) > 0:
+			lines[len(lines)-1].text += text[1:]
+		default:
+			lines = append(lines, icalLine{number: number, text: text})
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return lines, nil
+}
+
+// foldLine splits a content line longer than 75 octets into lines continued by a leading space,
+// without splitting its UTF-8 characters
+func foldLine(line string) string {
+	var b strings.Builder
+	limit := icalMaxLineBytes
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = icalMaxLineBytes - 1
+	}
+	b.WriteString(line)
+
+	return b.String()
+}
+
+// parseContentLine parses a content line such as DUE;TZID=Europe/Paris:20250502T170000 into its upper case name,
+// its parameters keyed by their upper case names and its value
+func parseContentLine(line string) (string, map[string]string, string, error) {
+	var (
+		params map[string]string
+		quoted bool
+		start  int
+		name   string
+	)
+
+	flush := func(end int) {
+		part := line[start:end]
+		if name == "" {
+			name = strings.ToUpper(part)
+			return
+		}
+
+		key, value, _ := strings.Cut(part, "=")
+		if params == nil {
+			params = make(map[string]string)
+		}
+		params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+	}
+
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == ';':
+			flush(i)
+			start = i + 1
+		case c == ':':
+			flush(i)
+			if name == "" {
+				return "", nil, "", fmt.Errorf("missing property name in %q", line)
+			}
+			return name, params, line[i+1:], nil
+		}
+	}
+
+	return "", nil, "", fmt.Errorf("missing value in %q", line)
+}
+
+// escapeText escapes the backslashes, semicolons, commas and line breaks of a text value
+func escapeText(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(value)
+}
+
+// unescapeText reverts escapeText
+func unescapeText(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i == len(value)-1 {
+			b.WriteByte(value[i])
+			continue
+		}
+
+		i++
+		switch value[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(value[i])
+		}
+	}
+
+	return b.String()
+}
+
+// splitText splits a list of text values on the commas which are not escaped
+func splitText(value string) []string {
+	var (
+		items []string
+		start int
+	)
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			i++
+		case ',':
+			items = append(items, value[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(items, value[start:])
+}
@@ -0,0 +1,168 @@
+package task
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/pkg/util"
+)
+
+func TestICalCodec(t *testing.T) {
+	created := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+	updated := time.Date(2025, 5, 1, 10, 30, 0, 0, time.UTC)
+	due := time.Date(2025, 5, 2, 17, 0, 0, 0, time.UTC)
+
+	t.Run("should encode a VTODO component per task", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		err := icalCodec{}.encode(&buf, []*Task{
+			{
+				ID: "TASK-000001", Title: "Write report; draft, then final", Description: "Numbers\nfor Q2", Status: StatusInProgress,
+				Priority: util.Ptr(PriorityHigh), DueDate: &due, Tags: []string{"docs", "work"},
+				Recurrence: &Recurrence{Frequency: FrequencyWeekly}, CreatedAt: created, UpdatedAt: updated,
+			},
+			{
+				ID: "TASK-000002", Title: "Draft outline", Status: StatusCompleted, Priority: util.Ptr(PriorityLow),
+				ParentID: util.Ptr("TASK-000001"), CompletedAt: &updated, CreatedAt: created, UpdatedAt: updated,
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"VERSION:2.0",
+			"PRODID:-//Task Master//Tasks//EN",
+			"CALSCALE:GREGORIAN",
+			"BEGIN:VTODO",
+			"UID:TASK-000001",
+			"DTSTAMP:20250501T103000Z",
+			"CREATED:20250501T090000Z",
+			"LAST-MODIFIED:20250501T103000Z",
+			`SUMMARY:Write report\; draft\, then final`,
+			`DESCRIPTION:Numbers\nfor Q2`,
+			"STATUS:IN-PROCESS",
+			"PRIORITY:1",
+			"DTSTART:20250502T170000Z",
+			"DUE:20250502T170000Z",
+			"CATEGORIES:docs,work",
+			"RRULE:FREQ=WEEKLY",
+			"END:VTODO",
+			"BEGIN:VTODO",
+			"UID:TASK-000002",
+			"DTSTAMP:20250501T103000Z",
+			"CREATED:20250501T090000Z",
+			"LAST-MODIFIED:20250501T103000Z",
+			"SUMMARY:Draft outline",
+			"STATUS:COMPLETED",
+			"PRIORITY:9",
+			"COMPLETED:20250501T103000Z",
+			"RELATED-TO;RELTYPE=PARENT:TASK-000001",
+			"END:VTODO",
+			"END:VCALENDAR",
+			"",
+		}, "\r\n"), buf.String())
+	})
+
+	t.Run("should fold long lines without splitting characters", func(t *testing.T) {
+		var buf bytes.Buffer
+		title := strings.Repeat("é", 80)
+
+		require.NoError(t, icalCodec{}.encode(&buf, []*Task{{ID: "TASK-1", Title: title, Status: StatusNotStarted}}))
+
+		for line := range strings.SplitSeq(buf.String(), "\r\n") {
+			assert.LessOrEqual(t, len(line), 75)
+		}
+		rows, err := icalCodec{}.decode(&buf)
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Equal(t, title, rows[0].Task.Title)
+	})
+
+	t.Run("should decode VTODO components ignoring other components", func(t *testing.T) {
+		input := strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"VERSION:2.0",
+			"BEGIN:VEVENT",
+			"UID:meeting",
+			"SUMMARY:Weekly meeting",
+			"END:VEVENT",
+			"BEGIN:VTODO",
+			"UID:report@example.com",
+			"SUMMARY:Write quarterly",
+			"  report",
+			`DESCRIPTION:Numbers\, charts\nand notes`,
+			"STATUS:NEEDS-ACTION",
+			"PRIORITY:3",
+			"DUE;TZID=Europe/Paris:20250502T190000",
+			"CATEGORIES:Work,docs",
+			"RRULE:FREQ=MONTHLY;COUNT=3",
+			"BEGIN:VALARM",
+			"ACTION:DISPLAY",
+			"DESCRIPTION:Reminder",
+			"END:VALARM",
+			"END:VTODO",
+			"BEGIN:VTODO",
+			"UID:outline",
+			"SUMMARY:Draft outline",
+			"STATUS:IN-PROCESS",
+			"PRIORITY:0",
+			"DUE;VALUE=DATE:20250501",
+			"RELATED-TO:report@example.com",
+			"RELATED-TO;RELTYPE=SIBLING:meeting",
+			"END:VTODO",
+			"BEGIN:VTODO",
+			"UID:invalid",
+			"SUMMARY:Review report",
+			"DUE:tomorrow",
+			"END:VTODO",
+			"BEGIN:VTODO",
+			"SUMMARY:Archive report",
+			"STATUS:DONE",
+			"END:VTODO",
+			"END:VCALENDAR",
+		}, "\r\n")
+
+		rows, err := icalCodec{}.decode(strings.NewReader(input))
+
+		require.NoError(t, err)
+		require.Len(t, rows, 4)
+		assert.Equal(t, ImportRow{Line: 7, Ref: "report@example.com", Task: &Task{
+			Title: "Write quarterly report", Description: "Numbers, charts\nand notes", Status: StatusNotStarted,
+			Priority: util.Ptr(PriorityHigh), DueDate: &due, Tags: []string{"docs", "work"},
+			Recurrence: &Recurrence{Frequency: FrequencyMonthly, Count: 3},
+		}}, rows[0])
+		assert.Equal(t, ImportRow{Line: 22, Ref: "outline", Task: &Task{
+			Title: "Draft outline", Status: StatusInProgress, DueDate: util.Ptr(time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)),
+			ParentID: util.Ptr("report@example.com"),
+		}}, rows[1])
+		assert.Equal(t, 31, rows[2].Line)
+		assert.ErrorContains(t, rows[2].Err, "invalid DUE")
+		assert.Equal(t, 36, rows[3].Line)
+		assert.ErrorIs(t, rows[3].Err, ErrInvalidStatus)
+	})
+
+	t.Run("should return error when components are not closed", func(t *testing.T) {
+		for _, input := range []string{
+			"BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nSUMMARY:Write report\r\nEND:VCALENDAR\r\n",
+			"BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nSUMMARY:Write report\r\n",
+			"BEGIN:VCALENDAR\r\nSUMMARY\r\nEND:VCALENDAR\r\n",
+		} {
+			_, err := icalCodec{}.decode(strings.NewReader(input))
+			assert.ErrorIs(t, err, ErrInvalidImport)
+		}
+	})
+}
+
+func TestParseICalPriority(t *testing.T) {
+	t.Run("should map the priorities from 1 to 9 to high, medium and low", func(t *testing.T) {
+		for value, expected := range map[string]string{
+			"0": "", "1": "HIGH", "4": "HIGH", "5": "MEDIUM", "6": "LOW", "9": "LOW", "10": "10", "x": "x",
+		} {
+			assert.Equal(t, expected, parseICalPriority(value), value)
+		}
+	})
+}
@@ -0,0 +1,111 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+var (
+	// ErrInvalidStatus is returned when a task is given a status which is not part of the lifecycle
+	ErrInvalidStatus = errors.New("invalid status")
+	// ErrInvalidTransition is returned when a task cannot move from its current status to the requested one
+	ErrInvalidTransition = errors.New("invalid status transition")
+)
+
+// TransitionError describes a status change rejected by the task lifecycle, it matches ErrInvalidTransition
+type TransitionError struct {
+	// From is the current status of the task
+	From Status
+	// To is the requested status
+	To Status
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("%s from %s to %s, allowed: %v", ErrInvalidTransition, e.From, e.To, e.From.Transitions())
+}
+
+func (e *TransitionError) Unwrap() error {
+	return ErrInvalidTransition
+}
+
+var statuses = []Status{StatusNotStarted, StatusInProgress, StatusBlocked, StatusCompleted, StatusCancelled}
+
+var transitions = map[Status][]Status{
+	StatusNotStarted: {StatusInProgress, StatusBlocked, StatusCancelled},
+	StatusInProgress: {StatusNotStarted, StatusBlocked, StatusCompleted, StatusCancelled},
+	StatusBlocked:    {StatusNotStarted, StatusInProgress, StatusCancelled},
+	StatusCompleted:  {StatusInProgress},
+	StatusCancelled:  {StatusNotStarted},
+}
+
+// Statuses returns every status of the task lifecycle
+func Statuses() []Status {
+	return slices.Clone(statuses)
+}
+
+// Valid reports whether the status is part of the task lifecycle
+func (s Status) Valid() bool {
+	return slices.Contains(statuses, s)
+}
+
+// Transitions returns the statuses a task can move to from this status
+func (s Status) Transitions() []Status {
+	return slices.Clone(transitions[s])
+}
+
+// CanTransitionTo reports whether a task can move from this status to the given one
+func (s Status) CanTransitionTo(to Status) bool {
+	return slices.Contains(transitions[s], to)
+}
+
+func (t *Task) initStatus(now time.Time) error {
+	if t.Status == "" {
+		t.Status = StatusNotStarted
+	}
+	if !t.Status.Valid() {
+		return fmt.Errorf("%w: %q", ErrInvalidStatus, t.Status)
+	}
+
+	t.StartedAt = nil
+	t.CompletedAt = nil
+	t.stamp(now)
+	return nil
+}
+
+func (t *Task) transition(to Status, now time.Time) error {
+	if !to.Valid() {
+		return fmt.Errorf("%w: %q", ErrInvalidStatus, to)
+	}
+	if t.Status == to {
+		return nil
+	}
+	if !t.Status.CanTransitionTo(to) {
+		return &TransitionError{From: t.Status, To: to}
+	}
+
+	t.Status = to
+	t.stamp(now)
+	return nil
+}
+
+func (t *Task) stamp(now time.Time) {
+	switch t.Status {
+	case StatusNotStarted:
+		t.StartedAt = nil
+		t.CompletedAt = nil
+	case StatusInProgress:
+		if t.StartedAt == nil {
+			t.StartedAt = &now
+		}
+		t.CompletedAt = nil
+	case StatusBlocked, StatusCancelled:
+		t.CompletedAt = nil
+	case StatusCompleted:
+		if t.StartedAt == nil {
+			t.StartedAt = &now
+		}
+		t.CompletedAt = &now
+	}
+}
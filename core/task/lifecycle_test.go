@@ -0,0 +1,102 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatus_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from Status
+		to   Status
+		want bool
+	}{
+		{StatusNotStarted, StatusInProgress, true},
+		{StatusNotStarted, StatusBlocked, true},
+		{StatusNotStarted, StatusCancelled, true},
+		{StatusNotStarted, StatusCompleted, false},
+		{StatusInProgress, StatusCompleted, true},
+		{StatusInProgress, StatusNotStarted, true},
+		{StatusBlocked, StatusInProgress, true},
+		{StatusBlocked, StatusCompleted, false},
+		{StatusCompleted, StatusInProgress, true},
+		{StatusCompleted, StatusCancelled, false},
+		{StatusCancelled, StatusNotStarted, true},
+		{StatusCancelled, StatusInProgress, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+" to "+string(tt.to), func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.from.CanTransitionTo(tt.to))
+		})
+	}
+}
+
+func TestStatus_Valid(t *testing.T) {
+	for _, status := range Statuses() {
+		assert.True(t, status.Valid(), status)
+	}
+
+	assert.False(t, Status("DONE").Valid())
+	assert.False(t, Status("").Valid())
+}
+
+func TestTask_transition(t *testing.T) {
+	start := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	reopen := end.Add(time.Hour)
+
+	t.Run("should record start and completion times", func(t *testing.T) {
+		task := &Task{Status: StatusNotStarted}
+
+		assert.NoError(t, task.transition(StatusInProgress, start))
+		assert.Equal(t, &start, task.StartedAt)
+		assert.Nil(t, task.CompletedAt)
+
+		assert.NoError(t, task.transition(StatusCompleted, end))
+		assert.Equal(t, &start, task.StartedAt)
+		assert.Equal(t, &end, task.CompletedAt)
+	})
+
+	t.Run("should clear completion time when reopened", func(t *testing.T) {
+		task := &Task{Status: StatusCompleted, StartedAt: &start, CompletedAt: &end}
+
+		assert.NoError(t, task.transition(StatusInProgress, reopen))
+		assert.Equal(t, StatusInProgress, task.Status)
+		assert.Equal(t, &start, task.StartedAt)
+		assert.Nil(t, task.CompletedAt)
+	})
+
+	t.Run("should clear times when moved back to not started", func(t *testing.T) {
+		task := &Task{Status: StatusCancelled, StartedAt: &start}
+
+		assert.NoError(t, task.transition(StatusNotStarted, reopen))
+		assert.Nil(t, task.StartedAt)
+		assert.Nil(t, task.CompletedAt)
+	})
+
+	t.Run("should keep task unchanged when status is the same", func(t *testing.T) {
+		task := &Task{Status: StatusCompleted, StartedAt: &start, CompletedAt: &end}
+
+		assert.NoError(t, task.transition(StatusCompleted, reopen))
+		assert.Equal(t, &end, task.CompletedAt)
+	})
+
+	t.Run("should return transition error when not allowed", func(t *testing.T) {
+		task := &Task{Status: StatusCancelled}
+
+		err := task.transition(StatusCompleted, end)
+
+		assert.ErrorIs(t, err, ErrInvalidTransition)
+		assert.EqualError(t, err, "invalid status transition from CANCELLED to COMPLETED, allowed: [NOT_STARTED]")
+		assert.Equal(t, StatusCancelled, task.Status)
+	})
+
+	t.Run("should return error when status is unknown", func(t *testing.T) {
+		task := &Task{Status: StatusNotStarted}
+
+		assert.ErrorIs(t, task.transition("DONE", end), ErrInvalidStatus)
+	})
+}
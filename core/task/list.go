@@ -0,0 +1,409 @@
+package task
+
+import (
+	"cmp"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/utsabbera/task-master/pkg/util"
+)
+
+// ErrInvalidListOptions is returned when the filter, sort or pagination options of a list are invalid
+var ErrInvalidListOptions = errors.New("invalid list options")
+
+// SortField identifies a field tasks can be sorted by
+type SortField string
+
+const (
+	// SortByCreatedAt sorts tasks by creation time, it is the default order
+	SortByCreatedAt SortField = "createdAt"
+	// SortByUpdatedAt sorts tasks by last modification time
+	SortByUpdatedAt SortField = "updatedAt"
+	// SortByDueDate sorts tasks by deadline, tasks without due date come last
+	SortByDueDate SortField = "dueDate"
+	// SortByPriority sorts tasks from low to high priority, tasks without priority come last
+	SortByPriority SortField = "priority"
+	// SortByTitle sorts tasks by title
+	SortByTitle SortField = "title"
+)
+
+var sortFields = []SortField{SortByCreatedAt, SortByUpdatedAt, SortByDueDate, SortByPriority, SortByTitle}
+
+// Sort orders tasks by a single field
+type Sort struct {
+	// Field is the field to sort by
+	Field SortField
+	// Descending reverses the order of the field
+	Descending bool
+}
+
+// ParseSort parses a comma separated list of sort fields, where a leading "-" sorts the field in
+// descending order, e.g. "-priority,dueDate"
+func ParseSort(value string) ([]Sort, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	fields := strings.Split(value, ",")
+	sorts := make([]Sort, 0, len(fields))
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		name, descending := strings.CutPrefix(field, "-")
+
+		sort := Sort{Field: SortField(name), Descending: descending}
+		if !slices.Contains(sortFields, sort.Field) {
+			return nil, fmt.Errorf("%w: unknown sort field %q", ErrInvalidListOptions, name)
+		}
+
+		sorts = append(sorts, sort)
+	}
+
+	return sorts, nil
+}
+
+// Filter restricts the tasks returned by a list, zero fields match every task
+type Filter struct {
+	// IDs matches tasks having any of the given IDs
+	IDs []string
+	// Projects matches tasks of any of the given projects
+	Projects []string
+	// Statuses matches tasks with any of the given statuses
+	Statuses []Status
+	// Priorities matches tasks with any of the given priorities
+	Priorities []Priority
+	// DueBefore matches tasks due strictly before the given time
+	DueBefore *time.Time
+	// DueAfter matches tasks due strictly after the given time
+	DueAfter *time.Time
+	// HasDueDate matches tasks having a due date
+	HasDueDate bool
+	// Query matches tasks whose title or description contains the given text, ignoring case
+	Query string
+	// Tags matches tasks having every given normalized tag
+	Tags []string
+	// ExcludedTags matches tasks having none of the given normalized tags
+	ExcludedTags []string
+	// Parents matches subtasks of any of the given tasks
+	Parents []string
+	// Blockers matches tasks blocked by any of the given tasks
+	Blockers []string
+	// Owners matches tasks owned by any of the given users
+	Owners []string
+	// Assignees matches tasks assigned to any of the given users
+	Assignees []string
+	// Expression matches tasks satisfying the filter expression
+	Expression *Expression
+}
+
+// Matches reports whether the task satisfies every condition of the filter
+func (f Filter) Matches(t *Task) bool {
+	if len(f.IDs) > 0 && !slices.Contains(f.IDs, t.ID) {
+		return false
+	}
+
+	if len(f.Projects) > 0 && !slices.Contains(f.Projects, t.Project) {
+		return false
+	}
+
+	if len(f.Statuses) > 0 && !slices.Contains(f.Statuses, t.Status) {
+		return false
+	}
+
+	if len(f.Priorities) > 0 && (t.Priority == nil || !slices.Contains(f.Priorities, *t.Priority)) {
+		return false
+	}
+
+	if f.DueBefore != nil && (t.DueDate == nil || !t.DueDate.Before(*f.DueBefore)) {
+		return false
+	}
+
+	if f.DueAfter != nil && (t.DueDate == nil || !t.DueDate.After(*f.DueAfter)) {
+		return false
+	}
+
+	if f.HasDueDate && t.DueDate == nil {
+		return false
+	}
+
+	if len(f.Parents) > 0 && (t.ParentID == nil || !slices.Contains(f.Parents, *t.ParentID)) {
+		return false
+	}
+
+	if len(f.Blockers) > 0 && !slices.ContainsFunc(f.Blockers, func(id string) bool { return slices.Contains(t.BlockedBy, id) }) {
+		return false
+	}
+
+	if len(f.Owners) > 0 && !slices.Contains(f.Owners, t.OwnerID) {
+		return false
+	}
+
+	if len(f.Assignees) > 0 && !slices.ContainsFunc(f.Assignees, func(id string) bool { return slices.Contains(t.Assignees, id) }) {
+		return false
+	}
+
+	for _, tag := range f.Tags {
+		if !t.HasTag(tag) {
+			return false
+		}
+	}
+
+	for _, tag := range f.ExcludedTags {
+		if t.HasTag(tag) {
+			return false
+		}
+	}
+
+	if f.Query != "" {
+		query := strings.ToLower(f.Query)
+		if !strings.Contains(strings.ToLower(t.Title), query) && !strings.Contains(strings.ToLower(t.Description), query) {
+			return false
+		}
+	}
+
+	if f.Expression != nil && !f.Expression.Matches(t) {
+		return false
+	}
+
+	return true
+}
+
+// bind resolves the times of the expression of the filter against the current time and me against the user of the context
+func (f Filter) bind(ctx context.Context, clock util.Clock) (Filter, error) {
+	if f.Expression == nil {
+		return f, nil
+	}
+
+	expression, err := f.Expression.bind(ctx, clock.Now())
+	if err != nil {
+		return f, err
+	}
+
+	f.Expression = expression
+	return f, nil
+}
+
+// ListOptions controls which tasks a list returns and in which order
+type ListOptions struct {
+	// Filter restricts the returned tasks
+	Filter Filter
+	// Sort orders the returned tasks, ties are broken by creation time and then by ID
+	Sort []Sort
+	// Limit is the maximum number of tasks in a page, zero means no limit
+	Limit int
+	// Cursor is the NextCursor of the previous page, empty for the first page
+	Cursor string
+}
+
+// Validate returns ErrInvalidListOptions if the options are invalid
+func (o ListOptions) Validate() error {
+	if o.Limit < 0 {
+		return fmt.Errorf("%w: limit must not be negative", ErrInvalidListOptions)
+	}
+
+	for _, status := range o.Filter.Statuses {
+		if !status.Valid() {
+			return fmt.Errorf("%w: unknown status %q", ErrInvalidListOptions, status)
+		}
+	}
+
+	for _, priority := range o.Filter.Priorities {
+		if !priority.Valid() {
+			return fmt.Errorf("%w: unknown priority %q", ErrInvalidListOptions, priority)
+		}
+	}
+
+	for _, tag := range slices.Concat(o.Filter.Tags, o.Filter.ExcludedTags) {
+		if normalized, err := NormalizeTag(tag); err != nil || normalized != tag {
+			return fmt.Errorf("%w: tag %q is not normalized", ErrInvalidListOptions, tag)
+		}
+	}
+
+	for _, sort := range o.Sort {
+		if !slices.Contains(sortFields, sort.Field) {
+			return fmt.Errorf("%w: unknown sort field %q", ErrInvalidListOptions, sort.Field)
+		}
+	}
+
+	if _, err := decodeCursor(o.Cursor); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o ListOptions) compare(a, b *Task) int {
+	for _, sort := range o.Sort {
+		if c := compareField(a, b, sort); c != 0 {
+			return c
+		}
+	}
+
+	return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), strings.Compare(a.ID, b.ID))
+}
+
+func compareField(a, b *Task, sort Sort) int {
+	var c int
+
+	switch sort.Field {
+	case SortByCreatedAt:
+		c = a.CreatedAt.Compare(b.CreatedAt)
+	case SortByUpdatedAt:
+		c = a.UpdatedAt.Compare(b.UpdatedAt)
+	case SortByTitle:
+		c = strings.Compare(a.Title, b.Title)
+	case SortByDueDate:
+		if a.DueDate == nil || b.DueDate == nil {
+			return compareMissing(a.DueDate == nil, b.DueDate == nil)
+		}
+		c = a.DueDate.Compare(*b.DueDate)
+	case SortByPriority:
+		if a.Priority == nil || b.Priority == nil {
+			return compareMissing(a.Priority == nil, b.Priority == nil)
+		}
+		c = cmp.Compare(priorityRank(*a.Priority), priorityRank(*b.Priority))
+	}
+
+	if sort.Descending {
+		return -c
+	}
+
+	return c
+}
+
+func compareMissing(aMissing, bMissing bool) int {
+	switch {
+	case aMissing && bMissing:
+		return 0
+	case aMissing:
+		return 1
+	default:
+		return -1
+	}
+}
+
+func priorityRank(p Priority) int {
+	switch p {
+	case PriorityLow:
+		return 1
+	case PriorityMedium:
+		return 2
+	case PriorityHigh:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// Page is a single page of a task list
+type Page struct {
+	// Tasks are the tasks of the page
+	Tasks []*Task
+	// Total is the number of tasks matching the filter across all pages
+	Total int
+	// NextCursor is the cursor of the following page, empty on the last page
+	NextCursor string
+}
+
+// newPage returns the page of the given tasks out of total, with the cursor of the following page when more tasks follow them
+func newPage(tasks []*Task, total int, more bool, sorts []Sort) *Page {
+	page := &Page{Tasks: tasks, Total: total}
+	if more && len(tasks) > 0 {
+		page.NextCursor = encodeCursor(newCursor(tasks[len(tasks)-1], sorts))
+	}
+
+	return page
+}
+
+// paginate returns the page of at most limit of the sorted tasks coming after the cursor
+func paginate(tasks []*Task, opts ListOptions, after *cursor) *Page {
+	start := 0
+	if after != nil {
+		last := after.task()
+		start, _ = slices.BinarySearchFunc(tasks, last, func(t, last *Task) int {
+			if opts.compare(t, last) <= 0 {
+				return -1
+			}
+			return 1
+		})
+	}
+
+	end := len(tasks)
+	if opts.Limit > 0 {
+		end = min(start+opts.Limit, len(tasks))
+	}
+
+	return newPage(tasks[start:end], len(tasks), end < len(tasks), opts.Sort)
+}
+
+// cursor is the position of the last task of a page, holding the values it is sorted by.
+// The following page starts with the first task sorted after it
+type cursor struct {
+	ID        string     `json:"id"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+	Title     *string    `json:"title,omitempty"`
+	DueDate   *time.Time `json:"dueDate,omitempty"`
+	Priority  *Priority  `json:"priority,omitempty"`
+}
+
+// newCursor returns the position of the task in the order of the given sort
+func newCursor(t *Task, sorts []Sort) cursor {
+	c := cursor{ID: t.ID, CreatedAt: t.CreatedAt}
+	for _, sort := range sorts {
+		switch sort.Field {
+		case SortByUpdatedAt:
+			c.UpdatedAt = &t.UpdatedAt
+		case SortByTitle:
+			c.Title = &t.Title
+		case SortByDueDate:
+			c.DueDate = t.DueDate
+		case SortByPriority:
+			c.Priority = t.Priority
+		}
+	}
+
+	return c
+}
+
+// task returns a task holding the values of the cursor, to be compared with the listed tasks
+func (c cursor) task() *Task {
+	t := &Task{ID: c.ID, CreatedAt: c.CreatedAt, DueDate: c.DueDate, Priority: c.Priority}
+	if c.UpdatedAt != nil {
+		t.UpdatedAt = *c.UpdatedAt
+	}
+	if c.Title != nil {
+		t.Title = *c.Title
+	}
+
+	return t
+}
+
+func encodeCursor(c cursor) string {
+	value, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(value)
+}
+
+// decodeCursor returns the position a cursor holds, nil for the empty cursor of the first page
+func decodeCursor(value string) (*cursor, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidListOptions)
+	}
+
+	var c cursor
+	if err := json.Unmarshal(decoded, &c); err != nil || c.ID == "" {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidListOptions)
+	}
+
+	return &c, nil
+}
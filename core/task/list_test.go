@@ -0,0 +1,119 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/pkg/util"
+)
+
+func TestParseSort(t *testing.T) {
+	t.Run("should parse ascending and descending fields", func(t *testing.T) {
+		sort, err := ParseSort("-priority, dueDate")
+
+		require.NoError(t, err)
+		assert.Equal(t, []Sort{
+			{Field: SortByPriority, Descending: true},
+			{Field: SortByDueDate},
+		}, sort)
+	})
+
+	t.Run("should return nil for empty value", func(t *testing.T) {
+		sort, err := ParseSort("")
+
+		require.NoError(t, err)
+		assert.Nil(t, sort)
+	})
+
+	t.Run("should return error for unknown field", func(t *testing.T) {
+		sort, err := ParseSort("dueDate,-color")
+
+		assert.ErrorIs(t, err, ErrInvalidListOptions)
+		assert.Nil(t, sort)
+	})
+}
+
+func TestFilter_Matches(t *testing.T) {
+	due := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+	task := &Task{
+		Title:       "Quarterly Report",
+		Description: "Numbers for Q2",
+		Status:      StatusInProgress,
+		Priority:    util.Ptr(PriorityHigh),
+		DueDate:     &due,
+	}
+
+	t.Run("should match every task with empty filter", func(t *testing.T) {
+		assert.True(t, Filter{}.Matches(task))
+		assert.True(t, Filter{}.Matches(&Task{}))
+	})
+
+	t.Run("should match task satisfying every condition", func(t *testing.T) {
+		filter := Filter{
+			Statuses:   []Status{StatusNotStarted, StatusInProgress},
+			Priorities: []Priority{PriorityHigh},
+			DueBefore:  util.Ptr(due.Add(time.Hour)),
+			DueAfter:   util.Ptr(due.Add(-time.Hour)),
+			HasDueDate: true,
+			Query:      "quarterly report",
+		}
+
+		assert.True(t, filter.Matches(task))
+	})
+
+	t.Run("should not match task failing a condition", func(t *testing.T) {
+		filters := []Filter{
+			{Statuses: []Status{StatusCompleted}},
+			{Priorities: []Priority{PriorityLow}},
+			{DueBefore: &due},
+			{DueAfter: &due},
+			{Query: "budget"},
+		}
+
+		for _, filter := range filters {
+			assert.False(t, filter.Matches(task))
+		}
+	})
+
+	t.Run("should not match task without priority or due date", func(t *testing.T) {
+		assert.False(t, Filter{Priorities: []Priority{PriorityHigh}}.Matches(&Task{}))
+		assert.False(t, Filter{DueBefore: &due}.Matches(&Task{}))
+		assert.False(t, Filter{DueAfter: &due}.Matches(&Task{}))
+		assert.False(t, Filter{HasDueDate: true}.Matches(&Task{}))
+	})
+}
+
+func TestPaginate(t *testing.T) {
+	tasks := []*Task{{ID: "A"}, {ID: "B"}, {ID: "C"}}
+
+	t.Run("should return every task without limit", func(t *testing.T) {
+		page := paginate(tasks, ListOptions{}, nil)
+
+		assert.Equal(t, &Page{Tasks: tasks, Total: 3}, page)
+	})
+
+	t.Run("should return cursor of the following page", func(t *testing.T) {
+		page := paginate(tasks, ListOptions{Limit: 2}, nil)
+
+		assert.Equal(t, tasks[:2], page.Tasks)
+		after, err := decodeCursor(page.NextCursor)
+		require.NoError(t, err)
+		assert.Equal(t, &cursor{ID: "B"}, after)
+	})
+
+	t.Run("should return tasks after cursor", func(t *testing.T) {
+		page := paginate(tasks, ListOptions{Limit: 2}, &cursor{ID: "B"})
+
+		assert.Equal(t, &Page{Tasks: tasks[2:], Total: 3}, page)
+	})
+
+	t.Run("should return empty page when cursor is past the end", func(t *testing.T) {
+		page := paginate(tasks, ListOptions{Limit: 2}, &cursor{ID: "D"})
+
+		assert.Empty(t, page.Tasks)
+		assert.Equal(t, 3, page.Total)
+		assert.Empty(t, page.NextCursor)
+	})
+}
@@ -0,0 +1,99 @@
+package task
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// checklistItem matches an item of a GitHub-style checklist, capturing its indentation, its mark and its title
+var checklistItem = regexp.MustCompile(`^([ \t]*)[-*+][ \t]+\[([ xX])\][ \t]+(.*\S)[ \t]*$`)
+
+// markdownCodec encodes a task per item of a GitHub-style checklist, completed tasks being checked
+// and subtasks being indented below their parent. The other lines of an import are ignored
+type markdownCodec struct{}
+
+func (markdownCodec) encode(w io.Writer, tasks []*Task) error {
+	exported := make(map[string]bool, len(tasks))
+	for _, task := range tasks {
+		exported[task.ID] = true
+	}
+
+	children := make(map[string][]*Task)
+	var roots []*Task
+	for _, task := range tasks {
+		if task.ParentID != nil && exported[*task.ParentID] {
+			children[*task.ParentID] = append(children[*task.ParentID], task)
+			continue
+		}
+		roots = append(roots, task)
+	}
+
+	writer := bufio.NewWriter(w)
+	var write func(tasks []*Task, depth int)
+	write = func(tasks []*Task, depth int) {
+		for _, task := range tasks {
+			mark := " "
+			if task.Status == StatusCompleted {
+				mark = "x"
+			}
+			title := strings.Join(strings.Fields(task.Title), " ")
+			_, _ = fmt.Fprintf(writer, "%s- [%s] %s\n", strings.Repeat("  ", depth), mark, title)
+			write(children[task.ID], depth+1)
+		}
+	}
+	write(roots, 0)
+
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("error writing tasks: %w", err)
+	}
+
+	return nil
+}
+
+func (markdownCodec) decode(r io.Reader) ([]ImportRow, error) {
+	type item struct {
+		indent int
+		ref    string
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLineSize)
+
+	var (
+		rows    []ImportRow
+		parents []item
+	)
+	for line := 1; scanner.Scan(); line++ {
+		match := checklistItem.FindStringSubmatch(scanner.Text())
+		if match == nil {
+			continue
+		}
+
+		indent := len(strings.ReplaceAll(match[1], "\t", "    "))
+		for len(parents) > 0 && parents[len(parents)-1].indent >= indent {
+			parents = parents[:len(parents)-1]
+		}
+
+		task := &Task{Title: match[3]}
+		if match[2] != " " {
+			task.Status = StatusCompleted
+		}
+		if len(parents) > 0 {
+			parent := parents[len(parents)-1].ref
+			task.ParentID = &parent
+		}
+
+		ref := fmt.Sprintf("L%d", line)
+		rows = append(rows, ImportRow{Line: line, Ref: ref, Task: task})
+		parents = append(parents, item{indent: indent, ref: ref})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidImport, err)
+	}
+
+	return rows, nil
+}
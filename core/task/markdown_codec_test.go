@@ -0,0 +1,59 @@
+package task
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/pkg/util"
+)
+
+func TestMarkdownCodec(t *testing.T) {
+	t.Run("should encode a checklist with subtasks indented below their parent", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		err := markdownCodec{}.encode(&buf, []*Task{
+			{ID: "TASK-1", Title: "Write report", Status: StatusInProgress},
+			{ID: "TASK-2", Title: "Draft\noutline", Status: StatusCompleted, ParentID: util.Ptr("TASK-1")},
+			{ID: "TASK-3", Title: "Review report", Status: StatusNotStarted},
+			{ID: "TASK-4", Title: "Add charts", Status: StatusNotStarted, ParentID: util.Ptr("TASK-2")},
+			{ID: "TASK-5", Title: "Archive report", Status: StatusNotStarted, ParentID: util.Ptr("TASK-9")},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, strings.Join([]string{
+			"- [ ] Write report",
+			"  - [x] Draft outline",
+			"    - [ ] Add charts",
+			"- [ ] Review report",
+			"- [ ] Archive report",
+			"",
+		}, "\n"), buf.String())
+	})
+
+	t.Run("should decode checklist items ignoring other lines", func(t *testing.T) {
+		input := strings.Join([]string{
+			"# Report",
+			"- [ ] Write report",
+			"  * [x] Draft outline",
+			"\t\t+ [X] Add charts",
+			"  - [ ] Proofread",
+			"- plain item",
+			"- [ ] Review report",
+			"- [ ]",
+		}, "\n")
+
+		rows, err := markdownCodec{}.decode(strings.NewReader(input))
+
+		require.NoError(t, err)
+		assert.Equal(t, []ImportRow{
+			{Line: 2, Ref: "L2", Task: &Task{Title: "Write report"}},
+			{Line: 3, Ref: "L3", Task: &Task{Title: "Draft outline", Status: StatusCompleted, ParentID: util.Ptr("L2")}},
+			{Line: 4, Ref: "L4", Task: &Task{Title: "Add charts", Status: StatusCompleted, ParentID: util.Ptr("L3")}},
+			{Line: 5, Ref: "L5", Task: &Task{Title: "Proofread", ParentID: util.Ptr("L2")}},
+			{Line: 7, Ref: "L7", Task: &Task{Title: "Review report"}},
+		}, rows)
+	})
+}
@@ -0,0 +1,2 @@
+ALTER TABLE tasks ADD COLUMN started_at TEXT;
+ALTER TABLE tasks ADD COLUMN completed_at TEXT;
@@ -0,0 +1,3 @@
+ALTER TABLE tasks ADD COLUMN parent_id TEXT REFERENCES tasks (id);
+
+CREATE INDEX idx_tasks_parent_id ON tasks (parent_id);
@@ -0,0 +1,7 @@
+CREATE TABLE task_dependencies (
+    task_id    TEXT NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    blocker_id TEXT NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    PRIMARY KEY (task_id, blocker_id)
+);
+
+CREATE INDEX idx_task_dependencies_blocker_id ON task_dependencies (blocker_id);
@@ -0,0 +1,17 @@
+CREATE TABLE task_events (
+    sequence    INTEGER PRIMARY KEY AUTOINCREMENT,
+    task_id     TEXT    NOT NULL,
+    type        TEXT    NOT NULL,
+    version     INTEGER NOT NULL,
+    changes     TEXT    NOT NULL,
+    actor       TEXT    NOT NULL DEFAULT '',
+    occurred_at TEXT    NOT NULL
+);
+
+CREATE INDEX idx_task_events_task_id ON task_events (task_id);
+
+CREATE TABLE task_snapshots (
+    sequence   INTEGER PRIMARY KEY,
+    tasks      TEXT NOT NULL,
+    created_at TEXT NOT NULL
+);
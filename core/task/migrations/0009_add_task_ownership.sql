@@ -0,0 +1,11 @@
+ALTER TABLE tasks ADD COLUMN owner_id TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX idx_tasks_owner_id ON tasks (owner_id);
+
+CREATE TABLE task_assignees (
+    task_id TEXT NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    PRIMARY KEY (task_id, user_id)
+);
+
+CREATE INDEX idx_task_assignees_user_id ON task_assignees (user_id);
@@ -0,0 +1,19 @@
+ALTER TABLE tasks ADD COLUMN project TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX idx_tasks_project ON tasks (project);
+
+CREATE TABLE projects (
+    key         TEXT PRIMARY KEY,
+    name        TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    sequence    INTEGER NOT NULL DEFAULT 0,
+    created_at  TEXT NOT NULL,
+    updated_at  TEXT NOT NULL
+);
+
+CREATE TABLE task_redirects (
+    from_id TEXT PRIMARY KEY,
+    to_id   TEXT NOT NULL
+);
+
+CREATE INDEX idx_task_redirects_to_id ON task_redirects (to_id);
@@ -0,0 +1,3 @@
+CREATE TABLE task_projection (
+    sequence INTEGER NOT NULL
+);
@@ -0,0 +1,52 @@
+package task
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// maxLineSize is the size of the longest line read from a JSON Lines import
+const maxLineSize = 1 << 20
+
+// ndjsonCodec encodes a task per line as a JSON object, its fields named as in the CSV header
+type ndjsonCodec struct{}
+
+func (ndjsonCodec) encode(w io.Writer, tasks []*Task) error {
+	encoder := json.NewEncoder(w)
+	for _, task := range tasks {
+		if err := encoder.Encode(newRecord(task)); err != nil {
+			return fmt.Errorf("error writing task %s: %w", task.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (ndjsonCodec) decode(r io.Reader) ([]ImportRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLineSize)
+
+	var rows []ImportRow
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		var rec record
+		if err := json.Unmarshal(data, &rec); err != nil {
+			rows = append(rows, ImportRow{Line: line, Err: fmt.Errorf("invalid JSON: %w", err)})
+			continue
+		}
+		rows = append(rows, rec.row(line))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidImport, err)
+	}
+
+	return rows, nil
+}
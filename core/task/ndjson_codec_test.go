@@ -0,0 +1,55 @@
+package task
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/pkg/util"
+)
+
+func TestNDJSONCodec(t *testing.T) {
+	created := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("should encode a JSON object per line", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		err := ndjsonCodec{}.encode(&buf, []*Task{
+			{ID: "TASK-000001", Title: "Write report", Status: StatusNotStarted, Priority: util.Ptr(PriorityHigh), Tags: []string{"work"}, CreatedAt: created, UpdatedAt: created},
+			{ID: "TASK-000002", Title: "Review report", Status: StatusBlocked, BlockedBy: []string{"TASK-000001"}, CreatedAt: created, UpdatedAt: created},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, strings.Join([]string{
+			`{"id":"TASK-000001","title":"Write report","status":"NOT_STARTED","priority":"HIGH","tags":["work"],"createdAt":"2025-05-01T09:00:00Z","updatedAt":"2025-05-01T09:00:00Z"}`,
+			`{"id":"TASK-000002","title":"Review report","status":"BLOCKED","blockedBy":["TASK-000001"],"createdAt":"2025-05-01T09:00:00Z","updatedAt":"2025-05-01T09:00:00Z"}`,
+			"",
+		}, "\n"), buf.String())
+	})
+
+	t.Run("should decode a row per line skipping blank lines", func(t *testing.T) {
+		input := strings.Join([]string{
+			`{"id":"a","title":"Write report","priority":"high","dueDate":"2025-05-02","size":"L"}`,
+			``,
+			`{"id":"b","title":"Review report","blockedBy":["a"]}`,
+			`{"title":`,
+			`{"title":"Archive report","status":"done"}`,
+		}, "\n")
+
+		rows, err := ndjsonCodec{}.decode(strings.NewReader(input))
+
+		require.NoError(t, err)
+		require.Len(t, rows, 4)
+		assert.Equal(t, ImportRow{Line: 1, Ref: "a", Task: &Task{
+			Title: "Write report", Priority: util.Ptr(PriorityHigh), DueDate: util.Ptr(time.Date(2025, 5, 2, 0, 0, 0, 0, time.UTC)),
+		}}, rows[0])
+		assert.Equal(t, ImportRow{Line: 3, Ref: "b", Task: &Task{Title: "Review report", BlockedBy: []string{"a"}}}, rows[1])
+		assert.Equal(t, 4, rows[2].Line)
+		assert.ErrorContains(t, rows[2].Err, "invalid JSON")
+		assert.Equal(t, 5, rows[3].Line)
+		assert.ErrorIs(t, rows[3].Err, ErrInvalidStatus)
+	})
+}
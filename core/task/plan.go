@@ -0,0 +1,118 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// Plan is an execution plan of the open tasks, the tasks which are neither completed nor cancelled
+type Plan struct {
+	// Order lists the open tasks so that every task comes after its open blockers.
+	// Tasks which can be worked on at the same time are sorted by due date, then from high to low priority
+	Order []*Task
+	// CriticalPath is the longest chain of open tasks blocking each other which ends with a task having a due date,
+	// from the first task to work on to the task due. Among chains of the same length the one due first is chosen.
+	// It is empty when no open task has a due date
+	CriticalPath []*Task
+}
+
+var planOrder = ListOptions{Sort: []Sort{{Field: SortByDueDate}, {Field: SortByPriority, Descending: true}}}
+
+// NewPlan builds the execution plan of the open tasks among the given tasks, ignoring the blockers which are not open
+// Returns ErrCyclicDependency if the open tasks block each other in a cycle
+func NewPlan(tasks []*Task) (*Plan, error) {
+	open := make(map[string]*Task)
+	for _, t := range tasks {
+		if !t.Status.finished() {
+			open[t.ID] = t
+		}
+	}
+
+	blockers := make(map[string][]string)
+	blocked := make(map[string][]*Task)
+	var ready []*Task
+	for _, t := range tasks {
+		if open[t.ID] == nil {
+			continue
+		}
+		for _, id := range t.BlockedBy {
+			if open[id] != nil {
+				blockers[t.ID] = append(blockers[t.ID], id)
+				blocked[id] = append(blocked[id], t)
+			}
+		}
+		if len(blockers[t.ID]) == 0 {
+			ready = append(ready, t)
+		}
+	}
+
+	remaining := make(map[string]int, len(blockers))
+	for id, ids := range blockers {
+		remaining[id] = len(ids)
+	}
+
+	order := make([]*Task, 0, len(open))
+	for len(ready) > 0 {
+		next := slices.MinFunc(ready, planOrder.compare)
+		ready = slices.DeleteFunc(ready, func(t *Task) bool { return t == next })
+		order = append(order, next)
+
+		for _, t := range blocked[next.ID] {
+			if remaining[t.ID]--; remaining[t.ID] == 0 {
+				ready = append(ready, t)
+			}
+		}
+	}
+
+	if len(order) < len(open) {
+		return nil, fmt.Errorf("%w: %d tasks block each other", ErrCyclicDependency, len(open)-len(order))
+	}
+
+	return &Plan{Order: order, CriticalPath: criticalPath(order, blockers)}, nil
+}
+
+func criticalPath(order []*Task, blockers map[string][]string) []*Task {
+	byID := make(map[string]*Task, len(order))
+	length := make(map[string]int, len(order))
+	previous := make(map[string]string, len(order))
+
+	var end *Task
+	for _, t := range order {
+		byID[t.ID] = t
+		length[t.ID] = 1
+		for _, id := range blockers[t.ID] {
+			if length[id]+1 > length[t.ID] {
+				length[t.ID] = length[id] + 1
+				previous[t.ID] = id
+			}
+		}
+
+		if t.DueDate == nil {
+			continue
+		}
+		if end == nil || length[t.ID] > length[end.ID] || (length[t.ID] == length[end.ID] && t.DueDate.Before(*end.DueDate)) {
+			end = t
+		}
+	}
+
+	if end == nil {
+		return []*Task{}
+	}
+
+	path := make([]*Task, length[end.ID])
+	for i, id := len(path)-1, end.ID; i >= 0; i, id = i-1, previous[id] {
+		path[i] = byID[id]
+	}
+
+	return path
+}
+
+func plan(ctx context.Context, s Service) (*Plan, error) {
+	page, err := s.List(ctx, ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPlan(page.Tasks)
+}
@@ -0,0 +1,103 @@
+package task
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/pkg/util"
+)
+
+func TestNewPlan(t *testing.T) {
+	day := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("should order tasks after their blockers", func(t *testing.T) {
+		plan, err := NewPlan([]*Task{
+			{ID: "deploy", Status: StatusBlocked, BlockedBy: []string{"migrate", "review"}},
+			{ID: "migrate", Status: StatusBlocked, BlockedBy: []string{"design"}},
+			{ID: "review", Status: StatusNotStarted},
+			{ID: "design", Status: StatusInProgress},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"design", "migrate", "review", "deploy"}, taskIDs(plan.Order))
+	})
+
+	t.Run("should order independent tasks by due date then priority", func(t *testing.T) {
+		plan, err := NewPlan([]*Task{
+			{ID: "A", Status: StatusNotStarted},
+			{ID: "B", Status: StatusNotStarted, DueDate: util.Ptr(day.Add(48 * time.Hour))},
+			{ID: "C", Status: StatusNotStarted, DueDate: util.Ptr(day), Priority: util.Ptr(PriorityLow)},
+			{ID: "D", Status: StatusNotStarted, DueDate: util.Ptr(day), Priority: util.Ptr(PriorityHigh)},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"D", "C", "B", "A"}, taskIDs(plan.Order))
+	})
+
+	t.Run("should skip finished tasks and their blocking", func(t *testing.T) {
+		plan, err := NewPlan([]*Task{
+			{ID: "A", Status: StatusNotStarted, BlockedBy: []string{"B"}},
+			{ID: "B", Status: StatusCompleted},
+			{ID: "C", Status: StatusCancelled},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"A"}, taskIDs(plan.Order))
+	})
+
+	t.Run("should return longest chain ending with a due task as critical path", func(t *testing.T) {
+		plan, err := NewPlan([]*Task{
+			{ID: "design", Status: StatusInProgress},
+			{ID: "migrate", Status: StatusBlocked, BlockedBy: []string{"design"}},
+			{ID: "deploy", Status: StatusBlocked, BlockedBy: []string{"migrate", "docs"}, DueDate: util.Ptr(day.Add(72 * time.Hour))},
+			{ID: "docs", Status: StatusNotStarted},
+			{ID: "demo", Status: StatusBlocked, BlockedBy: []string{"docs"}, DueDate: util.Ptr(day)},
+			{ID: "cleanup", Status: StatusBlocked, BlockedBy: []string{"deploy"}},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"design", "migrate", "deploy"}, taskIDs(plan.CriticalPath))
+	})
+
+	t.Run("should prefer chain due first among chains of the same length", func(t *testing.T) {
+		plan, err := NewPlan([]*Task{
+			{ID: "A", Status: StatusNotStarted, DueDate: util.Ptr(day.Add(24 * time.Hour))},
+			{ID: "B", Status: StatusNotStarted, DueDate: util.Ptr(day)},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"B"}, taskIDs(plan.CriticalPath))
+	})
+
+	t.Run("should return empty critical path without due dates", func(t *testing.T) {
+		plan, err := NewPlan([]*Task{{ID: "A", Status: StatusNotStarted}})
+
+		require.NoError(t, err)
+		assert.Empty(t, plan.CriticalPath)
+	})
+
+	t.Run("should return error when tasks block each other", func(t *testing.T) {
+		_, err := NewPlan([]*Task{
+			{ID: "A", Status: StatusBlocked, BlockedBy: []string{"B"}},
+			{ID: "B", Status: StatusBlocked, BlockedBy: []string{"A"}},
+			{ID: "C", Status: StatusNotStarted},
+		})
+
+		assert.ErrorIs(t, err, ErrCyclicDependency)
+	})
+}
+
+func TestService_Plan(t *testing.T) {
+	t.Run("should plan stored tasks", func(t *testing.T) {
+		service := newFixture(t, dependencyTasks()...).service
+
+		plan, err := service.Plan(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"TASK-000001", "TASK-000002", "TASK-000003"}, taskIDs(plan.Order))
+		assert.Empty(t, plan.CriticalPath)
+	})
+}
@@ -0,0 +1,250 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrProjectNotFound is returned when a project doesn't exist
+	ErrProjectNotFound = errors.New("project not found")
+	// ErrProjectExists is returned when a project is created with the key of another project
+	ErrProjectExists = errors.New("project already exists")
+	// ErrInvalidProject is returned when a project has an invalid key or no name
+	ErrInvalidProject = errors.New("invalid project")
+	// ErrProjectNotEmpty is returned when a project having tasks is deleted
+	ErrProjectNotEmpty = errors.New("project has tasks")
+	// ErrTaskMoved is returned when a task was moved to another project, wrapped in a *MovedError
+	ErrTaskMoved = errors.New("task moved")
+)
+
+const (
+	// DefaultProjectKey prefixes the IDs of the tasks of the default project, no project can have it
+	DefaultProjectKey = "TASK"
+	// MaxProjectKeyLength is the maximum number of characters of a project key
+	MaxProjectKeyLength = 10
+)
+
+var projectKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9]*$`)
+
+// Project is a group of tasks sharing the prefix of their IDs
+type Project struct {
+	// Key identifies the project and prefixes the IDs of its tasks, e.g. WEB for WEB-000001
+	Key string
+	// Name is the short name of the project
+	Name string
+	// Description provides additional details about the project
+	Description string
+	// CreatedAt stores when the project was created
+	CreatedAt time.Time
+	// UpdatedAt stores when the project was last modified
+	UpdatedAt time.Time
+}
+
+// ID returns the ID of the task of the project with the given sequence number
+func (p *Project) ID(sequence int) string {
+	return projectID(p.Key, sequence)
+}
+
+func projectID(key string, sequence int) string {
+	return fmt.Sprintf("%s-%06d", key, sequence)
+}
+
+// NormalizeProjectKey returns the key in upper case
+// Returns ErrInvalidProject if it doesn't start with a letter followed by letters and digits, is too long
+// or is the DefaultProjectKey
+func NormalizeProjectKey(key string) (string, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(key))
+	if len(normalized) < 2 || len(normalized) > MaxProjectKeyLength || !projectKeyPattern.MatchString(normalized) {
+		return "", fmt.Errorf("%w: key %q must be 2 to %d letters and digits starting with a letter", ErrInvalidProject, key, MaxProjectKeyLength)
+	}
+
+	if normalized == DefaultProjectKey {
+		return "", fmt.Errorf("%w: key %s is reserved for the default project", ErrInvalidProject, DefaultProjectKey)
+	}
+
+	return normalized, nil
+}
+
+// MovedError is returned when a task was moved to another project, it matches ErrTaskMoved.
+// The former ID of a moved task redirects to its new ID, which its subtasks and the tasks it blocks refer to
+type MovedError struct {
+	// ID is the former ID of the task
+	ID string
+	// MovedTo is the current ID of the task
+	MovedTo string
+}
+
+func (e *MovedError) Error() string {
+	return fmt.Sprintf("%s: %s is now %s", ErrTaskMoved, e.ID, e.MovedTo)
+}
+
+func (e *MovedError) Is(target error) bool {
+	return target == ErrTaskMoved
+}
+
+//go:generate mockgen -destination=project_repository_mock.go -package=task . ProjectRepository
+
+// ProjectRepository defines the interface for storing the projects, the sequences of their task IDs
+// and the IDs of the tasks moved between them
+type ProjectRepository interface {
+	// Create stores a new project with a sequence starting at zero
+	// Returns ErrProjectExists if a project has the same key
+	Create(ctx context.Context, project *Project) error
+
+	// Get returns a project by its key
+	// Returns ErrProjectNotFound if the project doesn't exist
+	Get(ctx context.Context, key string) (*Project, error)
+
+	// List returns every project sorted by key
+	List(ctx context.Context) ([]*Project, error)
+
+	// Update replaces the name and description of a project
+	// Returns ErrProjectNotFound if the project doesn't exist
+	Update(ctx context.Context, project *Project) error
+
+	// Delete removes a project, the sequence of its task IDs restarts if a project is created again with its key
+	// Returns ErrProjectNotFound if the project doesn't exist
+	Delete(ctx context.Context, key string) error
+
+	// NextSequence increments the sequence of the task IDs of a project and returns it
+	// Returns ErrProjectNotFound if the project doesn't exist
+	NextSequence(ctx context.Context, key string) (int, error)
+
+	// SaveRedirect records that the task with the ID from now has the ID to,
+	// the tasks moved to from before are redirected to to as well
+	SaveRedirect(ctx context.Context, from, to string) error
+
+	// Redirect returns the current ID of a task moved from the given ID
+	// Returns ErrTaskNotFound if no task was moved from this ID
+	Redirect(ctx context.Context, id string) (string, error)
+}
+
+// MemoryProjectRepository is an in-memory implementation of ProjectRepository
+type MemoryProjectRepository struct {
+	projects  map[string]*Project
+	sequences map[string]int
+	redirects map[string]string
+	mu        sync.RWMutex
+}
+
+// NewMemoryProjectRepository creates a new empty memory project repository
+func NewMemoryProjectRepository() *MemoryProjectRepository {
+	return &MemoryProjectRepository{
+		projects:  make(map[string]*Project),
+		sequences: make(map[string]int),
+		redirects: make(map[string]string),
+	}
+}
+
+func (r *MemoryProjectRepository) Create(_ context.Context, project *Project) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.projects[project.Key]; ok {
+		return fmt.Errorf("%w: %s", ErrProjectExists, project.Key)
+	}
+
+	stored := *project
+	r.projects[project.Key] = &stored
+	r.sequences[project.Key] = 0
+	return nil
+}
+
+func (r *MemoryProjectRepository) Get(_ context.Context, key string) (*Project, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	project, ok := r.projects[key]
+	if !ok {
+		return nil, ErrProjectNotFound
+	}
+
+	found := *project
+	return &found, nil
+}
+
+func (r *MemoryProjectRepository) List(_ context.Context) ([]*Project, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	projects := make([]*Project, 0, len(r.projects))
+	for _, project := range r.projects {
+		found := *project
+		projects = append(projects, &found)
+	}
+
+	slices.SortFunc(projects, func(a, b *Project) int { return strings.Compare(a.Key, b.Key) })
+	return projects, nil
+}
+
+func (r *MemoryProjectRepository) Update(_ context.Context, project *Project) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.projects[project.Key]; !ok {
+		return ErrProjectNotFound
+	}
+
+	stored := *project
+	r.projects[project.Key] = &stored
+	return nil
+}
+
+func (r *MemoryProjectRepository) Delete(_ context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.projects[key]; !ok {
+		return ErrProjectNotFound
+	}
+
+	delete(r.projects, key)
+	delete(r.sequences, key)
+	return nil
+}
+
+func (r *MemoryProjectRepository) NextSequence(_ context.Context, key string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.projects[key]; !ok {
+		return 0, ErrProjectNotFound
+	}
+
+	r.sequences[key]++
+	return r.sequences[key], nil
+}
+
+func (r *MemoryProjectRepository) SaveRedirect(_ context.Context, from, to string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, target := range r.redirects {
+		if target == from {
+			r.redirects[id] = to
+		}
+	}
+
+	r.redirects[from] = to
+	delete(r.redirects, to)
+	return nil
+}
+
+func (r *MemoryProjectRepository) Redirect(_ context.Context, id string) (string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	to, ok := r.redirects[id]
+	if !ok {
+		return "", ErrTaskNotFound
+	}
+
+	return to, nil
+}
@@ -0,0 +1,181 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/utsabbera/task-master/pkg/util"
+)
+
+//go:generate mockgen -destination=project_service_mock.go -package=task . ProjectService
+
+// ProjectService defines the interface for managing the projects the tasks are grouped in
+type ProjectService interface {
+	// Create validates and stores a new project, normalizing its key and setting its timestamps
+	// Returns ErrInvalidProject if the key is invalid or the name is empty, or ErrProjectExists if the key is taken
+	Create(ctx context.Context, project *Project) error
+
+	// Get returns a project by its key
+	// Returns ErrProjectNotFound if the project doesn't exist
+	Get(ctx context.Context, key string) (*Project, error)
+
+	// List returns every project sorted by key
+	List(ctx context.Context) ([]*Project, error)
+
+	// Update changes the name and the description of a project, empty fields of the patch are left unchanged
+	// Returns ErrProjectNotFound if the project doesn't exist
+	Update(ctx context.Context, key string, patch *Project) (*Project, error)
+
+	// Delete removes a project
+	// Returns ErrProjectNotFound if the project doesn't exist, or ErrProjectNotEmpty if it still has tasks
+	Delete(ctx context.Context, key string) error
+}
+
+// Projects provides the IDs of the tasks of the projects and keeps track of the tasks moved between them
+type Projects interface {
+	// NextID returns the ID of the next task of the project
+	// Returns ErrProjectNotFound if the project doesn't exist
+	NextID(ctx context.Context, key string) (string, error)
+
+	// Moved records that the task with the ID from now has the ID to
+	Moved(ctx context.Context, from, to string) error
+
+	// Redirect returns the current ID of a task moved from the given ID
+	// Returns ErrTaskNotFound if no task was moved from this ID
+	Redirect(ctx context.Context, id string) (string, error)
+}
+
+type projectService struct {
+	repo  ProjectRepository
+	tasks Repository
+	clock util.Clock
+}
+
+// NewProjectService creates a new project service storing the projects in the given repository.
+// The tasks repository is checked for the tasks of a project before it is deleted
+func NewProjectService(repo ProjectRepository, tasks Repository, clock util.Clock) ProjectService {
+	return &projectService{repo: repo, tasks: tasks, clock: clock}
+}
+
+func (s *projectService) Create(ctx context.Context, project *Project) error {
+	key, err := NormalizeProjectKey(project.Key)
+	if err != nil {
+		return fmt.Errorf("error creating project: %w", err)
+	}
+
+	if strings.TrimSpace(project.Name) == "" {
+		return fmt.Errorf("error creating project: %w: name is required", ErrInvalidProject)
+	}
+
+	now := s.clock.Now()
+	project.Key = key
+	project.CreatedAt = now
+	project.UpdatedAt = now
+
+	if err := s.repo.Create(ctx, project); err != nil {
+		return fmt.Errorf("error creating project: %w", err)
+	}
+
+	return nil
+}
+
+func (s *projectService) Get(ctx context.Context, key string) (*Project, error) {
+	project, err := s.repo.Get(ctx, strings.ToUpper(key))
+	if err != nil {
+		return nil, fmt.Errorf("error finding project: %w", err)
+	}
+
+	return project, nil
+}
+
+func (s *projectService) List(ctx context.Context) ([]*Project, error) {
+	projects, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing projects: %w", err)
+	}
+
+	return projects, nil
+}
+
+func (s *projectService) Update(ctx context.Context, key string, patch *Project) (*Project, error) {
+	project, err := s.repo.Get(ctx, strings.ToUpper(key))
+	if err != nil {
+		return nil, fmt.Errorf("error updating project: %w", err)
+	}
+
+	if patch.Name != "" {
+		project.Name = patch.Name
+	}
+	if patch.Description != "" {
+		project.Description = patch.Description
+	}
+	project.UpdatedAt = s.clock.Now()
+
+	if err := s.repo.Update(ctx, project); err != nil {
+		return nil, fmt.Errorf("error updating project: %w", err)
+	}
+
+	return project, nil
+}
+
+func (s *projectService) Delete(ctx context.Context, key string) error {
+	key = strings.ToUpper(key)
+	if _, err := s.repo.Get(ctx, key); err != nil {
+		return fmt.Errorf("error deleting project: %w", err)
+	}
+
+	page, err := s.tasks.List(ctx, ListOptions{Filter: Filter{Projects: []string{key}}, Limit: 1})
+	if err != nil {
+		return fmt.Errorf("error deleting project: %w", err)
+	}
+
+	if page.Total > 0 {
+		return fmt.Errorf("error deleting project: %w: %s has %d tasks", ErrProjectNotEmpty, key, page.Total)
+	}
+
+	if err := s.repo.Delete(ctx, key); err != nil {
+		return fmt.Errorf("error deleting project: %w", err)
+	}
+
+	return nil
+}
+
+type projects struct {
+	repo ProjectRepository
+}
+
+// NewProjects creates the Projects of the tasks, allocating their IDs from the sequences of the projects
+// stored in the given repository, along with the IDs of the moved tasks
+func NewProjects(repo ProjectRepository) Projects {
+	return &projects{repo: repo}
+}
+
+func (p *projects) NextID(ctx context.Context, key string) (string, error) {
+	sequence, err := p.repo.NextSequence(ctx, key)
+	if err != nil {
+		return "", fmt.Errorf("error allocating task ID of project %s: %w", key, err)
+	}
+
+	return projectID(key, sequence), nil
+}
+
+func (p *projects) Moved(ctx context.Context, from, to string) error {
+	return p.repo.SaveRedirect(ctx, from, to)
+}
+
+func (p *projects) Redirect(ctx context.Context, id string) (string, error) {
+	return p.repo.Redirect(ctx, id)
+}
+
+// within returns the projects joining the unit of work tx of the task repository when their repository can,
+// so that the IDs and redirects of the tasks are saved along with the tasks
+func (p *projects) within(tx Repository) Projects {
+	if repo, ok := p.repo.(interface {
+		within(tx Repository) ProjectRepository
+	}); ok {
+		return &projects{repo: repo.within(tx)}
+	}
+
+	return p
+}
@@ -0,0 +1,265 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/pkg/idgen"
+	"github.com/utsabbera/task-master/pkg/util"
+	"go.uber.org/mock/gomock"
+)
+
+func TestProjectService_Create(t *testing.T) {
+	t.Run("should normalize key and set timestamps", func(t *testing.T) {
+		projects := newFixture(t).projects
+		project := &Project{Key: "web", Name: "Website"}
+
+		require.NoError(t, projects.Create(context.Background(), project))
+
+		assert.Equal(t, "WEB", project.Key)
+		assert.False(t, project.CreatedAt.IsZero())
+		assert.Equal(t, project.CreatedAt, project.UpdatedAt)
+
+		found, err := projects.Get(context.Background(), "web")
+		require.NoError(t, err)
+		assert.Equal(t, project, found)
+	})
+
+	t.Run("should return error when name is empty", func(t *testing.T) {
+		projects := newFixture(t).projects
+
+		err := projects.Create(context.Background(), &Project{Key: "WEB", Name: " "})
+
+		assert.ErrorIs(t, err, ErrInvalidProject)
+	})
+
+	t.Run("should return error when key is taken", func(t *testing.T) {
+		projects := newFixture(t).projects
+		require.NoError(t, projects.Create(context.Background(), &Project{Key: "WEB", Name: "Website"}))
+
+		err := projects.Create(context.Background(), &Project{Key: "web", Name: "Other"})
+
+		assert.ErrorIs(t, err, ErrProjectExists)
+	})
+}
+
+func TestProjectService_Update(t *testing.T) {
+	t.Run("should only change given fields", func(t *testing.T) {
+		projects := newFixture(t).projects
+		created := &Project{Key: "WEB", Name: "Website", Description: "Public site"}
+		require.NoError(t, projects.Create(context.Background(), created))
+
+		updated, err := projects.Update(context.Background(), "web", &Project{Name: "Web app"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Web app", updated.Name)
+		assert.Equal(t, "Public site", updated.Description)
+		assert.True(t, updated.UpdatedAt.After(created.UpdatedAt))
+	})
+
+	t.Run("should return error when project doesn't exist", func(t *testing.T) {
+		projects := newFixture(t).projects
+
+		_, err := projects.Update(context.Background(), "WEB", &Project{Name: "Web app"})
+
+		assert.ErrorIs(t, err, ErrProjectNotFound)
+	})
+}
+
+func TestProjectService_Delete(t *testing.T) {
+	t.Run("should return error when project has tasks", func(t *testing.T) {
+		ctx := context.Background()
+		f := newFixture(t)
+		projects, service := f.projects, f.service
+		require.NoError(t, projects.Create(ctx, &Project{Key: "WEB", Name: "Website"}))
+		require.NoError(t, service.Create(ctx, &Task{Title: "Landing page", Project: "WEB"}))
+
+		err := projects.Delete(ctx, "WEB")
+
+		assert.ErrorIs(t, err, ErrProjectNotEmpty)
+	})
+
+	t.Run("should delete empty project", func(t *testing.T) {
+		ctx := context.Background()
+		f := newFixture(t)
+		projects, service := f.projects, f.service
+		require.NoError(t, projects.Create(ctx, &Project{Key: "WEB", Name: "Website"}))
+		require.NoError(t, service.Create(ctx, &Task{Title: "Buy milk"}))
+
+		require.NoError(t, projects.Delete(ctx, "web"))
+
+		_, err := projects.Get(ctx, "WEB")
+		assert.ErrorIs(t, err, ErrProjectNotFound)
+	})
+}
+
+func TestService_Create_Project(t *testing.T) {
+	t.Run("should give task the next ID of its project", func(t *testing.T) {
+		ctx := context.Background()
+		f := newFixture(t)
+		projects, service := f.projects, f.service
+		require.NoError(t, projects.Create(ctx, &Project{Key: "WEB", Name: "Website"}))
+		require.NoError(t, projects.Create(ctx, &Project{Key: "OPS", Name: "Operations"}))
+
+		tasks := []*Task{
+			{Title: "Landing page", Project: "web"},
+			{Title: "Buy milk"},
+			{Title: "Deploy", Project: "OPS"},
+			{Title: "Pricing page", Project: "WEB"},
+		}
+		for _, task := range tasks {
+			require.NoError(t, service.Create(ctx, task))
+		}
+
+		assert.Equal(t, []string{"WEB-000001", "TASK-000001", "OPS-000001", "WEB-000002"}, util.Map(tasks, func(t *Task) string { return t.ID }))
+		assert.Equal(t, "WEB", tasks[0].Project)
+	})
+
+	t.Run("should return error when project doesn't exist", func(t *testing.T) {
+		service := newFixture(t).service
+
+		err := service.Create(context.Background(), &Task{Title: "Landing page", Project: "WEB"})
+
+		assert.ErrorIs(t, err, ErrProjectNotFound)
+	})
+
+	t.Run("should return error when service has no projects", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		service := NewService(NewMemoryRepository(), idgen.NewSequential("TASK-", 1, 6), newTickingClock(ctrl))
+
+		err := service.Create(context.Background(), &Task{Title: "Landing page", Project: "WEB"})
+
+		assert.ErrorIs(t, err, ErrProjectNotFound)
+	})
+}
+
+func TestService_Move(t *testing.T) {
+	t.Run("should move task with new ID and redirect former ID", func(t *testing.T) {
+		ctx := context.Background()
+		f := newFixture(t)
+		projects, service := f.projects, f.service
+		require.NoError(t, projects.Create(ctx, &Project{Key: "WEB", Name: "Website"}))
+		task := &Task{Title: "Landing page", Tags: []string{"design"}}
+		require.NoError(t, service.Create(ctx, task))
+
+		moved, err := service.Move(ctx, task.ID, "web")
+
+		require.NoError(t, err)
+		assert.Equal(t, "WEB-000001", moved.ID)
+		assert.Equal(t, "WEB", moved.Project)
+		assert.Equal(t, task.Title, moved.Title)
+		assert.Equal(t, task.Tags, moved.Tags)
+		assert.Equal(t, task.CreatedAt, moved.CreatedAt)
+
+		_, err = service.Get(ctx, "TASK-000001")
+		var movedErr *MovedError
+		require.ErrorAs(t, err, &movedErr)
+		assert.Equal(t, "WEB-000001", movedErr.MovedTo)
+
+		_, err = service.Update(ctx, "TASK-000001", &Task{Title: "Home page"})
+		assert.ErrorIs(t, err, ErrTaskMoved)
+	})
+
+	t.Run("should remap subtasks and blocked tasks", func(t *testing.T) {
+		ctx := context.Background()
+		f := newFixture(t)
+		projects, service := f.projects, f.service
+		require.NoError(t, projects.Create(ctx, &Project{Key: "WEB", Name: "Website"}))
+		require.NoError(t, service.Create(ctx, &Task{Title: "Landing page"}))
+		require.NoError(t, service.Create(ctx, &Task{Title: "Hero image", ParentID: util.Ptr("TASK-000001")}))
+		require.NoError(t, service.Create(ctx, &Task{Title: "Launch", BlockedBy: []string{"TASK-000001"}}))
+
+		_, err := service.Move(ctx, "TASK-000001", "WEB")
+		require.NoError(t, err)
+
+		subtask, err := service.Get(ctx, "TASK-000002")
+		require.NoError(t, err)
+		assert.Equal(t, util.Ptr("WEB-000001"), subtask.ParentID)
+
+		dependent, err := service.Get(ctx, "TASK-000003")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"WEB-000001"}, dependent.BlockedBy)
+		assert.Equal(t, StatusBlocked, dependent.Status)
+	})
+
+	t.Run("should redirect every former ID after moving twice", func(t *testing.T) {
+		ctx := context.Background()
+		f := newFixture(t)
+		projects, service := f.projects, f.service
+		require.NoError(t, projects.Create(ctx, &Project{Key: "WEB", Name: "Website"}))
+		require.NoError(t, service.Create(ctx, &Task{Title: "Landing page"}))
+
+		_, err := service.Move(ctx, "TASK-000001", "WEB")
+		require.NoError(t, err)
+		moved, err := service.Move(ctx, "WEB-000001", "")
+		require.NoError(t, err)
+
+		assert.Equal(t, "TASK-000002", moved.ID)
+		assert.Empty(t, moved.Project)
+		for _, id := range []string{"TASK-000001", "WEB-000001"} {
+			_, err := service.Get(ctx, id)
+			var movedErr *MovedError
+			require.ErrorAs(t, err, &movedErr)
+			assert.Equal(t, "TASK-000002", movedErr.MovedTo)
+		}
+	})
+
+	t.Run("should apply none of the move when the redirect can't be saved", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		projectRepo := NewMockProjectRepository(ctrl)
+		projectRepo.EXPECT().NextSequence(gomock.Any(), "WEB").Return(1, nil)
+		projectRepo.EXPECT().SaveRedirect(gomock.Any(), "TASK-000001", "WEB-000001").Return(errors.New("disk full"))
+		projectRepo.EXPECT().Redirect(gomock.Any(), "WEB-000001").Return("", ErrTaskNotFound)
+		service := NewServiceWithProjects(NewMemoryRepository(), idgen.NewSequential("TASK-", 1, 6), NewProjects(projectRepo), newTickingClock(ctrl))
+		require.NoError(t, service.Create(ctx, &Task{Title: "Landing page"}))
+		require.NoError(t, service.Create(ctx, &Task{Title: "Hero image", ParentID: util.Ptr("TASK-000001")}))
+
+		_, err := service.Move(ctx, "TASK-000001", "WEB")
+
+		assert.EqualError(t, err, "error moving task: disk full")
+		_, err = service.Get(ctx, "TASK-000001")
+		assert.NoError(t, err)
+		_, err = service.Get(ctx, "WEB-000001")
+		assert.ErrorIs(t, err, ErrTaskNotFound)
+		subtask, err := service.Get(ctx, "TASK-000002")
+		require.NoError(t, err)
+		assert.Equal(t, util.Ptr("TASK-000001"), subtask.ParentID)
+	})
+
+	t.Run("should keep task already in project", func(t *testing.T) {
+		ctx := context.Background()
+		f := newFixture(t)
+		projects, service := f.projects, f.service
+		require.NoError(t, projects.Create(ctx, &Project{Key: "WEB", Name: "Website"}))
+		require.NoError(t, service.Create(ctx, &Task{Title: "Landing page", Project: "WEB"}))
+
+		moved, err := service.Move(ctx, "WEB-000001", "WEB")
+
+		require.NoError(t, err)
+		assert.Equal(t, "WEB-000001", moved.ID)
+	})
+
+	t.Run("should return error when project doesn't exist", func(t *testing.T) {
+		ctx := context.Background()
+		service := newFixture(t).service
+		require.NoError(t, service.Create(ctx, &Task{Title: "Landing page"}))
+
+		_, err := service.Move(ctx, "TASK-000001", "WEB")
+
+		assert.ErrorIs(t, err, ErrProjectNotFound)
+		_, err = service.Get(ctx, "TASK-000001")
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return error when task doesn't exist", func(t *testing.T) {
+		service := newFixture(t).service
+
+		_, err := service.Move(context.Background(), "TASK-000001", "")
+
+		assert.ErrorIs(t, err, ErrTaskNotFound)
+	})
+}
@@ -0,0 +1,199 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeProjectKey(t *testing.T) {
+	t.Run("should upper case key", func(t *testing.T) {
+		key, err := NormalizeProjectKey(" web2 ")
+
+		require.NoError(t, err)
+		assert.Equal(t, "WEB2", key)
+	})
+
+	tests := []struct {
+		name string
+		key  string
+	}{
+		{name: "should reject empty key", key: ""},
+		{name: "should reject single letter", key: "W"},
+		{name: "should reject key starting with digit", key: "2WEB"},
+		{name: "should reject punctuation", key: "WEB-APP"},
+		{name: "should reject key longer than maximum", key: "ABCDEFGHIJK"},
+		{name: "should reject key of default project", key: "task"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NormalizeProjectKey(tt.key)
+
+			assert.ErrorIs(t, err, ErrInvalidProject)
+		})
+	}
+}
+
+func TestProject_ID(t *testing.T) {
+	t.Run("should prefix padded sequence with key", func(t *testing.T) {
+		project := &Project{Key: "WEB"}
+
+		assert.Equal(t, "WEB-000042", project.ID(42))
+	})
+}
+
+func TestMovedError(t *testing.T) {
+	t.Run("should match ErrTaskMoved", func(t *testing.T) {
+		err := fmt.Errorf("error finding task: %w", &MovedError{ID: "TASK-000001", MovedTo: "WEB-000001"})
+
+		var moved *MovedError
+		require.ErrorAs(t, err, &moved)
+		assert.ErrorIs(t, err, ErrTaskMoved)
+		assert.Equal(t, "WEB-000001", moved.MovedTo)
+		assert.Equal(t, "error finding task: task moved: TASK-000001 is now WEB-000001", err.Error())
+	})
+}
+
+func TestMemoryProjectRepository(t *testing.T) {
+	testProjectRepository(t, func(t *testing.T) ProjectRepository {
+		return NewMemoryProjectRepository()
+	})
+}
+
+// testProjectRepository runs the behavioral tests every ProjectRepository implementation must pass.
+func testProjectRepository(t *testing.T, newRepository func(t *testing.T) ProjectRepository) {
+	now := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("should create and get project", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+		project := &Project{Key: "WEB", Name: "Website", Description: "Public site", CreatedAt: now, UpdatedAt: now}
+
+		require.NoError(t, repo.Create(ctx, project))
+
+		found, err := repo.Get(ctx, "WEB")
+		require.NoError(t, err)
+		assert.Equal(t, project, found)
+	})
+
+	t.Run("should return error when key is taken", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+		require.NoError(t, repo.Create(ctx, &Project{Key: "WEB", Name: "Website"}))
+
+		err := repo.Create(ctx, &Project{Key: "WEB", Name: "Other"})
+
+		assert.ErrorIs(t, err, ErrProjectExists)
+	})
+
+	t.Run("should return error when project doesn't exist", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		_, err := repo.Get(ctx, "WEB")
+		assert.ErrorIs(t, err, ErrProjectNotFound)
+		assert.ErrorIs(t, repo.Update(ctx, &Project{Key: "WEB", Name: "Website"}), ErrProjectNotFound)
+		assert.ErrorIs(t, repo.Delete(ctx, "WEB"), ErrProjectNotFound)
+		_, err = repo.NextSequence(ctx, "WEB")
+		assert.ErrorIs(t, err, ErrProjectNotFound)
+	})
+
+	t.Run("should list projects sorted by key", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+		require.NoError(t, repo.Create(ctx, &Project{Key: "WEB", Name: "Website"}))
+		require.NoError(t, repo.Create(ctx, &Project{Key: "OPS", Name: "Operations"}))
+
+		projects, err := repo.List(ctx)
+
+		require.NoError(t, err)
+		require.Len(t, projects, 2)
+		assert.Equal(t, "OPS", projects[0].Key)
+		assert.Equal(t, "WEB", projects[1].Key)
+	})
+
+	t.Run("should update project", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+		require.NoError(t, repo.Create(ctx, &Project{Key: "WEB", Name: "Website", CreatedAt: now, UpdatedAt: now}))
+
+		updated := &Project{Key: "WEB", Name: "Web app", Description: "SPA", CreatedAt: now, UpdatedAt: now.Add(time.Hour)}
+		require.NoError(t, repo.Update(ctx, updated))
+
+		found, err := repo.Get(ctx, "WEB")
+		require.NoError(t, err)
+		assert.Equal(t, updated, found)
+	})
+
+	t.Run("should increment sequence per project", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+		require.NoError(t, repo.Create(ctx, &Project{Key: "WEB", Name: "Website"}))
+		require.NoError(t, repo.Create(ctx, &Project{Key: "OPS", Name: "Operations"}))
+
+		for _, want := range []int{1, 2} {
+			sequence, err := repo.NextSequence(ctx, "WEB")
+			require.NoError(t, err)
+			assert.Equal(t, want, sequence)
+		}
+
+		sequence, err := repo.NextSequence(ctx, "OPS")
+		require.NoError(t, err)
+		assert.Equal(t, 1, sequence)
+	})
+
+	t.Run("should restart sequence when project is created again", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+		require.NoError(t, repo.Create(ctx, &Project{Key: "WEB", Name: "Website"}))
+		_, err := repo.NextSequence(ctx, "WEB")
+		require.NoError(t, err)
+
+		require.NoError(t, repo.Delete(ctx, "WEB"))
+		require.NoError(t, repo.Create(ctx, &Project{Key: "WEB", Name: "Website"}))
+
+		sequence, err := repo.NextSequence(ctx, "WEB")
+		require.NoError(t, err)
+		assert.Equal(t, 1, sequence)
+	})
+
+	t.Run("should return error when task was not moved", func(t *testing.T) {
+		repo := newRepository(t)
+
+		_, err := repo.Redirect(context.Background(), "TASK-000001")
+
+		assert.ErrorIs(t, err, ErrTaskNotFound)
+	})
+
+	t.Run("should redirect former IDs to latest ID", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+		require.NoError(t, repo.SaveRedirect(ctx, "TASK-000001", "WEB-000001"))
+		require.NoError(t, repo.SaveRedirect(ctx, "WEB-000001", "OPS-000001"))
+
+		for _, id := range []string{"TASK-000001", "WEB-000001"} {
+			to, err := repo.Redirect(ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, "OPS-000001", to)
+		}
+	})
+
+	t.Run("should drop redirect of ID moved back", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+		require.NoError(t, repo.SaveRedirect(ctx, "TASK-000001", "WEB-000001"))
+		require.NoError(t, repo.SaveRedirect(ctx, "WEB-000001", "TASK-000001"))
+
+		_, err := repo.Redirect(ctx, "TASK-000001")
+		assert.ErrorIs(t, err, ErrTaskNotFound)
+
+		to, err := repo.Redirect(ctx, "WEB-000001")
+		require.NoError(t, err)
+		assert.Equal(t, "TASK-000001", to)
+	})
+}
@@ -0,0 +1,322 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidRecurrence is returned when a recurrence rule has an unknown frequency, an unknown or invalid part
+var ErrInvalidRecurrence = errors.New("invalid recurrence rule")
+
+// Frequency is the period at which a recurring task repeats
+type Frequency string
+
+const (
+	// FrequencyDaily repeats a task every day
+	FrequencyDaily Frequency = "DAILY"
+	// FrequencyWeekly repeats a task every week, weeks start on Monday
+	FrequencyWeekly Frequency = "WEEKLY"
+	// FrequencyMonthly repeats a task every month, on the day of the month it was first due
+	FrequencyMonthly Frequency = "MONTHLY"
+	// FrequencyYearly repeats a task every year, on the day of the year it was first due
+	FrequencyYearly Frequency = "YEARLY"
+)
+
+var frequencies = []Frequency{FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly}
+
+// Frequencies returns every period at which a task can repeat
+func Frequencies() []Frequency {
+	return slices.Clone(frequencies)
+}
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+const (
+	untilLayout     = "20060102T150405Z"
+	untilDateLayout = "20060102"
+)
+
+// Recurrence is the schedule of a recurring task, roughly following the RRULE of RFC 5545
+type Recurrence struct {
+	// Frequency is the period at which the task repeats
+	Frequency Frequency
+	// Interval is the number of periods between two occurrences, 0 is the same as 1
+	Interval int
+	// ByWeekday restricts the occurrences to these days of the week, sorted from Monday and without duplicates.
+	// A daily task skips the other days, a weekly, monthly or yearly task occurs on each of these days of its periods
+	ByWeekday []time.Weekday
+	// Count is the number of occurrences left including the current one, 0 repeats forever.
+	// The next occurrence is given one less
+	Count int
+	// Until is the latest time an occurrence can be due, nil repeats forever
+	Until *time.Time
+}
+
+// ParseRecurrence parses a rule such as FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;COUNT=10 with an optional RRULE: prefix.
+// UNTIL is a UTC time such as 20250630T170000Z or a date such as 20250630 which includes the whole day
+// Returns ErrInvalidRecurrence if a part is unknown or invalid
+func ParseRecurrence(rule string) (*Recurrence, error) {
+	r := &Recurrence{}
+	trimmed := strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(rule)), "RRULE:")
+
+	for part := range strings.SplitSeq(trimmed, ";") {
+		if part == "" {
+			continue
+		}
+
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRecurrence, part)
+		}
+
+		var err error
+		switch name {
+		case "FREQ":
+			r.Frequency = Frequency(value)
+		case "INTERVAL":
+			r.Interval, err = strconv.Atoi(value)
+		case "COUNT":
+			r.Count, err = strconv.Atoi(value)
+		case "UNTIL":
+			r.Until, err = parseUntil(value)
+		case "BYDAY":
+			r.ByWeekday, err = parseWeekdays(value)
+		default:
+			err = errors.New("unknown part")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRecurrence, part)
+		}
+	}
+
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	r.ByWeekday = normalizeWeekdays(r.ByWeekday)
+	return r, nil
+}
+
+func parseUntil(value string) (*time.Time, error) {
+	if until, err := time.Parse(untilLayout, value); err == nil {
+		return &until, nil
+	}
+
+	date, err := time.Parse(untilDateLayout, value)
+	if err != nil {
+		return nil, err
+	}
+
+	until := date.Add(24*time.Hour - time.Second)
+	return &until, nil
+}
+
+func parseWeekdays(value string) ([]time.Weekday, error) {
+	var days []time.Weekday
+	for code := range strings.SplitSeq(value, ",") {
+		day, ok := weekdays[code]
+		if !ok {
+			return nil, fmt.Errorf("unknown weekday %q", code)
+		}
+		days = append(days, day)
+	}
+
+	return days, nil
+}
+
+func normalizeWeekdays(days []time.Weekday) []time.Weekday {
+	if len(days) == 0 {
+		return nil
+	}
+
+	normalized := slices.Clone(days)
+	slices.SortFunc(normalized, func(a, b time.Weekday) int { return weekdayIndex(a) - weekdayIndex(b) })
+	return slices.Compact(normalized)
+}
+
+func weekdayIndex(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
+
+// Validate checks that the frequency is known, that the interval and count are not negative
+// and that count and until are not both set
+// Returns ErrInvalidRecurrence otherwise
+func (r *Recurrence) Validate() error {
+	switch {
+	case !slices.Contains(frequencies, r.Frequency):
+		return fmt.Errorf("%w: unknown frequency %q", ErrInvalidRecurrence, r.Frequency)
+	case r.Interval < 0:
+		return fmt.Errorf("%w: negative interval %d", ErrInvalidRecurrence, r.Interval)
+	case r.Count < 0:
+		return fmt.Errorf("%w: negative count %d", ErrInvalidRecurrence, r.Count)
+	case r.Count > 0 && r.Until != nil:
+		return fmt.Errorf("%w: count and until cannot be combined", ErrInvalidRecurrence)
+	}
+
+	for _, day := range r.ByWeekday {
+		if day < time.Sunday || day > time.Saturday {
+			return fmt.Errorf("%w: unknown weekday %d", ErrInvalidRecurrence, day)
+		}
+	}
+
+	return nil
+}
+
+// String formats the recurrence as a rule without the RRULE: prefix
+func (r *Recurrence) String() string {
+	parts := []string{"FREQ=" + string(r.Frequency)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByWeekday) > 0 {
+		codes := make([]string, 0, len(r.ByWeekday))
+		for _, day := range r.ByWeekday {
+			codes = append(codes, strings.ToUpper(day.String()[:2]))
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(untilLayout))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// Next returns the first occurrence after the given time, at the same time of day,
+// false when the recurrence ends before the next occurrence
+func (r *Recurrence) Next(after time.Time) (time.Time, bool) {
+	interval := max(r.Interval, 1)
+	limit := after.AddDate(8*interval, 0, 7)
+
+	for next := after.AddDate(0, 0, 1); !next.After(limit); next = next.AddDate(0, 0, 1) {
+		if r.Until != nil && next.After(*r.Until) {
+			break
+		}
+		if r.matches(after, next, interval) {
+			return next, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func (r *Recurrence) matches(start, day time.Time, interval int) bool {
+	var period int
+	switch r.Frequency {
+	case FrequencyDaily:
+		period = civilDays(day) - civilDays(start)
+	case FrequencyWeekly:
+		period = (civilDays(day) - civilDays(start) + weekdayIndex(start.Weekday())) / 7
+	case FrequencyMonthly:
+		period = (day.Year()-start.Year())*12 + int(day.Month()) - int(start.Month())
+	case FrequencyYearly:
+		period = day.Year() - start.Year()
+	}
+	if period%interval != 0 {
+		return false
+	}
+
+	if len(r.ByWeekday) > 0 {
+		return slices.Contains(r.ByWeekday, day.Weekday())
+	}
+
+	switch r.Frequency {
+	case FrequencyWeekly:
+		return day.Weekday() == start.Weekday()
+	case FrequencyMonthly:
+		return day.Day() == start.Day()
+	case FrequencyYearly:
+		return day.Month() == start.Month() && day.Day() == start.Day()
+	default:
+		return true
+	}
+}
+
+func civilDays(t time.Time) int {
+	year, month, day := t.Date()
+	return int(time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Unix() / (24 * 60 * 60))
+}
+
+func (r *Recurrence) clone() *Recurrence {
+	if r == nil {
+		return nil
+	}
+
+	c := *r
+	c.ByWeekday = slices.Clone(r.ByWeekday)
+	c.Until = clonePtr(r.Until)
+	return &c
+}
+
+// normalizeRecurrence validates the recurrence of a task, a recurrence without frequency removes it
+func normalizeRecurrence(r *Recurrence) (*Recurrence, error) {
+	if r == nil || r.Frequency == "" {
+		return nil, nil
+	}
+
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	normalized := r.clone()
+	normalized.ByWeekday = normalizeWeekdays(r.ByWeekday)
+	return normalized, nil
+}
+
+// recur moves the recurrence of a task completed by an update to its next occurrence and returns it,
+// nil when the task doesn't recur or its recurrence is over.
+// The next occurrence is due one period after the completed task was due, or after it was completed without due date
+func recur(before, after *Task) *Task {
+	if after.Recurrence == nil || before.Status == StatusCompleted || after.Status != StatusCompleted {
+		return nil
+	}
+
+	rule := after.Recurrence
+	after.Recurrence = nil
+	if rule.Count == 1 {
+		return nil
+	}
+
+	from := *after.CompletedAt
+	if after.DueDate != nil {
+		from = *after.DueDate
+	}
+
+	due, ok := rule.Next(from)
+	if !ok {
+		return nil
+	}
+
+	next := rule.clone()
+	if next.Count > 0 {
+		next.Count--
+	}
+
+	return &Task{
+		Project:     after.Project,
+		Title:       after.Title,
+		Description: after.Description,
+		Priority:    clonePtr(after.Priority),
+		DueDate:     &due,
+		Tags:        slices.Clone(after.Tags),
+		ParentID:    clonePtr(after.ParentID),
+		Recurrence:  next,
+		OwnerID:     after.OwnerID,
+		Assignees:   slices.Clone(after.Assignees),
+	}
+}
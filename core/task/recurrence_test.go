@@ -0,0 +1,233 @@
+package task
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/pkg/idgen"
+	"github.com/utsabbera/task-master/pkg/util"
+	"go.uber.org/mock/gomock"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	t.Run("should parse rule", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			rule     string
+			expected *Recurrence
+		}{
+			{"with frequency only", "FREQ=DAILY", &Recurrence{Frequency: FrequencyDaily}},
+			{"with prefix and lower case", "rrule:freq=weekly;interval=2", &Recurrence{Frequency: FrequencyWeekly, Interval: 2}},
+			{"with weekdays sorted from monday", "FREQ=WEEKLY;BYDAY=SU,MO,WE,MO", &Recurrence{Frequency: FrequencyWeekly, ByWeekday: []time.Weekday{time.Monday, time.Wednesday, time.Sunday}}},
+			{"with count", "FREQ=MONTHLY;COUNT=3", &Recurrence{Frequency: FrequencyMonthly, Count: 3}},
+			{"with until time", "FREQ=YEARLY;UNTIL=20250630T170000Z", &Recurrence{Frequency: FrequencyYearly, Until: util.Ptr(time.Date(2025, 6, 30, 17, 0, 0, 0, time.UTC))}},
+			{"with until date including the whole day", "FREQ=DAILY;UNTIL=20250630", &Recurrence{Frequency: FrequencyDaily, Until: util.Ptr(time.Date(2025, 6, 30, 23, 59, 59, 0, time.UTC))}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				r, err := ParseRecurrence(tt.rule)
+
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, r)
+			})
+		}
+	})
+
+	t.Run("should return error for invalid rule", func(t *testing.T) {
+		tests := []struct {
+			name string
+			rule string
+		}{
+			{"without frequency", "INTERVAL=2"},
+			{"with unknown frequency", "FREQ=HOURLY"},
+			{"with unknown part", "FREQ=DAILY;BYHOUR=9"},
+			{"without value", "FREQ=DAILY;COUNT"},
+			{"with invalid interval", "FREQ=DAILY;INTERVAL=two"},
+			{"with negative count", "FREQ=DAILY;COUNT=-1"},
+			{"with unknown weekday", "FREQ=WEEKLY;BYDAY=XX"},
+			{"with invalid until", "FREQ=DAILY;UNTIL=tomorrow"},
+			{"with count and until", "FREQ=DAILY;COUNT=2;UNTIL=20250630"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := ParseRecurrence(tt.rule)
+
+				assert.ErrorIs(t, err, ErrInvalidRecurrence)
+			})
+		}
+	})
+}
+
+func TestRecurrence_String(t *testing.T) {
+	t.Run("should format rule which parses back", func(t *testing.T) {
+		rule := "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;UNTIL=20250630T170000Z"
+
+		r, err := ParseRecurrence(rule)
+		require.NoError(t, err)
+
+		assert.Equal(t, rule, r.String())
+	})
+
+	t.Run("should omit default interval", func(t *testing.T) {
+		assert.Equal(t, "FREQ=DAILY;COUNT=3", (&Recurrence{Frequency: FrequencyDaily, Interval: 1, Count: 3}).String())
+	})
+}
+
+func TestRecurrence_Next(t *testing.T) {
+	friday := time.Date(2025, 5, 2, 17, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		rule     string
+		after    time.Time
+		expected time.Time
+	}{
+		{"daily", "FREQ=DAILY", friday, time.Date(2025, 5, 3, 17, 0, 0, 0, time.UTC)},
+		{"every third day", "FREQ=DAILY;INTERVAL=3", friday, time.Date(2025, 5, 5, 17, 0, 0, 0, time.UTC)},
+		{"daily on weekdays", "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR", friday, time.Date(2025, 5, 5, 17, 0, 0, 0, time.UTC)},
+		{"weekly", "FREQ=WEEKLY", friday, time.Date(2025, 5, 9, 17, 0, 0, 0, time.UTC)},
+		{"weekly on later day of the week", "FREQ=WEEKLY;BYDAY=TU,SA", friday, time.Date(2025, 5, 3, 17, 0, 0, 0, time.UTC)},
+		{"every other week on earlier day of the week", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO", friday, time.Date(2025, 5, 12, 17, 0, 0, 0, time.UTC)},
+		{"monthly", "FREQ=MONTHLY", friday, time.Date(2025, 6, 2, 17, 0, 0, 0, time.UTC)},
+		{"monthly skipping months without the day", "FREQ=MONTHLY", time.Date(2025, 1, 31, 9, 0, 0, 0, time.UTC), time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)},
+		{"quarterly", "FREQ=MONTHLY;INTERVAL=3", friday, time.Date(2025, 8, 2, 17, 0, 0, 0, time.UTC)},
+		{"yearly", "FREQ=YEARLY", friday, time.Date(2026, 5, 2, 17, 0, 0, 0, time.UTC)},
+		{"yearly on leap day", "FREQ=YEARLY", time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC), time.Date(2028, 2, 29, 9, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run("should return next occurrence "+tt.name, func(t *testing.T) {
+			r, err := ParseRecurrence(tt.rule)
+			require.NoError(t, err)
+
+			next, ok := r.Next(tt.after)
+
+			require.True(t, ok)
+			assert.Equal(t, tt.expected, next)
+		})
+	}
+
+	t.Run("should end after until", func(t *testing.T) {
+		r := &Recurrence{Frequency: FrequencyWeekly, Until: util.Ptr(friday.AddDate(0, 0, 6))}
+
+		_, ok := r.Next(friday)
+
+		assert.False(t, ok)
+	})
+}
+
+func TestService_Update_Recurrence(t *testing.T) {
+	due := time.Date(2025, 5, 2, 17, 0, 0, 0, time.UTC)
+
+	t.Run("should create next occurrence when recurring task is completed", func(t *testing.T) {
+		ctx := context.Background()
+		service := newFixture(t).service
+		require.NoError(t, service.Create(ctx, &Task{
+			Title:      "Take out the trash",
+			Status:     StatusInProgress,
+			Priority:   util.Ptr(PriorityLow),
+			DueDate:    &due,
+			Tags:       []string{"chores"},
+			Recurrence: &Recurrence{Frequency: FrequencyWeekly, Count: 3},
+		}))
+
+		completed, err := service.Update(ctx, "TASK-000001", &Task{Status: StatusCompleted})
+		require.NoError(t, err)
+		assert.Nil(t, completed.Recurrence)
+
+		next, err := service.Get(ctx, "TASK-000002")
+		require.NoError(t, err)
+		assert.Equal(t, "Take out the trash", next.Title)
+		assert.Equal(t, StatusNotStarted, next.Status)
+		assert.Equal(t, util.Ptr(PriorityLow), next.Priority)
+		assert.Equal(t, []string{"chores"}, next.Tags)
+		assert.Equal(t, util.Ptr(due.AddDate(0, 0, 7)), next.DueDate)
+		assert.Equal(t, &Recurrence{Frequency: FrequencyWeekly, Count: 2}, next.Recurrence)
+	})
+
+	t.Run("should schedule next occurrence from completion without due date", func(t *testing.T) {
+		ctx := context.Background()
+		service := newFixture(t).service
+		require.NoError(t, service.Create(ctx, &Task{Title: "Water plants", Status: StatusInProgress, Recurrence: &Recurrence{Frequency: FrequencyDaily, Interval: 2}}))
+
+		completed, err := service.Update(ctx, "TASK-000001", &Task{Status: StatusCompleted})
+		require.NoError(t, err)
+
+		next, err := service.Get(ctx, "TASK-000002")
+		require.NoError(t, err)
+		assert.Equal(t, util.Ptr(completed.CompletedAt.AddDate(0, 0, 2)), next.DueDate)
+	})
+
+	t.Run("should not create next occurrence after last one", func(t *testing.T) {
+		ctx := context.Background()
+		service := newFixture(t).service
+		require.NoError(t, service.Create(ctx, &Task{Title: "Pay rent", Status: StatusInProgress, DueDate: &due, Recurrence: &Recurrence{Frequency: FrequencyMonthly, Count: 1}}))
+
+		_, err := service.Update(ctx, "TASK-000001", &Task{Status: StatusCompleted})
+		require.NoError(t, err)
+
+		page, err := service.List(ctx, ListOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"TASK-000001"}, taskIDs(page.Tasks))
+	})
+
+	t.Run("should not create next occurrence again when reopened task is completed", func(t *testing.T) {
+		ctx := context.Background()
+		service := newFixture(t).service
+		require.NoError(t, service.Create(ctx, &Task{Title: "Pay rent", Status: StatusInProgress, DueDate: &due, Recurrence: &Recurrence{Frequency: FrequencyMonthly}}))
+
+		for _, status := range []Status{StatusCompleted, StatusInProgress, StatusCompleted} {
+			_, err := service.Update(ctx, "TASK-000001", &Task{Status: status})
+			require.NoError(t, err)
+		}
+
+		page, err := service.List(ctx, ListOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"TASK-000001", "TASK-000002"}, taskIDs(page.Tasks))
+	})
+
+	t.Run("should keep recurring task open when next occurrence cannot be created", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		ids := idgen.NewMockGenerator(ctrl)
+		ids.EXPECT().Next().Return("TASK-000001")
+		ids.EXPECT().Next().Return("")
+		service := NewService(NewMemoryRepository(), ids, newTickingClock(ctrl))
+		require.NoError(t, service.Create(ctx, &Task{Title: "Pay rent", Status: StatusInProgress, DueDate: &due, Recurrence: &Recurrence{Frequency: FrequencyMonthly}}))
+
+		_, err := service.Update(ctx, "TASK-000001", &Task{Status: StatusCompleted})
+		assert.ErrorIs(t, err, ErrInvalidTask)
+
+		task, err := service.Get(ctx, "TASK-000001")
+		require.NoError(t, err)
+		assert.Equal(t, StatusInProgress, task.Status)
+		assert.Equal(t, &Recurrence{Frequency: FrequencyMonthly}, task.Recurrence)
+		assert.Equal(t, 1, task.Version)
+	})
+
+	t.Run("should remove recurrence without frequency", func(t *testing.T) {
+		ctx := context.Background()
+		service := newFixture(t).service
+		require.NoError(t, service.Create(ctx, &Task{Title: "Pay rent", Recurrence: &Recurrence{Frequency: FrequencyMonthly}}))
+
+		task, err := service.Update(ctx, "TASK-000001", &Task{Recurrence: &Recurrence{}})
+
+		require.NoError(t, err)
+		assert.Nil(t, task.Recurrence)
+	})
+
+	t.Run("should return error for invalid recurrence", func(t *testing.T) {
+		ctx := context.Background()
+		service := newFixture(t).service
+		require.NoError(t, service.Create(ctx, &Task{Title: "Pay rent"}))
+
+		_, err := service.Update(ctx, "TASK-000001", &Task{Recurrence: &Recurrence{Frequency: "HOURLY"}})
+
+		assert.ErrorIs(t, err, ErrInvalidRecurrence)
+	})
+}
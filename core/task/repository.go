@@ -1,7 +1,10 @@
 package task
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"slices"
 	"sync"
 )
 
@@ -10,35 +13,49 @@ var (
 	ErrTaskNotFound = errors.New("task not found")
 	// ErrInvalidTask is returned when an operation is performed on an invalid task
 	ErrInvalidTask = errors.New("invalid task")
+	// ErrConflict is returned when a task doesn't have the version an operation expects
+	ErrConflict = errors.New("task version conflict")
 )
 
 //go:generate mockgen -destination=repository_mock.go -package=task . Repository
 
 // Repository defines the interface for task data storage operations
 type Repository interface {
-	// Create stores a new task in the repository and assigns it a unique ID
-	Create(task *Task) error
+	// Create stores a new task in the repository and sets its version to 1
+	Create(ctx context.Context, task *Task) error
 
 	// Get retrieves a task by its ID
 	// Returns ErrTaskNotFound if the task doesn't exist
-	Get(id string) (*Task, error)
+	Get(ctx context.Context, id string) (*Task, error)
 
-	// List returns all tasks stored in the repository
-	List() ([]*Task, error)
+	// List returns the page of tasks matching the options, in a stable order
+	// Returns ErrInvalidListOptions if the cursor is malformed
+	List(ctx context.Context, opts ListOptions) (*Page, error)
 
-	// Update modifies an existing task in the repository
+	// Update modifies an existing task in the repository and increments its version
 	// Returns ErrTaskNotFound if the task doesn't exist
-	Update(task *Task) error
+	// Returns ErrConflict if the stored task doesn't have the version of the given task, unless it is zero
+	Update(ctx context.Context, task *Task) error
 
-	// Delete removes a task from the repository
+	// Delete removes a task from the repository along with its ID from the blockers of the other tasks
 	// Returns ErrTaskNotFound if the task doesn't exist
-	Delete(id string) error
+	// Returns ErrConflict if the stored task doesn't have the given version, unless it is zero
+	Delete(ctx context.Context, id string, version int) error
+
+	// Tags returns every tag of the stored tasks with the number of tasks having it, sorted by tag
+	Tags(ctx context.Context) ([]TagCount, error)
+
+	// Transaction calls fn with a unit of work whose changes are applied all together when fn returns nil,
+	// and discarded when it returns an error, which is returned. The unit of work sees its own changes
+	// and must not be used once fn returns, nor the repository while fn runs
+	Transaction(ctx context.Context, fn func(tx Repository) error) error
 }
 
 // MemoryRepository is an in-memory implementation of Repository
 // that stores tasks in a map and uses an ID generator for task IDs
 type MemoryRepository struct {
 	tasks map[string]*Task
+	undo  map[string]*Task
 	mu    sync.RWMutex
 }
 
@@ -49,7 +66,7 @@ func NewMemoryRepository() *MemoryRepository {
 	}
 }
 
-func (r *MemoryRepository) Create(t *Task) error {
+func (r *MemoryRepository) Create(_ context.Context, t *Task) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -57,11 +74,12 @@ func (r *MemoryRepository) Create(t *Task) error {
 		return ErrInvalidTask
 	}
 
-	r.tasks[t.ID] = t
+	t.Version = 1
+	r.set(t.ID, t.clone())
 	return nil
 }
 
-func (r *MemoryRepository) Get(id string) (*Task, error) {
+func (r *MemoryRepository) Get(ctx context.Context, id string) (*Task, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -70,41 +88,159 @@ func (r *MemoryRepository) Get(id string) (*Task, error) {
 		return nil, ErrTaskNotFound
 	}
 
-	return t, nil
+	return t.clone(), nil
 }
 
-func (r *MemoryRepository) List() ([]*Task, error) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+func (r *MemoryRepository) List(_ context.Context, opts ListOptions) (*Page, error) {
+	after, err := decodeCursor(opts.Cursor)
+	if err != nil {
+		return nil, err
+	}
 
+	r.mu.RLock()
 	tasks := make([]*Task, 0, len(r.tasks))
 	for _, t := range r.tasks {
-		tasks = append(tasks, t)
+		if opts.Filter.Matches(t) {
+			tasks = append(tasks, t.clone())
+		}
 	}
+	r.mu.RUnlock()
+
+	slices.SortFunc(tasks, opts.compare)
 
-	return tasks, nil
+	return paginate(tasks, opts, after), nil
 }
 
-func (r *MemoryRepository) Update(t *Task) error {
+func (r *MemoryRepository) Update(_ context.Context, t *Task) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, exists := r.tasks[t.ID]; !exists {
+	current, exists := r.tasks[t.ID]
+	if !exists {
 		return ErrTaskNotFound
 	}
 
-	r.tasks[t.ID] = t
+	if err := checkVersion(t.Version, current.Version); err != nil {
+		return err
+	}
+
+	t.Version = current.Version + 1
+	r.set(t.ID, t.clone())
 	return nil
 }
 
-func (r *MemoryRepository) Delete(id string) error {
+func (r *MemoryRepository) Delete(ctx context.Context, id string, version int) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, exists := r.tasks[id]; !exists {
+	current, exists := r.tasks[id]
+	if !exists {
 		return ErrTaskNotFound
 	}
 
-	delete(r.tasks, id)
+	if err := checkVersion(version, current.Version); err != nil {
+		return err
+	}
+
+	r.deleteTask(id)
+	return nil
+}
+
+func (r *MemoryRepository) put(t *Task) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.set(t.ID, t.clone())
+}
+
+func (r *MemoryRepository) remove(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deleteTask(id)
+}
+
+func (r *MemoryRepository) deleteTask(id string) {
+	r.set(id, nil)
+	for _, t := range r.tasks {
+		if slices.Contains(t.BlockedBy, id) {
+			unblocked := t.clone()
+			unblocked.BlockedBy = slices.DeleteFunc(unblocked.BlockedBy, func(blocker string) bool { return blocker == id })
+			r.set(t.ID, unblocked)
+		}
+	}
+}
+
+// set stores the task under the ID, removing the task when nil. Within a transaction,
+// the task the ID had before its first change is kept for the transaction to be undone
+func (r *MemoryRepository) set(id string, t *Task) {
+	if r.undo != nil {
+		if _, changed := r.undo[id]; !changed {
+			r.undo[id] = r.tasks[id]
+		}
+	}
+
+	if t == nil {
+		delete(r.tasks, id)
+		return
+	}
+
+	r.tasks[id] = t
+}
+
+func (r *MemoryRepository) Tags(_ context.Context) ([]TagCount, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tasks := make([]*Task, 0, len(r.tasks))
+	for _, t := range r.tasks {
+		tasks = append(tasks, t)
+	}
+
+	return countTags(tasks), nil
+}
+
+// Transaction calls fn with a unit of work changing the tasks in place, whose changes are undone unless fn returns nil.
+// The repository is locked until fn returns
+func (r *MemoryRepository) Transaction(_ context.Context, fn func(tx Repository) error) error {
+	return r.transaction(func(tx *MemoryRepository) error { return fn(tx) })
+}
+
+func (r *MemoryRepository) transaction(fn func(tx *MemoryRepository) error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tx := &MemoryRepository{tasks: r.tasks, undo: make(map[string]*Task)}
+	committed := false
+	defer func() {
+		if !committed {
+			tx.rollback()
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	committed = true
+	return nil
+}
+
+// rollback restores the tasks changed by the transaction
+func (r *MemoryRepository) rollback() {
+	for id, t := range r.undo {
+		if t == nil {
+			delete(r.tasks, id)
+		} else {
+			r.tasks[id] = t
+		}
+	}
+}
+
+func checkVersion(expected, actual int) error {
+	if expected != 0 && expected != actual {
+		return fmt.Errorf("%w: expected version %d, found %d", ErrConflict, expected, actual)
+	}
+
 	return nil
 }
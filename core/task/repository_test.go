@@ -1,83 +1,152 @@
 package task
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/pkg/util"
 )
 
-func TestMemoryRepository_Create(t *testing.T) {
-	t.Run("should return error when id is empty", func(t *testing.T) {
+func TestMemoryRepository(t *testing.T) {
+	testRepository(t, func(t *testing.T) Repository {
+		return NewMemoryRepository()
+	})
+
+	t.Run("should undo the changes of a panicking unit of work", func(t *testing.T) {
 		repo := NewMemoryRepository()
+		ctx := context.Background()
+		require.NoError(t, repo.Create(ctx, &Task{ID: "A", Title: "Write report"}))
+		require.NoError(t, repo.Create(ctx, &Task{ID: "B", Title: "Buy milk", BlockedBy: []string{"A"}}))
+
+		assert.Panics(t, func() {
+			_ = repo.Transaction(ctx, func(tx Repository) error {
+				require.NoError(t, tx.Delete(ctx, "A", 0))
+				panic("unexpected")
+			})
+		})
+
+		page, err := repo.List(ctx, ListOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"A", "B"}, taskIDs(page.Tasks))
+		assert.Equal(t, []string{"A"}, page.Tasks[1].BlockedBy)
+	})
+}
+
+// testRepository runs the behavioral tests every Repository implementation must pass.
+func testRepository(t *testing.T, newRepository func(t *testing.T) Repository) {
+	t.Run("Create", func(t *testing.T) { testRepositoryCreate(t, newRepository) })
+	t.Run("Get", func(t *testing.T) { testRepositoryGet(t, newRepository) })
+	t.Run("List", func(t *testing.T) { testRepositoryList(t, newRepository) })
+	t.Run("Update", func(t *testing.T) { testRepositoryUpdate(t, newRepository) })
+	t.Run("Delete", func(t *testing.T) { testRepositoryDelete(t, newRepository) })
+	t.Run("Tags", func(t *testing.T) { testRepositoryTags(t, newRepository) })
+	t.Run("Transaction", func(t *testing.T) { testRepositoryTransaction(t, newRepository) })
+}
+
+func testRepositoryCreate(t *testing.T, newRepository func(t *testing.T) Repository) {
+	t.Run("should return error when id is empty", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
 		priority := PriorityMedium
-		due := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+		due := time.Now().UTC().Add(24 * time.Hour).Truncate(time.Second)
 		task := NewTask("Test Task", "Description", &priority, &due)
 
-		err := repo.Create(task)
+		err := repo.Create(ctx, task)
 
 		require.Error(t, err)
 		assert.ErrorIs(t, err, ErrInvalidTask)
 	})
 
 	t.Run("should preserve existing ID", func(t *testing.T) {
-		repo := NewMemoryRepository()
+		repo := newRepository(t)
+		ctx := context.Background()
 		task := &Task{ID: "CUSTOM-ID", Title: "Test Task", Description: "Description"}
 
-		err := repo.Create(task)
+		err := repo.Create(ctx, task)
 		assert.NoError(t, err)
 
-		storedTask, err := repo.Get("CUSTOM-ID")
+		storedTask, err := repo.Get(ctx, "CUSTOM-ID")
 		require.NoError(t, err)
 		assert.Equal(t, task, storedTask)
 	})
 
 	t.Run("should create task without priority", func(t *testing.T) {
-		repo := NewMemoryRepository()
-		due := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+		repo := newRepository(t)
+		ctx := context.Background()
+		due := time.Now().UTC().Add(24 * time.Hour).Truncate(time.Second)
 		task := &Task{ID: "B", Title: "No Priority", Description: "desc", DueDate: &due}
 
-		err := repo.Create(task)
+		err := repo.Create(ctx, task)
 		require.NoError(t, err)
-		storedTask, err := repo.Get("B")
+		storedTask, err := repo.Get(ctx, "B")
 
 		require.NoError(t, err)
 		assert.Nil(t, storedTask.Priority)
 	})
 
 	t.Run("should create task without dueDate", func(t *testing.T) {
-		repo := NewMemoryRepository()
+		repo := newRepository(t)
+		ctx := context.Background()
 		priority := PriorityMedium
 		task := &Task{ID: "C", Title: "No DueDate", Description: "desc", Priority: &priority}
 
-		err := repo.Create(task)
+		err := repo.Create(ctx, task)
 		require.NoError(t, err)
 		assert.Equal(t, "C", task.ID)
 
-		storedTask, err := repo.Get("C")
+		storedTask, err := repo.Get(ctx, "C")
 		require.NoError(t, err)
 		assert.Nil(t, storedTask.DueDate)
 	})
 }
 
-func TestMemoryRepository_Get(t *testing.T) {
+func testRepositoryGet(t *testing.T, newRepository func(t *testing.T) Repository) {
 	t.Run("should return task with matching ID", func(t *testing.T) {
-		repo := NewMemoryRepository()
+		repo := newRepository(t)
+		ctx := context.Background()
 		task := &Task{ID: "A", Title: "Test Task", Description: "Description"}
 
-		require.NoError(t, repo.Create(task))
+		require.NoError(t, repo.Create(ctx, task))
+
+		result, err := repo.Get(ctx, task.ID)
+
+		require.NoError(t, err)
+		assert.Equal(t, task, result)
+	})
+
+	t.Run("should return task with all fields", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		priority := PriorityHigh
+		due := time.Date(2025, 6, 1, 17, 30, 0, 0, time.UTC)
+		task := &Task{
+			ID:          "WEB-000001",
+			Project:     "WEB",
+			Title:       "Test Task",
+			Description: "Description",
+			Status:      StatusInProgress,
+			Priority:    &priority,
+			DueDate:     &due,
+			CreatedAt:   time.Date(2025, 5, 1, 9, 0, 0, 123456789, time.UTC),
+			UpdatedAt:   time.Date(2025, 5, 2, 9, 0, 0, 0, time.UTC),
+		}
+
+		require.NoError(t, repo.Create(ctx, task))
 
-		result, err := repo.Get(task.ID)
+		result, err := repo.Get(ctx, task.ID)
 
 		require.NoError(t, err)
 		assert.Equal(t, task, result)
 	})
 
 	t.Run("should return error when task not found", func(t *testing.T) {
-		repo := NewMemoryRepository()
+		repo := newRepository(t)
+		ctx := context.Background()
 
-		result, err := repo.Get("non-existent")
+		result, err := repo.Get(ctx, "non-existent")
 
 		assert.Error(t, err)
 		assert.ErrorIs(t, err, ErrTaskNotFound)
@@ -85,94 +154,625 @@ func TestMemoryRepository_Get(t *testing.T) {
 	})
 }
 
-func TestMemoryRepository_List(t *testing.T) {
+func testRepositoryList(t *testing.T, newRepository func(t *testing.T) Repository) {
 	t.Run("should return all tasks", func(t *testing.T) {
-		repo := NewMemoryRepository()
+		repo := newRepository(t)
+		ctx := context.Background()
 		task1 := &Task{ID: "task1-id", Title: "Task 1", Description: "Description 1"}
 		task2 := &Task{ID: "task2-id", Title: "Task 2", Description: "Description 2"}
 
-		require.NoError(t, repo.Create(task1))
-		require.NoError(t, repo.Create(task2))
+		require.NoError(t, repo.Create(ctx, task1))
+		require.NoError(t, repo.Create(ctx, task2))
 
-		results, err := repo.List()
+		page, err := repo.List(ctx, ListOptions{})
 
 		require.NoError(t, err)
-		assert.Len(t, results, 2)
+		assert.Equal(t, []*Task{task1, task2}, page.Tasks)
+		assert.Equal(t, 2, page.Total)
+		assert.Empty(t, page.NextCursor)
+	})
 
-		foundTask1, foundTask2 := false, false
-		for _, task := range results {
-			if task.ID == task1.ID {
-				foundTask1 = true
-			}
-			if task.ID == task2.ID {
-				foundTask2 = true
-			}
+	t.Run("should return empty slice when no tasks", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		page, err := repo.List(ctx, ListOptions{})
+
+		require.NoError(t, err)
+		assert.NotNil(t, page.Tasks)
+		assert.Empty(t, page.Tasks)
+		assert.Zero(t, page.Total)
+	})
+
+	t.Run("should order tasks by creation time by default", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		createListTasks(t, repo)
+
+		page, err := repo.List(ctx, ListOptions{})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"A", "B", "C", "D"}, taskIDs(page.Tasks))
+	})
+
+	t.Run("should filter tasks", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		createListTasks(t, repo)
+
+		tests := []struct {
+			name     string
+			filter   Filter
+			expected []string
+		}{
+			{"by status", Filter{Statuses: []Status{StatusInProgress, StatusCompleted}}, []string{"B", "C"}},
+			{"by priority", Filter{Priorities: []Priority{PriorityHigh}}, []string{"A", "C"}},
+			{"by due before", Filter{DueBefore: util.Ptr(listTime.Add(48 * time.Hour))}, []string{"A", "C"}},
+			{"by due after", Filter{DueAfter: util.Ptr(listTime.Add(24 * time.Hour))}, []string{"B"}},
+			{"by due date presence", Filter{HasDueDate: true}, []string{"A", "B", "C"}},
+			{"by IDs", Filter{IDs: []string{"D", "B", "E"}}, []string{"B", "D"}},
+			{"by title text ignoring case", Filter{Query: "REPORT"}, []string{"A", "D"}},
+			{"by text ignoring case of non-ASCII letters", Filter{Query: "été"}, []string{"B"}},
+			{"by description text", Filter{Query: "budget"}, []string{"B"}},
+			{"by every condition", Filter{Statuses: []Status{StatusNotStarted}, Query: "report"}, []string{"A", "D"}},
+			{"by tag", Filter{Tags: []string{"urgent"}}, []string{"A", "C"}},
+			{"by every tag", Filter{Tags: []string{"urgent", "backend"}}, []string{"C"}},
+			{"by excluded tag", Filter{ExcludedTags: []string{"urgent"}}, []string{"B", "D"}},
+			{"by tag and excluded tag", Filter{Tags: []string{"urgent"}, ExcludedTags: []string{"backend"}}, []string{"A"}},
+			{"by parent", Filter{Parents: []string{"A", "B"}}, []string{"D"}},
+			{"by blocker", Filter{Blockers: []string{"B", "C"}}, []string{"D"}},
+			{"by project", Filter{Projects: []string{"WEB"}}, []string{"B"}},
+			{"by default project", Filter{Projects: []string{""}}, []string{"A", "C", "D"}},
+			{"by owner", Filter{Owners: []string{"bob"}}, []string{"B"}},
+			{"by assignee", Filter{Assignees: []string{"carol", "dave"}}, []string{"C"}},
+			{"by owner and assignee", Filter{Owners: []string{"alice"}, Assignees: []string{"bob"}}, []string{"A", "C"}},
 		}
 
-		assert.True(t, foundTask1)
-		assert.True(t, foundTask2)
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				page, err := repo.List(ctx, ListOptions{Filter: tt.filter})
+
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, taskIDs(page.Tasks))
+				assert.Equal(t, len(tt.expected), page.Total)
+			})
+		}
 	})
 
-	t.Run("should return empty slice when no tasks", func(t *testing.T) {
-		repo := NewMemoryRepository()
+	t.Run("should filter tasks by expression", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		createListTasks(t, repo)
+
+		tests := []struct {
+			expression string
+			expected   []string
+		}{
+			{"status != COMPLETED and (priority = HIGH or due < now+3d) and tag:urgent", []string{"A"}},
+			{"status = completed", []string{"C"}},
+			{"priority >= MEDIUM", []string{"A", "C"}},
+			{"priority < HIGH", []string{"B"}},
+			{"priority != HIGH", []string{"B", "D"}},
+			{"priority = null", []string{"D"}},
+			{"not due <= now+1d", []string{"B", "D"}},
+			{"due > today and due != now", []string{"A", "B"}},
+			{`created > "2025-05-01T09:01:00Z"`, []string{"C", "D"}},
+			{"title ~ REPORT", []string{"A", "D"}},
+			{"description ~ été", []string{"B"}},
+			{`description:"for q3"`, []string{"B"}},
+			{`title = "Fix login"`, []string{"C"}},
+			{"tag != urgent", []string{"B", "D"}},
+			{"owner = me", []string{"A", "C"}},
+			{"assignee:carol or project:web", []string{"B", "C"}},
+			{`project = ""`, []string{"A", "C", "D"}},
+			{"parent = A", []string{"D"}},
+			{"parent != A", []string{"A", "B", "C"}},
+			{"not (id = B or id = D)", []string{"A", "C"}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.expression, func(t *testing.T) {
+				expression, err := ParseExpression(tt.expression)
+				require.NoError(t, err)
+				expression, err = expression.bind(WithUser(ctx, User{ID: "alice"}), listTime)
+				require.NoError(t, err)
+
+				page, err := repo.List(ctx, ListOptions{Filter: Filter{Expression: expression}})
+
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, taskIDs(page.Tasks))
+				assert.Equal(t, len(tt.expected), page.Total)
+			})
+		}
+	})
+
+	t.Run("should sort tasks", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		createListTasks(t, repo)
+
+		tests := []struct {
+			name     string
+			sort     string
+			expected []string
+		}{
+			{"by due date with missing dates last", "dueDate", []string{"C", "A", "B", "D"}},
+			{"by due date descending with missing dates last", "-dueDate", []string{"B", "A", "C", "D"}},
+			{"by priority", "priority", []string{"B", "A", "C", "D"}},
+			{"by priority descending", "-priority", []string{"A", "C", "B", "D"}},
+			{"by title", "title", []string{"C", "B", "D", "A"}},
+			{"by updated time descending", "-updatedAt", []string{"D", "C", "B", "A"}},
+			{"by several fields", "-priority,-dueDate", []string{"A", "C", "B", "D"}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				sort, err := ParseSort(tt.sort)
+				require.NoError(t, err)
 
-		results, err := repo.List()
+				page, err := repo.List(ctx, ListOptions{Sort: sort})
 
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, taskIDs(page.Tasks))
+			})
+		}
+	})
+
+	t.Run("should paginate tasks with cursor", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		createListTasks(t, repo)
+		opts := ListOptions{Sort: []Sort{{Field: SortByTitle}}, Limit: 3}
+
+		first, err := repo.List(ctx, opts)
 		require.NoError(t, err)
-		assert.Empty(t, results)
+		assert.Equal(t, []string{"C", "B", "D"}, taskIDs(first.Tasks))
+		assert.Equal(t, 4, first.Total)
+		require.NotEmpty(t, first.NextCursor)
+
+		opts.Cursor = first.NextCursor
+		second, err := repo.List(ctx, opts)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"A"}, taskIDs(second.Tasks))
+		assert.Equal(t, 4, second.Total)
+		assert.Empty(t, second.NextCursor)
+	})
+
+	t.Run("should page through tasks one at a time in every order", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		createListTasks(t, repo)
+
+		for _, value := range []string{"dueDate", "-dueDate", "priority", "-priority", "title", "-updatedAt", "-priority,-dueDate"} {
+			t.Run(value, func(t *testing.T) {
+				sort, err := ParseSort(value)
+				require.NoError(t, err)
+				all, err := repo.List(ctx, ListOptions{Sort: sort})
+				require.NoError(t, err)
+
+				var ids []string
+				opts := ListOptions{Sort: sort, Limit: 1}
+				for {
+					page, err := repo.List(ctx, opts)
+					require.NoError(t, err)
+					ids = append(ids, taskIDs(page.Tasks)...)
+					if page.NextCursor == "" {
+						break
+					}
+					opts.Cursor = page.NextCursor
+				}
+
+				assert.Equal(t, taskIDs(all.Tasks), ids)
+			})
+		}
+	})
+
+	t.Run("should continue after last task of page when tasks are created before it", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		createListTasks(t, repo)
+		opts := ListOptions{Sort: []Sort{{Field: SortByTitle}}, Limit: 2}
+
+		first, err := repo.List(ctx, opts)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"C", "B"}, taskIDs(first.Tasks))
+		require.NoError(t, repo.Create(ctx, &Task{ID: "E", Title: "Archive report", CreatedAt: listTime}))
+
+		opts.Cursor = first.NextCursor
+		second, err := repo.List(ctx, opts)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"D", "A"}, taskIDs(second.Tasks))
+		assert.Equal(t, 5, second.Total)
+	})
+
+	t.Run("should return error when cursor is malformed", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		page, err := repo.List(ctx, ListOptions{Cursor: "not a cursor"})
+
+		assert.ErrorIs(t, err, ErrInvalidListOptions)
+		assert.Nil(t, page)
 	})
 }
 
-func TestMemoryRepository_Update(t *testing.T) {
+var listTime = time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+
+func createListTasks(t *testing.T, repo Repository) {
+	t.Helper()
+
+	tasks := []*Task{
+		{ID: "A", Title: "Write report", Status: StatusNotStarted, Priority: util.Ptr(PriorityHigh), DueDate: util.Ptr(listTime.Add(24 * time.Hour)), Tags: []string{"docs", "urgent"}, OwnerID: "alice", Assignees: []string{"bob"}},
+		{ID: "B", Title: "Plan budget", Description: "Budget for Q3, ÉTÉ included", Status: StatusInProgress, Priority: util.Ptr(PriorityLow), DueDate: util.Ptr(listTime.Add(72 * time.Hour)), Tags: []string{"finance"}, OwnerID: "bob", Project: "WEB"},
+		{ID: "C", Title: "Fix login", Status: StatusCompleted, Priority: util.Ptr(PriorityHigh), DueDate: util.Ptr(listTime), Tags: []string{"backend", "urgent"}, OwnerID: "alice", Assignees: []string{"bob", "carol"}},
+		{ID: "D", Title: "Review report", Status: StatusNotStarted, ParentID: util.Ptr("A"), BlockedBy: []string{"A", "C"}},
+	}
+
+	for i, task := range tasks {
+		task.CreatedAt = listTime.Add(time.Duration(i) * time.Minute)
+		task.UpdatedAt = task.CreatedAt
+		require.NoError(t, repo.Create(context.Background(), task))
+	}
+}
+
+func taskIDs(tasks []*Task) []string {
+	ids := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+
+	return ids
+}
+
+func testRepositoryUpdate(t *testing.T, newRepository func(t *testing.T) Repository) {
 	t.Run("should update existing task", func(t *testing.T) {
-		repo := NewMemoryRepository()
+		repo := newRepository(t)
+		ctx := context.Background()
 		task := &Task{ID: "task-id", Title: "Original Title", Description: "Description"}
 
-		require.NoError(t, repo.Create(task))
+		require.NoError(t, repo.Create(ctx, task))
 
 		task.Title = "Updated Title"
 		task.Status = StatusInProgress
 
-		err := repo.Update(task)
+		err := repo.Update(ctx, task)
 		require.NoError(t, err)
 
-		updated, err := repo.Get(task.ID)
+		updated, err := repo.Get(ctx, task.ID)
 		require.NoError(t, err)
 		assert.Equal(t, "Updated Title", updated.Title)
 		assert.Equal(t, StatusInProgress, updated.Status)
 	})
 
+	t.Run("should update and clear parent", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		require.NoError(t, repo.Create(ctx, &Task{ID: "parent-id", Title: "Parent"}))
+		task := &Task{ID: "task-id", Title: "Child"}
+		require.NoError(t, repo.Create(ctx, task))
+
+		task.ParentID = util.Ptr("parent-id")
+		require.NoError(t, repo.Update(ctx, task))
+
+		updated, err := repo.Get(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, util.Ptr("parent-id"), updated.ParentID)
+
+		task.ParentID = nil
+		require.NoError(t, repo.Update(ctx, task))
+
+		updated, err = repo.Get(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Nil(t, updated.ParentID)
+	})
+
+	t.Run("should update and remove recurrence", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		task := &Task{ID: "task-id", Title: "Pay rent"}
+		require.NoError(t, repo.Create(ctx, task))
+
+		task.Recurrence = &Recurrence{Frequency: FrequencyWeekly, Interval: 2, ByWeekday: []time.Weekday{time.Monday}, Count: 3}
+		require.NoError(t, repo.Update(ctx, task))
+
+		updated, err := repo.Get(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, task.Recurrence, updated.Recurrence)
+
+		task.Recurrence = nil
+		require.NoError(t, repo.Update(ctx, task))
+
+		updated, err = repo.Get(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Nil(t, updated.Recurrence)
+	})
+
+	t.Run("should replace blockers", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		require.NoError(t, repo.Create(ctx, &Task{ID: "A", Title: "Design"}))
+		require.NoError(t, repo.Create(ctx, &Task{ID: "B", Title: "Review"}))
+		task := &Task{ID: "C", Title: "Build", BlockedBy: []string{"A"}}
+		require.NoError(t, repo.Create(ctx, task))
+
+		task.BlockedBy = []string{"A", "B"}
+		require.NoError(t, repo.Update(ctx, task))
+
+		updated, err := repo.Get(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"A", "B"}, updated.BlockedBy)
+
+		task.BlockedBy = nil
+		require.NoError(t, repo.Update(ctx, task))
+
+		updated, err = repo.Get(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Empty(t, updated.BlockedBy)
+	})
+
+	t.Run("should replace owner and assignees", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		task := &Task{ID: "A", Title: "Design", OwnerID: "alice", Assignees: []string{"bob"}}
+		require.NoError(t, repo.Create(ctx, task))
+
+		task.OwnerID = "carol"
+		task.Assignees = []string{"alice", "bob"}
+		require.NoError(t, repo.Update(ctx, task))
+
+		updated, err := repo.Get(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "carol", updated.OwnerID)
+		assert.Equal(t, []string{"alice", "bob"}, updated.Assignees)
+
+		task.Assignees = nil
+		require.NoError(t, repo.Update(ctx, task))
+
+		updated, err = repo.Get(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Empty(t, updated.Assignees)
+	})
+
 	t.Run("should return error when task does not exist", func(t *testing.T) {
-		repo := NewMemoryRepository()
+		repo := newRepository(t)
+		ctx := context.Background()
 		task := &Task{ID: "non-existent", Title: "Original Title", Description: "Description"}
 
-		err := repo.Update(task)
+		err := repo.Update(ctx, task)
 
 		assert.Error(t, err)
 		assert.ErrorIs(t, err, ErrTaskNotFound)
 	})
+
+	t.Run("should increment version", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		task := &Task{ID: "task-id", Title: "Original Title"}
+
+		require.NoError(t, repo.Create(ctx, task))
+		assert.Equal(t, 1, task.Version)
+
+		require.NoError(t, repo.Update(ctx, task))
+		assert.Equal(t, 2, task.Version)
+
+		stored, err := repo.Get(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, stored.Version)
+	})
+
+	t.Run("should return conflict when version is stale", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		require.NoError(t, repo.Create(ctx, &Task{ID: "task-id", Title: "Original Title"}))
+
+		first, err := repo.Get(ctx, "task-id")
+		require.NoError(t, err)
+		second, err := repo.Get(ctx, "task-id")
+		require.NoError(t, err)
+
+		first.Title = "First"
+		require.NoError(t, repo.Update(ctx, first))
+
+		second.Title = "Second"
+		err = repo.Update(ctx, second)
+
+		assert.ErrorIs(t, err, ErrConflict)
+		stored, err := repo.Get(ctx, "task-id")
+		require.NoError(t, err)
+		assert.Equal(t, "First", stored.Title)
+	})
 }
 
-func TestMemoryRepository_Delete(t *testing.T) {
+func testRepositoryDelete(t *testing.T, newRepository func(t *testing.T) Repository) {
 	t.Run("should delete existing task", func(t *testing.T) {
-		repo := NewMemoryRepository()
+		repo := newRepository(t)
+		ctx := context.Background()
 		task := &Task{ID: "A", Title: "Test Task", Description: "Description"}
 
-		require.NoError(t, repo.Create(task))
+		require.NoError(t, repo.Create(ctx, task))
 
-		err := repo.Delete(task.ID)
+		err := repo.Delete(ctx, task.ID, 0)
 		require.NoError(t, err)
 
-		_, err = repo.Get(task.ID)
+		_, err = repo.Get(ctx, task.ID)
 		assert.ErrorIs(t, err, ErrTaskNotFound)
 	})
 
+	t.Run("should remove deleted task from blockers", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		require.NoError(t, repo.Create(ctx, &Task{ID: "A", Title: "Design"}))
+		require.NoError(t, repo.Create(ctx, &Task{ID: "B", Title: "Build", BlockedBy: []string{"A"}}))
+
+		require.NoError(t, repo.Delete(ctx, "A", 0))
+
+		task, err := repo.Get(ctx, "B")
+		require.NoError(t, err)
+		assert.Empty(t, task.BlockedBy)
+	})
+
 	t.Run("should return error when task does not exist", func(t *testing.T) {
-		repo := NewMemoryRepository()
+		repo := newRepository(t)
+		ctx := context.Background()
 
-		err := repo.Delete("non-existent")
+		err := repo.Delete(ctx, "non-existent", 0)
 
 		assert.Error(t, err)
 		assert.ErrorIs(t, err, ErrTaskNotFound)
 	})
+
+	t.Run("should delete task with expected version", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		require.NoError(t, repo.Create(ctx, &Task{ID: "A", Title: "Test Task"}))
+
+		require.NoError(t, repo.Delete(ctx, "A", 1))
+
+		_, err := repo.Get(ctx, "A")
+		assert.ErrorIs(t, err, ErrTaskNotFound)
+	})
+
+	t.Run("should return conflict when version is stale", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		task := &Task{ID: "A", Title: "Test Task"}
+		require.NoError(t, repo.Create(ctx, task))
+		require.NoError(t, repo.Update(ctx, task))
+
+		err := repo.Delete(ctx, "A", 1)
+
+		assert.ErrorIs(t, err, ErrConflict)
+		_, err = repo.Get(ctx, "A")
+		assert.NoError(t, err)
+	})
+}
+
+func testRepositoryTags(t *testing.T, newRepository func(t *testing.T) Repository) {
+	t.Run("should store tags of task", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		task := &Task{ID: "A", Title: "Fix login", Tags: []string{"backend", "urgent"}}
+		require.NoError(t, repo.Create(ctx, task))
+
+		stored, err := repo.Get(ctx, "A")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"backend", "urgent"}, stored.Tags)
+
+		task.Tags = []string{"frontend"}
+		require.NoError(t, repo.Update(ctx, task))
+
+		stored, err = repo.Get(ctx, "A")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"frontend"}, stored.Tags)
+	})
+
+	t.Run("should count tasks of every tag", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		createListTasks(t, repo)
+
+		tags, err := repo.Tags(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, []TagCount{{"backend", 1}, {"docs", 1}, {"finance", 1}, {"urgent", 2}}, tags)
+	})
+
+	t.Run("should drop tags of deleted task", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		createListTasks(t, repo)
+
+		require.NoError(t, repo.Delete(ctx, "C", 0))
+
+		tags, err := repo.Tags(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []TagCount{{"docs", 1}, {"finance", 1}, {"urgent", 1}}, tags)
+	})
+
+	t.Run("should return empty slice when no task has tags", func(t *testing.T) {
+		repo := newRepository(t)
+
+		tags, err := repo.Tags(context.Background())
+
+		require.NoError(t, err)
+		assert.NotNil(t, tags)
+		assert.Empty(t, tags)
+	})
+}
+
+func testRepositoryTransaction(t *testing.T, newRepository func(t *testing.T) Repository) {
+	t.Run("should apply every change when the unit of work succeeds", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		require.NoError(t, repo.Create(ctx, &Task{ID: "A", Title: "Write report", Tags: []string{"work"}}))
+		require.NoError(t, repo.Create(ctx, &Task{ID: "B", Title: "Buy milk"}))
+
+		err := repo.Transaction(ctx, func(tx Repository) error {
+			if err := tx.Create(ctx, &Task{ID: "C", Title: "Fix login", BlockedBy: []string{"A"}}); err != nil {
+				return err
+			}
+
+			task, err := tx.Get(ctx, "A")
+			if err != nil {
+				return err
+			}
+			task.Title = "Write quarterly report"
+			if err := tx.Update(ctx, task); err != nil {
+				return err
+			}
+
+			page, err := tx.List(ctx, ListOptions{})
+			if err != nil {
+				return err
+			}
+			assert.Equal(t, []string{"A", "B", "C"}, taskIDs(page.Tasks))
+
+			return tx.Delete(ctx, "B", 1)
+		})
+
+		require.NoError(t, err)
+
+		page, err := repo.List(ctx, ListOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"A", "C"}, taskIDs(page.Tasks))
+		assert.Equal(t, "Write quarterly report", page.Tasks[0].Title)
+		assert.Equal(t, 2, page.Tasks[0].Version)
+		assert.Equal(t, []string{"A"}, page.Tasks[1].BlockedBy)
+	})
+
+	t.Run("should discard every change when the unit of work fails", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		require.NoError(t, repo.Create(ctx, &Task{ID: "A", Title: "Write report", Tags: []string{"work"}}))
+		require.NoError(t, repo.Create(ctx, &Task{ID: "B", Title: "Buy milk", BlockedBy: []string{"A"}}))
+
+		err := repo.Transaction(ctx, func(tx Repository) error {
+			if err := tx.Create(ctx, &Task{ID: "C", Title: "Fix login"}); err != nil {
+				return err
+			}
+
+			if err := tx.Update(ctx, &Task{ID: "B", Title: "Buy oat milk", Version: 1}); err != nil {
+				return err
+			}
+
+			if err := tx.Delete(ctx, "A", 0); err != nil {
+				return err
+			}
+
+			return tx.Update(ctx, &Task{ID: "B", Title: "Buy soy milk", Version: 1})
+		})
+
+		assert.ErrorIs(t, err, ErrConflict)
+
+		page, err := repo.List(ctx, ListOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"A", "B"}, taskIDs(page.Tasks))
+		assert.Equal(t, "Buy milk", page.Tasks[1].Title)
+		assert.Equal(t, 1, page.Tasks[1].Version)
+		assert.Equal(t, []string{"A"}, page.Tasks[1].BlockedBy)
+
+		tags, err := repo.Tags(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []TagCount{{Tag: "work", Count: 1}}, tags)
+	})
 }
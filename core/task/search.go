@@ -0,0 +1,440 @@
+package task
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"html"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrSearchUnavailable is returned when tasks are searched in a repository which doesn't index them
+var ErrSearchUnavailable = errors.New("task search is not available")
+
+const (
+	// DefaultSearchLimit is the number of hits returned when SearchOptions.Limit is zero
+	DefaultSearchLimit = 20
+
+	bm25K1           = 1.2
+	bm25B            = 0.75
+	titleWeight      = 2
+	maxFragmentRunes = 160
+	fragmentContext  = 40
+	highlightStart   = "<mark>"
+	highlightEnd     = "</mark>"
+)
+
+var stopWords = map[string]bool{
+	"a": true, "about": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true,
+	"by": true, "for": true, "from": true, "in": true, "is": true, "it": true, "of": true, "on": true,
+	"or": true, "that": true, "the": true, "this": true, "to": true, "with": true,
+}
+
+// SearchOptions holds the filter of the searched tasks and the maximum number of hits
+type SearchOptions struct {
+	// Filter restricts the hits to the matching tasks, its Query is ignored
+	Filter Filter
+	// Limit is the maximum number of hits, DefaultSearchLimit when zero
+	Limit int
+}
+
+// SearchHit is a task matching a search query
+type SearchHit struct {
+	// Task is the matching task
+	Task *Task
+	// Score is the BM25 relevance of the task, higher is better
+	Score float64
+	// Highlights are the fragments of the fields of the task matching the query
+	Highlights []Highlight
+}
+
+// Highlight is a fragment of a field of a task, its matching words are wrapped in <mark> and </mark>.
+// The text of the fragment is HTML escaped so that it can be rendered as is
+type Highlight struct {
+	// Field is either title or description
+	Field string
+	// Fragment is the field, shortened around the first match when it is long
+	Fragment string
+}
+
+// SearchResult holds the best hits of a search and the number of matching tasks
+type SearchResult struct {
+	// Hits are the best matching tasks, the most relevant first
+	Hits []SearchHit
+	// Total is the number of tasks matching the query and the filter
+	Total int
+}
+
+// SearchRepository is a Repository which indexes the title and the description of the tasks
+type SearchRepository interface {
+	Repository
+
+	// Search returns the tasks matching the query ranked by relevance
+	Search(ctx context.Context, query string, opts SearchOptions) (*SearchResult, error)
+}
+
+// token is a word of a text with its byte offsets
+type token struct {
+	word       string
+	start, end int
+}
+
+// tokenize splits a text into lower case words of letters and digits
+func tokenize(text string) []token {
+	var tokens []token
+	start := -1
+	for i, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			tokens = append(tokens, token{word: strings.ToLower(text[start:i]), start: start, end: i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, token{word: strings.ToLower(text[start:]), start: start, end: len(text)})
+	}
+
+	return tokens
+}
+
+// terms returns the stems of the words of a text which are not stop words
+func terms(text string) []string {
+	var result []string
+	for _, t := range tokenize(text) {
+		if !stopWords[t.word] {
+			result = append(result, stem(t.word))
+		}
+	}
+
+	return result
+}
+
+// stem strips the common English inflections of a lower case word, e.g. reports, reporting and reported become report
+func stem(word string) string {
+	if utf8.RuneCountInString(word) <= 3 {
+		return word
+	}
+
+	switch {
+	case strings.HasSuffix(word, "sses"):
+		word = strings.TrimSuffix(word, "es")
+	case strings.HasSuffix(word, "ies") && len(word) > 4:
+		word = strings.TrimSuffix(word, "ies") + "y"
+	case strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") && !strings.HasSuffix(word, "us") && !strings.HasSuffix(word, "is"):
+		word = strings.TrimSuffix(word, "s")
+	}
+
+	for _, suffix := range []string{"ing", "ed", "ly"} {
+		if trimmed, ok := strings.CutSuffix(word, suffix); ok && len(trimmed) >= 3 && hasVowel(trimmed) {
+			word = undouble(trimmed)
+			break
+		}
+	}
+
+	if trimmed, ok := strings.CutSuffix(word, "e"); ok && len(trimmed) >= 4 {
+		word = trimmed
+	}
+
+	return word
+}
+
+func hasVowel(word string) bool {
+	return strings.ContainsAny(word, "aeiouy")
+}
+
+// undouble removes the last letter of a word ending with a doubled consonant, e.g. plann becomes plan
+func undouble(word string) string {
+	n := len(word)
+	if n >= 2 && word[n-1] == word[n-2] && !strings.ContainsRune("aeiouslz", rune(word[n-1])) {
+		return word[:n-1]
+	}
+
+	return word
+}
+
+// queryTerm is a term of a search query with the indexed terms it matches, every term starting with it for prefixes
+type queryTerm struct {
+	stems []string
+}
+
+// parseQuery returns the terms of a query, a word ending with * matches every word starting with it
+func (i *SearchIndex) parseQuery(query string) []queryTerm {
+	var result []queryTerm
+	for _, field := range strings.Fields(query) {
+		prefix := strings.HasSuffix(field, "*")
+		tokens := tokenize(field)
+		for n, t := range tokens {
+			if prefix && n == len(tokens)-1 {
+				if stems := i.expand(t.word); len(stems) > 0 {
+					result = append(result, queryTerm{stems: stems})
+				}
+				continue
+			}
+
+			if !stopWords[t.word] {
+				result = append(result, queryTerm{stems: []string{stem(t.word)}})
+			}
+		}
+	}
+
+	return result
+}
+
+// expand returns the stems of the indexed words starting with the prefix
+func (i *SearchIndex) expand(prefix string) []string {
+	var stems []string
+	for word := range i.words {
+		if strings.HasPrefix(word, prefix) {
+			stems = append(stems, stem(word))
+		}
+	}
+
+	slices.Sort(stems)
+	return slices.Compact(stems)
+}
+
+// posting counts the occurrences of a term in the fields of a task
+type posting struct {
+	title       int
+	description int
+}
+
+func (p posting) frequency() float64 {
+	return float64(titleWeight*p.title + p.description)
+}
+
+type indexedTask struct {
+	length float64
+	terms  []string
+	words  []string
+}
+
+// SearchIndex is an inverted index of the title and the description of the tasks ranking them with BM25,
+// the words of the title weigh twice as much as the words of the description
+type SearchIndex struct {
+	postings    map[string]map[string]posting
+	tasks       map[string]indexedTask
+	words       map[string]int
+	totalLength float64
+	mu          sync.RWMutex
+}
+
+// NewSearchIndex creates a new empty search index
+func NewSearchIndex() *SearchIndex {
+	return &SearchIndex{
+		postings: make(map[string]map[string]posting),
+		tasks:    make(map[string]indexedTask),
+		words:    make(map[string]int),
+	}
+}
+
+// Index adds a task to the index, replacing its previous title and description
+func (i *SearchIndex) Index(t *Task) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.remove(t.ID)
+
+	postings := make(map[string]posting)
+	for _, term := range terms(t.Title) {
+		p := postings[term]
+		p.title++
+		postings[term] = p
+	}
+	for _, term := range terms(t.Description) {
+		p := postings[term]
+		p.description++
+		postings[term] = p
+	}
+
+	indexed := indexedTask{}
+	for term, p := range postings {
+		if i.postings[term] == nil {
+			i.postings[term] = make(map[string]posting)
+		}
+		i.postings[term][t.ID] = p
+		indexed.terms = append(indexed.terms, term)
+		indexed.length += p.frequency()
+	}
+
+	for _, tok := range tokenize(t.Title + " " + t.Description) {
+		if !slices.Contains(indexed.words, tok.word) {
+			indexed.words = append(indexed.words, tok.word)
+			i.words[tok.word]++
+		}
+	}
+
+	i.tasks[t.ID] = indexed
+	i.totalLength += indexed.length
+}
+
+// Remove removes a task from the index
+func (i *SearchIndex) Remove(id string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.remove(id)
+}
+
+func (i *SearchIndex) remove(id string) {
+	indexed, ok := i.tasks[id]
+	if !ok {
+		return
+	}
+
+	for _, term := range indexed.terms {
+		delete(i.postings[term], id)
+		if len(i.postings[term]) == 0 {
+			delete(i.postings, term)
+		}
+	}
+
+	for _, word := range indexed.words {
+		if i.words[word]--; i.words[word] == 0 {
+			delete(i.words, word)
+		}
+	}
+
+	delete(i.tasks, id)
+	i.totalLength -= indexed.length
+}
+
+// scoredTask is the ID of a task matching a query with its relevance
+type scoredTask struct {
+	id    string
+	score float64
+}
+
+// search returns the tasks matching any term of the query, the most relevant first,
+// along with the stems of the query to highlight
+func (i *SearchIndex) search(query string) ([]scoredTask, map[string]bool) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	queryTerms := i.parseQuery(query)
+	matched := make(map[string]bool)
+	if len(i.tasks) == 0 {
+		return nil, matched
+	}
+
+	count := float64(len(i.tasks))
+	averageLength := i.totalLength / count
+	scores := make(map[string]float64)
+
+	for _, qt := range queryTerms {
+		best := make(map[string]float64)
+		for _, s := range qt.stems {
+			matched[s] = true
+			postings := i.postings[s]
+			idf := math.Log(1 + (count-float64(len(postings))+0.5)/(float64(len(postings))+0.5))
+			for id, p := range postings {
+				tf := p.frequency()
+				norm := bm25K1 * (1 - bm25B + bm25B*i.tasks[id].length/averageLength)
+				best[id] = max(best[id], idf*tf*(bm25K1+1)/(tf+norm))
+			}
+		}
+
+		for id, score := range best {
+			scores[id] += score
+		}
+	}
+
+	result := make([]scoredTask, 0, len(scores))
+	for id, score := range scores {
+		result = append(result, scoredTask{id: id, score: score})
+	}
+
+	slices.SortFunc(result, func(a, b scoredTask) int {
+		if c := cmp.Compare(b.score, a.score); c != 0 {
+			return c
+		}
+		return strings.Compare(a.id, b.id)
+	})
+
+	return result, matched
+}
+
+// highlights returns the fragments of the title and the description of a task having words with the given stems
+func highlights(t *Task, stems map[string]bool) []Highlight {
+	var result []Highlight
+	for _, f := range []struct{ name, text string }{{"title", t.Title}, {"description", t.Description}} {
+		if fragment, ok := highlight(f.text, stems); ok {
+			result = append(result, Highlight{Field: f.name, Fragment: fragment})
+		}
+	}
+
+	return result
+}
+
+// highlight wraps the words of a text having one of the stems in <mark> and </mark> and escapes the rest of the HTML,
+// a text longer than maxFragmentRunes is shortened around its first match
+func highlight(text string, stems map[string]bool) (string, bool) {
+	var matches []token
+	for _, t := range tokenize(text) {
+		if !stopWords[t.word] && stems[stem(t.word)] {
+			matches = append(matches, t)
+		}
+	}
+
+	if len(matches) == 0 {
+		return "", false
+	}
+
+	start, end := fragmentBounds(text, matches[0].start)
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+
+	last := start
+	for _, m := range matches {
+		if m.start < start || m.end > end {
+			continue
+		}
+		b.WriteString(html.EscapeString(text[last:m.start]))
+		b.WriteString(highlightStart)
+		b.WriteString(html.EscapeString(text[m.start:m.end]))
+		b.WriteString(highlightEnd)
+		last = m.end
+	}
+	b.WriteString(html.EscapeString(text[last:end]))
+
+	if end < len(text) {
+		b.WriteString("…")
+	}
+
+	return b.String(), true
+}
+
+// fragmentBounds returns the byte offsets of the fragment of at most maxFragmentRunes of a text
+// starting fragmentContext runes before the given offset
+func fragmentBounds(text string, offset int) (int, int) {
+	if utf8.RuneCountInString(text) <= maxFragmentRunes {
+		return 0, len(text)
+	}
+
+	start := offset
+	for n := 0; n < fragmentContext && start > 0; n++ {
+		_, size := utf8.DecodeLastRuneInString(text[:start])
+		start -= size
+	}
+
+	end := start
+	for n := 0; n < maxFragmentRunes && end < len(text); n++ {
+		_, size := utf8.DecodeRuneInString(text[end:])
+		end += size
+	}
+
+	return start, end
+}
@@ -0,0 +1,184 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// IndexedRepository is a SearchRepository which keeps a SearchIndex of the tasks of another repository
+// up to date on every write, the index is kept in memory and rebuilt when the repository is created
+type IndexedRepository struct {
+	base  Repository
+	index *SearchIndex
+}
+
+// NewIndexedRepository creates a new repository indexing the tasks stored in the base repository
+func NewIndexedRepository(ctx context.Context, base Repository) (*IndexedRepository, error) {
+	page, err := base.List(ctx, ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error indexing tasks: %w", err)
+	}
+
+	index := NewSearchIndex()
+	for _, t := range page.Tasks {
+		index.Index(t)
+	}
+
+	return &IndexedRepository{base: base, index: index}, nil
+}
+
+func (r *IndexedRepository) Create(ctx context.Context, t *Task) error {
+	if err := r.base.Create(ctx, t); err != nil {
+		return err
+	}
+
+	r.index.Index(t)
+	return nil
+}
+
+func (r *IndexedRepository) Get(ctx context.Context, id string) (*Task, error) {
+	return r.base.Get(ctx, id)
+}
+
+func (r *IndexedRepository) List(ctx context.Context, opts ListOptions) (*Page, error) {
+	return r.base.List(ctx, opts)
+}
+
+func (r *IndexedRepository) Update(ctx context.Context, t *Task) error {
+	if err := r.base.Update(ctx, t); err != nil {
+		return err
+	}
+
+	r.index.Index(t)
+	return nil
+}
+
+func (r *IndexedRepository) Delete(ctx context.Context, id string, version int) error {
+	if err := r.base.Delete(ctx, id, version); err != nil {
+		return err
+	}
+
+	r.index.Remove(id)
+	return nil
+}
+
+func (r *IndexedRepository) Tags(ctx context.Context) ([]TagCount, error) {
+	return r.base.Tags(ctx)
+}
+
+// Transaction calls fn with a unit of work of the base repository, the tasks it changes are indexed once it is applied
+func (r *IndexedRepository) Transaction(ctx context.Context, fn func(tx Repository) error) error {
+	changed := make(map[string]bool)
+	err := r.base.Transaction(ctx, func(tx Repository) error {
+		return fn(&indexedTx{Repository: tx, changed: changed})
+	})
+	if err != nil {
+		return err
+	}
+
+	for id := range changed {
+		t, err := r.base.Get(ctx, id)
+		if errors.Is(err, ErrTaskNotFound) {
+			r.index.Remove(id)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("error indexing task %s: %w", id, err)
+		}
+		r.index.Index(t)
+	}
+
+	return nil
+}
+
+// History returns the events of a task recorded by the base repository
+// Returns ErrHistoryUnavailable if the base repository doesn't record them
+func (r *IndexedRepository) History(ctx context.Context, id string) ([]Event, error) {
+	history, ok := r.base.(HistoryRepository)
+	if !ok {
+		return nil, ErrHistoryUnavailable
+	}
+
+	return history.History(ctx, id)
+}
+
+func (r *IndexedRepository) Search(ctx context.Context, query string, opts SearchOptions) (*SearchResult, error) {
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+
+	filter := opts.Filter
+	filter.Query = ""
+
+	scored, stems := r.index.search(query)
+	filter.IDs = make([]string, 0, len(scored))
+	for _, s := range scored {
+		if len(opts.Filter.IDs) == 0 || slices.Contains(opts.Filter.IDs, s.id) {
+			filter.IDs = append(filter.IDs, s.id)
+		}
+	}
+
+	result := &SearchResult{Hits: []SearchHit{}}
+	if len(filter.IDs) == 0 {
+		return result, nil
+	}
+
+	page, err := r.base.List(ctx, ListOptions{Filter: filter})
+	if err != nil {
+		return nil, fmt.Errorf("error listing tasks: %w", err)
+	}
+
+	tasks := make(map[string]*Task, len(page.Tasks))
+	for _, t := range page.Tasks {
+		tasks[t.ID] = t
+	}
+
+	result.Total = len(tasks)
+	for _, s := range scored {
+		if t, ok := tasks[s.id]; ok && len(result.Hits) < limit {
+			result.Hits = append(result.Hits, SearchHit{Task: t, Score: s.score, Highlights: highlights(t, stems)})
+		}
+	}
+
+	return result, nil
+}
+
+// indexedTx records the IDs of the tasks changed by a unit of work
+type indexedTx struct {
+	Repository
+	changed map[string]bool
+}
+
+func (tx *indexedTx) Create(ctx context.Context, t *Task) error {
+	if err := tx.Repository.Create(ctx, t); err != nil {
+		return err
+	}
+
+	tx.changed[t.ID] = true
+	return nil
+}
+
+func (tx *indexedTx) Update(ctx context.Context, t *Task) error {
+	if err := tx.Repository.Update(ctx, t); err != nil {
+		return err
+	}
+
+	tx.changed[t.ID] = true
+	return nil
+}
+
+func (tx *indexedTx) Delete(ctx context.Context, id string, version int) error {
+	if err := tx.Repository.Delete(ctx, id, version); err != nil {
+		return err
+	}
+
+	tx.changed[id] = true
+	return nil
+}
+
+func (tx *indexedTx) Transaction(_ context.Context, fn func(tx Repository) error) error {
+	return fn(tx)
+}
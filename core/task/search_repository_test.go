@@ -0,0 +1,176 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/pkg/idgen"
+	"github.com/utsabbera/task-master/pkg/util"
+	"go.uber.org/mock/gomock"
+)
+
+func TestIndexedRepository(t *testing.T) {
+	testRepository(t, func(t *testing.T) Repository {
+		repo, err := NewIndexedRepository(context.Background(), NewMemoryRepository())
+		require.NoError(t, err)
+
+		return repo
+	})
+}
+
+func TestIndexedRepository_Search(t *testing.T) {
+	hitIDs := func(result *SearchResult) []string {
+		return util.Map(result.Hits, func(h SearchHit) string { return h.Task.ID })
+	}
+
+	t.Run("should index tasks stored before it was created", func(t *testing.T) {
+		ctx := context.Background()
+		base := NewMemoryRepository()
+		require.NoError(t, base.Create(ctx, &Task{ID: "A", Title: "Write quarterly report"}))
+
+		repo, err := NewIndexedRepository(ctx, base)
+		require.NoError(t, err)
+
+		result, err := repo.Search(ctx, "report", SearchOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"A"}, hitIDs(result))
+	})
+
+	t.Run("should keep index up to date on writes", func(t *testing.T) {
+		ctx := context.Background()
+		repo, err := NewIndexedRepository(ctx, NewMemoryRepository())
+		require.NoError(t, err)
+		require.NoError(t, repo.Create(ctx, &Task{ID: "A", Title: "Write quarterly report"}))
+		require.NoError(t, repo.Create(ctx, &Task{ID: "B", Title: "Review report"}))
+
+		require.NoError(t, repo.Update(ctx, &Task{ID: "A", Title: "Buy milk"}))
+		require.NoError(t, repo.Delete(ctx, "B", 0))
+
+		result, err := repo.Search(ctx, "report", SearchOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, result.Hits)
+
+		result, err = repo.Search(ctx, "milk", SearchOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"A"}, hitIDs(result))
+	})
+
+	t.Run("should index changes of committed transaction only", func(t *testing.T) {
+		ctx := context.Background()
+		repo, err := NewIndexedRepository(ctx, NewMemoryRepository())
+		require.NoError(t, err)
+		require.NoError(t, repo.Create(ctx, &Task{ID: "A", Title: "Write quarterly report"}))
+
+		require.NoError(t, repo.Transaction(ctx, func(tx Repository) error {
+			if err := tx.Create(ctx, &Task{ID: "B", Title: "Review report"}); err != nil {
+				return err
+			}
+			return tx.Delete(ctx, "A", 0)
+		}))
+
+		err = repo.Transaction(ctx, func(tx Repository) error {
+			if err := tx.Create(ctx, &Task{ID: "C", Title: "Print report"}); err != nil {
+				return err
+			}
+			return errors.New("rollback")
+		})
+		require.Error(t, err)
+
+		result, err := repo.Search(ctx, "report", SearchOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"B"}, hitIDs(result))
+	})
+
+	t.Run("should filter and limit hits with highlights", func(t *testing.T) {
+		ctx := context.Background()
+		repo, err := NewIndexedRepository(ctx, NewMemoryRepository())
+		require.NoError(t, err)
+		require.NoError(t, repo.Create(ctx, &Task{ID: "A", Title: "Quarterly report", Status: StatusNotStarted}))
+		require.NoError(t, repo.Create(ctx, &Task{ID: "B", Title: "Report bug", Description: "Crash report of the app", Status: StatusNotStarted}))
+		require.NoError(t, repo.Create(ctx, &Task{ID: "C", Title: "Annual report", Status: StatusCompleted}))
+
+		result, err := repo.Search(ctx, "bug report", SearchOptions{Filter: Filter{Statuses: []Status{StatusNotStarted}}, Limit: 1})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Total)
+		require.Len(t, result.Hits, 1)
+		assert.Equal(t, "B", result.Hits[0].Task.ID)
+		assert.Equal(t, []Highlight{
+			{Field: "title", Fragment: "<mark>Report</mark> <mark>bug</mark>"},
+			{Field: "description", Fragment: "Crash <mark>report</mark> of the app"},
+		}, result.Hits[0].Highlights)
+	})
+
+	t.Run("should load hits with a single list of the base repository", func(t *testing.T) {
+		ctx := context.Background()
+		base := &countingRepository{Repository: NewMemoryRepository()}
+		repo, err := NewIndexedRepository(ctx, base)
+		require.NoError(t, err)
+		require.NoError(t, repo.Create(ctx, &Task{ID: "A", Title: "Quarterly report"}))
+		require.NoError(t, repo.Create(ctx, &Task{ID: "B", Title: "Report bug"}))
+		require.NoError(t, repo.Create(ctx, &Task{ID: "C", Title: "Buy milk"}))
+		base.gets, base.lists = 0, 0
+
+		result, err := repo.Search(ctx, "report", SearchOptions{Filter: Filter{IDs: []string{"B", "C"}}})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"B"}, hitIDs(result))
+		assert.Zero(t, base.gets)
+		assert.Equal(t, 1, base.lists)
+	})
+
+	t.Run("should pass history of base repository through", func(t *testing.T) {
+		repo, err := NewIndexedRepository(context.Background(), NewMemoryRepository())
+		require.NoError(t, err)
+
+		_, err = repo.History(context.Background(), "A")
+
+		assert.ErrorIs(t, err, ErrHistoryUnavailable)
+	})
+}
+
+func TestService_Search(t *testing.T) {
+	t.Run("should search tasks of indexed repository", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		repo, err := NewIndexedRepository(ctx, NewMemoryRepository())
+		require.NoError(t, err)
+		service := NewService(repo, idgen.NewSequential("TASK-", 1, 6), newTickingClock(ctrl))
+		require.NoError(t, service.Create(ctx, &Task{Title: "Write quarterly report"}))
+		require.NoError(t, service.Create(ctx, &Task{Title: "Buy milk"}))
+
+		result, err := service.Search(ctx, "find the task about the quarterly report", SearchOptions{})
+
+		require.NoError(t, err)
+		require.Len(t, result.Hits, 1)
+		assert.Equal(t, "TASK-000001", result.Hits[0].Task.ID)
+	})
+
+	t.Run("should return error when repository isn't indexed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		service := NewService(NewMemoryRepository(), idgen.NewSequential("TASK-", 1, 6), newTickingClock(ctrl))
+
+		_, err := service.Search(context.Background(), "report", SearchOptions{})
+
+		assert.ErrorIs(t, err, ErrSearchUnavailable)
+	})
+}
+
+// countingRepository counts the reads of the tasks of a repository
+type countingRepository struct {
+	Repository
+	gets, lists int
+}
+
+func (r *countingRepository) Get(ctx context.Context, id string) (*Task, error) {
+	r.gets++
+	return r.Repository.Get(ctx, id)
+}
+
+func (r *countingRepository) List(ctx context.Context, opts ListOptions) (*Page, error) {
+	r.lists++
+	return r.Repository.List(ctx, opts)
+}
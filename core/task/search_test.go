@@ -0,0 +1,174 @@
+package task
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenize(t *testing.T) {
+	t.Run("should split lower case words with their offsets", func(t *testing.T) {
+		tokens := tokenize("Fix Q3-report, café!")
+
+		assert.Equal(t, []token{
+			{word: "fix", start: 0, end: 3},
+			{word: "q3", start: 4, end: 6},
+			{word: "report", start: 7, end: 13},
+			{word: "café", start: 15, end: 20},
+		}, tokens)
+	})
+}
+
+func TestStem(t *testing.T) {
+	tests := []struct {
+		words []string
+		want  string
+	}{
+		{words: []string{"report", "reports", "reporting", "reported"}, want: "report"},
+		{words: []string{"plan", "plans", "planned", "planning"}, want: "plan"},
+		{words: []string{"quarter", "quarterly", "quarters"}, want: "quarter"},
+		{words: []string{"release", "releases", "released", "releasing"}, want: "releas"},
+		{words: []string{"story", "stories"}, want: "story"},
+		{words: []string{"address", "addresses"}, want: "address"},
+		{words: []string{"bus"}, want: "bus"},
+	}
+
+	for _, tt := range tests {
+		t.Run("should stem "+strings.Join(tt.words, ", ")+" to "+tt.want, func(t *testing.T) {
+			for _, word := range tt.words {
+				assert.Equal(t, tt.want, stem(word), word)
+			}
+		})
+	}
+}
+
+func TestSearchIndex(t *testing.T) {
+	newIndex := func(tasks ...*Task) *SearchIndex {
+		index := NewSearchIndex()
+		for _, task := range tasks {
+			index.Index(task)
+		}
+		return index
+	}
+
+	ids := func(scored []scoredTask) []string {
+		result := make([]string, 0, len(scored))
+		for _, s := range scored {
+			result = append(result, s.id)
+		}
+		return result
+	}
+
+	t.Run("should rank tasks matching more terms first", func(t *testing.T) {
+		index := newIndex(
+			&Task{ID: "A", Title: "Write quarterly report"},
+			&Task{ID: "B", Title: "Review report"},
+			&Task{ID: "C", Title: "Buy milk"},
+		)
+
+		scored, _ := index.search("the quarterly reports")
+
+		assert.Equal(t, []string{"A", "B"}, ids(scored))
+		assert.Greater(t, scored[0].score, scored[1].score)
+	})
+
+	t.Run("should rank title matches above description matches", func(t *testing.T) {
+		index := newIndex(
+			&Task{ID: "A", Title: "Plan offsite", Description: "Book the budget review room"},
+			&Task{ID: "B", Title: "Budget review", Description: "Go through the numbers"},
+		)
+
+		scored, _ := index.search("budget")
+
+		assert.Equal(t, []string{"B", "A"}, ids(scored))
+	})
+
+	t.Run("should rank rare terms above common terms", func(t *testing.T) {
+		index := newIndex(
+			&Task{ID: "A", Title: "Deploy api"},
+			&Task{ID: "B", Title: "Deploy web"},
+			&Task{ID: "C", Title: "Deploy worker"},
+			&Task{ID: "D", Title: "Rotate keys"},
+		)
+
+		scored, _ := index.search("deploy keys")
+
+		assert.Equal(t, "D", scored[0].id)
+	})
+
+	t.Run("should match words starting with prefix", func(t *testing.T) {
+		index := newIndex(
+			&Task{ID: "A", Title: "Quarterly report"},
+			&Task{ID: "B", Title: "Quartz migration"},
+			&Task{ID: "C", Title: "Queue cleanup"},
+		)
+
+		scored, stems := index.search("quart*")
+
+		assert.Equal(t, []string{"A", "B"}, ids(scored))
+		assert.True(t, stems["quarter"])
+	})
+
+	t.Run("should ignore stop words", func(t *testing.T) {
+		index := newIndex(&Task{ID: "A", Title: "The end of the year"})
+
+		scored, _ := index.search("the of")
+
+		assert.Empty(t, scored)
+	})
+
+	t.Run("should forget removed and replaced text", func(t *testing.T) {
+		index := newIndex(
+			&Task{ID: "A", Title: "Write report"},
+			&Task{ID: "B", Title: "Review report"},
+		)
+
+		index.Index(&Task{ID: "A", Title: "Buy milk"})
+		index.Remove("B")
+
+		scored, _ := index.search("report")
+		assert.Empty(t, scored)
+
+		scored, _ = index.search("rep*")
+		assert.Empty(t, scored)
+
+		scored, _ = index.search("milk")
+		assert.Equal(t, []string{"A"}, ids(scored))
+	})
+}
+
+func TestHighlight(t *testing.T) {
+	stems := map[string]bool{"report": true, "quarter": true}
+
+	t.Run("should mark matching words", func(t *testing.T) {
+		fragment, ok := highlight("Write the Quarterly reports", stems)
+
+		assert.True(t, ok)
+		assert.Equal(t, "Write the <mark>Quarterly</mark> <mark>reports</mark>", fragment)
+	})
+
+	t.Run("should escape the html of the text", func(t *testing.T) {
+		fragment, ok := highlight(`<script>alert("report")</script> & reports`, stems)
+
+		assert.True(t, ok)
+		assert.Equal(t, "&lt;script&gt;alert(&#34;<mark>report</mark>&#34;)&lt;/script&gt; &amp; <mark>reports</mark>", fragment)
+	})
+
+	t.Run("should not highlight text without matches", func(t *testing.T) {
+		_, ok := highlight("Buy milk", stems)
+
+		assert.False(t, ok)
+	})
+
+	t.Run("should shorten long text around first match", func(t *testing.T) {
+		text := strings.Repeat("lorem ipsum ", 20) + "the quarterly report is due " + strings.Repeat("dolor sit ", 20)
+
+		fragment, ok := highlight(text, stems)
+
+		assert.True(t, ok)
+		assert.True(t, strings.HasPrefix(fragment, "…"))
+		assert.True(t, strings.HasSuffix(fragment, "…"))
+		assert.Contains(t, fragment, "the <mark>quarterly</mark> <mark>report</mark> is due")
+	})
+}
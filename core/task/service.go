@@ -1,7 +1,11 @@
 package task
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/utsabbera/task-master/pkg/idgen"
 	"github.com/utsabbera/task-master/pkg/util"
@@ -14,60 +18,164 @@ type Service interface {
 	// Create adds a new task with the specified fields.
 	// The caller must set Title, Description, Priority,DueDate, and Status.
 	// The method mutates the provided *Task and returns an error if creation fails.
-	Create(task *Task) error
+	Create(ctx context.Context, task *Task) error
 
 	// Get retrieves a task by its ID
-	// Returns an error if the task cannot be found
-	Get(id string) (*Task, error)
+	// Returns an error if the task cannot be found, or a *MovedError if it was moved to another project
+	Get(ctx context.Context, id string) (*Task, error)
 
-	// List retrieves all tasks from the repository
-	List() ([]*Task, error)
+	// List retrieves the page of tasks matching the filter, sort and pagination options
+	List(ctx context.Context, opts ListOptions) (*Page, error)
 
 	// Update updates an existing task with the provided fields in the update parameter.
 	// Only non-zero fields in the update parameter will overwrite the corresponding fields in the existing task.
 	// Returns an error if the update parameter is nil, the task cannot be found, or the update operation fails.
-	Update(id string, patch *Task) (*Task, error)
+	Update(ctx context.Context, id string, patch *Task) (*Task, error)
 
 	// Delete removes a task from the repository by its ID
 	// Returns an error if the task cannot be found
-	Delete(id string) error
+	Delete(ctx context.Context, id string, opts DeleteOptions) error
+
+	// Batch applies the operations in order, all of them or none, and returns the change made by each of them
+	// Returns a *BatchError wrapping the error of the first failed operation
+	Batch(ctx context.Context, ops []Operation) ([]Change, error)
+
+	// Plan returns the execution plan of the open tasks
+	Plan(ctx context.Context) (*Plan, error)
+
+	// Progress returns the roll-up of the completion of the subtasks of the given tasks having subtasks
+	Progress(ctx context.Context, ids []string) (map[string]Progress, error)
+
+	// Tags returns every tag used by the tasks with the number of tasks having it, sorted by tag
+	Tags(ctx context.Context) ([]TagCount, error)
+
+	// RenameTag replaces the tag from by the tag to on every task having it and returns the renamed tag
+	RenameTag(ctx context.Context, from, to string) (TagCount, error)
+
+	// MergeTags replaces the source tags by the target tag on every task having any of them and returns the target tag
+	MergeTags(ctx context.Context, sources []string, target string) (TagCount, error)
+
+	// History returns the changes of a task in the order they happened, including its deletion
+	History(ctx context.Context, id string) ([]Event, error)
+
+	// Search returns the tasks whose title or description match the query, ranked by relevance
+	Search(ctx context.Context, query string, opts SearchOptions) (*SearchResult, error)
+
+	// Move moves a task to another project, the default project when empty, and returns it with its new ID
+	Move(ctx context.Context, id, project string) (*Task, error)
+
+	// Subscribe returns a subscription to the changes of the tasks matching the filter, closed when the context is done
+	// A positive lastID resumes after the change with this ID
+	Subscribe(ctx context.Context, filter NotificationFilter, lastID int64) (*Subscription, error)
+}
+
+// DeleteOptions controls the deletion of a task
+type DeleteOptions struct {
+	// Version is the version the task is expected to have, zero deletes the task regardless of its version
+	Version int
+	// Mode defines what happens to the subtasks of the task, DeleteReject when empty
+	Mode DeleteMode
 }
 
 type service struct {
 	repo        Repository
 	clock       util.Clock
 	idGenerator idgen.Generator
+	projects    Projects
+	bus         *Bus
+	outbox      *[]Notification
 }
 
 // NewService creates a new instance of Service with the provided repository, ID generator, and clock.
+// The changes of the tasks are published on a bus keeping the latest DefaultReplayBufferSize of them.
+// Every task belongs to the default project, whose IDs are given by the ID generator
 func NewService(repo Repository, idGenerator idgen.Generator, time util.Clock) Service {
+	return NewServiceWithProjects(repo, idGenerator, nil, time)
+}
+
+// NewServiceWithProjects creates a new instance of Service whose tasks belong to the default project,
+// with the IDs of the ID generator, or to one of the given projects
+func NewServiceWithProjects(repo Repository, idGenerator idgen.Generator, projects Projects, time util.Clock) Service {
 	return &service{
 		repo:        repo,
 		clock:       time,
 		idGenerator: idGenerator,
+		projects:    projects,
+		bus:         NewBus(DefaultReplayBufferSize),
 	}
 }
 
-func (s *service) Create(task *Task) error {
-	if task.Status == "" {
-		task.Status = StatusNotStarted
+func (s *service) Create(ctx context.Context, task *Task) error {
+	now := s.clock.Now()
+	if err := prepareTask(ctx, s.repo.Get, task, now); err != nil {
+		return fmt.Errorf("error creating task: %w", err)
+	}
+
+	var err error
+	task.Project = strings.ToUpper(strings.TrimSpace(task.Project))
+	task.ID, err = s.nextID(ctx, task.Project)
+	if err != nil {
+		return fmt.Errorf("error creating task: %w", err)
 	}
 
-	task.ID = s.idGenerator.Next()
-	now := s.clock.Now()
 	task.UpdatedAt = now
 	task.CreatedAt = now
 
-	err := s.repo.Create(task)
+	err = s.repo.Create(ctx, task)
 	if err != nil {
 		return fmt.Errorf("error creating task: %w", err)
 	}
 
+	s.publish(ctx, nil, task, now)
 	return nil
 }
 
-func (s *service) Get(id string) (*Task, error) {
-	task, err := s.repo.Get(id)
+// prepareTask validates a new task against the tasks read by get, derives its status from its blockers
+// and normalizes its fields, leaving its ID, project and timestamps to the caller
+func prepareTask(ctx context.Context, get getter, task *Task, now time.Time) error {
+	if err := task.initStatus(now); err != nil {
+		return err
+	}
+
+	tags, err := NormalizeTags(task.Tags)
+	if err != nil {
+		return err
+	}
+
+	task.ParentID = normalizeParent(task.ParentID)
+	if task.ParentID != nil {
+		if err := checkParent(ctx, get, "", *task.ParentID); err != nil {
+			return err
+		}
+	}
+
+	recurrence, err := normalizeRecurrence(task.Recurrence)
+	if err != nil {
+		return err
+	}
+
+	task.BlockedBy = normalizeBlockers(task.BlockedBy)
+	if err := checkBlockers(ctx, get, "", task.BlockedBy); err != nil {
+		return err
+	}
+
+	open, err := checkCompletion(ctx, get, task.BlockedBy, task.Status)
+	if err != nil {
+		return err
+	}
+
+	if err := resolveBlocked(task, open, task.Status, false, now); err != nil {
+		return err
+	}
+
+	task.Tags = tags
+	task.Assignees = normalizeAssignees(task.Assignees)
+	task.Recurrence = recurrence
+	return nil
+}
+
+func (s *service) Get(ctx context.Context, id string) (*Task, error) {
+	task, err := s.get(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("error finding task: %w", err)
 	}
@@ -75,32 +183,106 @@ func (s *service) Get(id string) (*Task, error) {
 	return task, nil
 }
 
-func (s *service) List() ([]*Task, error) {
-	tasks, err := s.repo.List()
+func (s *service) List(ctx context.Context, opts ListOptions) (*Page, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("error listing tasks: %w", err)
+	}
+
+	var err error
+	if opts.Filter, err = opts.Filter.bind(ctx, s.clock); err != nil {
+		return nil, fmt.Errorf("error listing tasks: %w", err)
+	}
+
+	page, err := s.repo.List(ctx, opts)
 	if err != nil {
 		return nil, fmt.Errorf("error listing tasks: %w", err)
 	}
 
-	return tasks, nil
+	return page, nil
 }
 
-func (s *service) Update(id string, patch *Task) (*Task, error) {
-	task, err := s.repo.Get(id)
+func (s *service) Update(ctx context.Context, id string, patch *Task) (*Task, error) {
+	var task *Task
+	err := s.transaction(ctx, func(tx *service) error {
+		var err error
+		task, err = tx.update(ctx, id, patch)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// update applies the patch to the task, refreshes the tasks it blocks and creates its next occurrence
+// when it completes a recurring task
+func (s *service) update(ctx context.Context, id string, patch *Task) (*Task, error) {
+	task, err := s.get(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("error finding task: %w", err)
 	}
 
-	s.update(task, patch)
+	before := task.clone()
+	if err := patchTask(ctx, s.repo.Get, task, patch, s.clock); err != nil {
+		return nil, fmt.Errorf("error updating task: %w", err)
+	}
 
-	err = s.repo.Update(task)
+	next := recur(before, task)
+	err = s.repo.Update(ctx, task)
 	if err != nil {
 		return nil, fmt.Errorf("error updating task: %w", err)
 	}
 
+	s.publish(ctx, before, task, task.UpdatedAt)
+
+	if err := refreshDependents(ctx, s, before, task); err != nil {
+		return nil, fmt.Errorf("error updating task: %w", err)
+	}
+
+	if next != nil {
+		if err := s.Create(ctx, next); err != nil {
+			return nil, fmt.Errorf("error creating next occurrence: %w", err)
+		}
+	}
+
 	return task, nil
 }
 
-func (s *service) update(task *Task, patch *Task) {
+// patchTask validates the patch of a task against the tasks read by get and applies it,
+// deriving the status of the task from its blockers
+func patchTask(ctx context.Context, get getter, task *Task, patch *Task, clock util.Clock) error {
+	if err := checkVersion(patch.Version, task.Version); err != nil {
+		return err
+	}
+
+	if parentID := normalizeParent(patch.ParentID); parentID != nil {
+		if err := checkParent(ctx, get, task.ID, *parentID); err != nil {
+			return err
+		}
+	}
+
+	if patch.BlockedBy != nil {
+		if err := checkBlockers(ctx, get, task.ID, normalizeBlockers(patch.BlockedBy)); err != nil {
+			return err
+		}
+	}
+
+	now := clock.Now()
+	if err := applyBlockedPatch(ctx, get, task, patch, now); err != nil {
+		return err
+	}
+
+	task.UpdatedAt = now
+	return nil
+}
+
+func applyPatch(task *Task, patch *Task, now time.Time) error {
+	if patch.Status != "" {
+		if err := task.transition(patch.Status, now); err != nil {
+			return err
+		}
+	}
 	if patch.Title != "" {
 		task.Title = patch.Title
 	}
@@ -113,15 +295,363 @@ func (s *service) update(task *Task, patch *Task) {
 	if patch.DueDate != nil {
 		task.DueDate = patch.DueDate
 	}
-
-	task.UpdatedAt = s.clock.Now()
+	if patch.ParentID != nil {
+		task.ParentID = normalizeParent(patch.ParentID)
+	}
+	if patch.BlockedBy != nil {
+		task.BlockedBy = normalizeBlockers(patch.BlockedBy)
+	}
+	if patch.Tags != nil {
+		tags, err := NormalizeTags(patch.Tags)
+		if err != nil {
+			return err
+		}
+		task.Tags = tags
+	}
+	if patch.Recurrence != nil {
+		recurrence, err := normalizeRecurrence(patch.Recurrence)
+		if err != nil {
+			return err
+		}
+		task.Recurrence = recurrence
+	}
+	if patch.Assignees != nil {
+		task.Assignees = normalizeAssignees(patch.Assignees)
+	}
+	return nil
 }
 
-func (s *service) Delete(id string) error {
-	err := s.repo.Delete(id)
+func (s *service) Delete(ctx context.Context, id string, opts DeleteOptions) error {
+	err := s.transaction(ctx, func(tx *service) error {
+		task, err := tx.get(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return deleteTask(ctx, tx, task, opts, func() error {
+			if err := tx.repo.Delete(ctx, id, opts.Version); err != nil {
+				return err
+			}
+
+			tx.publish(ctx, task, nil, tx.clock.Now())
+			return nil
+		})
+	})
 	if err != nil {
 		return fmt.Errorf("error deleting task: %w", err)
 	}
 
 	return nil
 }
+
+// deleteTask deletes a task through s, checking its version, orphaning or deleting its subtasks according to the mode,
+// removing it with remove and then refreshing the status of the tasks it blocked
+func deleteTask(ctx context.Context, s Service, task *Task, opts DeleteOptions, remove func() error) error {
+	if err := checkVersion(opts.Version, task.Version); err != nil {
+		return err
+	}
+
+	if err := deleteSubtasks(ctx, s, task.ID, opts.Mode); err != nil {
+		return err
+	}
+
+	blocked, err := dependents(ctx, s, task.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := remove(); err != nil {
+		return err
+	}
+
+	return refreshBlocked(ctx, s, blocked)
+}
+
+func (s *service) Batch(ctx context.Context, ops []Operation) ([]Change, error) {
+	if err := checkBatch(ops); err != nil {
+		return nil, fmt.Errorf("error applying batch: %w", err)
+	}
+
+	var changes []Change
+	err := s.transaction(ctx, func(tx *service) error {
+		var err error
+		changes, err = applyOperations(ctx, tx, ops)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error applying batch: %w", err)
+	}
+
+	return changes, nil
+}
+
+// transaction calls fn with a service whose changes are applied within a unit of work of the repository,
+// all of them or none, and published once they are applied. A service already within a unit of work calls fn with itself
+func (s *service) transaction(ctx context.Context, fn func(tx *service) error) error {
+	if s.outbox != nil {
+		return fn(s)
+	}
+
+	var notifications []Notification
+	err := s.repo.Transaction(ctx, func(tx Repository) error {
+		projects := s.projects
+		if p, ok := projects.(interface{ within(tx Repository) Projects }); ok {
+			projects = p.within(tx)
+		}
+
+		return fn(&service{
+			repo:        tx,
+			clock:       s.clock,
+			idGenerator: s.idGenerator,
+			projects:    projects,
+			bus:         s.bus,
+			outbox:      &notifications,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, n := range notifications {
+		s.bus.Publish(n)
+	}
+
+	return nil
+}
+
+// get returns the task with the given ID, a *MovedError if it was moved to another project
+func (s *service) get(ctx context.Context, id string) (*Task, error) {
+	task, err := s.repo.Get(ctx, id)
+	if errors.Is(err, ErrTaskNotFound) && s.projects != nil {
+		if movedTo, redirectErr := s.projects.Redirect(ctx, id); redirectErr == nil {
+			return nil, &MovedError{ID: id, MovedTo: movedTo}
+		}
+	}
+
+	return task, err
+}
+
+// nextID returns the ID of a new task of the project
+func (s *service) nextID(ctx context.Context, project string) (string, error) {
+	if project == "" {
+		return s.idGenerator.Next(), nil
+	}
+
+	if s.projects == nil {
+		return "", fmt.Errorf("%w: %s", ErrProjectNotFound, project)
+	}
+
+	return s.projects.NextID(ctx, project)
+}
+
+func (s *service) Move(ctx context.Context, id, project string) (*Task, error) {
+	var moved *Task
+	err := s.transaction(ctx, func(tx *service) error {
+		var err error
+		moved, err = tx.move(ctx, id, project)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error moving task: %w", err)
+	}
+
+	return moved, nil
+}
+
+// move creates the task under its new ID, points the tasks referencing it to the new ID,
+// deletes it under its former ID and redirects the former ID to the new one
+func (s *service) move(ctx context.Context, id, project string) (*Task, error) {
+	task, err := s.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	project = strings.ToUpper(strings.TrimSpace(project))
+	if task.Project == project {
+		return task, nil
+	}
+
+	movedID, err := s.nextID(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	moved := task.clone()
+	moved.ID = movedID
+	moved.Project = project
+	moved.UpdatedAt = now
+
+	if err := s.repo.Create(ctx, moved); err != nil {
+		return nil, err
+	}
+
+	if err := s.remapReferences(ctx, id, movedID, now); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Delete(ctx, id, task.Version); err != nil {
+		return nil, err
+	}
+
+	if s.projects != nil {
+		if err := s.projects.Moved(ctx, id, movedID); err != nil {
+			return nil, err
+		}
+	}
+
+	s.publish(ctx, task, nil, now)
+	s.publish(ctx, nil, moved, now)
+
+	return moved, nil
+}
+
+// remapReferences replaces the former ID of a moved task in the parent and the blockers of the other tasks
+func (s *service) remapReferences(ctx context.Context, from, to string, now time.Time) error {
+	page, err := s.repo.List(ctx, ListOptions{Filter: Filter{Parents: []string{from}}})
+	if err != nil {
+		return err
+	}
+
+	dependents, err := s.repo.List(ctx, ListOptions{Filter: Filter{Blockers: []string{from}}})
+	if err != nil {
+		return err
+	}
+
+	referencing := make(map[string]*Task)
+	for _, t := range append(page.Tasks, dependents.Tasks...) {
+		referencing[t.ID] = t
+	}
+
+	for _, t := range referencing {
+		before := t.clone()
+		if t.ParentID != nil && *t.ParentID == from {
+			t.ParentID = &to
+		}
+		for i, blocker := range t.BlockedBy {
+			if blocker == from {
+				t.BlockedBy[i] = to
+			}
+		}
+		t.BlockedBy = normalizeBlockers(t.BlockedBy)
+		t.UpdatedAt = now
+
+		if err := s.repo.Update(ctx, t); err != nil {
+			return err
+		}
+
+		s.publish(ctx, before, t, now)
+	}
+
+	return nil
+}
+
+func (s *service) Plan(ctx context.Context) (*Plan, error) {
+	result, err := plan(ctx, s)
+	if err != nil {
+		return nil, fmt.Errorf("error planning tasks: %w", err)
+	}
+
+	return result, nil
+}
+
+func (s *service) Progress(ctx context.Context, ids []string) (map[string]Progress, error) {
+	result, err := progress(ctx, s, ids)
+	if err != nil {
+		return nil, fmt.Errorf("error computing progress: %w", err)
+	}
+
+	return result, nil
+}
+
+func (s *service) Tags(ctx context.Context) ([]TagCount, error) {
+	tags, err := s.repo.Tags(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+func (s *service) History(ctx context.Context, id string) ([]Event, error) {
+	repo, ok := s.repo.(HistoryRepository)
+	if !ok {
+		return nil, ErrHistoryUnavailable
+	}
+
+	events, err := repo.History(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting task history: %w", err)
+	}
+
+	return events, nil
+}
+
+func (s *service) Search(ctx context.Context, query string, opts SearchOptions) (*SearchResult, error) {
+	repo, ok := s.repo.(SearchRepository)
+	if !ok {
+		return nil, ErrSearchUnavailable
+	}
+
+	var err error
+	if opts.Filter, err = opts.Filter.bind(ctx, s.clock); err != nil {
+		return nil, fmt.Errorf("error searching tasks: %w", err)
+	}
+
+	result, err := repo.Search(ctx, query, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error searching tasks: %w", err)
+	}
+
+	return result, nil
+}
+
+func (s *service) Subscribe(ctx context.Context, filter NotificationFilter, lastID int64) (*Subscription, error) {
+	sub, err := s.bus.Subscribe(ctx, filter, lastID)
+	if err != nil {
+		return nil, fmt.Errorf("error subscribing to task changes: %w", err)
+	}
+
+	return sub, nil
+}
+
+// publish publishes the change of a task from before to after on the bus
+func (s *service) publish(ctx context.Context, before, after *Task, at time.Time) {
+	n := Notification{
+		Type:       eventType(before, after),
+		Changes:    Diff(before, after),
+		Actor:      ActorFromContext(ctx),
+		OccurredAt: at,
+	}
+
+	if after != nil {
+		n.TaskID, n.Task = after.ID, after.clone()
+	} else {
+		n.TaskID, n.Task = before.ID, before.clone()
+	}
+
+	if s.outbox != nil {
+		*s.outbox = append(*s.outbox, n)
+		return
+	}
+
+	s.bus.Publish(n)
+}
+
+func (s *service) RenameTag(ctx context.Context, from, to string) (TagCount, error) {
+	tag, err := renameTag(ctx, s, from, to)
+	if err != nil {
+		return TagCount{}, fmt.Errorf("error renaming tag: %w", err)
+	}
+
+	return tag, nil
+}
+
+func (s *service) MergeTags(ctx context.Context, sources []string, target string) (TagCount, error) {
+	tag, err := mergeTags(ctx, s, sources, target)
+	if err != nil {
+		return TagCount{}, fmt.Errorf("error merging tags: %w", err)
+	}
+
+	return tag, nil
+}
@@ -10,6 +10,7 @@
 package task
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -38,75 +39,225 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 	return m.recorder
 }
 
+// Batch mocks base method.
+func (m *MockService) Batch(arg0 context.Context, arg1 []Operation) ([]Change, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Batch", arg0, arg1)
+	ret0, _ := ret[0].([]Change)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Batch indicates an expected call of Batch.
+func (mr *MockServiceMockRecorder) Batch(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Batch", reflect.TypeOf((*MockService)(nil).Batch), arg0, arg1)
+}
+
 // Create mocks base method.
-func (m *MockService) Create(arg0 *Task) error {
+func (m *MockService) Create(arg0 context.Context, arg1 *Task) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", arg0)
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockServiceMockRecorder) Create(arg0 any) *gomock.Call {
+func (mr *MockServiceMockRecorder) Create(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockService)(nil).Create), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockService)(nil).Create), arg0, arg1)
 }
 
 // Delete mocks base method.
-func (m *MockService) Delete(arg0 string) error {
+func (m *MockService) Delete(arg0 context.Context, arg1 string, arg2 DeleteOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockServiceMockRecorder) Delete(arg0 any) *gomock.Call {
+func (mr *MockServiceMockRecorder) Delete(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockService)(nil).Delete), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockService)(nil).Delete), arg0, arg1, arg2)
 }
 
 // Get mocks base method.
-func (m *MockService) Get(arg0 string) (*Task, error) {
+func (m *MockService) Get(arg0 context.Context, arg1 string) (*Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", arg0)
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
 	ret0, _ := ret[0].(*Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockServiceMockRecorder) Get(arg0 any) *gomock.Call {
+func (mr *MockServiceMockRecorder) Get(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockService)(nil).Get), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockService)(nil).Get), arg0, arg1)
+}
+
+// History mocks base method.
+func (m *MockService) History(arg0 context.Context, arg1 string) ([]Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "History", arg0, arg1)
+	ret0, _ := ret[0].([]Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// History indicates an expected call of History.
+func (mr *MockServiceMockRecorder) History(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockService)(nil).History), arg0, arg1)
 }
 
 // List mocks base method.
-func (m *MockService) List() ([]*Task, error) {
+func (m *MockService) List(arg0 context.Context, arg1 ListOptions) (*Page, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List")
-	ret0, _ := ret[0].([]*Task)
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].(*Page)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // List indicates an expected call of List.
-func (mr *MockServiceMockRecorder) List() *gomock.Call {
+func (mr *MockServiceMockRecorder) List(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockService)(nil).List), arg0, arg1)
+}
+
+// MergeTags mocks base method.
+func (m *MockService) MergeTags(arg0 context.Context, arg1 []string, arg2 string) (TagCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeTags", arg0, arg1, arg2)
+	ret0, _ := ret[0].(TagCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeTags indicates an expected call of MergeTags.
+func (mr *MockServiceMockRecorder) MergeTags(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeTags", reflect.TypeOf((*MockService)(nil).MergeTags), arg0, arg1, arg2)
+}
+
+// Move mocks base method.
+func (m *MockService) Move(arg0 context.Context, arg1, arg2 string) (*Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockServiceMockRecorder) Move(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockService)(nil).Move), arg0, arg1, arg2)
+}
+
+// Plan mocks base method.
+func (m *MockService) Plan(arg0 context.Context) (*Plan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Plan", arg0)
+	ret0, _ := ret[0].(*Plan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Plan indicates an expected call of Plan.
+func (mr *MockServiceMockRecorder) Plan(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Plan", reflect.TypeOf((*MockService)(nil).Plan), arg0)
+}
+
+// Progress mocks base method.
+func (m *MockService) Progress(arg0 context.Context, arg1 []string) (map[string]Progress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Progress", arg0, arg1)
+	ret0, _ := ret[0].(map[string]Progress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Progress indicates an expected call of Progress.
+func (mr *MockServiceMockRecorder) Progress(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Progress", reflect.TypeOf((*MockService)(nil).Progress), arg0, arg1)
+}
+
+// RenameTag mocks base method.
+func (m *MockService) RenameTag(arg0 context.Context, arg1, arg2 string) (TagCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameTag", arg0, arg1, arg2)
+	ret0, _ := ret[0].(TagCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameTag indicates an expected call of RenameTag.
+func (mr *MockServiceMockRecorder) RenameTag(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameTag", reflect.TypeOf((*MockService)(nil).RenameTag), arg0, arg1, arg2)
+}
+
+// Search mocks base method.
+func (m *MockService) Search(arg0 context.Context, arg1 string, arg2 SearchOptions) (*SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockServiceMockRecorder) Search(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockService)(nil).Search), arg0, arg1, arg2)
+}
+
+// Subscribe mocks base method.
+func (m *MockService) Subscribe(arg0 context.Context, arg1 NotificationFilter, arg2 int64) (*Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockServiceMockRecorder) Subscribe(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockService)(nil).Subscribe), arg0, arg1, arg2)
+}
+
+// Tags mocks base method.
+func (m *MockService) Tags(arg0 context.Context) ([]TagCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tags", arg0)
+	ret0, _ := ret[0].([]TagCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Tags indicates an expected call of Tags.
+func (mr *MockServiceMockRecorder) Tags(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockService)(nil).List))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tags", reflect.TypeOf((*MockService)(nil).Tags), arg0)
 }
 
 // Update mocks base method.
-func (m *MockService) Update(arg0 string, arg1 *Task) (*Task, error) {
+func (m *MockService) Update(arg0 context.Context, arg1 string, arg2 *Task) (*Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockServiceMockRecorder) Update(arg0, arg1 any) *gomock.Call {
+func (mr *MockServiceMockRecorder) Update(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockService)(nil).Update), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockService)(nil).Update), arg0, arg1, arg2)
 }
@@ -1,11 +1,14 @@
 package task
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/utsabbera/task-master/pkg/idgen"
 	"github.com/utsabbera/task-master/pkg/match"
 	"github.com/utsabbera/task-master/pkg/util"
@@ -14,6 +17,7 @@ import (
 
 func TestService_Create(t *testing.T) {
 	t.Run("should create task with valid data", func(t *testing.T) {
+		ctx := context.Background()
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
@@ -37,7 +41,7 @@ func TestService_Create(t *testing.T) {
 			DueDate:     &due,
 		}
 
-		mockRepo.EXPECT().Create(match.PtrTo(&Task{
+		mockRepo.EXPECT().Create(ctx, match.PtrTo(&Task{
 			ID:          "TEST-ID",
 			Title:       "Test Task",
 			Description: "Description",
@@ -46,9 +50,10 @@ func TestService_Create(t *testing.T) {
 			DueDate:     &due,
 			CreatedAt:   createTime,
 			UpdatedAt:   createTime,
+			StartedAt:   &createTime,
 		})).Return(nil)
 
-		err := service.Create(task)
+		err := service.Create(ctx, task)
 
 		assert.NoError(t, err)
 		assert.Equal(t, "TEST-ID", task.ID)
@@ -59,6 +64,7 @@ func TestService_Create(t *testing.T) {
 	})
 
 	t.Run("should create task without priority", func(t *testing.T) {
+		ctx := context.Background()
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
@@ -80,7 +86,7 @@ func TestService_Create(t *testing.T) {
 			DueDate:     &due,
 		}
 
-		mockRepo.EXPECT().Create(match.PtrTo(&Task{
+		mockRepo.EXPECT().Create(ctx, match.PtrTo(&Task{
 			ID:          "TEST-ID",
 			Title:       "No Priority",
 			Description: "Description",
@@ -89,9 +95,10 @@ func TestService_Create(t *testing.T) {
 			DueDate:     &due,
 			CreatedAt:   createTime,
 			UpdatedAt:   createTime,
+			StartedAt:   &createTime,
 		})).Return(nil)
 
-		err := service.Create(task)
+		err := service.Create(ctx, task)
 
 		assert.NoError(t, err)
 		assert.Equal(t, "TEST-ID", task.ID)
@@ -99,6 +106,7 @@ func TestService_Create(t *testing.T) {
 	})
 
 	t.Run("should create task without due date", func(t *testing.T) {
+		ctx := context.Background()
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
@@ -119,7 +127,7 @@ func TestService_Create(t *testing.T) {
 			Priority:    &priority,
 		}
 
-		mockRepo.EXPECT().Create(match.PtrTo(&Task{
+		mockRepo.EXPECT().Create(ctx, match.PtrTo(&Task{
 			ID:          "TEST-ID",
 			Title:       "No DueDate",
 			Description: "Description",
@@ -128,9 +136,10 @@ func TestService_Create(t *testing.T) {
 			DueDate:     nil,
 			CreatedAt:   createTime,
 			UpdatedAt:   createTime,
+			StartedAt:   &createTime,
 		})).Return(nil)
 
-		err := service.Create(task)
+		err := service.Create(ctx, task)
 
 		assert.NoError(t, err)
 		assert.Equal(t, "TEST-ID", task.ID)
@@ -138,6 +147,7 @@ func TestService_Create(t *testing.T) {
 	})
 
 	t.Run("should set status to not started when not passed", func(t *testing.T) {
+		ctx := context.Background()
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
@@ -157,7 +167,7 @@ func TestService_Create(t *testing.T) {
 			DueDate:     nil,
 		}
 
-		mockRepo.EXPECT().Create(match.PtrTo(&Task{
+		mockRepo.EXPECT().Create(ctx, match.PtrTo(&Task{
 			ID:          "TEST-ID",
 			Title:       "Task Without Status",
 			Description: "Description",
@@ -168,13 +178,14 @@ func TestService_Create(t *testing.T) {
 			UpdatedAt:   createTime,
 		})).Return(nil)
 
-		err := service.Create(task)
+		err := service.Create(ctx, task)
 
 		assert.NoError(t, err)
 		assert.Equal(t, StatusNotStarted, task.Status)
 	})
 
 	t.Run("should return error when repository create fails", func(t *testing.T) {
+		ctx := context.Background()
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
@@ -192,7 +203,7 @@ func TestService_Create(t *testing.T) {
 			Description: "Description",
 		}
 
-		mockRepo.EXPECT().Create(match.PtrTo(&Task{
+		mockRepo.EXPECT().Create(ctx, match.PtrTo(&Task{
 			ID:          "TEST-ID",
 			Title:       "Test Task",
 			Description: "Description",
@@ -203,15 +214,32 @@ func TestService_Create(t *testing.T) {
 			UpdatedAt:   createTime,
 		})).Return(errors.New("repository error"))
 
-		err := service.Create(task)
+		err := service.Create(ctx, task)
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "repository error")
 	})
+
+	t.Run("should return error when status is unknown", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		clock := util.NewMockClock(ctrl)
+		mockRepo := NewMockRepository(ctrl)
+		service := NewService(mockRepo, idgen.NewMockGenerator(ctrl), clock)
+
+		clock.EXPECT().Now().Return(time.Now())
+
+		err := service.Create(ctx, &Task{Title: "Test Task", Status: "DONE"})
+
+		assert.ErrorIs(t, err, ErrInvalidStatus)
+	})
 }
 
 func TestService_Get(t *testing.T) {
 	t.Run("should get task by id", func(t *testing.T) {
+		ctx := context.Background()
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
@@ -221,10 +249,10 @@ func TestService_Get(t *testing.T) {
 		service := NewService(mockRepo, mockIdGen, clock)
 
 		mockRepo.EXPECT().
-			Get("TEST-ID").
+			Get(ctx, "TEST-ID").
 			Return(&Task{ID: "TEST-ID", Title: "Test Task"}, nil)
 
-		task, err := service.Get("TEST-ID")
+		task, err := service.Get(ctx, "TEST-ID")
 
 		assert.NoError(t, err)
 		assert.Equal(t, "TEST-ID", task.ID)
@@ -232,6 +260,7 @@ func TestService_Get(t *testing.T) {
 	})
 
 	t.Run("should return error when task not found", func(t *testing.T) {
+		ctx := context.Background()
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
@@ -241,10 +270,10 @@ func TestService_Get(t *testing.T) {
 		service := NewService(mockRepo, mockIdGen, clock)
 
 		mockRepo.EXPECT().
-			Get("UNKNOWN").
+			Get(ctx, "UNKNOWN").
 			Return(nil, errors.New("not found"))
 
-		task, err := service.Get("UNKNOWN")
+		task, err := service.Get(ctx, "UNKNOWN")
 
 		assert.Error(t, err)
 		assert.Nil(t, task)
@@ -253,7 +282,8 @@ func TestService_Get(t *testing.T) {
 }
 
 func TestService_List(t *testing.T) {
-	t.Run("should list all tasks", func(t *testing.T) {
+	t.Run("should list tasks matching options", func(t *testing.T) {
+		ctx := context.Background()
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
@@ -262,23 +292,32 @@ func TestService_List(t *testing.T) {
 		mockIdGen := idgen.NewMockGenerator(ctrl)
 		service := NewService(mockRepo, mockIdGen, clock)
 
-		tasks := []*Task{
-			{ID: "1", Title: "Task 1"},
-			{ID: "2", Title: "Task 2"},
+		opts := ListOptions{
+			Filter: Filter{Statuses: []Status{StatusInProgress}},
+			Sort:   []Sort{{Field: SortByDueDate, Descending: true}},
+			Limit:  2,
+		}
+		page := &Page{
+			Tasks: []*Task{
+				{ID: "1", Title: "Task 1"},
+				{ID: "2", Title: "Task 2"},
+			},
+			Total:      3,
+			NextCursor: "Mg",
 		}
 
 		mockRepo.EXPECT().
-			List().
-			Return(tasks, nil)
+			List(ctx, opts).
+			Return(page, nil)
 
-		result, err := service.List()
+		result, err := service.List(ctx, opts)
 
 		assert.NoError(t, err)
-		assert.Equal(t, tasks, result)
-		assert.Len(t, result, 2)
+		assert.Equal(t, page, result)
 	})
 
 	t.Run("should return empty list when no tasks", func(t *testing.T) {
+		ctx := context.Background()
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
@@ -288,16 +327,42 @@ func TestService_List(t *testing.T) {
 		service := NewService(mockRepo, mockIdGen, clock)
 
 		mockRepo.EXPECT().
-			List().
-			Return([]*Task{}, nil)
+			List(ctx, ListOptions{}).
+			Return(&Page{Tasks: []*Task{}}, nil)
 
-		result, err := service.List()
+		result, err := service.List(ctx, ListOptions{})
 
 		assert.NoError(t, err)
-		assert.Empty(t, result)
+		assert.Empty(t, result.Tasks)
+	})
+
+	t.Run("should return error when options are invalid", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		clock := util.NewMockClock(ctrl)
+		mockRepo := NewMockRepository(ctrl)
+		mockIdGen := idgen.NewMockGenerator(ctrl)
+		service := NewService(mockRepo, mockIdGen, clock)
+
+		invalid := []ListOptions{
+			{Limit: -1},
+			{Filter: Filter{Statuses: []Status{"DONE"}}},
+			{Sort: []Sort{{Field: "color"}}},
+			{Cursor: "%%%"},
+		}
+
+		for _, opts := range invalid {
+			result, err := service.List(ctx, opts)
+
+			assert.ErrorIs(t, err, ErrInvalidListOptions)
+			assert.Nil(t, result)
+		}
 	})
 
 	t.Run("should return error when repository fails", func(t *testing.T) {
+		ctx := context.Background()
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
@@ -307,10 +372,10 @@ func TestService_List(t *testing.T) {
 		service := NewService(mockRepo, mockIdGen, clock)
 
 		mockRepo.EXPECT().
-			List().
+			List(ctx, ListOptions{}).
 			Return(nil, errors.New("repository error"))
 
-		result, err := service.List()
+		result, err := service.List(ctx, ListOptions{})
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -320,6 +385,7 @@ func TestService_List(t *testing.T) {
 
 func TestService_Update(t *testing.T) {
 	t.Run("should update task fields", func(t *testing.T) {
+		ctx := context.Background()
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
@@ -353,18 +419,130 @@ func TestService_Update(t *testing.T) {
 			UpdatedAt:   updateTime,
 		}
 
-		mockRepo.EXPECT().Get(id).Return(existing, nil)
-		mockRepo.EXPECT().Update(match.PtrTo(updated)).Return(nil)
+		expectTransaction(ctx, mockRepo)
+		mockRepo.EXPECT().Get(ctx, id).Return(existing, nil)
+		mockRepo.EXPECT().Update(ctx, match.PtrTo(updated)).Return(nil)
 		clock.EXPECT().Now().Return(updateTime)
 
-		result, err := service.Update(id, patch)
+		result, err := service.Update(ctx, id, patch)
 
 		assert.NoError(t, err)
 		assert.Equal(t, "New Title", result.Title)
 		assert.Equal(t, "New Desc", result.Description)
 	})
 
+	t.Run("should return conflict when patch version is stale", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := NewMockRepository(ctrl)
+		service := NewService(mockRepo, idgen.NewMockGenerator(ctrl), util.NewMockClock(ctrl))
+
+		expectTransaction(ctx, mockRepo)
+		mockRepo.EXPECT().Get(ctx, "TEST-ID").Return(&Task{ID: "TEST-ID", Title: "Title", Version: 3}, nil)
+
+		result, err := service.Update(ctx, "TEST-ID", &Task{Title: "New Title", Version: 2})
+
+		assert.ErrorIs(t, err, ErrConflict)
+		assert.Nil(t, result)
+	})
+
+	t.Run("should update task with the version it was read with", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		clock := util.NewMockClock(ctrl)
+		mockRepo := NewMockRepository(ctrl)
+		service := NewService(mockRepo, idgen.NewMockGenerator(ctrl), clock)
+
+		updateTime := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+		expectTransaction(ctx, mockRepo)
+		mockRepo.EXPECT().Get(ctx, "TEST-ID").Return(&Task{ID: "TEST-ID", Title: "Title", Version: 3}, nil)
+		mockRepo.EXPECT().Update(ctx, match.PtrTo(Task{ID: "TEST-ID", Title: "New Title", UpdatedAt: updateTime, Version: 3})).Return(fmt.Errorf("%w: expected version 3, found 4", ErrConflict))
+		clock.EXPECT().Now().Return(updateTime)
+
+		_, err := service.Update(ctx, "TEST-ID", &Task{Title: "New Title", Version: 3})
+
+		assert.ErrorIs(t, err, ErrConflict)
+	})
+
+	t.Run("should move task to new status and record completion", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		clock := util.NewMockClock(ctrl)
+		mockRepo := NewMockRepository(ctrl)
+		service := NewService(mockRepo, idgen.NewMockGenerator(ctrl), clock)
+
+		startTime := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+		updateTime := startTime.Add(time.Hour)
+		existing := &Task{ID: "TEST-ID", Title: "Title", Status: StatusInProgress, StartedAt: &startTime, UpdatedAt: startTime}
+
+		expectTransaction(ctx, mockRepo)
+		mockRepo.EXPECT().Get(ctx, "TEST-ID").Return(existing, nil)
+		mockRepo.EXPECT().Update(ctx, match.PtrTo(&Task{
+			ID:          "TEST-ID",
+			Title:       "Title",
+			Status:      StatusCompleted,
+			StartedAt:   &startTime,
+			CompletedAt: &updateTime,
+			UpdatedAt:   updateTime,
+		})).Return(nil)
+		mockRepo.EXPECT().List(ctx, ListOptions{Filter: Filter{Blockers: []string{"TEST-ID"}}}).Return(&Page{Tasks: []*Task{}}, nil)
+		clock.EXPECT().Now().Return(updateTime)
+
+		result, err := service.Update(ctx, "TEST-ID", &Task{Status: StatusCompleted})
+
+		assert.NoError(t, err)
+		assert.Equal(t, StatusCompleted, result.Status)
+	})
+
+	t.Run("should return transition error when status change is not allowed", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		clock := util.NewMockClock(ctrl)
+		mockRepo := NewMockRepository(ctrl)
+		service := NewService(mockRepo, idgen.NewMockGenerator(ctrl), clock)
+
+		expectTransaction(ctx, mockRepo)
+		mockRepo.EXPECT().Get(ctx, "TEST-ID").Return(&Task{ID: "TEST-ID", Status: StatusNotStarted}, nil)
+		clock.EXPECT().Now().Return(time.Now())
+
+		result, err := service.Update(ctx, "TEST-ID", &Task{Status: StatusCompleted})
+
+		assert.ErrorIs(t, err, ErrInvalidTransition)
+		var transitionErr *TransitionError
+		assert.ErrorAs(t, err, &transitionErr)
+		assert.Equal(t, &TransitionError{From: StatusNotStarted, To: StatusCompleted}, transitionErr)
+		assert.Nil(t, result)
+	})
+
+	t.Run("should return error when status is unknown", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		clock := util.NewMockClock(ctrl)
+		mockRepo := NewMockRepository(ctrl)
+		service := NewService(mockRepo, idgen.NewMockGenerator(ctrl), clock)
+
+		expectTransaction(ctx, mockRepo)
+		mockRepo.EXPECT().Get(ctx, "TEST-ID").Return(&Task{ID: "TEST-ID", Status: StatusNotStarted}, nil)
+		clock.EXPECT().Now().Return(time.Now())
+
+		result, err := service.Update(ctx, "TEST-ID", &Task{Status: "DONE"})
+
+		assert.ErrorIs(t, err, ErrInvalidStatus)
+		assert.Nil(t, result)
+	})
+
 	t.Run("should return error if repo.Get fails", func(t *testing.T) {
+		ctx := context.Background()
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
@@ -373,10 +551,11 @@ func TestService_Update(t *testing.T) {
 		mockIdGen := idgen.NewMockGenerator(ctrl)
 		service := NewService(mockRepo, mockIdGen, clock)
 
-		mockRepo.EXPECT().Get("BAD-ID").Return(nil, errors.New("not found"))
+		expectTransaction(ctx, mockRepo)
+		mockRepo.EXPECT().Get(ctx, "BAD-ID").Return(nil, errors.New("not found"))
 
 		patch := &Task{Title: "Patch"}
-		result, err := service.Update("BAD-ID", patch)
+		result, err := service.Update(ctx, "BAD-ID", patch)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -384,6 +563,7 @@ func TestService_Update(t *testing.T) {
 	})
 
 	t.Run("should return error if repo.Update fails", func(t *testing.T) {
+		ctx := context.Background()
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
@@ -405,11 +585,12 @@ func TestService_Update(t *testing.T) {
 			UpdatedAt: updateTime,
 		}
 
-		mockRepo.EXPECT().Get(id).Return(existing, nil)
-		mockRepo.EXPECT().Update(updated).Return(errors.New("update error"))
+		expectTransaction(ctx, mockRepo)
+		mockRepo.EXPECT().Get(ctx, id).Return(existing, nil)
+		mockRepo.EXPECT().Update(ctx, updated).Return(errors.New("update error"))
 		clock.EXPECT().Now().Return(updateTime)
 
-		result, err := service.Update(id, patch)
+		result, err := service.Update(ctx, id, patch)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -419,6 +600,7 @@ func TestService_Update(t *testing.T) {
 
 func TestService_Delete(t *testing.T) {
 	t.Run("should delete task", func(t *testing.T) {
+		ctx := context.Background()
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
@@ -427,16 +609,28 @@ func TestService_Delete(t *testing.T) {
 		mockIdGen := idgen.NewMockGenerator(ctrl)
 		service := NewService(mockRepo, mockIdGen, clock)
 
+		expectTransaction(ctx, mockRepo)
 		mockRepo.EXPECT().
-			Delete("TEST-ID").
+			Get(ctx, "TEST-ID").
+			Return(&Task{ID: "TEST-ID", Version: 3}, nil)
+		mockRepo.EXPECT().
+			List(ctx, ListOptions{Filter: Filter{Parents: []string{"TEST-ID"}}}).
+			Return(&Page{Tasks: []*Task{}}, nil)
+		mockRepo.EXPECT().
+			List(ctx, ListOptions{Filter: Filter{Blockers: []string{"TEST-ID"}}}).
+			Return(&Page{Tasks: []*Task{}}, nil)
+		mockRepo.EXPECT().
+			Delete(ctx, "TEST-ID", 3).
 			Return(nil)
+		clock.EXPECT().Now().Return(time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC))
 
-		err := service.Delete("TEST-ID")
+		err := service.Delete(ctx, "TEST-ID", DeleteOptions{Version: 3})
 
 		assert.NoError(t, err)
 	})
 
 	t.Run("should return error when delete fails", func(t *testing.T) {
+		ctx := context.Background()
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
@@ -445,13 +639,178 @@ func TestService_Delete(t *testing.T) {
 		mockIdGen := idgen.NewMockGenerator(ctrl)
 		service := NewService(mockRepo, mockIdGen, clock)
 
+		expectTransaction(ctx, mockRepo)
+		mockRepo.EXPECT().
+			Get(ctx, "TEST-ID").
+			Return(&Task{ID: "TEST-ID", Version: 1}, nil)
 		mockRepo.EXPECT().
-			Delete("TEST-ID").
+			List(ctx, ListOptions{Filter: Filter{Parents: []string{"TEST-ID"}}}).
+			Return(&Page{Tasks: []*Task{}}, nil)
+		mockRepo.EXPECT().
+			List(ctx, ListOptions{Filter: Filter{Blockers: []string{"TEST-ID"}}}).
+			Return(&Page{Tasks: []*Task{}}, nil)
+		mockRepo.EXPECT().
+			Delete(ctx, "TEST-ID", 0).
 			Return(errors.New("delete error"))
 
-		err := service.Delete("TEST-ID")
+		err := service.Delete(ctx, "TEST-ID", DeleteOptions{})
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "delete error")
 	})
+
+	t.Run("should return conflict without deleting when version differs", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		clock := util.NewMockClock(ctrl)
+		mockRepo := NewMockRepository(ctrl)
+		mockIdGen := idgen.NewMockGenerator(ctrl)
+		service := NewService(mockRepo, mockIdGen, clock)
+
+		expectTransaction(ctx, mockRepo)
+		mockRepo.EXPECT().
+			Get(ctx, "TEST-ID").
+			Return(&Task{ID: "TEST-ID", Version: 4}, nil)
+
+		err := service.Delete(ctx, "TEST-ID", DeleteOptions{Version: 3})
+
+		assert.ErrorIs(t, err, ErrConflict)
+	})
+}
+
+// expectTransaction expects a unit of work of the mock repository, running its operations on the repository itself
+func expectTransaction(ctx context.Context, mockRepo *MockRepository) {
+	mockRepo.EXPECT().
+		Transaction(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, fn func(tx Repository) error) error { return fn(mockRepo) })
+}
+
+func TestService_History(t *testing.T) {
+	t.Run("should get history from repository", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo, err := NewEventRepository(ctx, NewMemoryEventStore(), newTickingClock(ctrl), 0)
+		require.NoError(t, err)
+		service := NewService(repo, idgen.NewSequential("TASK-", 1, 6), newTickingClock(ctrl))
+		require.NoError(t, service.Create(ctx, &Task{Title: "Test Task"}))
+
+		events, err := service.History(ctx, "TASK-000001")
+
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, EventCreated, events[0].Type)
+	})
+
+	t.Run("should return error when repository doesn't record history", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service := NewService(NewMockRepository(ctrl), idgen.NewMockGenerator(ctrl), util.NewMockClock(ctrl))
+
+		_, err := service.History(ctx, "TEST-ID")
+
+		assert.ErrorIs(t, err, ErrHistoryUnavailable)
+	})
+}
+
+func TestService_Batch(t *testing.T) {
+	newService := func(t *testing.T) Service {
+		t.Helper()
+		return NewService(NewMemoryRepository(), idgen.NewSequential("TASK-", 1, 6), newTickingClock(gomock.NewController(t)))
+	}
+
+	t.Run("should apply operations in order replacing references by IDs", func(t *testing.T) {
+		ctx := context.Background()
+		service := newService(t)
+		require.NoError(t, service.Create(ctx, &Task{Title: "Write report"}))
+
+		changes, err := service.Batch(ctx, []Operation{
+			{Type: ChangeCreate, Ref: "review", Task: &Task{Title: "Review report", BlockedBy: []string{"TASK-000001"}}},
+			{Type: ChangeCreate, Task: &Task{Title: "Fix typos", ParentID: util.Ptr("review")}},
+			{Type: ChangeUpdate, TaskID: "review", Task: &Task{Description: "Check figures", Version: 1}},
+			{Type: ChangeDelete, TaskID: "TASK-000001", DeleteOptions: DeleteOptions{Mode: DeleteOrphan}},
+		})
+		require.NoError(t, err)
+
+		require.Len(t, changes, 4)
+		assert.Equal(t, []string{"TASK-000002", "TASK-000003", "TASK-000002", "TASK-000001"}, util.Map(changes, func(c Change) string { return c.TaskID }))
+		assert.Equal(t, util.Ptr("TASK-000002"), changes[1].After.ParentID)
+		assert.Equal(t, "Check figures", changes[2].After.Description)
+		assert.Equal(t, "Write report", changes[3].Before.Title)
+
+		task, err := service.Get(ctx, "TASK-000002")
+		require.NoError(t, err)
+		assert.Empty(t, task.BlockedBy)
+		assert.Equal(t, 3, task.Version)
+	})
+
+	t.Run("should apply none of the operations when one fails", func(t *testing.T) {
+		ctx := context.Background()
+		service := newService(t)
+		require.NoError(t, service.Create(ctx, &Task{Title: "Write report"}))
+		sub, err := service.Subscribe(ctx, NotificationFilter{}, 0)
+		require.NoError(t, err)
+		defer sub.Close()
+
+		_, err = service.Batch(ctx, []Operation{
+			{Type: ChangeCreate, Task: &Task{Title: "Review report"}},
+			{Type: ChangeUpdate, TaskID: "TASK-000001", Task: &Task{Title: "Write summary"}},
+			{Type: ChangeUpdate, TaskID: "TASK-000001", Task: &Task{Title: "Write abstract", Version: 1}},
+		})
+
+		var batchErr *BatchError
+		require.ErrorAs(t, err, &batchErr)
+		assert.Equal(t, 2, batchErr.Index)
+		assert.ErrorIs(t, err, ErrConflict)
+
+		page, err := service.List(ctx, ListOptions{})
+		require.NoError(t, err)
+		require.Len(t, page.Tasks, 1)
+		assert.Equal(t, "Write report", page.Tasks[0].Title)
+
+		require.NoError(t, service.Delete(ctx, "TASK-000001", DeleteOptions{}))
+		assert.Equal(t, []EventType{EventDeleted}, util.Map(receive(t, sub, 1), func(n Notification) EventType { return n.Type }))
+	})
+
+	t.Run("should publish notifications once applied", func(t *testing.T) {
+		ctx := context.Background()
+		service := newService(t)
+		sub, err := service.Subscribe(ctx, NotificationFilter{}, 0)
+		require.NoError(t, err)
+		defer sub.Close()
+
+		_, err = service.Batch(ctx, []Operation{
+			{Type: ChangeCreate, Ref: "report", Task: &Task{Title: "Write report"}},
+			{Type: ChangeDelete, TaskID: "report"},
+		})
+		require.NoError(t, err)
+
+		notifications := receive(t, sub, 2)
+		assert.Equal(t, []EventType{EventCreated, EventDeleted}, util.Map(notifications, func(n Notification) EventType { return n.Type }))
+	})
+
+	t.Run("should return invalid batch error", func(t *testing.T) {
+		tests := []struct {
+			name string
+			ops  []Operation
+		}{
+			{"without operations", nil},
+			{"with unknown operation", []Operation{{Type: "move", TaskID: "TASK-000001"}}},
+			{"with create without task", []Operation{{Type: ChangeCreate}}},
+			{"with delete without task ID", []Operation{{Type: ChangeDelete}}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := newService(t).Batch(context.Background(), tt.ops)
+
+				assert.ErrorIs(t, err, ErrInvalidBatch)
+			})
+		}
+	})
 }
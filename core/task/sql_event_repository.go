@@ -0,0 +1,148 @@
+package task
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/utsabbera/task-master/pkg/util"
+)
+
+// NewSQLEventRepository creates a new SQL repository which appends the event of every change of the tasks to the
+// event log of the database within the transaction of the change, the tasks table being the projection of the log
+// which answers the queries. The tasks table is brought in line with the log on first use: the tasks stored before
+// the log existed are recorded as created with their current version, and the tasks only kept in the log
+// are restored by replaying it
+func NewSQLEventRepository(ctx context.Context, db *sql.DB, clock util.Clock) (*SQLRepository, error) {
+	if err := migrate(ctx, db); err != nil {
+		return nil, err
+	}
+
+	r := &SQLRepository{db: db, clock: clock}
+	if err := r.project(ctx); err != nil {
+		return nil, fmt.Errorf("error projecting task events: %w", err)
+	}
+
+	return r, nil
+}
+
+// History returns the events of a task, ErrHistoryUnavailable if the repository doesn't record them
+func (r *SQLRepository) History(ctx context.Context, id string) ([]Event, error) {
+	if r.clock == nil {
+		return nil, ErrHistoryUnavailable
+	}
+
+	events, err := queryEvents(ctx, r.querier(), `WHERE task_id = ?`, id)
+	if err != nil {
+		return nil, fmt.Errorf("error loading events: %w", err)
+	}
+
+	if len(events) == 0 {
+		return nil, ErrTaskNotFound
+	}
+
+	return events, nil
+}
+
+// record appends the event of the change of a task from before to after within the transaction of the change,
+// unless the repository doesn't record events
+func (r *SQLRepository) record(ctx context.Context, tx *sql.Tx, before, after *Task) error {
+	if r.clock == nil {
+		return nil
+	}
+
+	return insertEvent(ctx, tx, newEvent(ctx, before, after, r.clock.Now()))
+}
+
+// previous returns the task before it is changed within the transaction, for the event recording the change.
+// Returns nil if the repository doesn't record events or the task doesn't exist
+func (r *SQLRepository) previous(ctx context.Context, tx *sql.Tx, id string) (*Task, error) {
+	if r.clock == nil {
+		return nil, nil
+	}
+
+	t, err := getTask(ctx, tx, id)
+	if errors.Is(err, ErrTaskNotFound) {
+		return nil, nil
+	}
+
+	return t, err
+}
+
+// project makes the tasks table the projection of the event log unless it already is
+func (r *SQLRepository) project(ctx context.Context) error {
+	var projected, logged bool
+	err := r.db.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM task_projection), EXISTS (SELECT 1 FROM task_events)`,
+	).Scan(&projected, &logged)
+	if err != nil {
+		return err
+	}
+
+	if projected {
+		return nil
+	}
+
+	var replayed []*Task
+	if logged {
+		repo, err := NewEventRepository(ctx, &SQLEventStore{db: r.db}, r.clock, DefaultSnapshotInterval)
+		if err != nil {
+			return err
+		}
+
+		page, err := repo.List(ctx, ListOptions{})
+		if err != nil {
+			return err
+		}
+		replayed = page.Tasks
+	}
+
+	return inTx(ctx, r.db, func(tx *sql.Tx) error {
+		if logged {
+			if err := restoreTasks(ctx, tx, replayed); err != nil {
+				return err
+			}
+		} else if err := recordTasks(ctx, tx, r.clock); err != nil {
+			return err
+		}
+
+		_, err := tx.ExecContext(ctx, `INSERT INTO task_projection (sequence) SELECT COALESCE(MAX(sequence), 0) FROM task_events`)
+		return err
+	})
+}
+
+// restoreTasks replaces the stored tasks by the given ones, keeping their versions
+func restoreTasks(ctx context.Context, tx *sql.Tx, tasks []*Task) error {
+	if _, err := tx.ExecContext(ctx, `PRAGMA defer_foreign_keys = ON`); err != nil {
+		return fmt.Errorf("error deferring foreign keys: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM tasks`); err != nil {
+		return fmt.Errorf("error deleting tasks: %w", err)
+	}
+
+	for _, t := range tasks {
+		if err := insertTask(ctx, tx, t); err != nil {
+			return fmt.Errorf("error restoring task %s: %w", t.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// recordTasks appends the creation of the stored tasks with their current version to the event log
+func recordTasks(ctx context.Context, tx *sql.Tx, clock util.Clock) error {
+	page, err := (&SQLRepository{tx: tx}).List(ctx, ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	for _, t := range page.Tasks {
+		if err := insertEvent(ctx, tx, newEvent(ctx, nil, t, clock.Now())); err != nil {
+			return fmt.Errorf("error recording task %s: %w", t.ID, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package task
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/pkg/database"
+	"github.com/utsabbera/task-master/pkg/util"
+	"go.uber.org/mock/gomock"
+)
+
+func openSQLite(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := database.OpenSQLite(context.Background(), ":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	return db
+}
+
+func TestSQLEventRepository(t *testing.T) {
+	testRepository(t, func(t *testing.T) Repository {
+		repo, err := NewSQLEventRepository(context.Background(), openSQLite(t), newTickingClock(gomock.NewController(t)))
+		require.NoError(t, err)
+
+		return repo
+	})
+}
+
+func TestNewSQLEventRepository(t *testing.T) {
+	t.Run("should record stored tasks as created with their version", func(t *testing.T) {
+		ctx := context.Background()
+		db := openSQLite(t)
+		legacy, err := NewSQLRepository(ctx, db)
+		require.NoError(t, err)
+		require.NoError(t, legacy.Create(ctx, &Task{ID: "TASK-1", Title: "Write report", Status: StatusNotStarted}))
+		require.NoError(t, legacy.Update(ctx, &Task{ID: "TASK-1", Title: "Write summary", Status: StatusNotStarted}))
+
+		repo, err := NewSQLEventRepository(ctx, db, newTickingClock(gomock.NewController(t)))
+		require.NoError(t, err)
+
+		task, err := repo.Get(ctx, "TASK-1")
+		require.NoError(t, err)
+		assert.Equal(t, 2, task.Version)
+
+		events, err := repo.History(ctx, "TASK-1")
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, EventCreated, events[0].Type)
+		assert.Equal(t, 2, events[0].Version)
+
+		repo, err = NewSQLEventRepository(ctx, db, newTickingClock(gomock.NewController(t)))
+		require.NoError(t, err)
+
+		events, err = repo.History(ctx, "TASK-1")
+		require.NoError(t, err)
+		assert.Len(t, events, 1)
+	})
+
+	t.Run("should restore tasks kept in the event log by replaying it", func(t *testing.T) {
+		ctx := context.Background()
+		db := openSQLite(t)
+		store, err := NewSQLEventStore(ctx, db)
+		require.NoError(t, err)
+		logged, err := NewEventRepository(ctx, store, newTickingClock(gomock.NewController(t)), 2)
+		require.NoError(t, err)
+		for _, task := range []*Task{
+			{ID: "TASK-1", Title: "Write report", Status: StatusNotStarted, Tags: []string{"work"}},
+			{ID: "TASK-2", Title: "Review report", Status: StatusNotStarted, ParentID: util.Ptr("TASK-3"), BlockedBy: []string{"TASK-1"}},
+			{ID: "TASK-3", Title: "Publish report", Status: StatusNotStarted},
+		} {
+			require.NoError(t, logged.Create(ctx, task))
+		}
+		require.NoError(t, logged.Update(ctx, &Task{ID: "TASK-1", Title: "Write summary", Status: StatusInProgress, Tags: []string{"work"}}))
+
+		repo, err := NewSQLEventRepository(ctx, db, newTickingClock(gomock.NewController(t)))
+		require.NoError(t, err)
+
+		expected, err := logged.List(ctx, ListOptions{})
+		require.NoError(t, err)
+		actual, err := repo.List(ctx, ListOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+
+		events, err := repo.History(ctx, "TASK-1")
+		require.NoError(t, err)
+		assert.Len(t, events, 2)
+	})
+}
+
+func TestSQLEventRepository_History(t *testing.T) {
+	t.Run("should return events of task with actor and version", func(t *testing.T) {
+		ctx := context.Background()
+		repo, err := NewSQLEventRepository(ctx, openSQLite(t), newTickingClock(gomock.NewController(t)))
+		require.NoError(t, err)
+
+		require.NoError(t, repo.Create(WithActor(ctx, "alice"), &Task{ID: "TASK-1", Title: "Write report", Status: StatusNotStarted}))
+		require.NoError(t, repo.Update(WithActor(ctx, "bob"), &Task{ID: "TASK-1", Title: "Write report", Status: StatusInProgress}))
+		require.NoError(t, repo.Delete(ctx, "TASK-1", 0))
+
+		events, err := repo.History(ctx, "TASK-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, []EventType{EventCreated, EventStatusChanged, EventDeleted},
+			util.Map(events, func(e Event) EventType { return e.Type }))
+		assert.Equal(t, []int{1, 2, 3}, util.Map(events, func(e Event) int { return e.Version }))
+		assert.Equal(t, []string{"alice", "bob", ""}, util.Map(events, func(e Event) string { return e.Actor }))
+		assert.Equal(t, []FieldChange{{Field: "status", Before: string(StatusNotStarted), After: string(StatusInProgress)}}, events[1].Changes)
+	})
+
+	t.Run("should discard events of a rolled back transaction", func(t *testing.T) {
+		ctx := context.Background()
+		repo, err := NewSQLEventRepository(ctx, openSQLite(t), newTickingClock(gomock.NewController(t)))
+		require.NoError(t, err)
+
+		err = repo.Transaction(ctx, func(tx Repository) error {
+			require.NoError(t, tx.Create(ctx, &Task{ID: "TASK-1", Title: "Write report", Status: StatusNotStarted}))
+			return errors.New("rollback")
+		})
+		require.Error(t, err)
+
+		_, err = repo.History(ctx, "TASK-1")
+		assert.ErrorIs(t, err, ErrTaskNotFound)
+	})
+
+	t.Run("should return error when events are not recorded", func(t *testing.T) {
+		repo, err := NewSQLRepository(context.Background(), openSQLite(t))
+		require.NoError(t, err)
+
+		_, err = repo.History(context.Background(), "TASK-1")
+
+		assert.ErrorIs(t, err, ErrHistoryUnavailable)
+	})
+}
@@ -0,0 +1,175 @@
+package task
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// SQLEventStore is an implementation of EventStore that stores events and snapshots in a SQL database.
+// The changes of the events and the tasks of the snapshots are stored as JSON.
+type SQLEventStore struct {
+	db *sql.DB
+}
+
+// NewSQLEventStore creates a new SQL event store using the given database
+// and migrates its schema to the latest version
+func NewSQLEventStore(ctx context.Context, db *sql.DB) (*SQLEventStore, error) {
+	if err := migrate(ctx, db); err != nil {
+		return nil, err
+	}
+
+	return &SQLEventStore{db: db}, nil
+}
+
+func (s *SQLEventStore) Append(ctx context.Context, events ...*Event) error {
+	return inTx(ctx, s.db, func(tx *sql.Tx) error {
+		for _, e := range events {
+			if err := insertEvent(ctx, tx, e); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// insertEvent appends the event to the log within the transaction and sets its sequence number
+func insertEvent(ctx context.Context, tx *sql.Tx, e *Event) error {
+	changes, err := json.Marshal(e.Changes)
+	if err != nil {
+		return fmt.Errorf("error encoding event changes: %w", err)
+	}
+
+	err = tx.QueryRowContext(ctx,
+		`INSERT INTO task_events (task_id, type, version, changes, actor, occurred_at) VALUES (?, ?, ?, ?, ?, ?) RETURNING sequence`,
+		e.TaskID, e.Type, e.Version, string(changes), e.Actor, formatTime(e.OccurredAt),
+	).Scan(&e.Sequence)
+	if err != nil {
+		return fmt.Errorf("error inserting event: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SQLEventStore) Events(ctx context.Context, after int64) ([]Event, error) {
+	return queryEvents(ctx, s.db, `WHERE sequence > ?`, after)
+}
+
+func (s *SQLEventStore) TaskEvents(ctx context.Context, id string) ([]Event, error) {
+	return queryEvents(ctx, s.db, `WHERE task_id = ?`, id)
+}
+
+func (s *SQLEventStore) TaskIDs(ctx context.Context) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT DISTINCT task_id FROM task_events`)
+	if err != nil {
+		return nil, fmt.Errorf("error querying task IDs: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("error reading task ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading task IDs: %w", err)
+	}
+
+	return ids, nil
+}
+
+func queryEvents(ctx context.Context, q querier, where string, args ...any) ([]Event, error) {
+	rows, err := q.QueryContext(ctx,
+		`SELECT sequence, task_id, type, version, changes, actor, occurred_at FROM task_events `+where+` ORDER BY sequence`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	events := make([]Event, 0)
+	for rows.Next() {
+		var (
+			e          Event
+			changes    string
+			occurredAt string
+		)
+
+		if err := rows.Scan(&e.Sequence, &e.TaskID, &e.Type, &e.Version, &changes, &e.Actor, &occurredAt); err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal([]byte(changes), &e.Changes); err != nil {
+			return nil, fmt.Errorf("error decoding event changes: %w", err)
+		}
+
+		if e.OccurredAt, err = parseTime(occurredAt); err != nil {
+			return nil, err
+		}
+
+		events = append(events, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading events: %w", err)
+	}
+
+	return events, nil
+}
+
+func (s *SQLEventStore) SaveSnapshot(ctx context.Context, snapshot Snapshot) error {
+	tasks, err := json.Marshal(snapshot.Tasks)
+	if err != nil {
+		return fmt.Errorf("error encoding snapshot tasks: %w", err)
+	}
+
+	return inTx(ctx, s.db, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx,
+			`INSERT OR REPLACE INTO task_snapshots (sequence, tasks, created_at) VALUES (?, ?, ?)`,
+			snapshot.Sequence, string(tasks), formatTime(snapshot.CreatedAt),
+		)
+		if err != nil {
+			return fmt.Errorf("error inserting snapshot: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM task_snapshots WHERE sequence < ?`, snapshot.Sequence); err != nil {
+			return fmt.Errorf("error deleting older snapshots: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (s *SQLEventStore) LatestSnapshot(ctx context.Context) (*Snapshot, error) {
+	var (
+		snapshot  Snapshot
+		tasks     string
+		createdAt string
+	)
+
+	err := s.db.QueryRowContext(ctx,
+		`SELECT sequence, tasks, created_at FROM task_snapshots ORDER BY sequence DESC LIMIT 1`,
+	).Scan(&snapshot.Sequence, &tasks, &createdAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error querying snapshot: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(tasks), &snapshot.Tasks); err != nil {
+		return nil, fmt.Errorf("error decoding snapshot tasks: %w", err)
+	}
+
+	if snapshot.CreatedAt, err = parseTime(createdAt); err != nil {
+		return nil, err
+	}
+
+	return &snapshot, nil
+}
@@ -0,0 +1,216 @@
+package task
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// SQLProjectRepository is an implementation of ProjectRepository that stores the projects in a SQL database
+type SQLProjectRepository struct {
+	db *sql.DB
+	tx *sql.Tx
+}
+
+// NewSQLProjectRepository creates a new SQL project repository using the given database
+// and migrates its schema to the latest version
+func NewSQLProjectRepository(ctx context.Context, db *sql.DB) (*SQLProjectRepository, error) {
+	if err := migrate(ctx, db); err != nil {
+		return nil, err
+	}
+
+	return &SQLProjectRepository{db: db}, nil
+}
+
+func (r *SQLProjectRepository) Create(ctx context.Context, project *Project) error {
+	return r.write(ctx, func(tx *sql.Tx) error {
+		var exists bool
+		if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM projects WHERE key = ?)`, project.Key).Scan(&exists); err != nil {
+			return fmt.Errorf("error finding project: %w", err)
+		}
+		if exists {
+			return fmt.Errorf("%w: %s", ErrProjectExists, project.Key)
+		}
+
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO projects (key, name, description, created_at, updated_at) VALUES (?, ?, ?, ?, ?)`,
+			project.Key, project.Name, project.Description, formatTime(project.CreatedAt), formatTime(project.UpdatedAt),
+		)
+		if err != nil {
+			return fmt.Errorf("error inserting project: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *SQLProjectRepository) Get(ctx context.Context, key string) (*Project, error) {
+	row := r.querier().QueryRowContext(ctx, `SELECT key, name, description, created_at, updated_at FROM projects WHERE key = ?`, key)
+
+	project, err := scanProject(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrProjectNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading project: %w", err)
+	}
+
+	return project, nil
+}
+
+func (r *SQLProjectRepository) List(ctx context.Context) ([]*Project, error) {
+	rows, err := r.querier().QueryContext(ctx, `SELECT key, name, description, created_at, updated_at FROM projects ORDER BY key`)
+	if err != nil {
+		return nil, fmt.Errorf("error listing projects: %w", err)
+	}
+	defer rows.Close()
+
+	projects := make([]*Project, 0)
+	for rows.Next() {
+		project, err := scanProject(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error reading project: %w", err)
+		}
+		projects = append(projects, project)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading projects: %w", err)
+	}
+
+	return projects, nil
+}
+
+func (r *SQLProjectRepository) Update(ctx context.Context, project *Project) error {
+	result, err := r.querier().ExecContext(ctx,
+		`UPDATE projects SET name = ?, description = ?, updated_at = ? WHERE key = ?`,
+		project.Name, project.Description, formatTime(project.UpdatedAt), project.Key,
+	)
+	if err != nil {
+		return fmt.Errorf("error updating project: %w", err)
+	}
+
+	return requireProject(result)
+}
+
+func (r *SQLProjectRepository) Delete(ctx context.Context, key string) error {
+	result, err := r.querier().ExecContext(ctx, `DELETE FROM projects WHERE key = ?`, key)
+	if err != nil {
+		return fmt.Errorf("error deleting project: %w", err)
+	}
+
+	return requireProject(result)
+}
+
+func (r *SQLProjectRepository) NextSequence(ctx context.Context, key string) (int, error) {
+	var sequence int
+	err := r.querier().QueryRowContext(ctx, `UPDATE projects SET sequence = sequence + 1 WHERE key = ? RETURNING sequence`, key).Scan(&sequence)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrProjectNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("error incrementing project sequence: %w", err)
+	}
+
+	return sequence, nil
+}
+
+func (r *SQLProjectRepository) SaveRedirect(ctx context.Context, from, to string) error {
+	return r.write(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `UPDATE task_redirects SET to_id = ? WHERE to_id = ?`, to, from); err != nil {
+			return fmt.Errorf("error updating task redirects: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM task_redirects WHERE from_id = ?`, to); err != nil {
+			return fmt.Errorf("error deleting task redirect: %w", err)
+		}
+
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO task_redirects (from_id, to_id) VALUES (?, ?) ON CONFLICT (from_id) DO UPDATE SET to_id = excluded.to_id`,
+			from, to,
+		)
+		if err != nil {
+			return fmt.Errorf("error inserting task redirect: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *SQLProjectRepository) Redirect(ctx context.Context, id string) (string, error) {
+	var to string
+	err := r.querier().QueryRowContext(ctx, `SELECT to_id FROM task_redirects WHERE from_id = ?`, id).Scan(&to)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrTaskNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("error reading task redirect: %w", err)
+	}
+
+	return to, nil
+}
+
+// within returns the repository running its statements in the database transaction of the unit of work tx
+// of a task repository stored in the same database, the repository itself otherwise
+func (r *SQLProjectRepository) within(tx Repository) ProjectRepository {
+	if sqlTx := unitOfWork(tx, r.db); sqlTx != nil {
+		return &SQLProjectRepository{db: r.db, tx: sqlTx}
+	}
+
+	return r
+}
+
+// querier returns the transaction of the unit of work, the database otherwise
+func (r *SQLProjectRepository) querier() querier {
+	if r.tx != nil {
+		return r.tx
+	}
+
+	return r.db
+}
+
+// write runs fn in the transaction of the unit of work, in a transaction of its own otherwise
+func (r *SQLProjectRepository) write(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	if r.tx != nil {
+		return fn(r.tx)
+	}
+
+	return inTx(ctx, r.db, fn)
+}
+
+func scanProject(row scanner) (*Project, error) {
+	var (
+		p         Project
+		createdAt string
+		updatedAt string
+	)
+
+	if err := row.Scan(&p.Key, &p.Name, &p.Description, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if p.CreatedAt, err = parseTime(createdAt); err != nil {
+		return nil, err
+	}
+
+	if p.UpdatedAt, err = parseTime(updatedAt); err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+func requireProject(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error reading affected rows: %w", err)
+	}
+
+	if affected == 0 {
+		return ErrProjectNotFound
+	}
+
+	return nil
+}
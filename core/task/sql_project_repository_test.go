@@ -0,0 +1,22 @@
+package task
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/pkg/database"
+)
+
+func TestSQLProjectRepository(t *testing.T) {
+	testProjectRepository(t, func(t *testing.T) ProjectRepository {
+		db, err := database.OpenSQLite(context.Background(), ":memory:")
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = db.Close() })
+
+		repo, err := NewSQLProjectRepository(context.Background(), db)
+		require.NoError(t, err)
+
+		return repo
+	})
+}
@@ -0,0 +1,830 @@
+package task
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/utsabbera/task-master/pkg/database"
+	"github.com/utsabbera/task-master/pkg/query"
+	"github.com/utsabbera/task-master/pkg/util"
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+const (
+	taskColumns   = "id, title, description, status, priority, due_date, started_at, completed_at, created_at, updated_at, version, parent_id, recurrence, owner_id, project"
+	selectColumns = taskColumns + ", (SELECT group_concat(tag, ',') FROM task_tags WHERE task_id = tasks.id)" +
+		", (SELECT group_concat(blocker_id, ',') FROM task_dependencies WHERE task_id = tasks.id)" +
+		", (SELECT group_concat(user_id, ',') FROM task_assignees WHERE task_id = tasks.id)"
+	timeLayout = "2006-01-02T15:04:05.000000000Z07:00"
+)
+
+// SQLRepository is an implementation of Repository that stores tasks in a SQL database.
+// Timestamps are stored in UTC with a fixed width layout so that they sort chronologically.
+// A repository created with NewSQLEventRepository also appends the events of the changes to the event log.
+type SQLRepository struct {
+	db    *sql.DB
+	tx    *sql.Tx
+	clock util.Clock
+}
+
+// NewSQLRepository creates a new SQL repository using the given database
+// and migrates its schema to the latest version
+func NewSQLRepository(ctx context.Context, db *sql.DB) (*SQLRepository, error) {
+	if err := migrate(ctx, db); err != nil {
+		return nil, err
+	}
+
+	return &SQLRepository{db: db}, nil
+}
+
+func migrate(ctx context.Context, db *sql.DB) error {
+	fsys, err := fs.Sub(migrations, "migrations")
+	if err != nil {
+		return err
+	}
+
+	if err := database.Migrate(ctx, db, fsys); err != nil {
+		return fmt.Errorf("error migrating task schema: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SQLRepository) Create(ctx context.Context, t *Task) error {
+	if t.ID == "" {
+		return ErrInvalidTask
+	}
+
+	created := t.clone()
+	created.Version = 1
+	err := r.write(ctx, func(tx *sql.Tx) error {
+		if err := insertTask(ctx, tx, created); err != nil {
+			return err
+		}
+
+		return r.record(ctx, tx, nil, created)
+	})
+	if err != nil {
+		return err
+	}
+
+	t.Version = created.Version
+	return nil
+}
+
+// insertTask inserts the task with its version, along with its tags, blockers and assignees
+func insertTask(ctx context.Context, tx *sql.Tx, t *Task) error {
+	_, err := tx.ExecContext(ctx,
+		`INSERT INTO tasks (`+taskColumns+`) VALUES (`+placeholders(15)+`)`,
+		t.ID, t.Title, t.Description, t.Status, nullPriority(t.Priority), nullTime(t.DueDate),
+		nullTime(t.StartedAt), nullTime(t.CompletedAt), formatTime(t.CreatedAt), formatTime(t.UpdatedAt), t.Version, nullString(t.ParentID), nullRecurrence(t.Recurrence),
+		t.OwnerID, t.Project,
+	)
+	if err != nil {
+		return fmt.Errorf("error inserting task: %w", err)
+	}
+
+	if err := insertTags(ctx, tx, t.ID, t.Tags); err != nil {
+		return err
+	}
+
+	if err := insertBlockers(ctx, tx, t.ID, t.BlockedBy); err != nil {
+		return err
+	}
+
+	return insertAssignees(ctx, tx, t.ID, t.Assignees)
+}
+
+func (r *SQLRepository) Get(ctx context.Context, id string) (*Task, error) {
+	return getTask(ctx, r.querier(), id)
+}
+
+func getTask(ctx context.Context, q rowQuerier, id string) (*Task, error) {
+	row := q.QueryRowContext(ctx, `SELECT `+selectColumns+` FROM tasks WHERE id = ?`, id)
+
+	t, err := scanTask(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTaskNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (r *SQLRepository) List(ctx context.Context, opts ListOptions) (*Page, error) {
+	after, err := decodeCursor(opts.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	where, args := filterClause(opts.Filter)
+
+	var total int
+	if err := r.querier().QueryRowContext(ctx, `SELECT COUNT(*) FROM tasks`+where, args...).Scan(&total); err != nil {
+		return nil, fmt.Errorf("error counting tasks: %w", err)
+	}
+
+	if after != nil {
+		condition, keysetArgs := keysetCondition(opts.Sort, after.task())
+		if where == "" {
+			where = ` WHERE ` + condition
+		} else {
+			where += ` AND ` + condition
+		}
+		args = append(args, keysetArgs...)
+	}
+
+	limit := -1
+	if opts.Limit > 0 {
+		limit = opts.Limit + 1
+	}
+
+	query := `SELECT ` + selectColumns + ` FROM tasks` + where + orderClause(opts.Sort) + ` LIMIT ?`
+	rows, err := r.querier().QueryContext(ctx, query, append(args, limit)...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying tasks: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	tasks := make([]*Task, 0)
+	for rows.Next() {
+		t, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading tasks: %w", err)
+	}
+
+	more := opts.Limit > 0 && len(tasks) > opts.Limit
+	if more {
+		tasks = tasks[:opts.Limit]
+	}
+
+	return newPage(tasks, total, more, opts.Sort), nil
+}
+
+func (r *SQLRepository) Update(ctx context.Context, t *Task) error {
+	var version int
+	err := r.write(ctx, func(tx *sql.Tx) error {
+		before, err := r.previous(ctx, tx, t.ID)
+		if err != nil {
+			return err
+		}
+
+		err = tx.QueryRowContext(ctx,
+			`UPDATE tasks SET title = ?, description = ?, status = ?, priority = ?, due_date = ?, started_at = ?, completed_at = ?, created_at = ?, updated_at = ?, parent_id = ?, recurrence = ?, owner_id = ?, project = ?, version = version + 1
+			WHERE id = ? AND (? = 0 OR version = ?) RETURNING version`,
+			t.Title, t.Description, t.Status, nullPriority(t.Priority), nullTime(t.DueDate),
+			nullTime(t.StartedAt), nullTime(t.CompletedAt), formatTime(t.CreatedAt), formatTime(t.UpdatedAt), nullString(t.ParentID),
+			nullRecurrence(t.Recurrence), t.OwnerID, t.Project, t.ID, t.Version, t.Version,
+		).Scan(&version)
+		if errors.Is(err, sql.ErrNoRows) {
+			return verifyVersion(ctx, tx, t.ID, t.Version)
+		}
+		if err != nil {
+			return fmt.Errorf("error updating task: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM task_tags WHERE task_id = ?`, t.ID); err != nil {
+			return fmt.Errorf("error deleting task tags: %w", err)
+		}
+
+		if err := insertTags(ctx, tx, t.ID, t.Tags); err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM task_dependencies WHERE task_id = ?`, t.ID); err != nil {
+			return fmt.Errorf("error deleting task dependencies: %w", err)
+		}
+
+		if err := insertBlockers(ctx, tx, t.ID, t.BlockedBy); err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM task_assignees WHERE task_id = ?`, t.ID); err != nil {
+			return fmt.Errorf("error deleting task assignees: %w", err)
+		}
+
+		if err := insertAssignees(ctx, tx, t.ID, t.Assignees); err != nil {
+			return err
+		}
+
+		after := t.clone()
+		after.Version = version
+		return r.record(ctx, tx, before, after)
+	})
+	if err != nil {
+		return err
+	}
+
+	t.Version = version
+	return nil
+}
+
+func (r *SQLRepository) Delete(ctx context.Context, id string, version int) error {
+	return r.write(ctx, func(tx *sql.Tx) error {
+		before, err := r.previous(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx, `DELETE FROM tasks WHERE id = ? AND (? = 0 OR version = ?)`, id, version, version)
+		if err != nil {
+			return fmt.Errorf("error deleting task: %w", err)
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if affected == 0 {
+			return verifyVersion(ctx, tx, id, version)
+		}
+
+		return r.record(ctx, tx, before, nil)
+	})
+}
+
+func (r *SQLRepository) Tags(ctx context.Context) ([]TagCount, error) {
+	rows, err := r.querier().QueryContext(ctx, `SELECT tag, COUNT(*) FROM task_tags GROUP BY tag ORDER BY tag`)
+	if err != nil {
+		return nil, fmt.Errorf("error querying tags: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	tags := make([]TagCount, 0)
+	for rows.Next() {
+		var tag TagCount
+		if err := rows.Scan(&tag.Tag, &tag.Count); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+// Transaction calls fn with a unit of work running its statements in a database transaction,
+// committed when fn returns nil and rolled back otherwise
+func (r *SQLRepository) Transaction(ctx context.Context, fn func(tx Repository) error) error {
+	if r.tx != nil {
+		return fn(r)
+	}
+
+	return inTx(ctx, r.db, func(tx *sql.Tx) error {
+		return fn(&SQLRepository{db: r.db, tx: tx, clock: r.clock})
+	})
+}
+
+// querier returns the transaction of the unit of work, the database otherwise
+func (r *SQLRepository) querier() querier {
+	if r.tx != nil {
+		return r.tx
+	}
+
+	return r.db
+}
+
+// write runs fn in the transaction of the unit of work, in a transaction of its own otherwise
+func (r *SQLRepository) write(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	if r.tx != nil {
+		return fn(r.tx)
+	}
+
+	return inTx(ctx, r.db, fn)
+}
+
+// unitOfWork returns the database transaction of the unit of work tx when it runs in the database db, nil otherwise
+func unitOfWork(tx Repository, db *sql.DB) *sql.Tx {
+	for {
+		switch r := tx.(type) {
+		case *SQLRepository:
+			if r.db != db {
+				return nil
+			}
+			return r.tx
+		case *indexedTx:
+			tx = r.Repository
+		default:
+			return nil
+		}
+	}
+}
+
+func inTx(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+
+	return nil
+}
+
+func insertTags(ctx context.Context, tx *sql.Tx, id string, tags []string) error {
+	for _, tag := range tags {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO task_tags (task_id, tag) VALUES (?, ?)`, id, tag); err != nil {
+			return fmt.Errorf("error inserting task tag: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func insertBlockers(ctx context.Context, tx *sql.Tx, id string, blockers []string) error {
+	for _, blocker := range blockers {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO task_dependencies (task_id, blocker_id) VALUES (?, ?)`, id, blocker); err != nil {
+			return fmt.Errorf("error inserting task dependency: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func insertAssignees(ctx context.Context, tx *sql.Tx, id string, assignees []string) error {
+	for _, assignee := range assignees {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO task_assignees (task_id, user_id) VALUES (?, ?)`, id, assignee); err != nil {
+			return fmt.Errorf("error inserting task assignee: %w", err)
+		}
+	}
+
+	return nil
+}
+
+type rowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type querier interface {
+	rowQuerier
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// verifyVersion explains why a conditional statement matched no row,
+// returning ErrTaskNotFound or ErrConflict
+func verifyVersion(ctx context.Context, q rowQuerier, id string, expected int) error {
+	var actual int
+	err := q.QueryRowContext(ctx, `SELECT version FROM tasks WHERE id = ?`, id).Scan(&actual)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrTaskNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("error reading task version: %w", err)
+	}
+
+	return checkVersion(expected, actual)
+}
+
+func filterClause(f Filter) (string, []any) {
+	var (
+		conditions []string
+		args       []any
+	)
+
+	if len(f.IDs) > 0 {
+		conditions = append(conditions, `id IN (`+placeholders(len(f.IDs))+`)`)
+		for _, id := range f.IDs {
+			args = append(args, id)
+		}
+	}
+
+	if len(f.Projects) > 0 {
+		conditions = append(conditions, `project IN (`+placeholders(len(f.Projects))+`)`)
+		for _, project := range f.Projects {
+			args = append(args, project)
+		}
+	}
+
+	if len(f.Statuses) > 0 {
+		conditions = append(conditions, `status IN (`+placeholders(len(f.Statuses))+`)`)
+		for _, status := range f.Statuses {
+			args = append(args, status)
+		}
+	}
+
+	if len(f.Priorities) > 0 {
+		conditions = append(conditions, `priority IN (`+placeholders(len(f.Priorities))+`)`)
+		for _, priority := range f.Priorities {
+			args = append(args, priority)
+		}
+	}
+
+	if f.DueBefore != nil {
+		conditions = append(conditions, `due_date < ?`)
+		args = append(args, formatTime(*f.DueBefore))
+	}
+
+	if f.DueAfter != nil {
+		conditions = append(conditions, `due_date > ?`)
+		args = append(args, formatTime(*f.DueAfter))
+	}
+
+	if f.HasDueDate {
+		conditions = append(conditions, `due_date IS NOT NULL`)
+	}
+
+	if len(f.Parents) > 0 {
+		conditions = append(conditions, `parent_id IN (`+placeholders(len(f.Parents))+`)`)
+		for _, parent := range f.Parents {
+			args = append(args, parent)
+		}
+	}
+
+	if len(f.Blockers) > 0 {
+		conditions = append(conditions, `EXISTS (SELECT 1 FROM task_dependencies WHERE task_id = tasks.id AND blocker_id IN (`+placeholders(len(f.Blockers))+`))`)
+		for _, blocker := range f.Blockers {
+			args = append(args, blocker)
+		}
+	}
+
+	if len(f.Owners) > 0 {
+		conditions = append(conditions, `owner_id IN (`+placeholders(len(f.Owners))+`)`)
+		for _, owner := range f.Owners {
+			args = append(args, owner)
+		}
+	}
+
+	if len(f.Assignees) > 0 {
+		conditions = append(conditions, `EXISTS (SELECT 1 FROM task_assignees WHERE task_id = tasks.id AND user_id IN (`+placeholders(len(f.Assignees))+`))`)
+		for _, assignee := range f.Assignees {
+			args = append(args, assignee)
+		}
+	}
+
+	for _, tag := range f.Tags {
+		conditions = append(conditions, `EXISTS (SELECT 1 FROM task_tags WHERE task_id = tasks.id AND tag = ?)`)
+		args = append(args, tag)
+	}
+
+	for _, tag := range f.ExcludedTags {
+		conditions = append(conditions, `NOT EXISTS (SELECT 1 FROM task_tags WHERE task_id = tasks.id AND tag = ?)`)
+		args = append(args, tag)
+	}
+
+	if f.Query != "" {
+		query := strings.ToLower(f.Query)
+		conditions = append(conditions, `(instr(`+database.LowerFunction+`(title), ?) > 0 OR instr(`+database.LowerFunction+`(description), ?) > 0)`)
+		args = append(args, query, query)
+	}
+
+	if f.Expression != nil {
+		clause, expressionArgs := expressionClause(f.Expression, f.Expression.root)
+		conditions = append(conditions, clause)
+		args = append(args, expressionArgs...)
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+
+	return ` WHERE ` + strings.Join(conditions, ` AND `), args
+}
+
+var expressionColumns = map[string]string{
+	"id":          "id",
+	"title":       "title",
+	"description": "description",
+	"status":      "status",
+	"priority":    "priority",
+	"due":         "due_date",
+	"created":     "created_at",
+	"updated":     "updated_at",
+	"owner":       "owner_id",
+	"project":     "project",
+	"parent":      "parent_id",
+}
+
+// expressionClause translates an expression to a condition which is never NULL, so that its negation
+// matches the same tasks as Expression.Matches
+func expressionClause(e *Expression, node query.Expr) (string, []any) {
+	switch node := node.(type) {
+	case *query.And:
+		left, leftArgs := expressionClause(e, node.Left)
+		right, rightArgs := expressionClause(e, node.Right)
+		return `(` + left + ` AND ` + right + `)`, append(leftArgs, rightArgs...)
+	case *query.Or:
+		left, leftArgs := expressionClause(e, node.Left)
+		right, rightArgs := expressionClause(e, node.Right)
+		return `(` + left + ` OR ` + right + `)`, append(leftArgs, rightArgs...)
+	case *query.Not:
+		operand, args := expressionClause(e, node.Operand)
+		return `NOT ` + operand, args
+	default:
+		return conditionClause(e.conditions[node.(*query.Comparison)])
+	}
+}
+
+func conditionClause(c condition) (string, []any) {
+	switch c.field.name {
+	case "tag", "assignee":
+		subquery := `EXISTS (SELECT 1 FROM task_tags WHERE task_id = tasks.id AND tag = ?)`
+		if c.field.name == "assignee" {
+			subquery = `EXISTS (SELECT 1 FROM task_assignees WHERE task_id = tasks.id AND user_id = ?)`
+		}
+		if c.op == query.NotEqual {
+			subquery = `NOT ` + subquery
+		}
+		return subquery, []any{c.value}
+	}
+
+	column := expressionColumns[c.field.name]
+	if c.null {
+		if c.op == query.NotEqual {
+			return column + ` IS NOT NULL`, nil
+		}
+		return column + ` IS NULL`, nil
+	}
+
+	var value any = c.value
+	switch c.field.kind {
+	case kindTime:
+		value = formatTime(c.time)
+	case kindPriority:
+		if c.op != query.Equal && c.op != query.NotEqual {
+			column = `CASE priority WHEN 'LOW' THEN 1 WHEN 'MEDIUM' THEN 2 WHEN 'HIGH' THEN 3 END`
+			value = priorityRank(Priority(c.value))
+		}
+	}
+
+	switch c.op {
+	case query.Contains:
+		return `instr(` + database.LowerFunction + `(` + column + `), ?) > 0`, []any{strings.ToLower(c.value)}
+	case query.Equal:
+		return column + ` IS ?`, []any{value}
+	case query.NotEqual:
+		return column + ` IS NOT ?`, []any{value}
+	default:
+		return `(` + expressionColumns[c.field.name] + ` IS NOT NULL AND ` + column + ` ` + string(c.op) + ` ?)`, []any{value}
+	}
+}
+
+// priorityRankExpr ranks the priorities of the tasks as priorityRank does
+const priorityRankExpr = `CASE priority WHEN 'LOW' THEN 1 WHEN 'MEDIUM' THEN 2 WHEN 'HIGH' THEN 3 ELSE 0 END`
+
+func orderClause(sorts []Sort) string {
+	terms := make([]string, 0, len(sorts)+2)
+	for _, sort := range sorts {
+		direction := ` ASC`
+		if sort.Descending {
+			direction = ` DESC`
+		}
+
+		switch sort.Field {
+		case SortByCreatedAt:
+			terms = append(terms, `created_at`+direction)
+		case SortByUpdatedAt:
+			terms = append(terms, `updated_at`+direction)
+		case SortByTitle:
+			terms = append(terms, `title`+direction)
+		case SortByDueDate:
+			terms = append(terms, `due_date IS NULL`, `due_date`+direction)
+		case SortByPriority:
+			terms = append(terms, `priority IS NULL`, priorityRankExpr+direction)
+		}
+	}
+
+	terms = append(terms, `created_at ASC`, `id ASC`)
+
+	return ` ORDER BY ` + strings.Join(terms, `, `)
+}
+
+// sortKey is an expression of the order of a list with its value for the last task of a page.
+// The value of a nullable key is nil when the task misses it, the tasks missing it being ordered last
+type sortKey struct {
+	expr       string
+	value      any
+	descending bool
+	nullColumn string
+}
+
+// keysetCondition returns the condition matching the tasks ordered after the last task of a page by orderClause
+func keysetCondition(sorts []Sort, last *Task) (string, []any) {
+	keys := make([]sortKey, 0, len(sorts)+2)
+	for _, sort := range sorts {
+		key := sortKey{descending: sort.Descending}
+		switch sort.Field {
+		case SortByCreatedAt:
+			key.expr, key.value = `created_at`, formatTime(last.CreatedAt)
+		case SortByUpdatedAt:
+			key.expr, key.value = `updated_at`, formatTime(last.UpdatedAt)
+		case SortByTitle:
+			key.expr, key.value = `title`, last.Title
+		case SortByDueDate:
+			key.expr, key.nullColumn = `due_date`, `due_date`
+			if last.DueDate != nil {
+				key.value = formatTime(*last.DueDate)
+			}
+		case SortByPriority:
+			key.expr, key.nullColumn = priorityRankExpr, `priority`
+			if last.Priority != nil {
+				key.value = priorityRank(*last.Priority)
+			}
+		}
+		keys = append(keys, key)
+	}
+	keys = append(keys, sortKey{expr: `created_at`, value: formatTime(last.CreatedAt)}, sortKey{expr: `id`, value: last.ID})
+
+	var (
+		alternatives []string
+		args         []any
+	)
+	for i, key := range keys {
+		if key.nullColumn != "" && key.value == nil {
+			continue
+		}
+
+		var terms []string
+		for _, previous := range keys[:i] {
+			if previous.nullColumn != "" && previous.value == nil {
+				terms = append(terms, previous.nullColumn+` IS NULL`)
+				continue
+			}
+			terms = append(terms, previous.expr+` = ?`)
+			args = append(args, previous.value)
+		}
+
+		operator := ` > ?`
+		if key.descending {
+			operator = ` < ?`
+		}
+		if key.nullColumn != "" {
+			terms = append(terms, `(`+key.nullColumn+` IS NULL OR `+key.expr+operator+`)`)
+		} else {
+			terms = append(terms, key.expr+operator)
+		}
+		args = append(args, key.value)
+
+		alternatives = append(alternatives, `(`+strings.Join(terms, ` AND `)+`)`)
+	}
+
+	return `(` + strings.Join(alternatives, ` OR `) + `)`, args
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat(`?, `, n), `, `)
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanTask(row scanner) (*Task, error) {
+	var (
+		t           Task
+		priority    sql.NullString
+		dueDate     sql.NullString
+		startedAt   sql.NullString
+		completedAt sql.NullString
+		createdAt   string
+		updatedAt   string
+		parentID    sql.NullString
+		recurrence  sql.NullString
+		tags        sql.NullString
+		blockers    sql.NullString
+		assignees   sql.NullString
+	)
+
+	err := row.Scan(&t.ID, &t.Title, &t.Description, &t.Status, &priority, &dueDate, &startedAt, &completedAt, &createdAt, &updatedAt, &t.Version, &parentID, &recurrence, &t.OwnerID, &t.Project, &tags, &blockers, &assignees)
+	if err != nil {
+		return nil, err
+	}
+
+	if tags.Valid {
+		t.Tags = strings.Split(tags.String, ",")
+		slices.Sort(t.Tags)
+	}
+
+	if blockers.Valid {
+		t.BlockedBy = strings.Split(blockers.String, ",")
+		slices.Sort(t.BlockedBy)
+	}
+
+	if assignees.Valid {
+		t.Assignees = strings.Split(assignees.String, ",")
+		slices.Sort(t.Assignees)
+	}
+
+	if parentID.Valid {
+		t.ParentID = &parentID.String
+	}
+
+	if recurrence.Valid {
+		if t.Recurrence, err = ParseRecurrence(recurrence.String); err != nil {
+			return nil, err
+		}
+	}
+
+	if priority.Valid {
+		p := Priority(priority.String)
+		t.Priority = &p
+	}
+
+	if t.DueDate, err = parseNullTime(dueDate); err != nil {
+		return nil, err
+	}
+
+	if t.StartedAt, err = parseNullTime(startedAt); err != nil {
+		return nil, err
+	}
+
+	if t.CompletedAt, err = parseNullTime(completedAt); err != nil {
+		return nil, err
+	}
+
+	if t.CreatedAt, err = parseTime(createdAt); err != nil {
+		return nil, err
+	}
+
+	if t.UpdatedAt, err = parseTime(updatedAt); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+func nullPriority(p *Priority) sql.NullString {
+	if p == nil {
+		return sql.NullString{}
+	}
+
+	return sql.NullString{String: string(*p), Valid: true}
+}
+
+func nullString(s *string) sql.NullString {
+	if s == nil {
+		return sql.NullString{}
+	}
+
+	return sql.NullString{String: *s, Valid: true}
+}
+
+func nullRecurrence(r *Recurrence) sql.NullString {
+	if r == nil {
+		return sql.NullString{}
+	}
+
+	return sql.NullString{String: r.String(), Valid: true}
+}
+
+func nullTime(t *time.Time) sql.NullString {
+	if t == nil {
+		return sql.NullString{}
+	}
+
+	return sql.NullString{String: formatTime(*t), Valid: true}
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
+
+func parseTime(value string) (time.Time, error) {
+	t, err := time.Parse(timeLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error parsing time %q: %w", value, err)
+	}
+
+	return t, nil
+}
+
+func parseNullTime(value sql.NullString) (*time.Time, error) {
+	if !value.Valid {
+		return nil, nil
+	}
+
+	t, err := parseTime(value.String)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
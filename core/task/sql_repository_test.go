@@ -0,0 +1,77 @@
+package task
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/pkg/database"
+	"github.com/utsabbera/task-master/pkg/util"
+)
+
+func TestSQLRepository(t *testing.T) {
+	testRepository(t, func(t *testing.T) Repository {
+		db, err := database.OpenSQLite(context.Background(), ":memory:")
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = db.Close() })
+
+		repo, err := NewSQLRepository(context.Background(), db)
+		require.NoError(t, err)
+
+		return repo
+	})
+}
+
+func TestNewSQLRepository(t *testing.T) {
+	t.Run("should keep tasks when migrated again", func(t *testing.T) {
+		ctx := context.Background()
+		db, err := database.OpenSQLite(ctx, ":memory:")
+		require.NoError(t, err)
+		defer db.Close()
+
+		repo, err := NewSQLRepository(ctx, db)
+		require.NoError(t, err)
+		require.NoError(t, repo.Create(ctx, &Task{ID: "A", Title: "Test Task"}))
+
+		repo, err = NewSQLRepository(ctx, db)
+		require.NoError(t, err)
+
+		page, err := repo.List(ctx, ListOptions{})
+		require.NoError(t, err)
+		assert.Len(t, page.Tasks, 1)
+	})
+
+	t.Run("should persist tasks across connections", func(t *testing.T) {
+		ctx := context.Background()
+		dsn := filepath.Join(t.TempDir(), "tasks.db")
+		task := &Task{
+			ID:          "A",
+			Title:       "Test Task",
+			Status:      StatusCompleted,
+			StartedAt:   util.Ptr(time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)),
+			CompletedAt: util.Ptr(time.Date(2025, 5, 1, 11, 0, 0, 0, time.UTC)),
+			CreatedAt:   time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC),
+			UpdatedAt:   time.Date(2025, 5, 1, 11, 0, 0, 0, time.UTC),
+		}
+
+		db, err := database.OpenSQLite(ctx, dsn)
+		require.NoError(t, err)
+		repo, err := NewSQLRepository(ctx, db)
+		require.NoError(t, err)
+		require.NoError(t, repo.Create(ctx, task))
+		require.NoError(t, db.Close())
+
+		db, err = database.OpenSQLite(ctx, dsn)
+		require.NoError(t, err)
+		defer db.Close()
+		repo, err = NewSQLRepository(ctx, db)
+		require.NoError(t, err)
+
+		result, err := repo.Get(ctx, "A")
+		require.NoError(t, err)
+		assert.Equal(t, task, result)
+	})
+}
@@ -0,0 +1,129 @@
+package task
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+const viewColumns = "name, description, filter, sort, owner_id, created_at, updated_at"
+
+// SQLViewRepository is an implementation of ViewRepository that stores the views in a SQL database
+type SQLViewRepository struct {
+	db *sql.DB
+}
+
+// NewSQLViewRepository creates a new SQL view repository using the given database
+// and migrates its schema to the latest version
+func NewSQLViewRepository(ctx context.Context, db *sql.DB) (*SQLViewRepository, error) {
+	if err := migrate(ctx, db); err != nil {
+		return nil, err
+	}
+
+	return &SQLViewRepository{db: db}, nil
+}
+
+func (r *SQLViewRepository) Create(ctx context.Context, view *View) error {
+	result, err := r.db.ExecContext(ctx,
+		`INSERT INTO views (`+viewColumns+`) VALUES (`+placeholders(7)+`) ON CONFLICT (name) DO NOTHING`,
+		view.Name, view.Description, view.Filter, FormatSort(view.Sort), view.OwnerID, formatTime(view.CreatedAt), formatTime(view.UpdatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("error inserting view: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error reading affected rows: %w", err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("%w: %s", ErrViewExists, view.Name)
+	}
+
+	return nil
+}
+
+func (r *SQLViewRepository) Get(ctx context.Context, name string) (*View, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+viewColumns+` FROM views WHERE name = ?`, name)
+
+	view, err := scanView(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrViewNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading view: %w", err)
+	}
+
+	return view, nil
+}
+
+func (r *SQLViewRepository) List(ctx context.Context) ([]*View, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+viewColumns+` FROM views ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("error listing views: %w", err)
+	}
+	defer rows.Close()
+
+	views := make([]*View, 0)
+	for rows.Next() {
+		view, err := scanView(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error reading view: %w", err)
+		}
+		views = append(views, view)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading views: %w", err)
+	}
+
+	return views, nil
+}
+
+func (r *SQLViewRepository) Delete(ctx context.Context, name string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM views WHERE name = ?`, name)
+	if err != nil {
+		return fmt.Errorf("error deleting view: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error reading affected rows: %w", err)
+	}
+
+	if affected == 0 {
+		return ErrViewNotFound
+	}
+
+	return nil
+}
+
+func scanView(row scanner) (*View, error) {
+	var (
+		v         View
+		sort      string
+		createdAt string
+		updatedAt string
+	)
+
+	if err := row.Scan(&v.Name, &v.Description, &v.Filter, &sort, &v.OwnerID, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if v.Sort, err = ParseSort(sort); err != nil {
+		return nil, err
+	}
+
+	if v.CreatedAt, err = parseTime(createdAt); err != nil {
+		return nil, err
+	}
+
+	if v.UpdatedAt, err = parseTime(updatedAt); err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
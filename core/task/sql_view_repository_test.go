@@ -0,0 +1,22 @@
+package task
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/pkg/database"
+)
+
+func TestSQLViewRepository(t *testing.T) {
+	testViewRepository(t, func(t *testing.T) ViewRepository {
+		db, err := database.OpenSQLite(context.Background(), ":memory:")
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = db.Close() })
+
+		repo, err := NewSQLViewRepository(context.Background(), db)
+		require.NoError(t, err)
+
+		return repo
+	})
+}
@@ -0,0 +1,574 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/utsabbera/task-master/pkg/util"
+)
+
+// StagedIDPrefix is the prefix of the temporary IDs given to staged tasks until they are committed
+const StagedIDPrefix = "STAGED-"
+
+var (
+	// ErrStaleChange is returned when committing a change to a task that was modified after the change was staged
+	ErrStaleChange = errors.New("task was modified after the change was staged")
+	// ErrNotStageable is returned by the operations which cannot be staged
+	ErrNotStageable = errors.New("operation cannot be staged")
+)
+
+// ChangeType identifies the kind of a staged change
+type ChangeType string
+
+const (
+	// ChangeCreate stages the creation of a task
+	ChangeCreate ChangeType = "create"
+	// ChangeUpdate stages the update of an existing task
+	ChangeUpdate ChangeType = "update"
+	// ChangeDelete stages the deletion of an existing task
+	ChangeDelete ChangeType = "delete"
+)
+
+var changeTypes = []ChangeType{ChangeCreate, ChangeUpdate, ChangeDelete}
+
+// ChangeTypes returns every kind of change
+func ChangeTypes() []ChangeType {
+	return slices.Clone(changeTypes)
+}
+
+// Change is a pending create, update or delete operation recorded by a Stage
+type Change struct {
+	// Type is the kind of the change
+	Type ChangeType
+	// TaskID is the ID of the changed task, a temporary ID for staged creations until they are committed
+	TaskID string
+	// Before is the task before the change, nil for creations
+	Before *Task
+	// After is the task after the change, nil for deletions
+	After *Task
+
+	patches []*Task
+}
+
+// Diff returns the fields modified by the change
+func (c Change) Diff() []FieldChange {
+	return Diff(c.Before, c.After)
+}
+
+// Stage is a Service which records the create, update and delete operations as pending changes
+// instead of applying them, so that they can be previewed and then committed to or discarded from
+// the underlying service. Reads return the tasks as they will be once the changes are committed.
+// Every task has at most one pending change, further operations on the same task are merged into it.
+type Stage struct {
+	base    Service
+	clock   util.Clock
+	changes []*Change
+	nextID  int
+	mu      sync.Mutex
+}
+
+// NewStage creates a new stage on top of the given service
+func NewStage(base Service, clock util.Clock) *Stage {
+	return &Stage{
+		base:  base,
+		clock: clock,
+	}
+}
+
+func (s *Stage) Create(ctx context.Context, task *Task) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	if err := prepareTask(ctx, s.get, task, now); err != nil {
+		return fmt.Errorf("error creating task: %w", err)
+	}
+
+	s.nextID++
+	task.ID = fmt.Sprintf("%s%d", StagedIDPrefix, s.nextID)
+	task.CreatedAt = now
+	task.UpdatedAt = now
+
+	s.changes = append(s.changes, &Change{Type: ChangeCreate, TaskID: task.ID, After: task.clone()})
+	return nil
+}
+
+func (s *Stage) Get(ctx context.Context, id string) (*Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.get(ctx, id)
+}
+
+func (s *Stage) get(ctx context.Context, id string) (*Task, error) {
+	if change := s.find(id); change != nil {
+		if change.After == nil {
+			return nil, fmt.Errorf("error finding task: %w", ErrTaskNotFound)
+		}
+		return change.After.clone(), nil
+	}
+
+	return s.base.Get(ctx, id)
+}
+
+func (s *Stage) List(ctx context.Context, opts ListOptions) (*Page, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("error listing tasks: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.changes) == 0 {
+		return s.base.List(ctx, opts)
+	}
+
+	filter, err := opts.Filter.bind(ctx, s.clock)
+	if err != nil {
+		return nil, fmt.Errorf("error listing tasks: %w", err)
+	}
+
+	page, err := s.base.List(ctx, ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*Task, 0, len(page.Tasks))
+	for _, t := range page.Tasks {
+		if change := s.find(t.ID); change != nil {
+			if change.After != nil {
+				tasks = append(tasks, change.After.clone())
+			}
+			continue
+		}
+		tasks = append(tasks, t)
+	}
+
+	for _, change := range s.changes {
+		if change.Type == ChangeCreate {
+			tasks = append(tasks, change.After.clone())
+		}
+	}
+
+	tasks = slices.DeleteFunc(tasks, func(t *Task) bool { return !filter.Matches(t) })
+	slices.SortFunc(tasks, opts.compare)
+
+	after, _ := decodeCursor(opts.Cursor)
+	return paginate(tasks, opts, after), nil
+}
+
+// Update stages the update of the task, along with the updates refreshing the status of the tasks it blocks
+func (s *Stage) Update(ctx context.Context, id string, patch *Task) (*Task, error) {
+	before, after, err := s.update(ctx, id, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := refreshDependents(ctx, s, before, after); err != nil {
+		return nil, fmt.Errorf("error updating task: %w", err)
+	}
+
+	return after, nil
+}
+
+func (s *Stage) update(ctx context.Context, id string, patch *Task) (*Task, *Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	change := s.find(id)
+	staged := change != nil
+	if !staged {
+		task, err := s.base.Get(ctx, id)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		change = &Change{Type: ChangeUpdate, TaskID: id, Before: task.clone(), After: task.clone()}
+	}
+
+	if change.After == nil {
+		return nil, nil, fmt.Errorf("error finding task: %w", ErrTaskNotFound)
+	}
+
+	before := change.After
+	after := before.clone()
+	if err := patchTask(ctx, s.get, after, patch, s.clock); err != nil {
+		return nil, nil, fmt.Errorf("error updating task: %w", err)
+	}
+
+	change.After = after
+	if change.Type == ChangeUpdate {
+		change.patches = append(change.patches, patch.clone())
+	}
+	if !staged {
+		s.changes = append(s.changes, change)
+	}
+
+	return before.clone(), after.clone(), nil
+}
+
+// Delete stages the deletion of the task, along with the updates orphaning or the deletions of its subtasks
+// according to the mode of the options, and the updates refreshing the status of the tasks it blocks
+func (s *Stage) Delete(ctx context.Context, id string, opts DeleteOptions) error {
+	task, err := s.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("error deleting task: %w", err)
+	}
+
+	err = deleteTask(ctx, s, task, opts, func() error { return s.delete(ctx, id, opts) })
+	if err != nil {
+		return fmt.Errorf("error deleting task: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Stage) delete(ctx context.Context, id string, opts DeleteOptions) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	change := s.find(id)
+	if change != nil && change.After != nil {
+		if err := checkVersion(opts.Version, change.After.Version); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case change == nil:
+		task, err := s.base.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(opts.Version, task.Version); err != nil {
+			return err
+		}
+		s.changes = append(s.changes, &Change{Type: ChangeDelete, TaskID: id, Before: task.clone()})
+	case change.Type == ChangeCreate:
+		s.changes = slices.DeleteFunc(s.changes, func(c *Change) bool { return c == change })
+	case change.Type == ChangeUpdate:
+		change.Type = ChangeDelete
+		change.After = nil
+		change.patches = nil
+	default:
+		return ErrTaskNotFound
+	}
+
+	return nil
+}
+
+// Batch stages the operations in order, all of them or none, and returns the change made by each of them
+// with the temporary IDs of the created tasks
+func (s *Stage) Batch(ctx context.Context, ops []Operation) ([]Change, error) {
+	if err := checkBatch(ops); err != nil {
+		return nil, fmt.Errorf("error applying batch: %w", err)
+	}
+
+	s.mu.Lock()
+	saved := make([]*Change, 0, len(s.changes))
+	for _, change := range s.changes {
+		saved = append(saved, change.clone())
+	}
+	nextID := s.nextID
+	s.mu.Unlock()
+
+	changes, err := applyOperations(ctx, s, ops)
+	if err != nil {
+		s.mu.Lock()
+		s.changes, s.nextID = saved, nextID
+		s.mu.Unlock()
+
+		return nil, fmt.Errorf("error applying batch: %w", err)
+	}
+
+	return changes, nil
+}
+
+// Plan returns the execution plan of the open tasks as they would be once the changes are committed
+func (s *Stage) Plan(ctx context.Context) (*Plan, error) {
+	result, err := plan(ctx, s)
+	if err != nil {
+		return nil, fmt.Errorf("error planning tasks: %w", err)
+	}
+
+	return result, nil
+}
+
+// Progress returns the roll-up of the subtasks as it would be once the changes are committed
+func (s *Stage) Progress(ctx context.Context, ids []string) (map[string]Progress, error) {
+	result, err := progress(ctx, s, ids)
+	if err != nil {
+		return nil, fmt.Errorf("error computing progress: %w", err)
+	}
+
+	return result, nil
+}
+
+// Tags returns the tags of the tasks as they would be once the changes are committed
+func (s *Stage) Tags(ctx context.Context) ([]TagCount, error) {
+	page, err := s.List(ctx, ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing tags: %w", err)
+	}
+
+	return countTags(page.Tasks), nil
+}
+
+// History returns the committed history of a task, the staged changes are not part of it
+func (s *Stage) History(ctx context.Context, id string) ([]Event, error) {
+	return s.base.History(ctx, id)
+}
+
+// Subscribe returns a subscription to the committed changes of the tasks, the staged changes are published once committed
+func (s *Stage) Subscribe(ctx context.Context, filter NotificationFilter, lastID int64) (*Subscription, error) {
+	return s.base.Subscribe(ctx, filter, lastID)
+}
+
+// Search searches the committed tasks, the staged changes are only indexed once committed
+func (s *Stage) Search(ctx context.Context, query string, opts SearchOptions) (*SearchResult, error) {
+	return s.base.Search(ctx, query, opts)
+}
+
+// Move returns ErrNotStageable, the IDs of the moved tasks are only known once committed
+func (s *Stage) Move(_ context.Context, id, _ string) (*Task, error) {
+	return nil, fmt.Errorf("error moving task %s: %w", id, ErrNotStageable)
+}
+
+// RenameTag stages the updates renaming the tag on every task having it
+func (s *Stage) RenameTag(ctx context.Context, from, to string) (TagCount, error) {
+	tag, err := renameTag(ctx, s, from, to)
+	if err != nil {
+		return TagCount{}, fmt.Errorf("error renaming tag: %w", err)
+	}
+
+	return tag, nil
+}
+
+// MergeTags stages the updates merging the source tags into the target tag on every task having any of them
+func (s *Stage) MergeTags(ctx context.Context, sources []string, target string) (TagCount, error) {
+	tag, err := mergeTags(ctx, s, sources, target)
+	if err != nil {
+		return TagCount{}, fmt.Errorf("error merging tags: %w", err)
+	}
+
+	return tag, nil
+}
+
+// Changes returns the pending changes in the order they were staged
+func (s *Stage) Changes() []Change {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.snapshot()
+}
+
+// Discard drops every pending change
+func (s *Stage) Discard() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.changes = nil
+}
+
+// Commit applies the pending changes all together as a batch of the underlying service and returns them with the IDs
+// and values of the committed tasks. Nothing is applied if a changed task was modified or deleted since its change
+// was staged, which returns ErrStaleChange or ErrTaskNotFound, or if applying a change fails, and the pending changes
+// are kept so that the commit can be retried. Creations are applied first, parents before their subtasks,
+// so that the temporary IDs can be replaced by the committed ones, and deletions last.
+func (s *Stage) Commit(ctx context.Context) ([]Change, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.changes) == 0 {
+		return []Change{}, nil
+	}
+
+	if err := s.verify(ctx); err != nil {
+		return nil, fmt.Errorf("error committing changes: %w", err)
+	}
+
+	changes := commitOrder(s.changes)
+	ops := make([]Operation, 0, len(changes))
+	counts := make([]int, 0, len(changes))
+	for _, change := range changes {
+		changeOps := changeOperations(*change)
+		ops = append(ops, changeOps...)
+		counts = append(counts, len(changeOps))
+	}
+
+	results, err := s.base.Batch(ctx, ops)
+	if err != nil {
+		return nil, fmt.Errorf("error committing changes: %w", err)
+	}
+
+	committed := make([]Change, 0, len(changes))
+	for i, change := range changes {
+		last := results[counts[i]-1]
+		results = results[counts[i]:]
+		committed = append(committed, Change{Type: change.Type, TaskID: last.TaskID, Before: change.Before, After: last.After})
+	}
+
+	s.changes = nil
+	return committed, nil
+}
+
+func (s *Stage) verify(ctx context.Context) error {
+	for _, change := range s.changes {
+		if change.Type == ChangeCreate {
+			continue
+		}
+
+		current, err := s.base.Get(ctx, change.TaskID)
+		if err != nil {
+			return err
+		}
+
+		if current.Version != change.Before.Version {
+			return fmt.Errorf("%w: %s", ErrStaleChange, change.TaskID)
+		}
+	}
+
+	return nil
+}
+
+// changeOperations returns the operations of the batch applying the change, one per patch of an update.
+// The first patch expects the version the task had when the change was staged
+func changeOperations(change Change) []Operation {
+	switch change.Type {
+	case ChangeCreate:
+		return []Operation{{Type: ChangeCreate, Ref: change.TaskID, Task: change.After.clone()}}
+	case ChangeUpdate:
+		ops := make([]Operation, 0, len(change.patches))
+		for i, patch := range change.patches {
+			patch := patch.clone()
+			patch.Version = 0
+			if i == 0 {
+				patch.Version = change.Before.Version
+			}
+			ops = append(ops, Operation{Type: ChangeUpdate, TaskID: change.TaskID, Task: patch})
+		}
+		return ops
+	default:
+		return []Operation{{Type: ChangeDelete, TaskID: change.TaskID, DeleteOptions: DeleteOptions{Version: change.Before.Version}}}
+	}
+}
+
+// commitOrder orders the creations before the updates and the deletions, and the staged creations
+// of parents and blockers before the creations of the tasks referencing them
+func commitOrder(changes []*Change) []*Change {
+	created := make(map[string]*Change)
+	for _, change := range changes {
+		if change.Type == ChangeCreate {
+			created[change.TaskID] = change
+		}
+	}
+
+	depths := make(map[string]int)
+	var depth func(change *Change) int
+	depth = func(change *Change) int {
+		if d, ok := depths[change.TaskID]; ok {
+			return d
+		}
+
+		references := slices.Clone(change.After.BlockedBy)
+		if change.After.ParentID != nil {
+			references = append(references, *change.After.ParentID)
+		}
+
+		d := 0
+		for _, id := range references {
+			if referenced := created[id]; referenced != nil {
+				d = max(d, depth(referenced)+1)
+			}
+		}
+
+		depths[change.TaskID] = d
+		return d
+	}
+
+	ordered := slices.Clone(changes)
+	slices.SortStableFunc(ordered, func(a, b *Change) int {
+		if c := changeRank(a.Type) - changeRank(b.Type); c != 0 || a.Type != ChangeCreate {
+			return c
+		}
+		return depth(a) - depth(b)
+	})
+
+	return ordered
+}
+
+func changeRank(t ChangeType) int {
+	switch t {
+	case ChangeCreate:
+		return 0
+	case ChangeUpdate:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func resolveIDs(ids map[string]string, values []string) []string {
+	if values == nil {
+		return nil
+	}
+
+	resolved := make([]string, 0, len(values))
+	for _, id := range values {
+		resolved = append(resolved, *resolveID(ids, &id))
+	}
+
+	return resolved
+}
+
+func resolveID(ids map[string]string, id *string) *string {
+	if id == nil {
+		return nil
+	}
+	if resolved, ok := ids[*id]; ok {
+		return &resolved
+	}
+
+	return id
+}
+
+func (s *Stage) find(id string) *Change {
+	for _, change := range s.changes {
+		if change.TaskID == id {
+			return change
+		}
+	}
+
+	return nil
+}
+
+func (s *Stage) snapshot() []Change {
+	changes := make([]Change, 0, len(s.changes))
+	for _, change := range s.changes {
+		c := change.clone()
+		c.patches = nil
+		changes = append(changes, *c)
+	}
+
+	return changes
+}
+
+func (c *Change) clone() *Change {
+	clone := *c
+	if c.Before != nil {
+		clone.Before = c.Before.clone()
+	}
+	if c.After != nil {
+		clone.After = c.After.clone()
+	}
+
+	clone.patches = make([]*Task, 0, len(c.patches))
+	for _, patch := range c.patches {
+		clone.patches = append(clone.patches, patch.clone())
+	}
+
+	return &clone
+}
@@ -0,0 +1,524 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/pkg/util"
+	"go.uber.org/mock/gomock"
+)
+
+func TestStage_Create(t *testing.T) {
+	t.Run("should stage task with temporary ID without creating it", func(t *testing.T) {
+		ctx := context.Background()
+		f := newFixture(t)
+		stage, base := NewStage(f.service, f.clock), f.service
+
+		task := &Task{Title: "Buy milk"}
+		require.NoError(t, stage.Create(ctx, task))
+
+		assert.Equal(t, "STAGED-1", task.ID)
+		assert.Equal(t, StatusNotStarted, task.Status)
+
+		staged, err := stage.Get(ctx, "STAGED-1")
+		require.NoError(t, err)
+		assert.Equal(t, "Buy milk", staged.Title)
+
+		page, err := base.List(ctx, ListOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, page.Tasks)
+
+		changes := stage.Changes()
+		require.Len(t, changes, 1)
+		assert.Equal(t, ChangeCreate, changes[0].Type)
+		assert.Equal(t, []FieldChange{
+			{Field: "title", Before: nil, After: "Buy milk"},
+			{Field: "status", Before: nil, After: StatusNotStarted},
+		}, changes[0].Diff())
+	})
+}
+
+func TestStage_Update(t *testing.T) {
+	t.Run("should stage update and return updated task on reads", func(t *testing.T) {
+		ctx := context.Background()
+		f := newFixture(t)
+		stage, base := NewStage(f.service, f.clock), f.service
+		require.NoError(t, base.Create(ctx, &Task{Title: "Write report"}))
+
+		updated, err := stage.Update(ctx, "TASK-000001", &Task{Priority: util.Ptr(PriorityHigh)})
+		require.NoError(t, err)
+		assert.Equal(t, util.Ptr(PriorityHigh), updated.Priority)
+
+		staged, err := stage.Get(ctx, "TASK-000001")
+		require.NoError(t, err)
+		assert.Equal(t, util.Ptr(PriorityHigh), staged.Priority)
+
+		original, err := base.Get(ctx, "TASK-000001")
+		require.NoError(t, err)
+		assert.Nil(t, original.Priority)
+
+		changes := stage.Changes()
+		require.Len(t, changes, 1)
+		assert.Equal(t, ChangeUpdate, changes[0].Type)
+		assert.Equal(t, []FieldChange{{Field: "priority", Before: nil, After: PriorityHigh}}, changes[0].Diff())
+	})
+
+	t.Run("should merge updates of the same task", func(t *testing.T) {
+		ctx := context.Background()
+		f := newFixture(t)
+		stage, base := NewStage(f.service, f.clock), f.service
+		require.NoError(t, base.Create(ctx, &Task{Title: "Write report"}))
+
+		_, err := stage.Update(ctx, "TASK-000001", &Task{Priority: util.Ptr(PriorityHigh)})
+		require.NoError(t, err)
+		_, err = stage.Update(ctx, "TASK-000001", &Task{Title: "Write quarterly report"})
+		require.NoError(t, err)
+
+		changes := stage.Changes()
+		require.Len(t, changes, 1)
+		assert.Equal(t, []FieldChange{
+			{Field: "title", Before: "Write report", After: "Write quarterly report"},
+			{Field: "priority", Before: nil, After: PriorityHigh},
+		}, changes[0].Diff())
+	})
+
+	t.Run("should merge update into staged creation", func(t *testing.T) {
+		ctx := context.Background()
+		f := newFixture(t)
+		stage := NewStage(f.service, f.clock)
+		require.NoError(t, stage.Create(ctx, &Task{Title: "Buy milk"}))
+
+		_, err := stage.Update(ctx, "STAGED-1", &Task{Description: "Oat milk"})
+		require.NoError(t, err)
+
+		changes := stage.Changes()
+		require.Len(t, changes, 1)
+		assert.Equal(t, ChangeCreate, changes[0].Type)
+		assert.Equal(t, "Oat milk", changes[0].After.Description)
+	})
+
+	t.Run("should return error when task does not exist", func(t *testing.T) {
+		ctx := context.Background()
+		f := newFixture(t)
+		stage := NewStage(f.service, f.clock)
+
+		task, err := stage.Update(ctx, "TASK-000404", &Task{Title: "Missing"})
+
+		assert.ErrorIs(t, err, ErrTaskNotFound)
+		assert.Nil(t, task)
+		assert.Empty(t, stage.Changes())
+	})
+}
+
+func TestStage_Delete(t *testing.T) {
+	t.Run("should stage deletion and hide task from reads", func(t *testing.T) {
+		ctx := context.Background()
+		f := newFixture(t)
+		stage, base := NewStage(f.service, f.clock), f.service
+		require.NoError(t, base.Create(ctx, &Task{Title: "Write report"}))
+
+		require.NoError(t, stage.Delete(ctx, "TASK-000001", DeleteOptions{}))
+
+		_, err := stage.Get(ctx, "TASK-000001")
+		assert.ErrorIs(t, err, ErrTaskNotFound)
+
+		page, err := stage.List(ctx, ListOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, page.Tasks)
+
+		_, err = base.Get(ctx, "TASK-000001")
+		assert.NoError(t, err)
+
+		assert.ErrorIs(t, stage.Delete(ctx, "TASK-000001", DeleteOptions{}), ErrTaskNotFound)
+	})
+
+	t.Run("should turn staged update into deletion", func(t *testing.T) {
+		ctx := context.Background()
+		f := newFixture(t)
+		stage, base := NewStage(f.service, f.clock), f.service
+		require.NoError(t, base.Create(ctx, &Task{Title: "Write report"}))
+
+		_, err := stage.Update(ctx, "TASK-000001", &Task{Title: "Write quarterly report"})
+		require.NoError(t, err)
+		require.NoError(t, stage.Delete(ctx, "TASK-000001", DeleteOptions{}))
+
+		changes := stage.Changes()
+		require.Len(t, changes, 1)
+		assert.Equal(t, ChangeDelete, changes[0].Type)
+		assert.Equal(t, "Write report", changes[0].Before.Title)
+		assert.Nil(t, changes[0].After)
+	})
+
+	t.Run("should drop staged creation", func(t *testing.T) {
+		ctx := context.Background()
+		f := newFixture(t)
+		stage := NewStage(f.service, f.clock)
+		require.NoError(t, stage.Create(ctx, &Task{Title: "Buy milk"}))
+
+		require.NoError(t, stage.Delete(ctx, "STAGED-1", DeleteOptions{}))
+
+		assert.Empty(t, stage.Changes())
+	})
+
+	t.Run("should return error when task does not exist", func(t *testing.T) {
+		ctx := context.Background()
+		f := newFixture(t)
+		stage := NewStage(f.service, f.clock)
+
+		err := stage.Delete(ctx, "TASK-000404", DeleteOptions{})
+
+		assert.ErrorIs(t, err, ErrTaskNotFound)
+		assert.Empty(t, stage.Changes())
+	})
+}
+
+func TestStage_Batch(t *testing.T) {
+	t.Run("should stage operations replacing references by temporary IDs", func(t *testing.T) {
+		ctx := context.Background()
+		f := newFixture(t)
+		stage, base := NewStage(f.service, f.clock), f.service
+
+		changes, err := stage.Batch(ctx, []Operation{
+			{Type: ChangeCreate, Ref: "report", Task: &Task{Title: "Write report"}},
+			{Type: ChangeCreate, Task: &Task{Title: "Review report", BlockedBy: []string{"report"}}},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"STAGED-1", "STAGED-2"}, util.Map(changes, func(c Change) string { return c.TaskID }))
+		assert.Equal(t, []string{"STAGED-1"}, changes[1].After.BlockedBy)
+		assert.Len(t, stage.Changes(), 2)
+
+		page, err := base.List(ctx, ListOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, page.Tasks)
+	})
+
+	t.Run("should stage none of the operations when one fails", func(t *testing.T) {
+		ctx := context.Background()
+		f := newFixture(t)
+		stage := NewStage(f.service, f.clock)
+		require.NoError(t, stage.Create(ctx, &Task{Title: "Write report"}))
+
+		_, err := stage.Batch(ctx, []Operation{
+			{Type: ChangeUpdate, TaskID: "STAGED-1", Task: &Task{Title: "Write summary"}},
+			{Type: ChangeCreate, Task: &Task{Title: "Review report"}},
+			{Type: ChangeDelete, TaskID: "TASK-000009"},
+		})
+
+		var batchErr *BatchError
+		require.ErrorAs(t, err, &batchErr)
+		assert.Equal(t, 2, batchErr.Index)
+
+		changes := stage.Changes()
+		require.Len(t, changes, 1)
+		assert.Equal(t, "Write report", changes[0].After.Title)
+
+		task := &Task{Title: "Review report"}
+		require.NoError(t, stage.Create(ctx, task))
+		assert.Equal(t, "STAGED-2", task.ID)
+	})
+}
+
+func TestStage_List(t *testing.T) {
+	t.Run("should filter, sort and paginate staged tasks with existing tasks", func(t *testing.T) {
+		ctx := context.Background()
+		f := newFixture(t)
+		stage, base := NewStage(f.service, f.clock), f.service
+		require.NoError(t, base.Create(ctx, &Task{Title: "Write report", Priority: util.Ptr(PriorityLow)}))
+		require.NoError(t, base.Create(ctx, &Task{Title: "Review report", Priority: util.Ptr(PriorityMedium)}))
+		require.NoError(t, base.Create(ctx, &Task{Title: "Fix login"}))
+
+		require.NoError(t, stage.Create(ctx, &Task{Title: "Send report", Priority: util.Ptr(PriorityHigh)}))
+		_, err := stage.Update(ctx, "TASK-000003", &Task{Title: "Fix report login"})
+		require.NoError(t, err)
+		require.NoError(t, stage.Delete(ctx, "TASK-000002", DeleteOptions{}))
+
+		opts := ListOptions{
+			Filter: Filter{Query: "report"},
+			Sort:   []Sort{{Field: SortByPriority, Descending: true}},
+			Limit:  2,
+		}
+		page, err := stage.List(ctx, opts)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"STAGED-1", "TASK-000001"}, taskIDs(page.Tasks))
+		assert.Equal(t, 3, page.Total)
+
+		opts.Cursor = page.NextCursor
+		page, err = stage.List(ctx, opts)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"TASK-000003"}, taskIDs(page.Tasks))
+	})
+}
+
+func TestStage_RenameTag(t *testing.T) {
+	t.Run("should stage tag updates without applying them", func(t *testing.T) {
+		ctx := context.Background()
+		f := newFixture(t)
+		stage, base := NewStage(f.service, f.clock), f.service
+		require.NoError(t, base.Create(ctx, &Task{Title: "Fix login", Tags: []string{"backend"}}))
+
+		tag, err := stage.RenameTag(ctx, "backend", "server")
+
+		require.NoError(t, err)
+		assert.Equal(t, TagCount{Tag: "server", Count: 1}, tag)
+
+		baseTags, err := base.Tags(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []TagCount{{"backend", 1}}, baseTags)
+
+		changes := stage.Changes()
+		require.Len(t, changes, 1)
+		assert.Equal(t, []FieldChange{{Field: "tags", Before: []string{"backend"}, After: []string{"server"}}}, changes[0].Diff())
+	})
+}
+
+func TestStage_Commit(t *testing.T) {
+	t.Run("should apply changes and return committed tasks", func(t *testing.T) {
+		ctx := context.Background()
+		f := newFixture(t)
+		stage, base := NewStage(f.service, f.clock), f.service
+		require.NoError(t, base.Create(ctx, &Task{Title: "Write report"}))
+		require.NoError(t, base.Create(ctx, &Task{Title: "Fix login"}))
+
+		require.NoError(t, stage.Delete(ctx, "TASK-000002", DeleteOptions{}))
+		require.NoError(t, stage.Create(ctx, &Task{Title: "Buy milk"}))
+		_, err := stage.Update(ctx, "TASK-000001", &Task{Priority: util.Ptr(PriorityHigh)})
+		require.NoError(t, err)
+
+		committed, err := stage.Commit(ctx)
+
+		require.NoError(t, err)
+		require.Len(t, committed, 3)
+		assert.Equal(t, ChangeCreate, committed[0].Type)
+		assert.Equal(t, "TASK-000003", committed[0].TaskID)
+		assert.Equal(t, "TASK-000003", committed[0].After.ID)
+		assert.Equal(t, ChangeUpdate, committed[1].Type)
+		assert.Equal(t, util.Ptr(PriorityHigh), committed[1].After.Priority)
+		assert.Equal(t, ChangeDelete, committed[2].Type)
+		assert.Empty(t, stage.Changes())
+
+		page, err := base.List(ctx, ListOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"TASK-000001", "TASK-000003"}, taskIDs(page.Tasks))
+		assert.Equal(t, util.Ptr(PriorityHigh), page.Tasks[0].Priority)
+	})
+
+	t.Run("should apply staged status changes in order", func(t *testing.T) {
+		ctx := context.Background()
+		f := newFixture(t)
+		stage, base := NewStage(f.service, f.clock), f.service
+		require.NoError(t, base.Create(ctx, &Task{Title: "Write report"}))
+
+		_, err := stage.Update(ctx, "TASK-000001", &Task{Status: StatusInProgress})
+		require.NoError(t, err)
+		_, err = stage.Update(ctx, "TASK-000001", &Task{Status: StatusCompleted})
+		require.NoError(t, err)
+
+		_, err = stage.Commit(ctx)
+		require.NoError(t, err)
+
+		committed, err := base.Get(ctx, "TASK-000001")
+		require.NoError(t, err)
+		assert.Equal(t, StatusCompleted, committed.Status)
+		assert.NotNil(t, committed.StartedAt)
+		assert.NotNil(t, committed.CompletedAt)
+	})
+
+	t.Run("should not apply anything when a task changed after staging", func(t *testing.T) {
+		ctx := context.Background()
+		f := newFixture(t)
+		stage, base := NewStage(f.service, f.clock), f.service
+		require.NoError(t, base.Create(ctx, &Task{Title: "Write report"}))
+
+		require.NoError(t, stage.Create(ctx, &Task{Title: "Buy milk"}))
+		_, err := stage.Update(ctx, "TASK-000001", &Task{Priority: util.Ptr(PriorityHigh)})
+		require.NoError(t, err)
+
+		_, err = base.Update(ctx, "TASK-000001", &Task{Description: "Changed elsewhere"})
+		require.NoError(t, err)
+
+		committed, err := stage.Commit(ctx)
+
+		assert.ErrorIs(t, err, ErrStaleChange)
+		assert.Nil(t, committed)
+		assert.Len(t, stage.Changes(), 2)
+
+		page, err := base.List(ctx, ListOptions{})
+		require.NoError(t, err)
+		assert.Len(t, page.Tasks, 1)
+	})
+
+	t.Run("should commit changes as one batch and keep them when it fails", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := NewMockService(ctrl)
+		stage := NewStage(mockService, newTickingClock(ctrl))
+
+		existing := &Task{ID: "TASK-000001", Title: "Write report", Version: 3}
+		mockService.EXPECT().Get(ctx, "TASK-000001").Return(existing, nil).Times(2)
+
+		require.NoError(t, stage.Create(ctx, &Task{Title: "Buy milk"}))
+		_, err := stage.Update(ctx, "TASK-000001", &Task{Title: "Write quarterly report"})
+		require.NoError(t, err)
+
+		mockService.EXPECT().Batch(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, ops []Operation) ([]Change, error) {
+			require.Len(t, ops, 2)
+			assert.Equal(t, ChangeCreate, ops[0].Type)
+			assert.Equal(t, "STAGED-1", ops[0].Ref)
+			assert.Equal(t, "Buy milk", ops[0].Task.Title)
+			assert.Equal(t, ChangeUpdate, ops[1].Type)
+			assert.Equal(t, "TASK-000001", ops[1].TaskID)
+			assert.Equal(t, 3, ops[1].Task.Version)
+			return nil, &BatchError{Index: 1, Err: errors.New("database error")}
+		})
+
+		committed, err := stage.Commit(ctx)
+
+		assert.ErrorContains(t, err, "operation 1: database error")
+		assert.Nil(t, committed)
+		assert.Len(t, stage.Changes(), 2)
+	})
+}
+
+func TestStage_Subtasks(t *testing.T) {
+	t.Run("should commit staged parent before its subtasks", func(t *testing.T) {
+		ctx := context.Background()
+		f := newFixture(t)
+		stage, base := NewStage(f.service, f.clock), f.service
+
+		child := &Task{Title: "Write changelog"}
+		require.NoError(t, stage.Create(ctx, child))
+		parent := &Task{Title: "Release v2"}
+		require.NoError(t, stage.Create(ctx, parent))
+		_, err := stage.Update(ctx, child.ID, &Task{ParentID: util.Ptr(parent.ID)})
+		require.NoError(t, err)
+
+		committed, err := stage.Commit(ctx)
+
+		require.NoError(t, err)
+		require.Len(t, committed, 2)
+		assert.Equal(t, "TASK-000001", committed[0].TaskID)
+		assert.Equal(t, "Release v2", committed[0].After.Title)
+		assert.Equal(t, util.Ptr("TASK-000001"), committed[1].After.ParentID)
+
+		progress, err := base.Progress(ctx, []string{"TASK-000001"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]Progress{"TASK-000001": {Total: 1}}, progress)
+	})
+
+	t.Run("should stage deletion of subtasks in cascade mode", func(t *testing.T) {
+		ctx := context.Background()
+		f := newFixture(t)
+		stage, base := NewStage(f.service, f.clock), f.service
+		require.NoError(t, base.Create(ctx, &Task{Title: "Release v2"}))
+		require.NoError(t, base.Create(ctx, &Task{Title: "Write changelog", ParentID: util.Ptr("TASK-000001")}))
+
+		require.NoError(t, stage.Delete(ctx, "TASK-000001", DeleteOptions{Mode: DeleteCascade}))
+
+		changes := stage.Changes()
+		require.Len(t, changes, 2)
+		assert.Equal(t, "TASK-000002", changes[0].TaskID)
+		assert.Equal(t, "TASK-000001", changes[1].TaskID)
+
+		_, err := stage.Commit(ctx)
+		require.NoError(t, err)
+
+		page, err := base.List(ctx, ListOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, page.Tasks)
+	})
+
+	t.Run("should reject cyclic parent", func(t *testing.T) {
+		ctx := context.Background()
+		f := newFixture(t)
+		stage := NewStage(f.service, f.clock)
+		parent := &Task{Title: "Release v2"}
+		require.NoError(t, stage.Create(ctx, parent))
+		child := &Task{Title: "Write changelog", ParentID: util.Ptr(parent.ID)}
+		require.NoError(t, stage.Create(ctx, child))
+
+		_, err := stage.Update(ctx, parent.ID, &Task{ParentID: util.Ptr(child.ID)})
+
+		assert.ErrorIs(t, err, ErrCyclicParent)
+	})
+}
+
+func TestStage_Dependencies(t *testing.T) {
+	t.Run("should commit staged blocker before the task it blocks", func(t *testing.T) {
+		ctx := context.Background()
+		f := newFixture(t)
+		stage, base := NewStage(f.service, f.clock), f.service
+
+		deploy := &Task{Title: "Deploy"}
+		require.NoError(t, stage.Create(ctx, deploy))
+		migrate := &Task{Title: "Write migration"}
+		require.NoError(t, stage.Create(ctx, migrate))
+		updated, err := stage.Update(ctx, deploy.ID, &Task{BlockedBy: []string{migrate.ID}})
+		require.NoError(t, err)
+		assert.Equal(t, StatusBlocked, updated.Status)
+
+		_, err = stage.Commit(ctx)
+		require.NoError(t, err)
+
+		task, err := base.Get(ctx, "TASK-000002")
+		require.NoError(t, err)
+		assert.Equal(t, "Deploy", task.Title)
+		assert.Equal(t, StatusBlocked, task.Status)
+		assert.Equal(t, []string{"TASK-000001"}, task.BlockedBy)
+	})
+
+	t.Run("should resume staged dependents when blocker is completed", func(t *testing.T) {
+		ctx := context.Background()
+		f := newFixture(t)
+		stage, base := NewStage(f.service, f.clock), f.service
+		require.NoError(t, base.Create(ctx, &Task{Title: "Write migration", Status: StatusInProgress}))
+		require.NoError(t, base.Create(ctx, &Task{Title: "Deploy", BlockedBy: []string{"TASK-000001"}}))
+
+		_, err := stage.Update(ctx, "TASK-000001", &Task{Status: StatusCompleted})
+		require.NoError(t, err)
+
+		task, err := stage.Get(ctx, "TASK-000002")
+		require.NoError(t, err)
+		assert.Equal(t, StatusNotStarted, task.Status)
+	})
+
+	t.Run("should reject cyclic dependency", func(t *testing.T) {
+		ctx := context.Background()
+		f := newFixture(t)
+		stage := NewStage(f.service, f.clock)
+		design := &Task{Title: "Design"}
+		require.NoError(t, stage.Create(ctx, design))
+		build := &Task{Title: "Build", BlockedBy: []string{design.ID}}
+		require.NoError(t, stage.Create(ctx, build))
+
+		_, err := stage.Update(ctx, design.ID, &Task{BlockedBy: []string{build.ID}})
+
+		assert.ErrorIs(t, err, ErrCyclicDependency)
+	})
+}
+
+func TestStage_Discard(t *testing.T) {
+	t.Run("should drop pending changes", func(t *testing.T) {
+		ctx := context.Background()
+		f := newFixture(t)
+		stage, base := NewStage(f.service, f.clock), f.service
+		require.NoError(t, stage.Create(ctx, &Task{Title: "Buy milk"}))
+
+		stage.Discard()
+
+		assert.Empty(t, stage.Changes())
+		_, err := stage.Get(ctx, "STAGED-1")
+		assert.ErrorIs(t, err, ErrTaskNotFound)
+
+		page, err := base.List(ctx, ListOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, page.Tasks)
+	})
+}
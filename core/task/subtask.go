@@ -0,0 +1,151 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/utsabbera/task-master/pkg/util"
+)
+
+var (
+	// ErrParentNotFound is returned when a task is attached to a parent task which doesn't exist
+	ErrParentNotFound = errors.New("parent task not found")
+	// ErrCyclicParent is returned when a task is attached to itself or to one of its subtasks
+	ErrCyclicParent = errors.New("task cannot be a subtask of itself")
+	// ErrHasSubtasks is returned when deleting a task which has subtasks in DeleteReject mode
+	ErrHasSubtasks = errors.New("task has subtasks")
+)
+
+// DeleteMode defines what happens to the subtasks of a deleted task
+type DeleteMode string
+
+const (
+	// DeleteReject refuses to delete a task which has subtasks, it is the default mode
+	DeleteReject DeleteMode = "reject"
+	// DeleteOrphan moves the subtasks of the deleted task to the top level
+	DeleteOrphan DeleteMode = "orphan"
+	// DeleteCascade deletes the subtasks of the deleted task, recursively
+	DeleteCascade DeleteMode = "cascade"
+)
+
+var deleteModes = []DeleteMode{DeleteReject, DeleteOrphan, DeleteCascade}
+
+// DeleteModes returns every mode of deleting a task with subtasks
+func DeleteModes() []DeleteMode {
+	return slices.Clone(deleteModes)
+}
+
+// Progress is the roll-up of the completion of the subtasks of a task
+type Progress struct {
+	// Total is the number of subtasks
+	Total int
+	// Completed is the number of completed subtasks
+	Completed int
+}
+
+// Percent returns the percentage of completed subtasks, rounded down
+func (p Progress) Percent() int {
+	if p.Total == 0 {
+		return 0
+	}
+
+	return p.Completed * 100 / p.Total
+}
+
+type getter func(ctx context.Context, id string) (*Task, error)
+
+// checkParent verifies that the parent exists and that the task is not the parent or one of its ancestors,
+// an empty id checks a task which is not created yet
+func checkParent(ctx context.Context, get getter, id, parentID string) error {
+	parent, err := get(ctx, parentID)
+	if errors.Is(err, ErrTaskNotFound) {
+		return fmt.Errorf("%w: %s", ErrParentNotFound, parentID)
+	}
+	if err != nil {
+		return err
+	}
+
+	for ancestor := parent; ; {
+		if ancestor.ID == id {
+			return fmt.Errorf("%w: %s is %s or one of its subtasks", ErrCyclicParent, parentID, id)
+		}
+		if ancestor.ParentID == nil {
+			return nil
+		}
+		if ancestor, err = get(ctx, *ancestor.ParentID); err != nil {
+			return err
+		}
+	}
+}
+
+func normalizeParent(parentID *string) *string {
+	if parentID == nil || *parentID == "" {
+		return nil
+	}
+
+	return clonePtr(parentID)
+}
+
+func subtasks(ctx context.Context, s Service, ids ...string) ([]*Task, error) {
+	page, err := s.List(ctx, ListOptions{Filter: Filter{Parents: ids}})
+	if err != nil {
+		return nil, err
+	}
+
+	return page.Tasks, nil
+}
+
+// deleteSubtasks orphans or deletes the subtasks of a task being deleted according to the mode
+func deleteSubtasks(ctx context.Context, s Service, id string, mode DeleteMode) error {
+	children, err := subtasks(ctx, s, id)
+	if err != nil {
+		return err
+	}
+	if len(children) == 0 {
+		return nil
+	}
+
+	switch mode {
+	case DeleteOrphan:
+		for _, child := range children {
+			if _, err := s.Update(ctx, child.ID, &Task{ParentID: util.Ptr(""), Version: child.Version}); err != nil {
+				return fmt.Errorf("error orphaning subtask %s: %w", child.ID, err)
+			}
+		}
+	case DeleteCascade:
+		for _, child := range children {
+			if err := s.Delete(ctx, child.ID, DeleteOptions{Version: child.Version, Mode: DeleteCascade}); err != nil {
+				return fmt.Errorf("error deleting subtask %s: %w", child.ID, err)
+			}
+		}
+	default:
+		return fmt.Errorf("%w: %s has %d subtasks", ErrHasSubtasks, id, len(children))
+	}
+
+	return nil
+}
+
+func progress(ctx context.Context, s Service, ids []string) (map[string]Progress, error) {
+	result := make(map[string]Progress)
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	children, err := subtasks(ctx, s, ids...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, child := range children {
+		p := result[*child.ParentID]
+		p.Total++
+		if child.Status == StatusCompleted {
+			p.Completed++
+		}
+		result[*child.ParentID] = p
+	}
+
+	return result, nil
+}
@@ -0,0 +1,209 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/pkg/idgen"
+	"github.com/utsabbera/task-master/pkg/util"
+)
+
+// releaseTasks returns a release task with subtasks, one of them having a subtask of its own
+func releaseTasks() []*Task {
+	return []*Task{
+		{Title: "Release v2"},
+		{Title: "Write changelog", ParentID: util.Ptr("TASK-000001"), Status: StatusCompleted},
+		{Title: "Tag release", ParentID: util.Ptr("TASK-000001")},
+		{Title: "Update website", ParentID: util.Ptr("TASK-000003")},
+	}
+}
+
+// failingDeleteRepository is a repository failing to delete the task with the given ID
+type failingDeleteRepository struct {
+	Repository
+	id string
+}
+
+func (r *failingDeleteRepository) Delete(ctx context.Context, id string, version int) error {
+	if id == r.id {
+		return errors.New("delete error")
+	}
+
+	return r.Repository.Delete(ctx, id, version)
+}
+
+func (r *failingDeleteRepository) Transaction(ctx context.Context, fn func(tx Repository) error) error {
+	return r.Repository.Transaction(ctx, func(tx Repository) error {
+		return fn(&failingDeleteRepository{Repository: tx, id: r.id})
+	})
+}
+
+func TestProgress_Percent(t *testing.T) {
+	t.Run("should round percentage down", func(t *testing.T) {
+		assert.Equal(t, 33, Progress{Total: 3, Completed: 1}.Percent())
+	})
+
+	t.Run("should return zero without subtasks", func(t *testing.T) {
+		assert.Equal(t, 0, Progress{}.Percent())
+	})
+}
+
+func TestService_Create_Subtask(t *testing.T) {
+	t.Run("should create subtask of existing task", func(t *testing.T) {
+		ctx := context.Background()
+		service := newFixture(t, releaseTasks()...).service
+
+		task := &Task{Title: "Announce release", ParentID: util.Ptr("TASK-000001")}
+		require.NoError(t, service.Create(ctx, task))
+
+		created, err := service.Get(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, util.Ptr("TASK-000001"), created.ParentID)
+	})
+
+	t.Run("should create top level task when parent is empty", func(t *testing.T) {
+		task := &Task{Title: "Plan v3", ParentID: util.Ptr("")}
+
+		require.NoError(t, newFixture(t, releaseTasks()...).service.Create(context.Background(), task))
+
+		assert.Nil(t, task.ParentID)
+	})
+
+	t.Run("should return error when parent doesn't exist", func(t *testing.T) {
+		err := newFixture(t, releaseTasks()...).service.Create(context.Background(), &Task{Title: "Orphan", ParentID: util.Ptr("TASK-999999")})
+
+		assert.ErrorIs(t, err, ErrParentNotFound)
+	})
+}
+
+func TestService_Update_Parent(t *testing.T) {
+	t.Run("should move task under another parent", func(t *testing.T) {
+		task, err := newFixture(t, releaseTasks()...).service.Update(context.Background(), "TASK-000004", &Task{ParentID: util.Ptr("TASK-000001")})
+
+		require.NoError(t, err)
+		assert.Equal(t, util.Ptr("TASK-000001"), task.ParentID)
+	})
+
+	t.Run("should move task to top level when parent is empty", func(t *testing.T) {
+		task, err := newFixture(t, releaseTasks()...).service.Update(context.Background(), "TASK-000004", &Task{ParentID: util.Ptr("")})
+
+		require.NoError(t, err)
+		assert.Nil(t, task.ParentID)
+	})
+
+	t.Run("should keep parent when patch has no parent", func(t *testing.T) {
+		task, err := newFixture(t, releaseTasks()...).service.Update(context.Background(), "TASK-000004", &Task{Title: "Update landing page"})
+
+		require.NoError(t, err)
+		assert.Equal(t, util.Ptr("TASK-000003"), task.ParentID)
+	})
+
+	t.Run("should return error when task becomes its own parent", func(t *testing.T) {
+		_, err := newFixture(t, releaseTasks()...).service.Update(context.Background(), "TASK-000001", &Task{ParentID: util.Ptr("TASK-000001")})
+
+		assert.ErrorIs(t, err, ErrCyclicParent)
+	})
+
+	t.Run("should return error when task becomes a subtask of its descendant", func(t *testing.T) {
+		_, err := newFixture(t, releaseTasks()...).service.Update(context.Background(), "TASK-000001", &Task{ParentID: util.Ptr("TASK-000004")})
+
+		assert.ErrorIs(t, err, ErrCyclicParent)
+	})
+
+	t.Run("should return error when parent doesn't exist", func(t *testing.T) {
+		_, err := newFixture(t, releaseTasks()...).service.Update(context.Background(), "TASK-000004", &Task{ParentID: util.Ptr("TASK-999999")})
+
+		assert.ErrorIs(t, err, ErrParentNotFound)
+	})
+}
+
+func TestService_Delete_Subtasks(t *testing.T) {
+	t.Run("should reject deleting task with subtasks by default", func(t *testing.T) {
+		ctx := context.Background()
+		service := newFixture(t, releaseTasks()...).service
+
+		err := service.Delete(ctx, "TASK-000001", DeleteOptions{})
+
+		assert.ErrorIs(t, err, ErrHasSubtasks)
+		_, err = service.Get(ctx, "TASK-000001")
+		assert.NoError(t, err)
+	})
+
+	t.Run("should move subtasks to top level in orphan mode", func(t *testing.T) {
+		ctx := context.Background()
+		service := newFixture(t, releaseTasks()...).service
+
+		require.NoError(t, service.Delete(ctx, "TASK-000001", DeleteOptions{Mode: DeleteOrphan}))
+
+		page, err := service.List(ctx, ListOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"TASK-000002", "TASK-000003", "TASK-000004"}, taskIDs(page.Tasks))
+		assert.Nil(t, page.Tasks[0].ParentID)
+		assert.Nil(t, page.Tasks[1].ParentID)
+		assert.Equal(t, util.Ptr("TASK-000003"), page.Tasks[2].ParentID)
+	})
+
+	t.Run("should delete subtasks recursively in cascade mode", func(t *testing.T) {
+		ctx := context.Background()
+		service := newFixture(t, releaseTasks()...).service
+		require.NoError(t, service.Create(ctx, &Task{Title: "Plan v3"}))
+
+		require.NoError(t, service.Delete(ctx, "TASK-000001", DeleteOptions{Mode: DeleteCascade}))
+
+		page, err := service.List(ctx, ListOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"TASK-000005"}, taskIDs(page.Tasks))
+	})
+
+	t.Run("should keep task and subtasks when deleting a subtask fails", func(t *testing.T) {
+		ctx := context.Background()
+		service := NewService(&failingDeleteRepository{Repository: NewMemoryRepository(), id: "TASK-000003"}, idgen.NewSequential("TASK-", 1, 6), util.NewClock())
+		for _, task := range []*Task{
+			{Title: "Release v2"},
+			{Title: "Write changelog", ParentID: util.Ptr("TASK-000001")},
+			{Title: "Tag release", ParentID: util.Ptr("TASK-000001")},
+		} {
+			require.NoError(t, service.Create(ctx, task))
+		}
+
+		err := service.Delete(ctx, "TASK-000001", DeleteOptions{Mode: DeleteCascade})
+		assert.ErrorContains(t, err, "delete error")
+
+		page, err := service.List(ctx, ListOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"TASK-000001", "TASK-000002", "TASK-000003"}, taskIDs(page.Tasks))
+		assert.Equal(t, util.Ptr("TASK-000001"), page.Tasks[1].ParentID)
+	})
+
+	t.Run("should delete task without subtasks in reject mode", func(t *testing.T) {
+		ctx := context.Background()
+		service := newFixture(t, releaseTasks()...).service
+
+		require.NoError(t, service.Delete(ctx, "TASK-000004", DeleteOptions{Mode: DeleteReject}))
+
+		_, err := service.Get(ctx, "TASK-000004")
+		assert.ErrorIs(t, err, ErrTaskNotFound)
+	})
+}
+
+func TestService_Progress(t *testing.T) {
+	t.Run("should roll up completion of direct subtasks", func(t *testing.T) {
+		progress, err := newFixture(t, releaseTasks()...).service.Progress(context.Background(), []string{"TASK-000001", "TASK-000003", "TASK-000004"})
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]Progress{
+			"TASK-000001": {Total: 2, Completed: 1},
+			"TASK-000003": {Total: 1, Completed: 0},
+		}, progress)
+	})
+
+	t.Run("should return empty result without tasks", func(t *testing.T) {
+		progress, err := newFixture(t, releaseTasks()...).service.Progress(context.Background(), nil)
+
+		require.NoError(t, err)
+		assert.Empty(t, progress)
+	})
+}
@@ -0,0 +1,180 @@
+package task
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	// ErrInvalidTag is returned when a tag is empty, too long or contains characters other than letters, digits, '-', '_' and '/'
+	ErrInvalidTag = errors.New("invalid tag")
+	// ErrTagNotFound is returned when no task has the specified tag
+	ErrTagNotFound = errors.New("tag not found")
+	// ErrTagExists is returned when a tag is renamed to a tag which is already used, the tags must be merged instead
+	ErrTagExists = errors.New("tag already exists")
+)
+
+// MaxTagLength is the maximum number of characters of a tag
+const MaxTagLength = 50
+
+// TagCount is a tag with the number of tasks having it
+type TagCount struct {
+	// Tag is the normalized tag
+	Tag string
+	// Count is the number of tasks having the tag
+	Count int
+}
+
+// NormalizeTag returns the canonical form of a tag, trimmed, without leading '#' and in lower case
+// Returns ErrInvalidTag if the tag is empty, too long or contains invalid characters
+func NormalizeTag(tag string) (string, error) {
+	normalized := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+
+	if normalized == "" || utf8.RuneCountInString(normalized) > MaxTagLength {
+		return "", fmt.Errorf("%w: %q", ErrInvalidTag, tag)
+	}
+
+	for _, r := range normalized {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' && r != '/' {
+			return "", fmt.Errorf("%w: %q", ErrInvalidTag, tag)
+		}
+	}
+
+	return normalized, nil
+}
+
+// NormalizeTags normalizes every tag, removing duplicates and sorting them
+// A nil slice stays nil so that it can tell an unchanged field from a cleared one in update patches
+func NormalizeTags(tags []string) ([]string, error) {
+	if tags == nil {
+		return nil, nil
+	}
+
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		n, err := NormalizeTag(tag)
+		if err != nil {
+			return nil, err
+		}
+		normalized = append(normalized, n)
+	}
+
+	slices.Sort(normalized)
+	return slices.Compact(normalized), nil
+}
+
+// HasTag reports whether the task has the given normalized tag
+func (t *Task) HasTag(tag string) bool {
+	_, found := slices.BinarySearch(t.Tags, tag)
+	return found
+}
+
+func countTags(tasks []*Task) []TagCount {
+	counts := make(map[string]int)
+	for _, t := range tasks {
+		for _, tag := range t.Tags {
+			counts[tag]++
+		}
+	}
+
+	result := make([]TagCount, 0, len(counts))
+	for tag, count := range counts {
+		result = append(result, TagCount{Tag: tag, Count: count})
+	}
+
+	slices.SortFunc(result, func(a, b TagCount) int { return cmp.Compare(a.Tag, b.Tag) })
+	return result
+}
+
+func findTag(counts []TagCount, tag string) (TagCount, bool) {
+	i, found := slices.BinarySearchFunc(counts, tag, func(c TagCount, tag string) int { return cmp.Compare(c.Tag, tag) })
+	if !found {
+		return TagCount{Tag: tag}, false
+	}
+
+	return counts[i], true
+}
+
+func renameTag(ctx context.Context, s Service, from, to string) (TagCount, error) {
+	from, err := NormalizeTag(from)
+	if err != nil {
+		return TagCount{}, err
+	}
+
+	to, err = NormalizeTag(to)
+	if err != nil {
+		return TagCount{}, err
+	}
+
+	counts, err := s.Tags(ctx)
+	if err != nil {
+		return TagCount{}, err
+	}
+
+	source, found := findTag(counts, from)
+	if !found {
+		return TagCount{}, fmt.Errorf("%w: %q", ErrTagNotFound, from)
+	}
+	if from == to {
+		return source, nil
+	}
+	if _, found := findTag(counts, to); found {
+		return TagCount{}, fmt.Errorf("%w: %q", ErrTagExists, to)
+	}
+
+	return mergeTags(ctx, s, []string{from}, to)
+}
+
+// mergeTags replaces the source tags by the target tag through updates of the service,
+// so that every retagged task gets a new version
+func mergeTags(ctx context.Context, s Service, sources []string, target string) (TagCount, error) {
+	sources, err := NormalizeTags(sources)
+	if err != nil {
+		return TagCount{}, err
+	}
+
+	target, err = NormalizeTag(target)
+	if err != nil {
+		return TagCount{}, err
+	}
+
+	sources = slices.DeleteFunc(sources, func(tag string) bool { return tag == target })
+
+	tasks := make(map[string]*Task)
+	for _, source := range sources {
+		page, err := s.List(ctx, ListOptions{Filter: Filter{Tags: []string{source}}})
+		if err != nil {
+			return TagCount{}, err
+		}
+		for _, t := range page.Tasks {
+			tasks[t.ID] = t
+		}
+	}
+
+	if len(tasks) == 0 && len(sources) > 0 {
+		return TagCount{}, fmt.Errorf("%w: %s", ErrTagNotFound, strings.Join(sources, ", "))
+	}
+
+	for _, t := range tasks {
+		tags := slices.DeleteFunc(slices.Clone(t.Tags), func(tag string) bool { return slices.Contains(sources, tag) })
+		tags = append(tags, target)
+
+		if _, err := s.Update(ctx, t.ID, &Task{Tags: tags, Version: t.Version}); err != nil {
+			return TagCount{}, fmt.Errorf("error retagging task %s: %w", t.ID, err)
+		}
+	}
+
+	counts, err := s.Tags(ctx)
+	if err != nil {
+		return TagCount{}, err
+	}
+
+	result, _ := findTag(counts, target)
+	return result, nil
+}
@@ -0,0 +1,135 @@
+package task
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeTag(t *testing.T) {
+	tests := []struct {
+		tag      string
+		expected string
+	}{
+		{"backend", "backend"},
+		{"  #Backend ", "backend"},
+		{"area/API_v2", "area/api_v2"},
+		{"før-sale", "før-sale"},
+	}
+
+	for _, tt := range tests {
+		t.Run("should normalize "+tt.tag, func(t *testing.T) {
+			tag, err := NormalizeTag(tt.tag)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, tag)
+		})
+	}
+
+	for _, tag := range []string{"", "#", "two words", "a,b", "!blocked", strings.Repeat("a", MaxTagLength+1)} {
+		t.Run("should reject "+tag, func(t *testing.T) {
+			_, err := NormalizeTag(tag)
+
+			assert.ErrorIs(t, err, ErrInvalidTag)
+		})
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	t.Run("should sort tags and remove duplicates", func(t *testing.T) {
+		tags, err := NormalizeTags([]string{"urgent", "#Backend", "backend"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"backend", "urgent"}, tags)
+	})
+
+	t.Run("should keep nil and empty tags apart", func(t *testing.T) {
+		tags, err := NormalizeTags(nil)
+		require.NoError(t, err)
+		assert.Nil(t, tags)
+
+		tags, err = NormalizeTags([]string{})
+		require.NoError(t, err)
+		assert.NotNil(t, tags)
+		assert.Empty(t, tags)
+	})
+}
+
+// taggedTasks returns tasks having various tags
+func taggedTasks() []*Task {
+	return []*Task{
+		{Title: "Fix login", Tags: []string{"backend", "urgent"}},
+		{Title: "Write docs", Tags: []string{"docs"}},
+		{Title: "Add endpoint", Tags: []string{"api"}},
+	}
+}
+
+func TestService_RenameTag(t *testing.T) {
+	t.Run("should rename tag on every task having it", func(t *testing.T) {
+		ctx := context.Background()
+		service := newFixture(t, taggedTasks()...).service
+
+		tag, err := service.RenameTag(ctx, "backend", "#Server")
+
+		require.NoError(t, err)
+		assert.Equal(t, TagCount{Tag: "server", Count: 1}, tag)
+
+		task, err := service.Get(ctx, "TASK-000001")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"server", "urgent"}, task.Tags)
+		assert.Equal(t, 2, task.Version)
+	})
+
+	t.Run("should return error when tag is not used", func(t *testing.T) {
+		_, err := newFixture(t, taggedTasks()...).service.RenameTag(context.Background(), "frontend", "ui")
+
+		assert.ErrorIs(t, err, ErrTagNotFound)
+	})
+
+	t.Run("should return error when new tag is already used", func(t *testing.T) {
+		_, err := newFixture(t, taggedTasks()...).service.RenameTag(context.Background(), "backend", "api")
+
+		assert.ErrorIs(t, err, ErrTagExists)
+	})
+
+	t.Run("should return error when new tag is invalid", func(t *testing.T) {
+		_, err := newFixture(t, taggedTasks()...).service.RenameTag(context.Background(), "backend", "back end")
+
+		assert.ErrorIs(t, err, ErrInvalidTag)
+	})
+}
+
+func TestService_MergeTags(t *testing.T) {
+	t.Run("should replace source tags by target tag", func(t *testing.T) {
+		ctx := context.Background()
+		service := newFixture(t, taggedTasks()...).service
+
+		tag, err := service.MergeTags(ctx, []string{"api", "backend"}, "server")
+
+		require.NoError(t, err)
+		assert.Equal(t, TagCount{Tag: "server", Count: 2}, tag)
+
+		tags, err := service.Tags(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []TagCount{{"docs", 1}, {"server", 2}, {"urgent", 1}}, tags)
+	})
+
+	t.Run("should merge into tag which is already used", func(t *testing.T) {
+		ctx := context.Background()
+		service := newFixture(t, taggedTasks()...).service
+
+		tag, err := service.MergeTags(ctx, []string{"api", "docs"}, "backend")
+
+		require.NoError(t, err)
+		assert.Equal(t, TagCount{Tag: "backend", Count: 3}, tag)
+	})
+
+	t.Run("should return error when no task has source tags", func(t *testing.T) {
+		_, err := newFixture(t, taggedTasks()...).service.MergeTags(context.Background(), []string{"frontend"}, "ui")
+
+		assert.ErrorIs(t, err, ErrTagNotFound)
+	})
+}
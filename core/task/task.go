@@ -1,6 +1,7 @@
 package task
 
 import (
+	"slices"
 	"time"
 )
 
@@ -12,8 +13,12 @@ const (
 	StatusNotStarted Status = "NOT_STARTED"
 	// StatusInProgress indicates a task that is currently being worked on
 	StatusInProgress Status = "IN_PROGRESS"
+	// StatusBlocked indicates a task that cannot progress until something else happens
+	StatusBlocked Status = "BLOCKED"
 	// StatusCompleted indicates a task that has been finished
 	StatusCompleted Status = "COMPLETED"
+	// StatusCancelled indicates a task that was abandoned before being finished
+	StatusCancelled Status = "CANCELLED"
 )
 
 // Priority defines the importance level of a task
@@ -28,15 +33,46 @@ const (
 	PriorityHigh Priority = "HIGH"
 )
 
+var priorities = []Priority{PriorityLow, PriorityMedium, PriorityHigh}
+
+// Priorities returns every importance level of a task
+func Priorities() []Priority {
+	return slices.Clone(priorities)
+}
+
+// Valid reports whether the priority is a known importance level
+func (p Priority) Valid() bool {
+	return slices.Contains(priorities, p)
+}
+
+const (
+	// MaxTitleLength is the maximum number of characters of the title of a task
+	MaxTitleLength = 200
+	// MaxDescriptionLength is the maximum number of characters of the description of a task
+	MaxDescriptionLength = 10000
+	// MaxUserIDLength is the maximum number of characters of the ID of an assignee
+	MaxUserIDLength = 128
+)
+
+var (
+	// MinDueDate is the earliest due date of a task
+	MinDueDate = time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)
+	// MaxDueDate is the due date every task must be due before
+	MaxDueDate = time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
 // Task represents a single task in the task management system
 type Task struct {
-	// ID is the unique identifier for the task
+	// ID is the unique identifier for the task, starting with the prefix of its project
 	ID string
+	// Project is the key of the project the task belongs to, empty for the tasks of the default project.
+	// A new task gets the next ID of its project
+	Project string
 	// Title is the short name of the task
 	Title string
 	// Description provides additional details about the task
 	Description string
-	// Status indicates the current state of the task
+	// Status indicates the current state of the task, changing along the task lifecycle
 	Status Status
 	// Priority indicates the importance level of the task
 	Priority *Priority
@@ -46,6 +82,32 @@ type Task struct {
 	UpdatedAt time.Time
 	// DueDate is the optional deadline for the task
 	DueDate *time.Time
+	// StartedAt stores when the task was first moved to IN_PROGRESS, cleared when it is moved back to NOT_STARTED
+	StartedAt *time.Time
+	// CompletedAt stores when the task was moved to COMPLETED, cleared when it is reopened
+	CompletedAt *time.Time
+	// Tags are the normalized labels of the task, sorted and without duplicates.
+	// In an update patch nil Tags leave the tags unchanged and empty Tags clear them
+	Tags []string
+	// ParentID is the ID of the task this task is a subtask of, nil for top level tasks.
+	// In an update patch nil ParentID leaves the parent unchanged and an empty ParentID moves the task to the top level
+	ParentID *string
+	// BlockedBy are the IDs of the tasks which must be finished before this task, sorted and without duplicates.
+	// A task having open blockers is BLOCKED unless it is cancelled, and resumed once they are finished.
+	// In an update patch nil BlockedBy leave the blockers unchanged and empty BlockedBy clear them
+	BlockedBy []string
+	// Recurrence is the schedule of a recurring task, nil for tasks which don't repeat.
+	// Completing a recurring task moves its recurrence to a new task for the next occurrence.
+	// In an update patch nil Recurrence leaves the schedule unchanged and a Recurrence without Frequency removes it
+	Recurrence *Recurrence
+	// OwnerID is the ID of the user who created the task, empty for tasks created without a user
+	OwnerID string
+	// Assignees are the IDs of the users working on the task, sorted and without duplicates.
+	// In an update patch nil Assignees leave the assignees unchanged and empty Assignees clear them
+	Assignees []string
+	// Version is incremented by the repository on every update, starting from 1 when the task is created.
+	// In an update patch a non-zero Version is the version the task is expected to have
+	Version int
 }
 
 // NewTask creates a new task with the specified properties
@@ -59,3 +121,25 @@ func NewTask(title, description string, priority *Priority, dueDate *time.Time)
 		DueDate:     dueDate,
 	}
 }
+
+func (t *Task) clone() *Task {
+	c := *t
+	c.Priority = clonePtr(t.Priority)
+	c.DueDate = clonePtr(t.DueDate)
+	c.StartedAt = clonePtr(t.StartedAt)
+	c.CompletedAt = clonePtr(t.CompletedAt)
+	c.Tags = slices.Clone(t.Tags)
+	c.ParentID = clonePtr(t.ParentID)
+	c.BlockedBy = slices.Clone(t.BlockedBy)
+	c.Recurrence = t.Recurrence.clone()
+	c.Assignees = slices.Clone(t.Assignees)
+	return &c
+}
+
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
@@ -61,3 +61,12 @@ func TestStatus(t *testing.T) {
 		assert.Equal(t, Status("COMPLETED"), StatusCompleted)
 	})
 }
+
+func TestPriority_Valid(t *testing.T) {
+	for _, priority := range Priorities() {
+		assert.True(t, priority.Valid(), priority)
+	}
+
+	assert.False(t, Priority("URGENT").Valid())
+	assert.False(t, Priority("").Valid())
+}
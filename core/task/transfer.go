@@ -0,0 +1,467 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	// ErrUnsupportedFormat is returned when tasks are imported or exported in an unknown format
+	ErrUnsupportedFormat = errors.New("unsupported format")
+	// ErrInvalidImport is returned when an import cannot be read or has rows which cannot be imported
+	ErrInvalidImport = errors.New("invalid import")
+)
+
+// Format is an encoding the tasks are imported from and exported to
+type Format string
+
+const (
+	// FormatCSV encodes a task per row of a CSV file, its first row naming the fields of the columns
+	FormatCSV Format = "csv"
+	// FormatNDJSON encodes a task per line as a JSON object
+	FormatNDJSON Format = "ndjson"
+	// FormatMarkdown encodes a task per item of a GitHub-style checklist, e.g. - [ ] Write report,
+	// with its subtasks indented below it
+	FormatMarkdown Format = "markdown"
+	// FormatICalendar encodes a task per VTODO component of an iCalendar file
+	FormatICalendar Format = "ical"
+)
+
+var formats = []Format{FormatCSV, FormatNDJSON, FormatMarkdown, FormatICalendar}
+
+// Formats returns every format the tasks are imported from and exported to
+func Formats() []Format {
+	return slices.Clone(formats)
+}
+
+// Field names of the imported and exported tasks, also used as CSV header
+const (
+	FieldID          = "id"
+	FieldProject     = "project"
+	FieldTitle       = "title"
+	FieldDescription = "description"
+	FieldStatus      = "status"
+	FieldPriority    = "priority"
+	FieldDueDate     = "dueDate"
+	FieldTags        = "tags"
+	FieldParentID    = "parentId"
+	FieldBlockedBy   = "blockedBy"
+	FieldRecurrence  = "recurrence"
+	FieldAssignees   = "assignees"
+	FieldOwnerID     = "ownerId"
+	FieldCreatedAt   = "createdAt"
+	FieldUpdatedAt   = "updatedAt"
+	FieldStartedAt   = "startedAt"
+	FieldCompletedAt = "completedAt"
+)
+
+var (
+	exportFields = []string{
+		FieldID, FieldProject, FieldTitle, FieldDescription, FieldStatus, FieldPriority, FieldDueDate, FieldTags,
+		FieldParentID, FieldBlockedBy, FieldRecurrence, FieldAssignees, FieldOwnerID,
+		FieldCreatedAt, FieldUpdatedAt, FieldStartedAt, FieldCompletedAt,
+	}
+	importFields = []string{
+		FieldID, FieldProject, FieldTitle, FieldDescription, FieldStatus, FieldPriority, FieldDueDate, FieldTags,
+		FieldParentID, FieldBlockedBy, FieldRecurrence, FieldAssignees,
+	}
+)
+
+// ImportFields returns the fields read from the imported tasks, the other exported fields are ignored
+func ImportFields() []string {
+	return slices.Clone(importFields)
+}
+
+// ImportOptions controls an import
+type ImportOptions struct {
+	// DryRun validates the rows without creating their tasks
+	DryRun bool
+	// Columns maps CSV header names to the fields they hold, in addition to the field names and their usual aliases
+	// such as name for title or due for dueDate
+	Columns map[string]string
+}
+
+// ImportRow is a task read from an import
+type ImportRow struct {
+	// Line is the line of the row in the imported file, starting at 1
+	Line int
+	// Ref is the ID of the task in the imported file, the parent and blockers of the other rows refer to it
+	Ref string
+	// Task is the task to create, or the created task once imported
+	Task *Task
+	// Err is the reason why the row cannot be imported
+	Err error
+}
+
+// ImportResult reports the rows of an import, none of them is imported if one of them has an error
+type ImportResult struct {
+	// Rows are the rows of the import in the order of the file
+	Rows []ImportRow
+	// Imported is true when the tasks of the rows were created
+	Imported bool
+}
+
+// Failed returns the rows which cannot be imported
+func (r *ImportResult) Failed() []ImportRow {
+	var failed []ImportRow
+	for _, row := range r.Rows {
+		if row.Err != nil {
+			failed = append(failed, row)
+		}
+	}
+
+	return failed
+}
+
+// ImportError is returned when rows of an import cannot be imported, it matches ErrInvalidImport
+type ImportError struct {
+	// Rows are the rows which cannot be imported
+	Rows []ImportRow
+}
+
+func (e *ImportError) Error() string {
+	messages := make([]string, 0, len(e.Rows))
+	for _, row := range e.Rows {
+		messages = append(messages, fmt.Sprintf("line %d: %v", row.Line, row.Err))
+	}
+
+	return fmt.Sprintf("%s: %s", ErrInvalidImport, strings.Join(messages, "; "))
+}
+
+func (e *ImportError) Is(target error) bool {
+	return target == ErrInvalidImport
+}
+
+// codec encodes the tasks to a format and decodes the rows of an import from it
+type codec interface {
+	encode(w io.Writer, tasks []*Task) error
+	decode(r io.Reader) ([]ImportRow, error)
+}
+
+func newCodec(format Format, columns map[string]string) (codec, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVCodec(columns)
+	case FormatNDJSON:
+		return ndjsonCodec{}, nil
+	case FormatMarkdown:
+		return markdownCodec{}, nil
+	case FormatICalendar:
+		return icalCodec{}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q, expected one of %v", ErrUnsupportedFormat, format, formats)
+	}
+}
+
+// parseStatus parses a status regardless of case, spaces and dashes, e.g. In progress
+func parseStatus(value string) (Status, error) {
+	status := Status(normalizeEnum(value))
+	if !status.Valid() {
+		return "", fmt.Errorf("%w: %q", ErrInvalidStatus, value)
+	}
+
+	return status, nil
+}
+
+// parsePriority parses a priority regardless of case
+func parsePriority(value string) (*Priority, error) {
+	priority := Priority(normalizeEnum(value))
+	if !priority.Valid() {
+		return nil, fmt.Errorf("unknown priority %q, expected one of %v", value, priorities)
+	}
+
+	return &priority, nil
+}
+
+func normalizeEnum(value string) string {
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToUpper(strings.TrimSpace(value)))
+}
+
+// parseDate parses a RFC 3339 time or a date, e.g. 2025-05-02
+func parseDate(value string) (*time.Time, error) {
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, strings.TrimSpace(value)); err == nil {
+			return &t, nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid date %q, expected a date such as 2025-05-02 or a RFC 3339 time", value)
+}
+
+// splitList splits a list of tags, IDs or users separated by commas or spaces, nil if the list is empty
+func splitList(value string) []string {
+	items := strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+	if len(items) == 0 {
+		return nil
+	}
+
+	return items
+}
+
+// record is a task as imported and exported, its values being kept as written until they are parsed
+type record struct {
+	ID          string   `json:"id,omitempty"`
+	Project     string   `json:"project,omitempty"`
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	Status      string   `json:"status,omitempty"`
+	Priority    string   `json:"priority,omitempty"`
+	DueDate     string   `json:"dueDate,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	ParentID    string   `json:"parentId,omitempty"`
+	BlockedBy   []string `json:"blockedBy,omitempty"`
+	Recurrence  string   `json:"recurrence,omitempty"`
+	Assignees   []string `json:"assignees,omitempty"`
+	OwnerID     string   `json:"ownerId,omitempty"`
+	CreatedAt   string   `json:"createdAt,omitempty"`
+	UpdatedAt   string   `json:"updatedAt,omitempty"`
+	StartedAt   string   `json:"startedAt,omitempty"`
+	CompletedAt string   `json:"completedAt,omitempty"`
+}
+
+func newRecord(task *Task) record {
+	r := record{
+		ID:          task.ID,
+		Project:     task.Project,
+		Title:       task.Title,
+		Description: task.Description,
+		Status:      string(task.Status),
+		DueDate:     formatOptionalTime(task.DueDate),
+		Tags:        task.Tags,
+		BlockedBy:   task.BlockedBy,
+		Assignees:   task.Assignees,
+		OwnerID:     task.OwnerID,
+		CreatedAt:   formatOptionalTime(&task.CreatedAt),
+		UpdatedAt:   formatOptionalTime(&task.UpdatedAt),
+		StartedAt:   formatOptionalTime(task.StartedAt),
+		CompletedAt: formatOptionalTime(task.CompletedAt),
+	}
+	if task.Priority != nil {
+		r.Priority = string(*task.Priority)
+	}
+	if task.ParentID != nil {
+		r.ParentID = *task.ParentID
+	}
+	if task.Recurrence != nil {
+		r.Recurrence = task.Recurrence.String()
+	}
+
+	return r
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
+
+// value returns the value of a field formatted as a CSV cell, lists being separated by commas
+func (r record) value(field string) string {
+	switch field {
+	case FieldID:
+		return r.ID
+	case FieldProject:
+		return r.Project
+	case FieldTitle:
+		return r.Title
+	case FieldDescription:
+		return r.Description
+	case FieldStatus:
+		return r.Status
+	case FieldPriority:
+		return r.Priority
+	case FieldDueDate:
+		return r.DueDate
+	case FieldTags:
+		return strings.Join(r.Tags, ",")
+	case FieldParentID:
+		return r.ParentID
+	case FieldBlockedBy:
+		return strings.Join(r.BlockedBy, ",")
+	case FieldRecurrence:
+		return r.Recurrence
+	case FieldAssignees:
+		return strings.Join(r.Assignees, ",")
+	case FieldOwnerID:
+		return r.OwnerID
+	case FieldCreatedAt:
+		return r.CreatedAt
+	case FieldUpdatedAt:
+		return r.UpdatedAt
+	case FieldStartedAt:
+		return r.StartedAt
+	case FieldCompletedAt:
+		return r.CompletedAt
+	default:
+		return ""
+	}
+}
+
+// set sets an imported field from a CSV cell, the other fields are ignored
+func (r *record) set(field, value string) {
+	value = strings.TrimSpace(value)
+	switch field {
+	case FieldID:
+		r.ID = value
+	case FieldProject:
+		r.Project = value
+	case FieldTitle:
+		r.Title = value
+	case FieldDescription:
+		r.Description = value
+	case FieldStatus:
+		r.Status = value
+	case FieldPriority:
+		r.Priority = value
+	case FieldDueDate:
+		r.DueDate = value
+	case FieldTags:
+		r.Tags = splitList(value)
+	case FieldParentID:
+		r.ParentID = value
+	case FieldBlockedBy:
+		r.BlockedBy = splitList(value)
+	case FieldRecurrence:
+		r.Recurrence = value
+	case FieldAssignees:
+		r.Assignees = splitList(value)
+	}
+}
+
+// row parses the record into the row of an import, setting its error if a value is invalid
+func (r record) row(line int) ImportRow {
+	row := ImportRow{Line: line, Ref: strings.TrimSpace(r.ID)}
+	task, err := r.task()
+	if err != nil {
+		row.Err = err
+		return row
+	}
+
+	row.Task = task
+	return row
+}
+
+func (r record) task() (*Task, error) {
+	task := &Task{
+		Project:     strings.TrimSpace(r.Project),
+		Title:       strings.TrimSpace(r.Title),
+		Description: r.Description,
+		BlockedBy:   normalizeBlockers(r.BlockedBy),
+		Assignees:   normalizeAssignees(r.Assignees),
+	}
+	if task.Title == "" {
+		return nil, errors.New("title is required")
+	}
+	if utf8.RuneCountInString(task.Title) > MaxTitleLength {
+		return nil, fmt.Errorf("title must be at most %d characters", MaxTitleLength)
+	}
+	if utf8.RuneCountInString(task.Description) > MaxDescriptionLength {
+		return nil, fmt.Errorf("description must be at most %d characters", MaxDescriptionLength)
+	}
+	for _, id := range task.Assignees {
+		if utf8.RuneCountInString(id) > MaxUserIDLength {
+			return nil, fmt.Errorf("assignee %q must be at most %d characters", id, MaxUserIDLength)
+		}
+	}
+
+	var err error
+	if r.Status != "" {
+		if task.Status, err = parseStatus(r.Status); err != nil {
+			return nil, err
+		}
+	}
+
+	if r.Priority != "" {
+		if task.Priority, err = parsePriority(r.Priority); err != nil {
+			return nil, err
+		}
+	}
+
+	if r.DueDate != "" {
+		if task.DueDate, err = parseDate(r.DueDate); err != nil {
+			return nil, err
+		}
+		if task.DueDate.Before(MinDueDate) || !task.DueDate.Before(MaxDueDate) {
+			return nil, fmt.Errorf("due date %q must be between %s and %s", r.DueDate, MinDueDate.Format(time.DateOnly), MaxDueDate.Format(time.DateOnly))
+		}
+	}
+
+	if task.Tags, err = NormalizeTags(r.Tags); err != nil {
+		return nil, err
+	}
+
+	if parent := strings.TrimSpace(r.ParentID); parent != "" {
+		task.ParentID = &parent
+	}
+
+	if r.Recurrence != "" {
+		if task.Recurrence, err = ParseRecurrence(r.Recurrence); err != nil {
+			return nil, err
+		}
+	}
+
+	return task, nil
+}
+
+// orderRows returns the indexes of the rows ordered so that every row comes after the rows its parent and blockers
+// refer to, and otherwise in the order of the file. The rows referring to each other in a cycle get an error
+func orderRows(rows []ImportRow) []int {
+	refs := make(map[string]int)
+	for i, row := range rows {
+		if row.Ref == "" || row.Err != nil {
+			continue
+		}
+		if first, ok := refs[row.Ref]; ok {
+			rows[i].Err = fmt.Errorf("duplicate id %s, already used on line %d", row.Ref, rows[first].Line)
+			continue
+		}
+		refs[row.Ref] = i
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make([]int, len(rows))
+	order := make([]int, 0, len(rows))
+
+	var visit func(i int)
+	visit = func(i int) {
+		state[i] = visiting
+		task := rows[i].Task
+		deps := slices.Clone(task.BlockedBy)
+		if task.ParentID != nil {
+			deps = append(deps, *task.ParentID)
+		}
+		for _, dep := range deps {
+			j, ok := refs[dep]
+			switch {
+			case !ok:
+			case j == i || state[j] == visiting:
+				rows[i].Err = fmt.Errorf("cyclic reference to %s", dep)
+			case state[j] == unvisited:
+				visit(j)
+			}
+		}
+
+		state[i] = visited
+		order = append(order, i)
+	}
+
+	for i, row := range rows {
+		if row.Task != nil && state[i] == unvisited {
+			visit(i)
+		}
+	}
+
+	return order
+}
@@ -0,0 +1,173 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/utsabbera/task-master/pkg/util"
+)
+
+//go:generate mockgen -destination=transfer_service_mock.go -package=task . TransferService
+
+// TransferService defines the interface for exporting the tasks to a file and importing them from one
+type TransferService interface {
+	// Export writes the tasks matching the filter and sort of the options in the given format, ignoring their pagination
+	// Returns ErrUnsupportedFormat if the format is unknown, or ErrInvalidListOptions if the options are invalid
+	Export(ctx context.Context, w io.Writer, format Format, opts ListOptions) error
+
+	// Import reads the rows of a file in the given format and creates their tasks within a single batch, all of them
+	// or none. The parent and blockers of a row refer to the IDs of the other rows of the file or else to existing tasks,
+	// the rows being created after the rows they refer to. A dry run validates each row on its own against the current tasks
+	// without creating any of them, and returns the result along with the errors of all its rows.
+	// Returns ErrUnsupportedFormat if the format is unknown, ErrInvalidImport if the file cannot be read or has no row,
+	// or the result along with an *ImportError matching ErrInvalidImport if any row cannot be imported
+	Import(ctx context.Context, r io.Reader, format Format, opts ImportOptions) (*ImportResult, error)
+}
+
+type transferService struct {
+	tasks Service
+	clock util.Clock
+}
+
+// NewTransferService creates a new transfer service reading and creating the tasks with the given task service
+func NewTransferService(tasks Service, clock util.Clock) TransferService {
+	return &transferService{tasks: tasks, clock: clock}
+}
+
+func (s *transferService) Export(ctx context.Context, w io.Writer, format Format, opts ListOptions) error {
+	c, err := newCodec(format, nil)
+	if err != nil {
+		return fmt.Errorf("error exporting tasks: %w", err)
+	}
+
+	opts.Limit, opts.Cursor = 0, ""
+	page, err := s.tasks.List(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("error exporting tasks: %w", err)
+	}
+
+	if err := c.encode(w, page.Tasks); err != nil {
+		return fmt.Errorf("error exporting tasks: %w", err)
+	}
+
+	return nil
+}
+
+func (s *transferService) Import(ctx context.Context, r io.Reader, format Format, opts ImportOptions) (*ImportResult, error) {
+	c, err := newCodec(format, opts.Columns)
+	if err != nil {
+		return nil, fmt.Errorf("error importing tasks: %w", err)
+	}
+
+	rows, err := c.decode(r)
+	if err != nil {
+		return nil, fmt.Errorf("error importing tasks: %w", err)
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("error importing tasks: %w: no task found", ErrInvalidImport)
+	}
+
+	result := &ImportResult{Rows: rows}
+	order := orderRows(rows)
+	if opts.DryRun {
+		if err := s.validate(ctx, rows, order); err != nil {
+			return nil, fmt.Errorf("error importing tasks: %w", err)
+		}
+		return result, nil
+	}
+
+	if failed := result.Failed(); len(failed) > 0 {
+		return result, fmt.Errorf("error importing tasks: %w", &ImportError{Rows: failed})
+	}
+
+	ops := make([]Operation, 0, len(order))
+	for _, i := range order {
+		ops = append(ops, Operation{Type: ChangeCreate, Ref: rows[i].Ref, Task: rows[i].Task})
+	}
+
+	changes, err := s.tasks.Batch(ctx, ops)
+	var batchErr *BatchError
+	if errors.As(err, &batchErr) && batchErr.Index < len(order) {
+		rows[order[batchErr.Index]].Err = batchErr.Err
+		return result, fmt.Errorf("error importing tasks: %w", &ImportError{Rows: result.Failed()})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error importing tasks: %w", err)
+	}
+
+	for k, i := range order {
+		rows[i].Task = changes[k].After
+	}
+	result.Imported = true
+
+	return result, nil
+}
+
+// validate stages the tasks of the rows one at a time in the given order, so that each row is checked on its own
+// and every row which cannot be imported gets its error, including the rows referring to a row which cannot be imported
+func (s *transferService) validate(ctx context.Context, rows []ImportRow, order []int) error {
+	var stage Service = NewStage(s.tasks, s.clock)
+	if _, ok := UserFromContext(ctx); ok {
+		stage = NewAccessControl(stage)
+	}
+
+	refs := make(map[string]int)
+	for i, row := range rows {
+		if _, ok := refs[row.Ref]; row.Ref != "" && !ok {
+			refs[row.Ref] = i
+		}
+	}
+
+	ids := make(map[string]string)
+	for _, i := range order {
+		row := &rows[i]
+		if row.Err != nil {
+			continue
+		}
+
+		if row.Err = failedReference(rows, refs, row.Task); row.Err != nil {
+			continue
+		}
+
+		task := row.Task.clone()
+		task.ParentID = resolveID(ids, task.ParentID)
+		task.BlockedBy = resolveIDs(ids, task.BlockedBy)
+
+		changes, err := stage.Batch(ctx, []Operation{{Type: ChangeCreate, Task: task}})
+		var batchErr *BatchError
+		if errors.As(err, &batchErr) {
+			row.Err = batchErr.Err
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		row.Task = changes[0].After
+		if row.Ref != "" {
+			ids[row.Ref] = row.Task.ID
+		}
+	}
+
+	return nil
+}
+
+// failedReference returns an error if the parent or a blocker of the task is a row which cannot be imported
+func failedReference(rows []ImportRow, refs map[string]int, task *Task) error {
+	deps := slices.Clone(task.BlockedBy)
+	if task.ParentID != nil {
+		deps = append(deps, *task.ParentID)
+	}
+
+	for _, dep := range deps {
+		if j, ok := refs[dep]; ok && rows[j].Err != nil {
+			return fmt.Errorf("%s on line %d cannot be imported", dep, rows[j].Line)
+		}
+	}
+
+	return nil
+}
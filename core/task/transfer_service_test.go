@@ -0,0 +1,218 @@
+package task
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/pkg/util"
+)
+
+func TestTransferService_Export(t *testing.T) {
+	t.Run("should write every task matching the options", func(t *testing.T) {
+		f := newFixture(t)
+		transfer, service := NewTransferService(f.service, f.clock), f.service
+		for _, task := range []*Task{
+			{Title: "Write report"},
+			{Title: "Review report"},
+			{Title: "Archive report", Status: StatusCompleted},
+		} {
+			require.NoError(t, service.Create(context.Background(), task))
+		}
+		var buf bytes.Buffer
+
+		err := transfer.Export(context.Background(), &buf, FormatMarkdown, ListOptions{
+			Filter: Filter{Statuses: []Status{StatusNotStarted}},
+			Sort:   []Sort{{Field: SortByTitle}},
+			Limit:  1,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "- [ ] Review report\n- [ ] Write report\n", buf.String())
+	})
+
+	t.Run("should return error when format is unsupported", func(t *testing.T) {
+		f := newFixture(t)
+		transfer := NewTransferService(f.service, f.clock)
+
+		err := transfer.Export(context.Background(), &bytes.Buffer{}, "xlsx", ListOptions{})
+
+		assert.ErrorIs(t, err, ErrUnsupportedFormat)
+	})
+}
+
+func TestTransferService_Import(t *testing.T) {
+	input := strings.Join([]string{
+		"id,title,parent,blocked by,priority",
+		"b,Review report,,a,",
+		"a,Write report,,,high",
+		"c,Add charts,a,,",
+	}, "\n")
+
+	t.Run("should create the tasks after the tasks they refer to", func(t *testing.T) {
+		f := newFixture(t)
+		transfer, service := NewTransferService(f.service, f.clock), f.service
+
+		result, err := transfer.Import(context.Background(), strings.NewReader(input), FormatCSV, ImportOptions{})
+
+		require.NoError(t, err)
+		assert.True(t, result.Imported)
+		assert.Equal(t, []string{"TASK-000002", "TASK-000001", "TASK-000003"},
+			util.Map(result.Rows, func(r ImportRow) string { return r.Task.ID }))
+		assert.Equal(t, []string{"b", "a", "c"}, util.Map(result.Rows, func(r ImportRow) string { return r.Ref }))
+
+		review, err := service.Get(context.Background(), "TASK-000002")
+		require.NoError(t, err)
+		assert.Equal(t, StatusBlocked, review.Status)
+		assert.Equal(t, []string{"TASK-000001"}, review.BlockedBy)
+
+		charts, err := service.Get(context.Background(), "TASK-000003")
+		require.NoError(t, err)
+		assert.Equal(t, util.Ptr("TASK-000001"), charts.ParentID)
+	})
+
+	t.Run("should create the tasks of the VTODO components of an iCalendar file", func(t *testing.T) {
+		f := newFixture(t)
+		transfer, service := NewTransferService(f.service, f.clock), f.service
+		input := strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"BEGIN:VTODO",
+			"UID:outline",
+			"SUMMARY:Draft outline",
+			"RELATED-TO:report",
+			"END:VTODO",
+			"BEGIN:VTODO",
+			"UID:report",
+			"SUMMARY:Write report",
+			"PRIORITY:1",
+			"DUE:20250502T170000Z",
+			"END:VTODO",
+			"END:VCALENDAR",
+		}, "\r\n")
+
+		result, err := transfer.Import(context.Background(), strings.NewReader(input), FormatICalendar, ImportOptions{})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"TASK-000002", "TASK-000001"}, util.Map(result.Rows, func(r ImportRow) string { return r.Task.ID }))
+
+		outline, err := service.Get(context.Background(), "TASK-000002")
+		require.NoError(t, err)
+		assert.Equal(t, util.Ptr("TASK-000001"), outline.ParentID)
+	})
+
+	t.Run("should validate rows without creating tasks on dry run", func(t *testing.T) {
+		f := newFixture(t)
+		transfer, service := NewTransferService(f.service, f.clock), f.service
+
+		result, err := transfer.Import(asUser("alice", RoleMember), strings.NewReader(input), FormatCSV, ImportOptions{DryRun: true})
+
+		require.NoError(t, err)
+		assert.False(t, result.Imported)
+		assert.Empty(t, result.Failed())
+		assert.Equal(t, "alice", result.Rows[0].Task.OwnerID)
+		assert.True(t, strings.HasPrefix(result.Rows[0].Task.ID, StagedIDPrefix))
+
+		page, err := service.List(context.Background(), ListOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, page.Tasks)
+	})
+
+	t.Run("should report the rows which cannot be imported", func(t *testing.T) {
+		input := strings.Join([]string{
+			"id,title,parent,status",
+			"a,Write report,,",
+			"b,,,",
+			"c,Review report,TASK-9,",
+			"d,Add charts,a,done",
+		}, "\n")
+
+		for _, dryRun := range []bool{false, true} {
+			f := newFixture(t)
+			transfer, service := NewTransferService(f.service, f.clock), f.service
+
+			result, err := transfer.Import(context.Background(), strings.NewReader(input), FormatCSV, ImportOptions{DryRun: dryRun})
+
+			if dryRun {
+				require.NoError(t, err)
+				assert.Equal(t, []int{3, 4, 5}, util.Map(result.Failed(), func(r ImportRow) int { return r.Line }))
+			} else {
+				var importErr *ImportError
+				require.ErrorAs(t, err, &importErr)
+				assert.ErrorIs(t, err, ErrInvalidImport)
+				assert.Len(t, importErr.Rows, 2)
+				assert.Equal(t, []int{3, 5}, util.Map(result.Failed(), func(r ImportRow) int { return r.Line }))
+			}
+			assert.False(t, result.Imported)
+
+			page, err := service.List(context.Background(), ListOptions{})
+			require.NoError(t, err)
+			assert.Empty(t, page.Tasks)
+		}
+	})
+
+	t.Run("should report the row whose task cannot be created", func(t *testing.T) {
+		f := newFixture(t)
+		transfer, service := NewTransferService(f.service, f.clock), f.service
+		input := "title,parentId\nWrite report,\nReview report,TASK-9\n"
+
+		result, err := transfer.Import(context.Background(), strings.NewReader(input), FormatCSV, ImportOptions{})
+
+		assert.ErrorIs(t, err, ErrInvalidImport)
+		require.Len(t, result.Failed(), 1)
+		assert.Equal(t, 3, result.Failed()[0].Line)
+		assert.ErrorIs(t, result.Failed()[0].Err, ErrParentNotFound)
+
+		page, err := service.List(context.Background(), ListOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, page.Tasks)
+	})
+
+	t.Run("should report every row whose task cannot be created on dry run", func(t *testing.T) {
+		f := newFixture(t)
+		transfer, service := NewTransferService(f.service, f.clock), f.service
+		input := strings.Join([]string{
+			"id,title,parent,blocked by",
+			"a,Write report,TASK-9,",
+			"b,Review report,,TASK-8",
+			"c,Add charts,a,",
+			"d,Send report,,c",
+			"e,Archive report,,",
+		}, "\n")
+
+		result, err := transfer.Import(context.Background(), strings.NewReader(input), FormatCSV, ImportOptions{DryRun: true})
+
+		require.NoError(t, err)
+		failed := result.Failed()
+		assert.Equal(t, []int{2, 3, 4, 5}, util.Map(failed, func(r ImportRow) int { return r.Line }))
+		assert.ErrorIs(t, failed[0].Err, ErrParentNotFound)
+		assert.ErrorIs(t, failed[1].Err, ErrBlockerNotFound)
+		assert.EqualError(t, failed[2].Err, "a on line 2 cannot be imported")
+		assert.EqualError(t, failed[3].Err, "c on line 4 cannot be imported")
+		assert.True(t, strings.HasPrefix(result.Rows[4].Task.ID, StagedIDPrefix))
+
+		page, err := service.List(context.Background(), ListOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, page.Tasks)
+	})
+
+	t.Run("should return error when viewer imports tasks", func(t *testing.T) {
+		f := newFixture(t)
+		transfer := NewTransferService(f.service, f.clock)
+
+		_, err := transfer.Import(asUser("bob", RoleViewer), strings.NewReader("- [ ] Write report"), FormatMarkdown, ImportOptions{DryRun: true})
+
+		assert.ErrorIs(t, err, ErrForbidden)
+	})
+
+	t.Run("should return error when file has no task", func(t *testing.T) {
+		f := newFixture(t)
+		transfer := NewTransferService(f.service, f.clock)
+
+		_, err := transfer.Import(context.Background(), strings.NewReader("# Notes\n"), FormatMarkdown, ImportOptions{})
+
+		assert.ErrorIs(t, err, ErrInvalidImport)
+	})
+}
@@ -0,0 +1,112 @@
+package task
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/pkg/util"
+)
+
+func TestRecord_Task(t *testing.T) {
+	t.Run("should parse values regardless of case and layout", func(t *testing.T) {
+		rec := record{
+			Title:      "  Write report ",
+			Status:     "in progress",
+			Priority:   "high",
+			DueDate:    "2025-05-02",
+			Tags:       []string{"Work", "urgent", "work"},
+			ParentID:   " TASK-1 ",
+			BlockedBy:  []string{"TASK-3", "TASK-2", ""},
+			Recurrence: "FREQ=WEEKLY",
+			Assignees:  []string{"bob", "alice"},
+		}
+
+		task, err := rec.task()
+
+		require.NoError(t, err)
+		assert.Equal(t, &Task{
+			Title:      "Write report",
+			Status:     StatusInProgress,
+			Priority:   util.Ptr(PriorityHigh),
+			DueDate:    util.Ptr(time.Date(2025, 5, 2, 0, 0, 0, 0, time.UTC)),
+			Tags:       []string{"urgent", "work"},
+			ParentID:   util.Ptr("TASK-1"),
+			BlockedBy:  []string{"TASK-2", "TASK-3"},
+			Recurrence: &Recurrence{Frequency: FrequencyWeekly},
+			Assignees:  []string{"alice", "bob"},
+		}, task)
+	})
+
+	t.Run("should return error when a value is invalid", func(t *testing.T) {
+		for name, rec := range map[string]record{
+			"missing title":        {Title: " "},
+			"unknown status":       {Title: "Write report", Status: "done"},
+			"unknown priority":     {Title: "Write report", Priority: "urgent"},
+			"invalid due date":     {Title: "Write report", DueDate: "tomorrow"},
+			"invalid tag":          {Title: "Write report", Tags: []string{"#"}},
+			"invalid recurrence":   {Title: "Write report", Recurrence: "FREQ=HOURLY"},
+			"too long title":       {Title: strings.Repeat("é", MaxTitleLength+1)},
+			"too long description": {Title: "Write report", Description: strings.Repeat("a", MaxDescriptionLength+1)},
+			"too long assignee":    {Title: "Write report", Assignees: []string{strings.Repeat("a", MaxUserIDLength+1)}},
+			"too early due date":   {Title: "Write report", DueDate: "1969-12-31"},
+			"too late due date":    {Title: "Write report", DueDate: "3000-01-01"},
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, err := rec.task()
+				assert.Error(t, err)
+			})
+		}
+	})
+}
+
+func TestOrderRows(t *testing.T) {
+	row := func(line int, ref string, parent *string, blockers ...string) ImportRow {
+		return ImportRow{Line: line, Ref: ref, Task: &Task{Title: ref, ParentID: parent, BlockedBy: blockers}}
+	}
+
+	t.Run("should order rows after the rows they refer to", func(t *testing.T) {
+		rows := []ImportRow{
+			row(2, "A", util.Ptr("B")),
+			row(3, "B", nil, "C", "TASK-9"),
+			row(4, "C", nil),
+			row(5, "", nil),
+		}
+
+		order := orderRows(rows)
+
+		assert.Equal(t, []int{2, 1, 0, 3}, order)
+		assert.Empty(t, (&ImportResult{Rows: rows}).Failed())
+	})
+
+	t.Run("should flag duplicate ids and cyclic references", func(t *testing.T) {
+		rows := []ImportRow{
+			row(2, "A", nil, "B"),
+			row(3, "B", util.Ptr("A")),
+			row(4, "A", nil),
+			row(5, "C", util.Ptr("C")),
+		}
+
+		orderRows(rows)
+
+		failed := (&ImportResult{Rows: rows}).Failed()
+		require.Len(t, failed, 3)
+		assert.EqualError(t, failed[0].Err, "cyclic reference to A")
+		assert.EqualError(t, failed[1].Err, "duplicate id A, already used on line 2")
+		assert.EqualError(t, failed[2].Err, "cyclic reference to C")
+	})
+}
+
+func TestImportError(t *testing.T) {
+	t.Run("should list the errors of the rows and match ErrInvalidImport", func(t *testing.T) {
+		err := &ImportError{Rows: []ImportRow{
+			{Line: 2, Err: ErrInvalidStatus},
+			{Line: 4, Err: ErrParentNotFound},
+		}}
+
+		assert.EqualError(t, err, "invalid import: line 2: invalid status; line 4: parent task not found")
+		assert.ErrorIs(t, err, ErrInvalidImport)
+	})
+}
@@ -0,0 +1,156 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrViewNotFound is returned when a saved view doesn't exist
+	ErrViewNotFound = errors.New("view not found")
+	// ErrViewExists is returned when a view is saved with the name of another view
+	ErrViewExists = errors.New("view already exists")
+	// ErrInvalidView is returned when a view has an invalid name or no filter
+	ErrInvalidView = errors.New("invalid view")
+)
+
+// MaxViewNameLength is the maximum number of characters of the name of a view
+const MaxViewNameLength = 50
+
+var viewNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// View is a named filter expression whose tasks are listed on demand
+type View struct {
+	// Name identifies the view, e.g. open-backend-bugs
+	Name string
+	// Description provides additional details about the view
+	Description string
+	// Filter is the filter expression of the tasks of the view
+	Filter string
+	// Sort orders the tasks of the view unless the list is sorted otherwise
+	Sort []Sort
+	// OwnerID is the ID of the user who saved the view, empty when saved without authentication
+	OwnerID string
+	// CreatedAt stores when the view was saved
+	CreatedAt time.Time
+	// UpdatedAt stores when the view was last modified
+	UpdatedAt time.Time
+}
+
+// NormalizeViewName returns the name in lower case
+// Returns ErrInvalidView if it isn't made of letters, digits, dashes and underscores starting with a letter or a digit,
+// or is too long
+func NormalizeViewName(name string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(name))
+	if len(normalized) > MaxViewNameLength || !viewNamePattern.MatchString(normalized) {
+		return "", fmt.Errorf("%w: name %q must be 1 to %d letters, digits, dashes and underscores", ErrInvalidView, name, MaxViewNameLength)
+	}
+
+	return normalized, nil
+}
+
+// FormatSort formats sort fields the way ParseSort parses them, e.g. -priority,dueDate
+func FormatSort(sorts []Sort) string {
+	fields := make([]string, 0, len(sorts))
+	for _, sort := range sorts {
+		field := string(sort.Field)
+		if sort.Descending {
+			field = "-" + field
+		}
+		fields = append(fields, field)
+	}
+
+	return strings.Join(fields, ",")
+}
+
+//go:generate mockgen -destination=view_repository_mock.go -package=task . ViewRepository
+
+// ViewRepository defines the interface for storing the saved views
+type ViewRepository interface {
+	// Create stores a new view
+	// Returns ErrViewExists if a view has the same name
+	Create(ctx context.Context, view *View) error
+
+	// Get returns a view by its name
+	// Returns ErrViewNotFound if the view doesn't exist
+	Get(ctx context.Context, name string) (*View, error)
+
+	// List returns every view sorted by name
+	List(ctx context.Context) ([]*View, error)
+
+	// Delete removes a view
+	// Returns ErrViewNotFound if the view doesn't exist
+	Delete(ctx context.Context, name string) error
+}
+
+// MemoryViewRepository is an in-memory implementation of ViewRepository
+type MemoryViewRepository struct {
+	views map[string]*View
+	mu    sync.RWMutex
+}
+
+// NewMemoryViewRepository creates a new empty memory view repository
+func NewMemoryViewRepository() *MemoryViewRepository {
+	return &MemoryViewRepository{views: make(map[string]*View)}
+}
+
+func (r *MemoryViewRepository) Create(_ context.Context, view *View) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.views[view.Name]; ok {
+		return fmt.Errorf("%w: %s", ErrViewExists, view.Name)
+	}
+
+	stored := *view
+	stored.Sort = slices.Clone(view.Sort)
+	r.views[view.Name] = &stored
+	return nil
+}
+
+func (r *MemoryViewRepository) Get(_ context.Context, name string) (*View, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	view, ok := r.views[name]
+	if !ok {
+		return nil, ErrViewNotFound
+	}
+
+	found := *view
+	found.Sort = slices.Clone(view.Sort)
+	return &found, nil
+}
+
+func (r *MemoryViewRepository) List(_ context.Context) ([]*View, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	views := make([]*View, 0, len(r.views))
+	for _, view := range r.views {
+		found := *view
+		found.Sort = slices.Clone(view.Sort)
+		views = append(views, &found)
+	}
+
+	slices.SortFunc(views, func(a, b *View) int { return strings.Compare(a.Name, b.Name) })
+	return views, nil
+}
+
+func (r *MemoryViewRepository) Delete(_ context.Context, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.views[name]; !ok {
+		return ErrViewNotFound
+	}
+
+	delete(r.views, name)
+	return nil
+}
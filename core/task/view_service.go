@@ -0,0 +1,130 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/utsabbera/task-master/pkg/util"
+)
+
+//go:generate mockgen -destination=view_service_mock.go -package=task . ViewService
+
+// ViewService defines the interface for managing the saved views and listing their tasks
+type ViewService interface {
+	// Create validates and stores a new view, normalizing its name and setting its owner and timestamps
+	// Returns ErrInvalidView if the name is invalid or the filter is empty, ErrInvalidExpression if the filter
+	// cannot be parsed, or ErrViewExists if the name is taken
+	Create(ctx context.Context, view *View) error
+
+	// Get returns a view by its name
+	// Returns ErrViewNotFound if the view doesn't exist
+	Get(ctx context.Context, name string) (*View, error)
+
+	// List returns every view sorted by name
+	List(ctx context.Context) ([]*View, error)
+
+	// Delete removes a view
+	// Returns ErrViewNotFound if the view doesn't exist
+	Delete(ctx context.Context, name string) error
+
+	// ListTasks lists the tasks matching the filter of a view and the filter of the options,
+	// sorted by the sort of the view unless the options are sorted
+	// Returns ErrViewNotFound if the view doesn't exist
+	ListTasks(ctx context.Context, name string, opts ListOptions) (*Page, error)
+}
+
+type viewService struct {
+	repo  ViewRepository
+	tasks Service
+	clock util.Clock
+}
+
+// NewViewService creates a new view service storing the views in the given repository
+// and listing their tasks with the given task service
+func NewViewService(repo ViewRepository, tasks Service, clock util.Clock) ViewService {
+	return &viewService{repo: repo, tasks: tasks, clock: clock}
+}
+
+func (s *viewService) Create(ctx context.Context, view *View) error {
+	name, err := NormalizeViewName(view.Name)
+	if err != nil {
+		return fmt.Errorf("error creating view: %w", err)
+	}
+
+	if strings.TrimSpace(view.Filter) == "" {
+		return fmt.Errorf("error creating view: %w: filter is required", ErrInvalidView)
+	}
+
+	if _, err := ParseExpression(view.Filter); err != nil {
+		return fmt.Errorf("error creating view: %w", err)
+	}
+
+	if err := (ListOptions{Sort: view.Sort}).Validate(); err != nil {
+		return fmt.Errorf("error creating view: %w", err)
+	}
+
+	if user, ok := UserFromContext(ctx); ok {
+		view.OwnerID = user.ID
+	}
+
+	now := s.clock.Now()
+	view.Name = name
+	view.CreatedAt = now
+	view.UpdatedAt = now
+
+	if err := s.repo.Create(ctx, view); err != nil {
+		return fmt.Errorf("error creating view: %w", err)
+	}
+
+	return nil
+}
+
+func (s *viewService) Get(ctx context.Context, name string) (*View, error) {
+	view, err := s.repo.Get(ctx, strings.ToLower(name))
+	if err != nil {
+		return nil, fmt.Errorf("error finding view: %w", err)
+	}
+
+	return view, nil
+}
+
+func (s *viewService) List(ctx context.Context) ([]*View, error) {
+	views, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing views: %w", err)
+	}
+
+	return views, nil
+}
+
+func (s *viewService) Delete(ctx context.Context, name string) error {
+	if err := s.repo.Delete(ctx, strings.ToLower(name)); err != nil {
+		return fmt.Errorf("error deleting view: %w", err)
+	}
+
+	return nil
+}
+
+func (s *viewService) ListTasks(ctx context.Context, name string, opts ListOptions) (*Page, error) {
+	view, err := s.repo.Get(ctx, strings.ToLower(name))
+	if err != nil {
+		return nil, fmt.Errorf("error listing view tasks: %w", err)
+	}
+
+	expression, err := ParseExpression(view.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("error listing view tasks: %w", err)
+	}
+
+	if opts.Filter.Expression != nil {
+		expression = expression.And(opts.Filter.Expression)
+	}
+	opts.Filter.Expression = expression
+
+	if len(opts.Sort) == 0 {
+		opts.Sort = view.Sort
+	}
+
+	return s.tasks.List(ctx, opts)
+}
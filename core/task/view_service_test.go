@@ -0,0 +1,142 @@
+package task
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/pkg/idgen"
+	"github.com/utsabbera/task-master/pkg/util"
+	"go.uber.org/mock/gomock"
+)
+
+func TestViewService_Create(t *testing.T) {
+	t.Run("should normalize name and set owner and timestamps", func(t *testing.T) {
+		f := newFixture(t)
+		views := NewViewService(NewMemoryViewRepository(), f.service, f.clock)
+		view := &View{Name: "Open-Bugs", Filter: "status != COMPLETED and tag:bug"}
+
+		require.NoError(t, views.Create(asUser("alice", RoleMember), view))
+
+		assert.Equal(t, "open-bugs", view.Name)
+		assert.Equal(t, "alice", view.OwnerID)
+		assert.False(t, view.CreatedAt.IsZero())
+		assert.Equal(t, view.CreatedAt, view.UpdatedAt)
+
+		found, err := views.Get(context.Background(), "OPEN-BUGS")
+		require.NoError(t, err)
+		assert.Equal(t, view, found)
+	})
+
+	t.Run("should return error when filter is missing or invalid", func(t *testing.T) {
+		f := newFixture(t)
+		views := NewViewService(NewMemoryViewRepository(), f.service, f.clock)
+
+		err := views.Create(context.Background(), &View{Name: "open-bugs", Filter: " "})
+		assert.ErrorIs(t, err, ErrInvalidView)
+
+		err = views.Create(context.Background(), &View{Name: "open-bugs", Filter: "status = DONE"})
+		assert.ErrorIs(t, err, ErrInvalidExpression)
+
+		err = views.Create(context.Background(), &View{Name: "open-bugs", Filter: "tag:bug", Sort: []Sort{{Field: "size"}}})
+		assert.ErrorIs(t, err, ErrInvalidListOptions)
+	})
+
+	t.Run("should return error when name is taken", func(t *testing.T) {
+		f := newFixture(t)
+		views := NewViewService(NewMemoryViewRepository(), f.service, f.clock)
+		require.NoError(t, views.Create(context.Background(), &View{Name: "open-bugs", Filter: "tag:bug"}))
+
+		err := views.Create(context.Background(), &View{Name: "Open-Bugs", Filter: "tag:other"})
+
+		assert.ErrorIs(t, err, ErrViewExists)
+	})
+}
+
+func TestViewService_ListTasks(t *testing.T) {
+	createTasks := func(t *testing.T, service Service) {
+		t.Helper()
+
+		due := time.Date(2025, 5, 2, 9, 0, 0, 0, time.UTC)
+		for _, task := range []*Task{
+			{Title: "Fix login", Priority: util.Ptr(PriorityLow), Tags: []string{"backend"}, DueDate: &due},
+			{Title: "Fix signup", Priority: util.Ptr(PriorityHigh), Tags: []string{"backend"}},
+			{Title: "Write docs", Priority: util.Ptr(PriorityHigh), Tags: []string{"docs"}},
+			{Title: "Fix logout", Status: StatusCompleted, Tags: []string{"backend"}},
+		} {
+			require.NoError(t, service.Create(context.Background(), task))
+		}
+	}
+
+	t.Run("should list tasks matching the filter sorted by the sort of the view", func(t *testing.T) {
+		ctx := context.Background()
+		f := newFixture(t)
+		views, service := NewViewService(NewMemoryViewRepository(), f.service, f.clock), f.service
+		createTasks(t, service)
+		require.NoError(t, views.Create(ctx, &View{
+			Name:   "open-backend",
+			Filter: "status != COMPLETED and (priority = HIGH or due < now+3d) and tag:backend",
+			Sort:   []Sort{{Field: SortByPriority, Descending: true}},
+		}))
+
+		page, err := views.ListTasks(ctx, "open-backend", ListOptions{})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"TASK-000002", "TASK-000001"}, taskIDs(page.Tasks))
+	})
+
+	t.Run("should combine the filter of the view with the filter and sort of the options", func(t *testing.T) {
+		ctx := context.Background()
+		f := newFixture(t)
+		views, service := NewViewService(NewMemoryViewRepository(), f.service, f.clock), f.service
+		createTasks(t, service)
+		require.NoError(t, views.Create(ctx, &View{Name: "fixes", Filter: `title ~ fix`, Sort: []Sort{{Field: SortByTitle}}}))
+		expression, err := ParseExpression("status != COMPLETED")
+		require.NoError(t, err)
+
+		page, err := views.ListTasks(ctx, "fixes", ListOptions{
+			Filter: Filter{Expression: expression},
+			Sort:   []Sort{{Field: SortByTitle, Descending: true}},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"TASK-000002", "TASK-000001"}, taskIDs(page.Tasks))
+	})
+
+	t.Run("should return error when view doesn't exist", func(t *testing.T) {
+		f := newFixture(t)
+		views := NewViewService(NewMemoryViewRepository(), f.service, f.clock)
+
+		_, err := views.ListTasks(context.Background(), "open-backend", ListOptions{})
+
+		assert.ErrorIs(t, err, ErrViewNotFound)
+	})
+}
+
+func TestViewAccessControl(t *testing.T) {
+	t.Run("should let members save views and only their owner or an admin delete them", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		clock := newTickingClock(ctrl)
+		service := NewService(NewMemoryRepository(), idgen.NewSequential("TASK-", 1, 6), clock)
+		access := NewViewAccessControl(NewViewService(NewMemoryViewRepository(), service, clock))
+
+		err := access.Create(asUser("victor", RoleViewer), &View{Name: "mine", Filter: "owner = me"})
+		assert.ErrorIs(t, err, ErrForbidden)
+
+		require.NoError(t, access.Create(asUser("alice", RoleMember), &View{Name: "mine", Filter: "owner = me"}))
+		require.NoError(t, access.Create(asUser("alice", RoleMember), &View{Name: "urgent", Filter: "tag:urgent"}))
+
+		views, err := access.List(asUser("victor", RoleViewer))
+		require.NoError(t, err)
+		assert.Len(t, views, 2)
+
+		_, err = access.ListTasks(asUser("victor", RoleViewer), "mine", ListOptions{})
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, access.Delete(asUser("bob", RoleMember), "mine"), ErrForbidden)
+		require.NoError(t, access.Delete(asUser("alice", RoleMember), "mine"))
+		require.NoError(t, access.Delete(asUser("dave", RoleAdmin), "urgent"))
+	})
+}
@@ -0,0 +1,104 @@
+package task
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeViewName(t *testing.T) {
+	t.Run("should lower case name", func(t *testing.T) {
+		name, err := NormalizeViewName(" Open-Backend_Bugs ")
+
+		require.NoError(t, err)
+		assert.Equal(t, "open-backend_bugs", name)
+	})
+
+	t.Run("should return error for invalid names", func(t *testing.T) {
+		for _, name := range []string{"", "-open", "open bugs", "open/bugs", "a123456789a123456789a123456789a123456789a123456789a"} {
+			_, err := NormalizeViewName(name)
+
+			assert.ErrorIs(t, err, ErrInvalidView, name)
+		}
+	})
+}
+
+func TestFormatSort(t *testing.T) {
+	t.Run("should format sort the way it is parsed", func(t *testing.T) {
+		sort, err := ParseSort("-priority,dueDate")
+		require.NoError(t, err)
+
+		assert.Equal(t, "-priority,dueDate", FormatSort(sort))
+		assert.Equal(t, "", FormatSort(nil))
+	})
+}
+
+func TestMemoryViewRepository(t *testing.T) {
+	testViewRepository(t, func(t *testing.T) ViewRepository {
+		return NewMemoryViewRepository()
+	})
+}
+
+// testViewRepository runs the behavioral tests every ViewRepository implementation must pass.
+func testViewRepository(t *testing.T, newRepository func(t *testing.T) ViewRepository) {
+	now := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("should create and get view", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+		view := &View{
+			Name:        "open-bugs",
+			Description: "Bugs to fix",
+			Filter:      "status != COMPLETED and tag:bug",
+			Sort:        []Sort{{Field: SortByPriority, Descending: true}},
+			OwnerID:     "alice",
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+
+		require.NoError(t, repo.Create(ctx, view))
+
+		found, err := repo.Get(ctx, "open-bugs")
+		require.NoError(t, err)
+		assert.Equal(t, view, found)
+	})
+
+	t.Run("should return error when name is taken", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+		require.NoError(t, repo.Create(ctx, &View{Name: "open-bugs", Filter: "tag:bug"}))
+
+		err := repo.Create(ctx, &View{Name: "open-bugs", Filter: "tag:other"})
+
+		assert.ErrorIs(t, err, ErrViewExists)
+	})
+
+	t.Run("should return error when view doesn't exist", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+
+		_, err := repo.Get(ctx, "open-bugs")
+		assert.ErrorIs(t, err, ErrViewNotFound)
+		assert.ErrorIs(t, repo.Delete(ctx, "open-bugs"), ErrViewNotFound)
+	})
+
+	t.Run("should list views sorted by name and delete them", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+		require.NoError(t, repo.Create(ctx, &View{Name: "urgent", Filter: "tag:urgent"}))
+		require.NoError(t, repo.Create(ctx, &View{Name: "mine", Filter: "owner = me"}))
+
+		views, err := repo.List(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"mine", "urgent"}, []string{views[0].Name, views[1].Name})
+
+		require.NoError(t, repo.Delete(ctx, "mine"))
+
+		views, err = repo.List(ctx)
+		require.NoError(t, err)
+		assert.Len(t, views, 1)
+	})
+}
@@ -0,0 +1,81 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/utsabbera/task-master/core/task"
+)
+
+// AccessControl is a Service which only lets admins manage the webhooks and inspect their deliveries,
+// since a webhook receives every change of the tasks at a URL of its creator's choosing
+type AccessControl struct {
+	base Service
+}
+
+// NewAccessControl creates a new access control on top of the given webhook service
+func NewAccessControl(base Service) *AccessControl {
+	return &AccessControl{base: base}
+}
+
+func (a *AccessControl) Create(ctx context.Context, webhook *Webhook) error {
+	if err := authorize(ctx); err != nil {
+		return fmt.Errorf("error creating webhook: %w", err)
+	}
+
+	return a.base.Create(ctx, webhook)
+}
+
+func (a *AccessControl) Get(ctx context.Context, id string) (*Webhook, error) {
+	if err := authorize(ctx); err != nil {
+		return nil, fmt.Errorf("error finding webhook: %w", err)
+	}
+
+	return a.base.Get(ctx, id)
+}
+
+func (a *AccessControl) List(ctx context.Context) ([]*Webhook, error) {
+	if err := authorize(ctx); err != nil {
+		return nil, fmt.Errorf("error listing webhooks: %w", err)
+	}
+
+	return a.base.List(ctx)
+}
+
+func (a *AccessControl) Delete(ctx context.Context, id string) error {
+	if err := authorize(ctx); err != nil {
+		return fmt.Errorf("error deleting webhook: %w", err)
+	}
+
+	return a.base.Delete(ctx, id)
+}
+
+func (a *AccessControl) Deliveries(ctx context.Context, id string, status DeliveryStatus) ([]*Delivery, error) {
+	if err := authorize(ctx); err != nil {
+		return nil, fmt.Errorf("error listing deliveries: %w", err)
+	}
+
+	return a.base.Deliveries(ctx, id, status)
+}
+
+func (a *AccessControl) DeadLetters(ctx context.Context) ([]*Delivery, error) {
+	if err := authorize(ctx); err != nil {
+		return nil, fmt.Errorf("error listing dead letters: %w", err)
+	}
+
+	return a.base.DeadLetters(ctx)
+}
+
+// authorize checks that the user of the context is an admin
+func authorize(ctx context.Context) error {
+	user, ok := task.UserFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("%w: no user", task.ErrForbidden)
+	}
+
+	if user.Role != task.RoleAdmin {
+		return fmt.Errorf("%w: %s role required", task.ErrForbidden, task.RoleAdmin)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/core/task"
+	"github.com/utsabbera/task-master/pkg/idgen"
+	"github.com/utsabbera/task-master/pkg/util"
+)
+
+func TestAccessControl(t *testing.T) {
+	asUser := func(id string, role task.Role) context.Context {
+		return task.WithUser(context.Background(), task.User{ID: id, Role: role})
+	}
+
+	t.Run("should only let admin manage webhooks and inspect deliveries", func(t *testing.T) {
+		repo := NewMemoryRepository()
+		access := NewAccessControl(NewService(repo, idgen.NewSequential("WH-", 1, 3), util.NewClock()))
+
+		for _, role := range []task.Role{task.RoleViewer, task.RoleMember} {
+			ctx := asUser("alice", role)
+
+			err := access.Create(ctx, &Webhook{URL: "https://example.com/hook", Secret: "s3cr3t"})
+			assert.ErrorIs(t, err, task.ErrForbidden)
+			_, err = access.List(ctx)
+			assert.ErrorIs(t, err, task.ErrForbidden)
+			_, err = access.DeadLetters(ctx)
+			assert.ErrorIs(t, err, task.ErrForbidden)
+		}
+
+		admin := asUser("dave", task.RoleAdmin)
+		hook := &Webhook{URL: "https://example.com/hook", Secret: "s3cr3t"}
+		require.NoError(t, access.Create(admin, hook))
+
+		member := asUser("alice", task.RoleMember)
+		_, err := access.Get(member, hook.ID)
+		assert.ErrorIs(t, err, task.ErrForbidden)
+		_, err = access.Deliveries(member, hook.ID, "")
+		assert.ErrorIs(t, err, task.ErrForbidden)
+		assert.ErrorIs(t, access.Delete(member, hook.ID), task.ErrForbidden)
+
+		_, err = access.Get(admin, hook.ID)
+		require.NoError(t, err)
+		require.NoError(t, access.Delete(admin, hook.ID))
+	})
+
+	t.Run("should reject context without user", func(t *testing.T) {
+		access := NewAccessControl(NewService(NewMemoryRepository(), idgen.NewSequential("WH-", 1, 3), util.NewClock()))
+
+		_, err := access.List(context.Background())
+
+		assert.ErrorIs(t, err, task.ErrForbidden)
+	})
+}
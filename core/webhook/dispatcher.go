@@ -0,0 +1,338 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/utsabbera/task-master/core/task"
+	"github.com/utsabbera/task-master/pkg/idgen"
+	"github.com/utsabbera/task-master/pkg/util"
+)
+
+// EncodeFunc encodes a change of a task as the JSON payload posted to the webhooks
+type EncodeFunc func(n task.Notification) ([]byte, error)
+
+// Config holds the retry policy of the deliveries
+type Config struct {
+	// MaxAttempts is the number of attempts after which a delivery is given up, defaults to 6
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry, doubled for every following retry, defaults to 1 second
+	InitialBackoff time.Duration
+	// MaxBackoff is the longest delay between two attempts, defaults to 10 minutes
+	MaxBackoff time.Duration
+	// Timeout is the time a webhook has to respond to an attempt, defaults to 10 seconds
+	Timeout time.Duration
+	// Workers is the number of attempts made at the same time, defaults to 4
+	Workers int
+	// PollInterval is how often the deliveries whose retry is due are looked up, defaults to 1 second
+	PollInterval time.Duration
+}
+
+func (c Config) withDefaults() Config {
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = 6
+	}
+	if c.InitialBackoff <= 0 {
+		c.InitialBackoff = time.Second
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = 10 * time.Minute
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = 10 * time.Second
+	}
+	if c.Workers <= 0 {
+		c.Workers = 4
+	}
+	if c.PollInterval <= 0 {
+		c.PollInterval = time.Second
+	}
+	return c
+}
+
+// backoff returns the delay before the attempt following the given number of failed attempts
+func (c Config) backoff(failed int) time.Duration {
+	delay := c.InitialBackoff
+	for i := 1; i < failed && delay < c.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, c.MaxBackoff)
+}
+
+// Dispatcher delivers the changes of the tasks to the webhooks subscribed to them.
+// Each change is posted with its timestamp in TimestampHeader and its signature in SignatureHeader by a bounded pool of workers.
+// Failed attempts are retried once their exponential backoff has elapsed on the clock, the pending deliveries are looked up
+// every PollInterval so that those left over by a restart are resumed, and the deliveries still failing after the last attempt
+// are moved to the dead-letter list
+type Dispatcher struct {
+	tasks       task.Service
+	repo        Repository
+	idGenerator idgen.Generator
+	clock       util.Clock
+	client      *http.Client
+	encode      EncodeFunc
+	cfg         Config
+	queue       chan *Delivery
+	queued      map[string]bool
+	attempts    map[string]int
+	mu          sync.Mutex
+	wg          sync.WaitGroup
+}
+
+// NewDispatcher creates a new dispatcher of the changes published by the task service to the webhooks of the repository
+func NewDispatcher(tasks task.Service, repo Repository, idGenerator idgen.Generator, clock util.Clock, encode EncodeFunc, cfg Config) *Dispatcher {
+	cfg = cfg.withDefaults()
+
+	return &Dispatcher{
+		tasks:       tasks,
+		repo:        repo,
+		idGenerator: idGenerator,
+		clock:       clock,
+		client:      &http.Client{Timeout: cfg.Timeout},
+		encode:      encode,
+		cfg:         cfg,
+		queue:       make(chan *Delivery, cfg.Workers),
+		queued:      make(map[string]bool),
+		attempts:    make(map[string]int),
+	}
+}
+
+// Start subscribes to the changes of the tasks and delivers them in the background until the context is done.
+// A subscription closed for falling behind is resumed after the last change received
+func (d *Dispatcher) Start(ctx context.Context) error {
+	sub, err := d.tasks.Subscribe(ctx, task.NotificationFilter{}, 0)
+	if err != nil {
+		return fmt.Errorf("error subscribing to task changes: %w", err)
+	}
+
+	d.wg.Add(2 + d.cfg.Workers)
+	go func() {
+		defer d.wg.Done()
+		d.run(ctx, sub)
+	}()
+	go func() {
+		defer d.wg.Done()
+		d.poll(ctx)
+	}()
+	for range d.cfg.Workers {
+		go func() {
+			defer d.wg.Done()
+			d.work(ctx)
+		}()
+	}
+
+	return nil
+}
+
+// Wait blocks until the dispatcher is stopped and the attempts in progress are done
+func (d *Dispatcher) Wait() {
+	d.wg.Wait()
+}
+
+func (d *Dispatcher) run(ctx context.Context, sub *task.Subscription) {
+	var lastID int64
+	for {
+		for n := range sub.Notifications() {
+			d.dispatch(ctx, n)
+			lastID = n.ID
+		}
+
+		if sub.Err() == nil {
+			return
+		}
+
+		var err error
+		sub, err = d.tasks.Subscribe(ctx, task.NotificationFilter{}, lastID)
+		if errors.Is(err, task.ErrNotificationsExpired) {
+			log.Printf("webhook dispatcher missed task changes after %d", lastID)
+			sub, err = d.tasks.Subscribe(ctx, task.NotificationFilter{}, 0)
+		}
+		if err != nil {
+			log.Printf("error subscribing to task changes: %v", err)
+			return
+		}
+	}
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context, n task.Notification) {
+	webhooks, err := d.repo.List(ctx)
+	if err != nil {
+		log.Printf("error listing webhooks: %v", err)
+		return
+	}
+
+	var payload []byte
+	for _, webhook := range webhooks {
+		if !webhook.Subscribed(n.Type) {
+			continue
+		}
+
+		if payload == nil {
+			if payload, err = d.encode(n); err != nil {
+				log.Printf("error encoding task change %d: %v", n.ID, err)
+				return
+			}
+		}
+
+		now := d.clock.Now()
+		delivery := &Delivery{
+			ID:        d.idGenerator.Next(),
+			WebhookID: webhook.ID,
+			EventID:   n.ID,
+			EventType: n.Type,
+			Payload:   payload,
+			Status:    DeliveryPending,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if err := d.repo.SaveDelivery(ctx, delivery); err != nil {
+			log.Printf("error saving delivery %s: %v", delivery.ID, err)
+			continue
+		}
+
+		d.enqueue(delivery)
+	}
+}
+
+// poll enqueues the pending deliveries whose attempt is due on the clock every PollInterval until the context is done
+func (d *Dispatcher) poll(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		d.enqueueDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// enqueueDue enqueues the pending deliveries not attempted yet or whose retry is due
+func (d *Dispatcher) enqueueDue(ctx context.Context) {
+	pending, err := d.repo.Deliveries(ctx, DeliveryFilter{Status: DeliveryPending})
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("error listing pending deliveries: %v", err)
+		}
+		return
+	}
+
+	now := d.clock.Now()
+	for _, delivery := range pending {
+		if delivery.NextAttemptAt == nil || !delivery.NextAttemptAt.After(now) {
+			d.enqueue(delivery)
+		}
+	}
+}
+
+// enqueue hands the delivery to the workers unless it is already queued or being attempted,
+// or it was listed before its latest attempt was saved. A delivery not handed over for the workers being busy
+// is left pending for enqueueDue to retry, so that the dispatch of the task changes never waits for them
+func (d *Dispatcher) enqueue(delivery *Delivery) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.queued[delivery.ID] || len(delivery.Attempts) < d.attempts[delivery.ID] {
+		return
+	}
+
+	select {
+	case d.queue <- delivery:
+		d.queued[delivery.ID] = true
+	default:
+	}
+}
+
+// work attempts the queued deliveries until the context is done
+func (d *Dispatcher) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case delivery := <-d.queue:
+			d.deliver(ctx, delivery)
+
+			d.mu.Lock()
+			delete(d.queued, delivery.ID)
+			if delivery.Status == DeliveryPending {
+				d.attempts[delivery.ID] = len(delivery.Attempts)
+			} else {
+				delete(d.attempts, delivery.ID)
+			}
+			d.mu.Unlock()
+		}
+	}
+}
+
+// deliver makes an attempt of the delivery, scheduling a retry when it fails and attempts are left.
+// The delivery is dropped when its webhook was deleted
+func (d *Dispatcher) deliver(ctx context.Context, delivery *Delivery) {
+	webhook, err := d.repo.Get(ctx, delivery.WebhookID)
+	if err != nil {
+		if !errors.Is(err, ErrWebhookNotFound) {
+			log.Printf("error reading webhook %s: %v", delivery.WebhookID, err)
+		}
+		return
+	}
+
+	attempt := d.attempt(ctx, webhook, delivery)
+	delivery.Attempts = append(delivery.Attempts, attempt)
+	delivery.UpdatedAt = attempt.At
+	delivery.NextAttemptAt = nil
+
+	switch {
+	case attempt.Error == "":
+		delivery.Status = DeliverySucceeded
+	case len(delivery.Attempts) >= d.cfg.MaxAttempts:
+		delivery.Status = DeliveryDead
+	default:
+		next := attempt.At.Add(d.cfg.backoff(len(delivery.Attempts)))
+		delivery.NextAttemptAt = &next
+	}
+
+	if err := d.repo.SaveDelivery(ctx, delivery); err != nil && !errors.Is(err, ErrWebhookNotFound) {
+		log.Printf("error saving delivery %s: %v", delivery.ID, err)
+	}
+}
+
+func (d *Dispatcher) attempt(ctx context.Context, webhook *Webhook, delivery *Delivery) Attempt {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return Attempt{At: d.clock.Now(), Error: err.Error()}
+	}
+
+	timestamp := d.clock.Now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "task-master-webhooks")
+	req.Header.Set("X-Webhook-ID", webhook.ID)
+	req.Header.Set("X-Webhook-Delivery", delivery.ID)
+	req.Header.Set("X-Webhook-Event", string(delivery.EventType))
+	req.Header.Set("X-Webhook-Attempt", strconv.Itoa(len(delivery.Attempts)+1))
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp.Unix(), 10))
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	at := d.clock.Now()
+	if err != nil {
+		return Attempt{At: at, Error: err.Error()}
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return Attempt{At: at, StatusCode: resp.StatusCode, Error: "unexpected response status " + resp.Status}
+	}
+
+	return Attempt{At: at, StatusCode: resp.StatusCode}
+}
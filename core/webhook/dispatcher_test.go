@@ -0,0 +1,302 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/core/task"
+	"github.com/utsabbera/task-master/pkg/idgen"
+	"github.com/utsabbera/task-master/pkg/util"
+	"go.uber.org/mock/gomock"
+)
+
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+type receiver struct {
+	*httptest.Server
+	requests []receivedRequest
+	statuses []int
+	mu       sync.Mutex
+}
+
+func newReceiver(t *testing.T, statuses ...int) *receiver {
+	r := &receiver{statuses: statuses}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+
+		r.mu.Lock()
+		r.requests = append(r.requests, receivedRequest{header: req.Header.Clone(), body: body})
+		status := http.StatusNoContent
+		if len(r.statuses) > 0 {
+			status, r.statuses = r.statuses[0], r.statuses[1:]
+		}
+		r.mu.Unlock()
+
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func (r *receiver) received() []receivedRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]receivedRequest(nil), r.requests...)
+}
+
+func startDispatcher(t *testing.T, repo Repository, cfg Config) *task.Bus {
+	return startDispatcherWithClock(t, repo, util.NewClock(), cfg)
+}
+
+func startDispatcherWithClock(t *testing.T, repo Repository, clock util.Clock, cfg Config) *task.Bus {
+	ctrl := gomock.NewController(t)
+	bus := task.NewBus(0)
+	tasks := task.NewMockService(ctrl)
+
+	tasks.EXPECT().Subscribe(gomock.Any(), task.NotificationFilter{}, int64(0)).
+		DoAndReturn(func(ctx context.Context, filter task.NotificationFilter, lastID int64) (*task.Subscription, error) {
+			return bus.Subscribe(ctx, filter, lastID)
+		})
+
+	encode := func(n task.Notification) ([]byte, error) {
+		return json.Marshal(map[string]any{"id": n.ID, "type": n.Type, "taskId": n.TaskID})
+	}
+	dispatcher := NewDispatcher(tasks, repo, idgen.NewSequential("DLV-", 1, 3), clock, encode, cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, dispatcher.Start(ctx))
+	t.Cleanup(func() {
+		cancel()
+		dispatcher.Wait()
+	})
+
+	return bus
+}
+
+// newManualClock returns a clock standing still at the given time until advanced
+func newManualClock(ctrl *gomock.Controller, now time.Time) (util.Clock, func(time.Duration)) {
+	var mu sync.Mutex
+	clock := util.NewMockClock(ctrl)
+	clock.EXPECT().Now().DoAndReturn(func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}).AnyTimes()
+
+	return clock, func(d time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		now = now.Add(d)
+	}
+}
+
+func waitForDelivery(t *testing.T, repo Repository, status DeliveryStatus) *Delivery {
+	var delivery *Delivery
+	require.Eventually(t, func() bool {
+		deliveries, err := repo.Deliveries(context.Background(), DeliveryFilter{Status: status})
+		if err != nil || len(deliveries) == 0 {
+			return false
+		}
+		delivery = deliveries[0]
+		return true
+	}, 5*time.Second, 5*time.Millisecond)
+	return delivery
+}
+
+func TestDispatcher(t *testing.T) {
+	cfg := Config{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond, PollInterval: time.Millisecond}
+
+	t.Run("should post signed payload to subscribed webhook", func(t *testing.T) {
+		target := newReceiver(t)
+		repo := NewMemoryRepository()
+		require.NoError(t, repo.Create(context.Background(), &Webhook{ID: "WH-1", URL: target.URL, Secret: "secret"}))
+		bus := startDispatcher(t, repo, cfg)
+
+		bus.Publish(task.Notification{Type: task.EventCreated, TaskID: "TASK-000001"})
+
+		delivery := waitForDelivery(t, repo, DeliverySucceeded)
+		assert.Equal(t, "WH-1", delivery.WebhookID)
+		assert.Equal(t, int64(1), delivery.EventID)
+		require.Len(t, delivery.Attempts, 1)
+		assert.Equal(t, http.StatusNoContent, delivery.Attempts[0].StatusCode)
+
+		requests := target.received()
+		require.Len(t, requests, 1)
+		assert.JSONEq(t, `{"id":1,"type":"TASK_CREATED","taskId":"TASK-000001"}`, string(requests[0].body))
+		timestamp := requests[0].header.Get(TimestampHeader)
+		assert.True(t, Verify("secret", requests[0].body, timestamp, requests[0].header.Get(SignatureHeader), time.Now()))
+		assert.Equal(t, "application/json", requests[0].header.Get("Content-Type"))
+		assert.Equal(t, "WH-1", requests[0].header.Get("X-Webhook-ID"))
+		assert.Equal(t, delivery.ID, requests[0].header.Get("X-Webhook-Delivery"))
+		assert.Equal(t, "TASK_CREATED", requests[0].header.Get("X-Webhook-Event"))
+		assert.Equal(t, "1", requests[0].header.Get("X-Webhook-Attempt"))
+	})
+
+	t.Run("should retry failed delivery", func(t *testing.T) {
+		target := newReceiver(t, http.StatusInternalServerError, http.StatusBadGateway)
+		repo := NewMemoryRepository()
+		require.NoError(t, repo.Create(context.Background(), &Webhook{ID: "WH-1", URL: target.URL, Secret: "secret"}))
+		bus := startDispatcher(t, repo, cfg)
+
+		bus.Publish(task.Notification{Type: task.EventUpdated, TaskID: "TASK-000001"})
+
+		delivery := waitForDelivery(t, repo, DeliverySucceeded)
+		require.Len(t, delivery.Attempts, 3)
+		assert.Equal(t, http.StatusInternalServerError, delivery.Attempts[0].StatusCode)
+		assert.NotEmpty(t, delivery.Attempts[0].Error)
+		assert.Equal(t, http.StatusBadGateway, delivery.Attempts[1].StatusCode)
+		assert.Empty(t, delivery.Attempts[2].Error)
+		assert.Nil(t, delivery.NextAttemptAt)
+
+		requests := target.received()
+		require.Len(t, requests, 3)
+		assert.Equal(t, "3", requests[2].header.Get("X-Webhook-Attempt"))
+	})
+
+	t.Run("should retry failed delivery once backoff elapsed on clock", func(t *testing.T) {
+		target := newReceiver(t, http.StatusInternalServerError)
+		repo := NewMemoryRepository()
+		require.NoError(t, repo.Create(context.Background(), &Webhook{ID: "WH-1", URL: target.URL, Secret: "secret"}))
+		clock, advance := newManualClock(gomock.NewController(t), time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC))
+		bus := startDispatcherWithClock(t, repo, clock, Config{InitialBackoff: time.Hour, MaxBackoff: 2 * time.Hour, PollInterval: time.Millisecond})
+
+		bus.Publish(task.Notification{Type: task.EventUpdated, TaskID: "TASK-000001"})
+
+		require.Eventually(t, func() bool { return len(target.received()) == 1 }, 5*time.Second, time.Millisecond)
+		pending := waitForDelivery(t, repo, DeliveryPending)
+		require.NotNil(t, pending.NextAttemptAt)
+		assert.Equal(t, time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC), *pending.NextAttemptAt)
+		assert.Never(t, func() bool { return len(target.received()) > 1 }, 50*time.Millisecond, time.Millisecond)
+
+		advance(time.Hour)
+
+		delivery := waitForDelivery(t, repo, DeliverySucceeded)
+		assert.Len(t, delivery.Attempts, 2)
+		assert.Len(t, target.received(), 2)
+	})
+
+	t.Run("should resume pending delivery left over by restart", func(t *testing.T) {
+		target := newReceiver(t)
+		repo := NewMemoryRepository()
+		require.NoError(t, repo.Create(context.Background(), &Webhook{ID: "WH-1", URL: target.URL, Secret: "secret"}))
+		require.NoError(t, repo.SaveDelivery(context.Background(), &Delivery{ID: "DLV-000", WebhookID: "WH-1", EventType: task.EventCreated, Payload: []byte(`{}`), Status: DeliveryPending}))
+
+		startDispatcher(t, repo, cfg)
+
+		delivery := waitForDelivery(t, repo, DeliverySucceeded)
+		assert.Equal(t, "DLV-000", delivery.ID)
+		assert.Len(t, target.received(), 1)
+	})
+
+	t.Run("should attempt deliveries with bounded workers", func(t *testing.T) {
+		var (
+			inFlight, peak int
+			mu             sync.Mutex
+		)
+		target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			inFlight++
+			peak = max(peak, inFlight)
+			mu.Unlock()
+
+			time.Sleep(5 * time.Millisecond)
+
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		t.Cleanup(target.Close)
+		repo := NewMemoryRepository()
+		require.NoError(t, repo.Create(context.Background(), &Webhook{ID: "WH-1", URL: target.URL, Secret: "secret"}))
+		bus := startDispatcher(t, repo, Config{Workers: 2, PollInterval: time.Millisecond})
+
+		for range 10 {
+			bus.Publish(task.Notification{Type: task.EventCreated, TaskID: "TASK-000001"})
+		}
+
+		require.Eventually(t, func() bool {
+			succeeded, err := repo.Deliveries(context.Background(), DeliveryFilter{Status: DeliverySucceeded})
+			return err == nil && len(succeeded) == 10
+		}, 5*time.Second, time.Millisecond)
+		mu.Lock()
+		defer mu.Unlock()
+		assert.LessOrEqual(t, peak, 2)
+	})
+
+	t.Run("should move delivery to dead letters after last attempt", func(t *testing.T) {
+		target := newReceiver(t, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusNoContent)
+		repo := NewMemoryRepository()
+		require.NoError(t, repo.Create(context.Background(), &Webhook{ID: "WH-1", URL: target.URL, Secret: "secret"}))
+		bus := startDispatcher(t, repo, cfg)
+
+		bus.Publish(task.Notification{Type: task.EventDeleted, TaskID: "TASK-000001"})
+
+		delivery := waitForDelivery(t, repo, DeliveryDead)
+		assert.Len(t, delivery.Attempts, 3)
+		assert.Len(t, target.received(), 3)
+	})
+
+	t.Run("should only deliver subscribed event types", func(t *testing.T) {
+		target := newReceiver(t)
+		repo := NewMemoryRepository()
+		require.NoError(t, repo.Create(context.Background(), &Webhook{ID: "WH-1", URL: target.URL, Secret: "secret", Events: []task.EventType{task.EventDeleted}}))
+		bus := startDispatcher(t, repo, cfg)
+
+		bus.Publish(task.Notification{Type: task.EventCreated, TaskID: "TASK-000001"})
+		bus.Publish(task.Notification{Type: task.EventDeleted, TaskID: "TASK-000001"})
+
+		delivery := waitForDelivery(t, repo, DeliverySucceeded)
+		assert.Equal(t, task.EventDeleted, delivery.EventType)
+		assert.Equal(t, int64(2), delivery.EventID)
+		assert.Len(t, target.received(), 1)
+	})
+
+	t.Run("should count unreachable webhook as failed attempt", func(t *testing.T) {
+		target := newReceiver(t)
+		target.Close()
+		repo := NewMemoryRepository()
+		require.NoError(t, repo.Create(context.Background(), &Webhook{ID: "WH-1", URL: target.URL, Secret: "secret"}))
+		bus := startDispatcher(t, repo, cfg)
+
+		bus.Publish(task.Notification{Type: task.EventCreated, TaskID: "TASK-000001"})
+
+		delivery := waitForDelivery(t, repo, DeliveryDead)
+		require.Len(t, delivery.Attempts, 3)
+		assert.Zero(t, delivery.Attempts[0].StatusCode)
+		assert.NotEmpty(t, delivery.Attempts[0].Error)
+	})
+
+	t.Run("should leave delivery pending when workers are busy", func(t *testing.T) {
+		ctx := context.Background()
+		repo := NewMemoryRepository()
+		require.NoError(t, repo.Create(ctx, &Webhook{ID: "WH-1"}))
+		first := &Delivery{ID: "DLV-001", WebhookID: "WH-1", Status: DeliveryPending}
+		second := &Delivery{ID: "DLV-002", WebhookID: "WH-1", Status: DeliveryPending}
+		require.NoError(t, repo.SaveDelivery(ctx, first))
+		require.NoError(t, repo.SaveDelivery(ctx, second))
+		dispatcher := NewDispatcher(nil, repo, nil, util.NewClock(), nil, Config{Workers: 1})
+
+		dispatcher.enqueue(first)
+		dispatcher.enqueue(second)
+
+		assert.Equal(t, "DLV-001", (<-dispatcher.queue).ID)
+		assert.Empty(t, dispatcher.queue)
+		assert.False(t, dispatcher.queued["DLV-002"])
+
+		dispatcher.enqueueDue(ctx)
+
+		assert.Equal(t, "DLV-002", (<-dispatcher.queue).ID)
+	})
+}
@@ -0,0 +1,23 @@
+CREATE TABLE webhooks (
+    id         TEXT PRIMARY KEY,
+    url        TEXT NOT NULL,
+    events     TEXT NOT NULL DEFAULT '',
+    secret     TEXT NOT NULL,
+    created_at TEXT NOT NULL
+);
+
+CREATE TABLE webhook_deliveries (
+    id              TEXT PRIMARY KEY,
+    webhook_id      TEXT NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+    event_id        INTEGER NOT NULL,
+    event_type      TEXT NOT NULL,
+    payload         BLOB,
+    status          TEXT NOT NULL,
+    attempts        TEXT NOT NULL,
+    next_attempt_at TEXT,
+    created_at      TEXT NOT NULL,
+    updated_at      TEXT NOT NULL
+);
+
+CREATE INDEX idx_webhook_deliveries_webhook ON webhook_deliveries (webhook_id, created_at);
+CREATE INDEX idx_webhook_deliveries_status ON webhook_deliveries (status);
@@ -0,0 +1,18 @@
+CREATE TABLE webhook_issued_ids (
+    id TEXT PRIMARY KEY
+);
+
+INSERT INTO webhook_issued_ids (id)
+SELECT id FROM webhooks
+UNION
+SELECT id FROM webhook_deliveries;
+
+CREATE TRIGGER webhooks_issue_id AFTER INSERT ON webhooks
+BEGIN
+    INSERT OR IGNORE INTO webhook_issued_ids (id) VALUES (NEW.id);
+END;
+
+CREATE TRIGGER webhook_deliveries_issue_id AFTER INSERT ON webhook_deliveries
+BEGIN
+    INSERT OR IGNORE INTO webhook_issued_ids (id) VALUES (NEW.id);
+END;
@@ -0,0 +1,197 @@
+package webhook
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+)
+
+// maxDeliveries is the number of deliveries a repository keeps for each webhook besides its dead letters,
+// which are kept until the webhook is deleted
+const maxDeliveries = 100
+
+//go:generate mockgen -destination=repository_mock.go -package=webhook . Repository
+
+// Repository defines the interface for storing webhooks and their deliveries
+type Repository interface {
+	// Create stores a new webhook
+	Create(ctx context.Context, webhook *Webhook) error
+
+	// Get returns a webhook by its ID
+	// Returns ErrWebhookNotFound if the webhook doesn't exist
+	Get(ctx context.Context, id string) (*Webhook, error)
+
+	// List returns every webhook in the order they were created
+	List(ctx context.Context) ([]*Webhook, error)
+
+	// Delete removes a webhook with its deliveries
+	// Returns ErrWebhookNotFound if the webhook doesn't exist
+	Delete(ctx context.Context, id string) error
+
+	// SaveDelivery stores a new delivery or replaces the delivery with the same ID
+	// Returns ErrWebhookNotFound if the webhook of the delivery doesn't exist
+	SaveDelivery(ctx context.Context, delivery *Delivery) error
+
+	// Deliveries returns the deliveries matching the filter, the most recent first
+	Deliveries(ctx context.Context, filter DeliveryFilter) ([]*Delivery, error)
+
+	// IssuedIDs returns the IDs of every webhook and delivery ever stored, the deleted and trimmed ones included
+	IssuedIDs(ctx context.Context) ([]string, error)
+}
+
+// DeliveryFilter selects deliveries, empty fields select every delivery
+type DeliveryFilter struct {
+	// WebhookID selects the deliveries of this webhook
+	WebhookID string
+	// Status selects the deliveries in this state
+	Status DeliveryStatus
+}
+
+func (f DeliveryFilter) match(d *Delivery) bool {
+	return (f.WebhookID == "" || d.WebhookID == f.WebhookID) && (f.Status == "" || d.Status == f.Status)
+}
+
+// MemoryRepository is an in-memory implementation of Repository keeping the latest deliveries of each webhook
+// along with all its dead letters
+type MemoryRepository struct {
+	webhooks   map[string]*Webhook
+	deliveries map[string][]*Delivery
+	issued     map[string]bool
+	mu         sync.RWMutex
+}
+
+// NewMemoryRepository creates a new empty memory repository
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		webhooks:   make(map[string]*Webhook),
+		deliveries: make(map[string][]*Delivery),
+		issued:     make(map[string]bool),
+	}
+}
+
+func (r *MemoryRepository) Create(_ context.Context, webhook *Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if webhook.ID == "" {
+		return ErrInvalidWebhook
+	}
+
+	r.webhooks[webhook.ID] = webhook.clone()
+	r.issued[webhook.ID] = true
+	return nil
+}
+
+func (r *MemoryRepository) Get(_ context.Context, id string) (*Webhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	webhook, ok := r.webhooks[id]
+	if !ok {
+		return nil, ErrWebhookNotFound
+	}
+
+	return webhook.clone(), nil
+}
+
+func (r *MemoryRepository) List(_ context.Context) ([]*Webhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	webhooks := make([]*Webhook, 0, len(r.webhooks))
+	for _, webhook := range r.webhooks {
+		webhooks = append(webhooks, webhook.clone())
+	}
+
+	slices.SortFunc(webhooks, func(a, b *Webhook) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.ID, b.ID))
+	})
+
+	return webhooks, nil
+}
+
+func (r *MemoryRepository) Delete(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.webhooks[id]; !ok {
+		return ErrWebhookNotFound
+	}
+
+	delete(r.webhooks, id)
+	delete(r.deliveries, id)
+	return nil
+}
+
+func (r *MemoryRepository) SaveDelivery(_ context.Context, delivery *Delivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.webhooks[delivery.WebhookID]; !ok {
+		return ErrWebhookNotFound
+	}
+
+	deliveries := r.deliveries[delivery.WebhookID]
+	i := slices.IndexFunc(deliveries, func(d *Delivery) bool { return d.ID == delivery.ID })
+	if i >= 0 {
+		deliveries[i] = delivery.clone()
+		return nil
+	}
+
+	deliveries = append(deliveries, delivery.clone())
+	r.deliveries[delivery.WebhookID] = trimDeliveries(deliveries)
+	r.issued[delivery.ID] = true
+
+	return nil
+}
+
+func (r *MemoryRepository) Deliveries(_ context.Context, filter DeliveryFilter) ([]*Delivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	deliveries := make([]*Delivery, 0)
+	for _, list := range r.deliveries {
+		for _, d := range list {
+			if filter.match(d) {
+				deliveries = append(deliveries, d.clone())
+			}
+		}
+	}
+
+	slices.SortFunc(deliveries, func(a, b *Delivery) int {
+		return cmp.Or(b.CreatedAt.Compare(a.CreatedAt), cmp.Compare(b.ID, a.ID))
+	})
+
+	return deliveries, nil
+}
+
+func (r *MemoryRepository) IssuedIDs(_ context.Context) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := make([]string, 0, len(r.issued))
+	for id := range r.issued {
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// trimDeliveries drops the oldest deliveries beyond maxDeliveries, keeping the dead letters
+func trimDeliveries(deliveries []*Delivery) []*Delivery {
+	excess := len(deliveries) - maxDeliveries
+	for _, d := range deliveries {
+		if d.Status == DeliveryDead {
+			excess--
+		}
+	}
+
+	return slices.DeleteFunc(deliveries, func(d *Delivery) bool {
+		if excess <= 0 || d.Status == DeliveryDead {
+			return false
+		}
+		excess--
+		return true
+	})
+}
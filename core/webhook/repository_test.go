@@ -0,0 +1,194 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/core/task"
+	"github.com/utsabbera/task-master/pkg/database"
+)
+
+func TestMemoryRepository(t *testing.T) {
+	testRepository(t, func(t *testing.T) Repository { return NewMemoryRepository() })
+}
+
+func TestSQLRepository(t *testing.T) {
+	testRepository(t, func(t *testing.T) Repository {
+		repo, err := NewSQLRepository(context.Background(), openSQLite(t, ":memory:"))
+		require.NoError(t, err)
+		return repo
+	})
+
+	t.Run("should keep webhooks and deliveries after reopening the database", func(t *testing.T) {
+		ctx := context.Background()
+		dsn := filepath.Join(t.TempDir(), "webhooks.db")
+		repo, err := NewSQLRepository(ctx, openSQLite(t, dsn))
+		require.NoError(t, err)
+
+		at := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+		next := at.Add(time.Minute)
+		webhook := &Webhook{ID: "WH-1", URL: "https://example.com/hook", Events: []task.EventType{task.EventCreated, task.EventDeleted}, Secret: "s3cr3t", CreatedAt: at}
+		delivery := &Delivery{
+			ID:            "DLV-1",
+			WebhookID:     "WH-1",
+			EventID:       7,
+			EventType:     task.EventCreated,
+			Payload:       []byte(`{"id":7}`),
+			Status:        DeliveryPending,
+			Attempts:      []Attempt{{At: at, StatusCode: http.StatusBadGateway, Error: "unexpected response status 502 Bad Gateway"}},
+			NextAttemptAt: &next,
+			CreatedAt:     at,
+			UpdatedAt:     at,
+		}
+		require.NoError(t, repo.Create(ctx, webhook))
+		require.NoError(t, repo.SaveDelivery(ctx, delivery))
+
+		repo, err = NewSQLRepository(ctx, openSQLite(t, dsn))
+		require.NoError(t, err)
+
+		got, err := repo.Get(ctx, "WH-1")
+		require.NoError(t, err)
+		assert.Equal(t, webhook, got)
+
+		deliveries, err := repo.Deliveries(ctx, DeliveryFilter{WebhookID: "WH-1"})
+		require.NoError(t, err)
+		assert.Equal(t, []*Delivery{delivery}, deliveries)
+	})
+}
+
+func openSQLite(t *testing.T, dsn string) *sql.DB {
+	t.Helper()
+
+	db, err := database.OpenSQLite(context.Background(), dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	return db
+}
+
+func testRepository(t *testing.T, newRepository func(t *testing.T) Repository) {
+	createdAt := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("should list webhooks in creation order", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+		require.NoError(t, repo.Create(ctx, &Webhook{ID: "WH-2", URL: "https://example.com/b", CreatedAt: createdAt.Add(time.Minute)}))
+		require.NoError(t, repo.Create(ctx, &Webhook{ID: "WH-1", URL: "https://example.com/a", CreatedAt: createdAt}))
+
+		webhooks, err := repo.List(ctx)
+
+		require.NoError(t, err)
+		require.Len(t, webhooks, 2)
+		assert.Equal(t, "WH-1", webhooks[0].ID)
+		assert.Equal(t, "WH-2", webhooks[1].ID)
+	})
+
+	t.Run("should return error when webhook not found", func(t *testing.T) {
+		repo := newRepository(t)
+
+		_, err := repo.Get(context.Background(), "WH-1")
+		assert.ErrorIs(t, err, ErrWebhookNotFound)
+
+		err = repo.Delete(context.Background(), "WH-1")
+		assert.ErrorIs(t, err, ErrWebhookNotFound)
+	})
+
+	t.Run("should replace saved delivery and filter deliveries", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+		require.NoError(t, repo.Create(ctx, &Webhook{ID: "WH-1"}))
+		require.NoError(t, repo.Create(ctx, &Webhook{ID: "WH-2"}))
+
+		require.NoError(t, repo.SaveDelivery(ctx, &Delivery{ID: "DLV-1", WebhookID: "WH-1", Status: DeliveryPending, CreatedAt: createdAt}))
+		require.NoError(t, repo.SaveDelivery(ctx, &Delivery{ID: "DLV-2", WebhookID: "WH-2", Status: DeliveryDead, CreatedAt: createdAt.Add(time.Minute)}))
+		require.NoError(t, repo.SaveDelivery(ctx, &Delivery{ID: "DLV-1", WebhookID: "WH-1", Status: DeliveryDead, CreatedAt: createdAt}))
+
+		dead, err := repo.Deliveries(ctx, DeliveryFilter{Status: DeliveryDead})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"DLV-2", "DLV-1"}, deliveryIDs(dead))
+
+		first, err := repo.Deliveries(ctx, DeliveryFilter{WebhookID: "WH-1"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"DLV-1"}, deliveryIDs(first))
+	})
+
+	t.Run("should keep latest deliveries of each webhook", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+		require.NoError(t, repo.Create(ctx, &Webhook{ID: "WH-1"}))
+
+		for i := range maxDeliveries + 5 {
+			require.NoError(t, repo.SaveDelivery(ctx, &Delivery{ID: fmt.Sprintf("DLV-%03d", i), WebhookID: "WH-1", CreatedAt: createdAt.Add(time.Duration(i) * time.Second)}))
+		}
+
+		deliveries, err := repo.Deliveries(ctx, DeliveryFilter{})
+		require.NoError(t, err)
+		assert.Len(t, deliveries, maxDeliveries)
+		assert.Equal(t, "DLV-104", deliveries[0].ID)
+		assert.Equal(t, "DLV-005", deliveries[maxDeliveries-1].ID)
+	})
+
+	t.Run("should keep dead letters beyond latest deliveries", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+		require.NoError(t, repo.Create(ctx, &Webhook{ID: "WH-1"}))
+		require.NoError(t, repo.SaveDelivery(ctx, &Delivery{ID: "DLV-dead", WebhookID: "WH-1", Status: DeliveryDead, CreatedAt: createdAt}))
+
+		for i := range maxDeliveries + 5 {
+			require.NoError(t, repo.SaveDelivery(ctx, &Delivery{ID: fmt.Sprintf("DLV-%03d", i), WebhookID: "WH-1", Status: DeliverySucceeded, CreatedAt: createdAt.Add(time.Duration(i+1) * time.Second)}))
+		}
+
+		deliveries, err := repo.Deliveries(ctx, DeliveryFilter{})
+		require.NoError(t, err)
+		assert.Len(t, deliveries, maxDeliveries+1)
+		assert.Equal(t, "DLV-005", deliveries[maxDeliveries-1].ID)
+
+		dead, err := repo.Deliveries(ctx, DeliveryFilter{Status: DeliveryDead})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"DLV-dead"}, deliveryIDs(dead))
+	})
+
+	t.Run("should delete deliveries with webhook", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+		require.NoError(t, repo.Create(ctx, &Webhook{ID: "WH-1"}))
+		require.NoError(t, repo.SaveDelivery(ctx, &Delivery{ID: "DLV-1", WebhookID: "WH-1"}))
+
+		require.NoError(t, repo.Delete(ctx, "WH-1"))
+
+		deliveries, err := repo.Deliveries(ctx, DeliveryFilter{})
+		require.NoError(t, err)
+		assert.Empty(t, deliveries)
+		assert.ErrorIs(t, repo.SaveDelivery(ctx, &Delivery{ID: "DLV-1", WebhookID: "WH-1"}), ErrWebhookNotFound)
+	})
+
+	t.Run("should keep the IDs of deleted webhooks and deliveries as issued", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+		require.NoError(t, repo.Create(ctx, &Webhook{ID: "WH-1"}))
+		require.NoError(t, repo.Create(ctx, &Webhook{ID: "WH-2"}))
+		require.NoError(t, repo.SaveDelivery(ctx, &Delivery{ID: "DLV-1", WebhookID: "WH-1"}))
+		require.NoError(t, repo.SaveDelivery(ctx, &Delivery{ID: "DLV-1", WebhookID: "WH-1", Status: DeliverySucceeded}))
+		require.NoError(t, repo.Delete(ctx, "WH-1"))
+
+		ids, err := repo.IssuedIDs(ctx)
+
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"WH-1", "WH-2", "DLV-1"}, ids)
+	})
+}
+
+func deliveryIDs(deliveries []*Delivery) []string {
+	ids := make([]string, 0, len(deliveries))
+	for _, d := range deliveries {
+		ids = append(ids, d.ID)
+	}
+	return ids
+}
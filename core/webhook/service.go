@@ -0,0 +1,148 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+
+	"github.com/utsabbera/task-master/core/task"
+	"github.com/utsabbera/task-master/pkg/idgen"
+	"github.com/utsabbera/task-master/pkg/util"
+)
+
+//go:generate mockgen -destination=service_mock.go -package=webhook . Service
+
+// Service defines the interface for managing the webhooks and inspecting their deliveries
+type Service interface {
+	// Create validates and stores a new webhook, setting its ID and creation time
+	// Returns ErrInvalidWebhook if the URL is not an absolute http or https URL, the secret is empty
+	// or an event type is unknown
+	Create(ctx context.Context, webhook *Webhook) error
+
+	// Get returns a webhook by its ID
+	// Returns ErrWebhookNotFound if the webhook doesn't exist
+	Get(ctx context.Context, id string) (*Webhook, error)
+
+	// List returns every webhook in the order they were created
+	List(ctx context.Context) ([]*Webhook, error)
+
+	// Delete removes a webhook, its pending deliveries are dropped
+	// Returns ErrWebhookNotFound if the webhook doesn't exist
+	Delete(ctx context.Context, id string) error
+
+	// Deliveries returns the deliveries of a webhook in the given state, in every state when empty, the most recent first
+	// Returns ErrWebhookNotFound if the webhook doesn't exist
+	Deliveries(ctx context.Context, id string, status DeliveryStatus) ([]*Delivery, error)
+
+	// DeadLetters returns the deliveries of every webhook given up after their last attempt failed, the most recent first
+	DeadLetters(ctx context.Context) ([]*Delivery, error)
+}
+
+type service struct {
+	repo        Repository
+	idGenerator idgen.Generator
+	clock       util.Clock
+}
+
+// NewService creates a new instance of Service with the provided repository, ID generator, and clock
+func NewService(repo Repository, idGenerator idgen.Generator, clock util.Clock) Service {
+	return &service{
+		repo:        repo,
+		idGenerator: idGenerator,
+		clock:       clock,
+	}
+}
+
+func (s *service) Create(ctx context.Context, webhook *Webhook) error {
+	if err := validateWebhook(webhook); err != nil {
+		return fmt.Errorf("error creating webhook: %w", err)
+	}
+
+	webhook.ID = s.idGenerator.Next()
+	webhook.Events = normalizeEvents(webhook.Events)
+	webhook.CreatedAt = s.clock.Now()
+
+	if err := s.repo.Create(ctx, webhook); err != nil {
+		return fmt.Errorf("error creating webhook: %w", err)
+	}
+
+	return nil
+}
+
+func validateWebhook(webhook *Webhook) error {
+	u, err := url.Parse(webhook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: URL %q must be an absolute http or https URL", ErrInvalidWebhook, webhook.URL)
+	}
+
+	if webhook.Secret == "" {
+		return fmt.Errorf("%w: secret is required", ErrInvalidWebhook)
+	}
+
+	for _, eventType := range webhook.Events {
+		if !slices.Contains(task.EventTypes(), eventType) {
+			return fmt.Errorf("%w: unknown event type %q", ErrInvalidWebhook, eventType)
+		}
+	}
+
+	return nil
+}
+
+func normalizeEvents(events []task.EventType) []task.EventType {
+	if len(events) == 0 {
+		return nil
+	}
+
+	normalized := slices.Clone(events)
+	slices.Sort(normalized)
+	return slices.Compact(normalized)
+}
+
+func (s *service) Get(ctx context.Context, id string) (*Webhook, error) {
+	webhook, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error finding webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+func (s *service) List(ctx context.Context) ([]*Webhook, error) {
+	webhooks, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+func (s *service) Delete(ctx context.Context, id string) error {
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("error deleting webhook: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) Deliveries(ctx context.Context, id string, status DeliveryStatus) ([]*Delivery, error) {
+	if _, err := s.repo.Get(ctx, id); err != nil {
+		return nil, fmt.Errorf("error finding webhook: %w", err)
+	}
+
+	deliveries, err := s.repo.Deliveries(ctx, DeliveryFilter{WebhookID: id, Status: status})
+	if err != nil {
+		return nil, fmt.Errorf("error listing deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func (s *service) DeadLetters(ctx context.Context) ([]*Delivery, error) {
+	deliveries, err := s.repo.Deliveries(ctx, DeliveryFilter{Status: DeliveryDead})
+	if err != nil {
+		return nil, fmt.Errorf("error listing dead letters: %w", err)
+	}
+
+	return deliveries, nil
+}
@@ -0,0 +1,128 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/core/task"
+	"github.com/utsabbera/task-master/pkg/idgen"
+	"github.com/utsabbera/task-master/pkg/util"
+	"go.uber.org/mock/gomock"
+)
+
+func TestService_Create(t *testing.T) {
+	now := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("should create webhook", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		clock := util.NewMockClock(ctrl)
+		mockRepo := NewMockRepository(ctrl)
+		mockIdGen := idgen.NewMockGenerator(ctrl)
+		service := NewService(mockRepo, mockIdGen, clock)
+
+		mockIdGen.EXPECT().Next().Return("WH-1")
+		clock.EXPECT().Now().Return(now)
+		mockRepo.EXPECT().Create(ctx, &Webhook{
+			ID:        "WH-1",
+			URL:       "https://example.com/hooks",
+			Events:    []task.EventType{task.EventCreated, task.EventDeleted},
+			Secret:    "secret",
+			CreatedAt: now,
+		}).Return(nil)
+
+		webhook := &Webhook{
+			URL:    "https://example.com/hooks",
+			Events: []task.EventType{task.EventDeleted, task.EventCreated, task.EventDeleted},
+			Secret: "secret",
+		}
+		err := service.Create(ctx, webhook)
+
+		require.NoError(t, err)
+		assert.Equal(t, "WH-1", webhook.ID)
+	})
+
+	t.Run("should return error for invalid webhook", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			webhook *Webhook
+		}{
+			{"relative URL", &Webhook{URL: "/hooks", Secret: "secret"}},
+			{"URL with other scheme", &Webhook{URL: "ftp://example.com/hooks", Secret: "secret"}},
+			{"empty secret", &Webhook{URL: "https://example.com/hooks"}},
+			{"unknown event type", &Webhook{URL: "https://example.com/hooks", Secret: "secret", Events: []task.EventType{"TASK_RENAMED"}}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				service := NewService(NewMockRepository(ctrl), idgen.NewMockGenerator(ctrl), util.NewMockClock(ctrl))
+
+				err := service.Create(context.Background(), tt.webhook)
+
+				assert.ErrorIs(t, err, ErrInvalidWebhook)
+			})
+		}
+	})
+}
+
+func TestService_Deliveries(t *testing.T) {
+	t.Run("should return deliveries of webhook in given state", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := NewMockRepository(ctrl)
+		service := NewService(mockRepo, idgen.NewMockGenerator(ctrl), util.NewMockClock(ctrl))
+
+		mockRepo.EXPECT().Get(ctx, "WH-1").Return(&Webhook{ID: "WH-1"}, nil)
+		mockRepo.EXPECT().Deliveries(ctx, DeliveryFilter{WebhookID: "WH-1", Status: DeliveryDead}).
+			Return([]*Delivery{{ID: "DLV-1", WebhookID: "WH-1", Status: DeliveryDead}}, nil)
+
+		deliveries, err := service.Deliveries(ctx, "WH-1", DeliveryDead)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"DLV-1"}, deliveryIDs(deliveries))
+	})
+
+	t.Run("should return error when webhook not found", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := NewMockRepository(ctrl)
+		service := NewService(mockRepo, idgen.NewMockGenerator(ctrl), util.NewMockClock(ctrl))
+
+		mockRepo.EXPECT().Get(ctx, "WH-1").Return(nil, ErrWebhookNotFound)
+
+		_, err := service.Deliveries(ctx, "WH-1", "")
+
+		assert.ErrorIs(t, err, ErrWebhookNotFound)
+	})
+}
+
+func TestService_DeadLetters(t *testing.T) {
+	t.Run("should return dead deliveries of every webhook", func(t *testing.T) {
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := NewMockRepository(ctrl)
+		service := NewService(mockRepo, idgen.NewMockGenerator(ctrl), util.NewMockClock(ctrl))
+
+		mockRepo.EXPECT().Deliveries(ctx, DeliveryFilter{Status: DeliveryDead}).
+			Return([]*Delivery{{ID: "DLV-2", WebhookID: "WH-2"}, {ID: "DLV-1", WebhookID: "WH-1"}}, nil)
+
+		deliveries, err := service.DeadLetters(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"DLV-2", "DLV-1"}, deliveryIDs(deliveries))
+	})
+}
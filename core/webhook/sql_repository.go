@@ -0,0 +1,323 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"time"
+
+	"github.com/utsabbera/task-master/core/task"
+	"github.com/utsabbera/task-master/pkg/database"
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+const (
+	deliveryColumns = "id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at, created_at, updated_at"
+	timeLayout      = "2006-01-02T15:04:05.000000000Z07:00"
+)
+
+// SQLRepository is an implementation of Repository that stores the webhooks with their latest deliveries
+// and all their dead letters in a SQL database,
+// so that the subscriptions, the delivery history and the dead-letter list survive a restart
+type SQLRepository struct {
+	db *sql.DB
+}
+
+// NewSQLRepository creates a new SQL webhook repository using the given database
+// and migrates its schema to the latest version
+func NewSQLRepository(ctx context.Context, db *sql.DB) (*SQLRepository, error) {
+	fsys, err := fs.Sub(migrations, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := database.MigrateTable(ctx, db, fsys, "webhook_schema_migrations"); err != nil {
+		return nil, fmt.Errorf("error migrating webhook schema: %w", err)
+	}
+
+	return &SQLRepository{db: db}, nil
+}
+
+func (r *SQLRepository) Create(ctx context.Context, webhook *Webhook) error {
+	if webhook.ID == "" {
+		return ErrInvalidWebhook
+	}
+
+	events := make([]string, len(webhook.Events))
+	for i, event := range webhook.Events {
+		events[i] = string(event)
+	}
+
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO webhooks (id, url, events, secret, created_at) VALUES (?, ?, ?, ?, ?)`,
+		webhook.ID, webhook.URL, strings.Join(events, ","), webhook.Secret, formatTime(webhook.CreatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("error inserting webhook: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SQLRepository) Get(ctx context.Context, id string) (*Webhook, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT id, url, events, secret, created_at FROM webhooks WHERE id = ?`, id)
+
+	webhook, err := scanWebhook(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrWebhookNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+func (r *SQLRepository) List(ctx context.Context) ([]*Webhook, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, url, events, secret, created_at FROM webhooks ORDER BY created_at, id`)
+	if err != nil {
+		return nil, fmt.Errorf("error listing webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	webhooks := make([]*Webhook, 0)
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error reading webhook: %w", err)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+func (r *SQLRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM webhooks WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("error deleting webhook: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error reading affected rows: %w", err)
+	}
+
+	if affected == 0 {
+		return ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+func (r *SQLRepository) SaveDelivery(ctx context.Context, delivery *Delivery) error {
+	attempts, err := json.Marshal(delivery.Attempts)
+	if err != nil {
+		return fmt.Errorf("error encoding delivery attempts: %w", err)
+	}
+
+	var nextAttemptAt sql.NullString
+	if delivery.NextAttemptAt != nil {
+		nextAttemptAt = sql.NullString{String: formatTime(*delivery.NextAttemptAt), Valid: true}
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var exists bool
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM webhooks WHERE id = ?)`, delivery.WebhookID).Scan(&exists); err != nil {
+		return fmt.Errorf("error finding webhook: %w", err)
+	}
+	if !exists {
+		return ErrWebhookNotFound
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO webhook_deliveries (`+deliveryColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET status = excluded.status, attempts = excluded.attempts,
+		next_attempt_at = excluded.next_attempt_at, updated_at = excluded.updated_at`,
+		delivery.ID, delivery.WebhookID, delivery.EventID, string(delivery.EventType), delivery.Payload, string(delivery.Status),
+		string(attempts), nextAttemptAt, formatTime(delivery.CreatedAt), formatTime(delivery.UpdatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("error saving delivery: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`DELETE FROM webhook_deliveries WHERE webhook_id = ? AND status != ? AND id NOT IN (
+			SELECT id FROM webhook_deliveries WHERE webhook_id = ? AND status != ? ORDER BY created_at DESC, id DESC LIMIT ?
+		)`,
+		delivery.WebhookID, string(DeliveryDead), delivery.WebhookID, string(DeliveryDead), maxDeliveries,
+	)
+	if err != nil {
+		return fmt.Errorf("error trimming deliveries: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SQLRepository) IssuedIDs(ctx context.Context) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id FROM webhook_issued_ids`)
+	if err != nil {
+		return nil, fmt.Errorf("error listing issued IDs: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("error reading issued ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading issued IDs: %w", err)
+	}
+
+	return ids, nil
+}
+
+func (r *SQLRepository) Deliveries(ctx context.Context, filter DeliveryFilter) ([]*Delivery, error) {
+	var (
+		conditions []string
+		args       []any
+	)
+	if filter.WebhookID != "" {
+		conditions = append(conditions, "webhook_id = ?")
+		args = append(args, filter.WebhookID)
+	}
+	if filter.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, string(filter.Status))
+	}
+
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += ` ORDER BY created_at DESC, id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error listing deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := make([]*Delivery, 0)
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error reading delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanWebhook(row scanner) (*Webhook, error) {
+	var (
+		w         Webhook
+		events    string
+		createdAt string
+	)
+
+	if err := row.Scan(&w.ID, &w.URL, &events, &w.Secret, &createdAt); err != nil {
+		return nil, err
+	}
+
+	if events != "" {
+		for _, event := range strings.Split(events, ",") {
+			w.Events = append(w.Events, task.EventType(event))
+		}
+	}
+
+	var err error
+	if w.CreatedAt, err = parseTime(createdAt); err != nil {
+		return nil, err
+	}
+
+	return &w, nil
+}
+
+func scanDelivery(row scanner) (*Delivery, error) {
+	var (
+		d             Delivery
+		eventType     string
+		status        string
+		attempts      string
+		nextAttemptAt sql.NullString
+		createdAt     string
+		updatedAt     string
+	)
+
+	err := row.Scan(&d.ID, &d.WebhookID, &d.EventID, &eventType, &d.Payload, &status, &attempts, &nextAttemptAt, &createdAt, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	d.EventType = task.EventType(eventType)
+	d.Status = DeliveryStatus(status)
+
+	if err := json.Unmarshal([]byte(attempts), &d.Attempts); err != nil {
+		return nil, fmt.Errorf("error decoding delivery attempts: %w", err)
+	}
+
+	if nextAttemptAt.Valid {
+		next, err := parseTime(nextAttemptAt.String)
+		if err != nil {
+			return nil, err
+		}
+		d.NextAttemptAt = &next
+	}
+
+	if d.CreatedAt, err = parseTime(createdAt); err != nil {
+		return nil, err
+	}
+	if d.UpdatedAt, err = parseTime(updatedAt); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
+
+func parseTime(value string) (time.Time, error) {
+	t, err := time.Parse(timeLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error parsing time %q: %w", value, err)
+	}
+
+	return t, nil
+}
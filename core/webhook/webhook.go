@@ -0,0 +1,146 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/utsabbera/task-master/core/task"
+)
+
+var (
+	// ErrWebhookNotFound is returned when a webhook doesn't exist
+	ErrWebhookNotFound = errors.New("webhook not found")
+	// ErrInvalidWebhook is returned when a webhook has no valid http or https URL, no secret or an unknown event type
+	ErrInvalidWebhook = errors.New("invalid webhook")
+)
+
+const (
+	// SignatureHeader is the header of a delivery carrying the signature of its timestamp and payload
+	SignatureHeader = "X-Webhook-Signature-256"
+	// TimestampHeader is the header of a delivery carrying the Unix time in seconds the attempt was made
+	TimestampHeader = "X-Webhook-Timestamp"
+	// SignatureTolerance is how old the timestamp of a delivery may be for Verify to accept it,
+	// a delivery captured and replayed later is rejected
+	SignatureTolerance = 5 * time.Minute
+)
+
+// Webhook is a subscription of an external URL to the changes of the tasks
+type Webhook struct {
+	// ID is the unique identifier of the webhook
+	ID string
+	// URL is the http or https URL the changes are posted to
+	URL string
+	// Events are the types of the changes delivered to the webhook, every type when empty
+	Events []task.EventType
+	// Secret is the key the payloads are signed with
+	Secret string
+	// CreatedAt stores when the webhook was created
+	CreatedAt time.Time
+}
+
+// Subscribed reports whether the changes of the given type are delivered to the webhook
+func (w *Webhook) Subscribed(eventType task.EventType) bool {
+	return len(w.Events) == 0 || slices.Contains(w.Events, eventType)
+}
+
+func (w *Webhook) clone() *Webhook {
+	c := *w
+	c.Events = slices.Clone(w.Events)
+	return &c
+}
+
+// DeliveryStatus is the state of the delivery of a change to a webhook
+type DeliveryStatus string
+
+const (
+	// DeliveryPending is a delivery waiting for its first attempt or for a retry
+	DeliveryPending DeliveryStatus = "PENDING"
+	// DeliverySucceeded is a delivery the webhook accepted with a 2xx response
+	DeliverySucceeded DeliveryStatus = "SUCCEEDED"
+	// DeliveryDead is a delivery given up after its last attempt failed, kept in the dead-letter list
+	DeliveryDead DeliveryStatus = "DEAD"
+)
+
+// DeliveryStatuses returns every state of a delivery
+func DeliveryStatuses() []DeliveryStatus {
+	return []DeliveryStatus{DeliveryPending, DeliverySucceeded, DeliveryDead}
+}
+
+// Delivery is the delivery of a change of a task to a webhook with its attempts
+type Delivery struct {
+	// ID is the unique identifier of the delivery, sent in the X-Webhook-Delivery header
+	ID string
+	// WebhookID is the ID of the webhook the change is delivered to
+	WebhookID string
+	// EventID is the ID of the delivered change on the task change feed
+	EventID int64
+	// EventType is the type of the delivered change
+	EventType task.EventType
+	// Payload is the JSON body posted to the webhook
+	Payload []byte
+	// Status is the state of the delivery
+	Status DeliveryStatus
+	// Attempts are the attempts made so far, in order
+	Attempts []Attempt
+	// NextAttemptAt stores when the delivery is retried, nil when it is not pending a retry
+	NextAttemptAt *time.Time
+	// CreatedAt stores when the delivery was created
+	CreatedAt time.Time
+	// UpdatedAt stores when the delivery was last attempted
+	UpdatedAt time.Time
+}
+
+func (d *Delivery) clone() *Delivery {
+	c := *d
+	c.Payload = slices.Clone(d.Payload)
+	c.Attempts = slices.Clone(d.Attempts)
+	if d.NextAttemptAt != nil {
+		next := *d.NextAttemptAt
+		c.NextAttemptAt = &next
+	}
+	return &c
+}
+
+// Attempt is a single post of a delivery to its webhook
+type Attempt struct {
+	// At stores when the attempt was made
+	At time.Time
+	// StatusCode is the status of the response, zero when no response was received
+	StatusCode int
+	// Error describes why the attempt failed, empty when it succeeded
+	Error string
+}
+
+// Sign returns the signature of a payload sent at the given time in SignatureHeader: sha256= followed by
+// the hex encoded HMAC-SHA256 of the Unix time in seconds sent in TimestampHeader, a dot and the payload
+func Sign(secret string, timestamp time.Time, payload []byte) string {
+	return sign(secret, strconv.FormatInt(timestamp.Unix(), 10), payload)
+}
+
+// Verify reports whether the signature is the signature of the payload with the secret and the timestamp
+// received in TimestampHeader, in constant time, and the timestamp is within SignatureTolerance of now
+func Verify(secret string, payload []byte, timestamp, signature string, now time.Time) bool {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	if age := now.Sub(time.Unix(seconds, 0)); age > SignatureTolerance || age < -SignatureTolerance {
+		return false
+	}
+
+	return hmac.Equal([]byte(sign(secret, timestamp, payload)), []byte(signature))
+}
+
+func sign(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
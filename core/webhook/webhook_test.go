@@ -0,0 +1,57 @@
+package webhook
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/utsabbera/task-master/core/task"
+)
+
+func TestSign(t *testing.T) {
+	t.Run("should sign timestamp and payload with hmac sha256", func(t *testing.T) {
+		signature := Sign("It's a Secret to Everybody", time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC), []byte("Hello, World!"))
+
+		assert.Equal(t, "sha256=bbd47bd2420028d77b8dd78dc10435faf1f7630d57d825cd3183314731ec55b2", signature)
+	})
+}
+
+func TestVerify(t *testing.T) {
+	payload := []byte(`{"type":"TASK_CREATED"}`)
+	sentAt := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+	timestamp := strconv.FormatInt(sentAt.Unix(), 10)
+
+	t.Run("should accept signature of timestamp and payload", func(t *testing.T) {
+		assert.True(t, Verify("secret", payload, timestamp, Sign("secret", sentAt, payload), sentAt.Add(time.Minute)))
+	})
+
+	t.Run("should reject signature with other secret, timestamp or payload", func(t *testing.T) {
+		assert.False(t, Verify("secret", payload, timestamp, Sign("other", sentAt, payload), sentAt))
+		assert.False(t, Verify("secret", payload, timestamp, Sign("secret", sentAt.Add(time.Second), payload), sentAt))
+		assert.False(t, Verify("secret", payload, timestamp, Sign("secret", sentAt, []byte(`{"type":"TASK_DELETED"}`)), sentAt))
+		assert.False(t, Verify("secret", payload, timestamp, "", sentAt))
+		assert.False(t, Verify("secret", payload, "", Sign("secret", sentAt, payload), sentAt))
+	})
+
+	t.Run("should reject replayed delivery", func(t *testing.T) {
+		assert.False(t, Verify("secret", payload, timestamp, Sign("secret", sentAt, payload), sentAt.Add(SignatureTolerance+time.Second)))
+	})
+}
+
+func TestWebhook_Subscribed(t *testing.T) {
+	t.Run("should subscribe to every event type without events", func(t *testing.T) {
+		webhook := &Webhook{}
+
+		for _, eventType := range task.EventTypes() {
+			assert.True(t, webhook.Subscribed(eventType))
+		}
+	})
+
+	t.Run("should subscribe only to given event types", func(t *testing.T) {
+		webhook := &Webhook{Events: []task.EventType{task.EventCreated, task.EventDeleted}}
+
+		assert.True(t, webhook.Subscribed(task.EventDeleted))
+		assert.False(t, webhook.Subscribed(task.EventUpdated))
+	})
+}
@@ -11,10 +11,11 @@
 //
 //	func main() {
 //		config := assistant.Config{ /* ... */ }
-//		client := assistant.NewClient(config)
+//		sessions := assistant.NewMemorySessionStore(30*time.Minute, util.NewClock())
+//		client := assistant.NewClient(config, sessions)
 //		client.Init()
 //		ctx := context.Background()
-//		resp, err := client.Chat(ctx, "Hello!")
+//		resp, err := client.Chat(ctx, assistant.NewSessionID(), "Hello!")
 //		if err != nil {
 //			// handle error
 //		}
@@ -25,7 +26,9 @@ package assistant
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/openai/openai-go"
@@ -44,21 +47,36 @@ type Client interface {
 	RegisterFunction(fn Function)
 	// RegisterFunctions registers multiple functions for use by the chat client.
 	RegisterFunctions(funcs ...Function)
-	// Chat sends a message to the chat client and returns the response.
-	Chat(ctx context.Context, message string) (string, error)
+	// Chat sends a message within the given session and returns the response.
+	// A new session is started if none exists with the given ID, owned by the user of the context (see WithOwner).
+	// Returns ErrSessionNotFound if the session is owned by another user.
+	Chat(ctx context.Context, sessionID, message string) (string, error)
+	// ChatStream sends a message within the given session like Chat, but streams the response from the LLM
+	// and reports its text chunks and function calls to emit as they happen.
+	ChatStream(ctx context.Context, sessionID, message string, emit EmitFunc) (string, error)
+	// GetSession retrieves a session with its conversation history.
+	// Returns ErrSessionNotFound if the session doesn't exist or has expired.
+	GetSession(ctx context.Context, sessionID string) (*Session, error)
+	// DeleteSession removes a session and its conversation history.
+	// Returns ErrSessionNotFound if the session doesn't exist or has expired.
+	DeleteSession(ctx context.Context, sessionID string) error
 }
 
 type client struct {
-	config Config
-	params openai.ChatCompletionNewParams
-	openai openai.Client
-	funcs  map[string]Function
+	config   Config
+	params   openai.ChatCompletionNewParams
+	openai   openai.Client
+	funcs    map[string]Function
+	sessions SessionStore
+	locks    sessionLocks
 }
 
-func NewClient(config Config) Client {
+// NewClient creates a new chat client which keeps the conversation history in the given session store.
+func NewClient(config Config, sessions SessionStore) Client {
 	return &client{
-		config: config,
-		funcs:  make(map[string]Function),
+		config:   config,
+		funcs:    make(map[string]Function),
+		sessions: sessions,
 	}
 }
 
@@ -129,59 +147,216 @@ Always confirm actions with the user, provide clear feedback, and handle errors
 `, c.config.AppName, c.config.AppDescription)
 }
 
-func (c *client) Chat(ctx context.Context, message string) (string, error) {
-	c.params.Messages = append(c.params.Messages, openai.UserMessage(message))
-	return c.process(ctx)
+func (c *client) Chat(ctx context.Context, sessionID, message string) (string, error) {
+	return c.chat(ctx, sessionID, message, nil)
 }
 
-func (c *client) process(ctx context.Context) (string, error) {
-	completion, err := c.openai.Chat.Completions.New(ctx, c.params)
+func (c *client) ChatStream(ctx context.Context, sessionID, message string, emit EmitFunc) (string, error) {
+	return c.chat(ctx, sessionID, message, emit)
+}
+
+func (c *client) chat(ctx context.Context, sessionID, message string, emit EmitFunc) (string, error) {
+	unlock := c.locks.lock(sessionID)
+	defer unlock()
+
+	owner := OwnerFromContext(ctx)
+	session, err := c.sessions.Get(ctx, sessionID)
+	if errors.Is(err, ErrSessionNotFound) {
+		session = &Session{ID: sessionID, Owner: owner}
+	} else if err != nil {
+		return "", fmt.Errorf("error loading session: %w", err)
+	} else if session.Owner != owner {
+		return "", ErrSessionNotFound
+	}
+
+	session.Messages = append(session.Messages, Message{Role: RoleUser, Content: message})
+
+	response, err := c.process(ctx, session, emit)
 	if err != nil {
 		return "", err
 	}
 
-	if len(completion.Choices) > 0 {
-		response := completion.Choices[0].Message
+	if err := c.sessions.Save(ctx, session); err != nil {
+		return "", fmt.Errorf("error saving session: %w", err)
+	}
 
-		c.params.Messages = append(c.params.Messages, response.ToParam())
+	return response, nil
+}
 
-		// TODO: handle refusal
+func (c *client) GetSession(ctx context.Context, sessionID string) (*Session, error) {
+	return c.sessions.Get(ctx, sessionID)
+}
 
-		if len(response.ToolCalls) > 0 {
-			err := c.handleToolCalls(ctx, response.ToolCalls)
-			if err != nil {
-				return "", err
-			}
+func (c *client) DeleteSession(ctx context.Context, sessionID string) error {
+	unlock := c.locks.lock(sessionID)
+	defer unlock()
+
+	return c.sessions.Delete(ctx, sessionID)
+}
+
+func (c *client) process(ctx context.Context, session *Session, emit EmitFunc) (string, error) {
+	params := c.params
+	params.Messages = slices.Clone(c.params.Messages)
+	for _, message := range session.Messages {
+		params.Messages = append(params.Messages, toParam(message))
+	}
+
+	response, err := c.complete(ctx, params, emit)
+	if err != nil {
+		return "", err
+	}
 
-			return c.process(ctx)
+	if response == nil {
+		return "", nil
+	}
+
+	session.Messages = append(session.Messages, fromCompletion(*response))
+
+	// TODO: handle refusal
+
+	if len(response.ToolCalls) > 0 {
+		err := c.handleToolCalls(ctx, session, response.ToolCalls, emit)
+		if err != nil {
+			return "", err
 		}
 
-		return response.Content, nil
+		return c.process(ctx, session, emit)
 	}
 
-	return "", nil
+	return response.Content, nil
 }
 
-func (c *client) handleToolCalls(ctx context.Context, calls []openai.ChatCompletionMessageToolCall) error {
+// complete requests the next message from the LLM, streaming it to emit unless emit is nil.
+// It returns nil if the LLM returned no choices.
+func (c *client) complete(ctx context.Context, params openai.ChatCompletionNewParams, emit EmitFunc) (*openai.ChatCompletionMessage, error) {
+	if emit == nil {
+		completion, err := c.openai.Chat.Completions.New(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(completion.Choices) == 0 {
+			return nil, nil
+		}
+
+		return &completion.Choices[0].Message, nil
+	}
+
+	stream := c.openai.Chat.Completions.NewStreaming(ctx, params)
+	defer func() { _ = stream.Close() }()
+
+	var acc openai.ChatCompletionAccumulator
+	for stream.Next() {
+		chunk := stream.Current()
+		if !acc.AddChunk(chunk) {
+			return nil, errors.New("error accumulating completion chunk")
+		}
+
+		if len(chunk.Choices) > 0 && chunk.Choices[0].Delta.Content != "" {
+			if err := emit(Event{Type: EventDelta, Content: chunk.Choices[0].Delta.Content}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := stream.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(acc.Choices) == 0 {
+		return nil, nil
+	}
+
+	return &acc.Choices[0].Message, nil
+}
+
+func (c *client) handleToolCalls(ctx context.Context, session *Session, calls []openai.ChatCompletionMessageToolCall, emit EmitFunc) error {
 	// TODO: Handle the errors properly - loop it through chat
 
 	for _, call := range calls {
 		// TODO: Pass these errors for the AI model to handle
 
+		toolCall := &ToolCall{ID: call.ID, Name: call.Function.Name, Arguments: call.Function.Arguments}
+
 		fn, exists := c.funcs[call.Function.Name]
 		if !exists {
 			return fmt.Errorf("function %s not found", call.Function.Name)
 		}
 
+		if err := notify(emit, Event{Type: EventToolCallStart, ToolCall: toolCall}); err != nil {
+			return err
+		}
+
 		response := fn.Call(ctx, call.Function.Arguments)
 		respBytes, err := json.Marshal(response)
 		if err != nil {
 			return fmt.Errorf("error marshalling function %s response: %w", call.Function.Name, err)
 		}
 
-		message := "```json\n" + string(respBytes) + "\n```"
-		c.params.Messages = append(c.params.Messages, openai.ToolMessage(message, call.ID))
+		if err := notify(emit, Event{Type: EventToolCallFinish, Content: string(respBytes), ToolCall: toolCall}); err != nil {
+			return err
+		}
+
+		session.Messages = append(session.Messages, Message{
+			Role:       RoleTool,
+			Content:    "```json\n" + string(respBytes) + "\n```",
+			ToolCallID: call.ID,
+		})
 	}
 
 	return nil
 }
+
+func notify(emit EmitFunc, event Event) error {
+	if emit == nil {
+		return nil
+	}
+
+	return emit(event)
+}
+
+func toParam(message Message) openai.ChatCompletionMessageParamUnion {
+	switch message.Role {
+	case RoleAssistant:
+		if len(message.ToolCalls) == 0 {
+			return openai.AssistantMessage(message.Content)
+		}
+
+		assistant := openai.ChatCompletionAssistantMessageParam{
+			ToolCalls: util.Map(message.ToolCalls, func(call ToolCall) openai.ChatCompletionMessageToolCallParam {
+				return openai.ChatCompletionMessageToolCallParam{
+					ID: call.ID,
+					Function: openai.ChatCompletionMessageToolCallFunctionParam{
+						Name:      call.Name,
+						Arguments: call.Arguments,
+					},
+				}
+			}),
+		}
+		if message.Content != "" {
+			assistant.Content.OfString = openai.String(message.Content)
+		}
+
+		return openai.ChatCompletionMessageParamUnion{OfAssistant: &assistant}
+	case RoleTool:
+		return openai.ToolMessage(message.Content, message.ToolCallID)
+	default:
+		return openai.UserMessage(message.Content)
+	}
+}
+
+func fromCompletion(message openai.ChatCompletionMessage) Message {
+	result := Message{Role: RoleAssistant, Content: message.Content}
+
+	if len(message.ToolCalls) > 0 {
+		result.ToolCalls = util.Map(message.ToolCalls, func(call openai.ChatCompletionMessageToolCall) ToolCall {
+			return ToolCall{
+				ID:        call.ID,
+				Name:      call.Function.Name,
+				Arguments: call.Function.Arguments,
+			}
+		})
+	}
+
+	return result
+}
@@ -40,18 +40,62 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 }
 
 // Chat mocks base method.
-func (m *MockClient) Chat(arg0 context.Context, arg1 string) (string, error) {
+func (m *MockClient) Chat(arg0 context.Context, arg1, arg2 string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Chat", arg0, arg1)
+	ret := m.ctrl.Call(m, "Chat", arg0, arg1, arg2)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Chat indicates an expected call of Chat.
-func (mr *MockClientMockRecorder) Chat(arg0, arg1 any) *gomock.Call {
+func (mr *MockClientMockRecorder) Chat(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Chat", reflect.TypeOf((*MockClient)(nil).Chat), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Chat", reflect.TypeOf((*MockClient)(nil).Chat), arg0, arg1, arg2)
+}
+
+// ChatStream mocks base method.
+func (m *MockClient) ChatStream(arg0 context.Context, arg1, arg2 string, arg3 EmitFunc) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChatStream", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChatStream indicates an expected call of ChatStream.
+func (mr *MockClientMockRecorder) ChatStream(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChatStream", reflect.TypeOf((*MockClient)(nil).ChatStream), arg0, arg1, arg2, arg3)
+}
+
+// DeleteSession mocks base method.
+func (m *MockClient) DeleteSession(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSession", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSession indicates an expected call of DeleteSession.
+func (mr *MockClientMockRecorder) DeleteSession(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockClient)(nil).DeleteSession), arg0, arg1)
+}
+
+// GetSession mocks base method.
+func (m *MockClient) GetSession(arg0 context.Context, arg1 string) (*Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", arg0, arg1)
+	ret0, _ := ret[0].(*Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockClientMockRecorder) GetSession(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockClient)(nil).GetSession), arg0, arg1)
 }
 
 // Init mocks base method.
@@ -3,9 +3,13 @@ package assistant
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/pkg/util"
 )
 
 func TestClient_Chat(t *testing.T) {
@@ -19,10 +23,10 @@ func TestClient_Chat(t *testing.T) {
 			BaseURL: ts.URL,
 			Model:   "echo",
 		}
-		cli := NewClient(config)
+		cli := NewClient(config, NewMemorySessionStore(0, util.NewClock()))
 		cli.Init()
 
-		msg, err := cli.Chat(ctx, "Hello, world!")
+		msg, err := cli.Chat(ctx, "session-1", "Hello, world!")
 
 		assert.NoError(t, err)
 		assert.Equal(t, "Hello, world!", msg)
@@ -36,19 +40,35 @@ func TestClient_Chat(t *testing.T) {
 			BaseURL: ts.URL,
 			Model:   "tool-call",
 		}
-		cli := NewClient(config)
+		cli := NewClient(config, NewMemorySessionStore(0, util.NewClock()))
 		cli.RegisterFunction(NewFunction("test", "desc", func(context.Context, struct{}) (any, error) {
 			called = true
 			return "Done!", nil
 		}))
 		cli.Init()
 
-		msg, err := cli.Chat(ctx, "test")
+		msg, err := cli.Chat(ctx, "session-1", "test")
 		assert.NoError(t, err)
 		assert.Equal(t, "```json\n"+`{"data":"Done!"}`+"\n```", msg)
 		assert.True(t, called)
 	})
 
+	t.Run("should pass message arguments to tool call", func(t *testing.T) {
+		ctx := context.Background()
+
+		config := Config{
+			BaseURL: ts.URL,
+			Model:   "tool-call",
+		}
+		cli := NewClient(config, NewMemorySessionStore(0, util.NewClock()))
+		cli.RegisterFunction(NewFunction("add", "Adds two numbers", addFunc))
+		cli.Init()
+
+		msg, err := cli.Chat(ctx, "session-1", `add {"a":2,"b":3}`)
+		assert.NoError(t, err)
+		assert.Equal(t, "```json\n"+`{"data":{"sum":5}}`+"\n```", msg)
+	})
+
 	t.Run("should handle tool error and send it in response", func(t *testing.T) {
 		ctx := context.Background()
 		called := false
@@ -57,14 +77,14 @@ func TestClient_Chat(t *testing.T) {
 			BaseURL: ts.URL,
 			Model:   "tool-call",
 		}
-		cli := NewClient(config)
+		cli := NewClient(config, NewMemorySessionStore(0, util.NewClock()))
 		cli.RegisterFunction(NewFunction("test", "desc", func(context.Context, struct{}) (any, error) {
 			called = true
 			return nil, errors.New("failed to process request")
 		}))
 		cli.Init()
 
-		msg, err := cli.Chat(ctx, "test")
+		msg, err := cli.Chat(ctx, "session-1", "test")
 		assert.NoError(t, err)
 		assert.Equal(t, "```json\n"+`{"error":"function execution failed: failed to process request"}`+"\n```", msg)
 		assert.True(t, called)
@@ -75,10 +95,10 @@ func TestClient_Chat(t *testing.T) {
 			BaseURL: ts.URL,
 			Model:   "tool-call",
 		}
-		cli := NewClient(config)
+		cli := NewClient(config, NewMemorySessionStore(0, util.NewClock()))
 		cli.Init()
 
-		msg, err := cli.Chat(ctx, "notfound")
+		msg, err := cli.Chat(ctx, "session-1", "notfound")
 
 		assert.Empty(t, msg)
 		assert.Error(t, err)
@@ -91,19 +111,225 @@ func TestClient_Chat(t *testing.T) {
 			BaseURL: ts.URL,
 			Model:   "tool-call",
 		}
-		cli := NewClient(config)
+		cli := NewClient(config, NewMemorySessionStore(0, util.NewClock()))
 		cli.RegisterFunction(NewFunction("bad", "desc", func(context.Context, struct{}) (any, error) {
 			return make(chan int), nil
 		}))
 		cli.Init()
 
-		msg, err := cli.Chat(ctx, "bad")
+		msg, err := cli.Chat(ctx, "session-1", "bad")
 		assert.Empty(t, msg)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "error marshalling function bad response")
 	})
 }
 
+func TestClient_ChatStream(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestServer(t)
+	defer ts.Close()
+
+	t.Run("should emit text deltas and return full message", func(t *testing.T) {
+		cli := NewClient(Config{BaseURL: ts.URL, Model: "echo"}, NewMemorySessionStore(0, util.NewClock()))
+		cli.Init()
+
+		var events []Event
+		msg, err := cli.ChatStream(ctx, "session-1", "Hello, streaming world!", func(event Event) error {
+			events = append(events, event)
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Hello, streaming world!", msg)
+		assert.Equal(t, []Event{
+			{Type: EventDelta, Content: "Hello, "},
+			{Type: EventDelta, Content: "streaming "},
+			{Type: EventDelta, Content: "world!"},
+		}, events)
+	})
+
+	t.Run("should emit tool call start and finish", func(t *testing.T) {
+		cli := NewClient(Config{BaseURL: ts.URL, Model: "tool-call"}, NewMemorySessionStore(0, util.NewClock()))
+		cli.RegisterFunction(NewFunction("add", "Adds two numbers", addFunc))
+		cli.Init()
+
+		var events []Event
+		msg, err := cli.ChatStream(ctx, "session-1", `add {"a":2,"b":3}`, func(event Event) error {
+			events = append(events, event)
+			return nil
+		})
+
+		require.NoError(t, err)
+		result := "```json\n" + `{"data":{"sum":5}}` + "\n```"
+		assert.Equal(t, result, msg)
+
+		call := &ToolCall{ID: "1", Name: "add", Arguments: `{"a":2,"b":3}`}
+		require.Len(t, events, 3)
+		assert.Equal(t, Event{Type: EventToolCallStart, ToolCall: call}, events[0])
+		assert.Equal(t, Event{Type: EventToolCallFinish, Content: `{"data":{"sum":5}}`, ToolCall: call}, events[1])
+		assert.Equal(t, Event{Type: EventDelta, Content: result}, events[2])
+	})
+
+	t.Run("should save streamed messages in session", func(t *testing.T) {
+		sessions := NewMemorySessionStore(0, util.NewClock())
+		cli := NewClient(Config{BaseURL: ts.URL, Model: "tool-call"}, sessions)
+		cli.RegisterFunction(NewFunction("add", "Adds two numbers", addFunc))
+		cli.Init()
+
+		_, err := cli.ChatStream(ctx, "session-1", `add {"a":2,"b":3}`, func(Event) error { return nil })
+		require.NoError(t, err)
+
+		session, err := sessions.Get(ctx, "session-1")
+		require.NoError(t, err)
+		require.Len(t, session.Messages, 4)
+		assert.Equal(t, []ToolCall{{ID: "1", Name: "add", Arguments: `{"a":2,"b":3}`}}, session.Messages[1].ToolCalls)
+		assert.Equal(t, RoleTool, session.Messages[2].Role)
+	})
+
+	t.Run("should stop and not save session when emit fails", func(t *testing.T) {
+		sessions := NewMemorySessionStore(0, util.NewClock())
+		cli := NewClient(Config{BaseURL: ts.URL, Model: "echo"}, sessions)
+		cli.Init()
+
+		calls := 0
+		msg, err := cli.ChatStream(ctx, "session-1", "Hello, world!", func(Event) error {
+			calls++
+			return errors.New("client disconnected")
+		})
+
+		assert.Empty(t, msg)
+		assert.EqualError(t, err, "client disconnected")
+		assert.Equal(t, 1, calls)
+
+		_, err = sessions.Get(ctx, "session-1")
+		assert.ErrorIs(t, err, ErrSessionNotFound)
+	})
+}
+
+func TestClient_Sessions(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestServer(t)
+	defer ts.Close()
+
+	config := Config{
+		BaseURL: ts.URL,
+		Model:   "echo",
+	}
+
+	t.Run("should keep conversation history per session", func(t *testing.T) {
+		cli := NewClient(config, NewMemorySessionStore(0, util.NewClock()))
+		cli.Init()
+
+		_, err := cli.Chat(ctx, "session-a", "Hello from A")
+		require.NoError(t, err)
+		_, err = cli.Chat(ctx, "session-b", "Hello from B")
+		require.NoError(t, err)
+		_, err = cli.Chat(ctx, "session-a", "Bye from A")
+		require.NoError(t, err)
+
+		sessionA, err := cli.GetSession(ctx, "session-a")
+		require.NoError(t, err)
+		assert.Equal(t, []Message{
+			{Role: RoleUser, Content: "Hello from A"},
+			{Role: RoleAssistant, Content: "Hello from A"},
+			{Role: RoleUser, Content: "Bye from A"},
+			{Role: RoleAssistant, Content: "Bye from A"},
+		}, sessionA.Messages)
+
+		sessionB, err := cli.GetSession(ctx, "session-b")
+		require.NoError(t, err)
+		assert.Equal(t, []Message{
+			{Role: RoleUser, Content: "Hello from B"},
+			{Role: RoleAssistant, Content: "Hello from B"},
+		}, sessionB.Messages)
+	})
+
+	t.Run("should record tool calls in session history", func(t *testing.T) {
+		cli := NewClient(Config{BaseURL: ts.URL, Model: "tool-call"}, NewMemorySessionStore(0, util.NewClock()))
+		cli.RegisterFunction(NewFunction("add", "Adds two numbers", addFunc))
+		cli.Init()
+
+		_, err := cli.Chat(ctx, "session-1", `add {"a":1,"b":1}`)
+		require.NoError(t, err)
+
+		session, err := cli.GetSession(ctx, "session-1")
+		require.NoError(t, err)
+		assert.Equal(t, []Message{
+			{Role: RoleUser, Content: `add {"a":1,"b":1}`},
+			{Role: RoleAssistant, ToolCalls: []ToolCall{{ID: "1", Name: "add", Arguments: `{"a":1,"b":1}`}}},
+			{Role: RoleTool, Content: "```json\n" + `{"data":{"sum":2}}` + "\n```", ToolCallID: "1"},
+			{Role: RoleAssistant, Content: "```json\n" + `{"data":{"sum":2}}` + "\n```"},
+		}, session.Messages)
+	})
+
+	t.Run("should not save session when chat fails", func(t *testing.T) {
+		cli := NewClient(Config{BaseURL: ts.URL, Model: "tool-call"}, NewMemorySessionStore(0, util.NewClock()))
+		cli.Init()
+
+		_, err := cli.Chat(ctx, "session-1", "notfound")
+		require.Error(t, err)
+
+		_, err = cli.GetSession(ctx, "session-1")
+		assert.ErrorIs(t, err, ErrSessionNotFound)
+	})
+
+	t.Run("should delete session", func(t *testing.T) {
+		cli := NewClient(config, NewMemorySessionStore(0, util.NewClock()))
+		cli.Init()
+
+		_, err := cli.Chat(ctx, "session-1", "Hello")
+		require.NoError(t, err)
+
+		require.NoError(t, cli.DeleteSession(ctx, "session-1"))
+
+		_, err = cli.GetSession(ctx, "session-1")
+		assert.ErrorIs(t, err, ErrSessionNotFound)
+		assert.ErrorIs(t, cli.DeleteSession(ctx, "session-1"), ErrSessionNotFound)
+	})
+
+	t.Run("should not continue the session of another owner", func(t *testing.T) {
+		cli := NewClient(config, NewMemorySessionStore(0, util.NewClock()))
+		cli.Init()
+
+		_, err := cli.Chat(WithOwner(ctx, "alice"), "session-1", "Hello")
+		require.NoError(t, err)
+
+		_, err = cli.Chat(WithOwner(ctx, "bob"), "session-1", "Hello")
+		assert.ErrorIs(t, err, ErrSessionNotFound)
+		_, err = cli.Chat(ctx, "session-1", "Hello")
+		assert.ErrorIs(t, err, ErrSessionNotFound)
+
+		session, err := cli.GetSession(ctx, "session-1")
+		require.NoError(t, err)
+		assert.Equal(t, "alice", session.Owner)
+		assert.Len(t, session.Messages, 2)
+	})
+
+	t.Run("should handle concurrent chats", func(t *testing.T) {
+		cli := NewClient(config, NewMemorySessionStore(0, util.NewClock()))
+		cli.Init()
+
+		var wg sync.WaitGroup
+		for i := range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := cli.Chat(ctx, fmt.Sprintf("session-%d", i%2), "Hello")
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		for _, id := range []string{"session-0", "session-1"} {
+			session, err := cli.GetSession(ctx, id)
+			require.NoError(t, err)
+			assert.Len(t, session.Messages, 10)
+		}
+	})
+}
+
 func TestClient_RegisterFunction(t *testing.T) {
 	t.Run("should register a single function", func(t *testing.T) {
 		config := Config{
@@ -114,7 +340,7 @@ func TestClient_RegisterFunction(t *testing.T) {
 			AppDescription: "Test app desc",
 		}
 
-		cli := NewClient(config)
+		cli := NewClient(config, NewMemorySessionStore(0, util.NewClock()))
 
 		fn := NewFunction("fn1", "desc1", func(context.Context, struct{}) (struct{}, error) { return struct{}{}, nil })
 		cli.RegisterFunction(fn)
@@ -134,7 +360,7 @@ func TestClient_RegisterFunctions(t *testing.T) {
 			AppName:        "TestApp",
 			AppDescription: "Test app desc",
 		}
-		cli := NewClient(config)
+		cli := NewClient(config, NewMemorySessionStore(0, util.NewClock()))
 		fn1 := NewFunction("fn1", "desc1", func(context.Context, struct{}) (struct{}, error) { return struct{}{}, nil })
 		fn2 := NewFunction("fn2", "desc2", func(context.Context, struct{}) (struct{}, error) { return struct{}{}, nil })
 
@@ -157,7 +383,7 @@ func TestClient_Init(t *testing.T) {
 			AppName:        "TestApp",
 			AppDescription: "Test app desc",
 		}
-		cli := NewClient(config)
+		cli := NewClient(config, NewMemorySessionStore(0, util.NewClock()))
 		cli.RegisterFunction(NewFunction("fn", "desc", func(context.Context, struct{}) (struct{}, error) { return struct{}{}, nil }))
 
 		cli.Init()
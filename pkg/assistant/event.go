@@ -0,0 +1,27 @@
+package assistant
+
+// EventType identifies the kind of progress reported while a chat response is streamed.
+type EventType string
+
+const (
+	// EventDelta carries the next chunk of text generated by the LLM.
+	EventDelta EventType = "delta"
+	// EventToolCallStart is reported before a function requested by the LLM is called.
+	EventToolCallStart EventType = "tool_call_start"
+	// EventToolCallFinish is reported after a function requested by the LLM has returned.
+	EventToolCallFinish EventType = "tool_call_finish"
+)
+
+// Event is a progress update of a streamed chat response.
+type Event struct {
+	// Type is the kind of the event.
+	Type EventType `json:"type"`
+	// Content is the text chunk of a delta event or the JSON encoded result of a finished function call.
+	Content string `json:"content,omitempty"`
+	// ToolCall is the function call of a tool call event.
+	ToolCall *ToolCall `json:"toolCall,omitempty"`
+}
+
+// EmitFunc receives the events of a streamed chat response.
+// Returning an error stops the chat, e.g. when the receiver has disconnected.
+type EmitFunc func(event Event) error
@@ -0,0 +1,220 @@
+package assistant
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/utsabbera/task-master/pkg/util"
+)
+
+// ErrSessionNotFound is returned when a chat session doesn't exist or has expired.
+var ErrSessionNotFound = errors.New("session not found")
+
+// Role identifies the author of a message in a chat session.
+type Role string
+
+const (
+	// RoleUser is the role of messages sent by the user.
+	RoleUser Role = "user"
+	// RoleAssistant is the role of messages generated by the LLM.
+	RoleAssistant Role = "assistant"
+	// RoleTool is the role of messages holding the result of a function call.
+	RoleTool Role = "tool"
+)
+
+// ToolCall is a function call requested by the LLM.
+type ToolCall struct {
+	// ID is the identifier of the call, referenced by the tool message holding its result.
+	ID string `json:"id"`
+	// Name is the name of the called function.
+	Name string `json:"name"`
+	// Arguments are the JSON encoded arguments of the call.
+	Arguments string `json:"arguments"`
+}
+
+// Message is a single entry of the conversation history of a session.
+type Message struct {
+	// Role is the author of the message.
+	Role Role `json:"role"`
+	// Content is the text of the message.
+	Content string `json:"content"`
+	// ToolCalls are the function calls requested by an assistant message.
+	ToolCalls []ToolCall `json:"toolCalls,omitempty"`
+	// ToolCallID is the ID of the call a tool message responds to.
+	ToolCallID string `json:"toolCallId,omitempty"`
+}
+
+// Session is an isolated conversation between a user and the LLM.
+type Session struct {
+	// ID is the unique identifier of the session.
+	ID string `json:"id"`
+	// Owner is the ID of the user who started the session, empty when started without one.
+	Owner string `json:"owner,omitempty"`
+	// Messages is the conversation history, without the system prompt.
+	Messages []Message `json:"messages"`
+	// CreatedAt stores when the session was started.
+	CreatedAt time.Time `json:"createdAt"`
+	// UpdatedAt stores when the session was last saved.
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type ownerKey struct{}
+
+// WithOwner returns a copy of the context carrying the ID of the user chatting,
+// who becomes the owner of the sessions started with it.
+func WithOwner(ctx context.Context, owner string) context.Context {
+	return context.WithValue(ctx, ownerKey{}, owner)
+}
+
+// OwnerFromContext returns the ID of the user chatting carried by the context, empty when there is none.
+func OwnerFromContext(ctx context.Context) string {
+	owner, _ := ctx.Value(ownerKey{}).(string)
+	return owner
+}
+
+// NewSessionID returns a new random, hard to guess session ID.
+func NewSessionID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func (s *Session) clone() *Session {
+	c := *s
+	c.Messages = slices.Clone(s.Messages)
+	return &c
+}
+
+//go:generate mockgen -destination=session_mock.go -package=assistant . SessionStore
+
+// SessionStore defines the interface for storing chat sessions.
+type SessionStore interface {
+	// Get retrieves a session by its ID.
+	// Returns ErrSessionNotFound if the session doesn't exist or has expired.
+	Get(ctx context.Context, id string) (*Session, error)
+
+	// Save creates or replaces a session.
+	Save(ctx context.Context, session *Session) error
+
+	// Delete removes a session by its ID.
+	// Returns ErrSessionNotFound if the session doesn't exist or has expired.
+	Delete(ctx context.Context, id string) error
+}
+
+// MemorySessionStore is an in-memory implementation of SessionStore
+// that evicts sessions which have not been saved within the TTL.
+type MemorySessionStore struct {
+	sessions map[string]*Session
+	ttl      time.Duration
+	clock    util.Clock
+	mu       sync.Mutex
+}
+
+// NewMemorySessionStore creates a new memory session store.
+// Sessions never expire if ttl is zero or negative.
+func NewMemorySessionStore(ttl time.Duration, clock util.Clock) *MemorySessionStore {
+	return &MemorySessionStore{
+		sessions: make(map[string]*Session),
+		ttl:      ttl,
+		clock:    clock,
+	}
+}
+
+func (s *MemorySessionStore) Get(_ context.Context, id string) (*Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, exists := s.sessions[id]
+	if !exists || s.expired(session, s.clock.Now()) {
+		delete(s.sessions, id)
+		return nil, ErrSessionNotFound
+	}
+
+	return session.clone(), nil
+}
+
+func (s *MemorySessionStore) Save(_ context.Context, session *Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	s.evict(now)
+
+	if session.CreatedAt.IsZero() {
+		session.CreatedAt = now
+	}
+	session.UpdatedAt = now
+
+	s.sessions[session.ID] = session.clone()
+	return nil
+}
+
+func (s *MemorySessionStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, exists := s.sessions[id]
+	if !exists || s.expired(session, s.clock.Now()) {
+		delete(s.sessions, id)
+		return ErrSessionNotFound
+	}
+
+	delete(s.sessions, id)
+	return nil
+}
+
+func (s *MemorySessionStore) expired(session *Session, now time.Time) bool {
+	return s.ttl > 0 && now.Sub(session.UpdatedAt) > s.ttl
+}
+
+func (s *MemorySessionStore) evict(now time.Time) {
+	for id, session := range s.sessions {
+		if s.expired(session, now) {
+			delete(s.sessions, id)
+		}
+	}
+}
+
+type sessionLocks struct {
+	locks map[string]*sessionLock
+	mu    sync.Mutex
+}
+
+type sessionLock struct {
+	sync.Mutex
+	refs int
+}
+
+// lock serializes the chats of a session and returns the function releasing the lock.
+func (l *sessionLocks) lock(id string) func() {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = make(map[string]*sessionLock)
+	}
+	lock, exists := l.locks[id]
+	if !exists {
+		lock = &sessionLock{}
+		l.locks[id] = lock
+	}
+	lock.refs++
+	l.mu.Unlock()
+
+	lock.Lock()
+
+	return func() {
+		lock.Unlock()
+
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		lock.refs--
+		if lock.refs == 0 {
+			delete(l.locks, id)
+		}
+	}
+}
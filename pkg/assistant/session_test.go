@@ -0,0 +1,145 @@
+package assistant
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/pkg/util"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMemorySessionStore(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should save and get session", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		clock := util.NewMockClock(ctrl)
+		clock.EXPECT().Now().Return(start).AnyTimes()
+		store := NewMemorySessionStore(time.Minute, clock)
+
+		session := &Session{ID: "session-1", Messages: []Message{{Role: RoleUser, Content: "Hello"}}}
+		require.NoError(t, store.Save(ctx, session))
+
+		result, err := store.Get(ctx, "session-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, session.Messages, result.Messages)
+		assert.Equal(t, start, result.CreatedAt)
+		assert.Equal(t, start, result.UpdatedAt)
+	})
+
+	t.Run("should not share messages with the caller", func(t *testing.T) {
+		store := NewMemorySessionStore(0, util.NewClock())
+
+		session := &Session{ID: "session-1", Messages: []Message{{Role: RoleUser, Content: "Hello"}}}
+		require.NoError(t, store.Save(ctx, session))
+		session.Messages[0].Content = "Changed"
+
+		result, err := store.Get(ctx, "session-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Hello", result.Messages[0].Content)
+	})
+
+	t.Run("should return error when session not found", func(t *testing.T) {
+		store := NewMemorySessionStore(0, util.NewClock())
+
+		result, err := store.Get(ctx, "unknown")
+
+		assert.ErrorIs(t, err, ErrSessionNotFound)
+		assert.Nil(t, result)
+	})
+
+	t.Run("should evict session after ttl", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		clock := util.NewMockClock(ctrl)
+		store := NewMemorySessionStore(time.Minute, clock)
+
+		clock.EXPECT().Now().Return(start)
+		require.NoError(t, store.Save(ctx, &Session{ID: "session-1"}))
+
+		clock.EXPECT().Now().Return(start.Add(time.Minute))
+		_, err := store.Get(ctx, "session-1")
+		require.NoError(t, err)
+
+		clock.EXPECT().Now().Return(start.Add(time.Minute + time.Second))
+		_, err = store.Get(ctx, "session-1")
+		assert.ErrorIs(t, err, ErrSessionNotFound)
+	})
+
+	t.Run("should extend ttl when session is saved", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		clock := util.NewMockClock(ctrl)
+		store := NewMemorySessionStore(time.Minute, clock)
+
+		clock.EXPECT().Now().Return(start)
+		require.NoError(t, store.Save(ctx, &Session{ID: "session-1"}))
+
+		clock.EXPECT().Now().Return(start.Add(50 * time.Second))
+		session, err := store.Get(ctx, "session-1")
+		require.NoError(t, err)
+
+		clock.EXPECT().Now().Return(start.Add(50 * time.Second))
+		require.NoError(t, store.Save(ctx, session))
+
+		clock.EXPECT().Now().Return(start.Add(100 * time.Second))
+		result, err := store.Get(ctx, "session-1")
+		require.NoError(t, err)
+		assert.Equal(t, start, result.CreatedAt)
+	})
+
+	t.Run("should evict expired sessions on save", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		clock := util.NewMockClock(ctrl)
+		store := NewMemorySessionStore(time.Minute, clock)
+
+		clock.EXPECT().Now().Return(start)
+		require.NoError(t, store.Save(ctx, &Session{ID: "session-1"}))
+
+		clock.EXPECT().Now().Return(start.Add(2 * time.Minute))
+		require.NoError(t, store.Save(ctx, &Session{ID: "session-2"}))
+
+		assert.NotContains(t, store.sessions, "session-1")
+		assert.Contains(t, store.sessions, "session-2")
+	})
+
+	t.Run("should delete session", func(t *testing.T) {
+		store := NewMemorySessionStore(0, util.NewClock())
+		require.NoError(t, store.Save(ctx, &Session{ID: "session-1"}))
+
+		require.NoError(t, store.Delete(ctx, "session-1"))
+
+		_, err := store.Get(ctx, "session-1")
+		assert.ErrorIs(t, err, ErrSessionNotFound)
+	})
+
+	t.Run("should return error when deleting unknown session", func(t *testing.T) {
+		store := NewMemorySessionStore(0, util.NewClock())
+
+		err := store.Delete(ctx, "unknown")
+
+		assert.ErrorIs(t, err, ErrSessionNotFound)
+	})
+}
+
+func TestNewSessionID(t *testing.T) {
+	t.Run("should generate unique ids", func(t *testing.T) {
+		first := NewSessionID()
+		second := NewSessionID()
+
+		assert.Len(t, first, 32)
+		assert.NotEqual(t, first, second)
+	})
+}
@@ -2,8 +2,11 @@ package assistant
 
 import (
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/openai/openai-go"
@@ -15,7 +18,11 @@ import (
 //
 // Supported models:
 //   - "echo": Responds with the user's message content as the reply.
-//   - "tool-call": If the last message is a tool message, replies with its content. Otherwise, replies with a tool call where the function name matches the user's message.
+//   - "tool-call": If the last message is a tool message, replies with its content. Otherwise, replies with a tool call where
+//     the function name is the first word of the user's message and the arguments are the rest of the message, or "{}" if empty.
+//
+// Streaming requests are answered with Server-Sent Events, sending the reply content word by word
+// and each tool call in two chunks, its name followed by its arguments.
 //
 // The server uses testify/require for request validation and fails the test on unexpected input or model.
 //
@@ -24,69 +31,119 @@ import (
 //	ts := NewTestServer(t)
 //	defer ts.Close()
 //	config := Config{BaseURL: ts.URL, Model: "echo"}
-//	cli := NewClient(config)
+//	cli := NewClient(config, NewMemorySessionStore(0, util.NewClock()))
 //	cli.Init()
-//	msg, err := cli.Chat(context.TODO(), "Hello, world!")
+//	msg, err := cli.Chat(context.TODO(), "session-1", "Hello, world!")
 //
 // Use this helper to simulate LLM responses in tests without real API calls.
 func NewTestServer(t *testing.T) *httptest.Server {
 	t.Helper()
 
 	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		var params openai.ChatCompletionNewParams
-		err := json.NewDecoder(r.Body).Decode(&params)
+		body, err := io.ReadAll(r.Body)
 		require.NoError(t, err)
 
+		var params openai.ChatCompletionNewParams
+		require.NoError(t, json.Unmarshal(body, &params))
+
+		var options struct {
+			Stream bool `json:"stream"`
+		}
+		require.NoError(t, json.Unmarshal(body, &options))
+
 		message := params.Messages[len(params.Messages)-1]
 
+		var reply openai.ChatCompletionMessage
+
 		switch params.Model {
 		case "echo":
-			resp := openai.ChatCompletion{
-				Choices: []openai.ChatCompletionChoice{
-					{
-						Message: openai.ChatCompletionMessage{
-							Content: message.OfUser.Content.OfString.String(),
-						},
-					},
-				},
+			reply = openai.ChatCompletionMessage{
+				Content: message.OfUser.Content.OfString.String(),
 			}
 
-			w.Header().Set("Content-Type", "application/json")
-			require.NoError(t, json.NewEncoder(w).Encode(resp))
-			return
-
 		case "tool-call":
 			if toolMessage := message.OfTool; toolMessage != nil {
-				resp := openai.ChatCompletion{
-					Choices: []openai.ChatCompletionChoice{{Message: openai.ChatCompletionMessage{
-						Content: toolMessage.Content.OfString.String(),
-					}}},
+				reply = openai.ChatCompletionMessage{
+					Content: toolMessage.Content.OfString.String(),
 				}
-
-				w.Header().Set("Content-Type", "application/json")
-				require.NoError(t, json.NewEncoder(w).Encode(resp))
-				return
+				break
 			}
 
-			resp := openai.ChatCompletion{
-				Choices: []openai.ChatCompletionChoice{{Message: openai.ChatCompletionMessage{
-					Content: "",
-					ToolCalls: []openai.ChatCompletionMessageToolCall{{
-						ID: "1",
-						Function: openai.ChatCompletionMessageToolCallFunction{
-							Name:      message.OfUser.Content.OfString.String(),
-							Arguments: "{}",
-						},
-					}},
-				}}},
+			name, args, _ := strings.Cut(message.OfUser.Content.OfString.String(), " ")
+			if args == "" {
+				args = "{}"
 			}
 
-			w.Header().Set("Content-Type", "application/json")
-			require.NoError(t, json.NewEncoder(w).Encode(resp))
-			return
+			reply = openai.ChatCompletionMessage{
+				Content: "",
+				ToolCalls: []openai.ChatCompletionMessageToolCall{{
+					ID: "1",
+					Function: openai.ChatCompletionMessageToolCallFunction{
+						Name:      name,
+						Arguments: args,
+					},
+				}},
+			}
 
 		default:
 			require.Fail(t, "Unexpected model: %s", params.Model)
 		}
+
+		if options.Stream {
+			writeTestStream(t, w, reply)
+			return
+		}
+
+		resp := openai.ChatCompletion{
+			Choices: []openai.ChatCompletionChoice{{Message: reply}},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
 	}))
 }
+
+func writeTestStream(t *testing.T, w http.ResponseWriter, reply openai.ChatCompletionMessage) {
+	t.Helper()
+
+	chunks := make([]openai.ChatCompletionChunkChoiceDelta, 0)
+
+	if reply.Content != "" {
+		for _, word := range strings.SplitAfter(reply.Content, " ") {
+			chunks = append(chunks, openai.ChatCompletionChunkChoiceDelta{Content: word})
+		}
+	}
+
+	for i, call := range reply.ToolCalls {
+		chunks = append(chunks,
+			openai.ChatCompletionChunkChoiceDelta{ToolCalls: []openai.ChatCompletionChunkChoiceDeltaToolCall{{
+				Index:    int64(i),
+				ID:       call.ID,
+				Type:     "function",
+				Function: openai.ChatCompletionChunkChoiceDeltaToolCallFunction{Name: call.Function.Name},
+			}}},
+			openai.ChatCompletionChunkChoiceDelta{ToolCalls: []openai.ChatCompletionChunkChoiceDeltaToolCall{{
+				Index:    int64(i),
+				Function: openai.ChatCompletionChunkChoiceDeltaToolCallFunction{Arguments: call.Function.Arguments},
+			}}},
+		)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+
+	for _, delta := range chunks {
+		chunk := openai.ChatCompletionChunk{
+			ID:      "chatcmpl-test",
+			Choices: []openai.ChatCompletionChunkChoice{{Delta: delta}},
+		}
+
+		data, err := json.Marshal(chunk)
+		require.NoError(t, err)
+
+		_, err = fmt.Fprintf(w, "data: %s\n\n", data)
+		require.NoError(t, err)
+	}
+
+	_, err := fmt.Fprint(w, "data: [DONE]\n\n")
+	require.NoError(t, err)
+}
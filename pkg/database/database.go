@@ -0,0 +1,55 @@
+// Package database provides helpers to open SQL databases and migrate their schema.
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+
+	"modernc.org/sqlite"
+)
+
+// LowerFunction names the SQL function of the SQLite databases returning its text in lower case as strings.ToLower does,
+// unlike the lower function of SQLite which only changes the ASCII letters.
+const LowerFunction = "unicode_lower"
+
+func init() {
+	sqlite.MustRegisterDeterministicScalarFunction(LowerFunction, 1, func(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		switch value := args[0].(type) {
+		case string:
+			return strings.ToLower(value), nil
+		case []byte:
+			return strings.ToLower(string(value)), nil
+		default:
+			return value, nil
+		}
+	})
+}
+
+// OpenSQLite opens the SQLite database at the given data source name, e.g. a file path or ":memory:".
+// Foreign keys are enforced and connections are limited to one, since SQLite serializes writes
+// and every connection to an in-memory database opens a new empty database.
+func OpenSQLite(ctx context.Context, dsn string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("error opening database: %w", err)
+	}
+
+	db.SetMaxOpenConns(1)
+
+	pragmas := []string{
+		"PRAGMA foreign_keys = ON",
+		"PRAGMA busy_timeout = 5000",
+	}
+
+	for _, pragma := range pragmas {
+		if _, err := db.ExecContext(ctx, pragma); err != nil {
+			_ = db.Close()
+			return nil, fmt.Errorf("error configuring database: %w", err)
+		}
+	}
+
+	return db, nil
+}
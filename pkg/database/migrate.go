@@ -0,0 +1,116 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Migration is a versioned change of the database schema.
+type Migration struct {
+	// Version orders the migrations, it is parsed from the numeric prefix of the file name.
+	Version int
+	// Name is the file name of the migration.
+	Name string
+	// SQL holds the statements applied by the migration.
+	SQL string
+}
+
+// Migrations reads the migrations from the .sql files at the root of the given file system.
+// File names must start with the version followed by an underscore, e.g. 0001_create_tasks.sql.
+func Migrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("error reading migrations: %w", err)
+	}
+
+	migrations := make([]Migration, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+
+		prefix, _, found := strings.Cut(entry.Name(), "_")
+		version, err := strconv.Atoi(prefix)
+		if !found || err != nil {
+			return nil, fmt.Errorf("invalid migration file name %s", entry.Name())
+		}
+
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("error reading migration %s: %w", entry.Name(), err)
+		}
+
+		migrations = append(migrations, Migration{Version: version, Name: entry.Name(), SQL: string(content)})
+	}
+
+	slices.SortFunc(migrations, func(a, b Migration) int { return a.Version - b.Version })
+
+	for i := 1; i < len(migrations); i++ {
+		if migrations[i].Version == migrations[i-1].Version {
+			return nil, fmt.Errorf("duplicate migration version %d", migrations[i].Version)
+		}
+	}
+
+	return migrations, nil
+}
+
+// Migrate applies the migrations found in the given file system which have not been applied yet.
+// Applied versions are recorded in the schema_migrations table and every migration runs in its own transaction.
+func Migrate(ctx context.Context, db *sql.DB, fsys fs.FS) error {
+	return MigrateTable(ctx, db, fsys, "schema_migrations")
+}
+
+// MigrateTable applies the migrations like Migrate, recording their versions in the given table instead,
+// so that the schemas of several packages sharing a database are versioned independently.
+func MigrateTable(ctx context.Context, db *sql.DB, fsys fs.FS, table string) error {
+	migrations, err := Migrations(fsys)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+table+` (version INTEGER PRIMARY KEY)`)
+	if err != nil {
+		return fmt.Errorf("error creating %s table: %w", table, err)
+	}
+
+	var current int
+	err = db.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM `+table).Scan(&current)
+	if err != nil {
+		return fmt.Errorf("error reading schema version: %w", err)
+	}
+
+	for _, migration := range migrations {
+		if migration.Version <= current {
+			continue
+		}
+
+		if err := apply(ctx, db, table, migration); err != nil {
+			return fmt.Errorf("error applying migration %s: %w", migration.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func apply(ctx context.Context, db *sql.DB, table string, migration Migration) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, migration.SQL); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `INSERT INTO `+table+` (version) VALUES (?)`, migration.Version); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,109 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrations(t *testing.T) {
+	t.Run("should return migrations sorted by version", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"0002_add_index.sql":    {Data: []byte("CREATE INDEX idx ON items (name);")},
+			"0001_create_items.sql": {Data: []byte("CREATE TABLE items (name TEXT);")},
+			"README.md":             {Data: []byte("ignored")},
+		}
+
+		migrations, err := Migrations(fsys)
+
+		require.NoError(t, err)
+		assert.Equal(t, []Migration{
+			{Version: 1, Name: "0001_create_items.sql", SQL: "CREATE TABLE items (name TEXT);"},
+			{Version: 2, Name: "0002_add_index.sql", SQL: "CREATE INDEX idx ON items (name);"},
+		}, migrations)
+	})
+
+	t.Run("should return error for file without version", func(t *testing.T) {
+		fsys := fstest.MapFS{"create_items.sql": {Data: []byte("")}}
+
+		_, err := Migrations(fsys)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should return error for duplicate versions", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"0001_create_items.sql": {Data: []byte("")},
+			"0001_create_other.sql": {Data: []byte("")},
+		}
+
+		_, err := Migrations(fsys)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestMigrate(t *testing.T) {
+	t.Run("should apply pending migrations only once", func(t *testing.T) {
+		ctx := context.Background()
+		db, err := OpenSQLite(ctx, ":memory:")
+		require.NoError(t, err)
+		defer db.Close()
+
+		fsys := fstest.MapFS{
+			"0001_create_items.sql": {Data: []byte("CREATE TABLE items (name TEXT);")},
+		}
+		require.NoError(t, Migrate(ctx, db, fsys))
+		require.NoError(t, Migrate(ctx, db, fsys))
+
+		fsys["0002_seed_items.sql"] = &fstest.MapFile{Data: []byte("INSERT INTO items (name) VALUES ('a'), ('b');")}
+		require.NoError(t, Migrate(ctx, db, fsys))
+
+		var count int
+		require.NoError(t, db.QueryRowContext(ctx, "SELECT COUNT(*) FROM items").Scan(&count))
+		assert.Equal(t, 2, count)
+
+		var version int
+		require.NoError(t, db.QueryRowContext(ctx, "SELECT MAX(version) FROM schema_migrations").Scan(&version))
+		assert.Equal(t, 2, version)
+	})
+
+	t.Run("should record versions in the given table", func(t *testing.T) {
+		ctx := context.Background()
+		db, err := OpenSQLite(ctx, ":memory:")
+		require.NoError(t, err)
+		defer db.Close()
+
+		require.NoError(t, Migrate(ctx, db, fstest.MapFS{
+			"0001_create_items.sql": {Data: []byte("CREATE TABLE items (name TEXT);")},
+		}))
+		require.NoError(t, MigrateTable(ctx, db, fstest.MapFS{
+			"0001_create_orders.sql": {Data: []byte("CREATE TABLE orders (name TEXT);")},
+		}, "order_migrations"))
+
+		var count int
+		require.NoError(t, db.QueryRowContext(ctx, "SELECT COUNT(*) FROM sqlite_master WHERE name IN ('items', 'orders')").Scan(&count))
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("should roll back failed migration", func(t *testing.T) {
+		ctx := context.Background()
+		db, err := OpenSQLite(ctx, ":memory:")
+		require.NoError(t, err)
+		defer db.Close()
+
+		fsys := fstest.MapFS{
+			"0001_create_items.sql": {Data: []byte("CREATE TABLE items (name TEXT); INSERT INTO missing VALUES (1);")},
+		}
+
+		err = Migrate(ctx, db, fsys)
+
+		require.Error(t, err)
+		var count int
+		require.NoError(t, db.QueryRowContext(ctx, "SELECT COUNT(*) FROM sqlite_master WHERE name = 'items'").Scan(&count))
+		assert.Zero(t, count)
+	})
+}
@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// APIKeyHeader is the request header carrying an API key.
+const APIKeyHeader = "X-API-Key"
+
+var (
+	// ErrNoCredentials is returned when a request carries no credentials an authenticator understands.
+	ErrNoCredentials = errors.New("authentication required")
+	// ErrInvalidCredentials is returned when the credentials of a request are unknown, expired or tampered with.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	// Subject identifies the caller, e.g. a user name or the subject of a token.
+	Subject string
+	// Roles are the roles granted to the caller.
+	Roles []string
+	// Method is how the caller was authenticated, e.g. "api_key" or "jwt".
+	Method string
+}
+
+// Authenticator authenticates the caller of a request from its credentials.
+type Authenticator interface {
+	// Authenticate returns the caller of the request.
+	// Returns ErrNoCredentials if the request carries no credentials for this authenticator
+	// and ErrInvalidCredentials if they are not valid.
+	Authenticate(r *http.Request) (Principal, error)
+
+	// Challenge returns the WWW-Authenticate challenge of the authenticator.
+	Challenge() string
+}
+
+// ErrorHandler writes the response of a request which failed authentication.
+type ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)
+
+type principalKey struct{}
+
+// WithPrincipal returns a context carrying the authenticated caller.
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the caller set by WithPrincipal, false if the request is not authenticated.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
+
+// Auth rejects the requests which are not authenticated by any of the authenticators
+// and injects the caller of the others into their context.
+// The authenticators are tried in order, invalid credentials are rejected without trying the next ones.
+// Rejected requests get a WWW-Authenticate header and are answered by onError, with a plain 401 when it is nil.
+func Auth(onError ErrorHandler, authenticators ...Authenticator) Middleware {
+	if onError == nil {
+		onError = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+		}
+	}
+
+	challenges := make([]string, 0, len(authenticators))
+	for _, a := range authenticators {
+		challenges = append(challenges, a.Challenge())
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, err := authenticate(r, authenticators)
+			if err != nil {
+				for _, challenge := range challenges {
+					w.Header().Add("WWW-Authenticate", challenge)
+				}
+				onError(w, r, err)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+func authenticate(r *http.Request, authenticators []Authenticator) (Principal, error) {
+	for _, a := range authenticators {
+		principal, err := a.Authenticate(r)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+		return principal, err
+	}
+
+	return Principal{}, ErrNoCredentials
+}
+
+type apiKeys struct {
+	principals map[[sha256.Size]byte]Principal
+}
+
+// APIKeys returns an authenticator of the static API keys sent in the APIKeyHeader header,
+// each key authenticating the principal it is mapped to.
+func APIKeys(keys map[string]Principal) Authenticator {
+	principals := make(map[[sha256.Size]byte]Principal, len(keys))
+	for key, principal := range keys {
+		principal.Method = "api_key"
+		principals[sha256.Sum256([]byte(key))] = principal
+	}
+
+	return &apiKeys{principals: principals}
+}
+
+func (a *apiKeys) Authenticate(r *http.Request) (Principal, error) {
+	key := strings.TrimSpace(r.Header.Get(APIKeyHeader))
+	if key == "" {
+		return Principal{}, ErrNoCredentials
+	}
+
+	principal, ok := a.principals[sha256.Sum256([]byte(key))]
+	if !ok {
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	return principal, nil
+}
+
+func (a *apiKeys) Challenge() string {
+	return `ApiKey header="` + APIKeyHeader + `"`
+}
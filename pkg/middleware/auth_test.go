@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuth(t *testing.T) {
+	var (
+		principal     Principal
+		authenticated bool
+	)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, authenticated = PrincipalFromContext(r.Context())
+		w.WriteHeader(http.StatusNoContent)
+	})
+	keys := APIKeys(map[string]Principal{"key-1": {Subject: "alice", Roles: []string{"admin"}}})
+	tokens := JWT(JWTConfig{Secret: []byte("secret")})
+	handler := Auth(nil, keys, tokens)(next)
+
+	t.Run("should inject principal of valid API key", func(t *testing.T) {
+		authenticated = false
+		req := httptest.NewRequest(http.MethodGet, "/tasks", nil)
+		req.Header.Set(APIKeyHeader, "key-1")
+		res := httptest.NewRecorder()
+
+		handler.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusNoContent, res.Code)
+		require.True(t, authenticated)
+		assert.Equal(t, Principal{Subject: "alice", Roles: []string{"admin"}, Method: "api_key"}, principal)
+	})
+
+	t.Run("should inject principal of valid bearer token", func(t *testing.T) {
+		authenticated = false
+		req := httptest.NewRequest(http.MethodGet, "/tasks", nil)
+		req.Header.Set("Authorization", "Bearer "+signHS256(t, "secret", `{"sub":"bob"}`))
+		res := httptest.NewRecorder()
+
+		handler.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusNoContent, res.Code)
+		require.True(t, authenticated)
+		assert.Equal(t, "bob", principal.Subject)
+		assert.Equal(t, "jwt", principal.Method)
+	})
+
+	t.Run("should reject request without credentials with challenges", func(t *testing.T) {
+		authenticated = false
+		req := httptest.NewRequest(http.MethodGet, "/tasks", nil)
+		res := httptest.NewRecorder()
+
+		handler.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusUnauthorized, res.Code)
+		assert.False(t, authenticated)
+		assert.Equal(t, []string{`ApiKey header="X-API-Key"`, `Bearer realm="task-master"`}, res.Header().Values("WWW-Authenticate"))
+	})
+
+	t.Run("should reject unknown API key without trying next authenticator", func(t *testing.T) {
+		authenticated = false
+		req := httptest.NewRequest(http.MethodGet, "/tasks", nil)
+		req.Header.Set(APIKeyHeader, "key-2")
+		req.Header.Set("Authorization", "Bearer "+signHS256(t, "secret", `{"sub":"bob"}`))
+		res := httptest.NewRecorder()
+
+		handler.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusUnauthorized, res.Code)
+		assert.False(t, authenticated)
+	})
+
+	t.Run("should answer rejected request with error handler", func(t *testing.T) {
+		var handled error
+		handler := Auth(func(w http.ResponseWriter, r *http.Request, err error) {
+			handled = err
+			w.WriteHeader(http.StatusTeapot)
+		}, keys)(next)
+		req := httptest.NewRequest(http.MethodGet, "/tasks", nil)
+		req.Header.Set(APIKeyHeader, "key-2")
+		res := httptest.NewRecorder()
+
+		handler.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusTeapot, res.Code)
+		assert.ErrorIs(t, handled, ErrInvalidCredentials)
+	})
+}
@@ -0,0 +1,262 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	_ "crypto/sha256" // registers SHA-256 for HS256 and RS256
+	_ "crypto/sha512" // registers SHA-384 and SHA-512 for HS384, HS512, RS384 and RS512
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/utsabbera/task-master/pkg/util"
+)
+
+// JWTConfig holds the keys and the expected claims of the JWT bearer tokens.
+type JWTConfig struct {
+	// Secret verifies the tokens signed with HS256, HS384 or HS512, they are rejected when it is empty.
+	Secret []byte
+	// PublicKey verifies the tokens signed with RS256, RS384 or RS512, they are rejected when it is nil.
+	PublicKey *rsa.PublicKey
+	// Issuer is the expected iss claim, not checked when empty.
+	Issuer string
+	// Audience must be one of the aud claim, not checked when empty.
+	Audience string
+	// Leeway is the clock skew tolerated when checking the exp and nbf claims.
+	Leeway time.Duration
+	// Clock provides the current time, defaults to the system clock.
+	Clock util.Clock
+}
+
+type jwtAlgorithm struct {
+	hash crypto.Hash
+	rsa  bool
+}
+
+var jwtAlgorithms = map[string]jwtAlgorithm{
+	"HS256": {hash: crypto.SHA256},
+	"HS384": {hash: crypto.SHA384},
+	"HS512": {hash: crypto.SHA512},
+	"RS256": {hash: crypto.SHA256, rsa: true},
+	"RS384": {hash: crypto.SHA384, rsa: true},
+	"RS512": {hash: crypto.SHA512, rsa: true},
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+}
+
+type jwtClaims struct {
+	Subject   string       `json:"sub"`
+	Issuer    string       `json:"iss"`
+	Audience  jwtAudience  `json:"aud"`
+	ExpiresAt *json.Number `json:"exp"`
+	NotBefore *json.Number `json:"nbf"`
+	Roles     []string     `json:"roles"`
+}
+
+// jwtAudience is the aud claim, which is either a single string or an array of strings.
+type jwtAudience []string
+
+func (a *jwtAudience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = jwtAudience{single}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return err
+	}
+	*a = multiple
+	return nil
+}
+
+type jwtAuthenticator struct {
+	cfg JWTConfig
+}
+
+// JWT returns an authenticator of the JWT bearer tokens sent in the Authorization header.
+// The principal is the sub claim of the token with the roles of its roles claim.
+func JWT(cfg JWTConfig) Authenticator {
+	if cfg.Clock == nil {
+		cfg.Clock = util.NewClock()
+	}
+
+	return &jwtAuthenticator{cfg: cfg}
+}
+
+func (a *jwtAuthenticator) Authenticate(r *http.Request) (Principal, error) {
+	scheme, token, found := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return Principal{}, ErrNoCredentials
+	}
+
+	claims, err := a.verify(strings.TrimSpace(token))
+	if err != nil {
+		return Principal{}, fmt.Errorf("%w: %w", ErrInvalidCredentials, err)
+	}
+
+	return Principal{Subject: claims.Subject, Roles: claims.Roles, Method: "jwt"}, nil
+}
+
+func (a *jwtAuthenticator) Challenge() string {
+	return `Bearer realm="task-master"`
+}
+
+func (a *jwtAuthenticator) verify(token string) (*jwtClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("token is malformed")
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("error decoding token header: %w", err)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("error decoding token signature: %w", err)
+	}
+
+	if err := a.verifySignature(header.Alg, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, err
+	}
+
+	var claims jwtClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("error decoding token claims: %w", err)
+	}
+
+	if err := a.validateClaims(&claims); err != nil {
+		return nil, err
+	}
+
+	return &claims, nil
+}
+
+func (a *jwtAuthenticator) verifySignature(alg, signed string, signature []byte) error {
+	algorithm, ok := jwtAlgorithms[alg]
+	if !ok {
+		return fmt.Errorf("token algorithm %q is not supported", alg)
+	}
+
+	hash := algorithm.hash.New()
+	hash.Write([]byte(signed))
+	digest := hash.Sum(nil)
+
+	if algorithm.rsa {
+		if a.cfg.PublicKey == nil {
+			return fmt.Errorf("token algorithm %q is not accepted", alg)
+		}
+		if err := rsa.VerifyPKCS1v15(a.cfg.PublicKey, algorithm.hash, digest, signature); err != nil {
+			return errors.New("token signature is invalid")
+		}
+		return nil
+	}
+
+	if len(a.cfg.Secret) == 0 {
+		return fmt.Errorf("token algorithm %q is not accepted", alg)
+	}
+
+	mac := hmac.New(algorithm.hash.New, a.cfg.Secret)
+	mac.Write([]byte(signed))
+	if !hmac.Equal(mac.Sum(nil), signature) {
+		return errors.New("token signature is invalid")
+	}
+
+	return nil
+}
+
+func (a *jwtAuthenticator) validateClaims(claims *jwtClaims) error {
+	now := a.cfg.Clock.Now()
+
+	if claims.Subject == "" {
+		return errors.New("token has no subject")
+	}
+
+	if claims.ExpiresAt != nil {
+		exp, err := numericDate(*claims.ExpiresAt)
+		if err != nil {
+			return fmt.Errorf("error parsing token expiration: %w", err)
+		}
+		if !now.Before(exp.Add(a.cfg.Leeway)) {
+			return errors.New("token is expired")
+		}
+	}
+
+	if claims.NotBefore != nil {
+		nbf, err := numericDate(*claims.NotBefore)
+		if err != nil {
+			return fmt.Errorf("error parsing token start: %w", err)
+		}
+		if now.Add(a.cfg.Leeway).Before(nbf) {
+			return errors.New("token is not valid yet")
+		}
+	}
+
+	if a.cfg.Issuer != "" && claims.Issuer != a.cfg.Issuer {
+		return fmt.Errorf("token issuer %q is not accepted", claims.Issuer)
+	}
+
+	if a.cfg.Audience != "" && !slices.Contains(claims.Audience, a.cfg.Audience) {
+		return errors.New("token is not intended for this audience")
+	}
+
+	return nil
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+func numericDate(n json.Number) (time.Time, error) {
+	seconds, err := n.Float64()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(0, int64(seconds*float64(time.Second))), nil
+}
+
+// ParseRSAPublicKey parses a PEM encoded RSA public key, either PKIX or PKCS #1, verifying RS256 signed tokens.
+func ParseRSAPublicKey(data []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("error decoding public key: no PEM block found")
+	}
+
+	if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing public key: %w", err)
+	}
+
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("error parsing public key: not an RSA key")
+	}
+
+	return rsaKey, nil
+}
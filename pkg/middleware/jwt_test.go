@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/pkg/util"
+	"go.uber.org/mock/gomock"
+)
+
+func signHS256(t *testing.T, secret, claims string) string {
+	t.Helper()
+
+	signed := segment(`{"alg":"HS256","typ":"JWT"}`) + "." + segment(claims)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, claims string) string {
+	t.Helper()
+
+	signed := segment(`{"alg":"RS256","typ":"JWT"}`) + "." + segment(claims)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func segment(s string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(s))
+}
+
+func bearer(token string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/tasks", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	return req
+}
+
+func TestJWT(t *testing.T) {
+	now := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+	newClock := func(t *testing.T) util.Clock {
+		clock := util.NewMockClock(gomock.NewController(t))
+		clock.EXPECT().Now().Return(now).AnyTimes()
+		return clock
+	}
+
+	t.Run("should authenticate HMAC signed token", func(t *testing.T) {
+		authenticator := JWT(JWTConfig{Secret: []byte("secret"), Issuer: "task-master", Audience: "api", Clock: newClock(t)})
+		token := signHS256(t, "secret", `{"sub":"alice","iss":"task-master","aud":["web","api"],"exp":1746090060,"nbf":1746090000,"roles":["admin"]}`)
+
+		principal, err := authenticator.Authenticate(bearer(token))
+
+		require.NoError(t, err)
+		assert.Equal(t, Principal{Subject: "alice", Roles: []string{"admin"}, Method: "jwt"}, principal)
+	})
+
+	t.Run("should authenticate RSA signed token", func(t *testing.T) {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		require.NoError(t, err)
+		publicKey, err := ParseRSAPublicKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+		require.NoError(t, err)
+		authenticator := JWT(JWTConfig{PublicKey: publicKey, Clock: newClock(t)})
+
+		principal, err := authenticator.Authenticate(bearer(signRS256(t, key, `{"sub":"bob"}`)))
+
+		require.NoError(t, err)
+		assert.Equal(t, "bob", principal.Subject)
+	})
+
+	t.Run("should return no credentials without bearer token", func(t *testing.T) {
+		authenticator := JWT(JWTConfig{Secret: []byte("secret")})
+		req := httptest.NewRequest(http.MethodGet, "/tasks", nil)
+		req.Header.Set("Authorization", "Basic YWxpY2U6c2VjcmV0")
+
+		_, err := authenticator.Authenticate(req)
+
+		assert.ErrorIs(t, err, ErrNoCredentials)
+	})
+
+	t.Run("should reject invalid token", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			token string
+		}{
+			{"malformed", "not-a-token"},
+			{"signed with other secret", signHS256(t, "other", `{"sub":"alice"}`)},
+			{"unsigned", segment(`{"alg":"none"}`) + "." + segment(`{"sub":"alice"}`) + "."},
+			{"signed with RSA without public key", segment(`{"alg":"RS256"}`) + "." + segment(`{"sub":"alice"}`) + ".c2ln"},
+			{"expired", signHS256(t, "secret", `{"sub":"alice","exp":1746090000}`)},
+			{"not valid yet", signHS256(t, "secret", `{"sub":"alice","nbf":1746090060}`)},
+			{"from other issuer", signHS256(t, "secret", `{"sub":"alice","iss":"other"}`)},
+			{"for other audience", signHS256(t, "secret", `{"sub":"alice","iss":"task-master","aud":"web"}`)},
+			{"without subject", signHS256(t, "secret", `{"iss":"task-master","aud":"api"}`)},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				authenticator := JWT(JWTConfig{Secret: []byte("secret"), Issuer: "task-master", Audience: "api", Clock: newClock(t)})
+
+				_, err := authenticator.Authenticate(bearer(tt.token))
+
+				assert.ErrorIs(t, err, ErrInvalidCredentials)
+			})
+		}
+	})
+
+	t.Run("should tolerate clock skew within leeway", func(t *testing.T) {
+		authenticator := JWT(JWTConfig{Secret: []byte("secret"), Leeway: time.Minute, Clock: newClock(t)})
+		token := signHS256(t, "secret", `{"sub":"alice","exp":1746090000}`)
+
+		_, err := authenticator.Authenticate(bearer(token))
+
+		assert.NoError(t, err)
+	})
+}
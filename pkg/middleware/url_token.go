@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/utsabbera/task-master/pkg/util"
+)
+
+// URLTokenParam is the query parameter carrying a URL token.
+const URLTokenParam = "token"
+
+type urlTokenClaims struct {
+	Subject   string `json:"sub"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// URLTokens issues and authenticates the tokens sent in the URLTokenParam query parameter, for the clients
+// which are given a URL and cannot send headers, e.g. calendar clients subscribing to a feed.
+// The tokens are signed with HMAC-SHA256 and expire after their TTL, they are revoked early by changing the secret
+// or the scope. They only carry the subject of the principal, whose roles are resolved when the token is used.
+type URLTokens struct {
+	secret []byte
+	scope  string
+	ttl    time.Duration
+	clock  util.Clock
+}
+
+// NewURLTokens returns the URL tokens signed with the secret and expiring after ttl,
+// a token of a scope being rejected for any other scope.
+func NewURLTokens(secret []byte, scope string, ttl time.Duration, clock util.Clock) *URLTokens {
+	return &URLTokens{secret: secret, scope: scope, ttl: ttl, clock: clock}
+}
+
+// Issue returns the token authenticating the subject of the principal and the time it expires.
+func (t *URLTokens) Issue(principal Principal) (string, time.Time) {
+	expiresAt := t.clock.Now().Add(t.ttl).Truncate(time.Second)
+	claims, _ := json.Marshal(urlTokenClaims{Subject: principal.Subject, ExpiresAt: expiresAt.Unix()})
+	payload := base64.RawURLEncoding.EncodeToString(claims)
+
+	return payload + "." + base64.RawURLEncoding.EncodeToString(t.sign(payload)), expiresAt
+}
+
+// Authenticate returns the principal of the token of the request.
+func (t *URLTokens) Authenticate(r *http.Request) (Principal, error) {
+	token := strings.TrimSpace(r.URL.Query().Get(URLTokenParam))
+	if token == "" {
+		return Principal{}, ErrNoCredentials
+	}
+
+	payload, signature, found := strings.Cut(token, ".")
+	if !found {
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, t.sign(payload)) {
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	var claims urlTokenClaims
+	if err := decodeSegment(payload, &claims); err != nil || claims.Subject == "" {
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	if !t.clock.Now().Before(time.Unix(claims.ExpiresAt, 0)) {
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	return Principal{Subject: claims.Subject, Method: "url_token"}, nil
+}
+
+// Challenge returns the challenge of the URL tokens.
+func (t *URLTokens) Challenge() string {
+	return `Token param="` + URLTokenParam + `"`
+}
+
+func (t *URLTokens) sign(payload string) []byte {
+	mac := hmac.New(sha256.New, t.secret)
+	mac.Write([]byte(t.scope))
+	mac.Write([]byte{0})
+	mac.Write([]byte(payload))
+
+	return mac.Sum(nil)
+}
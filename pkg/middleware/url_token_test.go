@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utsabbera/task-master/pkg/util"
+	"go.uber.org/mock/gomock"
+)
+
+func TestURLTokens(t *testing.T) {
+	now := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+	clock := util.NewMockClock(gomock.NewController(t))
+	clock.EXPECT().Now().DoAndReturn(func() time.Time { return now }).AnyTimes()
+
+	tokens := NewURLTokens([]byte("secret"), "calendar", time.Hour, clock)
+	withToken := func(token string) *http.Request {
+		return httptest.NewRequest(http.MethodGet, "/tasks.ics?"+URLTokenParam+"="+url.QueryEscape(token), nil)
+	}
+
+	t.Run("should authenticate subject of issued token", func(t *testing.T) {
+		token, expiresAt := tokens.Issue(Principal{Subject: "alice", Roles: []string{"admin"}, Method: "api_key"})
+
+		principal, err := tokens.Authenticate(withToken(token))
+
+		require.NoError(t, err)
+		assert.Equal(t, Principal{Subject: "alice", Method: "url_token"}, principal)
+		assert.Equal(t, now.Add(time.Hour), expiresAt)
+	})
+
+	t.Run("should reject token once expired", func(t *testing.T) {
+		token, _ := tokens.Issue(Principal{Subject: "alice"})
+		issuedAt := now
+		defer func() { now = issuedAt }()
+
+		now = issuedAt.Add(time.Hour - time.Second)
+		_, err := tokens.Authenticate(withToken(token))
+		require.NoError(t, err)
+
+		now = issuedAt.Add(time.Hour)
+		_, err = tokens.Authenticate(withToken(token))
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+
+	t.Run("should return no credentials without token", func(t *testing.T) {
+		_, err := tokens.Authenticate(httptest.NewRequest(http.MethodGet, "/tasks.ics", nil))
+
+		assert.ErrorIs(t, err, ErrNoCredentials)
+	})
+
+	t.Run("should reject invalid token", func(t *testing.T) {
+		issued, _ := tokens.Issue(Principal{Subject: "alice"})
+		_, signature, _ := strings.Cut(issued, ".")
+		otherSecret, _ := NewURLTokens([]byte("other"), "calendar", time.Hour, clock).Issue(Principal{Subject: "alice"})
+		otherScope, _ := NewURLTokens([]byte("secret"), "export", time.Hour, clock).Issue(Principal{Subject: "alice"})
+		expired, _ := NewURLTokens([]byte("secret"), "calendar", -time.Hour, clock).Issue(Principal{Subject: "alice"})
+		withoutSubject, _ := tokens.Issue(Principal{})
+		tests := []struct {
+			name  string
+			token string
+		}{
+			{"malformed", "not-a-token"},
+			{"signed with other secret", otherSecret},
+			{"of other scope", otherScope},
+			{"expired", expired},
+			{"tampered with", segment(`{"sub":"bob","exp":1746093600}`) + "." + signature},
+			{"without subject", withoutSubject},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := tokens.Authenticate(withToken(tt.token))
+
+				assert.ErrorIs(t, err, ErrInvalidCredentials)
+			})
+		}
+	})
+}
@@ -0,0 +1,283 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxDepth is the maximum number of nested parentheses and nots of an expression.
+const MaxDepth = 32
+
+// SyntaxError is returned when an expression cannot be parsed.
+type SyntaxError struct {
+	// Pos is the position of the error in the expression, starting at 1.
+	Pos int
+	// Message describes the error.
+	Message string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Message, e.Pos)
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOperator
+	tokenOpen
+	tokenClose
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) describe() string {
+	if t.kind == tokenEOF {
+		return "end of expression"
+	}
+
+	return fmt.Sprintf("%q", t.text)
+}
+
+func (t token) keyword(name string) bool {
+	return t.kind == tokenWord && strings.EqualFold(t.text, name)
+}
+
+// isDelimiter reports whether the rune ends a bare word.
+func isDelimiter(r rune) bool {
+	return unicode.IsSpace(r) || strings.ContainsRune(`()=!<>:~"`, r)
+}
+
+// lex splits an expression into tokens, positions count runes from 1.
+func lex(source string) ([]token, error) {
+	var tokens []token
+	pos := 1
+	for i := 0; i < len(source); {
+		r, size := utf8.DecodeRuneInString(source[i:])
+
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+			pos++
+		case r == '(' || r == ')':
+			kind := tokenOpen
+			if r == ')' {
+				kind = tokenClose
+			}
+			tokens = append(tokens, token{kind: kind, text: string(r), pos: pos})
+			i += size
+			pos++
+		case r == '"':
+			text, length, ok := lexString(source[i:])
+			if !ok {
+				return nil, &SyntaxError{Pos: pos, Message: "unterminated string"}
+			}
+			tokens = append(tokens, token{kind: tokenString, text: text, pos: pos})
+			i += length
+			pos += utf8.RuneCountInString(source[i-length : i])
+		case strings.ContainsRune("=!<>:~", r):
+			op, ok := lexOperator(source[i:])
+			if !ok {
+				return nil, &SyntaxError{Pos: pos, Message: fmt.Sprintf("unexpected %q", r)}
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: string(op), pos: pos})
+			i += len(op)
+			pos += len(op)
+		default:
+			end := i
+			for end < len(source) {
+				r, size := utf8.DecodeRuneInString(source[end:])
+				if isDelimiter(r) {
+					break
+				}
+				end += size
+			}
+			word := source[i:end]
+			tokens = append(tokens, token{kind: tokenWord, text: word, pos: pos})
+			pos += utf8.RuneCountInString(word)
+			i = end
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: pos}), nil
+}
+
+// lexString returns the content of the double quoted string the source starts with and its length in bytes,
+// a backslash escapes the following character
+func lexString(source string) (string, int, bool) {
+	var b strings.Builder
+	for i := 1; i < len(source); i++ {
+		switch source[i] {
+		case '"':
+			return b.String(), i + 1, true
+		case '\\':
+			if i+1 == len(source) {
+				return "", 0, false
+			}
+			i++
+		}
+		b.WriteByte(source[i])
+	}
+
+	return "", 0, false
+}
+
+func lexOperator(source string) (Operator, bool) {
+	for _, op := range operators {
+		if strings.HasPrefix(source, string(op)) {
+			return op, true
+		}
+	}
+
+	return "", false
+}
+
+type parser struct {
+	tokens []token
+	next   int
+	depth  int
+}
+
+// Parse parses an expression, and binds tighter than or and not tighter than and.
+// Returns a *SyntaxError if the expression is empty or malformed.
+func Parse(source string) (Expr, error) {
+	tokens, err := lex(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	if p.peek().kind == tokenEOF {
+		return nil, &SyntaxError{Pos: 1, Message: "empty expression"}
+	}
+
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, p.unexpected(t, "and, or or end of expression")
+	}
+
+	return e, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.next]
+}
+
+func (p *parser) advance() token {
+	t := p.tokens[p.next]
+	if t.kind != tokenEOF {
+		p.next++
+	}
+
+	return t
+}
+
+func (p *parser) unexpected(t token, expected string) error {
+	return &SyntaxError{Pos: t.pos, Message: fmt.Sprintf("unexpected %s, expected %s", t.describe(), expected)}
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().keyword("or") {
+		p.advance()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &Or{Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().keyword("and") {
+		p.advance()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &And{Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseNot() (Expr, error) {
+	t := p.peek()
+	if !t.keyword("not") && t.kind != tokenOpen {
+		return p.parseComparison()
+	}
+
+	if p.depth++; p.depth > MaxDepth {
+		return nil, &SyntaxError{Pos: t.pos, Message: fmt.Sprintf("expression nested more than %d levels", MaxDepth)}
+	}
+	defer func() { p.depth-- }()
+
+	p.advance()
+	if t.kind == tokenOpen {
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if closing := p.advance(); closing.kind != tokenClose {
+			return nil, p.unexpected(closing, "closing parenthesis")
+		}
+
+		return e, nil
+	}
+
+	operand, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Not{Operand: operand}, nil
+}
+
+func (p *parser) parseComparison() (Expr, error) {
+	field := p.advance()
+	if field.kind != tokenWord || field.keyword("and") || field.keyword("or") {
+		return nil, p.unexpected(field, "field name")
+	}
+
+	op := p.advance()
+	if op.kind != tokenOperator {
+		return nil, p.unexpected(op, "comparison operator")
+	}
+
+	value := p.advance()
+	if value.kind != tokenWord && value.kind != tokenString {
+		return nil, p.unexpected(value, "value")
+	}
+
+	return &Comparison{
+		Field:    field.text,
+		Operator: Operator(op.text),
+		Value:    value.text,
+		Quoted:   value.kind == tokenString,
+		Pos:      field.pos,
+	}, nil
+}
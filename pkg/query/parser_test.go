@@ -0,0 +1,131 @@
+package query
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("should parse comparisons combined with and, or, not and parentheses", func(t *testing.T) {
+		e, err := Parse(`status != COMPLETED and (priority = HIGH or due < now+3d) and tag:backend`)
+
+		require.NoError(t, err)
+		assert.Equal(t, "((status != COMPLETED and (priority = HIGH or due < now+3d)) and tag : backend)", e.String())
+	})
+
+	t.Run("should bind and tighter than or and not tighter than and", func(t *testing.T) {
+		e, err := Parse(`a = 1 or not b = 2 and c = 3`)
+
+		require.NoError(t, err)
+		assert.Equal(t, "(a = 1 or (not b = 2 and c = 3))", e.String())
+	})
+
+	t.Run("should accept keywords in any case", func(t *testing.T) {
+		e, err := Parse(`a = 1 AND NOT b = 2 Or c = 3`)
+
+		require.NoError(t, err)
+		assert.Equal(t, "((a = 1 and not b = 2) or c = 3)", e.String())
+	})
+
+	t.Run("should parse every operator", func(t *testing.T) {
+		for _, op := range []Operator{Equal, NotEqual, Less, LessOrEqual, Greater, GreaterOrEqual, Has, Contains} {
+			e, err := Parse("field" + string(op) + "value")
+
+			require.NoError(t, err)
+			assert.Equal(t, &Comparison{Field: "field", Operator: op, Value: "value", Pos: 1}, e)
+		}
+	})
+
+	t.Run("should parse quoted values with escapes", func(t *testing.T) {
+		e, err := Parse(`title ~ "say \"hi\" (now)" and due < "2025-01-02T10:00:00Z"`)
+
+		require.NoError(t, err)
+		comparisons := Comparisons(e)
+		require.Len(t, comparisons, 2)
+		assert.Equal(t, &Comparison{Field: "title", Operator: Contains, Value: `say "hi" (now)`, Quoted: true, Pos: 1}, comparisons[0])
+		assert.Equal(t, &Comparison{Field: "due", Operator: Less, Value: "2025-01-02T10:00:00Z", Quoted: true, Pos: 32}, comparisons[1])
+	})
+
+	t.Run("should accept keywords as values", func(t *testing.T) {
+		e, err := Parse(`tag:and`)
+
+		require.NoError(t, err)
+		assert.Equal(t, &Comparison{Field: "tag", Operator: Has, Value: "and", Pos: 1}, e)
+	})
+
+	t.Run("should return syntax errors with their position", func(t *testing.T) {
+		tests := []struct {
+			source  string
+			pos     int
+			message string
+		}{
+			{"", 1, "empty expression"},
+			{"   ", 1, "empty expression"},
+			{"status", 7, "unexpected end of expression, expected comparison operator"},
+			{"status =", 9, "unexpected end of expression, expected value"},
+			{"status = = DONE", 10, `unexpected "=", expected value`},
+			{"status = DONE priority = HIGH", 15, `unexpected "priority", expected and, or or end of expression`},
+			{"(status = DONE", 15, "unexpected end of expression, expected closing parenthesis"},
+			{"status = DONE)", 14, `unexpected ")", expected and, or or end of expression`},
+			{"and = 1", 1, `unexpected "and", expected field name`},
+			{"tag ! x", 5, `unexpected '!'`},
+			{`title ~ "open`, 9, "unterminated string"},
+			{"é = 1 or", 9, "unexpected end of expression, expected field name"},
+		}
+
+		for _, tt := range tests {
+			_, err := Parse(tt.source)
+
+			var syntaxErr *SyntaxError
+			require.True(t, errors.As(err, &syntaxErr), tt.source)
+			assert.Equal(t, tt.pos, syntaxErr.Pos, tt.source)
+			assert.Equal(t, tt.message, syntaxErr.Message, tt.source)
+		}
+	})
+
+	t.Run("should reject expressions nested too deeply", func(t *testing.T) {
+		_, err := Parse(strings.Repeat("(", MaxDepth+1) + "a = 1" + strings.Repeat(")", MaxDepth+1))
+
+		assert.ErrorContains(t, err, "expression nested more than 32 levels")
+	})
+}
+
+func TestEval(t *testing.T) {
+	t.Run("should evaluate the comparisons with the given function", func(t *testing.T) {
+		e, err := Parse(`a = 1 and (b = 1 or not c = 1)`)
+		require.NoError(t, err)
+
+		values := map[string]string{"a": "1", "b": "2", "c": "2"}
+		match := func(c *Comparison) bool { return values[c.Field] == c.Value }
+
+		assert.True(t, Eval(e, match))
+
+		values["c"] = "1"
+		assert.False(t, Eval(e, match))
+	})
+
+	t.Run("should not evaluate operands which don't decide the result", func(t *testing.T) {
+		e, err := Parse(`a = 1 or (b = 1 and c = 1)`)
+		require.NoError(t, err)
+
+		var evaluated []string
+		Eval(e, func(c *Comparison) bool {
+			evaluated = append(evaluated, c.Field)
+			return c.Field == "a"
+		})
+
+		assert.Equal(t, []string{"a"}, evaluated)
+	})
+}
+
+func TestComparison_String(t *testing.T) {
+	t.Run("should quote values which are not bare words", func(t *testing.T) {
+		assert.Equal(t, `title ~ "weekly report"`, (&Comparison{Field: "title", Operator: Contains, Value: "weekly report"}).String())
+		assert.Equal(t, `priority = "null"`, (&Comparison{Field: "priority", Operator: Equal, Value: "null", Quoted: true}).String())
+		assert.Equal(t, `priority = null`, (&Comparison{Field: "priority", Operator: Equal, Value: "null"}).String())
+	})
+}
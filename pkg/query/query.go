@@ -0,0 +1,136 @@
+// Package query provides a small boolean expression language for filters, e.g.
+//
+//	status != COMPLETED and (priority = HIGH or due < now+3d) and tag:backend
+//
+// An expression compares fields with values using =, !=, <, <=, >, >=, : and ~, and combines the
+// comparisons with and, or, not and parentheses. Values are bare words or double quoted strings.
+// The meaning of the fields and of their values is left to the caller, which evaluates the comparisons.
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Operator is the operator of a comparison.
+type Operator string
+
+const (
+	// Equal matches a field equal to the value.
+	Equal Operator = "="
+	// NotEqual matches a field different from the value.
+	NotEqual Operator = "!="
+	// Less matches a field lower than the value.
+	Less Operator = "<"
+	// LessOrEqual matches a field lower than or equal to the value.
+	LessOrEqual Operator = "<="
+	// Greater matches a field greater than the value.
+	Greater Operator = ">"
+	// GreaterOrEqual matches a field greater than or equal to the value.
+	GreaterOrEqual Operator = ">="
+	// Has matches a field having the value, e.g. a list containing it.
+	Has Operator = ":"
+	// Contains matches a text field containing the value.
+	Contains Operator = "~"
+)
+
+var operators = []Operator{NotEqual, LessOrEqual, GreaterOrEqual, Equal, Less, Greater, Has, Contains}
+
+// Expr is a node of a parsed expression, either an *And, an *Or, a *Not or a *Comparison.
+type Expr interface {
+	// String returns the expression with every and and or in parentheses.
+	String() string
+
+	node()
+}
+
+// And matches when both of its operands match.
+type And struct {
+	Left  Expr
+	Right Expr
+}
+
+// Or matches when any of its operands matches.
+type Or struct {
+	Left  Expr
+	Right Expr
+}
+
+// Not matches when its operand doesn't match.
+type Not struct {
+	Operand Expr
+}
+
+// Comparison compares a field with a value.
+type Comparison struct {
+	// Field is the name of the compared field.
+	Field string
+	// Operator is the comparison operator.
+	Operator Operator
+	// Value is the value the field is compared with, without its quotes.
+	Value string
+	// Quoted reports whether the value was double quoted, so that null is told apart from "null".
+	Quoted bool
+	// Pos is the position of the field in the expression, starting at 1.
+	Pos int
+}
+
+func (*And) node()        {}
+func (*Or) node()         {}
+func (*Not) node()        {}
+func (*Comparison) node() {}
+
+func (e *And) String() string {
+	return "(" + e.Left.String() + " and " + e.Right.String() + ")"
+}
+
+func (e *Or) String() string {
+	return "(" + e.Left.String() + " or " + e.Right.String() + ")"
+}
+
+func (e *Not) String() string {
+	return "not " + e.Operand.String()
+}
+
+func (e *Comparison) String() string {
+	value := e.Value
+	if e.Quoted || value == "" || strings.ContainsFunc(value, isDelimiter) {
+		value = strconv.Quote(value)
+	}
+
+	return e.Field + " " + string(e.Operator) + " " + value
+}
+
+// Eval reports whether the expression matches, using match to evaluate its comparisons.
+// The operands of and and or are evaluated from left to right, only when they decide the result.
+func Eval(e Expr, match func(c *Comparison) bool) bool {
+	switch e := e.(type) {
+	case *And:
+		return Eval(e.Left, match) && Eval(e.Right, match)
+	case *Or:
+		return Eval(e.Left, match) || Eval(e.Right, match)
+	case *Not:
+		return !Eval(e.Operand, match)
+	case *Comparison:
+		return match(e)
+	default:
+		panic(fmt.Sprintf("query: unexpected expression %T", e))
+	}
+}
+
+// Comparisons returns the comparisons of the expression from left to right.
+func Comparisons(e Expr) []*Comparison {
+	switch e := e.(type) {
+	case *And:
+		return append(Comparisons(e.Left), Comparisons(e.Right)...)
+	case *Or:
+		return append(Comparisons(e.Left), Comparisons(e.Right)...)
+	case *Not:
+		return Comparisons(e.Operand)
+	case *Comparison:
+		return []*Comparison{e}
+	default:
+		panic(fmt.Sprintf("query: unexpected expression %T", e))
+	}
+}
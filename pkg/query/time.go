@@ -0,0 +1,80 @@
+package query
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+var timeUnits = map[byte]time.Duration{
+	'm': time.Minute,
+	'h': time.Hour,
+	'd': 24 * time.Hour,
+	'w': 7 * 24 * time.Hour,
+}
+
+// ParseTime parses a time value relative to now. The value is either an RFC 3339 time, a date such as 2025-01-31
+// meaning its midnight in the location of now, or now or today followed by an optional offset made of a sign,
+// a number and a unit among m (minutes), h (hours), d (days) and w (weeks), e.g. now+3d or today-1w.
+func ParseTime(value string, now time.Time) (time.Time, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	lower := strings.ToLower(value)
+	for name, base := range map[string]time.Time{"now": now, "today": today} {
+		offset, ok := strings.CutPrefix(lower, name)
+		if !ok {
+			continue
+		}
+
+		if offset == "" {
+			return base, nil
+		}
+
+		duration, err := parseOffset(offset)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid time %q: %w", value, err)
+		}
+
+		return base.Add(duration), nil
+	}
+
+	if t, err := time.ParseInLocation(dateLayout, value, now.Location()); err == nil {
+		return t, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time %q, expected now, today, an offset such as now+3d, a date or an RFC 3339 time", value)
+}
+
+func parseOffset(offset string) (time.Duration, error) {
+	if len(offset) < 3 || (offset[0] != '+' && offset[0] != '-') {
+		return 0, fmt.Errorf("offset %q must be a sign, a number and a unit", offset)
+	}
+
+	unit, ok := timeUnits[offset[len(offset)-1]]
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %q, expected m, h, d or w", offset[len(offset)-1:])
+	}
+
+	n, err := strconv.Atoi(offset[1 : len(offset)-1])
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("offset %q must be a sign, a number and a unit", offset)
+	}
+
+	if int64(n) > math.MaxInt64/int64(unit) {
+		return 0, fmt.Errorf("offset %q is out of range", offset)
+	}
+
+	if offset[0] == '-' {
+		n = -n
+	}
+
+	return time.Duration(n) * unit, nil
+}
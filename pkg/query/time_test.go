@@ -0,0 +1,53 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC)
+
+	t.Run("should parse absolute and relative times", func(t *testing.T) {
+		tests := []struct {
+			value    string
+			expected time.Time
+		}{
+			{"now", now},
+			{"NOW", now},
+			{"today", time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)},
+			{"now+3d", now.Add(72 * time.Hour)},
+			{"now-90m", now.Add(-90 * time.Minute)},
+			{"now+12h", now.Add(12 * time.Hour)},
+			{"today+1w", time.Date(2025, 3, 21, 0, 0, 0, 0, time.UTC)},
+			{"2025-01-31", time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)},
+			{"2025-01-31T10:00:00+02:00", time.Date(2025, 1, 31, 8, 0, 0, 0, time.UTC)},
+		}
+
+		for _, tt := range tests {
+			actual, err := ParseTime(tt.value, now)
+
+			require.NoError(t, err, tt.value)
+			assert.True(t, tt.expected.Equal(actual), "%s: expected %s, got %s", tt.value, tt.expected, actual)
+		}
+	})
+
+	t.Run("should return an error for invalid times", func(t *testing.T) {
+		for _, value := range []string{"", "tomorrow", "now+", "now+3", "now+3y", "now3d", "now+-3d", "2025-13-01", "31/01/2025"} {
+			_, err := ParseTime(value, now)
+
+			assert.Error(t, err, value)
+		}
+	})
+
+	t.Run("should return an error for offsets out of range", func(t *testing.T) {
+		for _, value := range []string{"now+9999999999d", "now-9999999999d", "now+99999999999999w"} {
+			_, err := ParseTime(value, now)
+
+			assert.ErrorContains(t, err, "out of range", value)
+		}
+	})
+}